@@ -18,7 +18,7 @@
 go get github.com/golang/protobuf/protoc-gen-go
 
 protoc \
---go_out=Mgoogle/protobuf/any.proto=github.com/golang/protobuf/ptypes/any:. \
+--go_out=Mgoogle/protobuf/any.proto=github.com/golang/protobuf/ptypes/any,Mgoogle/protobuf/wrappers.proto=github.com/golang/protobuf/ptypes/wrappers:. \
 OpenAPIv2/OpenAPIv2.proto 
 
 protoc \
@@ -26,6 +26,6 @@ protoc \
 plugins/plugin.proto 
 
 protoc \
---go_out=Mgoogle/protobuf/any.proto=github.com/golang/protobuf/ptypes/any:. \
+--go_out=Mgoogle/protobuf/any.proto=github.com/golang/protobuf/ptypes/any,Mgoogle/protobuf/wrappers.proto=github.com/golang/protobuf/ptypes/wrappers:. \
 OpenAPIv3/OpenAPIv3.proto 
 
@@ -76,6 +76,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/any"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/wrappers"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -320,9 +321,9 @@ type BodyParameter struct {
 	// Determines the location of the parameter.
 	In string `protobuf:"bytes,3,opt,name=in" json:"in,omitempty"`
 	// Determines whether or not this parameter is required or optional.
-	Required        bool        `protobuf:"varint,4,opt,name=required" json:"required,omitempty"`
-	Schema          *Schema     `protobuf:"bytes,5,opt,name=schema" json:"schema,omitempty"`
-	VendorExtension []*NamedAny `protobuf:"bytes,6,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	Required        *google_protobuf1.BoolValue `protobuf:"bytes,4,opt,name=required" json:"required,omitempty"`
+	Schema          *Schema                     `protobuf:"bytes,5,opt,name=schema" json:"schema,omitempty"`
+	VendorExtension []*NamedAny                 `protobuf:"bytes,6,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *BodyParameter) Reset()                    { *m = BodyParameter{} }
@@ -351,11 +352,11 @@ func (m *BodyParameter) GetIn() string {
 	return ""
 }
 
-func (m *BodyParameter) GetRequired() bool {
+func (m *BodyParameter) GetRequired() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Required
 	}
-	return false
+	return nil
 }
 
 func (m *BodyParameter) GetSchema() *Schema {
@@ -642,16 +643,16 @@ func (m *ExternalDocs) GetVendorExtension() []*NamedAny {
 
 // A deterministic version of a JSON Schema object.
 type FileSchema struct {
-	Format          string        `protobuf:"bytes,1,opt,name=format" json:"format,omitempty"`
-	Title           string        `protobuf:"bytes,2,opt,name=title" json:"title,omitempty"`
-	Description     string        `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	Default         *Any          `protobuf:"bytes,4,opt,name=default" json:"default,omitempty"`
-	Required        []string      `protobuf:"bytes,5,rep,name=required" json:"required,omitempty"`
-	Type            string        `protobuf:"bytes,6,opt,name=type" json:"type,omitempty"`
-	ReadOnly        bool          `protobuf:"varint,7,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
-	ExternalDocs    *ExternalDocs `protobuf:"bytes,8,opt,name=external_docs,json=externalDocs" json:"external_docs,omitempty"`
-	Example         *Any          `protobuf:"bytes,9,opt,name=example" json:"example,omitempty"`
-	VendorExtension []*NamedAny   `protobuf:"bytes,10,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	Format          string                      `protobuf:"bytes,1,opt,name=format" json:"format,omitempty"`
+	Title           string                      `protobuf:"bytes,2,opt,name=title" json:"title,omitempty"`
+	Description     string                      `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	Default         *Any                        `protobuf:"bytes,4,opt,name=default" json:"default,omitempty"`
+	Required        []string                    `protobuf:"bytes,5,rep,name=required" json:"required,omitempty"`
+	Type            string                      `protobuf:"bytes,6,opt,name=type" json:"type,omitempty"`
+	ReadOnly        *google_protobuf1.BoolValue `protobuf:"bytes,7,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
+	ExternalDocs    *ExternalDocs               `protobuf:"bytes,8,opt,name=external_docs,json=externalDocs" json:"external_docs,omitempty"`
+	Example         *Any                        `protobuf:"bytes,9,opt,name=example" json:"example,omitempty"`
+	VendorExtension []*NamedAny                 `protobuf:"bytes,10,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *FileSchema) Reset()                    { *m = FileSchema{} }
@@ -701,11 +702,11 @@ func (m *FileSchema) GetType() string {
 	return ""
 }
 
-func (m *FileSchema) GetReadOnly() bool {
+func (m *FileSchema) GetReadOnly() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ReadOnly
 	}
-	return false
+	return nil
 }
 
 func (m *FileSchema) GetExternalDocs() *ExternalDocs {
@@ -731,7 +732,7 @@ func (m *FileSchema) GetVendorExtension() []*NamedAny {
 
 type FormDataParameterSubSchema struct {
 	// Determines whether or not this parameter is required or optional.
-	Required bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Required *google_protobuf1.BoolValue `protobuf:"bytes,1,opt,name=required" json:"required,omitempty"`
 	// Determines the location of the parameter.
 	In string `protobuf:"bytes,2,opt,name=in" json:"in,omitempty"`
 	// A brief description of the parameter. This could contain examples of use.  GitHub Flavored Markdown is allowed.
@@ -739,25 +740,25 @@ type FormDataParameterSubSchema struct {
 	// The name of the parameter.
 	Name string `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
 	// allows sending a parameter by name only or with an empty value.
-	AllowEmptyValue  bool             `protobuf:"varint,5,opt,name=allow_empty_value,json=allowEmptyValue" json:"allow_empty_value,omitempty"`
-	Type             string           `protobuf:"bytes,6,opt,name=type" json:"type,omitempty"`
-	Format           string           `protobuf:"bytes,7,opt,name=format" json:"format,omitempty"`
-	Items            *PrimitivesItems `protobuf:"bytes,8,opt,name=items" json:"items,omitempty"`
-	CollectionFormat string           `protobuf:"bytes,9,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
-	Default          *Any             `protobuf:"bytes,10,opt,name=default" json:"default,omitempty"`
-	Maximum          float64          `protobuf:"fixed64,11,opt,name=maximum" json:"maximum,omitempty"`
-	ExclusiveMaximum bool             `protobuf:"varint,12,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
-	Minimum          float64          `protobuf:"fixed64,13,opt,name=minimum" json:"minimum,omitempty"`
-	ExclusiveMinimum bool             `protobuf:"varint,14,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
-	MaxLength        int64            `protobuf:"varint,15,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
-	MinLength        int64            `protobuf:"varint,16,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
-	Pattern          string           `protobuf:"bytes,17,opt,name=pattern" json:"pattern,omitempty"`
-	MaxItems         int64            `protobuf:"varint,18,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
-	MinItems         int64            `protobuf:"varint,19,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
-	UniqueItems      bool             `protobuf:"varint,20,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
-	Enum             []*Any           `protobuf:"bytes,21,rep,name=enum" json:"enum,omitempty"`
-	MultipleOf       float64          `protobuf:"fixed64,22,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
-	VendorExtension  []*NamedAny      `protobuf:"bytes,23,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	AllowEmptyValue  *google_protobuf1.BoolValue   `protobuf:"bytes,5,opt,name=allow_empty_value,json=allowEmptyValue" json:"allow_empty_value,omitempty"`
+	Type             string                        `protobuf:"bytes,6,opt,name=type" json:"type,omitempty"`
+	Format           string                        `protobuf:"bytes,7,opt,name=format" json:"format,omitempty"`
+	Items            *PrimitivesItems              `protobuf:"bytes,8,opt,name=items" json:"items,omitempty"`
+	CollectionFormat string                        `protobuf:"bytes,9,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
+	Default          *Any                          `protobuf:"bytes,10,opt,name=default" json:"default,omitempty"`
+	Maximum          *google_protobuf1.DoubleValue `protobuf:"bytes,11,opt,name=maximum" json:"maximum,omitempty"`
+	ExclusiveMaximum *google_protobuf1.BoolValue   `protobuf:"bytes,12,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
+	Minimum          *google_protobuf1.DoubleValue `protobuf:"bytes,13,opt,name=minimum" json:"minimum,omitempty"`
+	ExclusiveMinimum *google_protobuf1.BoolValue   `protobuf:"bytes,14,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
+	MaxLength        *google_protobuf1.Int64Value  `protobuf:"bytes,15,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	MinLength        *google_protobuf1.Int64Value  `protobuf:"bytes,16,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	Pattern          string                        `protobuf:"bytes,17,opt,name=pattern" json:"pattern,omitempty"`
+	MaxItems         *google_protobuf1.Int64Value  `protobuf:"bytes,18,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	MinItems         *google_protobuf1.Int64Value  `protobuf:"bytes,19,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	UniqueItems      *google_protobuf1.BoolValue   `protobuf:"bytes,20,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	Enum             []*Any                        `protobuf:"bytes,21,rep,name=enum" json:"enum,omitempty"`
+	MultipleOf       *google_protobuf1.DoubleValue `protobuf:"bytes,22,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
+	VendorExtension  []*NamedAny                   `protobuf:"bytes,23,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *FormDataParameterSubSchema) Reset()                    { *m = FormDataParameterSubSchema{} }
@@ -765,11 +766,11 @@ func (m *FormDataParameterSubSchema) String() string            { return proto.C
 func (*FormDataParameterSubSchema) ProtoMessage()               {}
 func (*FormDataParameterSubSchema) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
-func (m *FormDataParameterSubSchema) GetRequired() bool {
+func (m *FormDataParameterSubSchema) GetRequired() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Required
 	}
-	return false
+	return nil
 }
 
 func (m *FormDataParameterSubSchema) GetIn() string {
@@ -793,11 +794,11 @@ func (m *FormDataParameterSubSchema) GetName() string {
 	return ""
 }
 
-func (m *FormDataParameterSubSchema) GetAllowEmptyValue() bool {
+func (m *FormDataParameterSubSchema) GetAllowEmptyValue() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.AllowEmptyValue
 	}
-	return false
+	return nil
 }
 
 func (m *FormDataParameterSubSchema) GetType() string {
@@ -835,46 +836,46 @@ func (m *FormDataParameterSubSchema) GetDefault() *Any {
 	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetMaximum() float64 {
+func (m *FormDataParameterSubSchema) GetMaximum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Maximum
 	}
-	return 0
+	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetExclusiveMaximum() bool {
+func (m *FormDataParameterSubSchema) GetExclusiveMaximum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMaximum
 	}
-	return false
+	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetMinimum() float64 {
+func (m *FormDataParameterSubSchema) GetMinimum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Minimum
 	}
-	return 0
+	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetExclusiveMinimum() bool {
+func (m *FormDataParameterSubSchema) GetExclusiveMinimum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMinimum
 	}
-	return false
+	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetMaxLength() int64 {
+func (m *FormDataParameterSubSchema) GetMaxLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxLength
 	}
-	return 0
+	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetMinLength() int64 {
+func (m *FormDataParameterSubSchema) GetMinLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinLength
 	}
-	return 0
+	return nil
 }
 
 func (m *FormDataParameterSubSchema) GetPattern() string {
@@ -884,25 +885,25 @@ func (m *FormDataParameterSubSchema) GetPattern() string {
 	return ""
 }
 
-func (m *FormDataParameterSubSchema) GetMaxItems() int64 {
+func (m *FormDataParameterSubSchema) GetMaxItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxItems
 	}
-	return 0
+	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetMinItems() int64 {
+func (m *FormDataParameterSubSchema) GetMinItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinItems
 	}
-	return 0
+	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetUniqueItems() bool {
+func (m *FormDataParameterSubSchema) GetUniqueItems() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.UniqueItems
 	}
-	return false
+	return nil
 }
 
 func (m *FormDataParameterSubSchema) GetEnum() []*Any {
@@ -912,11 +913,11 @@ func (m *FormDataParameterSubSchema) GetEnum() []*Any {
 	return nil
 }
 
-func (m *FormDataParameterSubSchema) GetMultipleOf() float64 {
+func (m *FormDataParameterSubSchema) GetMultipleOf() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.MultipleOf
 	}
-	return 0
+	return nil
 }
 
 func (m *FormDataParameterSubSchema) GetVendorExtension() []*NamedAny {
@@ -927,25 +928,25 @@ func (m *FormDataParameterSubSchema) GetVendorExtension() []*NamedAny {
 }
 
 type Header struct {
-	Type             string           `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
-	Format           string           `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
-	Items            *PrimitivesItems `protobuf:"bytes,3,opt,name=items" json:"items,omitempty"`
-	CollectionFormat string           `protobuf:"bytes,4,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
-	Default          *Any             `protobuf:"bytes,5,opt,name=default" json:"default,omitempty"`
-	Maximum          float64          `protobuf:"fixed64,6,opt,name=maximum" json:"maximum,omitempty"`
-	ExclusiveMaximum bool             `protobuf:"varint,7,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
-	Minimum          float64          `protobuf:"fixed64,8,opt,name=minimum" json:"minimum,omitempty"`
-	ExclusiveMinimum bool             `protobuf:"varint,9,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
-	MaxLength        int64            `protobuf:"varint,10,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
-	MinLength        int64            `protobuf:"varint,11,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
-	Pattern          string           `protobuf:"bytes,12,opt,name=pattern" json:"pattern,omitempty"`
-	MaxItems         int64            `protobuf:"varint,13,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
-	MinItems         int64            `protobuf:"varint,14,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
-	UniqueItems      bool             `protobuf:"varint,15,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
-	Enum             []*Any           `protobuf:"bytes,16,rep,name=enum" json:"enum,omitempty"`
-	MultipleOf       float64          `protobuf:"fixed64,17,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
-	Description      string           `protobuf:"bytes,18,opt,name=description" json:"description,omitempty"`
-	VendorExtension  []*NamedAny      `protobuf:"bytes,19,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	Type             string                        `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Format           string                        `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+	Items            *PrimitivesItems              `protobuf:"bytes,3,opt,name=items" json:"items,omitempty"`
+	CollectionFormat string                        `protobuf:"bytes,4,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
+	Default          *Any                          `protobuf:"bytes,5,opt,name=default" json:"default,omitempty"`
+	Maximum          *google_protobuf1.DoubleValue `protobuf:"bytes,6,opt,name=maximum" json:"maximum,omitempty"`
+	ExclusiveMaximum *google_protobuf1.BoolValue   `protobuf:"bytes,7,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
+	Minimum          *google_protobuf1.DoubleValue `protobuf:"bytes,8,opt,name=minimum" json:"minimum,omitempty"`
+	ExclusiveMinimum *google_protobuf1.BoolValue   `protobuf:"bytes,9,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
+	MaxLength        *google_protobuf1.Int64Value  `protobuf:"bytes,10,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	MinLength        *google_protobuf1.Int64Value  `protobuf:"bytes,11,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	Pattern          string                        `protobuf:"bytes,12,opt,name=pattern" json:"pattern,omitempty"`
+	MaxItems         *google_protobuf1.Int64Value  `protobuf:"bytes,13,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	MinItems         *google_protobuf1.Int64Value  `protobuf:"bytes,14,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	UniqueItems      *google_protobuf1.BoolValue   `protobuf:"bytes,15,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	Enum             []*Any                        `protobuf:"bytes,16,rep,name=enum" json:"enum,omitempty"`
+	MultipleOf       *google_protobuf1.DoubleValue `protobuf:"bytes,17,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
+	Description      string                        `protobuf:"bytes,18,opt,name=description" json:"description,omitempty"`
+	VendorExtension  []*NamedAny                   `protobuf:"bytes,19,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *Header) Reset()                    { *m = Header{} }
@@ -988,46 +989,46 @@ func (m *Header) GetDefault() *Any {
 	return nil
 }
 
-func (m *Header) GetMaximum() float64 {
+func (m *Header) GetMaximum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Maximum
 	}
-	return 0
+	return nil
 }
 
-func (m *Header) GetExclusiveMaximum() bool {
+func (m *Header) GetExclusiveMaximum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMaximum
 	}
-	return false
+	return nil
 }
 
-func (m *Header) GetMinimum() float64 {
+func (m *Header) GetMinimum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Minimum
 	}
-	return 0
+	return nil
 }
 
-func (m *Header) GetExclusiveMinimum() bool {
+func (m *Header) GetExclusiveMinimum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMinimum
 	}
-	return false
+	return nil
 }
 
-func (m *Header) GetMaxLength() int64 {
+func (m *Header) GetMaxLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxLength
 	}
-	return 0
+	return nil
 }
 
-func (m *Header) GetMinLength() int64 {
+func (m *Header) GetMinLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinLength
 	}
-	return 0
+	return nil
 }
 
 func (m *Header) GetPattern() string {
@@ -1037,25 +1038,25 @@ func (m *Header) GetPattern() string {
 	return ""
 }
 
-func (m *Header) GetMaxItems() int64 {
+func (m *Header) GetMaxItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxItems
 	}
-	return 0
+	return nil
 }
 
-func (m *Header) GetMinItems() int64 {
+func (m *Header) GetMinItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinItems
 	}
-	return 0
+	return nil
 }
 
-func (m *Header) GetUniqueItems() bool {
+func (m *Header) GetUniqueItems() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.UniqueItems
 	}
-	return false
+	return nil
 }
 
 func (m *Header) GetEnum() []*Any {
@@ -1065,11 +1066,11 @@ func (m *Header) GetEnum() []*Any {
 	return nil
 }
 
-func (m *Header) GetMultipleOf() float64 {
+func (m *Header) GetMultipleOf() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.MultipleOf
 	}
-	return 0
+	return nil
 }
 
 func (m *Header) GetDescription() string {
@@ -1088,31 +1089,31 @@ func (m *Header) GetVendorExtension() []*NamedAny {
 
 type HeaderParameterSubSchema struct {
 	// Determines whether or not this parameter is required or optional.
-	Required bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Required *google_protobuf1.BoolValue `protobuf:"bytes,1,opt,name=required" json:"required,omitempty"`
 	// Determines the location of the parameter.
 	In string `protobuf:"bytes,2,opt,name=in" json:"in,omitempty"`
 	// A brief description of the parameter. This could contain examples of use.  GitHub Flavored Markdown is allowed.
 	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
 	// The name of the parameter.
-	Name             string           `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
-	Type             string           `protobuf:"bytes,5,opt,name=type" json:"type,omitempty"`
-	Format           string           `protobuf:"bytes,6,opt,name=format" json:"format,omitempty"`
-	Items            *PrimitivesItems `protobuf:"bytes,7,opt,name=items" json:"items,omitempty"`
-	CollectionFormat string           `protobuf:"bytes,8,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
-	Default          *Any             `protobuf:"bytes,9,opt,name=default" json:"default,omitempty"`
-	Maximum          float64          `protobuf:"fixed64,10,opt,name=maximum" json:"maximum,omitempty"`
-	ExclusiveMaximum bool             `protobuf:"varint,11,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
-	Minimum          float64          `protobuf:"fixed64,12,opt,name=minimum" json:"minimum,omitempty"`
-	ExclusiveMinimum bool             `protobuf:"varint,13,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
-	MaxLength        int64            `protobuf:"varint,14,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
-	MinLength        int64            `protobuf:"varint,15,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
-	Pattern          string           `protobuf:"bytes,16,opt,name=pattern" json:"pattern,omitempty"`
-	MaxItems         int64            `protobuf:"varint,17,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
-	MinItems         int64            `protobuf:"varint,18,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
-	UniqueItems      bool             `protobuf:"varint,19,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
-	Enum             []*Any           `protobuf:"bytes,20,rep,name=enum" json:"enum,omitempty"`
-	MultipleOf       float64          `protobuf:"fixed64,21,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
-	VendorExtension  []*NamedAny      `protobuf:"bytes,22,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	Name             string                        `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	Type             string                        `protobuf:"bytes,5,opt,name=type" json:"type,omitempty"`
+	Format           string                        `protobuf:"bytes,6,opt,name=format" json:"format,omitempty"`
+	Items            *PrimitivesItems              `protobuf:"bytes,7,opt,name=items" json:"items,omitempty"`
+	CollectionFormat string                        `protobuf:"bytes,8,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
+	Default          *Any                          `protobuf:"bytes,9,opt,name=default" json:"default,omitempty"`
+	Maximum          *google_protobuf1.DoubleValue `protobuf:"bytes,10,opt,name=maximum" json:"maximum,omitempty"`
+	ExclusiveMaximum *google_protobuf1.BoolValue   `protobuf:"bytes,11,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
+	Minimum          *google_protobuf1.DoubleValue `protobuf:"bytes,12,opt,name=minimum" json:"minimum,omitempty"`
+	ExclusiveMinimum *google_protobuf1.BoolValue   `protobuf:"bytes,13,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
+	MaxLength        *google_protobuf1.Int64Value  `protobuf:"bytes,14,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	MinLength        *google_protobuf1.Int64Value  `protobuf:"bytes,15,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	Pattern          string                        `protobuf:"bytes,16,opt,name=pattern" json:"pattern,omitempty"`
+	MaxItems         *google_protobuf1.Int64Value  `protobuf:"bytes,17,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	MinItems         *google_protobuf1.Int64Value  `protobuf:"bytes,18,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	UniqueItems      *google_protobuf1.BoolValue   `protobuf:"bytes,19,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	Enum             []*Any                        `protobuf:"bytes,20,rep,name=enum" json:"enum,omitempty"`
+	MultipleOf       *google_protobuf1.DoubleValue `protobuf:"bytes,21,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
+	VendorExtension  []*NamedAny                   `protobuf:"bytes,22,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *HeaderParameterSubSchema) Reset()                    { *m = HeaderParameterSubSchema{} }
@@ -1120,11 +1121,11 @@ func (m *HeaderParameterSubSchema) String() string            { return proto.Com
 func (*HeaderParameterSubSchema) ProtoMessage()               {}
 func (*HeaderParameterSubSchema) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
-func (m *HeaderParameterSubSchema) GetRequired() bool {
+func (m *HeaderParameterSubSchema) GetRequired() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Required
 	}
-	return false
+	return nil
 }
 
 func (m *HeaderParameterSubSchema) GetIn() string {
@@ -1183,46 +1184,46 @@ func (m *HeaderParameterSubSchema) GetDefault() *Any {
 	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetMaximum() float64 {
+func (m *HeaderParameterSubSchema) GetMaximum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Maximum
 	}
-	return 0
+	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetExclusiveMaximum() bool {
+func (m *HeaderParameterSubSchema) GetExclusiveMaximum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMaximum
 	}
-	return false
+	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetMinimum() float64 {
+func (m *HeaderParameterSubSchema) GetMinimum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Minimum
 	}
-	return 0
+	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetExclusiveMinimum() bool {
+func (m *HeaderParameterSubSchema) GetExclusiveMinimum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMinimum
 	}
-	return false
+	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetMaxLength() int64 {
+func (m *HeaderParameterSubSchema) GetMaxLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxLength
 	}
-	return 0
+	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetMinLength() int64 {
+func (m *HeaderParameterSubSchema) GetMinLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinLength
 	}
-	return 0
+	return nil
 }
 
 func (m *HeaderParameterSubSchema) GetPattern() string {
@@ -1232,25 +1233,25 @@ func (m *HeaderParameterSubSchema) GetPattern() string {
 	return ""
 }
 
-func (m *HeaderParameterSubSchema) GetMaxItems() int64 {
+func (m *HeaderParameterSubSchema) GetMaxItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxItems
 	}
-	return 0
+	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetMinItems() int64 {
+func (m *HeaderParameterSubSchema) GetMinItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinItems
 	}
-	return 0
+	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetUniqueItems() bool {
+func (m *HeaderParameterSubSchema) GetUniqueItems() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.UniqueItems
 	}
-	return false
+	return nil
 }
 
 func (m *HeaderParameterSubSchema) GetEnum() []*Any {
@@ -1260,11 +1261,11 @@ func (m *HeaderParameterSubSchema) GetEnum() []*Any {
 	return nil
 }
 
-func (m *HeaderParameterSubSchema) GetMultipleOf() float64 {
+func (m *HeaderParameterSubSchema) GetMultipleOf() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.MultipleOf
 	}
-	return 0
+	return nil
 }
 
 func (m *HeaderParameterSubSchema) GetVendorExtension() []*NamedAny {
@@ -2151,10 +2152,10 @@ type Operation struct {
 	Parameters []*ParametersItem `protobuf:"bytes,8,rep,name=parameters" json:"parameters,omitempty"`
 	Responses  *Responses        `protobuf:"bytes,9,opt,name=responses" json:"responses,omitempty"`
 	// The transfer protocol of the API.
-	Schemes         []string               `protobuf:"bytes,10,rep,name=schemes" json:"schemes,omitempty"`
-	Deprecated      bool                   `protobuf:"varint,11,opt,name=deprecated" json:"deprecated,omitempty"`
-	Security        []*SecurityRequirement `protobuf:"bytes,12,rep,name=security" json:"security,omitempty"`
-	VendorExtension []*NamedAny            `protobuf:"bytes,13,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	Schemes         []string                    `protobuf:"bytes,10,rep,name=schemes" json:"schemes,omitempty"`
+	Deprecated      *google_protobuf1.BoolValue `protobuf:"bytes,11,opt,name=deprecated" json:"deprecated,omitempty"`
+	Security        []*SecurityRequirement      `protobuf:"bytes,12,rep,name=security" json:"security,omitempty"`
+	VendorExtension []*NamedAny                 `protobuf:"bytes,13,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *Operation) Reset()                    { *m = Operation{} }
@@ -2232,11 +2233,11 @@ func (m *Operation) GetSchemes() []string {
 	return nil
 }
 
-func (m *Operation) GetDeprecated() bool {
+func (m *Operation) GetDeprecated() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Deprecated
 	}
-	return false
+	return nil
 }
 
 func (m *Operation) GetSecurity() []*SecurityRequirement {
@@ -2603,31 +2604,31 @@ func (m *PathItem) GetVendorExtension() []*NamedAny {
 
 type PathParameterSubSchema struct {
 	// Determines whether or not this parameter is required or optional.
-	Required bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Required *google_protobuf1.BoolValue `protobuf:"bytes,1,opt,name=required" json:"required,omitempty"`
 	// Determines the location of the parameter.
 	In string `protobuf:"bytes,2,opt,name=in" json:"in,omitempty"`
 	// A brief description of the parameter. This could contain examples of use.  GitHub Flavored Markdown is allowed.
 	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
 	// The name of the parameter.
-	Name             string           `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
-	Type             string           `protobuf:"bytes,5,opt,name=type" json:"type,omitempty"`
-	Format           string           `protobuf:"bytes,6,opt,name=format" json:"format,omitempty"`
-	Items            *PrimitivesItems `protobuf:"bytes,7,opt,name=items" json:"items,omitempty"`
-	CollectionFormat string           `protobuf:"bytes,8,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
-	Default          *Any             `protobuf:"bytes,9,opt,name=default" json:"default,omitempty"`
-	Maximum          float64          `protobuf:"fixed64,10,opt,name=maximum" json:"maximum,omitempty"`
-	ExclusiveMaximum bool             `protobuf:"varint,11,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
-	Minimum          float64          `protobuf:"fixed64,12,opt,name=minimum" json:"minimum,omitempty"`
-	ExclusiveMinimum bool             `protobuf:"varint,13,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
-	MaxLength        int64            `protobuf:"varint,14,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
-	MinLength        int64            `protobuf:"varint,15,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
-	Pattern          string           `protobuf:"bytes,16,opt,name=pattern" json:"pattern,omitempty"`
-	MaxItems         int64            `protobuf:"varint,17,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
-	MinItems         int64            `protobuf:"varint,18,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
-	UniqueItems      bool             `protobuf:"varint,19,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
-	Enum             []*Any           `protobuf:"bytes,20,rep,name=enum" json:"enum,omitempty"`
-	MultipleOf       float64          `protobuf:"fixed64,21,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
-	VendorExtension  []*NamedAny      `protobuf:"bytes,22,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	Name             string                        `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	Type             string                        `protobuf:"bytes,5,opt,name=type" json:"type,omitempty"`
+	Format           string                        `protobuf:"bytes,6,opt,name=format" json:"format,omitempty"`
+	Items            *PrimitivesItems              `protobuf:"bytes,7,opt,name=items" json:"items,omitempty"`
+	CollectionFormat string                        `protobuf:"bytes,8,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
+	Default          *Any                          `protobuf:"bytes,9,opt,name=default" json:"default,omitempty"`
+	Maximum          *google_protobuf1.DoubleValue `protobuf:"bytes,10,opt,name=maximum" json:"maximum,omitempty"`
+	ExclusiveMaximum *google_protobuf1.BoolValue   `protobuf:"bytes,11,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
+	Minimum          *google_protobuf1.DoubleValue `protobuf:"bytes,12,opt,name=minimum" json:"minimum,omitempty"`
+	ExclusiveMinimum *google_protobuf1.BoolValue   `protobuf:"bytes,13,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
+	MaxLength        *google_protobuf1.Int64Value  `protobuf:"bytes,14,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	MinLength        *google_protobuf1.Int64Value  `protobuf:"bytes,15,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	Pattern          string                        `protobuf:"bytes,16,opt,name=pattern" json:"pattern,omitempty"`
+	MaxItems         *google_protobuf1.Int64Value  `protobuf:"bytes,17,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	MinItems         *google_protobuf1.Int64Value  `protobuf:"bytes,18,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	UniqueItems      *google_protobuf1.BoolValue   `protobuf:"bytes,19,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	Enum             []*Any                        `protobuf:"bytes,20,rep,name=enum" json:"enum,omitempty"`
+	MultipleOf       *google_protobuf1.DoubleValue `protobuf:"bytes,21,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
+	VendorExtension  []*NamedAny                   `protobuf:"bytes,22,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *PathParameterSubSchema) Reset()                    { *m = PathParameterSubSchema{} }
@@ -2635,11 +2636,11 @@ func (m *PathParameterSubSchema) String() string            { return proto.Compa
 func (*PathParameterSubSchema) ProtoMessage()               {}
 func (*PathParameterSubSchema) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
-func (m *PathParameterSubSchema) GetRequired() bool {
+func (m *PathParameterSubSchema) GetRequired() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Required
 	}
-	return false
+	return nil
 }
 
 func (m *PathParameterSubSchema) GetIn() string {
@@ -2698,46 +2699,46 @@ func (m *PathParameterSubSchema) GetDefault() *Any {
 	return nil
 }
 
-func (m *PathParameterSubSchema) GetMaximum() float64 {
+func (m *PathParameterSubSchema) GetMaximum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Maximum
 	}
-	return 0
+	return nil
 }
 
-func (m *PathParameterSubSchema) GetExclusiveMaximum() bool {
+func (m *PathParameterSubSchema) GetExclusiveMaximum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMaximum
 	}
-	return false
+	return nil
 }
 
-func (m *PathParameterSubSchema) GetMinimum() float64 {
+func (m *PathParameterSubSchema) GetMinimum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Minimum
 	}
-	return 0
+	return nil
 }
 
-func (m *PathParameterSubSchema) GetExclusiveMinimum() bool {
+func (m *PathParameterSubSchema) GetExclusiveMinimum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMinimum
 	}
-	return false
+	return nil
 }
 
-func (m *PathParameterSubSchema) GetMaxLength() int64 {
+func (m *PathParameterSubSchema) GetMaxLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxLength
 	}
-	return 0
+	return nil
 }
 
-func (m *PathParameterSubSchema) GetMinLength() int64 {
+func (m *PathParameterSubSchema) GetMinLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinLength
 	}
-	return 0
+	return nil
 }
 
 func (m *PathParameterSubSchema) GetPattern() string {
@@ -2747,25 +2748,25 @@ func (m *PathParameterSubSchema) GetPattern() string {
 	return ""
 }
 
-func (m *PathParameterSubSchema) GetMaxItems() int64 {
+func (m *PathParameterSubSchema) GetMaxItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxItems
 	}
-	return 0
+	return nil
 }
 
-func (m *PathParameterSubSchema) GetMinItems() int64 {
+func (m *PathParameterSubSchema) GetMinItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinItems
 	}
-	return 0
+	return nil
 }
 
-func (m *PathParameterSubSchema) GetUniqueItems() bool {
+func (m *PathParameterSubSchema) GetUniqueItems() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.UniqueItems
 	}
-	return false
+	return nil
 }
 
 func (m *PathParameterSubSchema) GetEnum() []*Any {
@@ -2775,11 +2776,11 @@ func (m *PathParameterSubSchema) GetEnum() []*Any {
 	return nil
 }
 
-func (m *PathParameterSubSchema) GetMultipleOf() float64 {
+func (m *PathParameterSubSchema) GetMultipleOf() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.MultipleOf
 	}
-	return 0
+	return nil
 }
 
 func (m *PathParameterSubSchema) GetVendorExtension() []*NamedAny {
@@ -2815,24 +2816,24 @@ func (m *Paths) GetPath() []*NamedPathItem {
 }
 
 type PrimitivesItems struct {
-	Type             string           `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
-	Format           string           `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
-	Items            *PrimitivesItems `protobuf:"bytes,3,opt,name=items" json:"items,omitempty"`
-	CollectionFormat string           `protobuf:"bytes,4,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
-	Default          *Any             `protobuf:"bytes,5,opt,name=default" json:"default,omitempty"`
-	Maximum          float64          `protobuf:"fixed64,6,opt,name=maximum" json:"maximum,omitempty"`
-	ExclusiveMaximum bool             `protobuf:"varint,7,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
-	Minimum          float64          `protobuf:"fixed64,8,opt,name=minimum" json:"minimum,omitempty"`
-	ExclusiveMinimum bool             `protobuf:"varint,9,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
-	MaxLength        int64            `protobuf:"varint,10,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
-	MinLength        int64            `protobuf:"varint,11,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
-	Pattern          string           `protobuf:"bytes,12,opt,name=pattern" json:"pattern,omitempty"`
-	MaxItems         int64            `protobuf:"varint,13,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
-	MinItems         int64            `protobuf:"varint,14,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
-	UniqueItems      bool             `protobuf:"varint,15,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
-	Enum             []*Any           `protobuf:"bytes,16,rep,name=enum" json:"enum,omitempty"`
-	MultipleOf       float64          `protobuf:"fixed64,17,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
-	VendorExtension  []*NamedAny      `protobuf:"bytes,18,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	Type             string                        `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Format           string                        `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+	Items            *PrimitivesItems              `protobuf:"bytes,3,opt,name=items" json:"items,omitempty"`
+	CollectionFormat string                        `protobuf:"bytes,4,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
+	Default          *Any                          `protobuf:"bytes,5,opt,name=default" json:"default,omitempty"`
+	Maximum          *google_protobuf1.DoubleValue `protobuf:"bytes,6,opt,name=maximum" json:"maximum,omitempty"`
+	ExclusiveMaximum *google_protobuf1.BoolValue   `protobuf:"bytes,7,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
+	Minimum          *google_protobuf1.DoubleValue `protobuf:"bytes,8,opt,name=minimum" json:"minimum,omitempty"`
+	ExclusiveMinimum *google_protobuf1.BoolValue   `protobuf:"bytes,9,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
+	MaxLength        *google_protobuf1.Int64Value  `protobuf:"bytes,10,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	MinLength        *google_protobuf1.Int64Value  `protobuf:"bytes,11,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	Pattern          string                        `protobuf:"bytes,12,opt,name=pattern" json:"pattern,omitempty"`
+	MaxItems         *google_protobuf1.Int64Value  `protobuf:"bytes,13,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	MinItems         *google_protobuf1.Int64Value  `protobuf:"bytes,14,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	UniqueItems      *google_protobuf1.BoolValue   `protobuf:"bytes,15,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	Enum             []*Any                        `protobuf:"bytes,16,rep,name=enum" json:"enum,omitempty"`
+	MultipleOf       *google_protobuf1.DoubleValue `protobuf:"bytes,17,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
+	VendorExtension  []*NamedAny                   `protobuf:"bytes,18,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *PrimitivesItems) Reset()                    { *m = PrimitivesItems{} }
@@ -2875,46 +2876,46 @@ func (m *PrimitivesItems) GetDefault() *Any {
 	return nil
 }
 
-func (m *PrimitivesItems) GetMaximum() float64 {
+func (m *PrimitivesItems) GetMaximum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Maximum
 	}
-	return 0
+	return nil
 }
 
-func (m *PrimitivesItems) GetExclusiveMaximum() bool {
+func (m *PrimitivesItems) GetExclusiveMaximum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMaximum
 	}
-	return false
+	return nil
 }
 
-func (m *PrimitivesItems) GetMinimum() float64 {
+func (m *PrimitivesItems) GetMinimum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Minimum
 	}
-	return 0
+	return nil
 }
 
-func (m *PrimitivesItems) GetExclusiveMinimum() bool {
+func (m *PrimitivesItems) GetExclusiveMinimum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMinimum
 	}
-	return false
+	return nil
 }
 
-func (m *PrimitivesItems) GetMaxLength() int64 {
+func (m *PrimitivesItems) GetMaxLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxLength
 	}
-	return 0
+	return nil
 }
 
-func (m *PrimitivesItems) GetMinLength() int64 {
+func (m *PrimitivesItems) GetMinLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinLength
 	}
-	return 0
+	return nil
 }
 
 func (m *PrimitivesItems) GetPattern() string {
@@ -2924,25 +2925,25 @@ func (m *PrimitivesItems) GetPattern() string {
 	return ""
 }
 
-func (m *PrimitivesItems) GetMaxItems() int64 {
+func (m *PrimitivesItems) GetMaxItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxItems
 	}
-	return 0
+	return nil
 }
 
-func (m *PrimitivesItems) GetMinItems() int64 {
+func (m *PrimitivesItems) GetMinItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinItems
 	}
-	return 0
+	return nil
 }
 
-func (m *PrimitivesItems) GetUniqueItems() bool {
+func (m *PrimitivesItems) GetUniqueItems() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.UniqueItems
 	}
-	return false
+	return nil
 }
 
 func (m *PrimitivesItems) GetEnum() []*Any {
@@ -2952,11 +2953,11 @@ func (m *PrimitivesItems) GetEnum() []*Any {
 	return nil
 }
 
-func (m *PrimitivesItems) GetMultipleOf() float64 {
+func (m *PrimitivesItems) GetMultipleOf() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.MultipleOf
 	}
-	return 0
+	return nil
 }
 
 func (m *PrimitivesItems) GetVendorExtension() []*NamedAny {
@@ -2984,7 +2985,7 @@ func (m *Properties) GetAdditionalProperties() []*NamedSchema {
 
 type QueryParameterSubSchema struct {
 	// Determines whether or not this parameter is required or optional.
-	Required bool `protobuf:"varint,1,opt,name=required" json:"required,omitempty"`
+	Required *google_protobuf1.BoolValue `protobuf:"bytes,1,opt,name=required" json:"required,omitempty"`
 	// Determines the location of the parameter.
 	In string `protobuf:"bytes,2,opt,name=in" json:"in,omitempty"`
 	// A brief description of the parameter. This could contain examples of use.  GitHub Flavored Markdown is allowed.
@@ -2992,25 +2993,25 @@ type QueryParameterSubSchema struct {
 	// The name of the parameter.
 	Name string `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
 	// allows sending a parameter by name only or with an empty value.
-	AllowEmptyValue  bool             `protobuf:"varint,5,opt,name=allow_empty_value,json=allowEmptyValue" json:"allow_empty_value,omitempty"`
-	Type             string           `protobuf:"bytes,6,opt,name=type" json:"type,omitempty"`
-	Format           string           `protobuf:"bytes,7,opt,name=format" json:"format,omitempty"`
-	Items            *PrimitivesItems `protobuf:"bytes,8,opt,name=items" json:"items,omitempty"`
-	CollectionFormat string           `protobuf:"bytes,9,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
-	Default          *Any             `protobuf:"bytes,10,opt,name=default" json:"default,omitempty"`
-	Maximum          float64          `protobuf:"fixed64,11,opt,name=maximum" json:"maximum,omitempty"`
-	ExclusiveMaximum bool             `protobuf:"varint,12,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
-	Minimum          float64          `protobuf:"fixed64,13,opt,name=minimum" json:"minimum,omitempty"`
-	ExclusiveMinimum bool             `protobuf:"varint,14,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
-	MaxLength        int64            `protobuf:"varint,15,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
-	MinLength        int64            `protobuf:"varint,16,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
-	Pattern          string           `protobuf:"bytes,17,opt,name=pattern" json:"pattern,omitempty"`
-	MaxItems         int64            `protobuf:"varint,18,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
-	MinItems         int64            `protobuf:"varint,19,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
-	UniqueItems      bool             `protobuf:"varint,20,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
-	Enum             []*Any           `protobuf:"bytes,21,rep,name=enum" json:"enum,omitempty"`
-	MultipleOf       float64          `protobuf:"fixed64,22,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
-	VendorExtension  []*NamedAny      `protobuf:"bytes,23,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	AllowEmptyValue  *google_protobuf1.BoolValue   `protobuf:"bytes,5,opt,name=allow_empty_value,json=allowEmptyValue" json:"allow_empty_value,omitempty"`
+	Type             string                        `protobuf:"bytes,6,opt,name=type" json:"type,omitempty"`
+	Format           string                        `protobuf:"bytes,7,opt,name=format" json:"format,omitempty"`
+	Items            *PrimitivesItems              `protobuf:"bytes,8,opt,name=items" json:"items,omitempty"`
+	CollectionFormat string                        `protobuf:"bytes,9,opt,name=collection_format,json=collectionFormat" json:"collection_format,omitempty"`
+	Default          *Any                          `protobuf:"bytes,10,opt,name=default" json:"default,omitempty"`
+	Maximum          *google_protobuf1.DoubleValue `protobuf:"bytes,11,opt,name=maximum" json:"maximum,omitempty"`
+	ExclusiveMaximum *google_protobuf1.BoolValue   `protobuf:"bytes,12,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
+	Minimum          *google_protobuf1.DoubleValue `protobuf:"bytes,13,opt,name=minimum" json:"minimum,omitempty"`
+	ExclusiveMinimum *google_protobuf1.BoolValue   `protobuf:"bytes,14,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
+	MaxLength        *google_protobuf1.Int64Value  `protobuf:"bytes,15,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	MinLength        *google_protobuf1.Int64Value  `protobuf:"bytes,16,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	Pattern          string                        `protobuf:"bytes,17,opt,name=pattern" json:"pattern,omitempty"`
+	MaxItems         *google_protobuf1.Int64Value  `protobuf:"bytes,18,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	MinItems         *google_protobuf1.Int64Value  `protobuf:"bytes,19,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	UniqueItems      *google_protobuf1.BoolValue   `protobuf:"bytes,20,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	Enum             []*Any                        `protobuf:"bytes,21,rep,name=enum" json:"enum,omitempty"`
+	MultipleOf       *google_protobuf1.DoubleValue `protobuf:"bytes,22,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
+	VendorExtension  []*NamedAny                   `protobuf:"bytes,23,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *QueryParameterSubSchema) Reset()                    { *m = QueryParameterSubSchema{} }
@@ -3018,11 +3019,11 @@ func (m *QueryParameterSubSchema) String() string            { return proto.Comp
 func (*QueryParameterSubSchema) ProtoMessage()               {}
 func (*QueryParameterSubSchema) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
-func (m *QueryParameterSubSchema) GetRequired() bool {
+func (m *QueryParameterSubSchema) GetRequired() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Required
 	}
-	return false
+	return nil
 }
 
 func (m *QueryParameterSubSchema) GetIn() string {
@@ -3046,11 +3047,11 @@ func (m *QueryParameterSubSchema) GetName() string {
 	return ""
 }
 
-func (m *QueryParameterSubSchema) GetAllowEmptyValue() bool {
+func (m *QueryParameterSubSchema) GetAllowEmptyValue() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.AllowEmptyValue
 	}
-	return false
+	return nil
 }
 
 func (m *QueryParameterSubSchema) GetType() string {
@@ -3088,46 +3089,46 @@ func (m *QueryParameterSubSchema) GetDefault() *Any {
 	return nil
 }
 
-func (m *QueryParameterSubSchema) GetMaximum() float64 {
+func (m *QueryParameterSubSchema) GetMaximum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Maximum
 	}
-	return 0
+	return nil
 }
 
-func (m *QueryParameterSubSchema) GetExclusiveMaximum() bool {
+func (m *QueryParameterSubSchema) GetExclusiveMaximum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMaximum
 	}
-	return false
+	return nil
 }
 
-func (m *QueryParameterSubSchema) GetMinimum() float64 {
+func (m *QueryParameterSubSchema) GetMinimum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Minimum
 	}
-	return 0
+	return nil
 }
 
-func (m *QueryParameterSubSchema) GetExclusiveMinimum() bool {
+func (m *QueryParameterSubSchema) GetExclusiveMinimum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMinimum
 	}
-	return false
+	return nil
 }
 
-func (m *QueryParameterSubSchema) GetMaxLength() int64 {
+func (m *QueryParameterSubSchema) GetMaxLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxLength
 	}
-	return 0
+	return nil
 }
 
-func (m *QueryParameterSubSchema) GetMinLength() int64 {
+func (m *QueryParameterSubSchema) GetMinLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinLength
 	}
-	return 0
+	return nil
 }
 
 func (m *QueryParameterSubSchema) GetPattern() string {
@@ -3137,25 +3138,25 @@ func (m *QueryParameterSubSchema) GetPattern() string {
 	return ""
 }
 
-func (m *QueryParameterSubSchema) GetMaxItems() int64 {
+func (m *QueryParameterSubSchema) GetMaxItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxItems
 	}
-	return 0
+	return nil
 }
 
-func (m *QueryParameterSubSchema) GetMinItems() int64 {
+func (m *QueryParameterSubSchema) GetMinItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinItems
 	}
-	return 0
+	return nil
 }
 
-func (m *QueryParameterSubSchema) GetUniqueItems() bool {
+func (m *QueryParameterSubSchema) GetUniqueItems() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.UniqueItems
 	}
-	return false
+	return nil
 }
 
 func (m *QueryParameterSubSchema) GetEnum() []*Any {
@@ -3165,11 +3166,11 @@ func (m *QueryParameterSubSchema) GetEnum() []*Any {
 	return nil
 }
 
-func (m *QueryParameterSubSchema) GetMultipleOf() float64 {
+func (m *QueryParameterSubSchema) GetMultipleOf() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.MultipleOf
 	}
-	return 0
+	return nil
 }
 
 func (m *QueryParameterSubSchema) GetVendorExtension() []*NamedAny {
@@ -3392,37 +3393,37 @@ func (m *Responses) GetVendorExtension() []*NamedAny {
 
 // A deterministic version of a JSON Schema object.
 type Schema struct {
-	XRef                 string                    `protobuf:"bytes,1,opt,name=_ref,json=Ref" json:"_ref,omitempty"`
-	Format               string                    `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
-	Title                string                    `protobuf:"bytes,3,opt,name=title" json:"title,omitempty"`
-	Description          string                    `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
-	Default              *Any                      `protobuf:"bytes,5,opt,name=default" json:"default,omitempty"`
-	MultipleOf           float64                   `protobuf:"fixed64,6,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
-	Maximum              float64                   `protobuf:"fixed64,7,opt,name=maximum" json:"maximum,omitempty"`
-	ExclusiveMaximum     bool                      `protobuf:"varint,8,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
-	Minimum              float64                   `protobuf:"fixed64,9,opt,name=minimum" json:"minimum,omitempty"`
-	ExclusiveMinimum     bool                      `protobuf:"varint,10,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
-	MaxLength            int64                     `protobuf:"varint,11,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
-	MinLength            int64                     `protobuf:"varint,12,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
-	Pattern              string                    `protobuf:"bytes,13,opt,name=pattern" json:"pattern,omitempty"`
-	MaxItems             int64                     `protobuf:"varint,14,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
-	MinItems             int64                     `protobuf:"varint,15,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
-	UniqueItems          bool                      `protobuf:"varint,16,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
-	MaxProperties        int64                     `protobuf:"varint,17,opt,name=max_properties,json=maxProperties" json:"max_properties,omitempty"`
-	MinProperties        int64                     `protobuf:"varint,18,opt,name=min_properties,json=minProperties" json:"min_properties,omitempty"`
-	Required             []string                  `protobuf:"bytes,19,rep,name=required" json:"required,omitempty"`
-	Enum                 []*Any                    `protobuf:"bytes,20,rep,name=enum" json:"enum,omitempty"`
-	AdditionalProperties *AdditionalPropertiesItem `protobuf:"bytes,21,opt,name=additional_properties,json=additionalProperties" json:"additional_properties,omitempty"`
-	Type                 *TypeItem                 `protobuf:"bytes,22,opt,name=type" json:"type,omitempty"`
-	Items                *ItemsItem                `protobuf:"bytes,23,opt,name=items" json:"items,omitempty"`
-	AllOf                []*Schema                 `protobuf:"bytes,24,rep,name=all_of,json=allOf" json:"all_of,omitempty"`
-	Properties           *Properties               `protobuf:"bytes,25,opt,name=properties" json:"properties,omitempty"`
-	Discriminator        string                    `protobuf:"bytes,26,opt,name=discriminator" json:"discriminator,omitempty"`
-	ReadOnly             bool                      `protobuf:"varint,27,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
-	Xml                  *Xml                      `protobuf:"bytes,28,opt,name=xml" json:"xml,omitempty"`
-	ExternalDocs         *ExternalDocs             `protobuf:"bytes,29,opt,name=external_docs,json=externalDocs" json:"external_docs,omitempty"`
-	Example              *Any                      `protobuf:"bytes,30,opt,name=example" json:"example,omitempty"`
-	VendorExtension      []*NamedAny               `protobuf:"bytes,31,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	XRef                 string                        `protobuf:"bytes,1,opt,name=_ref,json=Ref" json:"_ref,omitempty"`
+	Format               string                        `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+	Title                string                        `protobuf:"bytes,3,opt,name=title" json:"title,omitempty"`
+	Description          string                        `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+	Default              *Any                          `protobuf:"bytes,5,opt,name=default" json:"default,omitempty"`
+	MultipleOf           *google_protobuf1.DoubleValue `protobuf:"bytes,6,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
+	Maximum              *google_protobuf1.DoubleValue `protobuf:"bytes,7,opt,name=maximum" json:"maximum,omitempty"`
+	ExclusiveMaximum     *google_protobuf1.BoolValue   `protobuf:"bytes,8,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
+	Minimum              *google_protobuf1.DoubleValue `protobuf:"bytes,9,opt,name=minimum" json:"minimum,omitempty"`
+	ExclusiveMinimum     *google_protobuf1.BoolValue   `protobuf:"bytes,10,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
+	MaxLength            *google_protobuf1.Int64Value  `protobuf:"bytes,11,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	MinLength            *google_protobuf1.Int64Value  `protobuf:"bytes,12,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	Pattern              string                        `protobuf:"bytes,13,opt,name=pattern" json:"pattern,omitempty"`
+	MaxItems             *google_protobuf1.Int64Value  `protobuf:"bytes,14,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	MinItems             *google_protobuf1.Int64Value  `protobuf:"bytes,15,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	UniqueItems          *google_protobuf1.BoolValue   `protobuf:"bytes,16,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	MaxProperties        *google_protobuf1.Int64Value  `protobuf:"bytes,17,opt,name=max_properties,json=maxProperties" json:"max_properties,omitempty"`
+	MinProperties        *google_protobuf1.Int64Value  `protobuf:"bytes,18,opt,name=min_properties,json=minProperties" json:"min_properties,omitempty"`
+	Required             []string                      `protobuf:"bytes,19,rep,name=required" json:"required,omitempty"`
+	Enum                 []*Any                        `protobuf:"bytes,20,rep,name=enum" json:"enum,omitempty"`
+	AdditionalProperties *AdditionalPropertiesItem     `protobuf:"bytes,21,opt,name=additional_properties,json=additionalProperties" json:"additional_properties,omitempty"`
+	Type                 *TypeItem                     `protobuf:"bytes,22,opt,name=type" json:"type,omitempty"`
+	Items                *ItemsItem                    `protobuf:"bytes,23,opt,name=items" json:"items,omitempty"`
+	AllOf                []*Schema                     `protobuf:"bytes,24,rep,name=all_of,json=allOf" json:"all_of,omitempty"`
+	Properties           *Properties                   `protobuf:"bytes,25,opt,name=properties" json:"properties,omitempty"`
+	Discriminator        string                        `protobuf:"bytes,26,opt,name=discriminator" json:"discriminator,omitempty"`
+	ReadOnly             *google_protobuf1.BoolValue   `protobuf:"bytes,27,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
+	Xml                  *Xml                          `protobuf:"bytes,28,opt,name=xml" json:"xml,omitempty"`
+	ExternalDocs         *ExternalDocs                 `protobuf:"bytes,29,opt,name=external_docs,json=externalDocs" json:"external_docs,omitempty"`
+	Example              *Any                          `protobuf:"bytes,30,opt,name=example" json:"example,omitempty"`
+	VendorExtension      []*NamedAny                   `protobuf:"bytes,31,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *Schema) Reset()                    { *m = Schema{} }
@@ -3465,53 +3466,53 @@ func (m *Schema) GetDefault() *Any {
 	return nil
 }
 
-func (m *Schema) GetMultipleOf() float64 {
+func (m *Schema) GetMultipleOf() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.MultipleOf
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetMaximum() float64 {
+func (m *Schema) GetMaximum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Maximum
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetExclusiveMaximum() bool {
+func (m *Schema) GetExclusiveMaximum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMaximum
 	}
-	return false
+	return nil
 }
 
-func (m *Schema) GetMinimum() float64 {
+func (m *Schema) GetMinimum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Minimum
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetExclusiveMinimum() bool {
+func (m *Schema) GetExclusiveMinimum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMinimum
 	}
-	return false
+	return nil
 }
 
-func (m *Schema) GetMaxLength() int64 {
+func (m *Schema) GetMaxLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxLength
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetMinLength() int64 {
+func (m *Schema) GetMinLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinLength
 	}
-	return 0
+	return nil
 }
 
 func (m *Schema) GetPattern() string {
@@ -3521,39 +3522,39 @@ func (m *Schema) GetPattern() string {
 	return ""
 }
 
-func (m *Schema) GetMaxItems() int64 {
+func (m *Schema) GetMaxItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxItems
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetMinItems() int64 {
+func (m *Schema) GetMinItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinItems
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetUniqueItems() bool {
+func (m *Schema) GetUniqueItems() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.UniqueItems
 	}
-	return false
+	return nil
 }
 
-func (m *Schema) GetMaxProperties() int64 {
+func (m *Schema) GetMaxProperties() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxProperties
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetMinProperties() int64 {
+func (m *Schema) GetMinProperties() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinProperties
 	}
-	return 0
+	return nil
 }
 
 func (m *Schema) GetRequired() []string {
@@ -3612,11 +3613,11 @@ func (m *Schema) GetDiscriminator() string {
 	return ""
 }
 
-func (m *Schema) GetReadOnly() bool {
+func (m *Schema) GetReadOnly() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ReadOnly
 	}
-	return false
+	return nil
 }
 
 func (m *Schema) GetXml() *Xml {
@@ -4135,12 +4136,12 @@ func (m *VendorExtension) GetAdditionalProperties() []*NamedAny {
 }
 
 type Xml struct {
-	Name            string      `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Namespace       string      `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
-	Prefix          string      `protobuf:"bytes,3,opt,name=prefix" json:"prefix,omitempty"`
-	Attribute       bool        `protobuf:"varint,4,opt,name=attribute" json:"attribute,omitempty"`
-	Wrapped         bool        `protobuf:"varint,5,opt,name=wrapped" json:"wrapped,omitempty"`
-	VendorExtension []*NamedAny `protobuf:"bytes,6,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
+	Name            string                      `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Namespace       string                      `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	Prefix          string                      `protobuf:"bytes,3,opt,name=prefix" json:"prefix,omitempty"`
+	Attribute       *google_protobuf1.BoolValue `protobuf:"bytes,4,opt,name=attribute" json:"attribute,omitempty"`
+	Wrapped         *google_protobuf1.BoolValue `protobuf:"bytes,5,opt,name=wrapped" json:"wrapped,omitempty"`
+	VendorExtension []*NamedAny                 `protobuf:"bytes,6,rep,name=vendor_extension,json=vendorExtension" json:"vendor_extension,omitempty"`
 }
 
 func (m *Xml) Reset()                    { *m = Xml{} }
@@ -4169,18 +4170,18 @@ func (m *Xml) GetPrefix() string {
 	return ""
 }
 
-func (m *Xml) GetAttribute() bool {
+func (m *Xml) GetAttribute() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Attribute
 	}
-	return false
+	return nil
 }
 
-func (m *Xml) GetWrapped() bool {
+func (m *Xml) GetWrapped() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Wrapped
 	}
-	return false
+	return nil
 }
 
 func (m *Xml) GetVendorExtension() []*NamedAny {
@@ -4256,201 +4257,205 @@ func init() {
 func init() { proto.RegisterFile("OpenAPIv2.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 3126 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x3b, 0x4b, 0x73, 0x1c, 0x57,
-	0xd5, 0xf3, 0x7e, 0x1c, 0x69, 0x46, 0xa3, 0x96, 0x2c, 0xb7, 0x24, 0xc7, 0x71, 0xe4, 0x3c, 0x6c,
-	0xe7, 0xb3, 0x9c, 0x4f, 0x29, 0x48, 0x05, 0x2a, 0x05, 0xf2, 0xab, 0xc6, 0xc4, 0x44, 0x4a, 0xcb,
-	0x0e, 0x09, 0x04, 0xba, 0xae, 0x66, 0xee, 0x48, 0x9d, 0x74, 0xf7, 0x6d, 0x77, 0xf7, 0xc8, 0x1a,
-	0x16, 0x2c, 0xa0, 0x8a, 0x35, 0x50, 0x59, 0x53, 0x15, 0x16, 0x14, 0x55, 0x59, 0xb0, 0x62, 0xc5,
-	0x1f, 0x60, 0xc7, 0x3f, 0x60, 0x0d, 0x5b, 0xaa, 0x58, 0x51, 0x3c, 0xea, 0xbe, 0xa6, 0x5f, 0xb7,
-	0xe7, 0x61, 0xb9, 0x80, 0x02, 0xad, 0x66, 0xee, 0x3d, 0xe7, 0x9e, 0x7b, 0xfa, 0xf4, 0x79, 0xdd,
-	0x73, 0x6e, 0xc3, 0xd2, 0x9e, 0x87, 0xdd, 0xdd, 0xfd, 0x07, 0x27, 0x3b, 0xdb, 0x9e, 0x4f, 0x42,
-	0xa2, 0x01, 0xf1, 0xb0, 0x8b, 0x3c, 0x6b, 0xfb, 0x64, 0x67, 0x63, 0xfd, 0x88, 0x90, 0x23, 0x1b,
-	0xdf, 0x62, 0x90, 0xc3, 0xe1, 0xe0, 0x16, 0x72, 0x47, 0x1c, 0x6d, 0xcb, 0x01, 0x7d, 0xb7, 0xdf,
-	0xb7, 0x42, 0x8b, 0xb8, 0xc8, 0xde, 0xf7, 0x89, 0x87, 0xfd, 0xd0, 0xc2, 0xc1, 0x83, 0x10, 0x3b,
-	0xda, 0xff, 0x41, 0x2d, 0xe8, 0x1d, 0x63, 0x07, 0xe9, 0xc5, 0x2b, 0xc5, 0x6b, 0x0b, 0x3b, 0xda,
-	0x76, 0x44, 0x73, 0xfb, 0x80, 0x41, 0xba, 0x05, 0x43, 0xe0, 0x68, 0x1b, 0x50, 0x3f, 0x24, 0xc4,
-	0xc6, 0xc8, 0xd5, 0x4b, 0x57, 0x8a, 0xd7, 0x1a, 0xdd, 0x82, 0x21, 0x27, 0x6e, 0xd7, 0xa1, 0x4a,
-	0x5c, 0x4c, 0x06, 0x5b, 0xf7, 0xa0, 0xbc, 0xeb, 0x8e, 0xb4, 0x1b, 0x50, 0x3d, 0x41, 0xf6, 0x10,
-	0x0b, 0xc2, 0xab, 0xdb, 0x9c, 0xc1, 0x6d, 0xc9, 0xe0, 0xf6, 0xae, 0x3b, 0x32, 0x38, 0x8a, 0xa6,
-	0x41, 0x65, 0x84, 0x1c, 0x9b, 0x11, 0x6d, 0x1a, 0xec, 0xff, 0xd6, 0x17, 0x45, 0x68, 0xef, 0x7a,
-	0xd6, 0xbb, 0x78, 0x74, 0x80, 0x7b, 0x43, 0xdf, 0x0a, 0x47, 0x14, 0x2d, 0x1c, 0x79, 0x9c, 0x62,
-	0xd3, 0x60, 0xff, 0xe9, 0x9c, 0x8b, 0x1c, 0x2c, 0x97, 0xd2, 0xff, 0x5a, 0x1b, 0x4a, 0x96, 0xab,
-	0x97, 0xd9, 0x4c, 0xc9, 0x72, 0xb5, 0x2b, 0xb0, 0xd0, 0xc7, 0x41, 0xcf, 0xb7, 0x3c, 0x2a, 0x03,
-	0xbd, 0xc2, 0x00, 0xf1, 0x29, 0xed, 0x6b, 0xd0, 0x39, 0xc1, 0x6e, 0x9f, 0xf8, 0x26, 0x3e, 0x0d,
-	0xb1, 0x1b, 0x50, 0xb4, 0xea, 0x95, 0x32, 0xe3, 0x3b, 0x26, 0x90, 0xf7, 0x90, 0x83, 0xfb, 0x94,
-	0xef, 0x25, 0x8e, 0x7d, 0x4f, 0x22, 0x6f, 0x7d, 0x56, 0x84, 0xcd, 0xdb, 0x28, 0xb0, 0x7a, 0xbb,
-	0xc3, 0xf0, 0x18, 0xbb, 0xa1, 0xd5, 0x43, 0x94, 0xf0, 0x44, 0xd6, 0x53, 0x6c, 0x95, 0x66, 0x63,
-	0xab, 0x3c, 0x0f, 0x5b, 0x7f, 0x28, 0x42, 0xeb, 0x36, 0xe9, 0x8f, 0xf6, 0x91, 0x8f, 0x1c, 0x1c,
-	0x62, 0x3f, 0xbd, 0x69, 0x31, 0xbb, 0xe9, 0x2c, 0x12, 0xdd, 0x80, 0x86, 0x8f, 0x9f, 0x0c, 0x2d,
-	0x1f, 0xf7, 0x99, 0x38, 0x1b, 0xc6, 0x78, 0xac, 0xdd, 0x18, 0xab, 0x54, 0x35, 0x4f, 0xa5, 0xc6,
-	0x0a, 0xa5, 0x7a, 0xc0, 0xda, 0x3c, 0x0f, 0xf8, 0xe3, 0x22, 0xd4, 0xef, 0x10, 0x37, 0x44, 0xbd,
-	0x70, 0xcc, 0x78, 0x31, 0xc6, 0x78, 0x07, 0xca, 0x43, 0x5f, 0x2a, 0x16, 0xfd, 0xab, 0xad, 0x42,
-	0x15, 0x3b, 0xc8, 0xb2, 0xc5, 0xd3, 0xf0, 0x81, 0x92, 0x91, 0xca, 0x3c, 0x8c, 0x3c, 0x82, 0xfa,
-	0x5d, 0x3c, 0x40, 0x43, 0x3b, 0xd4, 0x1e, 0xc0, 0x05, 0x34, 0xb6, 0x37, 0xd3, 0x1b, 0x1b, 0x9c,
-	0x5e, 0x9c, 0x40, 0x70, 0x15, 0x29, 0x4c, 0x74, 0xeb, 0x3b, 0xb0, 0x70, 0x17, 0x0f, 0x2c, 0x97,
-	0x41, 0x02, 0xed, 0xe1, 0x64, 0xca, 0x17, 0x33, 0x94, 0x85, 0xb8, 0xd5, 0xc4, 0xff, 0x58, 0x85,
-	0xc6, 0x5d, 0xd2, 0x1b, 0x3a, 0xd8, 0x0d, 0x35, 0x1d, 0xea, 0xc1, 0x53, 0x74, 0x74, 0x84, 0x7d,
-	0x21, 0x3f, 0x39, 0xd4, 0x5e, 0x86, 0x8a, 0xe5, 0x0e, 0x08, 0x93, 0xe1, 0xc2, 0x4e, 0x27, 0xbe,
-	0xc7, 0x03, 0x77, 0x40, 0x0c, 0x06, 0xa5, 0xc2, 0x3f, 0x26, 0x41, 0x28, 0xa4, 0xca, 0xfe, 0x6b,
-	0x9b, 0xd0, 0x3c, 0x44, 0x01, 0x36, 0x3d, 0x14, 0x1e, 0x0b, 0xab, 0x6b, 0xd0, 0x89, 0x7d, 0x14,
-	0x1e, 0xb3, 0x0d, 0x29, 0x77, 0x38, 0x60, 0x96, 0x46, 0x37, 0xe4, 0x43, 0xaa, 0x5c, 0x3d, 0xe2,
-	0x06, 0x43, 0x0a, 0xaa, 0x31, 0xd0, 0x78, 0x4c, 0x61, 0x9e, 0x4f, 0xfa, 0xc3, 0x1e, 0x0e, 0xf4,
-	0x3a, 0x87, 0xc9, 0xb1, 0xf6, 0x1a, 0x54, 0xe9, 0x4e, 0x81, 0xde, 0x60, 0x9c, 0x2e, 0xc7, 0x39,
-	0xa5, 0x5b, 0x06, 0x06, 0x87, 0x6b, 0x6f, 0x53, 0x1b, 0x18, 0x4b, 0x55, 0x6f, 0x32, 0xf4, 0x84,
-	0xf0, 0x62, 0x42, 0x37, 0xe2, 0xb8, 0xda, 0xd7, 0x01, 0x3c, 0x69, 0x4b, 0x81, 0x0e, 0x6c, 0xe5,
-	0x95, 0xe4, 0x46, 0x02, 0x1a, 0x27, 0x11, 0x5b, 0xa3, 0xbd, 0x03, 0x4d, 0x1f, 0x07, 0x1e, 0x71,
-	0x03, 0x1c, 0xe8, 0x0b, 0x8c, 0xc0, 0x8b, 0x71, 0x02, 0x86, 0x00, 0xc6, 0xd7, 0x47, 0x2b, 0xb4,
-	0xaf, 0x42, 0x23, 0x10, 0x4e, 0x45, 0x5f, 0x64, 0x6f, 0x3d, 0xb1, 0x5a, 0x3a, 0x1c, 0x83, 0x5b,
-	0x23, 0x7d, 0xb5, 0xc6, 0x78, 0x81, 0x66, 0xc0, 0xaa, 0xfc, 0x6f, 0xc6, 0x25, 0xd0, 0xca, 0xb2,
-	0x21, 0x09, 0xc5, 0xd9, 0x58, 0x09, 0xb2, 0x93, 0xda, 0x55, 0xa8, 0x84, 0xe8, 0x28, 0xd0, 0xdb,
-	0x8c, 0x99, 0xa5, 0x38, 0x8d, 0x47, 0xe8, 0xc8, 0x60, 0x40, 0xed, 0x1d, 0x68, 0x51, 0xbb, 0xf2,
-	0xa9, 0xda, 0xf6, 0x49, 0x2f, 0xd0, 0x97, 0xd8, 0x8e, 0x7a, 0x1c, 0xfb, 0x9e, 0x40, 0xb8, 0x4b,
-	0x7a, 0x81, 0xb1, 0x88, 0x63, 0x23, 0xa5, 0x75, 0x76, 0xe6, 0xb1, 0xce, 0xc7, 0xd0, 0xb8, 0x77,
-	0x8a, 0x1c, 0xcf, 0xc6, 0xc1, 0xf3, 0x34, 0xcf, 0x1f, 0x15, 0x61, 0x31, 0xce, 0xf6, 0x0c, 0xde,
-	0x35, 0xeb, 0x90, 0xce, 0xec, 0xe4, 0xff, 0x51, 0x02, 0xb8, 0x6f, 0xd9, 0x98, 0x1b, 0xbb, 0xb6,
-	0x06, 0xb5, 0x01, 0xf1, 0x1d, 0x14, 0x8a, 0xed, 0xc5, 0x88, 0x3a, 0xbe, 0xd0, 0x0a, 0x6d, 0xe9,
-	0xd8, 0xf9, 0x20, 0xcd, 0x71, 0x39, 0xcb, 0xf1, 0x75, 0xa8, 0xf7, 0xb9, 0x67, 0x63, 0x36, 0x9c,
-	0x7a, 0xc7, 0x94, 0x23, 0x09, 0x4f, 0x84, 0x05, 0x6e, 0xd4, 0x51, 0x58, 0x90, 0x11, 0xb0, 0x16,
-	0x8b, 0x80, 0x9b, 0xd4, 0x16, 0x50, 0xdf, 0x24, 0xae, 0x3d, 0xd2, 0xeb, 0x32, 0x8e, 0xa0, 0xfe,
-	0x9e, 0x6b, 0x8f, 0xb2, 0x3a, 0xd3, 0x98, 0x4b, 0x67, 0xae, 0x43, 0x1d, 0xf3, 0x57, 0x2e, 0x0c,
-	0x3c, 0xcb, 0xb6, 0x80, 0x2b, 0xdf, 0x00, 0xcc, 0xf3, 0x06, 0xbe, 0xa8, 0xc1, 0xc6, 0x7d, 0xe2,
-	0x3b, 0x77, 0x51, 0x88, 0xc6, 0x0e, 0xe0, 0x60, 0x78, 0x78, 0x20, 0xd3, 0xa6, 0x48, 0x2c, 0xc5,
-	0x54, 0xb4, 0xe4, 0x91, 0xb5, 0x94, 0x97, 0xab, 0x94, 0xf3, 0xe3, 0x73, 0x25, 0x16, 0xe6, 0x6e,
-	0xc0, 0x32, 0xb2, 0x6d, 0xf2, 0xd4, 0xc4, 0x8e, 0x17, 0x8e, 0x4c, 0x9e, 0x78, 0x55, 0xd9, 0x56,
-	0x4b, 0x0c, 0x70, 0x8f, 0xce, 0x7f, 0x20, 0x93, 0xad, 0xcc, 0x8b, 0x88, 0x74, 0xa6, 0x9e, 0xd0,
-	0x99, 0xff, 0x87, 0xaa, 0x15, 0x62, 0x47, 0xca, 0x7e, 0x33, 0xe1, 0xe9, 0x7c, 0xcb, 0xb1, 0x42,
-	0xeb, 0x84, 0x67, 0x92, 0x81, 0xc1, 0x31, 0xb5, 0xd7, 0x61, 0xb9, 0x47, 0x6c, 0x1b, 0xf7, 0x28,
-	0xb3, 0xa6, 0xa0, 0xda, 0x64, 0x54, 0x3b, 0x11, 0xe0, 0x3e, 0xa7, 0x1f, 0xd3, 0x2d, 0x98, 0xa2,
-	0x5b, 0x3a, 0xd4, 0x1d, 0x74, 0x6a, 0x39, 0x43, 0x87, 0x79, 0xcd, 0xa2, 0x21, 0x87, 0x74, 0x47,
-	0x7c, 0xda, 0xb3, 0x87, 0x81, 0x75, 0x82, 0x4d, 0x89, 0xb3, 0xc8, 0x1e, 0xbe, 0x33, 0x06, 0x7c,
-	0x53, 0x20, 0x53, 0x32, 0x96, 0xcb, 0x50, 0x5a, 0x82, 0x0c, 0x1f, 0xa6, 0xc8, 0x08, 0x9c, 0x76,
-	0x9a, 0x8c, 0x40, 0x7e, 0x01, 0xc0, 0x41, 0xa7, 0xa6, 0x8d, 0xdd, 0xa3, 0xf0, 0x98, 0x79, 0xb3,
-	0xb2, 0xd1, 0x74, 0xd0, 0xe9, 0x43, 0x36, 0xc1, 0xc0, 0x96, 0x2b, 0xc1, 0x1d, 0x01, 0xb6, 0x5c,
-	0x01, 0xd6, 0xa1, 0xee, 0xa1, 0x90, 0x2a, 0xab, 0xbe, 0xcc, 0x83, 0xad, 0x18, 0x52, 0x8b, 0xa0,
-	0x74, 0xb9, 0xd0, 0x35, 0xb6, 0xae, 0xe1, 0xa0, 0x53, 0x26, 0x61, 0x06, 0xb4, 0x5c, 0x01, 0x5c,
-	0x11, 0x40, 0xcb, 0xe5, 0xc0, 0x97, 0x60, 0x71, 0xe8, 0x5a, 0x4f, 0x86, 0x58, 0xc0, 0x57, 0x19,
-	0xe7, 0x0b, 0x7c, 0x8e, 0xa3, 0x5c, 0x85, 0x0a, 0x76, 0x87, 0x8e, 0x7e, 0x21, 0xeb, 0xaa, 0xa9,
-	0xa8, 0x19, 0x50, 0x7b, 0x11, 0x16, 0x9c, 0xa1, 0x1d, 0x5a, 0x9e, 0x8d, 0x4d, 0x32, 0xd0, 0xd7,
-	0x98, 0x90, 0x40, 0x4e, 0xed, 0x0d, 0x94, 0xd6, 0x72, 0x71, 0x2e, 0x6b, 0xa9, 0x42, 0xad, 0x8b,
-	0x51, 0x1f, 0xfb, 0xca, 0xb4, 0x38, 0xd2, 0xc5, 0x92, 0x5a, 0x17, 0xcb, 0x67, 0xd3, 0xc5, 0xca,
-	0x74, 0x5d, 0xac, 0xce, 0xae, 0x8b, 0xb5, 0x19, 0x74, 0xb1, 0x3e, 0x5d, 0x17, 0x1b, 0x33, 0xe8,
-	0x62, 0x73, 0x26, 0x5d, 0x84, 0xc9, 0xba, 0xb8, 0x30, 0x41, 0x17, 0x17, 0x27, 0xe8, 0x62, 0x6b,
-	0x92, 0x2e, 0xb6, 0xa7, 0xe8, 0xe2, 0x52, 0xbe, 0x2e, 0x76, 0xe6, 0xd0, 0xc5, 0xe5, 0x8c, 0x2e,
-	0xa6, 0xbc, 0xa5, 0x36, 0xdb, 0x11, 0x6a, 0x65, 0x1e, 0x6d, 0xfd, 0x5b, 0x15, 0x74, 0xae, 0xad,
-	0xff, 0x16, 0xcf, 0x2e, 0x2d, 0xa4, 0xaa, 0xb4, 0x90, 0x9a, 0xda, 0x42, 0xea, 0x67, 0xb3, 0x90,
-	0xc6, 0x74, 0x0b, 0x69, 0xce, 0x6e, 0x21, 0x30, 0x83, 0x85, 0x2c, 0x4c, 0xb7, 0x90, 0xc5, 0x19,
-	0x2c, 0xa4, 0x35, 0x93, 0x85, 0xb4, 0x27, 0x5b, 0xc8, 0xd2, 0x04, 0x0b, 0xe9, 0x4c, 0xb0, 0x90,
-	0xe5, 0x49, 0x16, 0xa2, 0x4d, 0xb1, 0x90, 0x95, 0x7c, 0x0b, 0x59, 0x9d, 0xc3, 0x42, 0x2e, 0xcc,
-	0xe4, 0xad, 0xd7, 0xe6, 0xd1, 0xff, 0x6f, 0x41, 0x9d, 0xab, 0xff, 0x33, 0x1c, 0x3f, 0xf9, 0xc2,
-	0x9c, 0xe4, 0xf9, 0xf3, 0x12, 0x54, 0xe8, 0x01, 0x32, 0x4a, 0x4c, 0x8b, 0xf1, 0xc4, 0x54, 0x87,
-	0xfa, 0x09, 0xf6, 0x83, 0xa8, 0x32, 0x22, 0x87, 0x33, 0x18, 0xd2, 0x35, 0xe8, 0x84, 0xd8, 0x77,
-	0x02, 0x93, 0x0c, 0xcc, 0x00, 0xfb, 0x27, 0x56, 0x4f, 0x1a, 0x55, 0x9b, 0xcd, 0xef, 0x0d, 0x0e,
-	0xf8, 0xac, 0x76, 0x13, 0xea, 0x3d, 0x5e, 0x3e, 0x10, 0x4e, 0x7f, 0x25, 0xfe, 0x10, 0xa2, 0xb2,
-	0x60, 0x48, 0x1c, 0x8a, 0x6e, 0x5b, 0x3d, 0xec, 0x06, 0x3c, 0x7d, 0x4a, 0xa1, 0x3f, 0xe4, 0x20,
-	0x43, 0xe2, 0x28, 0x85, 0x5f, 0x9f, 0x47, 0xf8, 0x6f, 0x41, 0x93, 0x29, 0x03, 0xab, 0xd5, 0xdd,
-	0x88, 0xd5, 0xea, 0xca, 0x93, 0x0b, 0x2b, 0x5b, 0x77, 0xa1, 0xf5, 0x8d, 0x80, 0xb8, 0x06, 0x1e,
-	0x60, 0x1f, 0xbb, 0x3d, 0xac, 0x2d, 0x43, 0xc5, 0xf4, 0xf1, 0x40, 0xc8, 0xb8, 0x6c, 0xe0, 0xc1,
-	0xf4, 0xfa, 0xd3, 0x96, 0x07, 0x75, 0xf1, 0x4c, 0x33, 0x16, 0x57, 0xce, 0x7c, 0x96, 0xb9, 0x07,
-	0x0d, 0x09, 0x54, 0x6e, 0xf9, 0x8a, 0xac, 0x2a, 0x96, 0xd4, 0x0e, 0x88, 0x43, 0xb7, 0xde, 0x85,
-	0x85, 0x98, 0x02, 0x2a, 0x29, 0x5d, 0x4b, 0x52, 0x4a, 0x08, 0x53, 0xe8, 0xad, 0x20, 0xf6, 0x3e,
-	0xb4, 0x19, 0xb1, 0xa8, 0x88, 0xa6, 0xa2, 0xf7, 0x7a, 0x92, 0xde, 0x05, 0x65, 0x51, 0x40, 0x92,
-	0xdc, 0x83, 0x96, 0x20, 0x19, 0x1e, 0xb3, 0x77, 0xab, 0xa2, 0x78, 0x23, 0x49, 0x71, 0x35, 0x5d,
-	0xcf, 0xa0, 0x0b, 0xd3, 0x04, 0x65, 0xf5, 0x60, 0x6e, 0x82, 0x72, 0xa1, 0x24, 0xf8, 0x11, 0x68,
-	0x09, 0x82, 0xe3, 0xb3, 0x43, 0x86, 0xea, 0xad, 0x24, 0xd5, 0x75, 0x15, 0x55, 0xb6, 0x3a, 0xfd,
-	0x72, 0x44, 0x0c, 0x9d, 0xf7, 0xe5, 0x08, 0x4d, 0x17, 0xc4, 0x1c, 0xb8, 0xc4, 0x89, 0x65, 0x4b,
-	0x13, 0xb9, 0x82, 0x7d, 0x3b, 0x49, 0xfd, 0xea, 0x94, 0xba, 0x47, 0x5c, 0xce, 0x6f, 0x49, 0xde,
-	0x43, 0xdf, 0x72, 0x8f, 0x94, 0xd4, 0x57, 0xe3, 0xd4, 0x9b, 0x72, 0xe1, 0x63, 0xe8, 0xc4, 0x16,
-	0xee, 0xfa, 0x3e, 0x52, 0x2b, 0xf8, 0xcd, 0x24, 0x6f, 0x09, 0x9f, 0x1a, 0x5b, 0x2b, 0xc9, 0xfe,
-	0xa6, 0x0c, 0x9d, 0xf7, 0x88, 0x9b, 0xac, 0xf1, 0x62, 0xd8, 0x3c, 0x66, 0x1a, 0x6c, 0x8e, 0xeb,
-	0x4e, 0x66, 0x30, 0x3c, 0x34, 0x13, 0x95, 0xfe, 0x97, 0xb3, 0x0a, 0x9f, 0x4d, 0x70, 0xba, 0x05,
-	0x43, 0x3f, 0xce, 0x4b, 0x7e, 0x6c, 0xb8, 0x4c, 0x13, 0x06, 0xb3, 0x8f, 0x42, 0xa4, 0xde, 0x89,
-	0x3f, 0xc3, 0xab, 0xf1, 0x9d, 0xf2, 0x8f, 0xc9, 0xdd, 0x82, 0xb1, 0x31, 0xc8, 0x3f, 0x44, 0x1f,
-	0xc2, 0xc6, 0x93, 0x21, 0xf6, 0x47, 0xea, 0x9d, 0xca, 0xd9, 0x37, 0xf9, 0x3e, 0xc5, 0x56, 0x6e,
-	0x73, 0xf1, 0x89, 0x1a, 0xa4, 0x99, 0xb0, 0xee, 0xa1, 0xf0, 0x58, 0xbd, 0x05, 0x2f, 0x7e, 0x6c,
-	0xa5, 0xad, 0x50, 0xb9, 0xc3, 0x9a, 0xa7, 0x84, 0x44, 0x4d, 0x92, 0xcf, 0x4b, 0xa0, 0xef, 0xa1,
-	0x61, 0x78, 0xbc, 0xb3, 0xdb, 0xeb, 0xe1, 0x20, 0xb8, 0x43, 0xfa, 0x78, 0x5a, 0x9f, 0x63, 0x60,
-	0x93, 0xa7, 0xb2, 0x2a, 0x4f, 0xff, 0x6b, 0x6f, 0xd0, 0x80, 0x40, 0x3c, 0x2c, 0x8f, 0x44, 0x89,
-	0xd2, 0x08, 0xa7, 0x7e, 0xc0, 0xe0, 0x86, 0xc0, 0xa3, 0x59, 0x13, 0x9d, 0x26, 0xbe, 0xf5, 0x7d,
-	0xd6, 0x9f, 0x30, 0xa9, 0xff, 0x16, 0x07, 0xa2, 0x04, 0xe0, 0xb1, 0x6f, 0xd3, 0x04, 0x26, 0x24,
-	0x9f, 0x62, 0x8e, 0xc4, 0xf3, 0xcf, 0x06, 0x9b, 0xa0, 0xc0, 0x54, 0xf0, 0xa8, 0xcd, 0x96, 0x79,
-	0xcf, 0x15, 0xfc, 0xfe, 0x52, 0x84, 0x75, 0x21, 0x23, 0xcf, 0xb3, 0x67, 0xe9, 0xa8, 0x3c, 0x1f,
-	0x21, 0x25, 0x9e, 0xbb, 0x32, 0xf9, 0xb9, 0xab, 0xb3, 0x3d, 0xf7, 0x5c, 0x3d, 0x8d, 0x1f, 0x96,
-	0x60, 0x8d, 0x33, 0xf6, 0xc0, 0xa1, 0xcf, 0x6d, 0x85, 0xff, 0x69, 0x9a, 0xf1, 0x2f, 0x10, 0xc2,
-	0x9f, 0x8b, 0x52, 0x08, 0xfb, 0x28, 0x08, 0x9e, 0x12, 0xbf, 0xff, 0x3f, 0xf0, 0xe6, 0x3f, 0x86,
-	0xc5, 0x38, 0x5f, 0xcf, 0xd0, 0xef, 0x61, 0x11, 0x22, 0x27, 0xe1, 0xfe, 0x79, 0x05, 0x9a, 0x7b,
-	0x1e, 0xf6, 0x91, 0x3c, 0x6c, 0xb2, 0xba, 0x7d, 0x91, 0xd5, 0x69, 0x79, 0x99, 0x5e, 0x87, 0x7a,
-	0x30, 0x74, 0x1c, 0xe4, 0x8f, 0x64, 0xce, 0x2d, 0x86, 0x33, 0xe4, 0xdc, 0x99, 0x72, 0x6d, 0x65,
-	0xae, 0x72, 0xed, 0x4b, 0xb0, 0x48, 0x24, 0x6f, 0xa6, 0xd5, 0x97, 0xe2, 0x1d, 0xcf, 0x3d, 0xe8,
-	0x27, 0x7a, 0x3f, 0xb5, 0x54, 0xef, 0x27, 0xde, 0x33, 0xaa, 0xa7, 0x7a, 0x46, 0x5f, 0x49, 0xf4,
-	0x6c, 0x1a, 0x4c, 0x74, 0x1b, 0xca, 0xf4, 0x8c, 0x87, 0xfa, 0x78, 0xb7, 0xe6, 0xcd, 0x78, 0xb7,
-	0xa6, 0x99, 0xcd, 0xec, 0x64, 0x82, 0x93, 0xe8, 0xd1, 0xc4, 0x5a, 0x5b, 0x90, 0x6c, 0x6d, 0x5d,
-	0x06, 0xe8, 0x63, 0xcf, 0xc7, 0x3d, 0x14, 0xe2, 0xbe, 0x38, 0xf5, 0xc6, 0x66, 0xce, 0xd6, 0xdd,
-	0x51, 0xa9, 0x5f, 0x6b, 0x1e, 0xf5, 0xfb, 0x65, 0x11, 0x9a, 0x51, 0x16, 0x71, 0x1b, 0xda, 0x87,
-	0xa4, 0x1f, 0x8b, 0xb7, 0x22, 0x71, 0x48, 0x24, 0x78, 0x89, 0xc4, 0xa3, 0x5b, 0x30, 0x5a, 0x87,
-	0x89, 0x4c, 0xe4, 0x21, 0x68, 0x2e, 0x71, 0xcd, 0x14, 0x1d, 0x9e, 0x16, 0x5c, 0x4a, 0x30, 0x95,
-	0xca, 0x61, 0xba, 0x05, 0xa3, 0xe3, 0xa6, 0xe6, 0xa2, 0xe8, 0x79, 0x04, 0xab, 0xaa, 0x3e, 0x9b,
-	0xb6, 0x37, 0xd9, 0x5e, 0x36, 0x32, 0x62, 0x88, 0x12, 0x73, 0xb5, 0xc9, 0x7c, 0x56, 0x84, 0x76,
-	0x52, 0x3b, 0xb4, 0x2f, 0x41, 0x33, 0x2d, 0x11, 0x75, 0xae, 0xdf, 0x2d, 0x18, 0x11, 0x26, 0x95,
-	0xe6, 0x27, 0x01, 0x71, 0xe9, 0x19, 0x8c, 0x9f, 0xc8, 0x54, 0xe9, 0x72, 0xe2, 0xc8, 0x46, 0xa5,
-	0xf9, 0x49, 0x7c, 0x22, 0x7a, 0xfe, 0xdf, 0x97, 0xa1, 0x31, 0x3e, 0x3a, 0x28, 0x4e, 0x76, 0xaf,
-	0x41, 0xf9, 0x08, 0x87, 0xaa, 0x93, 0xc8, 0xd8, 0xfe, 0x0d, 0x8a, 0x41, 0x11, 0xbd, 0x61, 0x28,
-	0xfc, 0x63, 0x1e, 0xa2, 0x37, 0x0c, 0xb5, 0xeb, 0x50, 0xf1, 0x48, 0x20, 0x3b, 0x40, 0x39, 0x98,
-	0x0c, 0x45, 0xbb, 0x09, 0xb5, 0x3e, 0xb6, 0x71, 0x88, 0xc5, 0x89, 0x3a, 0x07, 0x59, 0x20, 0x69,
-	0xb7, 0xa0, 0x4e, 0x3c, 0xde, 0x86, 0xac, 0x4d, 0xc2, 0x97, 0x58, 0x94, 0x15, 0x9a, 0x92, 0x8a,
-	0x22, 0x57, 0x1e, 0x2b, 0x14, 0x85, 0x9e, 0xc9, 0x3c, 0x14, 0xf6, 0x8e, 0x45, 0xfb, 0x22, 0x07,
-	0x97, 0xe3, 0xa4, 0xdc, 0x44, 0x73, 0x2e, 0x37, 0x71, 0xe6, 0x0e, 0xd2, 0x5f, 0xab, 0xb0, 0xa6,
-	0xce, 0x26, 0xcf, 0x6b, 0x8c, 0xe7, 0x35, 0xc6, 0xff, 0xf6, 0x1a, 0xe3, 0x53, 0xa8, 0xb2, 0x0b,
-	0x1a, 0x4a, 0x4a, 0xc5, 0x39, 0x28, 0x69, 0x37, 0xa1, 0xc2, 0x6e, 0x9b, 0x94, 0xd8, 0xa2, 0x75,
-	0x85, 0xc3, 0x17, 0x75, 0x13, 0x86, 0xb6, 0xf5, 0xb3, 0x2a, 0x2c, 0xa5, 0xb4, 0xf6, 0xbc, 0x27,
-	0x75, 0xde, 0x93, 0x3a, 0x53, 0x4f, 0x4a, 0xa5, 0xc3, 0xda, 0x3c, 0xd6, 0xf0, 0x6d, 0x80, 0x28,
-	0x05, 0x79, 0xce, 0x77, 0xbe, 0x7e, 0x55, 0x83, 0x8b, 0x39, 0x85, 0x91, 0xf3, 0x6b, 0x0a, 0xe7,
-	0xd7, 0x14, 0xce, 0xaf, 0x29, 0x44, 0x66, 0xf8, 0xf7, 0x22, 0x34, 0xc6, 0xe5, 0xf4, 0xe9, 0x17,
-	0xbb, 0xb6, 0xc7, 0xdd, 0x19, 0x9e, 0x76, 0xaf, 0x65, 0x6b, 0xd6, 0x2c, 0xf0, 0xc8, 0xab, 0xaf,
-	0x37, 0xa1, 0xce, 0x2b, 0xab, 0x32, 0x78, 0xac, 0x64, 0x0b, 0xb2, 0x81, 0x21, 0x71, 0xb4, 0x37,
-	0xa0, 0x21, 0xae, 0x2b, 0xc9, 0x93, 0xf5, 0x6a, 0xf2, 0x64, 0xcd, 0x61, 0xc6, 0x18, 0xeb, 0xec,
-	0x77, 0x9a, 0x31, 0xac, 0x28, 0x2e, 0x23, 0x6a, 0xef, 0x4d, 0x76, 0x48, 0xd9, 0x98, 0x3b, 0x6e,
-	0x2d, 0xa8, 0x5d, 0xd2, 0x4f, 0x8a, 0xd0, 0x4a, 0x76, 0x19, 0x76, 0xa8, 0x23, 0xe2, 0x13, 0xe3,
-	0xdb, 0xe3, 0x8a, 0x33, 0x77, 0xb7, 0x60, 0x8c, 0xf1, 0x9e, 0xef, 0xf9, 0xea, 0xa7, 0x45, 0x68,
-	0x8e, 0x4f, 0xf6, 0xda, 0x1d, 0x68, 0xc9, 0x6d, 0xcc, 0x1e, 0xe9, 0x63, 0xf1, 0xa0, 0x97, 0x73,
-	0x1f, 0x94, 0x77, 0x3b, 0x16, 0xe5, 0xa2, 0x3b, 0xa4, 0xaf, 0x6e, 0x05, 0x96, 0xe6, 0x79, 0x1b,
-	0xbf, 0x6e, 0x42, 0x4d, 0x38, 0x6a, 0xc5, 0x89, 0x2f, 0x2f, 0x41, 0x19, 0xf7, 0x56, 0xcb, 0x13,
-	0x2e, 0xfd, 0x55, 0x26, 0x5e, 0xfa, 0x9b, 0x96, 0x78, 0xa4, 0x2c, 0xb1, 0x96, 0xb1, 0xc4, 0x98,
-	0x4b, 0xac, 0xcf, 0xe0, 0x12, 0x1b, 0xd3, 0x5d, 0x62, 0x73, 0x06, 0x97, 0x08, 0x33, 0xb9, 0xc4,
-	0x85, 0xc9, 0x2e, 0x71, 0x71, 0x82, 0x4b, 0x6c, 0x4d, 0x70, 0x89, 0xed, 0x49, 0x2e, 0x71, 0x69,
-	0x8a, 0x4b, 0xec, 0x64, 0x5d, 0xe2, 0x2b, 0xd0, 0xa6, 0xc4, 0x63, 0xc6, 0xc6, 0x4f, 0x02, 0x2d,
-	0x07, 0x9d, 0xc6, 0x72, 0x05, 0x8a, 0x66, 0xb9, 0x71, 0x34, 0x4d, 0xa0, 0x59, 0x6e, 0x0c, 0x2d,
-	0x1e, 0xe8, 0x57, 0x52, 0xd7, 0x34, 0x67, 0x3a, 0x11, 0x7c, 0x94, 0xe7, 0x02, 0x2e, 0x64, 0x5b,
-	0x4b, 0x79, 0x9f, 0x9e, 0xa8, 0xbd, 0x81, 0x76, 0x4d, 0x84, 0xfd, 0xb5, 0xac, 0xdd, 0x3f, 0x1a,
-	0x79, 0x98, 0xe7, 0xee, 0x2c, 0x19, 0x78, 0x5d, 0x06, 0xfd, 0x8b, 0xd9, 0xc3, 0xfd, 0xb8, 0x69,
-	0x2e, 0xc3, 0xfd, 0x75, 0xa8, 0x21, 0xdb, 0xa6, 0xfa, 0xa9, 0xe7, 0xf6, 0xce, 0xab, 0xc8, 0xb6,
-	0xf7, 0x06, 0xda, 0x97, 0x01, 0x62, 0x4f, 0xb4, 0x9e, 0x75, 0xe6, 0x11, 0xb7, 0x46, 0x0c, 0x53,
-	0x7b, 0x19, 0x5a, 0x7d, 0x8b, 0x5a, 0x90, 0x63, 0xb9, 0x28, 0x24, 0xbe, 0xbe, 0xc1, 0x14, 0x24,
-	0x39, 0x99, 0xbc, 0xf2, 0xba, 0x99, 0xba, 0xf2, 0xfa, 0x12, 0x94, 0x4f, 0x1d, 0x5b, 0xbf, 0x94,
-	0xb5, 0xb8, 0x0f, 0x1d, 0xdb, 0xa0, 0xb0, 0x6c, 0x99, 0xf5, 0x85, 0x67, 0xbd, 0x15, 0x7b, 0xf9,
-	0x19, 0x6e, 0xc5, 0xbe, 0x38, 0x8f, 0xc7, 0xfa, 0x01, 0x40, 0x14, 0xf7, 0xe6, 0xfc, 0xd2, 0xe8,
-	0x6d, 0x58, 0x18, 0x58, 0x36, 0x36, 0xf3, 0x43, 0x6a, 0x74, 0xe3, 0xb9, 0x5b, 0x30, 0x60, 0x30,
-	0x1e, 0x45, 0x5e, 0x3c, 0x84, 0x15, 0x45, 0x37, 0x57, 0xfb, 0xee, 0xe4, 0xf8, 0x75, 0x2d, 0x9b,
-	0x50, 0xe7, 0xb4, 0x84, 0xd5, 0xe1, 0xec, 0x4f, 0x15, 0xb8, 0x98, 0xd7, 0x8c, 0x76, 0xe0, 0x85,
-	0x43, 0x14, 0x58, 0x3d, 0x13, 0x25, 0xbe, 0x12, 0x32, 0xc7, 0x35, 0x5f, 0x2e, 0x9a, 0xd7, 0x12,
-	0x15, 0xd6, 0xfc, 0xaf, 0x8a, 0xba, 0x05, 0x63, 0xf3, 0x70, 0xc2, 0x47, 0x47, 0xf7, 0xa1, 0x83,
-	0x3c, 0xcb, 0xfc, 0x14, 0x8f, 0xa2, 0x1d, 0xb8, 0x24, 0x13, 0x75, 0xad, 0xe4, 0x57, 0x56, 0xdd,
-	0x82, 0xd1, 0x46, 0xc9, 0xef, 0xae, 0xbe, 0x07, 0x3a, 0x61, 0x6d, 0x09, 0xd3, 0x12, 0x0d, 0xa9,
-	0x88, 0x5e, 0x39, 0xdb, 0x15, 0x55, 0xf7, 0xae, 0xba, 0x05, 0x63, 0x8d, 0xa8, 0xbb, 0x5a, 0x11,
-	0x7d, 0x4f, 0xf4, 0x7a, 0x22, 0xfa, 0x95, 0x3c, 0xfa, 0xe9, 0xb6, 0x50, 0x44, 0x3f, 0xd3, 0x30,
-	0x3a, 0x82, 0x4d, 0x41, 0x1f, 0x45, 0x8d, 0xc4, 0x68, 0x0b, 0x1e, 0xe0, 0x5e, 0xc9, 0x6e, 0xa1,
-	0x68, 0x3b, 0x76, 0x0b, 0xc6, 0x3a, 0xc9, 0xed, 0x49, 0xe2, 0x68, 0x23, 0xd6, 0xd5, 0x65, 0xe9,
-	0x42, 0xb4, 0x51, 0x2d, 0xeb, 0x1d, 0xf3, 0x7a, 0xc0, 0xdd, 0x82, 0x21, 0x64, 0x92, 0x85, 0x45,
-	0x1a, 0x7e, 0x1c, 0x69, 0x78, 0xac, 0x25, 0xa0, 0xbd, 0x3f, 0x59, 0xc3, 0x2f, 0xe5, 0xb4, 0x8d,
-	0xf8, 0xc5, 0x02, 0xb5, 0x56, 0x5f, 0x85, 0x85, 0xf8, 0xcd, 0x85, 0xd5, 0xe8, 0xe3, 0xbe, 0x72,
-	0x74, 0xc7, 0xe1, 0xb7, 0x45, 0x28, 0x3f, 0x42, 0xea, 0x5b, 0x11, 0xd3, 0x3f, 0x76, 0xcb, 0x78,
-	0xb6, 0xf2, 0x99, 0xbf, 0x11, 0x99, 0xeb, 0x0b, 0xae, 0x2b, 0xd0, 0x90, 0x11, 0x26, 0xe7, 0xf9,
-	0x3e, 0x86, 0xa5, 0x0f, 0x52, 0xf5, 0xa6, 0xe7, 0xf8, 0x31, 0xc9, 0xef, 0x8a, 0x50, 0xfe, 0xd0,
-	0xb1, 0x95, 0xd2, 0xbb, 0x04, 0x4d, 0xfa, 0x1b, 0x78, 0xa8, 0x27, 0xef, 0x95, 0x44, 0x13, 0x34,
-	0xf9, 0xf3, 0x7c, 0x3c, 0xb0, 0x4e, 0x45, 0x96, 0x27, 0x46, 0x74, 0x15, 0x0a, 0x43, 0xdf, 0x3a,
-	0x1c, 0x86, 0x58, 0x7c, 0xa6, 0x17, 0x4d, 0xd0, 0x54, 0xe6, 0xa9, 0x8f, 0x3c, 0x0f, 0xf7, 0xc5,
-	0x11, 0x5c, 0x0e, 0xcf, 0xdc, 0xc7, 0xbc, 0xfd, 0x2a, 0xb4, 0x89, 0x7f, 0x24, 0x71, 0xcd, 0x93,
-	0x9d, 0xdb, 0x8b, 0xe2, 0xdb, 0xd5, 0x7d, 0x9f, 0x84, 0x64, 0xbf, 0xf8, 0x8b, 0x52, 0x79, 0x6f,
-	0xf7, 0xe0, 0xb0, 0xc6, 0x3e, 0x06, 0x7d, 0xf3, 0x9f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xe2, 0x4b,
-	0xfc, 0xd7, 0xda, 0x3a, 0x00, 0x00,
+	// 3199 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3c, 0x4d, 0x73, 0x1c, 0xb7,
+	0xb1, 0xdc, 0xef, 0xdd, 0x5e, 0xee, 0x72, 0x09, 0x52, 0xd4, 0x88, 0x94, 0x65, 0x9a, 0xf2, 0x87,
+	0x6c, 0x3f, 0x51, 0x7e, 0xb4, 0x9f, 0xfd, 0xec, 0x57, 0xaa, 0x17, 0xd2, 0x92, 0x42, 0xc6, 0x8a,
+	0x49, 0x8f, 0x64, 0xc7, 0x4e, 0x9c, 0x4c, 0x81, 0xbb, 0x58, 0x72, 0xec, 0xf9, 0xd2, 0xcc, 0x2c,
+	0xc5, 0xcd, 0x21, 0x95, 0x72, 0x2a, 0x39, 0x27, 0x55, 0xbe, 0xe5, 0xe4, 0x1c, 0x92, 0x43, 0xae,
+	0xc9, 0x21, 0xf1, 0x6f, 0x48, 0xe5, 0x6f, 0x24, 0xd7, 0x54, 0xe5, 0x92, 0x43, 0x2a, 0x05, 0x60,
+	0x30, 0x1f, 0x3b, 0x98, 0xe5, 0x0e, 0x47, 0x4e, 0xb9, 0x2a, 0xbc, 0x48, 0x3b, 0xe8, 0x46, 0xa3,
+	0xd1, 0x40, 0x7f, 0xa0, 0x1b, 0x20, 0x2c, 0xec, 0x3b, 0xc4, 0xda, 0x3e, 0xd8, 0x3b, 0xd9, 0xda,
+	0x74, 0x5c, 0xdb, 0xb7, 0x11, 0xd8, 0x0e, 0xb1, 0xb0, 0xa3, 0x6f, 0x9e, 0x6c, 0xad, 0x5e, 0x39,
+	0xb2, 0xed, 0x23, 0x83, 0xdc, 0x62, 0x90, 0xc3, 0xd1, 0xf0, 0x16, 0xb6, 0xc6, 0x1c, 0x6d, 0xf5,
+	0xda, 0x24, 0xe8, 0xb1, 0x8b, 0x1d, 0x87, 0xb8, 0x1e, 0x87, 0x6f, 0x98, 0xa0, 0x6c, 0x0f, 0x06,
+	0xba, 0xaf, 0xdb, 0x16, 0x36, 0x0e, 0x5c, 0xdb, 0x21, 0xae, 0xaf, 0x13, 0x6f, 0xcf, 0x27, 0x26,
+	0xfa, 0x2f, 0xa8, 0x7b, 0xfd, 0x63, 0x62, 0x62, 0xa5, 0xb4, 0x5e, 0xba, 0xd1, 0xde, 0x42, 0x9b,
+	0xd1, 0x98, 0x9b, 0x0f, 0x18, 0x64, 0x77, 0x4e, 0x0d, 0x70, 0xd0, 0x2a, 0x34, 0x0e, 0x6d, 0xdb,
+	0x20, 0xd8, 0x52, 0xca, 0xeb, 0xa5, 0x1b, 0xcd, 0xdd, 0x39, 0x55, 0x34, 0xec, 0x34, 0xa0, 0x66,
+	0x5b, 0xc4, 0x1e, 0x6e, 0xdc, 0x85, 0xca, 0xb6, 0x35, 0x46, 0x2f, 0x41, 0xed, 0x04, 0x1b, 0x23,
+	0x12, 0x10, 0x5e, 0xde, 0xe4, 0x5c, 0x6e, 0x0a, 0x2e, 0x37, 0xb7, 0xad, 0xb1, 0xca, 0x51, 0x10,
+	0x82, 0xea, 0x18, 0x9b, 0x06, 0x23, 0xda, 0x52, 0xd9, 0xef, 0x8d, 0xdf, 0x96, 0xa0, 0xbb, 0xed,
+	0xe8, 0xef, 0x90, 0xf1, 0x03, 0xd2, 0x1f, 0xb9, 0xba, 0x3f, 0xa6, 0x68, 0xfe, 0xd8, 0xe1, 0x14,
+	0x5b, 0x2a, 0xfb, 0x4d, 0xdb, 0x2c, 0x6c, 0x12, 0xd1, 0x95, 0xfe, 0x46, 0x5d, 0x28, 0xeb, 0x96,
+	0x52, 0x61, 0x2d, 0x65, 0xdd, 0x42, 0xeb, 0xd0, 0x1e, 0x10, 0xaf, 0xef, 0xea, 0x0e, 0x95, 0x81,
+	0x52, 0x65, 0x80, 0x78, 0x13, 0xfa, 0x7f, 0xe8, 0x9d, 0x10, 0x6b, 0x60, 0xbb, 0x1a, 0x39, 0xf5,
+	0x89, 0xe5, 0x51, 0xb4, 0xda, 0x7a, 0x85, 0xf1, 0x1d, 0x13, 0xc8, 0xbb, 0xd8, 0x24, 0x03, 0xca,
+	0xf7, 0x02, 0xc7, 0xbe, 0x2b, 0x90, 0x37, 0x3e, 0x2f, 0xc1, 0xda, 0x0e, 0xf6, 0xf4, 0xfe, 0xf6,
+	0xc8, 0x3f, 0x26, 0x96, 0xaf, 0xf7, 0x31, 0x25, 0x3c, 0x95, 0xf5, 0x09, 0xb6, 0xca, 0xb3, 0xb1,
+	0x55, 0xc9, 0xc3, 0xd6, 0x3f, 0x4a, 0xd0, 0xd9, 0xb1, 0x07, 0xe3, 0x03, 0xec, 0x62, 0x93, 0xf8,
+	0xc4, 0x9d, 0x1c, 0xb4, 0x94, 0x1e, 0x74, 0x16, 0x89, 0xbe, 0x0e, 0x4d, 0x97, 0x3c, 0x1a, 0xe9,
+	0x2e, 0x19, 0x30, 0x71, 0xb6, 0xb7, 0x56, 0x53, 0xeb, 0xbb, 0x63, 0xdb, 0xc6, 0x07, 0x74, 0x79,
+	0xd5, 0x10, 0x17, 0xbd, 0x14, 0x6e, 0xb7, 0x5a, 0xd6, 0x76, 0x0b, 0x37, 0x9b, 0x6c, 0xf2, 0xf5,
+	0x3c, 0x93, 0xff, 0x59, 0x09, 0x1a, 0x6f, 0xdb, 0x96, 0x8f, 0xfb, 0x7e, 0x38, 0xa9, 0x52, 0x6c,
+	0x52, 0x3d, 0xa8, 0x8c, 0x5c, 0xb1, 0xe9, 0xe8, 0x4f, 0xb4, 0x0c, 0x35, 0x62, 0x62, 0xdd, 0x08,
+	0x66, 0xca, 0x3f, 0xa4, 0x8c, 0x54, 0xf3, 0x30, 0xf2, 0x10, 0x1a, 0x77, 0xc8, 0x10, 0x8f, 0x0c,
+	0x1f, 0xed, 0xc1, 0x25, 0x1c, 0xea, 0xa2, 0xe6, 0x84, 0xca, 0xa8, 0x94, 0xa6, 0x10, 0x5c, 0xc6,
+	0x12, 0xf5, 0xdd, 0xf8, 0x1e, 0xb4, 0xef, 0x90, 0xa1, 0x6e, 0x31, 0x88, 0x87, 0xee, 0x4f, 0xa7,
+	0x7c, 0x39, 0x45, 0x39, 0x10, 0xb7, 0x9c, 0xf8, 0x5f, 0x6a, 0xd0, 0xbc, 0x63, 0xf7, 0x47, 0x26,
+	0xb1, 0x7c, 0xa4, 0x40, 0xc3, 0x7b, 0x8c, 0x8f, 0x8e, 0x88, 0x1b, 0xc8, 0x4f, 0x7c, 0xa2, 0x67,
+	0xa1, 0xaa, 0x5b, 0x43, 0x9b, 0xc9, 0xb0, 0xbd, 0xd5, 0x8b, 0x8f, 0xb1, 0x67, 0x0d, 0x6d, 0x95,
+	0x41, 0xa9, 0xf0, 0x8f, 0x6d, 0xcf, 0x0f, 0xa4, 0xca, 0x7e, 0xa3, 0x35, 0x68, 0x1d, 0x62, 0x8f,
+	0x68, 0x0e, 0xf6, 0x8f, 0x03, 0x8d, 0x6c, 0xd2, 0x86, 0x03, 0xec, 0x1f, 0xb3, 0x01, 0x29, 0x77,
+	0xc4, 0x63, 0x5a, 0xd8, 0x52, 0xc5, 0x27, 0x5a, 0x85, 0x66, 0xdf, 0xb6, 0xbc, 0x11, 0x05, 0xd5,
+	0x19, 0x28, 0xfc, 0xa6, 0x30, 0xc7, 0xb5, 0x07, 0xa3, 0x3e, 0xf1, 0x94, 0x06, 0x87, 0x89, 0x6f,
+	0xf4, 0x02, 0xd4, 0xe8, 0x48, 0x9e, 0xd2, 0x64, 0x9c, 0x2e, 0xc6, 0x39, 0xa5, 0x43, 0x7a, 0x2a,
+	0x87, 0xa3, 0x37, 0xa9, 0x7e, 0x84, 0x52, 0x55, 0x5a, 0xeb, 0xa5, 0x49, 0xe1, 0xc5, 0x84, 0xae,
+	0xc6, 0x71, 0xd1, 0x37, 0x00, 0x1c, 0xa1, 0x67, 0x9e, 0x02, 0xac, 0xe7, 0x7a, 0x72, 0xa0, 0x00,
+	0x1a, 0x27, 0x11, 0xeb, 0x83, 0x6e, 0x43, 0xcb, 0x25, 0x9e, 0x63, 0x5b, 0x1e, 0xf1, 0x94, 0x36,
+	0x23, 0xf0, 0x74, 0x9c, 0x80, 0x1a, 0x00, 0xe3, 0xfd, 0xa3, 0x1e, 0xe8, 0xff, 0xa0, 0xe9, 0x05,
+	0x06, 0x47, 0x99, 0x5f, 0xaf, 0x4c, 0xf6, 0x16, 0xc6, 0x48, 0xe5, 0xda, 0x48, 0x97, 0x56, 0x0d,
+	0x3b, 0x20, 0x15, 0x96, 0xc5, 0x6f, 0x2d, 0x2e, 0x81, 0xce, 0x7a, 0x29, 0x8b, 0x50, 0x9c, 0x8d,
+	0x25, 0x2f, 0xdd, 0x88, 0xae, 0x43, 0xd5, 0xc7, 0x47, 0x9e, 0xd2, 0x65, 0xcc, 0x2c, 0xc4, 0x69,
+	0x3c, 0xc4, 0x47, 0x2a, 0x03, 0xa2, 0xdb, 0xd0, 0xa1, 0x7a, 0xe5, 0xd2, 0x6d, 0x3b, 0xb0, 0xfb,
+	0x9e, 0xb2, 0xc0, 0x46, 0x54, 0xe2, 0xd8, 0x77, 0x03, 0x84, 0x3b, 0x76, 0xdf, 0x53, 0xe7, 0x49,
+	0xec, 0x4b, 0xaa, 0x9d, 0xbd, 0x3c, 0xda, 0xf9, 0x3e, 0x34, 0xef, 0x9e, 0x62, 0xd3, 0x31, 0x88,
+	0xf7, 0x24, 0xd5, 0xf3, 0x27, 0x25, 0x98, 0x8f, 0xb3, 0x3d, 0x83, 0xe5, 0x4d, 0x1b, 0xa4, 0xc2,
+	0x0e, 0xe0, 0x97, 0x15, 0x80, 0x7b, 0xba, 0x41, 0xb8, 0xb2, 0xa3, 0x15, 0xa8, 0x0f, 0x6d, 0xd7,
+	0xc4, 0x7e, 0x30, 0x7c, 0xf0, 0x45, 0x0d, 0x9f, 0xaf, 0xfb, 0x86, 0x30, 0xfa, 0xfc, 0x63, 0x92,
+	0xe3, 0x4a, 0x9a, 0xe3, 0x17, 0xa1, 0x31, 0xe0, 0x96, 0x2d, 0x70, 0x03, 0x89, 0x35, 0xa6, 0x1c,
+	0x09, 0x38, 0xd5, 0xce, 0xd0, 0x65, 0x70, 0xa5, 0x0e, 0xbf, 0x43, 0xef, 0x58, 0x8f, 0x79, 0xc7,
+	0x37, 0xa8, 0x2e, 0xe0, 0x81, 0x66, 0x5b, 0xc6, 0x58, 0x69, 0xcc, 0xe2, 0x63, 0xf0, 0x60, 0xdf,
+	0x32, 0xc6, 0xe9, 0xfd, 0xd4, 0xcc, 0xb5, 0x9f, 0x5e, 0x84, 0x06, 0xe1, 0xdb, 0x41, 0x69, 0x65,
+	0x4c, 0x29, 0x80, 0x4b, 0x57, 0x07, 0xf2, 0xac, 0xce, 0x4f, 0x5b, 0xb0, 0x7a, 0xcf, 0x76, 0xcd,
+	0x3b, 0xd8, 0xc7, 0xa1, 0x71, 0x78, 0x30, 0x3a, 0x0c, 0x56, 0x2b, 0xee, 0x65, 0x4b, 0x39, 0xbc,
+	0x2c, 0xf7, 0xd6, 0xe5, 0xac, 0xf8, 0xa7, 0x92, 0xed, 0xf3, 0xab, 0x31, 0xf7, 0x78, 0x0f, 0x16,
+	0xb1, 0x61, 0xd8, 0x8f, 0x35, 0x62, 0x3a, 0xfe, 0x58, 0xe3, 0xc1, 0x5c, 0xed, 0x4c, 0x36, 0x16,
+	0x58, 0xa7, 0xbb, 0xb4, 0xcf, 0x07, 0x22, 0xb8, 0x4b, 0x2d, 0x6e, 0xb4, 0x0f, 0x1b, 0x89, 0x7d,
+	0xf8, 0xdf, 0x50, 0xd3, 0x7d, 0x62, 0x8a, 0x35, 0x5b, 0x4b, 0x58, 0x4f, 0x57, 0x37, 0x75, 0x5f,
+	0x3f, 0xe1, 0x91, 0xab, 0xa7, 0x72, 0x4c, 0xf4, 0x32, 0x2c, 0xf6, 0x6d, 0xc3, 0x20, 0x7d, 0x3a,
+	0x11, 0x2d, 0xa0, 0xda, 0x62, 0x54, 0x7b, 0x11, 0xe0, 0x1e, 0xa7, 0x1f, 0xdb, 0xaf, 0x70, 0xc6,
+	0x7e, 0x7d, 0x1d, 0x1a, 0x26, 0x3e, 0xd5, 0xcd, 0x91, 0x19, 0x58, 0xe2, 0xab, 0xa9, 0x49, 0xdf,
+	0xb1, 0x47, 0x87, 0x06, 0xe1, 0xd3, 0x16, 0xc8, 0xe8, 0x9b, 0xb0, 0x48, 0x4e, 0xfb, 0xc6, 0xc8,
+	0xd3, 0x4f, 0x88, 0x26, 0x28, 0xcc, 0x9f, 0x29, 0xb6, 0x5e, 0xd8, 0xe9, 0xdb, 0x01, 0x21, 0xca,
+	0x80, 0x6e, 0xb1, 0xee, 0x9d, 0x99, 0x18, 0xd0, 0x2d, 0x09, 0x03, 0x01, 0x85, 0x6e, 0x1e, 0x06,
+	0x02, 0x42, 0x6f, 0x01, 0x98, 0xf8, 0x54, 0x33, 0x88, 0x75, 0xe4, 0x1f, 0x07, 0x56, 0x79, 0x2d,
+	0x45, 0x61, 0xcf, 0xf2, 0x5f, 0x7f, 0x8d, 0x93, 0x68, 0x99, 0xf8, 0xf4, 0x3e, 0xc3, 0x66, 0x7d,
+	0x75, 0x4b, 0xf4, 0xed, 0xcd, 0xd2, 0x57, 0xb7, 0x82, 0xbe, 0x0a, 0x34, 0x1c, 0xec, 0x53, 0x95,
+	0x54, 0x16, 0x79, 0xb8, 0x11, 0x7c, 0xa2, 0xff, 0x05, 0x3a, 0x84, 0xc6, 0xb7, 0x08, 0x3a, 0x9b,
+	0x68, 0xd3, 0xc4, 0xa7, 0x6c, 0xb3, 0xb0, 0x9e, 0xba, 0x15, 0xf4, 0x5c, 0x9a, 0xa5, 0xa7, 0x6e,
+	0xf1, 0x9e, 0xb7, 0x61, 0x7e, 0x64, 0xe9, 0x8f, 0x46, 0x24, 0xe8, 0xbc, 0x7c, 0xa6, 0x24, 0xdb,
+	0x1c, 0x9f, 0x77, 0xbf, 0x0e, 0x55, 0x62, 0x8d, 0x4c, 0xe5, 0xd2, 0x7a, 0x45, 0xb6, 0xdd, 0x18,
+	0x10, 0xdd, 0x86, 0xb6, 0x39, 0x32, 0x7c, 0xdd, 0x31, 0x88, 0x66, 0x0f, 0x95, 0x95, 0x19, 0x96,
+	0x1b, 0x44, 0x87, 0xfd, 0xa1, 0xd4, 0x0e, 0x5d, 0xce, 0x63, 0x87, 0xfe, 0xd4, 0x80, 0xfa, 0x2e,
+	0xc1, 0x03, 0xe2, 0x4a, 0x0f, 0x2a, 0x91, 0xb6, 0x96, 0xe5, 0xda, 0x5a, 0x29, 0xa6, 0xad, 0xd5,
+	0xb3, 0xb5, 0xb5, 0x36, 0xbb, 0xb6, 0xd6, 0x0b, 0x6b, 0x6b, 0xa3, 0x98, 0xb6, 0x36, 0x0b, 0x6b,
+	0x6b, 0xab, 0xb0, 0xb6, 0x42, 0x01, 0x6d, 0x6d, 0x9f, 0x57, 0x5b, 0xe7, 0xa7, 0x68, 0x6b, 0xe7,
+	0xdc, 0xda, 0xda, 0x2d, 0xa2, 0xad, 0x0b, 0xe7, 0xd3, 0xd6, 0x5e, 0x0e, 0x6d, 0x5d, 0xcc, 0xa9,
+	0xad, 0x13, 0xde, 0x18, 0xcd, 0x76, 0xec, 0x5f, 0xca, 0xa3, 0xcf, 0x5f, 0x36, 0x41, 0xe1, 0xfa,
+	0xfc, 0xb5, 0x8b, 0x2a, 0x84, 0x7d, 0xa9, 0x49, 0xed, 0x4b, 0x5d, 0x6e, 0x5f, 0x1a, 0xc5, 0xec,
+	0x4b, 0xf3, 0x6c, 0xfb, 0xd2, 0x9a, 0xdd, 0xbe, 0x40, 0x61, 0xfb, 0xd2, 0x2e, 0x66, 0x5f, 0xe6,
+	0x0b, 0xdb, 0x97, 0x4e, 0x61, 0xfb, 0xd2, 0x2d, 0x60, 0x5f, 0x16, 0xce, 0x6b, 0x5f, 0x7a, 0x53,
+	0xec, 0xcb, 0xe2, 0xb9, 0xed, 0x0b, 0x2a, 0x62, 0x5f, 0x96, 0xce, 0x67, 0x5f, 0x96, 0x73, 0xd8,
+	0x97, 0x4b, 0x4f, 0x20, 0x1a, 0x58, 0xc9, 0x63, 0x3d, 0xbe, 0x03, 0x0d, 0x6e, 0x3c, 0xce, 0x91,
+	0x54, 0xe2, 0x1d, 0x33, 0x8e, 0xc4, 0x5f, 0x94, 0xa1, 0x4a, 0xd3, 0x42, 0xd1, 0x71, 0xb3, 0x14,
+	0x3f, 0x6e, 0x2a, 0xd0, 0x38, 0x21, 0xae, 0x17, 0xe5, 0x42, 0xc5, 0xe7, 0x0c, 0xa6, 0xe6, 0x06,
+	0xf4, 0x7c, 0xe2, 0x9a, 0x9e, 0x66, 0x0f, 0x35, 0x8f, 0xb8, 0x27, 0x7a, 0x5f, 0x98, 0x9d, 0x2e,
+	0x6b, 0xdf, 0x1f, 0x3e, 0xe0, 0xad, 0xe8, 0x26, 0x34, 0xfa, 0x3c, 0x29, 0x18, 0x04, 0x15, 0x4b,
+	0xf1, 0x49, 0x04, 0xf9, 0x42, 0x55, 0xe0, 0x50, 0x74, 0x43, 0xef, 0x13, 0xcb, 0x23, 0x4a, 0x3d,
+	0x8d, 0x7e, 0x9f, 0x83, 0x54, 0x81, 0x23, 0x15, 0x7e, 0x23, 0x8f, 0xf0, 0xdf, 0x80, 0x16, 0xdb,
+	0x2a, 0xf4, 0x9f, 0x58, 0xba, 0x94, 0xcb, 0x7b, 0x4a, 0xba, 0x74, 0xe3, 0x0e, 0x74, 0xbe, 0xe5,
+	0xd9, 0x96, 0x4a, 0x86, 0xc4, 0x25, 0x56, 0x9f, 0xa0, 0x45, 0xa8, 0x6a, 0x2e, 0x19, 0x06, 0x32,
+	0xae, 0xa8, 0x64, 0x78, 0x76, 0xc6, 0x79, 0xc3, 0x81, 0x46, 0x30, 0xa7, 0x19, 0x53, 0xa6, 0x85,
+	0x33, 0x14, 0x77, 0xa1, 0x29, 0x80, 0xd2, 0x21, 0x9f, 0x13, 0x75, 0x84, 0xb2, 0xdc, 0x44, 0x73,
+	0xe8, 0xc6, 0x3b, 0xd0, 0x8e, 0x6d, 0x40, 0x29, 0xa5, 0x1b, 0x49, 0x4a, 0x09, 0x61, 0x06, 0xfb,
+	0x36, 0x20, 0xf6, 0x1e, 0x74, 0x19, 0xb1, 0x28, 0x6d, 0x2e, 0xa3, 0xf7, 0x72, 0x92, 0xde, 0x25,
+	0x69, 0xaa, 0x4f, 0x90, 0xdc, 0x87, 0x4e, 0x40, 0xd2, 0x3f, 0x66, 0x6b, 0x2b, 0xa3, 0xf8, 0x52,
+	0x92, 0xe2, 0xf2, 0x64, 0x96, 0x92, 0x76, 0x9c, 0x24, 0x28, 0x72, 0x82, 0xb9, 0x09, 0x8a, 0x8e,
+	0x82, 0xe0, 0x47, 0x80, 0x12, 0x04, 0xc3, 0xd3, 0x7b, 0x8a, 0xea, 0xad, 0x24, 0xd5, 0x2b, 0x32,
+	0xaa, 0xdc, 0x2e, 0x4d, 0x2c, 0x4e, 0x10, 0x81, 0xe4, 0x5d, 0x9c, 0x60, 0xa7, 0x07, 0xc4, 0x4c,
+	0xb8, 0xca, 0x89, 0xa5, 0x13, 0x8e, 0x99, 0x82, 0x7d, 0x33, 0x49, 0xfd, 0xfa, 0x19, 0xd9, 0xcc,
+	0xb8, 0x9c, 0xdf, 0x10, 0xbc, 0xfb, 0xae, 0x6e, 0x1d, 0x49, 0xa9, 0x2f, 0xc7, 0xa9, 0xb7, 0x44,
+	0xc7, 0xf7, 0xa1, 0x17, 0xeb, 0xb8, 0xed, 0xba, 0x58, 0xbe, 0xc1, 0x6f, 0x26, 0x79, 0x4b, 0xd8,
+	0xd4, 0x58, 0x5f, 0x41, 0xf6, 0xf7, 0x15, 0xe8, 0xbd, 0x6b, 0x5b, 0xc9, 0xaa, 0x0e, 0x81, 0xb5,
+	0x63, 0xb6, 0x83, 0xb5, 0x30, 0x9b, 0xac, 0x79, 0xa3, 0x43, 0x2d, 0x51, 0xdb, 0x7b, 0x36, 0xbd,
+	0xe1, 0xd3, 0xe1, 0xe1, 0xee, 0x9c, 0xaa, 0x1c, 0x67, 0xc0, 0x90, 0x01, 0xd7, 0x68, 0x48, 0xa5,
+	0x0d, 0xb0, 0x8f, 0xe5, 0x23, 0xf1, 0x39, 0x3c, 0x1f, 0x1f, 0x29, 0x3b, 0xc1, 0xb5, 0x3b, 0xa7,
+	0xae, 0x0e, 0x33, 0xa1, 0xe8, 0x10, 0x56, 0x1f, 0x8d, 0x88, 0x3b, 0x96, 0x8f, 0x54, 0x49, 0xaf,
+	0xe4, 0x7b, 0x14, 0x5b, 0x3a, 0xcc, 0xe5, 0x47, 0x72, 0x10, 0xd2, 0xe0, 0x0a, 0xcd, 0xfb, 0xcb,
+	0x87, 0xe0, 0x29, 0xcd, 0x8d, 0x49, 0x2d, 0x94, 0x8e, 0xb0, 0xe2, 0x48, 0x21, 0x51, 0x59, 0xf4,
+	0x8b, 0x32, 0x28, 0xfb, 0x78, 0xe4, 0x1f, 0x6f, 0x6d, 0xf7, 0xfb, 0xc4, 0xf3, 0xde, 0xb6, 0x07,
+	0xe4, 0xac, 0xca, 0xe6, 0xd0, 0xb0, 0x1f, 0x8b, 0x3a, 0x1c, 0xfd, 0x8d, 0x5e, 0xa1, 0x0e, 0xc1,
+	0x76, 0x88, 0x38, 0x72, 0x27, 0x92, 0x9a, 0x9c, 0xfa, 0x03, 0x06, 0x57, 0x03, 0x3c, 0x1a, 0x10,
+	0xd3, 0x66, 0xdb, 0xd5, 0x7f, 0xc8, 0x2a, 0x92, 0x1a, 0xb5, 0xdf, 0xc1, 0x81, 0x3b, 0x01, 0x78,
+	0xdf, 0x35, 0x68, 0x51, 0xc6, 0xb7, 0x3f, 0x25, 0x1c, 0x89, 0x47, 0xe8, 0x4d, 0xd6, 0x40, 0x81,
+	0x13, 0xce, 0xa3, 0x3e, 0xdb, 0xb9, 0x25, 0x97, 0xf3, 0xfb, 0x7b, 0x09, 0xae, 0x04, 0x32, 0x72,
+	0x1c, 0x63, 0x96, 0x1a, 0xea, 0x93, 0x11, 0x52, 0x62, 0xde, 0xd5, 0xe9, 0xf3, 0xae, 0xcd, 0x36,
+	0xef, 0x5c, 0x95, 0xca, 0xcf, 0xca, 0xb0, 0xc2, 0x19, 0xdb, 0x33, 0xe9, 0xbc, 0x75, 0xff, 0xeb,
+	0xb6, 0x33, 0xfe, 0x0d, 0x42, 0xf8, 0x5b, 0x49, 0x08, 0xe1, 0x00, 0x7b, 0xde, 0x63, 0xdb, 0x1d,
+	0xfc, 0x07, 0xac, 0xfc, 0xc7, 0x30, 0x1f, 0xe7, 0xeb, 0x1c, 0x55, 0x5c, 0xe6, 0x21, 0x32, 0x02,
+	0xee, 0x3f, 0x56, 0xa1, 0xb5, 0xef, 0x10, 0x17, 0x8b, 0xe3, 0x38, 0xab, 0xc6, 0x95, 0x58, 0xf5,
+	0x85, 0xfd, 0x66, 0x95, 0xd6, 0x91, 0x69, 0x62, 0x77, 0x2c, 0x62, 0xee, 0xe0, 0x73, 0x86, 0x98,
+	0x3b, 0x55, 0x68, 0xa9, 0xe6, 0x2a, 0xb4, 0x3c, 0x03, 0xf3, 0xb6, 0xe0, 0x4d, 0xd3, 0x07, 0x42,
+	0xbc, 0x61, 0xdb, 0xde, 0x20, 0x51, 0xd1, 0xad, 0x4f, 0x54, 0x74, 0xe3, 0x95, 0xe0, 0xc6, 0x44,
+	0x25, 0xf8, 0xad, 0x44, 0x25, 0xb6, 0xc9, 0x44, 0xb7, 0x2a, 0x0d, 0xcf, 0xb8, 0xab, 0x8f, 0x61,
+	0xa3, 0x57, 0xe3, 0x35, 0xd8, 0x56, 0x3a, 0xb2, 0x13, 0x01, 0x4e, 0xa2, 0xf2, 0x1a, 0x2b, 0x58,
+	0x43, 0xb2, 0x60, 0xfd, 0x16, 0xc0, 0x80, 0x38, 0x2e, 0xe9, 0x63, 0x9f, 0x0c, 0x66, 0x38, 0xf9,
+	0xc7, 0xb0, 0x8b, 0xd5, 0x73, 0x65, 0x5b, 0xb3, 0x93, 0x67, 0x6b, 0xfe, 0xba, 0x04, 0xad, 0x28,
+	0xc2, 0xd8, 0x81, 0xee, 0xa1, 0x3d, 0x88, 0xf9, 0x62, 0xa5, 0x94, 0x0e, 0xfe, 0x12, 0x41, 0xc9,
+	0xee, 0x9c, 0xda, 0x39, 0x8c, 0x37, 0xa0, 0xfb, 0x80, 0x2c, 0xdb, 0xd2, 0x26, 0xe8, 0x94, 0x83,
+	0xf3, 0x6d, 0x9c, 0xa9, 0x89, 0xf8, 0x66, 0x77, 0x4e, 0xed, 0x59, 0x13, 0x6d, 0x91, 0x67, 0x3d,
+	0x82, 0x65, 0x59, 0x65, 0x1d, 0xed, 0x4f, 0xd7, 0xa5, 0xd5, 0x94, 0x18, 0xa2, 0xa0, 0x5d, 0xae,
+	0x4e, 0x9f, 0x97, 0xa0, 0x9b, 0xdc, 0x39, 0xe8, 0x7f, 0xa0, 0x35, 0x29, 0x11, 0xf9, 0x39, 0x60,
+	0x77, 0x4e, 0x6d, 0x39, 0x71, 0x69, 0x7e, 0xe2, 0xd9, 0x96, 0xe6, 0x8a, 0xd3, 0x9a, 0x2c, 0x94,
+	0x4e, 0x1c, 0xe7, 0xa8, 0x34, 0x3f, 0x89, 0x37, 0x44, 0xf3, 0xff, 0x73, 0x05, 0x9a, 0xe1, 0xb1,
+	0x42, 0x72, 0xea, 0x7b, 0x01, 0x2a, 0x47, 0xc4, 0x97, 0x9d, 0x52, 0x42, 0xdb, 0xa0, 0x52, 0x0c,
+	0x8a, 0xe8, 0x8c, 0x7c, 0xa5, 0x32, 0x15, 0xd1, 0x19, 0xd1, 0xc4, 0x59, 0xd5, 0xa1, 0x17, 0x3a,
+	0xaa, 0xd3, 0x30, 0x19, 0x0a, 0xba, 0x09, 0xf5, 0x01, 0x31, 0x88, 0x2f, 0x4a, 0x87, 0x19, 0xc8,
+	0x01, 0x12, 0xba, 0x05, 0x0d, 0xdb, 0xe1, 0x17, 0x0f, 0xea, 0xd3, 0xf0, 0x05, 0x16, 0x65, 0x85,
+	0x86, 0xab, 0x4a, 0x63, 0x1a, 0x36, 0x43, 0xa1, 0xe7, 0x35, 0x07, 0xfb, 0xfd, 0x63, 0xa5, 0x39,
+	0x0d, 0x97, 0xe3, 0x4c, 0x98, 0x90, 0x56, 0x2e, 0x13, 0x52, 0xb8, 0x2e, 0xfc, 0x87, 0x26, 0xac,
+	0xc8, 0x23, 0xcd, 0x8b, 0xec, 0xed, 0x45, 0xf6, 0xf6, 0x22, 0x7b, 0x7b, 0x91, 0xbd, 0xcd, 0xb6,
+	0x1d, 0x8f, 0xa1, 0xc6, 0x2e, 0xb4, 0x49, 0x29, 0x95, 0x72, 0x50, 0x42, 0x37, 0xa1, 0xca, 0x6e,
+	0xe7, 0x95, 0xd7, 0x2b, 0x93, 0xae, 0x29, 0x91, 0xca, 0x52, 0x19, 0xda, 0xc6, 0xef, 0x1a, 0xb0,
+	0x30, 0xa1, 0xd7, 0x17, 0xd5, 0xe4, 0x8b, 0x6a, 0xf2, 0x45, 0x35, 0xf9, 0x2b, 0xad, 0x26, 0xcb,
+	0xb4, 0x1c, 0xe5, 0xb1, 0x17, 0xdf, 0x05, 0x88, 0x42, 0xdc, 0x27, 0x7c, 0x8b, 0xf8, 0xb3, 0x16,
+	0x5c, 0xce, 0x48, 0xca, 0x5d, 0x5c, 0x6e, 0xbb, 0xb8, 0xdc, 0x76, 0x71, 0xb9, 0xed, 0xe2, 0x72,
+	0xdb, 0x57, 0x7f, 0xb9, 0xed, 0x9f, 0x25, 0x68, 0x86, 0x45, 0xb2, 0xb3, 0x2f, 0x61, 0x6f, 0x86,
+	0x35, 0x57, 0x7e, 0x60, 0x5e, 0x49, 0x57, 0xa2, 0x58, 0xd0, 0x13, 0x60, 0xd1, 0x02, 0x31, 0xaf,
+	0x97, 0x88, 0xc0, 0x65, 0x29, 0x5d, 0x66, 0xf1, 0x54, 0x81, 0x83, 0x5e, 0x81, 0x66, 0x70, 0x7d,
+	0x58, 0xe4, 0xcb, 0x96, 0x93, 0xf9, 0x32, 0x0e, 0x53, 0x43, 0xac, 0xe2, 0x6f, 0x93, 0x08, 0x2c,
+	0x49, 0x1e, 0x0e, 0xa0, 0x77, 0xa7, 0x9b, 0xfa, 0x74, 0xbc, 0x27, 0x88, 0x64, 0x18, 0xfb, 0x9f,
+	0x97, 0xa0, 0x93, 0xac, 0x1d, 0x6e, 0x51, 0x13, 0xcf, 0x1b, 0xc2, 0x57, 0x60, 0x92, 0x4c, 0xda,
+	0xee, 0x9c, 0x1a, 0xe2, 0x3d, 0xd9, 0xcc, 0xc8, 0x2f, 0x4a, 0xd0, 0x52, 0xc3, 0x24, 0xdd, 0xdb,
+	0xd0, 0x11, 0xc3, 0x68, 0x7d, 0x7b, 0x40, 0x82, 0x89, 0x5e, 0xcb, 0x9c, 0x28, 0xdf, 0x8c, 0xf3,
+	0xa2, 0x13, 0xad, 0xd8, 0x48, 0x57, 0xa3, 0x9c, 0x67, 0x35, 0x7e, 0x33, 0x0f, 0xf5, 0xc0, 0x05,
+	0x4a, 0x72, 0x35, 0x59, 0xc1, 0x71, 0x78, 0x63, 0xa2, 0x32, 0xe5, 0x82, 0x7e, 0x75, 0xea, 0x05,
+	0xfd, 0xb3, 0x82, 0xde, 0x09, 0x3d, 0xad, 0xe7, 0xd4, 0xd3, 0x98, 0x4b, 0x69, 0x14, 0x76, 0x29,
+	0xcd, 0x62, 0x2e, 0xa5, 0x55, 0xd8, 0xa5, 0x40, 0x61, 0x97, 0xd2, 0x2e, 0xe0, 0x52, 0xe6, 0xcf,
+	0xeb, 0x52, 0x3a, 0x53, 0x5c, 0x4a, 0xf7, 0xdc, 0x2e, 0x65, 0xa1, 0x88, 0x4b, 0xe9, 0xe5, 0x73,
+	0x29, 0x3b, 0xd0, 0xa5, 0x2c, 0xc7, 0xcc, 0xd1, 0x0c, 0xb9, 0x81, 0x8e, 0x89, 0x4f, 0x63, 0x41,
+	0x2c, 0xa5, 0xa1, 0x5b, 0x71, 0x1a, 0x68, 0x16, 0x1a, 0xba, 0x15, 0xa3, 0x11, 0x7f, 0xae, 0xb2,
+	0x34, 0xf1, 0x5c, 0x65, 0xa6, 0x3c, 0xc0, 0x47, 0x59, 0xe6, 0xf5, 0x52, 0xba, 0x18, 0x9f, 0xf5,
+	0x3c, 0x57, 0x6e, 0x69, 0xd1, 0x8d, 0x20, 0xe8, 0x5c, 0x49, 0xdb, 0xd4, 0x87, 0x63, 0x87, 0xf0,
+	0x33, 0x39, 0xc5, 0xa0, 0x29, 0x4f, 0xbe, 0x0a, 0x97, 0xd3, 0x29, 0xcf, 0xf0, 0x9a, 0x91, 0x08,
+	0x36, 0x5f, 0x84, 0x3a, 0x36, 0x0c, 0xaa, 0xfb, 0x4a, 0xe6, 0x6d, 0xa3, 0x1a, 0x36, 0x0c, 0xa6,
+	0xec, 0x10, 0x9b, 0xd1, 0x95, 0xb4, 0xa3, 0x8c, 0xb8, 0x55, 0x63, 0x98, 0xe8, 0x59, 0xe8, 0x0c,
+	0x74, 0x6a, 0x9d, 0x4c, 0xdd, 0xc2, 0xbe, 0xed, 0x2a, 0xab, 0x6c, 0xc3, 0x26, 0x1b, 0x93, 0x4f,
+	0x7f, 0xd6, 0x72, 0x3c, 0xfd, 0x79, 0x06, 0x2a, 0xa7, 0xa6, 0xa1, 0x5c, 0x4d, 0x5b, 0xba, 0x0f,
+	0x4d, 0x43, 0xa5, 0xb0, 0x74, 0xd1, 0xea, 0xa9, 0xf3, 0xbe, 0x0e, 0xba, 0x76, 0x8e, 0xd7, 0x41,
+	0x4f, 0xe7, 0xf1, 0x14, 0x3f, 0x02, 0x88, 0xe2, 0x8d, 0x9c, 0x2f, 0xb5, 0xdf, 0x84, 0xf6, 0x50,
+	0x37, 0x88, 0x96, 0x1d, 0xca, 0x44, 0xaf, 0xc2, 0x76, 0xe7, 0x54, 0x18, 0x86, 0x5f, 0x91, 0xf7,
+	0xf4, 0x61, 0x49, 0x72, 0x37, 0x06, 0x7d, 0x7f, 0x7a, 0xdc, 0x70, 0x23, 0x7d, 0x44, 0xcc, 0xb8,
+	0x60, 0x23, 0x0f, 0x23, 0xfe, 0x5a, 0x85, 0xcb, 0x19, 0x3d, 0x90, 0x09, 0x4f, 0x1d, 0x62, 0x4f,
+	0xef, 0x6b, 0x38, 0xf1, 0xca, 0x5a, 0x0b, 0xab, 0x64, 0x5c, 0x34, 0x2f, 0x24, 0x6a, 0x52, 0xd9,
+	0xaf, 0xb2, 0x77, 0xe7, 0xd4, 0xb5, 0xc3, 0x6c, 0x30, 0xba, 0x07, 0x3d, 0xec, 0xe8, 0xda, 0xa7,
+	0x64, 0x1c, 0x8d, 0x50, 0x0e, 0xb6, 0x63, 0x7c, 0xd5, 0x13, 0xaf, 0xd4, 0x77, 0xe7, 0xd4, 0x2e,
+	0x4e, 0xb4, 0xa0, 0x1f, 0x80, 0x62, 0xb3, 0x22, 0xaf, 0xa6, 0x07, 0xe5, 0xfd, 0x88, 0x5e, 0x25,
+	0x7d, 0xc7, 0x44, 0x7e, 0x13, 0x80, 0xde, 0x31, 0xb1, 0xa5, 0x90, 0x18, 0x7d, 0x27, 0xa8, 0x9c,
+	0x47, 0xf4, 0xab, 0x59, 0xf4, 0x27, 0x8b, 0xec, 0x11, 0xfd, 0x49, 0x08, 0x3a, 0x82, 0xb5, 0x80,
+	0x3e, 0x8e, 0xae, 0x65, 0x44, 0x43, 0xf0, 0xc0, 0xe2, 0xb9, 0xf4, 0x10, 0x92, 0x4b, 0x1c, 0xbb,
+	0x73, 0xea, 0x15, 0x3b, 0x0b, 0x48, 0xaf, 0x31, 0x89, 0x81, 0xd8, 0x1d, 0x19, 0x16, 0xa6, 0x45,
+	0x03, 0xd5, 0xd3, 0x96, 0x33, 0xeb, 0x46, 0x0d, 0xbd, 0xc6, 0x64, 0x67, 0xc0, 0xa2, 0x1d, 0x7e,
+	0x0c, 0x4b, 0x92, 0x22, 0x2a, 0x7a, 0x6f, 0xfa, 0x0e, 0xbf, 0x9a, 0x51, 0x84, 0xe7, 0xd7, 0xb4,
+	0xe4, 0xbb, 0xfa, 0x3a, 0xb4, 0x63, 0x48, 0xd1, 0x8d, 0x31, 0x5e, 0x8b, 0xe7, 0x1f, 0x1b, 0x5f,
+	0x96, 0xa0, 0xf2, 0x10, 0xcb, 0xef, 0x98, 0x9d, 0xfd, 0xc7, 0x02, 0x52, 0x96, 0xad, 0x52, 0xf8,
+	0x1d, 0x6d, 0xae, 0x57, 0xee, 0xeb, 0xd0, 0x14, 0xde, 0x27, 0x63, 0x7e, 0x1f, 0xc3, 0xc2, 0x07,
+	0xc9, 0x4e, 0x4f, 0xf2, 0xc1, 0xed, 0x8f, 0xcb, 0x50, 0xf9, 0xd0, 0x34, 0xa4, 0xd2, 0xbb, 0x0a,
+	0x2d, 0xfa, 0xbf, 0xe7, 0xe0, 0xbe, 0xb8, 0xa5, 0x17, 0x35, 0xd0, 0xa0, 0xdb, 0x71, 0xc9, 0x50,
+	0x3f, 0x0d, 0xa2, 0xeb, 0xe0, 0x8b, 0x06, 0x41, 0xd8, 0xf7, 0x5d, 0xfd, 0x70, 0xe4, 0x93, 0x19,
+	0xfe, 0xcc, 0x41, 0x84, 0x8c, 0x5e, 0x83, 0x06, 0xff, 0x23, 0x1c, 0x83, 0x19, 0x92, 0x4a, 0x02,
+	0xb5, 0xf0, 0x6d, 0x92, 0x9d, 0xe7, 0xa1, 0x6b, 0xbb, 0x47, 0x02, 0x57, 0x3b, 0xd9, 0xda, 0x99,
+	0x0f, 0xfe, 0xa6, 0xc8, 0x01, 0x1d, 0xf6, 0xa0, 0xf4, 0xab, 0x72, 0x65, 0x7f, 0xfb, 0xc1, 0x61,
+	0x9d, 0x71, 0xf1, 0xea, 0xbf, 0x06, 0x00, 0xed, 0xcd, 0x69, 0x32, 0x72, 0x44, 0x00, 0x00,
 }
@@ -0,0 +1,53 @@
+package openapi_v2
+
+import (
+	"testing"
+)
+
+func TestBuilders(t *testing.T) {
+	listPets := (&Operation{}).
+		SetOperationId("listPets").
+		AddParameters(NewParametersItemWithJsonReference(&JsonReference{XRef: "#/parameters/limit"})).
+		SetResponses((&Responses{}).
+			AddResponseCode("200", NewResponseValueWithResponse((&Response{}).SetDescription("A list of pets"))))
+	document := (&Document{}).
+		SetSwagger("2.0").
+		SetInfo((&Info{}).SetTitle("Pets").SetVersion("1.0")).
+		AddSchemes("https", "http").
+		SetPaths((&Paths{}).
+			AddPath("/pets", (&PathItem{}).SetGet(listPets)).
+			AddPath("/owners", &PathItem{}).
+			SetPath("/pets", (&PathItem{}).SetGet(listPets)).
+			RemovePath("/owners")).
+		AddVendorExtension("x-owner", &Any{Yaml: "admin"}).
+		SetVendorExtension("x-owner", &Any{Yaml: "staff"})
+	bytes, err := document.ToYAML()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	expected := `swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+schemes:
+  - https
+  - http
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - $ref: '#/parameters/limit'
+      responses:
+        "200":
+          description: A list of pets
+x-owner: staff
+`
+	if string(bytes) != expected {
+		t.Errorf("Unexpected document:\n%s\nexpected:\n%s", string(bytes), expected)
+	}
+	document.RemoveVendorExtension("x-owner")
+	if len(document.VendorExtension) != 0 {
+		t.Errorf("Expected the extension to be removed, found %v", document.VendorExtension)
+	}
+}
@@ -0,0 +1,83 @@
+package openapi_v2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func TestCompileManyFields(t *testing.T) {
+	// nodes with many fields are compiled with an index of their keys
+	document := readTestDocument(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+definitions:
+  Pet:
+    title: Pet
+    description: A pet
+    type: object
+    required: [name]
+    minProperties: 1
+    maxProperties: 10
+    readOnly: true
+    discriminator: kind
+    x-order: 1
+    properties:
+      name: {type: string, format: byte, minLength: 1, maxLength: 10, pattern: "^[a-z]+$", default: rex, readOnly: false, title: Name, description: The name}
+`)
+	pet := document.GetDefinition("Pet")
+	if pet.Title != "Pet" || pet.Description != "A pet" || pet.MaxProperties.GetValue() != 10 || !pet.ReadOnly.GetValue() || pet.Discriminator != "kind" || len(pet.VendorExtension) != 1 {
+		t.Errorf("Unexpected schema: %v", pet)
+	}
+	if name := pet.Properties.Get("name"); name.Pattern != "^[a-z]+$" || name.MaxLength.GetValue() != 10 || name.Default.Yaml != "rex\n" || name.Description != "The name" {
+		t.Errorf("Unexpected schema: %v", name)
+	}
+	// missing and invalid fields are reported as they are for small nodes
+	info, err := compiler.ReadInfoFromBytesWithOptions("", []byte(`
+swagger: "2.0"
+paths: {}
+host: pets.example.com
+basePath: /v1
+schemes: [https]
+consumes: [application/json]
+produces: [application/json]
+tags: []
+security: []
+colour: blue
+`), &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	_, err = NewDocument(info, compiler.NewContext("$root", nil))
+	if err == nil || !strings.Contains(err.Error(), "missing required property: info") || !strings.Contains(err.Error(), "invalid property: colour") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestCompileNonScalarKeys(t *testing.T) {
+	info, err := compiler.ReadInfoFromBytesWithOptions("", []byte(`
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+definitions:
+  Pet:
+    type: object
+    ? [1, 2]
+    : list
+`), &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	_, err = NewDocument(info, compiler.NewContext("$root", nil))
+	errors := compiler.ErrorList(err)
+	if len(errors) != 1 {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// keys that aren't scalars are reported at their own positions
+	if e := errors[0]; e.Code != compiler.ErrorCodeNonScalarKey || e.Position() != "8:7" ||
+		e.Error() != "ERROR $root.definitions.Pet map key is not a scalar" {
+		t.Errorf("Unexpected error: %v at %s", e, e.Position())
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// LazyDocument compiles the parts of an OpenAPI document when they are first
+// requested. Tools that only need one path or one schema of a large document
+// can use it instead of NewDocument to avoid compiling the rest.
+type LazyDocument struct {
+	compiler *compiler.LazyCompiler
+}
+
+// NewLazyDocument creates a LazyDocument for a parsed document.
+// Nothing is compiled until it is requested.
+func NewLazyDocument(info *yaml.Node, context *compiler.Context) (*LazyDocument, error) {
+	if _, ok := compiler.UnpackMap(info); !ok {
+		message := fmt.Sprintf("has unexpected value: %s", compiler.Display(info))
+		return nil, compiler.NewError(context, message)
+	}
+	return &LazyDocument{compiler: compiler.NewLazyCompiler(info, context)}, nil
+}
+
+// Document compiles the entire document.
+func (d *LazyDocument) Document() (*Document, error) {
+	value, _, err := d.compiler.Compile([]string{}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewDocument(in, context)
+	})
+	document, _ := value.(*Document)
+	return document, err
+}
+
+// PathNames returns the names of the paths in the document, in the order that they appear.
+func (d *LazyDocument) PathNames() []string {
+	names := make([]string, 0)
+	for _, name := range d.compiler.Keys("paths") {
+		if compiler.PatternMatches("^/", name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Path compiles and returns the path item with the specified name.
+func (d *LazyDocument) Path(name string) (*PathItem, error) {
+	value, err := d.compile([]string{"paths", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewPathItem(in, context)
+	})
+	pathItem, _ := value.(*PathItem)
+	return pathItem, err
+}
+
+// DefinitionNames returns the names of the schemas in the document, in the order that they appear.
+func (d *LazyDocument) DefinitionNames() []string {
+	return d.compiler.Keys("definitions")
+}
+
+// Definition compiles and returns the schema in the document's definitions with the specified name.
+func (d *LazyDocument) Definition(name string) (*Schema, error) {
+	value, err := d.compile([]string{"definitions", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewSchema(in, context)
+	})
+	schema, _ := value.(*Schema)
+	return schema, err
+}
+
+// ParameterNames returns the names of the parameters in the document, in the order that they appear.
+func (d *LazyDocument) ParameterNames() []string {
+	return d.compiler.Keys("parameters")
+}
+
+// Parameter compiles and returns the parameter in the document's parameters with the specified name.
+func (d *LazyDocument) Parameter(name string) (*Parameter, error) {
+	value, err := d.compile([]string{"parameters", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewParameter(in, context)
+	})
+	parameter, _ := value.(*Parameter)
+	return parameter, err
+}
+
+// ResponseNames returns the names of the responses in the document, in the order that they appear.
+func (d *LazyDocument) ResponseNames() []string {
+	return d.compiler.Keys("responses")
+}
+
+// Response compiles and returns the response in the document's responses with the specified name.
+func (d *LazyDocument) Response(name string) (*Response, error) {
+	value, err := d.compile([]string{"responses", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewResponse(in, context)
+	})
+	response, _ := value.(*Response)
+	return response, err
+}
+
+// SecurityDefinitionNames returns the names of the security schemes in the document, in the order that they appear.
+func (d *LazyDocument) SecurityDefinitionNames() []string {
+	return d.compiler.Keys("securityDefinitions")
+}
+
+// SecurityDefinition compiles and returns the security scheme in the document's securityDefinitions with the specified name.
+func (d *LazyDocument) SecurityDefinition(name string) (*SecurityDefinitionsItem, error) {
+	value, err := d.compile([]string{"securityDefinitions", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewSecurityDefinitionsItem(in, context)
+	})
+	securityDefinition, _ := value.(*SecurityDefinitionsItem)
+	return securityDefinition, err
+}
+
+// Compiles the node at a path, returning an error if there is none.
+func (d *LazyDocument) compile(path []string, compile compiler.CompileFunction) (interface{}, error) {
+	value, found, err := d.compiler.Compile(path, compile)
+	if !found {
+		return nil, errors.New(fmt.Sprintf("%s not found", path[len(path)-1]))
+	}
+	return value, err
+}
@@ -0,0 +1,57 @@
+package openapi_v2
+
+import (
+	"testing"
+
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func readTestDocument(t *testing.T, text string) *Document {
+	info, err := compiler.ReadInfoFromBytesWithOptions("", []byte(text), &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err := NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	return document
+}
+
+func TestLookups(t *testing.T) {
+	document := readTestDocument(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200": {description: OK}
+        default: {description: Error}
+      x-owner: admin
+definitions:
+  Pet: {type: object, properties: {name: {type: string}}}
+securityDefinitions:
+  key: {type: apiKey, name: key, in: header}
+`)
+	if path := document.GetPath("/pets"); path == nil || path.Get.Responses.Get("default").GetResponse().Description != "Error" {
+		t.Errorf("Unexpected path: %v", path)
+	}
+	if responses := document.GetPath("/pets").Get.Responses; responses.Get("x-owner") != nil {
+		t.Errorf("Expected extensions to be skipped by Get")
+	}
+	if schema := document.GetDefinition("Pet"); schema == nil || schema.Properties.Get("name").Type.Value[0] != "string" {
+		t.Errorf("Unexpected definition: %v", schema)
+	}
+	if item := document.GetSecurityDefinition("key"); item.GetApiKeySecurity().GetName() != "key" {
+		t.Errorf("Unexpected security definition: %v", item)
+	}
+	// missing values and containers are nil
+	if document.GetPath("/owners") != nil || document.GetDefinition("Owner") != nil || document.GetParameter("limit") != nil {
+		t.Errorf("Expected nil for missing values")
+	}
+	var missing *Document
+	if missing.GetPath("/pets") != nil {
+		t.Errorf("Expected nil for a nil document")
+	}
+}
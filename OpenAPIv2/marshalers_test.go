@@ -0,0 +1,85 @@
+package openapi_v2
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const marshalersTestDocument = `swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+  description: "Pets \"and\" owners\tof C:\\pets"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+x-owner:
+  name: admin
+  ids:
+    - 1
+    - 2
+`
+
+func TestMarshalJSON(t *testing.T) {
+	document := readTestDocument(t, marshalersTestDocument)
+	bytes, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	// models are written in the forms of source documents, with extensions inlined
+	var value map[string]interface{}
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		t.Fatalf("Unexpected error: %+v\n%s", err, string(bytes))
+	}
+	expected := map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":       "Pets",
+			"version":     "1.0",
+			"description": "Pets \"and\" owners\tof C:\\pets",
+		},
+		"paths": map[string]interface{}{
+			"/pets": map[string]interface{}{
+				"get": map[string]interface{}{
+					"responses": map[string]interface{}{
+						"200": map[string]interface{}{"description": "OK"},
+					},
+				},
+			},
+		},
+		"x-owner": map[string]interface{}{"name": "admin", "ids": []interface{}{1.0, 2.0}},
+	}
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("Unexpected JSON:\n%s", string(bytes))
+	}
+	// messages that are inside other messages are written the same way
+	bytes, err = json.Marshal(document.Info)
+	if err != nil || string(bytes) != `{"title":"Pets","version":"1.0","description":"Pets \"and\" owners\tof C:\\pets"}` {
+		t.Errorf("Unexpected JSON: %s, %v", string(bytes), err)
+	}
+	var missing *Info
+	if bytes, err := missing.MarshalJSON(); err != nil || string(bytes) != "null" {
+		t.Errorf("Unexpected JSON for a nil message: %s, %v", string(bytes), err)
+	}
+}
+
+func TestToYAML(t *testing.T) {
+	document := readTestDocument(t, marshalersTestDocument)
+	bytes, err := document.ToYAML()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if string(bytes) != marshalersTestDocument {
+		t.Errorf("Unexpected YAML:\n%s\nexpected:\n%s", string(bytes), marshalersTestDocument)
+	}
+	// the YAML forms of models can be read again
+	if again := readTestDocument(t, string(bytes)); !proto.Equal(again, document) {
+		t.Errorf("Expected the document that was read from YAML to equal the original")
+	}
+}
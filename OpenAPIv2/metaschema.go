@@ -0,0 +1,52 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate encode-schema openapi-2.0.json metaschema-text.go
+
+package openapi_v2
+
+import (
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// The JSON schema for OpenAPI 2.0 descriptions, which the models in this package are generated from.
+var metaSchemaJSON = []byte(metaSchemaText)
+
+// MetaSchemaJSON returns the JSON text of the schema for OpenAPI 2.0 descriptions.
+func MetaSchemaJSON() []byte {
+	return append([]byte(nil), metaSchemaJSON...)
+}
+
+// MetaSchema returns the schema for OpenAPI 2.0 descriptions. Each call
+// reads a new copy, so callers can modify the result.
+func MetaSchema() (*jsonschema.Schema, error) {
+	return jsonschema.NewSchemaFromBytes(metaSchemaJSON)
+}
+
+// ValidateAgainstMetaSchema checks a description against the schema for
+// OpenAPI 2.0 descriptions and returns a *jsonschema.ValidationErrors that
+// describes each constraint that the description fails. Unlike NewDocument,
+// it only checks the constraints of the schema, but it checks all of them.
+func ValidateAgainstMetaSchema(info *yaml.Node) error {
+	schema, err := MetaSchema()
+	if err != nil {
+		return err
+	}
+	draft4, err := jsonschema.MetaSchema()
+	if err != nil {
+		return err
+	}
+	return jsonschema.NewValidator(schema, draft4).Validate(info)
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v2
+
+import (
+	"strings"
+)
+
+// TypeName returns the type of a schema, ignoring "null". Schemas in
+// OpenAPI 2.0 can list several types, and lists like [string, "null"]
+// describe values of one type that can also be null.
+func (m *Schema) TypeName() string {
+	if m == nil || m.Type == nil {
+		return ""
+	}
+	for _, typeName := range m.Type.Value {
+		if typeName != "null" {
+			return typeName
+		}
+	}
+	return ""
+}
+
+// IsNullable returns true if a schema accepts null, either because its
+// types include "null" or because it has the x-nullable extension that
+// many tools use in place of the nullable property of OpenAPI 3.0.
+func (m *Schema) IsNullable() bool {
+	if m == nil {
+		return false
+	}
+	if m.Type != nil {
+		for _, typeName := range m.Type.Value {
+			if typeName == "null" {
+				return true
+			}
+		}
+	}
+	for _, extension := range m.VendorExtension {
+		if extension.Name == "x-nullable" && extension.Value != nil && strings.TrimSpace(extension.Value.Yaml) == "true" {
+			return true
+		}
+	}
+	return false
+}
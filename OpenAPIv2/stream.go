@@ -0,0 +1,115 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v2
+
+import (
+	"io"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// NewDocumentFromJSON reads and compiles a JSON OpenAPI document in chunks.
+// Each path and each schema in the document's definitions is compiled as soon
+// as it is read, so the YAML nodes for the entire document are never held in
+// memory at once, and the strings in the model are interned as it is built.
+// This uses much less memory than NewDocument for very large documents.
+// The resulting model is the same, but errors may be reported in a different order.
+func NewDocumentFromJSON(reader io.Reader, context *compiler.Context) (*Document, error) {
+	return newDocumentInChunks(context, func(chunked [][]string, handler compiler.ChunkHandler, resolver compiler.ChunkResolver) (*yaml.Node, error) {
+		return compiler.ReadInfoFromJSONInChunks(reader, chunked, handler)
+	})
+}
+
+// NewDocumentFromJSONFile reads and compiles a JSON OpenAPI file in chunks like
+// NewDocumentFromJSON, reading the file as it is compiled. The rest of the file
+// is cached, so references into the file are resolved without reading it again;
+// references to paths and definitions are resolved with the compiled models.
+func NewDocumentFromJSONFile(filename string, context *compiler.Context) (*Document, error) {
+	return newDocumentInChunks(context, func(chunked [][]string, handler compiler.ChunkHandler, resolver compiler.ChunkResolver) (*yaml.Node, error) {
+		return compiler.ReadInfoFromJSONFileInChunksWithOptions(filename, chunked, handler, resolver, compiler.OptionsForContext(context))
+	})
+}
+
+// Compiles a document that is read in chunks by a function.
+func newDocumentInChunks(context *compiler.Context, read func([][]string, compiler.ChunkHandler, compiler.ChunkResolver) (*yaml.Node, error)) (*Document, error) {
+	errors := make([]error, 0)
+	pool := compiler.NewStringPool()
+	pathsContext := compiler.NewContext("paths", context)
+	definitionsContext := compiler.NewContext("definitions", context)
+	paths := make([]*NamedPathItem, 0)
+	definitions := make([]*NamedSchema, 0)
+	pathsByName := make(map[string]*PathItem)
+	definitionsByName := make(map[string]*Schema)
+	handler := func(path []string, name string, value *yaml.Node) bool {
+		switch path[0] {
+		case "paths":
+			if !compiler.PatternMatches("^/", name) {
+				return false
+			}
+			pair := &NamedPathItem{Name: name}
+			var err error
+			pair.Value, err = NewPathItem(value, compiler.NewContext(name, pathsContext))
+			if err != nil {
+				errors = append(errors, err)
+			}
+			pool.InternStrings(pair)
+			paths = append(paths, pair)
+			pathsByName[name] = pair.Value
+		case "definitions":
+			pair := &NamedSchema{Name: name}
+			var err error
+			pair.Value, err = NewSchema(value, compiler.NewContext(name, definitionsContext))
+			if err != nil {
+				errors = append(errors, err)
+			}
+			pool.InternStrings(pair)
+			definitions = append(definitions, pair)
+			definitionsByName[name] = pair.Value
+		}
+		return true
+	}
+	// references to the entries that were compiled in chunks are resolved with their models
+	resolver := func(path []string, name string) *yaml.Node {
+		switch path[0] {
+		case "paths":
+			if pathItem, ok := pathsByName[name]; ok {
+				return pathItem.ToRawInfo()
+			}
+		case "definitions":
+			if schema, ok := definitionsByName[name]; ok {
+				return schema.ToRawInfo()
+			}
+		}
+		return nil
+	}
+	info, err := read([][]string{{"paths"}, {"definitions"}}, handler, resolver)
+	if err != nil {
+		return nil, err
+	}
+	// compile the rest of the document and add the entries that were compiled in chunks
+	document, err := NewDocument(info, context)
+	if err != nil {
+		errors = append(errors, err)
+	}
+	pool.InternStrings(document)
+	if document.Paths != nil {
+		document.Paths.Path = paths
+	}
+	if document.Definitions != nil {
+		document.Definitions.AdditionalProperties = definitions
+	}
+	return document, compiler.NewErrorGroupOrNil(errors)
+}
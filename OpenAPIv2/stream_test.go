@@ -0,0 +1,42 @@
+package openapi_v2
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+// Returns the address of the bytes of a string.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestNewDocumentFromJSON(t *testing.T) {
+	filename := "../examples/v2.0/json/petstore.json"
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	streamed, err := NewDocumentFromJSON(bytes.NewReader(data), compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	// documents that are read as streams have the same models as others
+	document := readTestDocument(t, string(data))
+	if !proto.Equal(streamed, document) {
+		t.Errorf("Expected the streamed document to equal the document read with NewDocument")
+	}
+	// the strings of models read as streams are interned
+	errorSchema := func(path string) string {
+		return streamed.GetPath(path).Get.Responses.Get("default").GetResponse().Schema.GetSchema().XRef
+	}
+	first, second := errorSchema("/pets"), errorSchema("/pets/{petId}")
+	if first != "#/definitions/Error" || first != second || stringData(first) != stringData(second) {
+		t.Errorf("Expected repeated strings to share one copy")
+	}
+}
@@ -0,0 +1,64 @@
+package openapi_v2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	document := readTestDocument(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200": {description: OK}
+    post:
+      operationId: createPet
+      responses:
+        "201": {description: Created}
+  /owners:
+    get:
+      operationId: listOwners
+      responses:
+        "200": {description: OK}
+`)
+	var visited []string
+	document.Walk(&Visitor{
+		VisitOperation: func(m *Operation, path string) bool {
+			visited = append(visited, m.OperationId+" "+path)
+			return true
+		},
+		VisitResponse: func(m *Response, path string) bool {
+			visited = append(visited, m.Description+" "+path)
+			return true
+		},
+	})
+	expected := []string{
+		"listPets $root.paths./pets.get",
+		"OK $root.paths./pets.get.responses.200.response",
+		"createPet $root.paths./pets.post",
+		"Created $root.paths./pets.post.responses.201.response",
+		"listOwners $root.paths./owners.get",
+		"OK $root.paths./owners.get.responses.200.response",
+	}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Unexpected visits:\n%v\nexpected:\n%v", visited, expected)
+	}
+	// messages inside of messages whose callbacks return false aren't visited
+	visited = nil
+	document.Walk(&Visitor{
+		VisitPathItem: func(m *PathItem, path string) bool {
+			return path != "$root.paths./pets"
+		},
+		VisitOperation: func(m *Operation, path string) bool {
+			visited = append(visited, m.OperationId)
+			return true
+		},
+	})
+	if !reflect.DeepEqual(visited, []string{"listOwners"}) {
+		t.Errorf("Unexpected visits: %v", visited)
+	}
+}
@@ -0,0 +1,57 @@
+package openapi_v2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func TestWrappedZeroValues(t *testing.T) {
+	// numbers and booleans that are set to zero or false are kept apart from absent ones
+	zero := readTestDocument(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+definitions:
+  Count: {type: integer, minimum: 0, readOnly: false}
+`)
+	absent := readTestDocument(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+definitions:
+  Count: {type: integer}
+`)
+	if count := zero.GetDefinition("Count"); count.Minimum == nil || count.Minimum.Value != 0 || count.ReadOnly == nil || count.ReadOnly.Value {
+		t.Errorf("Unexpected schema: %v", count)
+	}
+	if count := absent.GetDefinition("Count"); count.Minimum != nil || count.ReadOnly != nil {
+		t.Errorf("Unexpected schema: %v", count)
+	}
+	if proto.Equal(zero, absent) {
+		t.Errorf("Documents with zero and absent values should not be equal")
+	}
+	// the values are kept in serialized models
+	bytes, err := proto.Marshal(zero)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	var unmarshaled Document
+	if err := proto.Unmarshal(bytes, &unmarshaled); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if !proto.Equal(zero, &unmarshaled) {
+		t.Errorf("Unexpected document: %v", &unmarshaled)
+	}
+	// and in descriptions that are written from models
+	text := string(compiler.Marshal(zero.ToRawInfo()))
+	if !strings.Contains(text, "minimum: 0\n") || !strings.Contains(text, "readOnly: false\n") {
+		t.Errorf("Unexpected description:\n%s", text)
+	}
+	text = string(compiler.Marshal(absent.ToRawInfo()))
+	if strings.Contains(text, "minimum") || strings.Contains(text, "readOnly") {
+		t.Errorf("Unexpected description:\n%s", text)
+	}
+}
@@ -90,6 +90,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/any"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/wrappers"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -667,7 +668,7 @@ type EncodingProperty struct {
 	ContentType            string                         `protobuf:"bytes,1,opt,name=content_type,json=contentType" json:"content_type,omitempty"`
 	Headers                *Object                        `protobuf:"bytes,2,opt,name=headers" json:"headers,omitempty"`
 	Style                  string                         `protobuf:"bytes,3,opt,name=style" json:"style,omitempty"`
-	Explode                bool                           `protobuf:"varint,4,opt,name=explode" json:"explode,omitempty"`
+	Explode                *google_protobuf1.BoolValue    `protobuf:"bytes,4,opt,name=explode" json:"explode,omitempty"`
 	SpecificationExtension []*NamedSpecificationExtension `protobuf:"bytes,5,rep,name=specification_extension,json=specificationExtension" json:"specification_extension,omitempty"`
 }
 
@@ -697,11 +698,11 @@ func (m *EncodingProperty) GetStyle() string {
 	return ""
 }
 
-func (m *EncodingProperty) GetExplode() bool {
+func (m *EncodingProperty) GetExplode() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Explode
 	}
-	return false
+	return nil
 }
 
 func (m *EncodingProperty) GetSpecificationExtension() []*NamedSpecificationExtension {
@@ -900,19 +901,19 @@ func (m *ExternalDocs) GetSpecificationExtension() []*NamedSpecificationExtensio
 
 // The Header Object follows the structure of the Parameter Object, with the following changes:  1. `name` MUST NOT be specified, it is given in the Headers Object. 1. `in` MUST NOT be specified, it is implicitly in `header`. 1. All traits that are affected by the location MUST be applicable to a location of `header` (for example, `style`).
 type Header struct {
-	Name            string                `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	In              string                `protobuf:"bytes,2,opt,name=in" json:"in,omitempty"`
-	Description     string                `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	Required        bool                  `protobuf:"varint,4,opt,name=required" json:"required,omitempty"`
-	Deprecated      bool                  `protobuf:"varint,5,opt,name=deprecated" json:"deprecated,omitempty"`
-	AllowEmptyValue bool                  `protobuf:"varint,6,opt,name=allow_empty_value,json=allowEmptyValue" json:"allow_empty_value,omitempty"`
-	Style           string                `protobuf:"bytes,7,opt,name=style" json:"style,omitempty"`
-	Explode         bool                  `protobuf:"varint,8,opt,name=explode" json:"explode,omitempty"`
-	AllowReserved   bool                  `protobuf:"varint,9,opt,name=allow_reserved,json=allowReserved" json:"allow_reserved,omitempty"`
-	Schema          *SchemaOrReference    `protobuf:"bytes,10,opt,name=schema" json:"schema,omitempty"`
-	Examples        []*ExampleOrReference `protobuf:"bytes,11,rep,name=examples" json:"examples,omitempty"`
-	Example         *ExampleOrReference   `protobuf:"bytes,12,opt,name=example" json:"example,omitempty"`
-	Content         *Content              `protobuf:"bytes,13,opt,name=content" json:"content,omitempty"`
+	Name            string                      `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	In              string                      `protobuf:"bytes,2,opt,name=in" json:"in,omitempty"`
+	Description     string                      `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	Required        *google_protobuf1.BoolValue `protobuf:"bytes,4,opt,name=required" json:"required,omitempty"`
+	Deprecated      *google_protobuf1.BoolValue `protobuf:"bytes,5,opt,name=deprecated" json:"deprecated,omitempty"`
+	AllowEmptyValue *google_protobuf1.BoolValue `protobuf:"bytes,6,opt,name=allow_empty_value,json=allowEmptyValue" json:"allow_empty_value,omitempty"`
+	Style           string                      `protobuf:"bytes,7,opt,name=style" json:"style,omitempty"`
+	Explode         *google_protobuf1.BoolValue `protobuf:"bytes,8,opt,name=explode" json:"explode,omitempty"`
+	AllowReserved   *google_protobuf1.BoolValue `protobuf:"bytes,9,opt,name=allow_reserved,json=allowReserved" json:"allow_reserved,omitempty"`
+	Schema          *SchemaOrReference          `protobuf:"bytes,10,opt,name=schema" json:"schema,omitempty"`
+	Examples        []*ExampleOrReference       `protobuf:"bytes,11,rep,name=examples" json:"examples,omitempty"`
+	Example         *ExampleOrReference         `protobuf:"bytes,12,opt,name=example" json:"example,omitempty"`
+	Content         *Content                    `protobuf:"bytes,13,opt,name=content" json:"content,omitempty"`
 }
 
 func (m *Header) Reset()                    { *m = Header{} }
@@ -941,25 +942,25 @@ func (m *Header) GetDescription() string {
 	return ""
 }
 
-func (m *Header) GetRequired() bool {
+func (m *Header) GetRequired() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Required
 	}
-	return false
+	return nil
 }
 
-func (m *Header) GetDeprecated() bool {
+func (m *Header) GetDeprecated() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Deprecated
 	}
-	return false
+	return nil
 }
 
-func (m *Header) GetAllowEmptyValue() bool {
+func (m *Header) GetAllowEmptyValue() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.AllowEmptyValue
 	}
-	return false
+	return nil
 }
 
 func (m *Header) GetStyle() string {
@@ -969,18 +970,18 @@ func (m *Header) GetStyle() string {
 	return ""
 }
 
-func (m *Header) GetExplode() bool {
+func (m *Header) GetExplode() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Explode
 	}
-	return false
+	return nil
 }
 
-func (m *Header) GetAllowReserved() bool {
+func (m *Header) GetAllowReserved() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.AllowReserved
 	}
-	return false
+	return nil
 }
 
 func (m *Header) GetSchema() *SchemaOrReference {
@@ -2054,7 +2055,7 @@ type Operation struct {
 	RequestBody            *RequestBodyOrReference        `protobuf:"bytes,7,opt,name=request_body,json=requestBody" json:"request_body,omitempty"`
 	Responses              *Responses                     `protobuf:"bytes,8,opt,name=responses" json:"responses,omitempty"`
 	Callbacks              *Callbacks                     `protobuf:"bytes,9,opt,name=callbacks" json:"callbacks,omitempty"`
-	Deprecated             bool                           `protobuf:"varint,10,opt,name=deprecated" json:"deprecated,omitempty"`
+	Deprecated             *google_protobuf1.BoolValue    `protobuf:"bytes,10,opt,name=deprecated" json:"deprecated,omitempty"`
 	Security               []*SecurityRequirement         `protobuf:"bytes,11,rep,name=security" json:"security,omitempty"`
 	Servers                *Server                        `protobuf:"bytes,12,opt,name=servers" json:"servers,omitempty"`
 	SpecificationExtension []*NamedSpecificationExtension `protobuf:"bytes,13,rep,name=specification_extension,json=specificationExtension" json:"specification_extension,omitempty"`
@@ -2128,11 +2129,11 @@ func (m *Operation) GetCallbacks() *Callbacks {
 	return nil
 }
 
-func (m *Operation) GetDeprecated() bool {
+func (m *Operation) GetDeprecated() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Deprecated
 	}
-	return false
+	return nil
 }
 
 func (m *Operation) GetSecurity() []*SecurityRequirement {
@@ -2161,12 +2162,12 @@ type Parameter struct {
 	Name                   string                         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	In                     string                         `protobuf:"bytes,2,opt,name=in" json:"in,omitempty"`
 	Description            string                         `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	Required               bool                           `protobuf:"varint,4,opt,name=required" json:"required,omitempty"`
-	Deprecated             bool                           `protobuf:"varint,5,opt,name=deprecated" json:"deprecated,omitempty"`
-	AllowEmptyValue        bool                           `protobuf:"varint,6,opt,name=allow_empty_value,json=allowEmptyValue" json:"allow_empty_value,omitempty"`
+	Required               *google_protobuf1.BoolValue    `protobuf:"bytes,4,opt,name=required" json:"required,omitempty"`
+	Deprecated             *google_protobuf1.BoolValue    `protobuf:"bytes,5,opt,name=deprecated" json:"deprecated,omitempty"`
+	AllowEmptyValue        *google_protobuf1.BoolValue    `protobuf:"bytes,6,opt,name=allow_empty_value,json=allowEmptyValue" json:"allow_empty_value,omitempty"`
 	Style                  string                         `protobuf:"bytes,7,opt,name=style" json:"style,omitempty"`
-	Explode                bool                           `protobuf:"varint,8,opt,name=explode" json:"explode,omitempty"`
-	AllowReserved          bool                           `protobuf:"varint,9,opt,name=allow_reserved,json=allowReserved" json:"allow_reserved,omitempty"`
+	Explode                *google_protobuf1.BoolValue    `protobuf:"bytes,8,opt,name=explode" json:"explode,omitempty"`
+	AllowReserved          *google_protobuf1.BoolValue    `protobuf:"bytes,9,opt,name=allow_reserved,json=allowReserved" json:"allow_reserved,omitempty"`
 	Schema                 *SchemaOrReference             `protobuf:"bytes,10,opt,name=schema" json:"schema,omitempty"`
 	Examples               []*ExampleOrReference          `protobuf:"bytes,11,rep,name=examples" json:"examples,omitempty"`
 	Example                *ExampleOrReference            `protobuf:"bytes,12,opt,name=example" json:"example,omitempty"`
@@ -2200,25 +2201,25 @@ func (m *Parameter) GetDescription() string {
 	return ""
 }
 
-func (m *Parameter) GetRequired() bool {
+func (m *Parameter) GetRequired() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Required
 	}
-	return false
+	return nil
 }
 
-func (m *Parameter) GetDeprecated() bool {
+func (m *Parameter) GetDeprecated() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Deprecated
 	}
-	return false
+	return nil
 }
 
-func (m *Parameter) GetAllowEmptyValue() bool {
+func (m *Parameter) GetAllowEmptyValue() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.AllowEmptyValue
 	}
-	return false
+	return nil
 }
 
 func (m *Parameter) GetStyle() string {
@@ -2228,18 +2229,18 @@ func (m *Parameter) GetStyle() string {
 	return ""
 }
 
-func (m *Parameter) GetExplode() bool {
+func (m *Parameter) GetExplode() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Explode
 	}
-	return false
+	return nil
 }
 
-func (m *Parameter) GetAllowReserved() bool {
+func (m *Parameter) GetAllowReserved() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.AllowReserved
 	}
-	return false
+	return nil
 }
 
 func (m *Parameter) GetSchema() *SchemaOrReference {
@@ -2746,7 +2747,9 @@ func (m *Properties) GetAdditionalProperties() []*NamedSchema {
 
 // A simple object to allow referencing other components in the specification, internally and externally.  The Reference Object is defined by JSON Reference and follows the same structure, behavior and rules.   For this specification, reference resolution is done as defined by the JSON Reference specification and not by the JSON Schema specification.
 type Reference struct {
-	XRef string `protobuf:"bytes,1,opt,name=_ref,json=ref" json:"_ref,omitempty"`
+	XRef        string `protobuf:"bytes,1,opt,name=_ref,json=ref" json:"_ref,omitempty"`
+	Summary     string `protobuf:"bytes,2,opt,name=summary" json:"summary,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
 }
 
 func (m *Reference) Reset()                    { *m = Reference{} }
@@ -2761,6 +2764,20 @@ func (m *Reference) GetXRef() string {
 	return ""
 }
 
+func (m *Reference) GetSummary() string {
+	if m != nil {
+		return m.Summary
+	}
+	return ""
+}
+
+func (m *Reference) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
 type RequestBodies struct {
 	AdditionalProperties []*NamedRequestBody `protobuf:"bytes,1,rep,name=additional_properties,json=additionalProperties" json:"additional_properties,omitempty"`
 }
@@ -2781,7 +2798,7 @@ func (m *RequestBodies) GetAdditionalProperties() []*NamedRequestBody {
 type RequestBody struct {
 	Description            string                         `protobuf:"bytes,1,opt,name=description" json:"description,omitempty"`
 	Content                *Content                       `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
-	Required               bool                           `protobuf:"varint,3,opt,name=required" json:"required,omitempty"`
+	Required               *google_protobuf1.BoolValue    `protobuf:"bytes,3,opt,name=required" json:"required,omitempty"`
 	SpecificationExtension []*NamedSpecificationExtension `protobuf:"bytes,4,rep,name=specification_extension,json=specificationExtension" json:"specification_extension,omitempty"`
 }
 
@@ -2804,11 +2821,11 @@ func (m *RequestBody) GetContent() *Content {
 	return nil
 }
 
-func (m *RequestBody) GetRequired() bool {
+func (m *RequestBody) GetRequired() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Required
 	}
-	return false
+	return nil
 }
 
 func (m *RequestBody) GetSpecificationExtension() []*NamedSpecificationExtension {
@@ -3144,27 +3161,27 @@ func (m *Responses) GetSpecificationExtension() []*NamedSpecificationExtension {
 
 // The Schema Object allows the definition of input and output data types. These types can be objects, but also primitives and arrays. This object is an extended subset of the JSON Schema Specification Wright Draft 00.  Further information about the properties can be found in JSON Schema Core and JSON Schema Validation. Unless stated otherwise, the property definitions follow the JSON Schema specification as referenced here.
 type Schema struct {
-	Nullable               bool                           `protobuf:"varint,1,opt,name=nullable" json:"nullable,omitempty"`
+	Nullable               *google_protobuf1.BoolValue    `protobuf:"bytes,1,opt,name=nullable" json:"nullable,omitempty"`
 	Discriminator          string                         `protobuf:"bytes,2,opt,name=discriminator" json:"discriminator,omitempty"`
-	ReadOnly               bool                           `protobuf:"varint,3,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
-	WriteOnly              bool                           `protobuf:"varint,4,opt,name=write_only,json=writeOnly" json:"write_only,omitempty"`
+	ReadOnly               *google_protobuf1.BoolValue    `protobuf:"bytes,3,opt,name=read_only,json=readOnly" json:"read_only,omitempty"`
+	WriteOnly              *google_protobuf1.BoolValue    `protobuf:"bytes,4,opt,name=write_only,json=writeOnly" json:"write_only,omitempty"`
 	Xml                    *Xml                           `protobuf:"bytes,5,opt,name=xml" json:"xml,omitempty"`
 	ExternalDocs           *ExternalDocs                  `protobuf:"bytes,6,opt,name=external_docs,json=externalDocs" json:"external_docs,omitempty"`
-	Deprecated             bool                           `protobuf:"varint,7,opt,name=deprecated" json:"deprecated,omitempty"`
+	Deprecated             *google_protobuf1.BoolValue    `protobuf:"bytes,7,opt,name=deprecated" json:"deprecated,omitempty"`
 	Title                  string                         `protobuf:"bytes,8,opt,name=title" json:"title,omitempty"`
-	MultipleOf             float64                        `protobuf:"fixed64,9,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
-	Maximum                float64                        `protobuf:"fixed64,10,opt,name=maximum" json:"maximum,omitempty"`
-	ExclusiveMaximum       bool                           `protobuf:"varint,11,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
-	Minimum                float64                        `protobuf:"fixed64,12,opt,name=minimum" json:"minimum,omitempty"`
-	ExclusiveMinimum       bool                           `protobuf:"varint,13,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
-	MaxLength              int64                          `protobuf:"varint,14,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
-	MinLength              int64                          `protobuf:"varint,15,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
+	MultipleOf             *google_protobuf1.DoubleValue  `protobuf:"bytes,9,opt,name=multiple_of,json=multipleOf" json:"multiple_of,omitempty"`
+	Maximum                *google_protobuf1.DoubleValue  `protobuf:"bytes,10,opt,name=maximum" json:"maximum,omitempty"`
+	ExclusiveMaximum       *google_protobuf1.BoolValue    `protobuf:"bytes,11,opt,name=exclusive_maximum,json=exclusiveMaximum" json:"exclusive_maximum,omitempty"`
+	Minimum                *google_protobuf1.DoubleValue  `protobuf:"bytes,12,opt,name=minimum" json:"minimum,omitempty"`
+	ExclusiveMinimum       *google_protobuf1.BoolValue    `protobuf:"bytes,13,opt,name=exclusive_minimum,json=exclusiveMinimum" json:"exclusive_minimum,omitempty"`
+	MaxLength              *google_protobuf1.Int64Value   `protobuf:"bytes,14,opt,name=max_length,json=maxLength" json:"max_length,omitempty"`
+	MinLength              *google_protobuf1.Int64Value   `protobuf:"bytes,15,opt,name=min_length,json=minLength" json:"min_length,omitempty"`
 	Pattern                string                         `protobuf:"bytes,16,opt,name=pattern" json:"pattern,omitempty"`
-	MaxItems               int64                          `protobuf:"varint,17,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
-	MinItems               int64                          `protobuf:"varint,18,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
-	UniqueItems            bool                           `protobuf:"varint,19,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
-	MaxProperties          int64                          `protobuf:"varint,20,opt,name=max_properties,json=maxProperties" json:"max_properties,omitempty"`
-	MinProperties          int64                          `protobuf:"varint,21,opt,name=min_properties,json=minProperties" json:"min_properties,omitempty"`
+	MaxItems               *google_protobuf1.Int64Value   `protobuf:"bytes,17,opt,name=max_items,json=maxItems" json:"max_items,omitempty"`
+	MinItems               *google_protobuf1.Int64Value   `protobuf:"bytes,18,opt,name=min_items,json=minItems" json:"min_items,omitempty"`
+	UniqueItems            *google_protobuf1.BoolValue    `protobuf:"bytes,19,opt,name=unique_items,json=uniqueItems" json:"unique_items,omitempty"`
+	MaxProperties          *google_protobuf1.Int64Value   `protobuf:"bytes,20,opt,name=max_properties,json=maxProperties" json:"max_properties,omitempty"`
+	MinProperties          *google_protobuf1.Int64Value   `protobuf:"bytes,21,opt,name=min_properties,json=minProperties" json:"min_properties,omitempty"`
 	Required               []string                       `protobuf:"bytes,22,rep,name=required" json:"required,omitempty"`
 	Enum                   []*Any                         `protobuf:"bytes,23,rep,name=enum" json:"enum,omitempty"`
 	Type                   string                         `protobuf:"bytes,24,opt,name=type" json:"type,omitempty"`
@@ -3184,11 +3201,11 @@ func (m *Schema) String() string            { return proto.CompactTextString(m)
 func (*Schema) ProtoMessage()               {}
 func (*Schema) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
 
-func (m *Schema) GetNullable() bool {
+func (m *Schema) GetNullable() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Nullable
 	}
-	return false
+	return nil
 }
 
 func (m *Schema) GetDiscriminator() string {
@@ -3198,18 +3215,18 @@ func (m *Schema) GetDiscriminator() string {
 	return ""
 }
 
-func (m *Schema) GetReadOnly() bool {
+func (m *Schema) GetReadOnly() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ReadOnly
 	}
-	return false
+	return nil
 }
 
-func (m *Schema) GetWriteOnly() bool {
+func (m *Schema) GetWriteOnly() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.WriteOnly
 	}
-	return false
+	return nil
 }
 
 func (m *Schema) GetXml() *Xml {
@@ -3226,11 +3243,11 @@ func (m *Schema) GetExternalDocs() *ExternalDocs {
 	return nil
 }
 
-func (m *Schema) GetDeprecated() bool {
+func (m *Schema) GetDeprecated() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Deprecated
 	}
-	return false
+	return nil
 }
 
 func (m *Schema) GetTitle() string {
@@ -3240,53 +3257,53 @@ func (m *Schema) GetTitle() string {
 	return ""
 }
 
-func (m *Schema) GetMultipleOf() float64 {
+func (m *Schema) GetMultipleOf() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.MultipleOf
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetMaximum() float64 {
+func (m *Schema) GetMaximum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Maximum
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetExclusiveMaximum() bool {
+func (m *Schema) GetExclusiveMaximum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMaximum
 	}
-	return false
+	return nil
 }
 
-func (m *Schema) GetMinimum() float64 {
+func (m *Schema) GetMinimum() *google_protobuf1.DoubleValue {
 	if m != nil {
 		return m.Minimum
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetExclusiveMinimum() bool {
+func (m *Schema) GetExclusiveMinimum() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.ExclusiveMinimum
 	}
-	return false
+	return nil
 }
 
-func (m *Schema) GetMaxLength() int64 {
+func (m *Schema) GetMaxLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxLength
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetMinLength() int64 {
+func (m *Schema) GetMinLength() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinLength
 	}
-	return 0
+	return nil
 }
 
 func (m *Schema) GetPattern() string {
@@ -3296,39 +3313,39 @@ func (m *Schema) GetPattern() string {
 	return ""
 }
 
-func (m *Schema) GetMaxItems() int64 {
+func (m *Schema) GetMaxItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxItems
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetMinItems() int64 {
+func (m *Schema) GetMinItems() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinItems
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetUniqueItems() bool {
+func (m *Schema) GetUniqueItems() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.UniqueItems
 	}
-	return false
+	return nil
 }
 
-func (m *Schema) GetMaxProperties() int64 {
+func (m *Schema) GetMaxProperties() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MaxProperties
 	}
-	return 0
+	return nil
 }
 
-func (m *Schema) GetMinProperties() int64 {
+func (m *Schema) GetMinProperties() *google_protobuf1.Int64Value {
 	if m != nil {
 		return m.MinProperties
 	}
-	return 0
+	return nil
 }
 
 func (m *Schema) GetRequired() []string {
@@ -4028,8 +4045,8 @@ type Xml struct {
 	Name                   string                         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Namespace              string                         `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
 	Prefix                 string                         `protobuf:"bytes,3,opt,name=prefix" json:"prefix,omitempty"`
-	Attribute              bool                           `protobuf:"varint,4,opt,name=attribute" json:"attribute,omitempty"`
-	Wrapped                bool                           `protobuf:"varint,5,opt,name=wrapped" json:"wrapped,omitempty"`
+	Attribute              *google_protobuf1.BoolValue    `protobuf:"bytes,4,opt,name=attribute" json:"attribute,omitempty"`
+	Wrapped                *google_protobuf1.BoolValue    `protobuf:"bytes,5,opt,name=wrapped" json:"wrapped,omitempty"`
 	SpecificationExtension []*NamedSpecificationExtension `protobuf:"bytes,6,rep,name=specification_extension,json=specificationExtension" json:"specification_extension,omitempty"`
 }
 
@@ -4059,18 +4076,18 @@ func (m *Xml) GetPrefix() string {
 	return ""
 }
 
-func (m *Xml) GetAttribute() bool {
+func (m *Xml) GetAttribute() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Attribute
 	}
-	return false
+	return nil
 }
 
-func (m *Xml) GetWrapped() bool {
+func (m *Xml) GetWrapped() *google_protobuf1.BoolValue {
 	if m != nil {
 		return m.Wrapped
 	}
-	return false
+	return nil
 }
 
 func (m *Xml) GetSpecificationExtension() []*NamedSpecificationExtension {
@@ -4160,214 +4177,216 @@ func init() {
 func init() { proto.RegisterFile("OpenAPIv3/OpenAPIv3.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 3333 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x5b, 0x4b, 0x6f, 0x1c, 0xc7,
-	0xb5, 0x66, 0xcf, 0x7b, 0xce, 0x90, 0x14, 0x59, 0xa4, 0xa8, 0x11, 0xf5, 0x1e, 0xc9, 0xb6, 0x2c,
-	0x5b, 0x92, 0x2d, 0xd9, 0x86, 0xec, 0x7b, 0x8d, 0x6b, 0x4a, 0xa2, 0x40, 0xe2, 0xda, 0x77, 0xe8,
-	0x92, 0xfc, 0x80, 0x2f, 0x8c, 0x49, 0xb1, 0xa7, 0x86, 0xec, 0xa8, 0x5f, 0xee, 0xae, 0xa1, 0x38,
-	0x59, 0xc5, 0x40, 0xb2, 0xf0, 0xc2, 0x8b, 0x00, 0x49, 0x90, 0x4d, 0x36, 0x41, 0x00, 0x67, 0x91,
-	0x5f, 0x91, 0x75, 0x90, 0x4d, 0x56, 0x01, 0xbc, 0xc8, 0x22, 0x1b, 0x6f, 0x82, 0x00, 0x41, 0xf6,
-	0x41, 0xbd, 0x7a, 0xba, 0xa7, 0x6b, 0x86, 0x94, 0x38, 0x62, 0x80, 0x20, 0x1b, 0x69, 0xaa, 0xce,
-	0x77, 0x4e, 0x55, 0x57, 0x9d, 0x3a, 0xaf, 0x2a, 0xc2, 0xe9, 0x76, 0x48, 0xfd, 0xb5, 0xad, 0xcd,
-	0xbd, 0xdb, 0x37, 0x93, 0x5f, 0x37, 0xc2, 0x28, 0x60, 0x01, 0x82, 0x20, 0xa4, 0x3e, 0x09, 0x9d,
-	0x1b, 0x7b, 0xb7, 0x57, 0x4f, 0xef, 0x04, 0xc1, 0x8e, 0x4b, 0x6f, 0x0a, 0xca, 0x76, 0xbf, 0x77,
-	0x93, 0xf8, 0x03, 0x09, 0x6b, 0xad, 0x43, 0x71, 0xcd, 0x1f, 0xa0, 0x6b, 0x50, 0xde, 0x23, 0x6e,
-	0x9f, 0x36, 0xad, 0x8b, 0xd6, 0xd5, 0xc6, 0xad, 0xe5, 0x1b, 0x92, 0xe3, 0x86, 0xe6, 0xb8, 0xb1,
-	0xe6, 0x0f, 0xb0, 0x84, 0x20, 0x04, 0xa5, 0x01, 0xf1, 0xdc, 0x66, 0xe1, 0xa2, 0x75, 0xb5, 0x8e,
-	0xc5, 0xef, 0xd6, 0x00, 0x4e, 0xac, 0xf9, 0x83, 0x76, 0xb4, 0xbe, 0x1f, 0x46, 0x34, 0x8e, 0x9d,
-	0xc0, 0x47, 0x97, 0xa1, 0x48, 0xfc, 0x81, 0x12, 0x78, 0xe2, 0xc6, 0x70, 0x3a, 0x5c, 0xd6, 0xc6,
-	0x0c, 0xe6, 0x54, 0x74, 0x07, 0x80, 0x26, 0x2c, 0x42, 0x62, 0xe3, 0xd6, 0x4a, 0x1a, 0x3b, 0x14,
-	0xb8, 0x31, 0x83, 0x53, 0xd8, 0xbb, 0x55, 0x28, 0x07, 0x3e, 0x0d, 0x7a, 0xad, 0x6f, 0x2c, 0xa8,
-	0xdd, 0x23, 0xae, 0xbb, 0x4d, 0xec, 0xc7, 0xe8, 0xed, 0x8c, 0x3c, 0xeb, 0x62, 0xf1, 0x6a, 0xe3,
-	0xd6, 0xe9, 0xb4, 0xbc, 0xff, 0x23, 0x1e, 0xed, 0x6e, 0x11, 0xb6, 0xbb, 0xc9, 0xa8, 0x97, 0x16,
-	0x88, 0xbe, 0x07, 0xa7, 0xe2, 0x90, 0xda, 0x4e, 0xcf, 0xb1, 0x09, 0x73, 0x02, 0xbf, 0x43, 0xf7,
-	0x19, 0xf5, 0xd5, 0xbc, 0xb8, 0x9c, 0x97, 0x72, 0x72, 0x1e, 0xa6, 0xf1, 0xeb, 0x1a, 0x8e, 0x57,
-	0x62, 0x63, 0x7f, 0xeb, 0x2b, 0x0b, 0x96, 0xf4, 0x4c, 0xdb, 0x11, 0xa6, 0x3d, 0x1a, 0x51, 0xdf,
-	0xa6, 0xe8, 0x16, 0xd4, 0x6c, 0xd5, 0x9d, 0xac, 0x7f, 0x6a, 0x28, 0xcd, 0xb2, 0x31, 0x83, 0x13,
-	0x1c, 0x7a, 0x13, 0xea, 0x91, 0x16, 0xa0, 0xd6, 0xed, 0x64, 0x9a, 0x29, 0x91, 0xbe, 0x31, 0x83,
-	0x87, 0xc8, 0xcc, 0xaa, 0xd5, 0xb5, 0xe0, 0x18, 0xdd, 0x81, 0x92, 0x4f, 0x3c, 0xaa, 0x16, 0xec,
-	0x4a, 0xee, 0x43, 0x0d, 0xb3, 0xc6, 0x82, 0xe3, 0x18, 0x56, 0xed, 0x8f, 0x25, 0x80, 0x7b, 0x81,
-	0x17, 0x06, 0x3e, 0xf5, 0x59, 0x8c, 0xae, 0x43, 0x35, 0xb6, 0x77, 0xa9, 0x47, 0x62, 0xb5, 0x56,
-	0x4b, 0xe9, 0x01, 0x1e, 0x4a, 0x12, 0xd6, 0x18, 0x74, 0x9b, 0xaf, 0x53, 0x1c, 0x06, 0x7e, 0x4c,
-	0x63, 0xf3, 0x3a, 0x29, 0x22, 0x1e, 0xe2, 0xd0, 0x5b, 0x00, 0x21, 0x89, 0x88, 0x47, 0x19, 0x8d,
-	0xe2, 0x66, 0x31, 0xaf, 0x95, 0x5b, 0x09, 0x15, 0xa7, 0x90, 0xe8, 0x35, 0xa8, 0xd1, 0x7d, 0xe2,
-	0x85, 0x2e, 0x8d, 0x9b, 0xa5, 0xfc, 0x46, 0xae, 0x2b, 0x1a, 0x4e, 0x50, 0xe8, 0x3d, 0x98, 0x8f,
-	0xe8, 0x17, 0x7d, 0x1a, 0xb3, 0xce, 0x76, 0xd0, 0x75, 0x68, 0xdc, 0x2c, 0x0b, 0xbe, 0xd3, 0xd9,
-	0x39, 0x0a, 0xc4, 0x5d, 0x01, 0xc0, 0x73, 0x51, 0xba, 0xc9, 0xd7, 0x63, 0x97, 0x92, 0x2e, 0x9f,
-	0x68, 0x25, 0xbf, 0x1e, 0x1b, 0x92, 0x84, 0x35, 0x06, 0x3d, 0x80, 0x85, 0x98, 0xda, 0xfd, 0xc8,
-	0x61, 0x83, 0x8e, 0x58, 0x23, 0x1a, 0x37, 0xab, 0x82, 0xef, 0x4c, 0x66, 0x1d, 0x15, 0xe6, 0xa1,
-	0x84, 0xe0, 0x13, 0x71, 0xb6, 0x03, 0xbd, 0x04, 0x65, 0xd7, 0xf1, 0x1f, 0xc7, 0xcd, 0x9a, 0x60,
-	0x5e, 0x4c, 0x33, 0xbf, 0xcf, 0x09, 0x58, 0xd2, 0xf9, 0x06, 0x68, 0xa5, 0x8d, 0x9b, 0xf5, 0xfc,
-	0x06, 0x24, 0x4a, 0x88, 0x87, 0xb8, 0x49, 0x5a, 0x05, 0xd3, 0xd1, 0xaa, 0x6f, 0x2c, 0xa8, 0xde,
-	0x0b, 0x7c, 0x46, 0x6c, 0xc6, 0x0d, 0x9a, 0xd2, 0x7e, 0x61, 0xd0, 0x84, 0x5e, 0x2f, 0x40, 0xb1,
-	0x1f, 0x69, 0x1b, 0xc7, 0x7f, 0xa2, 0x65, 0x28, 0x53, 0x8f, 0x38, 0xae, 0xd0, 0x87, 0x3a, 0x96,
-	0x8d, 0x49, 0x33, 0x2d, 0x4d, 0x67, 0xa6, 0xf7, 0xe5, 0x44, 0xa9, 0xcf, 0xb8, 0x75, 0xf3, 0x68,
-	0xd7, 0x21, 0x1d, 0x36, 0x08, 0xf5, 0x61, 0x5d, 0xcd, 0xc9, 0xff, 0x80, 0x43, 0x1e, 0x0d, 0x42,
-	0x8a, 0xeb, 0x9e, 0xfe, 0xd9, 0xfa, 0xb6, 0x08, 0xb5, 0xfb, 0x81, 0xdd, 0xf7, 0xb8, 0x9c, 0x26,
-	0x54, 0x15, 0x93, 0xfa, 0x66, 0xdd, 0x44, 0x57, 0xa0, 0xe4, 0xf8, 0xbd, 0x40, 0x9d, 0x94, 0x85,
-	0xb4, 0xec, 0x4d, 0xbf, 0x17, 0x60, 0x41, 0x45, 0xaf, 0x42, 0x35, 0xa6, 0xd1, 0x9e, 0x3c, 0x1c,
-	0x7c, 0x12, 0x28, 0xab, 0x3b, 0x9c, 0x84, 0x35, 0x84, 0xab, 0x4a, 0x48, 0xd8, 0xae, 0x3e, 0x12,
-	0x8b, 0xd9, 0x83, 0xc4, 0x76, 0x63, 0x2c, 0xe9, 0xfc, 0xd8, 0xd9, 0xc9, 0x41, 0x57, 0x07, 0x21,
-	0x73, 0xec, 0x86, 0x66, 0x00, 0xa7, 0x90, 0xe8, 0xbf, 0xa0, 0xa6, 0xd5, 0xb3, 0x59, 0x11, 0xf3,
-	0xb9, 0x60, 0xd2, 0x65, 0x7e, 0x8c, 0x9c, 0x88, 0xf2, 0x15, 0xc0, 0x09, 0x03, 0xba, 0x0c, 0x25,
-	0x46, 0x76, 0xf8, 0x21, 0x28, 0x8e, 0xfa, 0xa9, 0x47, 0x64, 0x07, 0x0b, 0x22, 0x7a, 0x17, 0xe6,
-	0xf8, 0xbe, 0x46, 0x3e, 0x71, 0x3b, 0xdd, 0xc0, 0xd6, 0x5a, 0xdf, 0xcc, 0x9e, 0x6e, 0x09, 0xb8,
-	0x1f, 0xd8, 0x31, 0x9e, 0xa5, 0xa9, 0xd6, 0x24, 0x25, 0xa9, 0x4f, 0x47, 0x49, 0x36, 0xa1, 0xb6,
-	0xee, 0xdb, 0x41, 0xd7, 0xf1, 0x77, 0xd0, 0xbb, 0x50, 0x0b, 0xa3, 0x20, 0xa4, 0x11, 0x1b, 0x28,
-	0x1d, 0xb9, 0x94, 0x13, 0xaf, 0xc1, 0x5b, 0x0a, 0x88, 0x13, 0x96, 0xd6, 0x3f, 0x2c, 0x58, 0x18,
-	0x25, 0xa3, 0x4b, 0x30, 0x6b, 0x4b, 0x25, 0xd4, 0xba, 0xc7, 0xd5, 0xa6, 0xa1, 0xfa, 0xb8, 0x86,
-	0x71, 0xa5, 0xd0, 0x86, 0x48, 0x6a, 0x4f, 0x46, 0x29, 0xda, 0xdb, 0xdf, 0xa7, 0x36, 0x1b, 0xda,
-	0xa1, 0x65, 0x28, 0xc7, 0x6c, 0xe0, 0x52, 0x7d, 0x9a, 0x44, 0x83, 0x2b, 0x26, 0xdd, 0x0f, 0xdd,
-	0xa0, 0x4b, 0x85, 0xb2, 0xd4, 0xb0, 0x6e, 0x4e, 0x5a, 0xc2, 0xf2, 0x74, 0x96, 0xb0, 0x0e, 0x55,
-	0x65, 0xa0, 0x5b, 0x3f, 0xb6, 0x00, 0xa9, 0xdf, 0x69, 0x3f, 0x7d, 0x93, 0xcf, 0x4e, 0xf4, 0x9a,
-	0x5c, 0x8f, 0x62, 0xd8, 0x98, 0xc1, 0x1a, 0x75, 0x64, 0x27, 0x0d, 0x50, 0xd3, 0x3e, 0xa3, 0xf5,
-	0x09, 0x40, 0x2a, 0xb8, 0xda, 0x84, 0x93, 0xa4, 0xdb, 0x75, 0xf8, 0x17, 0x10, 0xb7, 0xa3, 0xf6,
-	0x8e, 0xbb, 0x0f, 0xb9, 0xe1, 0xcb, 0xb9, 0xc5, 0xe0, 0xf1, 0xdb, 0xf2, 0x90, 0x65, 0x2b, 0xe1,
-	0x68, 0xfd, 0xda, 0x82, 0xd9, 0xb4, 0xee, 0xa2, 0x8b, 0xd0, 0xe8, 0xd2, 0xd8, 0x8e, 0x9c, 0x90,
-	0xc9, 0x20, 0x4a, 0x6c, 0x75, 0xaa, 0xcb, 0x60, 0x1c, 0x27, 0x6c, 0x4f, 0x71, 0x3a, 0xdb, 0xf3,
-	0x5d, 0x11, 0x2a, 0xd2, 0x9b, 0x19, 0xed, 0xf5, 0x3c, 0x14, 0x1c, 0x5f, 0xcd, 0xa8, 0xe0, 0xf8,
-	0xa3, 0x1f, 0x51, 0xcc, 0x7f, 0xc4, 0x2a, 0xd4, 0x22, 0x69, 0x11, 0xba, 0x4a, 0xd9, 0x92, 0x36,
-	0x3a, 0x0f, 0xd0, 0xa5, 0x61, 0x44, 0x6d, 0xc2, 0x68, 0x57, 0x58, 0xa2, 0x1a, 0x4e, 0xf5, 0xa0,
-	0x6b, 0xb0, 0x48, 0x5c, 0x37, 0x78, 0xd2, 0xa1, 0x5e, 0xc8, 0x06, 0x1d, 0x19, 0x3a, 0x57, 0x04,
-	0xec, 0x84, 0x20, 0xac, 0xf3, 0xfe, 0x8f, 0x45, 0xb8, 0x9c, 0x68, 0x7a, 0x75, 0x8c, 0xa6, 0xd7,
-	0xb2, 0x9a, 0xfe, 0x02, 0xcc, 0x4b, 0xd9, 0x11, 0x15, 0x16, 0xb4, 0x2b, 0xbc, 0x66, 0x0d, 0xcf,
-	0x89, 0x5e, 0xac, 0x3a, 0xd1, 0x9b, 0x50, 0x91, 0x31, 0x4e, 0x13, 0x84, 0x62, 0x9d, 0xcb, 0x87,
-	0x41, 0xe9, 0x68, 0x4d, 0x81, 0xd1, 0x3b, 0xa9, 0x10, 0xa5, 0x21, 0x76, 0xe6, 0xbc, 0x41, 0x89,
-	0xd3, 0x9c, 0xc3, 0x60, 0xe5, 0xce, 0x50, 0xff, 0x67, 0xc5, 0x98, 0x07, 0xb1, 0x26, 0x07, 0xe1,
-	0x3a, 0x54, 0x95, 0xa9, 0x68, 0xce, 0xe5, 0x4f, 0x8e, 0x72, 0x6f, 0x58, 0x63, 0x5a, 0x5f, 0x5a,
-	0xb0, 0x28, 0xf7, 0x3a, 0x7d, 0xfc, 0x5e, 0x85, 0x8a, 0xb4, 0x1e, 0xea, 0xf4, 0xa1, 0x7c, 0xa0,
-	0xb3, 0x31, 0x83, 0x15, 0xe6, 0xc8, 0x67, 0x6f, 0x0d, 0xaa, 0x2a, 0x78, 0x42, 0x6f, 0x65, 0xa2,
-	0xe3, 0x56, 0x4e, 0x93, 0x73, 0x53, 0x95, 0x3a, 0xd9, 0xfa, 0x5d, 0x01, 0x4a, 0xdc, 0x6b, 0x72,
-	0x15, 0x60, 0x0e, 0x73, 0xb5, 0xc6, 0xca, 0xc6, 0xa8, 0x8a, 0x16, 0xf2, 0x2a, 0x7a, 0x15, 0x16,
-	0x18, 0x8d, 0xbc, 0xb8, 0x13, 0xf4, 0x3a, 0x7c, 0xdb, 0x1d, 0x5b, 0xdb, 0xcb, 0x79, 0xd1, 0xdf,
-	0xee, 0x3d, 0x94, 0xbd, 0x7a, 0x81, 0x89, 0xcd, 0x94, 0x97, 0xcd, 0x2d, 0x30, 0xb1, 0xd5, 0x02,
-	0xf3, 0x88, 0xe7, 0x3a, 0x54, 0x5d, 0xc7, 0xa6, 0x7e, 0x4c, 0x95, 0x9b, 0x5d, 0xca, 0xc6, 0x6f,
-	0x82, 0x84, 0x35, 0x86, 0x2b, 0x2b, 0xf7, 0xe4, 0x7c, 0x96, 0x15, 0x19, 0x2f, 0xa8, 0xe6, 0xa4,
-	0x73, 0x5f, 0x9d, 0xce, 0xb9, 0xff, 0x0c, 0xea, 0x3c, 0x55, 0x8b, 0xf9, 0x3f, 0xe8, 0x03, 0x58,
-	0x92, 0x7a, 0xdc, 0x09, 0xa2, 0xce, 0x70, 0x7b, 0xe5, 0xc6, 0x1c, 0x70, 0x02, 0x16, 0xe3, 0xd1,
-	0xae, 0xd6, 0x4f, 0x2c, 0xa8, 0xaa, 0x8f, 0x3d, 0x64, 0x10, 0xf8, 0xfc, 0xed, 0xdc, 0x6f, 0x0b,
-	0x50, 0xe2, 0x01, 0x34, 0x9f, 0xd0, 0x6e, 0x44, 0x7b, 0x7a, 0x42, 0xfc, 0x37, 0x77, 0xc3, 0xdc,
-	0x6e, 0xcb, 0xa1, 0x9d, 0xae, 0xd6, 0x99, 0xa4, 0x6f, 0xb3, 0x8b, 0xde, 0x31, 0xe4, 0x2e, 0xab,
-	0xa3, 0xd1, 0xf9, 0x98, 0xfc, 0x25, 0x95, 0x4b, 0x94, 0x0e, 0x91, 0x4b, 0x8c, 0x28, 0x70, 0x39,
-	0xaf, 0xc0, 0x13, 0x96, 0xab, 0x32, 0x9d, 0xe5, 0x1a, 0xc0, 0x09, 0xfe, 0x41, 0x69, 0x3b, 0xf1,
-	0x22, 0x94, 0x78, 0xea, 0xa1, 0xac, 0xc4, 0xc2, 0xe8, 0xb7, 0x6f, 0xcc, 0x60, 0x41, 0x3f, 0xb2,
-	0x85, 0x78, 0x00, 0xf3, 0xd9, 0xb5, 0x44, 0x6f, 0x64, 0x0c, 0xc5, 0x45, 0x93, 0x13, 0x4e, 0x97,
-	0x48, 0x94, 0x99, 0x78, 0x17, 0xca, 0x22, 0x63, 0x3a, 0x90, 0x7d, 0xe4, 0x43, 0x15, 0xfb, 0xef,
-	0x0b, 0x50, 0x4f, 0x42, 0xfe, 0x94, 0x5b, 0xb0, 0x9e, 0xd5, 0x2d, 0x14, 0x9e, 0xdd, 0x2d, 0x14,
-	0x9f, 0xce, 0x2d, 0xf0, 0x7c, 0x59, 0x45, 0x9a, 0xc6, 0x7c, 0x59, 0xd1, 0x70, 0x82, 0x3a, 0x86,
-	0x30, 0x70, 0x1d, 0x6a, 0x7a, 0xaf, 0x8c, 0x36, 0xe1, 0x05, 0x5d, 0x29, 0x2b, 0x18, 0x0b, 0x5b,
-	0xaa, 0x48, 0xd6, 0xfa, 0x1c, 0x96, 0x4d, 0x5b, 0x6e, 0x14, 0xf9, 0x7a, 0x56, 0xe4, 0x99, 0x11,
-	0x91, 0x19, 0x95, 0x51, 0xe2, 0x29, 0x34, 0xc7, 0x15, 0x66, 0x8c, 0x43, 0xbc, 0x99, 0x1d, 0xe2,
-	0x82, 0x29, 0x03, 0x4f, 0x6f, 0x91, 0x1a, 0xa6, 0x03, 0x27, 0x8d, 0xe9, 0x82, 0x71, 0x8c, 0x5b,
-	0xd9, 0x31, 0xce, 0x9a, 0xb6, 0x32, 0xc9, 0x37, 0xd4, 0x00, 0x04, 0x56, 0xcc, 0x2e, 0xd4, 0x38,
-	0xc2, 0xed, 0xec, 0x08, 0xe7, 0xf2, 0xd6, 0xc9, 0xf0, 0x0d, 0x7a, 0x27, 0x46, 0xcd, 0xc4, 0xd3,
-	0xee, 0xc4, 0xe8, 0xe9, 0x53, 0xe2, 0x3f, 0x84, 0xf9, 0x6c, 0xd6, 0x6d, 0x14, 0xfc, 0x4a, 0x56,
-	0x70, 0xc6, 0xce, 0x0c, 0xf3, 0xf5, 0x11, 0x91, 0x89, 0x65, 0x79, 0x6a, 0x91, 0x09, 0xa7, 0x16,
-	0xd9, 0x86, 0xb9, 0x4c, 0xe5, 0xd3, 0x28, 0xf1, 0x5a, 0x56, 0xe2, 0xf2, 0x68, 0xa2, 0x2e, 0x4a,
-	0xa6, 0x4a, 0xe0, 0x47, 0xb0, 0x20, 0x04, 0x0e, 0x6b, 0x53, 0x66, 0xa5, 0xb8, 0x9e, 0x95, 0x79,
-	0xca, 0x5c, 0xd7, 0x1a, 0x8c, 0xea, 0xb5, 0x2e, 0xcb, 0x1d, 0x45, 0xaf, 0x0d, 0x32, 0xf4, 0x30,
-	0xff, 0x0b, 0x0d, 0x69, 0x1b, 0xa4, 0xf5, 0x33, 0x49, 0xbe, 0x9a, 0x95, 0x8c, 0xf2, 0x76, 0x54,
-	0x0b, 0xfb, 0x7f, 0x58, 0x92, 0xc2, 0x32, 0x25, 0x32, 0xa3, 0xd0, 0xd7, 0xb2, 0x42, 0x57, 0xc7,
-	0x97, 0xdc, 0xf2, 0xc2, 0xa3, 0x3d, 0x1a, 0x7d, 0x4c, 0x22, 0x87, 0x6c, 0xbb, 0xcf, 0x22, 0x3c,
-	0xcd, 0xae, 0x85, 0x3f, 0x86, 0x33, 0x13, 0x4c, 0xa4, 0x71, 0x90, 0x3b, 0xd9, 0x41, 0x32, 0xc1,
-	0xf0, 0x18, 0x4b, 0xab, 0x06, 0xfb, 0x51, 0x01, 0xea, 0x6d, 0xd2, 0x67, 0xbb, 0x0f, 0xdc, 0xe0,
-	0x09, 0x7a, 0x05, 0x16, 0xf9, 0xef, 0x20, 0x72, 0x7e, 0x20, 0x0d, 0x39, 0x0f, 0xb4, 0xe4, 0x40,
-	0x0b, 0x19, 0xc2, 0x47, 0x91, 0x8b, 0xce, 0x40, 0x9d, 0x05, 0x8f, 0xa9, 0x04, 0xc9, 0x98, 0xa7,
-	0x26, 0x3a, 0x38, 0xf1, 0x02, 0x34, 0x22, 0xda, 0x8b, 0x68, 0xbc, 0x2b, 0xc8, 0x32, 0x3e, 0x06,
-	0xd5, 0xc5, 0x01, 0xd7, 0xb8, 0x4b, 0x0c, 0xc2, 0xa4, 0x26, 0x3b, 0xb2, 0x95, 0x9c, 0x82, 0x15,
-	0xe2, 0x18, 0xfc, 0xcb, 0x9f, 0x0b, 0x00, 0xc9, 0x32, 0xc4, 0xe8, 0x75, 0xa8, 0x39, 0x5e, 0xe8,
-	0x3a, 0xb6, 0xc3, 0x94, 0xc7, 0xce, 0x1c, 0xe4, 0x04, 0x89, 0x13, 0x18, 0x67, 0x09, 0x49, 0x1c,
-	0x3f, 0x09, 0xa2, 0xae, 0xe9, 0xec, 0xa7, 0x58, 0x34, 0x0c, 0xdd, 0x07, 0x64, 0xbb, 0x0e, 0xf5,
-	0x59, 0xc7, 0x8e, 0x68, 0x97, 0xfa, 0xcc, 0x21, 0xae, 0x0e, 0x0e, 0xc7, 0x30, 0x2f, 0x4a, 0x86,
-	0x7b, 0x43, 0x3c, 0x97, 0x92, 0xdd, 0x33, 0x5b, 0x17, 0x6a, 0xc6, 0x4b, 0xc9, 0x30, 0xdc, 0x3b,
-	0x9e, 0x4a, 0xce, 0x43, 0xa8, 0xc8, 0x72, 0xd3, 0x34, 0xcb, 0x24, 0xbf, 0x2c, 0x43, 0xbd, 0xad,
-	0xe3, 0x6c, 0x7e, 0x34, 0x44, 0xd5, 0x90, 0xcb, 0xa9, 0xab, 0x22, 0x61, 0x13, 0xaa, 0x71, 0xdf,
-	0xf3, 0x48, 0x34, 0x50, 0x3a, 0xaa, 0x9b, 0x87, 0x28, 0x46, 0xe4, 0x0a, 0x8c, 0xa5, 0xa7, 0x2a,
-	0x30, 0x8e, 0xe6, 0x05, 0xe5, 0x7c, 0x5e, 0xf0, 0x5e, 0x26, 0x2f, 0xa8, 0xe4, 0x43, 0xcc, 0xc4,
-	0x67, 0xa4, 0xed, 0x65, 0x3a, 0x3b, 0x58, 0x87, 0xd9, 0xd4, 0x5d, 0xc5, 0x40, 0x5d, 0x1b, 0xb4,
-	0xc6, 0x58, 0xf4, 0xb4, 0x94, 0x46, 0x94, 0xf2, 0x12, 0x99, 0x1b, 0x99, 0xda, 0x21, 0x6f, 0x64,
-	0x9e, 0xe9, 0x16, 0x21, 0x5b, 0xc5, 0x81, 0x5c, 0x15, 0x27, 0x5d, 0x37, 0x6e, 0x3c, 0x6d, 0xdd,
-	0x38, 0x55, 0x03, 0x9f, 0x35, 0x98, 0x95, 0x91, 0x1a, 0xf8, 0x04, 0xa5, 0x9f, 0x9b, 0x8e, 0xd2,
-	0xff, 0xa1, 0x04, 0xf5, 0xc9, 0x01, 0xc3, 0x7f, 0x4a, 0x64, 0xff, 0x3e, 0x25, 0xb2, 0x49, 0x0a,
-	0x35, 0x3f, 0x1d, 0x85, 0xfa, 0xda, 0x82, 0x65, 0x93, 0x4d, 0xe0, 0x79, 0x73, 0x62, 0x15, 0x4c,
-	0x3e, 0x2b, 0x61, 0xe2, 0x79, 0x73, 0x82, 0x3c, 0x72, 0xba, 0xfd, 0x39, 0x40, 0x2a, 0xd5, 0x6e,
-	0x4f, 0xb6, 0xec, 0xab, 0x86, 0x3b, 0x7f, 0x1d, 0x12, 0x9b, 0xed, 0xfb, 0xd7, 0x65, 0xa8, 0x25,
-	0xd1, 0xf1, 0x22, 0x94, 0x3a, 0xc3, 0xda, 0x4b, 0x31, 0xa2, 0xbd, 0x23, 0x59, 0xf7, 0x97, 0xa0,
-	0xb8, 0x43, 0x99, 0xd1, 0x53, 0x6a, 0x0b, 0x8d, 0x39, 0x82, 0x03, 0xc3, 0x3e, 0x53, 0x35, 0xb9,
-	0x71, 0xc0, 0xb0, 0xcf, 0xd0, 0xcb, 0x50, 0x0a, 0x83, 0x98, 0xa9, 0x2b, 0xdf, 0x31, 0x48, 0x01,
-	0x41, 0xd7, 0xa1, 0xd2, 0xa5, 0x2e, 0x65, 0x54, 0x19, 0xec, 0x31, 0x60, 0x05, 0x42, 0x37, 0xa1,
-	0x1a, 0x88, 0x59, 0x1b, 0x8d, 0xf3, 0x10, 0xaf, 0x51, 0x7c, 0x2a, 0xbb, 0x94, 0x74, 0x4d, 0x56,
-	0x39, 0x35, 0x15, 0x0e, 0xe1, 0x29, 0x4b, 0x48, 0x98, 0xbd, 0xab, 0xce, 0xe3, 0x18, 0xac, 0xc4,
-	0x70, 0x30, 0x8b, 0x88, 0x4d, 0x9b, 0x8d, 0x89, 0x60, 0x81, 0x79, 0x4a, 0x6b, 0x9c, 0xf5, 0x85,
-	0x73, 0xcf, 0xe0, 0x0b, 0x9f, 0xff, 0xf1, 0xfb, 0x85, 0x05, 0x65, 0x71, 0x3b, 0x8a, 0xae, 0x43,
-	0x29, 0x24, 0x6c, 0xf7, 0xe0, 0xd7, 0x2c, 0x02, 0x76, 0x0c, 0x2f, 0x32, 0x7e, 0x68, 0x41, 0x7d,
-	0x2b, 0x72, 0x3c, 0x87, 0x39, 0x7b, 0x14, 0xad, 0x42, 0xd5, 0xf1, 0x19, 0xdd, 0x51, 0xc6, 0xa0,
-	0xb8, 0x31, 0x83, 0x75, 0x07, 0x6a, 0x42, 0xc5, 0xef, 0x7b, 0xdb, 0x34, 0x12, 0x67, 0xc6, 0xda,
-	0x98, 0xc1, 0xaa, 0xcd, 0xb9, 0xb6, 0x83, 0xc0, 0xa5, 0x44, 0x1e, 0x98, 0x1a, 0xe7, 0x52, 0x1d,
-	0x9c, 0x2b, 0x66, 0x91, 0x2e, 0x0a, 0xd5, 0x39, 0x97, 0x6c, 0x0f, 0x8d, 0xc1, 0x67, 0x00, 0xc3,
-	0xb3, 0x8b, 0xde, 0x9f, 0x6c, 0x0c, 0x4e, 0xe5, 0x3f, 0x58, 0x26, 0x70, 0x66, 0x4b, 0x70, 0x1e,
-	0xea, 0x43, 0x63, 0x97, 0xb7, 0x04, 0xad, 0x6d, 0x98, 0xcb, 0xbc, 0xc8, 0x40, 0x1f, 0x4e, 0x1e,
-	0xfe, 0x6c, 0x6e, 0xf8, 0x74, 0xe2, 0x6b, 0x9e, 0xc3, 0xb7, 0x16, 0x34, 0xd2, 0xa9, 0xf5, 0xc1,
-	0x77, 0x72, 0x29, 0xff, 0x51, 0x38, 0x84, 0xff, 0x48, 0xbb, 0xf6, 0xe2, 0x88, 0x6b, 0x7f, 0xfe,
-	0x6f, 0x1a, 0x7e, 0x6e, 0xc1, 0x8a, 0x39, 0x56, 0x44, 0xff, 0x3d, 0x12, 0x65, 0x5a, 0x13, 0xeb,
-	0x06, 0x1b, 0x33, 0xd9, 0xe0, 0xf2, 0xa8, 0x4e, 0xe6, 0x67, 0x05, 0xa8, 0xe9, 0x00, 0xf4, 0x70,
-	0x8b, 0x9e, 0xbd, 0xf3, 0x9e, 0x5c, 0x30, 0x4f, 0xed, 0x51, 0xf1, 0x10, 0x7b, 0x94, 0xbc, 0xb1,
-	0x29, 0x1d, 0xf0, 0xc6, 0xe6, 0xf9, 0xa7, 0x54, 0x5f, 0x59, 0xb0, 0x64, 0xaa, 0xc9, 0xdc, 0xe2,
-	0x6a, 0x24, 0xbb, 0x4d, 0x4f, 0xd7, 0x34, 0xcb, 0xc6, 0x0c, 0x4e, 0x70, 0x47, 0xde, 0xa3, 0xbf,
-	0x5b, 0xfc, 0x80, 0xea, 0xcc, 0xe0, 0x6d, 0xa8, 0x76, 0x69, 0x8f, 0xf4, 0x5d, 0x9d, 0x3f, 0x1f,
-	0x58, 0x03, 0xd2, 0x78, 0xb4, 0x09, 0x73, 0x7a, 0x52, 0x32, 0x95, 0x2d, 0x8c, 0x79, 0xfe, 0x66,
-	0x92, 0x32, 0xab, 0x59, 0x0f, 0x4a, 0x6a, 0xa7, 0x74, 0x2f, 0xf4, 0xd7, 0x3a, 0x54, 0x54, 0xb9,
-	0x6a, 0x15, 0x6a, 0x7e, 0xdf, 0x75, 0xc9, 0xb6, 0xba, 0x51, 0xac, 0xe1, 0xa4, 0x8d, 0xae, 0xc0,
-	0x5c, 0xd7, 0xe1, 0x0a, 0xea, 0x39, 0x3e, 0x61, 0x41, 0xa4, 0x82, 0x95, 0x6c, 0x27, 0x3a, 0xc3,
-	0xb7, 0x80, 0x74, 0x3b, 0x81, 0xef, 0x0e, 0x86, 0xc7, 0x9f, 0x74, 0xdb, 0xbe, 0x3b, 0x40, 0xe7,
-	0x00, 0x9e, 0x44, 0x0e, 0xa3, 0x92, 0x2a, 0xe3, 0xfe, 0xba, 0xe8, 0x11, 0xe4, 0x4b, 0x50, 0xdc,
-	0xf7, 0x5c, 0x15, 0xa3, 0x64, 0xca, 0xdf, 0x9f, 0x7a, 0x2e, 0xe6, 0xb4, 0x7c, 0x36, 0x5b, 0x79,
-	0xaa, 0x6c, 0x36, 0x9b, 0x5a, 0x54, 0x73, 0xa9, 0x45, 0x72, 0x9d, 0x5a, 0x4b, 0x5f, 0xa7, 0x5e,
-	0x80, 0x86, 0xd7, 0x77, 0x99, 0x13, 0xba, 0xb4, 0x13, 0xf4, 0x44, 0x38, 0x62, 0x61, 0xd0, 0x5d,
-	0x6d, 0x11, 0xc1, 0x79, 0x64, 0xdf, 0xf1, 0xfa, 0x9e, 0x88, 0x3f, 0x2c, 0xac, 0x9b, 0xe8, 0x15,
-	0x58, 0xa4, 0xfb, 0xb6, 0xdb, 0x8f, 0x9d, 0x3d, 0xda, 0xd1, 0x98, 0x86, 0x18, 0x77, 0x21, 0x21,
-	0x7c, 0xa0, 0xc0, 0x5c, 0x8c, 0xe3, 0x0b, 0xc8, 0xac, 0x12, 0x23, 0x9b, 0x23, 0x62, 0x14, 0x66,
-	0x6e, 0x54, 0x8c, 0x02, 0x9f, 0x03, 0xf0, 0xc8, 0x7e, 0xc7, 0xa5, 0xfe, 0x0e, 0xdb, 0x6d, 0xce,
-	0x73, 0xcf, 0x89, 0xeb, 0x1e, 0xd9, 0x7f, 0x5f, 0x74, 0x08, 0xb2, 0xe3, 0x6b, 0xf2, 0x09, 0x45,
-	0x76, 0x7c, 0x45, 0x6e, 0x42, 0x35, 0x24, 0x8c, 0xaf, 0x59, 0x73, 0x41, 0x46, 0xa3, 0xaa, 0xc9,
-	0xb7, 0x96, 0xcb, 0x75, 0x18, 0xf5, 0xe2, 0xe6, 0xa2, 0xe0, 0xab, 0x79, 0x64, 0x5f, 0xdc, 0xa1,
-	0x0a, 0xa2, 0xe3, 0x2b, 0x22, 0x52, 0x44, 0xc7, 0x97, 0xc4, 0x4b, 0x30, 0xdb, 0xf7, 0x9d, 0x2f,
-	0xfa, 0x54, 0xd1, 0x97, 0xc4, 0xcc, 0x1b, 0xb2, 0x4f, 0x42, 0x5e, 0x80, 0x79, 0x2e, 0x3c, 0xe5,
-	0xe2, 0x96, 0x85, 0x90, 0x39, 0x8f, 0xec, 0xa7, 0xfc, 0x31, 0x87, 0x39, 0x7e, 0x1a, 0x76, 0x52,
-	0xc1, 0x1c, 0x3f, 0x05, 0x4b, 0xfb, 0xa0, 0x15, 0x51, 0x48, 0x19, 0xfa, 0xa0, 0xcb, 0x50, 0xa2,
-	0x7e, 0xdf, 0x6b, 0x9e, 0xca, 0x3f, 0xcb, 0x5a, 0xf3, 0x07, 0x58, 0x10, 0x45, 0x15, 0x66, 0x10,
-	0xd2, 0x66, 0x53, 0x66, 0xb9, 0xfc, 0x37, 0x7a, 0x03, 0x2a, 0xc4, 0x75, 0xb9, 0x06, 0x9c, 0x3e,
-	0xcc, 0xad, 0x70, 0x99, 0xb8, 0x6e, 0xbb, 0xc7, 0xb9, 0x02, 0x5f, 0xe8, 0xcd, 0xea, 0xa1, 0xb8,
-	0x02, 0x9f, 0x4a, 0x2e, 0xe2, 0x0f, 0x38, 0xd7, 0x99, 0xc3, 0x8d, 0xe5, 0x0f, 0xda, 0x3d, 0x74,
-	0x05, 0x8a, 0x7e, 0xc0, 0x9a, 0x67, 0xc7, 0xd6, 0x95, 0x39, 0x99, 0x87, 0xbf, 0x72, 0x1b, 0xce,
-	0xe5, 0x2d, 0x64, 0x72, 0x21, 0x8e, 0x25, 0x46, 0x3c, 0x58, 0x1d, 0x2e, 0xf6, 0x79, 0xc3, 0x83,
-	0xd5, 0x84, 0x8a, 0x53, 0xc8, 0x51, 0x0f, 0x77, 0x21, 0xef, 0xe1, 0x56, 0xa0, 0xd2, 0x0b, 0x22,
-	0x8f, 0xb0, 0xe6, 0x45, 0x41, 0x54, 0xad, 0x49, 0x06, 0xef, 0xd2, 0x74, 0x0c, 0xde, 0x97, 0x16,
-	0x2c, 0xe6, 0xd6, 0x10, 0xbd, 0x3a, 0x72, 0xbf, 0x69, 0x58, 0x3f, 0x11, 0x2f, 0x4a, 0x4b, 0x79,
-	0x54, 0x57, 0xf3, 0x09, 0x54, 0xd5, 0x8b, 0xe2, 0x29, 0xc7, 0x98, 0x3f, 0xb5, 0xb8, 0x35, 0x17,
-	0x25, 0xe7, 0xab, 0x99, 0x5b, 0x5f, 0x73, 0x49, 0xf2, 0xb8, 0xde, 0x5a, 0xff, 0x0f, 0x2c, 0x19,
-	0xaa, 0x5e, 0x87, 0x9f, 0x62, 0xeb, 0x2f, 0x05, 0x98, 0xcf, 0xdf, 0x83, 0xa4, 0x9e, 0x0c, 0xca,
-	0x43, 0x7a, 0xf0, 0xd3, 0x17, 0x5d, 0xc0, 0x2a, 0xe6, 0x0a, 0x58, 0xa5, 0xa4, 0x80, 0xb5, 0xa2,
-	0x74, 0x81, 0xaa, 0x7a, 0xa7, 0x6a, 0xa1, 0xcb, 0x30, 0xb7, 0x4d, 0x49, 0x44, 0xa3, 0x8e, 0x52,
-	0x5d, 0xf9, 0x68, 0x65, 0x56, 0x76, 0x3e, 0x90, 0x0a, 0x7c, 0x0d, 0x4a, 0x3d, 0x37, 0x78, 0xa2,
-	0x92, 0xe2, 0x15, 0x63, 0xf9, 0x3a, 0xc6, 0x02, 0x83, 0xae, 0xc3, 0x12, 0x27, 0x77, 0x9c, 0x6e,
-	0xc7, 0x0e, 0x7c, 0x9f, 0xda, 0x4c, 0x5c, 0x35, 0x48, 0xf7, 0xb3, 0xc0, 0x49, 0x9b, 0xdd, 0x7b,
-	0x92, 0xf0, 0xd1, 0xe4, 0x47, 0x22, 0x53, 0x7a, 0xee, 0xb9, 0x03, 0x27, 0x46, 0x5e, 0x68, 0xa3,
-	0x47, 0x93, 0xf5, 0xf3, 0x42, 0x7e, 0xc8, 0xec, 0x7d, 0x93, 0x59, 0x4f, 0xff, 0xc4, 0xf5, 0x54,
-	0x64, 0xcd, 0xfa, 0x31, 0x8c, 0x35, 0x7c, 0x0c, 0x73, 0xf0, 0x2e, 0xbe, 0x0d, 0xf5, 0x3d, 0x75,
-	0xe7, 0xa4, 0xaf, 0x1b, 0xce, 0x8c, 0xbf, 0x96, 0x8a, 0xf1, 0x10, 0x7d, 0x0c, 0x49, 0xc8, 0xdf,
-	0x2c, 0xae, 0xab, 0x99, 0x6b, 0xb5, 0x97, 0x95, 0xd7, 0x91, 0x6b, 0x96, 0xad, 0x6a, 0xe9, 0x84,
-	0x57, 0xf9, 0x9e, 0x9b, 0xc3, 0xb8, 0xd3, 0x74, 0x01, 0x9b, 0xa0, 0x93, 0x68, 0xf3, 0xe0, 0x32,
-	0xd1, 0xf3, 0xff, 0xe4, 0xdf, 0x58, 0x5c, 0x71, 0x32, 0x6b, 0x8e, 0x6e, 0x67, 0x0e, 0xb7, 0x49,
-	0x4f, 0x32, 0x57, 0x87, 0xc7, 0x65, 0x8a, 0xbe, 0xb6, 0x60, 0x65, 0xcc, 0xbd, 0xe4, 0xbf, 0xa4,
-	0xe2, 0x70, 0x19, 0x1a, 0x0f, 0x45, 0xd7, 0x5a, 0x14, 0x91, 0x01, 0x8f, 0x41, 0xf5, 0x1f, 0x4c,
-	0xf1, 0xc0, 0x45, 0xdd, 0x71, 0x7e, 0x6b, 0x41, 0xf1, 0x11, 0xd9, 0x31, 0x96, 0xdf, 0x0f, 0x3e,
-	0x2d, 0xb9, 0xb0, 0xb9, 0x38, 0xad, 0x57, 0xe6, 0x53, 0x52, 0x9f, 0xef, 0x2c, 0x28, 0x7e, 0xea,
-	0xb9, 0xc6, 0xcf, 0x3b, 0x0b, 0x75, 0xfe, 0x7f, 0x1c, 0x12, 0xe5, 0x6a, 0xeb, 0x78, 0xd8, 0xc1,
-	0x4d, 0x75, 0x18, 0xd1, 0x9e, 0xb3, 0xaf, 0xf4, 0x5e, 0xb5, 0x38, 0x17, 0x61, 0x2c, 0x72, 0xb6,
-	0xfb, 0x4c, 0x3f, 0xf9, 0x1e, 0x76, 0xf0, 0x28, 0xf7, 0x49, 0x44, 0xc2, 0x30, 0xb9, 0x60, 0xd0,
-	0xcd, 0xe7, 0xff, 0xb0, 0xec, 0xee, 0x8b, 0x30, 0x1f, 0x44, 0x3b, 0x5a, 0x4a, 0x67, 0xef, 0xf6,
-	0xdd, 0x59, 0xf5, 0x27, 0x76, 0x5b, 0x51, 0xc0, 0x82, 0x2d, 0xeb, 0x57, 0x85, 0x62, 0x7b, 0xed,
-	0xe1, 0x76, 0x45, 0xfc, 0x89, 0xdc, 0xed, 0x7f, 0x06, 0x00, 0x00, 0xff, 0xff, 0x01, 0x0d, 0x80,
-	0xb5, 0x8b, 0x37, 0x00, 0x00,
+	// 3370 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5b, 0xcd, 0x73, 0x1c, 0x47,
+	0xd9, 0xd7, 0xec, 0xf7, 0x3e, 0xab, 0xcf, 0xb6, 0x2c, 0x8f, 0x65, 0x27, 0x96, 0xd7, 0x7e, 0x13,
+	0xbf, 0x4e, 0x6c, 0x27, 0xb6, 0xe3, 0xd7, 0xc9, 0x8b, 0x8b, 0xc8, 0xb6, 0x8c, 0x54, 0x38, 0x48,
+	0x19, 0x39, 0x1f, 0x15, 0x2a, 0xb5, 0xb4, 0x66, 0x7b, 0xa5, 0xc1, 0xf3, 0x95, 0x99, 0x59, 0x59,
+	0xcb, 0x09, 0xaa, 0xe0, 0xc0, 0x21, 0x87, 0x54, 0x01, 0xc5, 0x15, 0x8a, 0xaa, 0x70, 0xe0, 0x1f,
+	0x80, 0x23, 0x67, 0x6e, 0x9c, 0xa0, 0x72, 0xe0, 0xc0, 0x1f, 0x40, 0x15, 0x45, 0x15, 0x47, 0xea,
+	0xe9, 0x8f, 0xd9, 0x99, 0x9d, 0xd1, 0xee, 0xca, 0x5a, 0x8b, 0x4b, 0x2e, 0xd2, 0x4c, 0xf7, 0xef,
+	0x79, 0xba, 0xa7, 0xfb, 0xf9, 0xee, 0x5e, 0x38, 0xbb, 0xe9, 0x33, 0x77, 0x75, 0x6b, 0x63, 0xff,
+	0xd6, 0x8d, 0xf8, 0xe9, 0xba, 0x1f, 0x78, 0x91, 0x47, 0xc0, 0xf3, 0x99, 0x4b, 0x7d, 0xeb, 0xfa,
+	0xfe, 0xad, 0xe5, 0xb3, 0xbb, 0x9e, 0xb7, 0x6b, 0xb3, 0x1b, 0xbc, 0x67, 0xa7, 0xdb, 0xb9, 0x41,
+	0xdd, 0x9e, 0x80, 0x2d, 0xbf, 0x3c, 0xd8, 0xf5, 0x2c, 0xa0, 0xbe, 0xcf, 0x82, 0x50, 0xf4, 0x37,
+	0xd7, 0xa0, 0xb8, 0xea, 0xf6, 0xc8, 0x55, 0x28, 0xef, 0x53, 0xbb, 0xcb, 0x74, 0x6d, 0x45, 0xbb,
+	0xd2, 0xb8, 0xb9, 0x78, 0x5d, 0x90, 0x5d, 0x57, 0x64, 0xd7, 0x57, 0xdd, 0x9e, 0x21, 0x20, 0x84,
+	0x40, 0xa9, 0x47, 0x1d, 0x5b, 0x2f, 0xac, 0x68, 0x57, 0xea, 0x06, 0x7f, 0x6e, 0xf6, 0x60, 0x6e,
+	0xd5, 0xed, 0x6d, 0x06, 0x6b, 0x07, 0x7e, 0xc0, 0xc2, 0xd0, 0xf2, 0x5c, 0x72, 0x09, 0x8a, 0xd4,
+	0xed, 0x49, 0x86, 0x73, 0xd7, 0xfb, 0xd3, 0x45, 0x5e, 0xeb, 0x53, 0x06, 0xf6, 0x92, 0xbb, 0x00,
+	0x2c, 0x26, 0xe1, 0x1c, 0x1b, 0x37, 0x97, 0x92, 0xd8, 0x3e, 0xc3, 0xf5, 0x29, 0x23, 0x81, 0xbd,
+	0x5f, 0x85, 0xb2, 0xe7, 0x32, 0xaf, 0xd3, 0xfc, 0x52, 0x83, 0xda, 0x03, 0x6a, 0xdb, 0x3b, 0xd4,
+	0x7c, 0x4a, 0xde, 0x4e, 0xf1, 0xd3, 0x56, 0x8a, 0x57, 0x1a, 0x37, 0xcf, 0x26, 0xf9, 0x7d, 0x87,
+	0x3a, 0xac, 0xbd, 0x45, 0xa3, 0xbd, 0x8d, 0x88, 0x39, 0x49, 0x86, 0xe4, 0x7b, 0x70, 0x26, 0xf4,
+	0x99, 0x69, 0x75, 0x2c, 0x93, 0x46, 0x96, 0xe7, 0xb6, 0xd8, 0x41, 0xc4, 0x5c, 0x39, 0x2f, 0xe4,
+	0xf3, 0x6a, 0x86, 0xcf, 0x76, 0x12, 0xbf, 0xa6, 0xe0, 0xc6, 0x52, 0x98, 0xdb, 0xde, 0xfc, 0xa9,
+	0x06, 0xa7, 0xd4, 0x4c, 0x37, 0x03, 0x83, 0x75, 0x58, 0xc0, 0x5c, 0x93, 0x91, 0x9b, 0x50, 0x33,
+	0x65, 0x73, 0xbc, 0xfe, 0x89, 0xa1, 0x14, 0xc9, 0xfa, 0x94, 0x11, 0xe3, 0xc8, 0x5b, 0x50, 0x0f,
+	0x14, 0x03, 0xb9, 0x6e, 0xa7, 0x93, 0x44, 0x31, 0xf7, 0xf5, 0x29, 0xa3, 0x8f, 0x4c, 0xad, 0x5a,
+	0x5d, 0x31, 0x0e, 0xc9, 0x5d, 0x28, 0xb9, 0xd4, 0x61, 0x72, 0xc1, 0x2e, 0x67, 0x3e, 0x34, 0x67,
+	0xd6, 0x06, 0xa7, 0x38, 0x81, 0x55, 0xfb, 0x73, 0x09, 0xe0, 0x81, 0xe7, 0xf8, 0x9e, 0xcb, 0xdc,
+	0x28, 0x24, 0xd7, 0xa0, 0x1a, 0x9a, 0x7b, 0xcc, 0xa1, 0xa1, 0x5c, 0xab, 0x53, 0xc9, 0x01, 0xb6,
+	0x45, 0x97, 0xa1, 0x30, 0xe4, 0x16, 0xae, 0x53, 0xe8, 0x7b, 0x6e, 0xc8, 0xc2, 0xfc, 0x75, 0x92,
+	0x9d, 0x46, 0x1f, 0x47, 0xee, 0x00, 0xf8, 0x34, 0xa0, 0x0e, 0x8b, 0x58, 0x10, 0xea, 0xc5, 0xac,
+	0x54, 0x6e, 0xc5, 0xbd, 0x46, 0x02, 0x49, 0xde, 0x80, 0x1a, 0x3b, 0xa0, 0x8e, 0x6f, 0xb3, 0x50,
+	0x2f, 0x65, 0x37, 0x72, 0x4d, 0xf6, 0x19, 0x31, 0x8a, 0xbc, 0x0b, 0xb3, 0x01, 0xfb, 0xac, 0xcb,
+	0xc2, 0xa8, 0xb5, 0xe3, 0xb5, 0x2d, 0x16, 0xea, 0xe5, 0x15, 0x6d, 0x50, 0x66, 0x0d, 0x81, 0xb8,
+	0xcf, 0x01, 0xc6, 0x4c, 0x90, 0x7c, 0xc5, 0xf5, 0xd8, 0x63, 0xb4, 0x8d, 0x13, 0xad, 0x64, 0xd7,
+	0x63, 0x5d, 0x74, 0x19, 0x0a, 0x43, 0x1e, 0xc1, 0x7c, 0xc8, 0xcc, 0x6e, 0x60, 0x45, 0xbd, 0x16,
+	0x5f, 0x23, 0x16, 0xea, 0x55, 0x4e, 0x77, 0x2e, 0xb5, 0x8e, 0x12, 0xb3, 0x2d, 0x20, 0xc6, 0x5c,
+	0x98, 0x6e, 0x20, 0xaf, 0x42, 0xd9, 0xb6, 0xdc, 0xa7, 0xa1, 0x5e, 0xe3, 0xc4, 0x0b, 0x49, 0xe2,
+	0xc7, 0xd8, 0x61, 0x88, 0x7e, 0xdc, 0x00, 0x25, 0xb4, 0xa1, 0x5e, 0xcf, 0x6e, 0x40, 0x2c, 0x84,
+	0x46, 0x1f, 0x37, 0x4c, 0xaa, 0x60, 0x32, 0x52, 0xf5, 0xa5, 0x06, 0xd5, 0x07, 0x9e, 0x1b, 0x51,
+	0x33, 0x42, 0x83, 0x26, 0xa5, 0x9f, 0x1b, 0x34, 0x7c, 0x26, 0xf3, 0x50, 0xec, 0x06, 0xca, 0xc6,
+	0xe1, 0x23, 0x59, 0x84, 0x32, 0x73, 0xa8, 0x65, 0x73, 0x79, 0xa8, 0x1b, 0xe2, 0x65, 0xd8, 0x4c,
+	0x4b, 0x93, 0x99, 0xe9, 0x43, 0x31, 0x51, 0xe6, 0x46, 0x68, 0xdd, 0x1c, 0xd6, 0xb6, 0x68, 0x2b,
+	0xea, 0xf9, 0x4a, 0x59, 0x97, 0x33, 0xfc, 0xdf, 0x43, 0xc8, 0x93, 0x9e, 0xcf, 0x8c, 0xba, 0xa3,
+	0x1e, 0x9b, 0x5f, 0x15, 0xa1, 0xf6, 0xd0, 0x33, 0xbb, 0x0e, 0xf2, 0xd1, 0xa1, 0x2a, 0x89, 0xe4,
+	0x37, 0xab, 0x57, 0x72, 0x19, 0x4a, 0x96, 0xdb, 0xf1, 0xa4, 0xa6, 0xcc, 0x27, 0x79, 0x6f, 0xb8,
+	0x1d, 0xcf, 0xe0, 0xbd, 0xe4, 0x75, 0xa8, 0x86, 0x2c, 0xd8, 0x17, 0xca, 0x81, 0x93, 0x20, 0x69,
+	0xd9, 0xc1, 0x2e, 0x43, 0x41, 0x50, 0x54, 0x7c, 0x1a, 0xed, 0x29, 0x95, 0x58, 0x48, 0x2b, 0x52,
+	0xb4, 0x17, 0x1a, 0xa2, 0x1f, 0xd5, 0xce, 0x8c, 0x15, 0x5d, 0x2f, 0x67, 0xd5, 0xae, 0x6f, 0x06,
+	0x8c, 0x04, 0x92, 0xfc, 0x3f, 0xd4, 0x94, 0x78, 0xea, 0x15, 0x3e, 0x9f, 0x0b, 0x79, 0xb2, 0x8c,
+	0x6a, 0x64, 0x05, 0x0c, 0x57, 0xc0, 0x88, 0x09, 0xc8, 0x25, 0x28, 0x45, 0x74, 0x17, 0x95, 0xa0,
+	0x38, 0xe8, 0xa7, 0x9e, 0xd0, 0x5d, 0x83, 0x77, 0x92, 0x7b, 0x30, 0x83, 0xfb, 0x1a, 0xb8, 0xd4,
+	0x6e, 0xb5, 0x3d, 0x53, 0x49, 0xbd, 0x9e, 0xd6, 0x6e, 0x01, 0x78, 0xe8, 0x99, 0xa1, 0x31, 0xcd,
+	0x12, 0x6f, 0xc3, 0x84, 0xa4, 0x3e, 0x19, 0x21, 0xd9, 0x80, 0xda, 0x9a, 0x6b, 0x7a, 0x6d, 0xcb,
+	0xdd, 0x25, 0xf7, 0xa0, 0xe6, 0x07, 0x9e, 0xcf, 0x82, 0xa8, 0x27, 0x65, 0xe4, 0x62, 0x86, 0xbd,
+	0x02, 0x6f, 0x49, 0xa0, 0x11, 0x93, 0x34, 0xbf, 0x28, 0xc0, 0xfc, 0x60, 0x37, 0xb9, 0x08, 0xd3,
+	0xa6, 0x10, 0x42, 0x25, 0x7b, 0x28, 0x36, 0x0d, 0xd9, 0x86, 0x12, 0x86, 0x42, 0xa1, 0x0c, 0x91,
+	0x90, 0x9e, 0x94, 0x50, 0x6c, 0xee, 0x7c, 0x9f, 0x99, 0x51, 0xdf, 0x0e, 0x2d, 0x42, 0x39, 0x8c,
+	0x7a, 0x36, 0x53, 0xda, 0xc4, 0x5f, 0xc8, 0x6d, 0xa8, 0xb2, 0x03, 0xdf, 0xf6, 0xda, 0x4c, 0x0a,
+	0xcb, 0x72, 0x26, 0x10, 0xb9, 0xef, 0x79, 0xf6, 0x87, 0x18, 0x87, 0x18, 0x0a, 0x3a, 0x6c, 0x79,
+	0xcb, 0x93, 0x59, 0xde, 0x3a, 0x54, 0xa5, 0xf1, 0x6e, 0xfe, 0x44, 0x03, 0x22, 0x9f, 0x93, 0x3e,
+	0xfc, 0x06, 0xce, 0x9c, 0xb7, 0xe6, 0xb9, 0x25, 0x49, 0xb0, 0x3e, 0x65, 0x28, 0xd4, 0xb1, 0x1d,
+	0x38, 0x40, 0x4d, 0xf9, 0x93, 0xe6, 0x47, 0x00, 0x89, 0xc0, 0x6b, 0x03, 0x4e, 0xd3, 0x76, 0xdb,
+	0xc2, 0x2f, 0xa0, 0x76, 0x4b, 0xee, 0x2b, 0xba, 0x16, 0x21, 0x0c, 0x8b, 0x99, 0xc5, 0xc0, 0xd8,
+	0x6e, 0xb1, 0x4f, 0xb2, 0x15, 0x53, 0x34, 0x7f, 0xa3, 0xc1, 0x74, 0x52, 0xae, 0xc9, 0x0a, 0x34,
+	0xda, 0x2c, 0x34, 0x03, 0xcb, 0x8f, 0x44, 0x80, 0xc5, 0xc5, 0x20, 0xd1, 0x94, 0x63, 0x38, 0x87,
+	0x6c, 0x4f, 0x71, 0x32, 0xdb, 0xf3, 0xaf, 0x12, 0x54, 0x84, 0xa7, 0xcb, 0xb5, 0xe5, 0xb3, 0x50,
+	0xb0, 0x5c, 0x39, 0xa3, 0x82, 0xe5, 0x0e, 0x7e, 0x44, 0x31, 0xfb, 0x11, 0x77, 0xa0, 0x16, 0x08,
+	0x6b, 0xd1, 0x1e, 0x43, 0x10, 0x63, 0x2c, 0x79, 0x07, 0xa0, 0xcd, 0xfc, 0x80, 0x99, 0x34, 0x62,
+	0x6d, 0xbd, 0x3c, 0x92, 0x32, 0x81, 0x26, 0x8f, 0x60, 0x81, 0xda, 0xb6, 0xf7, 0xac, 0xc5, 0x1c,
+	0x3f, 0xea, 0xb5, 0x44, 0x38, 0x5e, 0x19, 0xc9, 0x62, 0x8e, 0x13, 0xad, 0x21, 0x0d, 0x6f, 0xe8,
+	0x6b, 0x56, 0xf5, 0x10, 0xcd, 0xaa, 0x8d, 0xaf, 0x59, 0xab, 0x30, 0x2b, 0xe6, 0x14, 0x30, 0x6e,
+	0xcd, 0xdb, 0x7a, 0x7d, 0x24, 0xf1, 0x0c, 0xa7, 0x30, 0x24, 0x01, 0x79, 0x0b, 0x2a, 0x22, 0x16,
+	0xd3, 0x81, 0x93, 0xbe, 0x94, 0x0d, 0xd7, 0x92, 0x51, 0xa5, 0x04, 0x93, 0x77, 0x12, 0xa1, 0x54,
+	0x83, 0x4b, 0xc9, 0xcb, 0x39, 0x0a, 0x95, 0xa4, 0x8c, 0xf1, 0xe4, 0x6e, 0x5f, 0x17, 0xa7, 0x57,
+	0xb4, 0x31, 0x48, 0x63, 0xa5, 0xbc, 0x06, 0x55, 0x69, 0xd2, 0xf4, 0x99, 0xac, 0x16, 0x4b, 0x37,
+	0x6c, 0x28, 0x4c, 0xf3, 0x47, 0x1a, 0x2c, 0x08, 0xb9, 0x4b, 0x9a, 0x82, 0xd7, 0xa1, 0x22, 0xac,
+	0x9c, 0xae, 0x65, 0xed, 0xa0, 0x80, 0xaf, 0x4f, 0x19, 0x12, 0x73, 0x6c, 0x3b, 0xb0, 0x0a, 0xd5,
+	0x75, 0x69, 0x53, 0xef, 0xa4, 0xa2, 0xf8, 0x66, 0x46, 0xab, 0x32, 0x53, 0x15, 0xfa, 0xd1, 0xfc,
+	0x63, 0x01, 0x4a, 0xe8, 0xdd, 0x51, 0x74, 0x22, 0x2b, 0xb2, 0x95, 0xf6, 0x88, 0x97, 0x41, 0x75,
+	0x29, 0x64, 0xd5, 0xe5, 0x0a, 0xcc, 0x47, 0x2c, 0x70, 0xc2, 0x96, 0xd7, 0x69, 0xe1, 0xb6, 0x5b,
+	0xa6, 0xb2, 0xeb, 0xb3, 0xbc, 0x7d, 0xb3, 0xb3, 0x2d, 0x5a, 0xd5, 0x02, 0x53, 0x33, 0xd2, 0x4b,
+	0xf9, 0x0b, 0x4c, 0x4d, 0xb9, 0xc0, 0x18, 0x99, 0x5d, 0x83, 0xaa, 0x6d, 0x99, 0xcc, 0x0d, 0x99,
+	0x5e, 0xce, 0xc2, 0x1f, 0x8b, 0x2e, 0x43, 0x61, 0x30, 0xae, 0xc1, 0x88, 0x03, 0x67, 0x59, 0x11,
+	0x71, 0x8d, 0x7c, 0x1d, 0x66, 0x83, 0xaa, 0x93, 0xb1, 0x41, 0x9f, 0x40, 0x1d, 0x53, 0xca, 0x10,
+	0xff, 0x90, 0xf7, 0xe0, 0x94, 0x90, 0xe3, 0x96, 0x17, 0xb4, 0xfa, 0xdb, 0x2b, 0x36, 0x66, 0x84,
+	0x06, 0x2c, 0x84, 0x83, 0x4d, 0xcd, 0x2f, 0x34, 0xa8, 0xca, 0x8f, 0x1d, 0x33, 0x58, 0x7d, 0xf1,
+	0x36, 0xf7, 0x77, 0x05, 0x28, 0x61, 0xa0, 0x8f, 0x13, 0xda, 0x0b, 0x58, 0x47, 0x4d, 0x08, 0x9f,
+	0x31, 0x5c, 0x40, 0x1f, 0x22, 0x86, 0xb6, 0xda, 0x4a, 0x66, 0xe2, 0xb6, 0x0d, 0x6e, 0x2a, 0x33,
+	0x39, 0xd6, 0xf2, 0x60, 0x16, 0x71, 0x48, 0x9e, 0x95, 0xc8, 0x79, 0x4a, 0x63, 0xe4, 0x3c, 0x03,
+	0x02, 0x5c, 0xce, 0x0a, 0xf0, 0x90, 0xe5, 0xaa, 0x4c, 0x66, 0xb9, 0x7a, 0x30, 0x87, 0x1f, 0x94,
+	0xb4, 0x13, 0xaf, 0x40, 0x09, 0x53, 0x24, 0x5d, 0xcb, 0xc6, 0xda, 0x08, 0x5d, 0x9f, 0x32, 0x78,
+	0xff, 0xb1, 0x2d, 0xc4, 0x23, 0x98, 0x4d, 0xaf, 0x25, 0xb9, 0x9d, 0x32, 0x14, 0x2b, 0x79, 0x01,
+	0x41, 0xb2, 0x94, 0x23, 0xcd, 0xc4, 0x3d, 0x28, 0x3f, 0xe6, 0x29, 0xdd, 0x28, 0xf2, 0x81, 0x0f,
+	0x95, 0xe4, 0x7f, 0x2a, 0x40, 0x3d, 0x4e, 0x4d, 0x12, 0x6e, 0x41, 0x7b, 0x5e, 0xb7, 0x50, 0x78,
+	0x7e, 0xb7, 0x50, 0x3c, 0x9a, 0x5b, 0xc0, 0xbc, 0x5e, 0x46, 0xc4, 0xb9, 0x79, 0xbd, 0xec, 0x33,
+	0x62, 0xd4, 0x09, 0x84, 0xa4, 0x6b, 0x50, 0x53, 0x7b, 0x95, 0x6b, 0x13, 0xfe, 0x47, 0x55, 0xf4,
+	0x0a, 0xb9, 0x05, 0x38, 0x59, 0xcc, 0x6b, 0x7e, 0x0a, 0x8b, 0x79, 0x5b, 0x9e, 0xcb, 0xf2, 0xcd,
+	0x34, 0xcb, 0x73, 0x03, 0x2c, 0x53, 0x22, 0x23, 0xd9, 0x33, 0xd0, 0x0f, 0x2b, 0x20, 0xe5, 0x0e,
+	0xf1, 0x56, 0x7a, 0x88, 0x0b, 0x79, 0x95, 0x82, 0xe4, 0x16, 0xc9, 0x61, 0x5a, 0x70, 0x3a, 0x37,
+	0xad, 0xc9, 0x1d, 0xe3, 0x66, 0x7a, 0x8c, 0xf3, 0x79, 0x5b, 0x19, 0xe7, 0x45, 0x72, 0x00, 0x0a,
+	0x4b, 0xf9, 0x2e, 0x34, 0x77, 0x84, 0x5b, 0xe9, 0x11, 0x5e, 0xca, 0x5a, 0xa7, 0x9c, 0x6f, 0x50,
+	0x3b, 0x31, 0x68, 0x26, 0x8e, 0xba, 0x13, 0x83, 0xda, 0x27, 0xd9, 0xbf, 0x0f, 0xb3, 0xe9, 0xea,
+	0x40, 0x2e, 0xe3, 0xd7, 0xd2, 0x8c, 0x53, 0x76, 0x26, 0xa6, 0x1c, 0x64, 0x19, 0x5b, 0x96, 0x23,
+	0xb3, 0x8c, 0x29, 0x15, 0xcb, 0x4d, 0x98, 0x49, 0x55, 0x68, 0x73, 0x39, 0x5e, 0x4d, 0x73, 0x5c,
+	0x1c, 0x2c, 0x28, 0x20, 0xa1, 0x62, 0xf8, 0x01, 0xcc, 0x73, 0x86, 0xfd, 0x1a, 0x5a, 0xbe, 0x50,
+	0x5c, 0x4b, 0xf3, 0x3c, 0x93, 0x5f, 0x7f, 0xeb, 0x0d, 0xca, 0xb5, 0x2a, 0x1f, 0x1e, 0x47, 0xae,
+	0x73, 0x78, 0xa8, 0x61, 0xbe, 0x0d, 0x0d, 0x61, 0x1b, 0x84, 0xf5, 0xcb, 0xe3, 0x7c, 0x25, 0xcd,
+	0x99, 0x64, 0xed, 0xa8, 0x62, 0xf6, 0x5d, 0x38, 0x25, 0x98, 0xa5, 0x4a, 0x79, 0xb9, 0x4c, 0xdf,
+	0x48, 0x33, 0x5d, 0x3e, 0xbc, 0x34, 0x98, 0x65, 0x8e, 0x45, 0x9f, 0x0f, 0x69, 0x60, 0xd1, 0x1d,
+	0xfb, 0x79, 0x98, 0x27, 0xc9, 0x15, 0xf3, 0xa7, 0x70, 0x6e, 0x88, 0x89, 0xcc, 0x1d, 0xe4, 0x6e,
+	0x7a, 0x90, 0x54, 0x30, 0x7c, 0x88, 0xa5, 0x95, 0x83, 0xfd, 0xb8, 0x00, 0xf5, 0x4d, 0xda, 0x8d,
+	0xf6, 0x1e, 0xd9, 0xde, 0x33, 0xf2, 0x1a, 0x2c, 0xe0, 0xb3, 0x17, 0x58, 0x3f, 0x10, 0x86, 0x1c,
+	0x03, 0x2d, 0x31, 0xd0, 0x7c, 0xaa, 0xe3, 0x83, 0xc0, 0x26, 0xe7, 0xa0, 0x1e, 0x79, 0x4f, 0x99,
+	0x00, 0x89, 0x98, 0xa7, 0xc6, 0x1b, 0xb0, 0xf3, 0x02, 0x34, 0x02, 0xd6, 0x09, 0x58, 0xb8, 0xc7,
+	0xbb, 0x45, 0x7c, 0x0c, 0xb2, 0x09, 0x01, 0x57, 0xd1, 0x25, 0x7a, 0x7e, 0x5c, 0x3b, 0x1e, 0xd8,
+	0x4a, 0xec, 0x31, 0x24, 0xe2, 0x04, 0xfc, 0xcb, 0xdf, 0x0a, 0x00, 0xf1, 0x32, 0x84, 0xe4, 0x4d,
+	0xa8, 0x59, 0x8e, 0x6f, 0x5b, 0xa6, 0x15, 0xe9, 0x5a, 0x56, 0x91, 0x63, 0xa4, 0x11, 0xc3, 0x90,
+	0xc4, 0xa7, 0x61, 0xf8, 0xcc, 0x0b, 0xda, 0x7a, 0x61, 0x28, 0x89, 0x82, 0x91, 0x87, 0x40, 0x4c,
+	0xdb, 0xc2, 0x2a, 0x93, 0x19, 0xb0, 0x36, 0x73, 0x23, 0x8b, 0xda, 0x2a, 0x38, 0x3c, 0x84, 0x78,
+	0x41, 0x10, 0x3c, 0xe8, 0xe3, 0x91, 0x4b, 0x7a, 0xcf, 0xcc, 0x7e, 0x41, 0xe9, 0x30, 0x2e, 0x29,
+	0x82, 0x07, 0x27, 0x53, 0x55, 0xda, 0x86, 0x8a, 0x28, 0x8b, 0x4d, 0xb2, 0x64, 0xf3, 0xfb, 0x32,
+	0xd4, 0x37, 0x55, 0x9c, 0x8d, 0xaa, 0xc1, 0xab, 0x9b, 0xc8, 0xa7, 0x2e, 0x8b, 0x99, 0x3a, 0x54,
+	0xc3, 0xae, 0xe3, 0xd0, 0xa0, 0x27, 0x65, 0x54, 0xbd, 0x8e, 0x51, 0x18, 0xc9, 0x14, 0x42, 0x4b,
+	0x47, 0x2a, 0x84, 0x0e, 0xe6, 0x05, 0xe5, 0x6c, 0x5e, 0xf0, 0x6e, 0x2a, 0x2f, 0xa8, 0x64, 0x43,
+	0xcc, 0xd8, 0x67, 0x24, 0xed, 0x65, 0x82, 0x86, 0xac, 0xc1, 0x74, 0xe2, 0x4c, 0xa5, 0xa7, 0x57,
+	0xb3, 0x16, 0x20, 0x61, 0xd1, 0x93, 0x5c, 0x1a, 0xfd, 0xa3, 0x95, 0x5e, 0xfa, 0xe4, 0xa8, 0x36,
+	0xe6, 0xc9, 0xd1, 0x73, 0x9d, 0x76, 0xa4, 0xab, 0x46, 0x70, 0xa4, 0xaa, 0x51, 0xb2, 0xf6, 0xdd,
+	0x38, 0x6a, 0xed, 0x3b, 0x51, 0xc7, 0x9f, 0xce, 0x31, 0x39, 0x03, 0x75, 0xfc, 0x21, 0x0a, 0x31,
+	0x33, 0x19, 0x85, 0xf8, 0x6b, 0x19, 0xea, 0xc3, 0x83, 0x89, 0xaf, 0x4b, 0x79, 0x5f, 0x97, 0xf2,
+	0x5e, 0x44, 0x29, 0x6f, 0x98, 0x70, 0xcf, 0x4e, 0x46, 0xb8, 0x3f, 0xd7, 0x60, 0x31, 0xcf, 0x76,
+	0x61, 0x7e, 0x1f, 0x5b, 0xaf, 0x3c, 0xdf, 0x1a, 0x13, 0x61, 0x7e, 0x1f, 0x23, 0x8f, 0x5d, 0x16,
+	0xf8, 0x14, 0x20, 0x51, 0x12, 0xd8, 0x1c, 0xee, 0x81, 0x96, 0x73, 0xee, 0x50, 0x48, 0xda, 0x43,
+	0xfc, 0xd0, 0xe7, 0x65, 0xa8, 0xc5, 0x51, 0xfc, 0x02, 0x94, 0x5a, 0xfd, 0x1a, 0x51, 0x11, 0x4b,
+	0x44, 0xc7, 0xf1, 0x42, 0xaf, 0x42, 0x71, 0x97, 0x45, 0xb9, 0x1e, 0x5d, 0x79, 0x12, 0x03, 0x11,
+	0x08, 0xf4, 0xbb, 0x91, 0x5e, 0x1e, 0x0a, 0xf4, 0xbb, 0x11, 0xf9, 0x5f, 0x28, 0xf9, 0x5e, 0x18,
+	0xe9, 0x95, 0x61, 0x48, 0x0e, 0x21, 0xd7, 0xa0, 0xd2, 0x66, 0x36, 0x8b, 0x98, 0x5e, 0x1d, 0x06,
+	0x96, 0x20, 0x3c, 0x18, 0xf2, 0xf8, 0xac, 0x73, 0x9d, 0x48, 0x1f, 0xaf, 0x50, 0x38, 0x15, 0x2c,
+	0x5c, 0xe9, 0xf5, 0x61, 0x68, 0x0e, 0xc1, 0xd4, 0xca, 0xa7, 0x91, 0xb9, 0xa7, 0xc3, 0x30, 0xac,
+	0xc0, 0x20, 0x38, 0x0a, 0xa8, 0xc9, 0xf4, 0xc6, 0x50, 0x30, 0xc7, 0x1c, 0xd1, 0x33, 0xa4, 0x7d,
+	0xf6, 0xcc, 0x73, 0xf8, 0xec, 0x17, 0xaf, 0x7e, 0xbf, 0xd4, 0xa0, 0xcc, 0x4f, 0x9b, 0xc9, 0x35,
+	0x28, 0xe1, 0x79, 0xf3, 0xe8, 0xdb, 0x41, 0x1c, 0x76, 0x02, 0x37, 0x5c, 0x7e, 0xa8, 0x41, 0x7d,
+	0x2b, 0xb0, 0x1c, 0x2b, 0xb2, 0xf6, 0x19, 0x59, 0x86, 0xaa, 0xe5, 0x46, 0x6c, 0x57, 0x1a, 0x83,
+	0x22, 0x1e, 0x1a, 0xca, 0x06, 0xa2, 0x43, 0xc5, 0xed, 0x3a, 0x3b, 0x2c, 0xe0, 0x3a, 0xa3, 0xe1,
+	0x31, 0x82, 0x78, 0x47, 0xaa, 0x1d, 0xcf, 0xb3, 0x19, 0x15, 0x0a, 0x53, 0x43, 0x2a, 0xd9, 0x80,
+	0x54, 0x61, 0x14, 0xa8, 0xe2, 0x55, 0x1d, 0xa9, 0xc4, 0x7b, 0xdf, 0x18, 0x7c, 0x02, 0xd0, 0xd7,
+	0x5d, 0xf2, 0x78, 0xb8, 0x31, 0x38, 0x93, 0xfd, 0x60, 0x91, 0x68, 0xe6, 0x5b, 0x82, 0x4f, 0xa0,
+	0xde, 0x37, 0x76, 0x93, 0xb5, 0x04, 0xcd, 0x1d, 0x98, 0x49, 0xdd, 0x8e, 0x21, 0xef, 0x0f, 0x9f,
+	0xfa, 0xf9, 0xcc, 0xd4, 0x93, 0xc9, 0x7d, 0xfe, 0xfc, 0xff, 0xad, 0x41, 0x23, 0x81, 0x1a, 0xe3,
+	0x0c, 0x34, 0xe1, 0x7b, 0x0a, 0x63, 0xf8, 0x9e, 0x64, 0x88, 0x52, 0x3c, 0x42, 0x88, 0xf2, 0xe2,
+	0xef, 0x9e, 0xfc, 0x42, 0x83, 0xa5, 0xfc, 0x58, 0x99, 0x7c, 0x63, 0x20, 0xca, 0xd6, 0x86, 0xd6,
+	0x4d, 0xd6, 0xa7, 0xd2, 0xc1, 0xf5, 0x71, 0x9d, 0xd7, 0xcf, 0x0b, 0x50, 0x53, 0x01, 0xf8, 0x78,
+	0x1b, 0x92, 0xbe, 0x9b, 0x30, 0xfc, 0xc0, 0x20, 0xb1, 0x7f, 0xc5, 0x31, 0xf6, 0x2f, 0xbe, 0x0b,
+	0x55, 0x1a, 0x71, 0x17, 0xea, 0xc5, 0xa7, 0x94, 0x78, 0xc5, 0x30, 0xaf, 0x26, 0x75, 0x13, 0x45,
+	0x4c, 0x34, 0xe7, 0x5d, 0x31, 0x54, 0x24, 0x78, 0xc5, 0x50, 0xe1, 0x8e, 0xbd, 0x47, 0xff, 0xd4,
+	0x50, 0xf1, 0x55, 0x66, 0xf4, 0x36, 0x54, 0xdb, 0xac, 0x43, 0xbb, 0xb6, 0xaa, 0x1f, 0x8c, 0xac,
+	0x81, 0x29, 0x3c, 0xd9, 0x80, 0x19, 0x35, 0x29, 0x91, 0xca, 0x17, 0x0e, 0xb9, 0xa6, 0x98, 0xc7,
+	0x65, 0x5a, 0x91, 0x8e, 0x4a, 0xea, 0x27, 0x74, 0x2e, 0xf6, 0x87, 0x19, 0xa8, 0xc8, 0x72, 0xdd,
+	0x1d, 0xa8, 0xb9, 0x5d, 0xdb, 0xc6, 0x4a, 0x96, 0xae, 0x8d, 0xd6, 0x6b, 0x85, 0x25, 0x97, 0x61,
+	0xa6, 0x6d, 0xa1, 0xf0, 0x3a, 0x96, 0x4b, 0x23, 0x2f, 0x90, 0x66, 0x31, 0xdd, 0x48, 0xfe, 0x0f,
+	0xb7, 0x87, 0xb6, 0x5b, 0x9e, 0x6b, 0xf7, 0xc6, 0x33, 0x1b, 0xb4, 0xbd, 0xe9, 0xda, 0x3d, 0xbc,
+	0x45, 0xf6, 0x2c, 0xb0, 0x22, 0x26, 0x28, 0x47, 0xe7, 0x44, 0x75, 0x8e, 0xe6, 0xa4, 0x17, 0xa1,
+	0x78, 0xe0, 0xd8, 0x7a, 0x39, 0x7b, 0xa4, 0xf0, 0xb1, 0x63, 0x1b, 0xd8, 0x97, 0xad, 0x10, 0x54,
+	0x8e, 0x54, 0x21, 0x48, 0xa7, 0x5d, 0xd5, 0x23, 0xa5, 0x5d, 0xf1, 0xf1, 0x75, 0x2d, 0x79, 0x7c,
+	0x7d, 0x0f, 0x1a, 0x4e, 0xd7, 0x8e, 0x2c, 0xdf, 0x66, 0x2d, 0xaf, 0x23, 0xc3, 0xaa, 0xf3, 0x19,
+	0x96, 0x0f, 0xbd, 0xee, 0x8e, 0xcd, 0x24, 0x53, 0x45, 0xb0, 0xd9, 0x21, 0x77, 0xa0, 0xea, 0xd0,
+	0x03, 0xcb, 0xe9, 0x3a, 0x3a, 0x8c, 0x41, 0xaa, 0xc0, 0xe4, 0x5b, 0xb0, 0xc0, 0x0e, 0x4c, 0xbb,
+	0x1b, 0x5a, 0xfb, 0xac, 0xa5, 0x38, 0x34, 0x46, 0x7e, 0xcf, 0x7c, 0x4c, 0xf4, 0x9e, 0x64, 0x84,
+	0x13, 0xb0, 0x5c, 0x4e, 0x3e, 0x3d, 0xd6, 0x04, 0x2c, 0x37, 0x67, 0x02, 0x92, 0xc3, 0xcc, 0x51,
+	0x26, 0x20, 0x19, 0xbd, 0x03, 0xe0, 0xd0, 0x83, 0x96, 0xcd, 0xdc, 0xdd, 0x68, 0x4f, 0x9f, 0x95,
+	0x27, 0x0e, 0x83, 0x1c, 0x36, 0xdc, 0xe8, 0xce, 0x6d, 0x29, 0x30, 0x0e, 0x3d, 0x78, 0xcc, 0xd1,
+	0x9c, 0xd6, 0x72, 0x15, 0xed, 0xdc, 0x38, 0xb4, 0x96, 0x2b, 0x69, 0x75, 0xa8, 0xfa, 0x34, 0x42,
+	0xd9, 0xd0, 0xe7, 0x45, 0x5c, 0x20, 0x5f, 0xc9, 0x5d, 0xc0, 0x21, 0x5a, 0x16, 0x9e, 0xb7, 0xeb,
+	0x0b, 0xa3, 0x99, 0xd6, 0x1c, 0x7a, 0xc0, 0x0f, 0xe7, 0x39, 0xa5, 0xe5, 0x4a, 0x4a, 0x32, 0x0e,
+	0xa5, 0xe5, 0x0a, 0xca, 0x7b, 0x30, 0xdd, 0x75, 0xad, 0xcf, 0xba, 0x4c, 0x12, 0x9f, 0x1a, 0xb9,
+	0x92, 0x0d, 0x81, 0x17, 0xe4, 0xf7, 0x61, 0x16, 0xa7, 0x9c, 0x08, 0x48, 0x16, 0x47, 0x8f, 0x3e,
+	0xe3, 0xd0, 0x83, 0x44, 0x58, 0x86, 0x3c, 0x2c, 0x37, 0xc9, 0xe3, 0xf4, 0x38, 0x3c, 0x2c, 0x37,
+	0xc1, 0x63, 0x39, 0x11, 0x6b, 0x2c, 0xf1, 0xa2, 0x60, 0xfc, 0x8e, 0x57, 0x21, 0x99, 0xdb, 0x75,
+	0xf4, 0x33, 0xd9, 0xab, 0x90, 0x58, 0x6f, 0xe4, 0x9d, 0xbc, 0xa2, 0x88, 0x37, 0x00, 0x75, 0x51,
+	0x95, 0xc1, 0x67, 0x72, 0x1b, 0x2a, 0xd4, 0xb6, 0x51, 0xbb, 0xce, 0x8e, 0x73, 0xc3, 0xa1, 0x4c,
+	0x6d, 0x7b, 0xb3, 0x83, 0x54, 0x9e, 0xcb, 0x75, 0x72, 0x79, 0x2c, 0x2a, 0xcf, 0x65, 0x82, 0x8a,
+	0xba, 0x3d, 0xa4, 0x3a, 0x37, 0xde, 0x58, 0x6e, 0x6f, 0xb3, 0x43, 0x2e, 0x43, 0xd1, 0xf5, 0x22,
+	0xfd, 0xfc, 0xa1, 0x67, 0x24, 0xd8, 0x8d, 0x29, 0x92, 0xd8, 0xdc, 0x97, 0xb2, 0xde, 0x2e, 0xbe,
+	0xdc, 0x61, 0x08, 0x0c, 0xbf, 0x24, 0xde, 0xdf, 0x89, 0x97, 0x73, 0x2e, 0x89, 0xc7, 0xbd, 0x46,
+	0x02, 0x39, 0x18, 0xad, 0x5c, 0xc8, 0x46, 0x2b, 0x4b, 0x50, 0xe9, 0x78, 0x81, 0x43, 0x23, 0x7d,
+	0x85, 0x77, 0xca, 0xb7, 0x61, 0xce, 0xeb, 0xe2, 0x64, 0x9c, 0x17, 0x5e, 0x68, 0xca, 0xac, 0x21,
+	0x5e, 0x68, 0x4a, 0x9d, 0xd5, 0xe7, 0xac, 0x1f, 0xcf, 0x29, 0xf8, 0xd3, 0xb1, 0xc3, 0x86, 0x8f,
+	0xa0, 0xba, 0x2d, 0x2f, 0xef, 0x4f, 0x36, 0x0f, 0xf9, 0x99, 0x86, 0x9e, 0x99, 0x1f, 0x9f, 0x5c,
+	0x49, 0xdd, 0x60, 0xc8, 0x2f, 0xaf, 0x9f, 0xd4, 0xef, 0x1b, 0xbe, 0x09, 0xa7, 0x72, 0xaa, 0xb4,
+	0xe3, 0x4f, 0xb1, 0xf9, 0xf7, 0x02, 0xcc, 0x66, 0xcf, 0xf4, 0x12, 0xd7, 0x74, 0xf9, 0xf3, 0x18,
+	0xd7, 0xb8, 0x54, 0xc1, 0xb5, 0x98, 0x29, 0xb8, 0x96, 0xe2, 0x82, 0xeb, 0x92, 0x94, 0x05, 0x26,
+	0x6b, 0xf7, 0xf2, 0x8d, 0x5c, 0x82, 0x99, 0x1d, 0x46, 0x03, 0x16, 0xb4, 0xa4, 0xe8, 0x8a, 0x0b,
+	0x58, 0xd3, 0xa2, 0xf1, 0x91, 0x10, 0xe0, 0xab, 0x50, 0xea, 0xd8, 0xde, 0x33, 0xbd, 0x9a, 0x55,
+	0x96, 0xfe, 0x51, 0x93, 0xc1, 0x31, 0xe4, 0x1a, 0x9c, 0xc2, 0xee, 0x96, 0xd5, 0x6e, 0x99, 0x9e,
+	0xeb, 0x32, 0x33, 0xe2, 0xc7, 0x66, 0xc2, 0xb5, 0xcf, 0x63, 0xd7, 0x46, 0xfb, 0x81, 0xe8, 0xf8,
+	0x20, 0xb0, 0x4f, 0xe0, 0x8a, 0xf5, 0x2e, 0xcc, 0x6d, 0x0f, 0xfc, 0x08, 0xe2, 0xc9, 0x70, 0xf9,
+	0xbc, 0x90, 0x1d, 0x32, 0xc5, 0xe0, 0x10, 0x39, 0xfd, 0x0b, 0xca, 0x29, 0xaf, 0xac, 0xa8, 0x8b,
+	0x5d, 0x5a, 0xff, 0x62, 0xd7, 0xe8, 0x5d, 0x7c, 0x1b, 0xea, 0xfb, 0xf2, 0xfc, 0x54, 0x1d, 0x9d,
+	0x9d, 0x3b, 0xfc, 0x88, 0x35, 0x34, 0xfa, 0xe8, 0x13, 0x48, 0x28, 0xff, 0xa1, 0xc1, 0x6c, 0x7a,
+	0x02, 0x58, 0xef, 0xe2, 0x5e, 0x47, 0xac, 0x59, 0xba, 0xf2, 0xa9, 0x8a, 0x22, 0xd2, 0xf7, 0xdc,
+	0xe8, 0xe7, 0x10, 0x79, 0x97, 0x09, 0x62, 0xb4, 0x42, 0x8d, 0x51, 0x4a, 0x7c, 0xf1, 0x9f, 0xfc,
+	0x5b, 0x0d, 0xe6, 0xd2, 0x9f, 0x8c, 0xc7, 0x44, 0x49, 0xe5, 0xce, 0x93, 0x93, 0x24, 0xfe, 0xc4,
+	0x4c, 0xd1, 0xe7, 0x1a, 0x2c, 0xe5, 0x93, 0xfc, 0x77, 0xaa, 0x52, 0x97, 0xa0, 0xb1, 0xcd, 0x9b,
+	0x56, 0x83, 0x80, 0xf6, 0x30, 0xbe, 0x57, 0x3f, 0x52, 0xc4, 0xc0, 0x45, 0xbc, 0x34, 0xbf, 0xd2,
+	0xa0, 0xf8, 0x84, 0xee, 0xe6, 0x1e, 0x17, 0x8d, 0xd6, 0x96, 0x4c, 0xba, 0x52, 0x9c, 0xd4, 0x2f,
+	0x3b, 0x26, 0x24, 0x3e, 0xbf, 0x2a, 0x40, 0xf1, 0x63, 0xc7, 0xce, 0xfd, 0xbc, 0xf3, 0x50, 0xc7,
+	0xff, 0xa1, 0x4f, 0xa5, 0xab, 0xad, 0x1b, 0xfd, 0x06, 0x34, 0xd5, 0x7e, 0xc0, 0x3a, 0xd6, 0x81,
+	0x94, 0x7b, 0xf9, 0x86, 0x31, 0x30, 0x8d, 0xa2, 0xc0, 0xda, 0xe9, 0x46, 0xe3, 0xfc, 0xcc, 0xa2,
+	0x0f, 0xc6, 0x93, 0x27, 0xf1, 0xf3, 0xd1, 0x71, 0x0e, 0xc4, 0x14, 0xf4, 0xc5, 0x5f, 0xae, 0xbc,
+	0xff, 0x0a, 0xcc, 0x7a, 0xc1, 0xae, 0xe2, 0xd2, 0xda, 0xbf, 0x75, 0x7f, 0x5a, 0xfe, 0x5c, 0x76,
+	0x0b, 0xe7, 0xb5, 0xa5, 0xfd, 0xba, 0x50, 0xdc, 0x5c, 0xdd, 0xde, 0xa9, 0xf0, 0x69, 0xde, 0xfa,
+	0xcf, 0x00, 0xa2, 0xed, 0x91, 0x8f, 0x57, 0x3b, 0x00, 0x00,
 }
@@ -0,0 +1,120 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v3
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// LazyDocument compiles the parts of an OpenAPI document when they are first
+// requested. Tools that only need one path or one schema of a large document
+// can use it instead of NewDocument to avoid compiling the rest.
+type LazyDocument struct {
+	compiler *compiler.LazyCompiler
+}
+
+// NewLazyDocument creates a LazyDocument for a parsed document.
+// Nothing is compiled until it is requested.
+func NewLazyDocument(info *yaml.Node, context *compiler.Context) (*LazyDocument, error) {
+	if _, ok := compiler.UnpackMap(info); !ok {
+		message := fmt.Sprintf("has unexpected value: %s", compiler.Display(info))
+		return nil, compiler.NewError(context, message)
+	}
+	return &LazyDocument{compiler: compiler.NewLazyCompiler(info, context)}, nil
+}
+
+// Document compiles the entire document.
+func (d *LazyDocument) Document() (*Document, error) {
+	value, _, err := d.compiler.Compile([]string{}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewDocument(in, context)
+	})
+	document, _ := value.(*Document)
+	return document, err
+}
+
+// PathNames returns the names of the paths in the document, in the order that they appear.
+func (d *LazyDocument) PathNames() []string {
+	names := make([]string, 0)
+	for _, name := range d.compiler.Keys("paths") {
+		if compiler.PatternMatches("/{path}", name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Path compiles and returns the path item with the specified name.
+func (d *LazyDocument) Path(name string) (*PathItem, error) {
+	value, err := d.compile([]string{"paths", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewPathItem(in, context)
+	})
+	pathItem, _ := value.(*PathItem)
+	return pathItem, err
+}
+
+// ComponentNames returns the names of the entries in a section of the
+// document's components, such as "schemas" or "parameters".
+func (d *LazyDocument) ComponentNames(section string) []string {
+	return d.compiler.Keys("components", section)
+}
+
+// Schema compiles and returns the schema in the document's components with the specified name.
+func (d *LazyDocument) Schema(name string) (*Schema, error) {
+	value, err := d.compile([]string{"components", "schemas", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewSchema(in, context)
+	})
+	schema, _ := value.(*Schema)
+	return schema, err
+}
+
+// Parameter compiles and returns the parameter in the document's components with the specified name.
+func (d *LazyDocument) Parameter(name string) (*Parameter, error) {
+	value, err := d.compile([]string{"components", "parameters", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewParameter(in, context)
+	})
+	parameter, _ := value.(*Parameter)
+	return parameter, err
+}
+
+// RequestBody compiles and returns the request body in the document's components with the specified name.
+func (d *LazyDocument) RequestBody(name string) (*RequestBody, error) {
+	value, err := d.compile([]string{"components", "requestBodies", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewRequestBody(in, context)
+	})
+	requestBody, _ := value.(*RequestBody)
+	return requestBody, err
+}
+
+// SecurityScheme compiles and returns the security scheme in the document's components with the specified name.
+func (d *LazyDocument) SecurityScheme(name string) (*SecurityScheme, error) {
+	value, err := d.compile([]string{"components", "securitySchemes", name}, func(in *yaml.Node, context *compiler.Context) (interface{}, error) {
+		return NewSecurityScheme(in, context)
+	})
+	securityScheme, _ := value.(*SecurityScheme)
+	return securityScheme, err
+}
+
+// Compiles the node at a path, returning an error if there is none.
+func (d *LazyDocument) compile(path []string, compile compiler.CompileFunction) (interface{}, error) {
+	value, found, err := d.compiler.Compile(path, compile)
+	if !found {
+		return nil, errors.New(fmt.Sprintf("%s not found", path[len(path)-1]))
+	}
+	return value, err
+}
@@ -0,0 +1,113 @@
+package openapi_v3
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/googleapis/gnostic/compiler"
+)
+
+const lazyTestDocument = `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200": {description: OK}
+  /owners:
+    get:
+      responses:
+        "200": {description: OK, colour: blue}
+components:
+  schemas:
+    Pet: {type: object, properties: {name: {type: string}}}
+    Owner: {type: object, size: large}
+`
+
+func newTestLazyDocument(t *testing.T) *LazyDocument {
+	info, err := compiler.ReadInfoFromBytesWithOptions("", []byte(lazyTestDocument), &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err := NewLazyDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	return document
+}
+
+func TestLazyDocument(t *testing.T) {
+	document := newTestLazyDocument(t)
+	if names := document.PathNames(); len(names) != 2 || names[0] != "/pets" || names[1] != "/owners" {
+		t.Errorf("Unexpected path names: %v", names)
+	}
+	if names := document.ComponentNames("schemas"); len(names) != 2 || names[0] != "Pet" || names[1] != "Owner" {
+		t.Errorf("Unexpected schema names: %v", names)
+	}
+	path, err := document.Path("/pets")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if description := path.Get.Responses.ResponseCode[0].Value.GetResponse().Description; description != "OK" {
+		t.Errorf("Unexpected description: %s", description)
+	}
+	if again, _ := document.Path("/pets"); again != path {
+		t.Errorf("Expected the compiled path to be reused")
+	}
+	schema, err := document.Schema("Pet")
+	if err != nil || schema.Type != "object" {
+		t.Errorf("Unexpected schema: %+v, %v", schema, err)
+	}
+	if _, err := document.Path("/stores"); err == nil || err.Error() != "/stores not found" {
+		t.Errorf("Expected an error for a missing path, got %v", err)
+	}
+	if _, err := document.Schema("Store"); err == nil || err.Error() != "Store not found" {
+		t.Errorf("Expected an error for a missing schema, got %v", err)
+	}
+}
+
+// Errors in the parts of a lazy document are the errors that are reported
+// for them when the entire document is compiled.
+func TestLazyDocumentErrors(t *testing.T) {
+	document := newTestLazyDocument(t)
+	_, err := document.Document()
+	if err == nil {
+		t.Fatalf("Expected errors compiling the document")
+	}
+	full := err.Error()
+	_, pathErr := document.Path("/owners")
+	_, schemaErr := document.Schema("Owner")
+	for _, err := range []error{pathErr, schemaErr} {
+		if err == nil {
+			t.Errorf("Expected an error")
+		} else if !strings.Contains(full, err.Error()) {
+			t.Errorf("Expected the error %q in the errors of the document:\n%s", err.Error(), full)
+		}
+	}
+	if !strings.Contains(pathErr.Error(), "$root.paths./owners.get.responses") {
+		t.Errorf("Unexpected context: %s", pathErr.Error())
+	}
+}
+
+func TestLazyDocumentConcurrency(t *testing.T) {
+	document := newTestLazyDocument(t)
+	var wait sync.WaitGroup
+	results := make([]*PathItem, 8)
+	for i := range results {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			results[i], _ = document.Path("/pets")
+			document.Schema("Pet")
+			document.Document()
+		}(i)
+	}
+	wait.Wait()
+	for _, result := range results {
+		if result == nil || result != results[0] {
+			t.Errorf("Expected every caller to get the same path item")
+		}
+	}
+}
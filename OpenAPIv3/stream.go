@@ -0,0 +1,115 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_v3
+
+import (
+	"io"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// NewDocumentFromJSON reads and compiles a JSON OpenAPI document in chunks.
+// Each path and each schema in the document's components is compiled as soon
+// as it is read, so the YAML nodes for the entire document are never held in
+// memory at once, and the strings in the model are interned as it is built.
+// This uses much less memory than NewDocument for very large documents.
+// The resulting model is the same, but errors may be reported in a different order.
+func NewDocumentFromJSON(reader io.Reader, context *compiler.Context) (*Document, error) {
+	return newDocumentInChunks(context, func(chunked [][]string, handler compiler.ChunkHandler, resolver compiler.ChunkResolver) (*yaml.Node, error) {
+		return compiler.ReadInfoFromJSONInChunks(reader, chunked, handler)
+	})
+}
+
+// NewDocumentFromJSONFile reads and compiles a JSON OpenAPI file in chunks like
+// NewDocumentFromJSON, reading the file as it is compiled. The rest of the file
+// is cached, so references into the file are resolved without reading it again;
+// references to paths and schemas are resolved with the compiled models.
+func NewDocumentFromJSONFile(filename string, context *compiler.Context) (*Document, error) {
+	return newDocumentInChunks(context, func(chunked [][]string, handler compiler.ChunkHandler, resolver compiler.ChunkResolver) (*yaml.Node, error) {
+		return compiler.ReadInfoFromJSONFileInChunksWithOptions(filename, chunked, handler, resolver, compiler.OptionsForContext(context))
+	})
+}
+
+// Compiles a document that is read in chunks by a function.
+func newDocumentInChunks(context *compiler.Context, read func([][]string, compiler.ChunkHandler, compiler.ChunkResolver) (*yaml.Node, error)) (*Document, error) {
+	errors := make([]error, 0)
+	pool := compiler.NewStringPool()
+	pathsContext := compiler.NewContext("paths", context)
+	schemasContext := compiler.NewContext("schemas", compiler.NewContext("components", context))
+	paths := make([]*NamedPathItem, 0)
+	schemas := make([]*NamedSchema, 0)
+	pathsByName := make(map[string]*PathItem)
+	schemasByName := make(map[string]*Schema)
+	handler := func(path []string, name string, value *yaml.Node) bool {
+		switch path[0] {
+		case "paths":
+			if !compiler.PatternMatches("/{path}", name) {
+				return false
+			}
+			pair := &NamedPathItem{Name: name}
+			var err error
+			pair.Value, err = NewPathItem(value, compiler.NewContext(name, pathsContext))
+			if err != nil {
+				errors = append(errors, err)
+			}
+			pool.InternStrings(pair)
+			paths = append(paths, pair)
+			pathsByName[name] = pair.Value
+		case "components":
+			pair := &NamedSchema{Name: name}
+			var err error
+			pair.Value, err = NewSchema(value, compiler.NewContext(name, schemasContext))
+			if err != nil {
+				errors = append(errors, err)
+			}
+			pool.InternStrings(pair)
+			schemas = append(schemas, pair)
+			schemasByName[name] = pair.Value
+		}
+		return true
+	}
+	// references to the entries that were compiled in chunks are resolved with their models
+	resolver := func(path []string, name string) *yaml.Node {
+		switch path[0] {
+		case "paths":
+			if pathItem, ok := pathsByName[name]; ok {
+				return pathItem.ToRawInfo()
+			}
+		case "components":
+			if schema, ok := schemasByName[name]; ok {
+				return schema.ToRawInfo()
+			}
+		}
+		return nil
+	}
+	info, err := read([][]string{{"paths"}, {"components", "schemas"}}, handler, resolver)
+	if err != nil {
+		return nil, err
+	}
+	// compile the rest of the document and add the entries that were compiled in chunks
+	document, err := NewDocument(info, context)
+	if err != nil {
+		errors = append(errors, err)
+	}
+	pool.InternStrings(document)
+	if document.Paths != nil {
+		document.Paths.Path = paths
+	}
+	if document.Components != nil && document.Components.Schemas != nil {
+		document.Components.Schemas.AdditionalProperties = schemas
+	}
+	return document, compiler.NewErrorGroupOrNil(errors)
+}
@@ -0,0 +1,70 @@
+package openapi_v3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func readTestDocument(t *testing.T, text string) *Document {
+	info, err := compiler.ReadInfoFromBytesWithOptions("", []byte(text), &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err := NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	return document
+}
+
+func TestWrappedZeroValues(t *testing.T) {
+	// numbers and booleans that are set to zero or false are kept apart from absent ones
+	zero := readTestDocument(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Count: {type: integer, minimum: 0, nullable: false}
+`)
+	absent := readTestDocument(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Count: {type: integer}
+`)
+	if count := zero.Components.GetSchema("Count"); count.Minimum == nil || count.Minimum.Value != 0 || count.Nullable == nil || count.Nullable.Value {
+		t.Errorf("Unexpected schema: %v", count)
+	}
+	if count := absent.Components.GetSchema("Count"); count.Minimum != nil || count.Nullable != nil {
+		t.Errorf("Unexpected schema: %v", count)
+	}
+	if proto.Equal(zero, absent) {
+		t.Errorf("Documents with zero and absent values should not be equal")
+	}
+	text := string(compiler.Marshal(zero.ToRawInfo()))
+	if !strings.Contains(text, "minimum: 0\n") || !strings.Contains(text, "nullable: false\n") {
+		t.Errorf("Unexpected description:\n%s", text)
+	}
+	text = string(compiler.Marshal(absent.ToRawInfo()))
+	if strings.Contains(text, "minimum") || strings.Contains(text, "nullable") {
+		t.Errorf("Unexpected description:\n%s", text)
+	}
+	// a type that includes "null" makes a schema nullable
+	nullable := readTestDocument(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Name: {type: [string, "null"]}
+`)
+	if name := nullable.Components.GetSchema("Name"); !name.Nullable.GetValue() {
+		t.Errorf("Unexpected schema: %v", name)
+	}
+}
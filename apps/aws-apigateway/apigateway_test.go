@@ -0,0 +1,117 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/lib"
+)
+
+const description = `
+swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+x-amazon-apigateway-request-validators:
+  body:
+    validateRequestBody: true
+    validateRequestParameters: false
+x-amazon-apigateway-unknown: true
+securityDefinitions:
+  authorizer:
+    type: apiKey
+    name: Authorization
+    in: header
+    x-amazon-apigateway-authtype: custom
+    x-amazon-apigateway-authorizer:
+      type: token
+      authorizerUri: arn:aws:apigateway:us-east-1:lambda:path/authorize
+  oauth:
+    type: oauth2
+    flow: implicit
+    authorizationUrl: https://example.com/authorize
+    scopes: {}
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          description: OK
+          schema:
+            $ref: '#/definitions/Pet'
+      x-amazon-apigateway-integration:
+        type: http_proxy
+        uri: https://backend.example.com/pets
+        httpMethod: GET
+    post:
+      x-amazon-apigateway-request-validator: params
+      x-amazon-apigateway-integration:
+        type: aws_proxy
+        timeoutInMillis: 60000
+      responses:
+        201:
+          description: Created
+    delete:
+      responses:
+        204:
+          description: Deleted
+definitions:
+  Pet:
+    type: object
+    discriminator: kind
+    required: [kind]
+    properties:
+      kind:
+        type: string
+        readOnly: true
+`
+
+func TestExtensions(t *testing.T) {
+	info, err := compiler.ReadInfoFromBytes("", []byte(description))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	extensions, problems := readExtensions(info)
+	expected := []string{
+		"/x-amazon-apigateway-unknown: unsupported extension",
+		"/paths/~1pets/post/x-amazon-apigateway-integration: uri is required for aws_proxy integrations",
+		"/paths/~1pets/post/x-amazon-apigateway-integration: httpMethod is required for aws_proxy integrations",
+		"/paths/~1pets/post/x-amazon-apigateway-integration: timeoutInMillis must be between 50 and 29000",
+		"/paths/~1pets/post/x-amazon-apigateway-request-validator: request validator params is not defined",
+	}
+	if !reflect.DeepEqual(problems, expected) {
+		t.Errorf("unexpected problems: %+v", problems)
+	}
+	if integration := extensions.Operations["/pets"]["GET"].Integration; integration == nil || integration.URI != "https://backend.example.com/pets" {
+		t.Errorf("unexpected integration: %+v", integration)
+	}
+	if authorizer := extensions.SecuritySchemes["authorizer"].Authorizer; authorizer == nil || authorizer.Type != "token" {
+		t.Errorf("unexpected authorizer: %+v", authorizer)
+	}
+
+	document, err := gnostic.ReadDocumentFromBytes([]byte(description))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	node, warnings := export(document, extensions)
+	// references in compiled models of OpenAPI 2.0 descriptions are resolved
+	expected = []string{
+		"/definitions/Pet/properties/kind/readOnly: removed unsupported property",
+		"/definitions/Pet/discriminator: removed unsupported property",
+		"/paths/~1pets/get/responses/200/schema/properties/kind/readOnly: removed unsupported property",
+		"/paths/~1pets/get/responses/200/schema/discriminator: removed unsupported property",
+		"/securityDefinitions/oauth: oauth2 security schemes require an x-amazon-apigateway-authorizer",
+		"/paths/~1pets/delete: operation has no x-amazon-apigateway-integration",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("unexpected warnings: %+v", warnings)
+	}
+	pet := compiler.MapValueForKey(compiler.MapValueForKey(node, "definitions"), "Pet")
+	if compiler.MapValueForKey(pet, "discriminator") != nil {
+		t.Errorf("discriminator was not removed")
+	}
+	if compiler.MapValueForKey(node, "x-amazon-apigateway-request-validators") == nil {
+		t.Errorf("request validators were not exported")
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/lib"
+	"gopkg.in/yaml.v3"
+)
+
+// Properties of schemas that API Gateway rejects or ignores in models.
+var unsupportedSchemaProperties = []string{
+	"discriminator", "example", "xml", "readOnly", "writeOnly", "exclusiveMinimum", "exclusiveMaximum",
+}
+
+// An exporter writes the model of a description in the dialect of OpenAPI
+// that API Gateway imports. Features that API Gateway doesn't support are
+// removed or reported, and each change is recorded as a warning.
+type exporter struct {
+	extensions *Extensions
+	warnings   []string
+}
+
+func (x *exporter) warn(path string, format string, args ...interface{}) {
+	if path == "" {
+		path = "/"
+	}
+	x.warnings = append(x.warnings, fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// Returns the description of a compiled model for importing into API
+// Gateway, with the typed extensions written in their places. Models of
+// OpenAPI 3.0 descriptions only keep extensions with scalar values, so
+// the extensions that were read from the description are always written
+// from their types.
+func export(document *gnostic.Document, extensions *Extensions) (*yaml.Node, []string) {
+	x := &exporter{extensions: extensions}
+	var info *yaml.Node
+	schemesPath := []string{"securityDefinitions"}
+	switch document.Version {
+	case gnostic.OpenAPIv2:
+		info = document.V2.ToRawInfo()
+		if definitions, ok := compiler.UnpackMap(compiler.MapValueForKey(info, "definitions")); ok {
+			x.namedSchemas(definitions, "/definitions")
+		}
+	case gnostic.OpenAPIv3:
+		info = document.V3.ToRawInfo()
+		schemesPath = []string{"components", "securitySchemes"}
+		x.servers(info)
+		if components, ok := compiler.UnpackMap(compiler.MapValueForKey(info, "components")); ok {
+			if schemas, ok := compiler.UnpackMap(compiler.MapValueForKey(components, "schemas")); ok {
+				x.namedSchemas(schemas, "/components/schemas")
+			}
+		}
+	}
+	x.walk(info, "")
+	x.securitySchemes(info, schemesPath)
+	x.operations(info)
+	x.documentExtensions(info)
+	return info, x.warnings
+}
+
+// Only the first server of an API is used, and its only variable can be
+// basePath.
+func (x *exporter) servers(info *yaml.Node) {
+	servers := compiler.MapValueForKey(info, "servers")
+	if servers == nil || servers.Kind != yaml.SequenceNode || len(servers.Content) == 0 {
+		return
+	}
+	if len(servers.Content) > 1 {
+		x.warn("/servers", "only the first of %d servers is kept", len(servers.Content))
+		servers.Content = servers.Content[:1]
+	}
+	if variables, ok := compiler.UnpackMap(compiler.MapValueForKey(servers.Content[0], "variables")); ok {
+		for i := 0; i+1 < len(variables.Content); i += 2 {
+			if name := variables.Content[i].Value; name != "basePath" {
+				x.warn("/servers/0/variables/"+escape(name), "server variables other than basePath are not supported")
+			}
+		}
+	}
+}
+
+func (x *exporter) namedSchemas(schemas *yaml.Node, path string) {
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		x.schema(schemas.Content[i+1], path+"/"+escape(schemas.Content[i].Value))
+	}
+}
+
+// Removes the properties of a schema and its subschemas that API Gateway
+// doesn't support.
+func (x *exporter) schema(node *yaml.Node, path string) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "$ref":
+			if !strings.HasPrefix(value.Value, "#") {
+				x.warn(path, "references to other files are not supported: %s", value.Value)
+			}
+		case "properties", "patternProperties", "definitions":
+			if value.Kind == yaml.MappingNode {
+				x.namedSchemas(value, path+"/"+key.Value)
+			}
+		case "items", "allOf", "anyOf", "oneOf":
+			if value.Kind == yaml.SequenceNode {
+				for j, item := range value.Content {
+					x.schema(item, fmt.Sprintf("%s/%s/%d", path, key.Value, j))
+				}
+			} else {
+				x.schema(value, path+"/"+key.Value)
+			}
+		case "not", "additionalProperties":
+			x.schema(value, path+"/"+key.Value)
+		default:
+			if contains(unsupportedSchemaProperties, key.Value) {
+				x.warn(path+"/"+key.Value, "removed unsupported property")
+				continue
+			}
+		}
+		content = append(content, key, value)
+	}
+	node.Content = content
+}
+
+// Finds the schemas of parameters, media types, and responses, removes
+// cookie parameters and callbacks, and reports parameters of files.
+func (x *exporter) walk(node *yaml.Node, path string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		content := make([]*yaml.Node, 0, len(node.Content))
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s/%d", path, i)
+			if strings.HasSuffix(path, "/parameters") && item.Kind == yaml.MappingNode {
+				in := compiler.MapValueForKey(item, "in")
+				if in != nil && in.Value == "cookie" {
+					x.warn(itemPath, "removed unsupported cookie parameter")
+					continue
+				}
+				if t := compiler.MapValueForKey(item, "type"); t != nil && t.Value == "file" {
+					x.warn(itemPath, "file parameters are not supported")
+				}
+			}
+			x.walk(item, itemPath)
+			content = append(content, item)
+		}
+		node.Content = content
+	case yaml.MappingNode:
+		content := make([]*yaml.Node, 0, len(node.Content))
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := path + "/" + escape(key.Value)
+			switch {
+			case strings.HasPrefix(key.Value, "x-"):
+			case key.Value == "schema":
+				x.schema(value, keyPath)
+			case path == "" && key.Value == "definitions":
+			case path == "/components" && key.Value == "schemas":
+			case key.Value == "callbacks" || key.Value == "links":
+				x.warn(keyPath, "removed unsupported %s", key.Value)
+				continue
+			default:
+				x.walk(value, keyPath)
+			}
+			content = append(content, key, value)
+		}
+		node.Content = content
+	}
+}
+
+// Reports security schemes that API Gateway can only use with authorizers,
+// and writes the extensions of security schemes.
+func (x *exporter) securitySchemes(info *yaml.Node, location []string) {
+	schemes := info
+	for _, key := range location {
+		schemes, _ = compiler.UnpackMap(compiler.MapValueForKey(schemes, key))
+	}
+	if schemes == nil {
+		return
+	}
+	path := "/" + strings.Join(location, "/")
+	for i := 0; i+1 < len(schemes.Content); i += 2 {
+		name, scheme := schemes.Content[i].Value, schemes.Content[i+1]
+		extensions := x.extensions.SecuritySchemes[name]
+		if extensions == nil || extensions.Authorizer == nil {
+			if t := compiler.MapValueForKey(scheme, "type"); t != nil && t.Value != "apiKey" {
+				x.warn(path+"/"+escape(name), "%s security schemes require an x-amazon-apigateway-authorizer", t.Value)
+			}
+		}
+		if extensions != nil {
+			x.setExtensions(scheme, extensions)
+		}
+	}
+}
+
+// Writes the extensions of operations and reports operations without
+// integrations.
+func (x *exporter) operations(info *yaml.Node) {
+	paths, ok := compiler.UnpackMap(compiler.MapValueForKey(info, "paths"))
+	if !ok {
+		return
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		p, item := paths.Content[i].Value, paths.Content[i+1]
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		operations := x.extensions.Operations[p]
+		if any := operations["ANY"]; any != nil && any.AnyMethod != nil {
+			setValue(item, "x-amazon-apigateway-any-method", any.AnyMethod)
+		}
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			method := strings.ToUpper(item.Content[j].Value)
+			if method == "ANY" || !contains(methods, method) {
+				continue
+			}
+			operation := operations[method]
+			if operation == nil || operation.Integration == nil {
+				x.warn("/paths/"+escape(p)+"/"+item.Content[j].Value, "operation has no x-amazon-apigateway-integration")
+			}
+			if operation != nil {
+				x.setExtensions(item.Content[j+1], operation)
+			}
+		}
+	}
+}
+
+func (x *exporter) documentExtensions(info *yaml.Node) {
+	x.setExtensions(info, x.extensions)
+}
+
+// Writes the fields of a value as properties of an object, replacing any
+// properties with the same names.
+func (x *exporter) setExtensions(node *yaml.Node, value interface{}) {
+	var encoded yaml.Node
+	if err := encoded.Encode(value); err != nil {
+		x.warn("", "%s", err.Error())
+		return
+	}
+	for i := 0; i+1 < len(encoded.Content); i += 2 {
+		setValue(node, encoded.Content[i].Value, encoded.Content[i+1])
+	}
+}
+
+func setValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, compiler.NewScalarNodeForString(key), value)
+}
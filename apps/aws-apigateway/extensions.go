@@ -0,0 +1,275 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Extensions holds the x-amazon-apigateway-* extensions of an OpenAPI
+// description. Extensions that are properties of named objects are listed
+// under the names of the objects.
+type Extensions struct {
+	APIKeySource           string                       `yaml:"x-amazon-apigateway-api-key-source,omitempty"`
+	BinaryMediaTypes       []string                     `yaml:"x-amazon-apigateway-binary-media-types,omitempty"`
+	MinimumCompressionSize *int                         `yaml:"x-amazon-apigateway-minimum-compression-size,omitempty"`
+	EndpointConfiguration  *EndpointConfiguration       `yaml:"x-amazon-apigateway-endpoint-configuration,omitempty"`
+	GatewayResponses       map[string]*GatewayResponse  `yaml:"x-amazon-apigateway-gateway-responses,omitempty"`
+	Policy                 *yaml.Node                   `yaml:"x-amazon-apigateway-policy,omitempty"`
+	RequestValidators      map[string]*RequestValidator `yaml:"x-amazon-apigateway-request-validators,omitempty"`
+	RequestValidator       string                       `yaml:"x-amazon-apigateway-request-validator,omitempty"`
+	CORS                   *CORS                        `yaml:"x-amazon-apigateway-cors,omitempty"`
+	// Extensions of security schemes, by the names of the schemes.
+	SecuritySchemes map[string]*SecuritySchemeExtensions `yaml:"-"`
+	// Extensions of operations, by path and then by method. The method of
+	// an x-amazon-apigateway-any-method operation is ANY.
+	Operations map[string]map[string]*OperationExtensions `yaml:"-"`
+}
+
+// EndpointConfiguration describes the endpoints of an API
+// (x-amazon-apigateway-endpoint-configuration).
+type EndpointConfiguration struct {
+	Types                     []string `yaml:"types,omitempty"`
+	VpcEndpointIds            []string `yaml:"vpcEndpointIds,omitempty"`
+	DisableExecuteAPIEndpoint bool     `yaml:"disableExecuteApiEndpoint,omitempty"`
+}
+
+// A GatewayResponse customizes a response that API Gateway returns for
+// failed requests (x-amazon-apigateway-gateway-responses).
+type GatewayResponse struct {
+	StatusCode         string            `yaml:"statusCode,omitempty"`
+	ResponseParameters map[string]string `yaml:"responseParameters,omitempty"`
+	ResponseTemplates  map[string]string `yaml:"responseTemplates,omitempty"`
+}
+
+// A RequestValidator names the parts of requests that are validated
+// (x-amazon-apigateway-request-validators).
+type RequestValidator struct {
+	ValidateRequestBody       bool `yaml:"validateRequestBody"`
+	ValidateRequestParameters bool `yaml:"validateRequestParameters"`
+}
+
+// CORS is the CORS configuration of an HTTP API (x-amazon-apigateway-cors).
+type CORS struct {
+	AllowOrigins     []string `yaml:"allowOrigins,omitempty"`
+	AllowCredentials bool     `yaml:"allowCredentials,omitempty"`
+	ExposeHeaders    []string `yaml:"exposeHeaders,omitempty"`
+	MaxAge           int      `yaml:"maxAge,omitempty"`
+	AllowMethods     []string `yaml:"allowMethods,omitempty"`
+	AllowHeaders     []string `yaml:"allowHeaders,omitempty"`
+}
+
+// SecuritySchemeExtensions are the extensions of a security scheme.
+type SecuritySchemeExtensions struct {
+	AuthType   string      `yaml:"x-amazon-apigateway-authtype,omitempty"`
+	Authorizer *Authorizer `yaml:"x-amazon-apigateway-authorizer,omitempty"`
+}
+
+// An Authorizer authorizes requests with a Lambda function, a Cognito user
+// pool, or JWTs (x-amazon-apigateway-authorizer).
+type Authorizer struct {
+	Type                           string            `yaml:"type"`
+	AuthorizerURI                  string            `yaml:"authorizerUri,omitempty"`
+	AuthorizerCredentials          string            `yaml:"authorizerCredentials,omitempty"`
+	AuthorizerPayloadFormatVersion string            `yaml:"authorizerPayloadFormatVersion,omitempty"`
+	EnableSimpleResponses          bool              `yaml:"enableSimpleResponses,omitempty"`
+	IdentitySource                 string            `yaml:"identitySource,omitempty"`
+	IdentityValidationExpression   string            `yaml:"identityValidationExpression,omitempty"`
+	AuthorizerResultTTLInSeconds   *int              `yaml:"authorizerResultTtlInSeconds,omitempty"`
+	ProviderARNs                   []string          `yaml:"providerARNs,omitempty"`
+	JWTConfiguration               *JWTConfiguration `yaml:"jwtConfiguration,omitempty"`
+}
+
+// A JWTConfiguration describes the tokens accepted by a JWT authorizer.
+type JWTConfiguration struct {
+	Issuer   string   `yaml:"issuer,omitempty"`
+	Audience []string `yaml:"audience,omitempty"`
+}
+
+// OperationExtensions are the extensions of an operation.
+type OperationExtensions struct {
+	Integration      *Integration `yaml:"x-amazon-apigateway-integration,omitempty"`
+	Auth             *Auth        `yaml:"x-amazon-apigateway-auth,omitempty"`
+	RequestValidator string       `yaml:"x-amazon-apigateway-request-validator,omitempty"`
+	// The contents of an x-amazon-apigateway-any-method operation, which
+	// isn't part of the models of OpenAPI descriptions.
+	AnyMethod *yaml.Node `yaml:"-"`
+}
+
+// An Integration describes the backend of an operation
+// (x-amazon-apigateway-integration).
+type Integration struct {
+	Type                 string                          `yaml:"type"`
+	URI                  string                          `yaml:"uri,omitempty"`
+	HTTPMethod           string                          `yaml:"httpMethod,omitempty"`
+	Credentials          string                          `yaml:"credentials,omitempty"`
+	ConnectionType       string                          `yaml:"connectionType,omitempty"`
+	ConnectionID         string                          `yaml:"connectionId,omitempty"`
+	PassthroughBehavior  string                          `yaml:"passthroughBehavior,omitempty"`
+	ContentHandling      string                          `yaml:"contentHandling,omitempty"`
+	TimeoutInMillis      int                             `yaml:"timeoutInMillis,omitempty"`
+	PayloadFormatVersion string                          `yaml:"payloadFormatVersion,omitempty"`
+	CacheNamespace       string                          `yaml:"cacheNamespace,omitempty"`
+	CacheKeyParameters   []string                        `yaml:"cacheKeyParameters,omitempty"`
+	RequestParameters    map[string]string               `yaml:"requestParameters,omitempty"`
+	RequestTemplates     map[string]string               `yaml:"requestTemplates,omitempty"`
+	Responses            map[string]*IntegrationResponse `yaml:"responses,omitempty"`
+}
+
+// An IntegrationResponse maps the responses of a backend, selected by a
+// pattern, to the responses of an operation.
+type IntegrationResponse struct {
+	StatusCode         string            `yaml:"statusCode"`
+	ResponseParameters map[string]string `yaml:"responseParameters,omitempty"`
+	ResponseTemplates  map[string]string `yaml:"responseTemplates,omitempty"`
+	ContentHandling    string            `yaml:"contentHandling,omitempty"`
+}
+
+// Auth sets the authorization type of an operation (x-amazon-apigateway-auth).
+type Auth struct {
+	Type string `yaml:"type"`
+}
+
+// The methods of operations, in the order that they are checked.
+var methods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "ANY"}
+
+// Returns an error message if a value isn't one of the allowed values.
+// Values are compared without regard to case, as API Gateway does.
+func oneOf(name string, value string, allowed ...string) string {
+	for _, a := range allowed {
+		if strings.EqualFold(value, a) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s must be one of %s, not %q", name, strings.Join(allowed, ", "), value)
+}
+
+func (c *checker) checkIntegration(path string, integration *Integration) {
+	if integration.Type == "" {
+		c.problem(path, "type is required")
+		return
+	}
+	if message := oneOf("type", integration.Type, "aws", "aws_proxy", "http", "http_proxy", "mock"); message != "" {
+		c.problem(path, "%s", message)
+		return
+	}
+	if !strings.EqualFold(integration.Type, "mock") {
+		if integration.URI == "" {
+			c.problem(path, "uri is required for %s integrations", integration.Type)
+		}
+		if integration.HTTPMethod == "" {
+			c.problem(path, "httpMethod is required for %s integrations", integration.Type)
+		}
+	}
+	if integration.PassthroughBehavior != "" {
+		if message := oneOf("passthroughBehavior", integration.PassthroughBehavior, "when_no_match", "when_no_templates", "never"); message != "" {
+			c.problem(path, "%s", message)
+		}
+	}
+	if integration.ConnectionType != "" {
+		if message := oneOf("connectionType", integration.ConnectionType, "INTERNET", "VPC_LINK"); message != "" {
+			c.problem(path, "%s", message)
+		} else if strings.EqualFold(integration.ConnectionType, "VPC_LINK") && integration.ConnectionID == "" {
+			c.problem(path, "connectionId is required for VPC_LINK connections")
+		}
+	}
+	if integration.ContentHandling != "" {
+		if message := oneOf("contentHandling", integration.ContentHandling, "CONVERT_TO_TEXT", "CONVERT_TO_BINARY"); message != "" {
+			c.problem(path, "%s", message)
+		}
+	}
+	if integration.TimeoutInMillis != 0 && (integration.TimeoutInMillis < 50 || integration.TimeoutInMillis > 29000) {
+		c.problem(path, "timeoutInMillis must be between 50 and 29000")
+	}
+	for pattern, response := range integration.Responses {
+		if response == nil || response.StatusCode == "" {
+			c.problem(path+"/responses/"+escape(pattern), "statusCode is required")
+		}
+	}
+}
+
+func (c *checker) checkAuthorizer(path string, authorizer *Authorizer) {
+	if message := oneOf("type", authorizer.Type, "token", "request", "cognito_user_pools", "jwt"); message != "" {
+		c.problem(path, "%s", message)
+		return
+	}
+	switch strings.ToLower(authorizer.Type) {
+	case "token", "request":
+		if authorizer.AuthorizerURI == "" {
+			c.problem(path, "authorizerUri is required for %s authorizers", authorizer.Type)
+		}
+	case "cognito_user_pools":
+		if len(authorizer.ProviderARNs) == 0 {
+			c.problem(path, "providerARNs are required for cognito_user_pools authorizers")
+		}
+	case "jwt":
+		if authorizer.JWTConfiguration == nil || authorizer.JWTConfiguration.Issuer == "" {
+			c.problem(path, "jwtConfiguration.issuer is required for jwt authorizers")
+		}
+	}
+	if ttl := authorizer.AuthorizerResultTTLInSeconds; ttl != nil && (*ttl < 0 || *ttl > 3600) {
+		c.problem(path, "authorizerResultTtlInSeconds must be between 0 and 3600")
+	}
+}
+
+// Checks the values of extensions that their types don't constrain.
+func (c *checker) checkExtensions(e *Extensions) {
+	if e.APIKeySource != "" {
+		if message := oneOf("x-amazon-apigateway-api-key-source", e.APIKeySource, "HEADER", "AUTHORIZER"); message != "" {
+			c.problem("", "%s", message)
+		}
+	}
+	if e.EndpointConfiguration != nil {
+		for _, t := range e.EndpointConfiguration.Types {
+			if message := oneOf("endpoint type", t, "EDGE", "REGIONAL", "PRIVATE"); message != "" {
+				c.problem("/x-amazon-apigateway-endpoint-configuration", "%s", message)
+			}
+		}
+	}
+	if e.RequestValidator != "" && e.RequestValidators[e.RequestValidator] == nil {
+		c.problem("/x-amazon-apigateway-request-validator", "request validator %s is not defined", e.RequestValidator)
+	}
+	for name, scheme := range e.SecuritySchemes {
+		if scheme.Authorizer != nil {
+			c.checkAuthorizer(c.securitySchemesPath+"/"+escape(name)+"/x-amazon-apigateway-authorizer", scheme.Authorizer)
+		}
+	}
+	for _, p := range sortedKeys(e.Operations) {
+		for _, method := range methods {
+			operation := e.Operations[p][method]
+			if operation == nil {
+				continue
+			}
+			path := "/paths/" + escape(p) + "/" + strings.ToLower(method)
+			if method == "ANY" {
+				path = "/paths/" + escape(p) + "/x-amazon-apigateway-any-method"
+			}
+			if operation.Integration != nil {
+				c.checkIntegration(path+"/x-amazon-apigateway-integration", operation.Integration)
+			}
+			if operation.Auth != nil {
+				if message := oneOf("type", operation.Auth.Type, "NONE", "AWS_IAM"); message != "" {
+					c.problem(path+"/x-amazon-apigateway-auth", "%s", message)
+				}
+			}
+			if operation.RequestValidator != "" && e.RequestValidators[operation.RequestValidator] == nil {
+				c.problem(path+"/x-amazon-apigateway-request-validator", "request validator %s is not defined", operation.RequestValidator)
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+const extensionPrefix = "x-amazon-apigateway-"
+
+// The extensions that can be used in each kind of object.
+var (
+	documentExtensions = []string{
+		"x-amazon-apigateway-api-key-source",
+		"x-amazon-apigateway-binary-media-types",
+		"x-amazon-apigateway-minimum-compression-size",
+		"x-amazon-apigateway-endpoint-configuration",
+		"x-amazon-apigateway-gateway-responses",
+		"x-amazon-apigateway-policy",
+		"x-amazon-apigateway-request-validators",
+		"x-amazon-apigateway-request-validator",
+		"x-amazon-apigateway-cors",
+	}
+	securitySchemeExtensions = []string{
+		"x-amazon-apigateway-authtype",
+		"x-amazon-apigateway-authorizer",
+	}
+	operationExtensions = []string{
+		"x-amazon-apigateway-integration",
+		"x-amazon-apigateway-auth",
+		"x-amazon-apigateway-request-validator",
+	}
+	pathItemExtensions = []string{
+		"x-amazon-apigateway-any-method",
+	}
+)
+
+// A checker records the problems found in the extensions of a description.
+type checker struct {
+	problems []string
+	// The location of security schemes, which depends on the OpenAPI version.
+	securitySchemesPath string
+}
+
+func (c *checker) problem(path string, format string, args ...interface{}) {
+	if path == "" {
+		path = "/"
+	}
+	c.problems = append(c.problems, fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+var yamlLine = regexp.MustCompile(`^\s*line \d+: `)
+
+// Decodes the x-amazon-apigateway-* properties of an object into a value.
+// Unknown extensions and unknown fields of extensions are reported. If the
+// value is nil, the extensions are only checked.
+func (c *checker) decode(node *yaml.Node, path string, allowed []string, value interface{}) {
+	extensions := compiler.NewMappingNode()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if !strings.HasPrefix(key, extensionPrefix) {
+			continue
+		}
+		if !contains(allowed, key) {
+			c.problem(path+"/"+escape(key), "unsupported extension")
+			continue
+		}
+		extensions.Content = append(extensions.Content, node.Content[i], node.Content[i+1])
+	}
+	if len(extensions.Content) == 0 || value == nil {
+		return
+	}
+	// Nodes can't be decoded strictly, so they are encoded and decoded again.
+	encoded, err := yaml.Marshal(extensions)
+	if err == nil {
+		decoder := yaml.NewDecoder(bytes.NewReader(encoded))
+		decoder.KnownFields(true)
+		err = decoder.Decode(value)
+	}
+	if typeError, ok := err.(*yaml.TypeError); ok {
+		for _, message := range typeError.Errors {
+			c.problem(path, "%s", yamlLine.ReplaceAllString(message, ""))
+		}
+	} else if err != nil {
+		c.problem(path, "%s", err.Error())
+	}
+}
+
+// Returns the typed x-amazon-apigateway-* extensions of an OpenAPI 2.0 or
+// 3.0 description, along with the problems found in them.
+func readExtensions(info *yaml.Node) (*Extensions, []string) {
+	c := &checker{}
+	e := &Extensions{
+		SecuritySchemes: make(map[string]*SecuritySchemeExtensions, 0),
+		Operations:      make(map[string]map[string]*OperationExtensions, 0),
+	}
+	document, ok := compiler.UnpackMap(info)
+	if !ok {
+		c.problem("", "description is not an object")
+		return e, c.problems
+	}
+	c.decode(document, "", documentExtensions, e)
+
+	var schemes *yaml.Node
+	if compiler.MapValueForKey(document, "swagger") != nil {
+		c.securitySchemesPath = "/securityDefinitions"
+		schemes, _ = compiler.UnpackMap(compiler.MapValueForKey(document, "securityDefinitions"))
+	} else {
+		c.securitySchemesPath = "/components/securitySchemes"
+		components, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "components"))
+		schemes, _ = compiler.UnpackMap(compiler.MapValueForKey(components, "securitySchemes"))
+	}
+	if schemes != nil {
+		for i := 0; i+1 < len(schemes.Content); i += 2 {
+			name := schemes.Content[i].Value
+			scheme := &SecuritySchemeExtensions{}
+			c.decode(schemes.Content[i+1], c.securitySchemesPath+"/"+escape(name), securitySchemeExtensions, scheme)
+			if scheme.AuthType != "" || scheme.Authorizer != nil {
+				e.SecuritySchemes[name] = scheme
+			}
+		}
+	}
+
+	paths, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "paths"))
+	if paths != nil {
+		for i := 0; i+1 < len(paths.Content); i += 2 {
+			p := paths.Content[i].Value
+			item, ok := compiler.UnpackMap(paths.Content[i+1])
+			if !ok {
+				continue
+			}
+			itemPath := "/paths/" + escape(p)
+			c.decode(item, itemPath, pathItemExtensions, nil)
+			for j := 0; j+1 < len(item.Content); j += 2 {
+				key := item.Content[j].Value
+				method := strings.ToUpper(key)
+				if key == "x-amazon-apigateway-any-method" {
+					method = "ANY"
+				} else if !contains(methods, method) {
+					continue
+				}
+				node, ok := compiler.UnpackMap(item.Content[j+1])
+				if !ok {
+					continue
+				}
+				operation := &OperationExtensions{}
+				c.decode(node, itemPath+"/"+escape(key), operationExtensions, operation)
+				if method == "ANY" {
+					operation.AnyMethod = node
+				}
+				if e.Operations[p] == nil {
+					e.Operations[p] = make(map[string]*OperationExtensions, 0)
+				}
+				e.Operations[p][method] = operation
+			}
+		}
+	}
+	c.checkExtensions(e)
+	return e, c.problems
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]map[string]*OperationExtensions) []string {
+	keys := make([]string, 0)
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escape(name string) string {
+	return strings.Replace(strings.Replace(name, "~", "~0", -1), "/", "~1", -1)
+}
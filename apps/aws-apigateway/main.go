@@ -0,0 +1,113 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// aws-apigateway checks the x-amazon-apigateway-* extensions of OpenAPI
+// descriptions and exports descriptions in the dialect of OpenAPI that AWS
+// API Gateway (and SAM) import.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+	"github.com/googleapis/gnostic/lib"
+	"gopkg.in/yaml.v3"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] OPENAPI_FILE
+
+Reads the x-amazon-apigateway-* extensions of an OpenAPI 2.0 or 3.0
+description and reports the problems found in them. With --export, also
+writes the compiled description in the dialect of OpenAPI that AWS API
+Gateway imports, with its extensions, and reports the features that
+API Gateway doesn't support.
+
+Options:
+  --export      Write the description for API Gateway.
+  --json        Write JSON instead of YAML.
+  --out=FILE    File to write (default: standard output).
+`, path.Base(os.Args[0]))
+}
+
+func main() {
+	exportFlag := flag.Bool("export", false, "Write the description for API Gateway.")
+	jsonFlag := flag.Bool("json", false, "Write JSON instead of YAML.")
+	out := flag.String("out", "", "File to write.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+	filename := args[0]
+
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	extensions, problems := readExtensions(info)
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "ERROR %s\n", problem)
+	}
+	if !*exportFlag {
+		if len(problems) > 0 {
+			os.Exit(-1)
+		}
+		return
+	}
+
+	document, err := gnostic.ReadDocument(filename)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	node, warnings := export(document, extensions)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "WARNING %s\n", warning)
+	}
+	var output []byte
+	if *jsonFlag || strings.HasSuffix(*out, ".json") {
+		output, err = jsonwriter.Marshal(node)
+	} else {
+		output, err = yaml.Marshal(node)
+	}
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	if *out == "" {
+		os.Stdout.Write(output)
+		return
+	}
+	if err = ioutil.WriteFile(*out, output, 0644); err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+}
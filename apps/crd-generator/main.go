@@ -0,0 +1,183 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// crd-generator writes Kubernetes CustomResourceDefinitions using schemas
+// from OpenAPI descriptions as the specs and statuses of custom resources.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] OPENAPI_FILE
+
+Writes a Kubernetes CustomResourceDefinition with structural schemas
+converted from the named schemas of an OpenAPI 2.0 or 3.0 description.
+Constraints that CustomResourceDefinitions don't support are relaxed or
+removed, and each change is reported as a warning.
+
+Options:
+  --spec=SCHEMA     Name of the schema of the resource's spec (required).
+  --status=SCHEMA   Name of the schema of the resource's status.
+  --group=GROUP     API group of the resource (required).
+  --kind=KIND       Kind of the resource (default: the name of the spec schema).
+  --version=NAME    Version of the resource (default: v1).
+  --plural=NAME     Plural name of the resource (default: the lowercased kind + "s").
+  --scope=SCOPE     Namespaced or Cluster (default: Namespaced).
+  --out=FILE        File to write (default: standard output).
+`, path.Base(os.Args[0]))
+}
+
+// Options of a generated CustomResourceDefinition.
+type Options struct {
+	Group   string
+	Kind    string
+	Version string
+	Plural  string
+	Scope   string
+}
+
+// Returns a CustomResourceDefinition for a resource with the specified spec
+// and status schemas. The status schema can be nil.
+func newCustomResourceDefinition(options *Options, spec *yaml.Node, status *yaml.Node) *yaml.Node {
+	singular := strings.ToLower(options.Kind)
+	plural := options.Plural
+	if plural == "" {
+		plural = singular + "s"
+	}
+	str := func(value string) *yaml.Node { return scalarNode(value, "!!str") }
+	mapping := func(pairs ...interface{}) *yaml.Node {
+		node := mappingNode()
+		for i := 0; i+1 < len(pairs); i += 2 {
+			appendPair(node, pairs[i].(string), pairs[i+1].(*yaml.Node))
+		}
+		return node
+	}
+
+	properties := mapping("spec", spec)
+	if status != nil {
+		appendPair(properties, "status", status)
+	}
+	version := mapping(
+		"name", str(options.Version),
+		"served", scalarNode("true", "!!bool"),
+		"storage", scalarNode("true", "!!bool"),
+		"schema", mapping("openAPIV3Schema", mapping(
+			"type", str("object"),
+			"properties", properties)))
+	if status != nil {
+		appendPair(version, "subresources", mapping("status", mappingNode()))
+	}
+	return mapping(
+		"apiVersion", str("apiextensions.k8s.io/v1"),
+		"kind", str("CustomResourceDefinition"),
+		"metadata", mapping("name", str(plural+"."+options.Group)),
+		"spec", mapping(
+			"group", str(options.Group),
+			"names", mapping(
+				"kind", str(options.Kind),
+				"listKind", str(options.Kind+"List"),
+				"plural", str(plural),
+				"singular", str(singular)),
+			"scope", str(options.Scope),
+			"versions", &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{version}}))
+}
+
+// Returns a node as YAML, indented in the style of Kubernetes manifests.
+func marshal(node *yaml.Node) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func main() {
+	specName := flag.String("spec", "", "Name of the schema of the resource's spec.")
+	statusName := flag.String("status", "", "Name of the schema of the resource's status.")
+	options := &Options{}
+	flag.StringVar(&options.Group, "group", "", "API group of the resource.")
+	flag.StringVar(&options.Kind, "kind", "", "Kind of the resource.")
+	flag.StringVar(&options.Version, "version", "v1", "Version of the resource.")
+	flag.StringVar(&options.Plural, "plural", "", "Plural name of the resource.")
+	flag.StringVar(&options.Scope, "scope", "Namespaced", "Namespaced or Cluster.")
+	out := flag.String("out", "", "File to write.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 || *specName == "" || options.Group == "" {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+	if options.Scope != "Namespaced" && options.Scope != "Cluster" {
+		fmt.Printf("Invalid scope: %s\n", options.Scope)
+		os.Exit(-1)
+	}
+	if options.Kind == "" {
+		options.Kind = *specName
+	}
+
+	prefix, schemas, err := readSchemasFromFile(args[0])
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	c := &converter{}
+	structural := func(name string) *yaml.Node {
+		schema, err := resolvedSchema(prefix, schemas, name)
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+		return c.structural(schema, "/"+name)
+	}
+	spec := structural(*specName)
+	var status *yaml.Node
+	if *statusName != "" {
+		status = structural(*statusName)
+	}
+	for _, warning := range c.warnings {
+		fmt.Fprintf(os.Stderr, "WARNING %s\n", warning)
+	}
+
+	output, err := marshal(newCustomResourceDefinition(options, spec, status))
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	if *out == "" {
+		os.Stdout.Write(output)
+		return
+	}
+	if err = ioutil.WriteFile(*out, output, 0644); err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+}
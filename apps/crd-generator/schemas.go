@@ -0,0 +1,174 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Returns the location and contents of the named schemas in an OpenAPI description.
+func readSchemasFromFile(filename string) (string, *yaml.Node, error) {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		return "", nil, err
+	}
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		return "", nil, err
+	}
+	document, ok := compiler.UnpackMap(info)
+	if !ok {
+		return "", nil, errors.New(fmt.Sprintf("%s is not an OpenAPI description", filename))
+	}
+	if compiler.MapValueForKey(document, "swagger") != nil {
+		schemas, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "definitions"))
+		return "#/definitions", schemas, nil
+	}
+	if compiler.MapValueForKey(document, "openapi") != nil {
+		components, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "components"))
+		schemas, _ := compiler.UnpackMap(compiler.MapValueForKey(components, "schemas"))
+		return "#/components/schemas", schemas, nil
+	}
+	return "", nil, errors.New(fmt.Sprintf("unable to determine the OpenAPI version of %s", filename))
+}
+
+// Returns the named schema with all of its references inlined and all of
+// its allOfs merged. References to enclosing schemas are kept.
+func resolvedSchema(prefix string, schemas *yaml.Node, name string) (*jsonschema.Schema, error) {
+	if compiler.MapValueForKey(schemas, name) == nil {
+		return nil, errors.New(fmt.Sprintf("schema %s not found", name))
+	}
+	definitions := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		definitions.Content = append(definitions.Content,
+			schemas.Content[i], prepareSchema(schemas.Content[i+1], prefix))
+	}
+	root := jsonschema.NewSchemaFromObject(&yaml.Node{
+		Kind:    yaml.MappingNode,
+		Content: []*yaml.Node{scalarNode("definitions", "!!str"), definitions},
+	})
+	if root == nil {
+		return nil, errors.New("unable to read schemas")
+	}
+	dereferenced, _, err := root.Dereference()
+	if err != nil {
+		return nil, err
+	}
+	schema := dereferenced.DefinitionWithName(name)
+	if schema == nil {
+		return nil, errors.New(fmt.Sprintf("schema %s not found", name))
+	}
+	return schema.FlattenAllOfs()
+}
+
+// Keywords of OpenAPI schemas that are read as JSON schemas.
+var schemaKeywords = map[string]bool{
+	"$schema": true, "id": true, "$ref": true,
+	"multipleOf": true, "maximum": true, "exclusiveMaximum": true, "minimum": true, "exclusiveMinimum": true,
+	"maxLength": true, "minLength": true, "pattern": true,
+	"additionalItems": true, "items": true, "maxItems": true, "minItems": true, "uniqueItems": true,
+	"maxProperties": true, "minProperties": true, "required": true,
+	"additionalProperties": true, "properties": true, "patternProperties": true, "dependencies": true,
+	"enum": true, "type": true, "allOf": true, "anyOf": true, "oneOf": true, "not": true, "definitions": true,
+	"title": true, "description": true, "default": true, "format": true,
+}
+
+// Returns a copy of an OpenAPI schema that can be read as a JSON schema.
+// References are rewritten to refer to definitions, nullable types are
+// written as type lists, and annotations such as examples and extensions
+// are removed.
+func prepareSchema(node *yaml.Node, prefix string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return node
+	}
+	result := &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag}
+	nullable := false
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "nullable", "x-nullable":
+			nullable = nullable || value.Value == "true"
+			continue
+		case "$ref":
+			if strings.HasPrefix(value.Value, prefix+"/") {
+				value = scalarNode("#/definitions/"+strings.TrimPrefix(value.Value, prefix+"/"), "!!str")
+			}
+		case "properties", "patternProperties", "definitions", "dependencies":
+			value = prepareNamedSchemas(value, prefix)
+		case "items", "allOf", "anyOf", "oneOf":
+			if value.Kind == yaml.SequenceNode {
+				value = prepareSchemaArray(value, prefix)
+			} else {
+				value = prepareSchema(value, prefix)
+			}
+		case "not", "additionalProperties", "additionalItems":
+			value = prepareSchema(value, prefix)
+		default:
+			if !schemaKeywords[key.Value] {
+				continue
+			}
+		}
+		result.Content = append(result.Content, key, value)
+	}
+	if nullable {
+		if typeNode := mapValue(result, "type"); typeNode != nil && typeNode.Kind == yaml.ScalarNode {
+			*typeNode = yaml.Node{
+				Kind:    yaml.SequenceNode,
+				Content: []*yaml.Node{scalarNode(typeNode.Value, "!!str"), scalarNode("null", "!!str")},
+			}
+		} else if typeNode != nil && typeNode.Kind == yaml.SequenceNode && !containsNull(typeNode) {
+			*typeNode = yaml.Node{
+				Kind:    yaml.SequenceNode,
+				Content: append(append([]*yaml.Node{}, typeNode.Content...), scalarNode("null", "!!str")),
+			}
+		}
+	}
+	return result
+}
+
+// Returns true if a list of types includes null.
+func containsNull(types *yaml.Node) bool {
+	for _, item := range types.Content {
+		if item.Value == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+func prepareNamedSchemas(node *yaml.Node, prefix string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return node
+	}
+	result := &yaml.Node{Kind: yaml.MappingNode, Tag: node.Tag}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		result.Content = append(result.Content, node.Content[i], prepareSchema(node.Content[i+1], prefix))
+	}
+	return result
+}
+
+func prepareSchemaArray(node *yaml.Node, prefix string) *yaml.Node {
+	result := &yaml.Node{Kind: yaml.SequenceNode, Tag: node.Tag}
+	for _, item := range node.Content {
+		result.Content = append(result.Content, prepareSchema(item, prefix))
+	}
+	return result
+}
@@ -0,0 +1,321 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// A converter writes schemas as Kubernetes structural schemas, the subset
+// of OpenAPI v3 schemas that CustomResourceDefinitions accept. Structural
+// schemas specify a type for every node, have no references, and restrict
+// the keywords that can be used. Constraints that can't be expressed are
+// dropped or relaxed, and each change is recorded as a warning.
+type converter struct {
+	warnings []string
+}
+
+func (c *converter) warn(path string, format string, args ...interface{}) {
+	if path == "" {
+		path = "/"
+	}
+	c.warnings = append(c.warnings, fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// Returns a node that accepts any value, which is used where a schema
+// can't be expressed structurally.
+func preserveUnknownFields(typeName string) *yaml.Node {
+	node := mappingNode()
+	if typeName != "" {
+		appendPair(node, "type", scalarNode(typeName, "!!str"))
+	}
+	appendPair(node, "x-kubernetes-preserve-unknown-fields", scalarNode("true", "!!bool"))
+	return node
+}
+
+// Converts a schema that has been dereferenced and flattened.
+func (c *converter) structural(schema *jsonschema.Schema, path string) *yaml.Node {
+	if schema == nil {
+		return preserveUnknownFields("")
+	}
+	if schema.Ref != nil {
+		c.warn(path, "recursive reference to %s accepts any object", *schema.Ref)
+		return preserveUnknownFields("object")
+	}
+	for i, alternatives := range []*[]*jsonschema.Schema{schema.AnyOf, schema.OneOf} {
+		if alternatives == nil {
+			continue
+		}
+		if alternativeTypes(*alternatives) == "integer,string" {
+			return c.intOrString(schema, path)
+		}
+		c.warn(path, "%s accepts any value", []string{"anyOf", "oneOf"}[i])
+		return preserveUnknownFields("")
+	}
+	if schema.AllOf != nil {
+		c.warn(path, "allOf with schemas that can't be merged accepts any value")
+		return preserveUnknownFields("")
+	}
+
+	node := mappingNode()
+	typeName, nullable := c.typeForSchema(schema, path)
+	switch typeName {
+	case "":
+		return preserveUnknownFields("")
+	case "integer,string":
+		return c.intOrString(schema, path)
+	}
+	appendPair(node, "type", scalarNode(typeName, "!!str"))
+	if nullable {
+		appendPair(node, "nullable", scalarNode("true", "!!bool"))
+	}
+	if schema.Description != nil {
+		appendPair(node, "description", scalarNode(*schema.Description, "!!str"))
+	} else if schema.Title != nil {
+		appendPair(node, "description", scalarNode(*schema.Title, "!!str"))
+	}
+	if schema.Format != nil {
+		appendPair(node, "format", scalarNode(*schema.Format, "!!str"))
+	}
+	if schema.Default != nil {
+		appendPair(node, "default", schema.Default)
+	}
+	if schema.Enumeration != nil {
+		values := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, value := range *schema.Enumeration {
+			if value.String != nil {
+				values.Content = append(values.Content, scalarNode(*value.String, "!!str"))
+			} else if value.Bool != nil {
+				values.Content = append(values.Content, scalarNode(strconv.FormatBool(*value.Bool), "!!bool"))
+			}
+		}
+		appendPair(node, "enum", values)
+	}
+	c.appendValidations(node, schema)
+	if schema.Not != nil {
+		c.warn(path, "not is not supported and was removed")
+	}
+
+	switch typeName {
+	case "object":
+		c.appendObject(node, schema, path)
+	case "array":
+		c.appendArray(node, schema, path)
+	}
+	return node
+}
+
+// Returns the structural type of a schema and whether it allows null.
+// The types of schemas without types are inferred from their keywords.
+func (c *converter) typeForSchema(schema *jsonschema.Schema, path string) (string, bool) {
+	types := make([]string, 0)
+	if schema.Type != nil {
+		if schema.Type.String != nil {
+			types = append(types, *schema.Type.String)
+		} else if schema.Type.StringArray != nil {
+			types = append(types, *schema.Type.StringArray...)
+		}
+	}
+	nullable := false
+	nonNull := make([]string, 0)
+	for _, typeName := range types {
+		if typeName == "null" {
+			nullable = true
+		} else {
+			nonNull = append(nonNull, typeName)
+		}
+	}
+	sort.Strings(nonNull)
+	switch len(nonNull) {
+	case 0:
+		switch {
+		case schema.Properties != nil || schema.AdditionalProperties != nil || schema.Required != nil:
+			return "object", nullable
+		case schema.Items != nil:
+			return "array", nullable
+		}
+		return "", nullable
+	case 1:
+		return nonNull[0], nullable
+	}
+	if strings.Join(nonNull, ",") == "integer,string" {
+		return "integer,string", nullable
+	}
+	c.warn(path, "a value with types %s accepts any value", strings.Join(nonNull, ", "))
+	return "", nullable
+}
+
+// Returns a node for a value that can be an integer or a string.
+func (c *converter) intOrString(schema *jsonschema.Schema, path string) *yaml.Node {
+	node := mappingNode()
+	appendPair(node, "x-kubernetes-int-or-string", scalarNode("true", "!!bool"))
+	if schema.Description != nil {
+		appendPair(node, "description", scalarNode(*schema.Description, "!!str"))
+	}
+	return node
+}
+
+// Returns the types of a list of alternative schemas, sorted and joined by commas.
+func alternativeTypes(alternatives []*jsonschema.Schema) string {
+	types := make([]string, 0)
+	for _, alternative := range alternatives {
+		if alternative.Type == nil || alternative.Type.String == nil {
+			return ""
+		}
+		types = append(types, *alternative.Type.String)
+	}
+	sort.Strings(types)
+	return strings.Join(types, ",")
+}
+
+// Appends the validations of numbers, strings, arrays, and objects.
+func (c *converter) appendValidations(node *yaml.Node, schema *jsonschema.Schema) {
+	appendNumber := func(key string, number *jsonschema.SchemaNumber) {
+		if number == nil {
+			return
+		}
+		if number.Integer != nil {
+			appendPair(node, key, scalarNode(strconv.FormatInt(*number.Integer, 10), "!!int"))
+		} else if number.Float != nil {
+			appendPair(node, key, scalarNode(strconv.FormatFloat(*number.Float, 'g', -1, 64), "!!float"))
+		}
+	}
+	appendInt := func(key string, value *int64) {
+		if value != nil {
+			appendPair(node, key, scalarNode(strconv.FormatInt(*value, 10), "!!int"))
+		}
+	}
+	appendBool := func(key string, value *bool) {
+		if value != nil && *value {
+			appendPair(node, key, scalarNode("true", "!!bool"))
+		}
+	}
+	appendNumber("minimum", schema.Minimum)
+	appendBool("exclusiveMinimum", schema.ExclusiveMinimum)
+	appendNumber("maximum", schema.Maximum)
+	appendBool("exclusiveMaximum", schema.ExclusiveMaximum)
+	appendNumber("multipleOf", schema.MultipleOf)
+	appendInt("minLength", schema.MinLength)
+	appendInt("maxLength", schema.MaxLength)
+	if schema.Pattern != nil {
+		appendPair(node, "pattern", scalarNode(*schema.Pattern, "!!str"))
+	}
+	appendInt("minItems", schema.MinItems)
+	appendInt("maxItems", schema.MaxItems)
+	appendInt("minProperties", schema.MinProperties)
+	appendInt("maxProperties", schema.MaxProperties)
+}
+
+// Appends the properties of an object.
+func (c *converter) appendObject(node *yaml.Node, schema *jsonschema.Schema, path string) {
+	if schema.Required != nil && len(*schema.Required) > 0 {
+		values := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, name := range *schema.Required {
+			values.Content = append(values.Content, scalarNode(name, "!!str"))
+		}
+		appendPair(node, "required", values)
+	}
+	if schema.Dependencies != nil {
+		c.warn(path, "dependencies are not supported and were removed")
+	}
+	var additional *jsonschema.Schema
+	if schema.AdditionalProperties != nil {
+		additional = schema.AdditionalProperties.Schema
+	}
+	if schema.PatternProperties != nil && len(*schema.PatternProperties) > 0 {
+		patterns := *schema.PatternProperties
+		if schema.Properties == nil && additional == nil && len(patterns) == 1 {
+			c.warn(path, "the names of properties are no longer checked against %q", patterns[0].Name)
+			additional = patterns[0].Value
+		} else {
+			c.warn(path, "patternProperties are not supported and were removed")
+		}
+	}
+	if schema.Properties != nil && len(*schema.Properties) > 0 {
+		properties := mappingNode()
+		for _, pair := range *schema.Properties {
+			appendPair(properties, pair.Name, c.structural(pair.Value, path+"/properties/"+escape(pair.Name)))
+		}
+		appendPair(node, "properties", properties)
+		if additional != nil {
+			c.warn(path, "additionalProperties can't be used with properties and were removed")
+		}
+		return
+	}
+	if additional != nil {
+		appendPair(node, "additionalProperties", c.structural(additional, path+"/additionalProperties"))
+		return
+	}
+	if allowed := schema.AdditionalProperties; allowed == nil || allowed.Boolean == nil || *allowed.Boolean {
+		// objects without properties would have all of their fields pruned
+		appendPair(node, "x-kubernetes-preserve-unknown-fields", scalarNode("true", "!!bool"))
+	}
+}
+
+// Appends the items of an array.
+func (c *converter) appendArray(node *yaml.Node, schema *jsonschema.Schema, path string) {
+	var items *jsonschema.Schema
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			items = schema.Items.Schema
+		} else if schema.Items.SchemaArray != nil {
+			c.warn(path, "arrays of items with different schemas accept any items")
+		}
+	}
+	if schema.AdditionalItems != nil {
+		c.warn(path, "additionalItems is not supported and was removed")
+	}
+	itemsNode := c.structural(items, path+"/items")
+	appendPair(node, "items", itemsNode)
+	if schema.UniqueItems != nil && *schema.UniqueItems {
+		// unique items are expressed as a list type, which requires scalar items
+		if itemType := mapValue(itemsNode, "type"); itemType != nil && itemType.Value != "object" && itemType.Value != "array" {
+			appendPair(node, "x-kubernetes-list-type", scalarNode("set", "!!str"))
+		} else {
+			c.warn(path, "uniqueItems is not supported for items that aren't scalars and was removed")
+		}
+	}
+}
+
+func escape(name string) string {
+	return strings.Replace(strings.Replace(name, "~", "~0", -1), "/", "~1", -1)
+}
+
+func mappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode}
+}
+
+func scalarNode(value string, tag string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+func appendPair(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, scalarNode(key, "!!str"), value)
+}
+
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const description = `
+openapi: 3.0.0
+components:
+  schemas:
+    Widget:
+      type: object
+      description: A widget.
+      required: [name]
+      properties:
+        name:
+          type: string
+          example: sprocket
+          x-order: 1
+        size:
+          $ref: '#/components/schemas/Size'
+        port:
+          anyOf:
+            - type: integer
+            - type: string
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+        tags:
+          type: array
+          uniqueItems: true
+          items:
+            type: string
+        owner:
+          type: string
+          nullable: true
+        nickname:
+          type: [string, "null"]
+        note:
+          type: string
+          x-nullable: true
+        parent:
+          $ref: '#/components/schemas/Widget'
+    Size:
+      allOf:
+        - type: object
+          properties:
+            width:
+              type: integer
+              format: int32
+              minimum: 0
+        - properties:
+            height:
+              type: integer
+`
+
+const expected = `type: object
+description: A widget.
+required:
+  - name
+properties:
+  name:
+    type: string
+  size:
+    type: object
+    properties:
+      width:
+        type: integer
+        format: int32
+        minimum: 0
+      height:
+        type: integer
+  port:
+    x-kubernetes-int-or-string: true
+  labels:
+    type: object
+    additionalProperties:
+      type: string
+  tags:
+    type: array
+    items:
+      type: string
+    x-kubernetes-list-type: set
+  owner:
+    type: string
+    nullable: true
+  nickname:
+    type: string
+    nullable: true
+  note:
+    type: string
+    nullable: true
+  parent:
+    type: object
+    x-kubernetes-preserve-unknown-fields: true
+`
+
+func TestStructuralSchema(t *testing.T) {
+	var info yaml.Node
+	if err := yaml.Unmarshal([]byte(description), &info); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	schemas := info.Content[0].Content[3].Content[1]
+	schema, err := resolvedSchema("#/components/schemas", schemas, "Widget")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	c := &converter{}
+	bytes, err := marshal(c.structural(schema, "/Widget"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if string(bytes) != expected {
+		t.Errorf("unexpected structural schema:\n%s", string(bytes))
+	}
+	if len(c.warnings) != 1 || !strings.HasPrefix(c.warnings[0], "/Widget/properties/parent: recursive reference") {
+		t.Errorf("unexpected warnings: %+v", c.warnings)
+	}
+}
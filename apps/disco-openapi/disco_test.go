@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+const discoveryDocument = `{
+  "kind": "discovery#restDescription",
+  "discoveryVersion": "v1",
+  "id": "library:v1",
+  "name": "library",
+  "version": "v1",
+  "title": "Library API",
+  "protocol": "rest",
+  "rootUrl": "https://library.googleapis.com/",
+  "servicePath": "",
+  "parameters": {
+    "key": {"type": "string", "location": "query", "description": "API key."}
+  },
+  "auth": {
+    "oauth2": {
+      "scopes": {
+        "https://www.googleapis.com/auth/library": {"description": "Manage your books"}
+      }
+    }
+  },
+  "schemas": {
+    "Book": {
+      "id": "Book",
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "pages": {"type": "string", "format": "int64"},
+        "genre": {"type": "string", "enum": ["FICTION", "OTHER"], "enumDescriptions": ["Made up", ""]},
+        "shelf": {"$ref": "Shelf"},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+      }
+    },
+    "Shelf": {"id": "Shelf", "type": "object", "properties": {"name": {"type": "string"}}}
+  },
+  "resources": {
+    "shelves": {
+      "resources": {
+        "books": {
+          "methods": {
+            "get": {
+              "id": "library.shelves.books.get",
+              "path": "v1/{+name}",
+              "httpMethod": "GET",
+              "parameters": {
+                "name": {"type": "string", "location": "path", "required": true},
+                "view": {"type": "string", "location": "query", "repeated": true}
+              },
+              "parameterOrder": ["name"],
+              "response": {"$ref": "Book"},
+              "scopes": ["https://www.googleapis.com/auth/library"]
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func TestImport(t *testing.T) {
+	discovery := &Discovery{}
+	if err := json.Unmarshal([]byte(discoveryDocument), discovery); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	c := &converter{}
+	document := c.importDiscovery(discovery)
+	info, err := compiler.ReadInfoFromBytes("", compiler.Marshal(document.ToRawInfo()))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if _, err = openapi_v3.NewDocument(info, compiler.NewContext("$root", nil)); err != nil {
+		t.Fatalf("Imported document is invalid: %+v", err)
+	}
+	item := document.Paths.Get("/v1/{name}")
+	if item == nil || item.Get.OperationId != "library.shelves.books.get" || item.Get.Tags[0] != "shelves" {
+		t.Fatalf("Unexpected path item: %+v", item)
+	}
+	if parameters := item.Get.Parameters; len(parameters) != 3 ||
+		parameters[0].GetReference().XRef != "#/components/parameters/key" ||
+		parameters[2].GetParameter().Schema.GetSchema().Type != "array" {
+		t.Errorf("Unexpected parameters: %+v", parameters)
+	}
+	expected := []string{
+		"schemas.Book.labels: additionalProperties aren't supported by the OpenAPI model, so the map is written as a free-form object",
+		"library.shelves.books.get: reserved expansions in v1/{+name} are written as path parameters, which can't contain slashes",
+	}
+	if !reflect.DeepEqual(c.warnings, expected) {
+		t.Errorf("Unexpected warnings: %+v", c.warnings)
+	}
+
+	// converting the imported document back restores its methods
+	exported := (&converter{}).exportV3(document, &Options{Name: "library"})
+	method := exported.Resources["shelves"].Resources["books"].Methods["get"]
+	if method == nil || method.ID != "library.shelves.books.get" || method.Path != "v1/{name}" ||
+		!reflect.DeepEqual(method.ParameterOrder, []string{"name"}) || !method.Parameters["view"].Repeated ||
+		method.Response.Ref != "Book" || len(method.Scopes) != 1 {
+		t.Errorf("Unexpected method: %+v", method)
+	}
+	if exported.Parameters["key"] == nil || exported.Auth.OAuth2.Scopes["https://www.googleapis.com/auth/library"] == nil {
+		t.Errorf("Unexpected document: %+v", exported)
+	}
+	if shelf := exported.Schemas["Book"].Properties["shelf"]; shelf.Ref != "Shelf" {
+		t.Errorf("Unexpected property: %+v", shelf)
+	}
+}
+
+func TestExportV2(t *testing.T) {
+	info, err := compiler.ReadInfoFromBytes("", []byte(`
+swagger: "2.0"
+info:
+  title: Pet Store
+  version: 1.0.0
+host: example.com
+basePath: /api
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      tags: [pets]
+      parameters:
+      - name: X-Trace
+        in: header
+        type: string
+      - name: pet
+        in: body
+        schema:
+          type: object
+          properties:
+            name:
+              type: string
+      responses:
+        200:
+          description: A pet
+          schema:
+            $ref: "#/definitions/Pet"
+definitions:
+  Pet:
+    type: object
+    properties:
+      tags:
+        type: object
+        additionalProperties:
+          type: string
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	c := &converter{}
+	discovery := c.exportV2(document, &Options{})
+	if discovery.Name != "petstore" || discovery.RootURL != "https://example.com/" || discovery.ServicePath != "api/" {
+		t.Errorf("Unexpected document: %+v", discovery)
+	}
+	method := discovery.Resources["pets"].Methods["createPet"]
+	if method == nil || method.ID != "petstore.pets.createPet" || method.Request.Ref != "PetsCreatePetRequest" || method.Response.Ref != "Pet" {
+		t.Fatalf("Unexpected method: %+v", method)
+	}
+	if discovery.Schemas["PetsCreatePetRequest"].Properties["name"].Type != "string" ||
+		discovery.Schemas["Pet"].Properties["tags"].AdditionalProperties.Type != "string" {
+		t.Errorf("Unexpected schemas: %+v", discovery.Schemas)
+	}
+	expected := []string{
+		"/pets.post: header parameter X-Trace is skipped because Discovery methods only have path and query parameters",
+	}
+	if !reflect.DeepEqual(c.warnings, expected) {
+		t.Errorf("Unexpected warnings: %+v", c.warnings)
+	}
+}
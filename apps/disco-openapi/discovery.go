@@ -0,0 +1,136 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Discovery is a Google API Discovery document, which describes the
+// resources and methods of an API and the schemas of their messages.
+// Only the fields that have OpenAPI counterparts are read and written.
+type Discovery struct {
+	Kind              string               `json:"kind"`
+	DiscoveryVersion  string               `json:"discoveryVersion"`
+	ID                string               `json:"id"`
+	Name              string               `json:"name"`
+	Version           string               `json:"version"`
+	Revision          string               `json:"revision,omitempty"`
+	Title             string               `json:"title,omitempty"`
+	Description       string               `json:"description,omitempty"`
+	DocumentationLink string               `json:"documentationLink,omitempty"`
+	Protocol          string               `json:"protocol"`
+	RootURL           string               `json:"rootUrl"`
+	ServicePath       string               `json:"servicePath"`
+	BaseURL           string               `json:"baseUrl,omitempty"`
+	Parameters        map[string]*Schema   `json:"parameters,omitempty"`
+	Auth              *Auth                `json:"auth,omitempty"`
+	Schemas           map[string]*Schema   `json:"schemas,omitempty"`
+	Resources         map[string]*Resource `json:"resources,omitempty"`
+	Methods           map[string]*Method   `json:"methods,omitempty"`
+}
+
+// Auth describes the OAuth 2.0 scopes that methods can require.
+type Auth struct {
+	OAuth2 *OAuth2 `json:"oauth2,omitempty"`
+}
+
+// OAuth2 maps the URLs of scopes to their descriptions.
+type OAuth2 struct {
+	Scopes map[string]*Scope `json:"scopes"`
+}
+
+// Scope describes an OAuth 2.0 scope.
+type Scope struct {
+	Description string `json:"description"`
+}
+
+// Schema is a Discovery schema, which describes messages and parameters.
+// Parameters also have a location, and are required or repeated.
+type Schema struct {
+	ID                   string             `json:"id,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Default              string             `json:"default,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              string             `json:"minimum,omitempty"`
+	Maximum              string             `json:"maximum,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	EnumDescriptions     []string           `json:"enumDescriptions,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	ReadOnly             bool               `json:"readOnly,omitempty"`
+	Location             string             `json:"location,omitempty"`
+	Required             bool               `json:"required,omitempty"`
+	Repeated             bool               `json:"repeated,omitempty"`
+}
+
+// Resource groups methods and other resources.
+type Resource struct {
+	Methods   map[string]*Method   `json:"methods,omitempty"`
+	Resources map[string]*Resource `json:"resources,omitempty"`
+}
+
+// Method describes an API method, which is called with an HTTP request.
+type Method struct {
+	ID             string             `json:"id"`
+	Path           string             `json:"path"`
+	FlatPath       string             `json:"flatPath,omitempty"`
+	HTTPMethod     string             `json:"httpMethod"`
+	Description    string             `json:"description,omitempty"`
+	Parameters     map[string]*Schema `json:"parameters,omitempty"`
+	ParameterOrder []string           `json:"parameterOrder,omitempty"`
+	Request        *Schema            `json:"request,omitempty"`
+	Response       *Schema            `json:"response,omitempty"`
+	Scopes         []string           `json:"scopes,omitempty"`
+}
+
+// Returns true if bytes contain a Discovery document.
+func isDiscovery(bytes []byte) bool {
+	var header struct {
+		DiscoveryVersion string `json:"discoveryVersion"`
+	}
+	return json.Unmarshal(bytes, &header) == nil && header.DiscoveryVersion != ""
+}
+
+// Returns the keys of a map in order, since Discovery documents don't
+// preserve the order of their objects.
+func sortedKeys(m interface{}) []string {
+	keys := make([]string, 0)
+	switch m := m.(type) {
+	case map[string]*Schema:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*Resource:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*Method:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	case map[string]*Scope:
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
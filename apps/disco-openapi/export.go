@@ -0,0 +1,250 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"gopkg.in/yaml.v3"
+)
+
+// An exporter writes the operations and schemas of an OpenAPI model as the
+// methods and schemas of a Discovery document.
+type exporter struct {
+	*converter
+	discovery *Discovery
+}
+
+// Options describe the Discovery document that an OpenAPI model is
+// exported as, for the fields that OpenAPI doesn't have.
+type Options struct {
+	// Name is the name of the API, such as "petstore". If it is empty,
+	// a name is derived from the title of the API.
+	Name string
+}
+
+var nonNameCharacters = regexp.MustCompile("[^a-z0-9]+")
+
+func newExporter(c *converter, title, version, description string, options *Options) *exporter {
+	name := options.Name
+	if name == "" {
+		name = nonNameCharacters.ReplaceAllString(strings.ToLower(title), "")
+	}
+	if name == "" {
+		name = "api"
+	}
+	return &exporter{
+		converter: c,
+		discovery: &Discovery{
+			Kind:             "discovery#restDescription",
+			DiscoveryVersion: "v1",
+			ID:               name + ":" + version,
+			Name:             name,
+			Version:          version,
+			Title:            title,
+			Description:      description,
+			Protocol:         "rest",
+		},
+	}
+}
+
+// Sets the root URL and service path of the document from the URL of a
+// server, such as "https://example.com/v1".
+func (e *exporter) setURL(url string) {
+	rootURL, servicePath := url, ""
+	if i := strings.Index(url, "://"); i >= 0 {
+		if j := strings.Index(url[i+3:], "/"); j >= 0 {
+			rootURL, servicePath = url[:i+3+j], strings.Trim(url[i+3+j:], "/")
+		}
+	}
+	e.discovery.RootURL = strings.TrimSuffix(rootURL, "/") + "/"
+	if servicePath != "" {
+		servicePath += "/"
+	}
+	e.discovery.ServicePath = servicePath
+	e.discovery.BaseURL = e.discovery.RootURL + servicePath
+}
+
+// Adds a schema, with a name that is made unique if necessary.
+// Returns the name of the schema.
+func (e *exporter) addSchema(name string, schema *Schema) string {
+	if e.discovery.Schemas == nil {
+		e.discovery.Schemas = make(map[string]*Schema)
+	}
+	unique := name
+	for i := 2; e.discovery.Schemas[unique] != nil; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	schema.ID = unique
+	e.discovery.Schemas[unique] = schema
+	return unique
+}
+
+// Returns a schema that refers to a message schema. Inline schemas are
+// added to the document with a name, since Discovery methods can only
+// refer to requests and responses by name.
+func (e *exporter) messageSchema(schema *Schema, name string) *Schema {
+	if schema == nil || schema.Ref != "" {
+		return schema
+	}
+	return &Schema{Ref: e.addSchema(name, schema)}
+}
+
+// Adds the scopes of an OAuth 2.0 flow to the document.
+func (e *exporter) addScope(url, description string) {
+	if e.discovery.Auth == nil {
+		e.discovery.Auth = &Auth{OAuth2: &OAuth2{Scopes: make(map[string]*Scope)}}
+	}
+	e.discovery.Auth.OAuth2.Scopes[url] = &Scope{Description: description}
+}
+
+// Adds a method. Operations with ids of the form "NAME.RESOURCE.METHOD",
+// which are written by importDiscovery, are added to the resources in
+// their ids. Other operations are added to a resource named by their
+// first tag, or to the document if they have none.
+func (e *exporter) addMethod(path, operationID, tag string, method *Method) {
+	parts := strings.Split(operationID, ".")
+	var resources []string
+	var name string
+	if len(parts) >= 3 && parts[0] == e.discovery.Name {
+		resources, name = parts[1:len(parts)-1], parts[len(parts)-1]
+	} else {
+		name = parts[len(parts)-1]
+		if name == "" {
+			name = methodName(method.HTTPMethod, method.Path)
+		}
+		if tag != "" {
+			resources = []string{nonNameCharacters.ReplaceAllString(strings.ToLower(tag), "")}
+		}
+	}
+	children, methods := &e.discovery.Resources, &e.discovery.Methods
+	for _, name := range resources {
+		if *children == nil {
+			*children = make(map[string]*Resource)
+		}
+		resource := (*children)[name]
+		if resource == nil {
+			resource = &Resource{}
+			(*children)[name] = resource
+		}
+		children, methods = &resource.Resources, &resource.Methods
+	}
+	if *methods == nil {
+		*methods = make(map[string]*Method)
+	}
+	unique := name
+	for i := 2; (*methods)[unique] != nil; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	if unique != name {
+		e.warn(path, "method %s is renamed %s because its name is already used", name, unique)
+	}
+	method.ID = strings.Join(append(append([]string{e.discovery.Name}, resources...), unique), ".")
+	(*methods)[unique] = method
+}
+
+// Returns a name for a method without an operation id, such as "getPetsPetId".
+func methodName(httpMethod, path string) string {
+	name := strings.ToLower(httpMethod)
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '{' || r == '}' }) {
+		segment = nonNameCharacters.ReplaceAllString(strings.ToLower(segment), "")
+		if segment != "" {
+			name += strings.ToUpper(segment[:1]) + segment[1:]
+		}
+	}
+	return name
+}
+
+// Returns the name of a schema for the request or response of a method,
+// such as "PetsCreateRequest" for the request of "petstore.pets.create".
+func messageName(id, suffix string) string {
+	name := ""
+	for _, part := range strings.Split(id, ".")[1:] {
+		if part != "" {
+			name += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return name + suffix
+}
+
+// Returns the name of the component that a reference such as
+// "#/components/schemas/Pet" refers to.
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// Returns the values of an enumeration as strings, which is how Discovery
+// documents list them.
+func enumValue(value string) string {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(value), &v); err != nil || v == nil {
+		return strings.TrimSpace(value)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Returns a bound of a number as a string, or "" if it isn't set.
+func bound(value *wrappers.DoubleValue) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(value.Value, 'f', -1, 64)
+}
+
+// Returns a Discovery parameter for a parameter schema. Arrays become
+// repeated parameters of their items' type.
+func parameterSchema(schema *Schema, in, description string, required bool) *Schema {
+	parameter := *schema
+	if parameter.Type == "array" && parameter.Items != nil {
+		parameter = *parameter.Items
+		parameter.Repeated = true
+	}
+	parameter.Location = in
+	parameter.Description = description
+	parameter.Required = required
+	return &parameter
+}
+
+// Returns the order of the parameters of a method: path parameters in the
+// order that they appear in its path, then required query parameters.
+func parameterOrder(method *Method) []string {
+	order := make([]string, 0)
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(method.Path, -1) {
+		if _, ok := method.Parameters[match[1]]; ok {
+			order = append(order, match[1])
+		}
+	}
+	for _, name := range sortedKeys(method.Parameters) {
+		if p := method.Parameters[name]; p.Location == "query" && p.Required {
+			order = append(order, name)
+		}
+	}
+	if len(order) == 0 {
+		return nil
+	}
+	return order
+}
+
+var pathParameterPattern = regexp.MustCompile(`\{\+?([^}]+)\}`)
+
+// Returns true if a media type is JSON, such as "application/json" or
+// "application/merge-patch+json".
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,335 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+)
+
+// exportV2 converts an OpenAPI 2.0 document to a Discovery document.
+// Parameters and responses that operations refer to are written where
+// they are used, except for query parameters that every operation refers
+// to, which become the parameters of the document.
+func (c *converter) exportV2(document *openapi_v2.Document, options *Options) *Discovery {
+	info := document.Info
+	if info == nil {
+		info = &openapi_v2.Info{}
+	}
+	e := newExporter(c, info.Title, info.Version, info.Description, options)
+	if document.ExternalDocs != nil {
+		e.discovery.DocumentationLink = document.ExternalDocs.Url
+	}
+	if document.Host != "" {
+		scheme := "https"
+		if len(document.Schemes) > 0 {
+			scheme = document.Schemes[0]
+		}
+		e.setURL(scheme + "://" + document.Host + document.BasePath)
+	}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			e.addSchema(pair.Name, e.exportSchemaV2("definitions."+pair.Name, pair.Value))
+		}
+	}
+	oauth2 := make(map[string]bool)
+	if document.SecurityDefinitions != nil {
+		for _, pair := range document.SecurityDefinitions.AdditionalProperties {
+			var scopes *openapi_v2.Oauth2Scopes
+			switch {
+			case pair.Value.GetOauth2ImplicitSecurity() != nil:
+				scopes = pair.Value.GetOauth2ImplicitSecurity().Scopes
+			case pair.Value.GetOauth2PasswordSecurity() != nil:
+				scopes = pair.Value.GetOauth2PasswordSecurity().Scopes
+			case pair.Value.GetOauth2ApplicationSecurity() != nil:
+				scopes = pair.Value.GetOauth2ApplicationSecurity().Scopes
+			case pair.Value.GetOauth2AccessCodeSecurity() != nil:
+				scopes = pair.Value.GetOauth2AccessCodeSecurity().Scopes
+			default:
+				e.warn("securityDefinitions."+pair.Name, "only OAuth 2.0 security definitions are supported by Discovery documents")
+				continue
+			}
+			oauth2[pair.Name] = true
+			if scopes != nil {
+				for _, scope := range scopes.AdditionalProperties {
+					e.addScope(scope.Name, scope.Value)
+				}
+			}
+		}
+	}
+
+	type operation struct {
+		path, method string
+		operation    *openapi_v2.Operation
+		parameters   []*openapi_v2.ParametersItem
+	}
+	operations := make([]operation, 0)
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			item := pair.Value
+			for _, o := range []operation{
+				{pair.Name, "GET", item.Get, nil},
+				{pair.Name, "PUT", item.Put, nil},
+				{pair.Name, "POST", item.Post, nil},
+				{pair.Name, "DELETE", item.Delete, nil},
+				{pair.Name, "OPTIONS", item.Options, nil},
+				{pair.Name, "HEAD", item.Head, nil},
+				{pair.Name, "PATCH", item.Patch, nil},
+			} {
+				if o.operation != nil {
+					o.parameters = append(append([]*openapi_v2.ParametersItem{}, item.Parameters...), o.operation.Parameters...)
+					operations = append(operations, o)
+				}
+			}
+		}
+	}
+
+	// query parameters that every operation refers to apply to the whole document
+	counts := make(map[string]int)
+	for _, o := range operations {
+		seen := make(map[string]bool)
+		for _, p := range o.parameters {
+			if ref := p.GetJsonReference(); ref != nil && !seen[ref.XRef] {
+				seen[ref.XRef] = true
+				counts[ref.XRef]++
+			}
+		}
+	}
+	shared := make(map[string]bool)
+	if document.Parameters != nil && len(operations) > 0 {
+		for _, pair := range document.Parameters.AdditionalProperties {
+			ref := "#/parameters/" + pair.Name
+			query := pair.Value.GetNonBodyParameter().GetQueryParameterSubSchema()
+			if counts[ref] == len(operations) && query != nil {
+				shared[ref] = true
+				if e.discovery.Parameters == nil {
+					e.discovery.Parameters = make(map[string]*Schema)
+				}
+				e.discovery.Parameters[query.Name] = e.exportParameterV2("parameters."+pair.Name, pair.Value)
+			}
+		}
+	}
+
+	for _, o := range operations {
+		path := o.path + "." + strings.ToLower(o.method)
+		method := &Method{
+			Path:        strings.TrimPrefix(o.path, "/"),
+			HTTPMethod:  o.method,
+			Description: o.operation.Description,
+		}
+		if method.Description == "" {
+			method.Description = o.operation.Summary
+		}
+		var request *Schema
+		for _, p := range o.parameters {
+			parameter := p.GetParameter()
+			if ref := p.GetJsonReference(); ref != nil {
+				if shared[ref.XRef] {
+					continue
+				}
+				parameter = definedParameterV2(document, ref.XRef)
+				if parameter == nil {
+					e.warn(path, "parameter %s isn't defined and is skipped", ref.XRef)
+					continue
+				}
+			}
+			if body := parameter.GetBodyParameter(); body != nil {
+				request = e.exportSchemaV2(path+".parameters."+body.Name, body.Schema)
+				continue
+			}
+			name, in := nonBodyParameterNameV2(parameter)
+			if in != "path" && in != "query" {
+				e.warn(path, "%s parameter %s is skipped because Discovery methods only have path and query parameters", in, name)
+				continue
+			}
+			if method.Parameters == nil {
+				method.Parameters = make(map[string]*Schema)
+			}
+			method.Parameters[name] = e.exportParameterV2(path+".parameters."+name, parameter)
+		}
+		method.ParameterOrder = parameterOrder(method)
+		tag := ""
+		if len(o.operation.Tags) > 0 {
+			tag = o.operation.Tags[0]
+		}
+		e.addMethod(path, o.operation.OperationId, tag, method)
+		method.Request = e.messageSchema(request, messageName(method.ID, "Request"))
+		if response := successResponseV2(document, o.operation.Responses); response != nil && response.Schema != nil {
+			if schema := response.Schema.GetSchema(); schema != nil {
+				method.Response = e.messageSchema(e.exportSchemaV2(path+".responses", schema), messageName(method.ID, "Response"))
+			} else {
+				e.warn(path, "file responses are skipped because Discovery methods only have JSON messages")
+			}
+		}
+		security := o.operation.Security
+		if security == nil {
+			security = document.Security
+		}
+		for _, requirement := range security {
+			for _, pair := range requirement.AdditionalProperties {
+				if oauth2[pair.Name] && pair.Value != nil {
+					method.Scopes = appendUnique(method.Scopes, pair.Value.Value...)
+				}
+			}
+		}
+	}
+	return e.discovery
+}
+
+func definedParameterV2(document *openapi_v2.Document, ref string) *openapi_v2.Parameter {
+	if document.Parameters != nil {
+		for _, pair := range document.Parameters.AdditionalProperties {
+			if "#/parameters/"+pair.Name == ref {
+				return pair.Value
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the first successful response of an operation, in the order of
+// their status codes.
+func successResponseV2(document *openapi_v2.Document, responses *openapi_v2.Responses) *openapi_v2.Response {
+	if responses == nil {
+		return nil
+	}
+	codes := make([]*openapi_v2.NamedResponseValue, 0)
+	for _, pair := range responses.ResponseCode {
+		if strings.HasPrefix(pair.Name, "2") {
+			codes = append(codes, pair)
+		}
+	}
+	sort.SliceStable(codes, func(i, j int) bool { return codes[i].Name < codes[j].Name })
+	for _, pair := range codes {
+		if response := pair.Value.GetResponse(); response != nil {
+			return response
+		}
+		if ref := pair.Value.GetJsonReference(); ref != nil && document.Responses != nil {
+			for _, defined := range document.Responses.AdditionalProperties {
+				if "#/responses/"+defined.Name == ref.XRef {
+					return defined.Value
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the name and location of a non-body parameter.
+func nonBodyParameterNameV2(parameter *openapi_v2.Parameter) (string, string) {
+	p := parameter.GetNonBodyParameter()
+	switch {
+	case p.GetHeaderParameterSubSchema() != nil:
+		return p.GetHeaderParameterSubSchema().Name, "header"
+	case p.GetFormDataParameterSubSchema() != nil:
+		return p.GetFormDataParameterSubSchema().Name, "formData"
+	case p.GetQueryParameterSubSchema() != nil:
+		return p.GetQueryParameterSubSchema().Name, "query"
+	case p.GetPathParameterSubSchema() != nil:
+		return p.GetPathParameterSubSchema().Name, "path"
+	}
+	return "", ""
+}
+
+// Returns the Discovery parameter for a query or path parameter.
+func (e *exporter) exportParameterV2(path string, parameter *openapi_v2.Parameter) *Schema {
+	p := parameter.GetNonBodyParameter()
+	if query := p.GetQueryParameterSubSchema(); query != nil {
+		schema := primitivesSchemaV2(query.Type, query.Format, query.Items, query.Enum)
+		schema.Pattern = query.Pattern
+		schema.Minimum = bound(query.Minimum)
+		schema.Maximum = bound(query.Maximum)
+		return parameterSchema(schema, "query", query.Description, query.Required.GetValue())
+	}
+	if pathParameter := p.GetPathParameterSubSchema(); pathParameter != nil {
+		schema := primitivesSchemaV2(pathParameter.Type, pathParameter.Format, pathParameter.Items, pathParameter.Enum)
+		schema.Pattern = pathParameter.Pattern
+		schema.Minimum = bound(pathParameter.Minimum)
+		schema.Maximum = bound(pathParameter.Maximum)
+		return parameterSchema(schema, "path", pathParameter.Description, true)
+	}
+	return &Schema{Type: "string"}
+}
+
+// Returns the schema of a parameter with a primitive type.
+func primitivesSchemaV2(typeName, format string, items *openapi_v2.PrimitivesItems, enum []*openapi_v2.Any) *Schema {
+	schema := &Schema{Type: typeName, Format: format}
+	for _, value := range enum {
+		schema.Enum = append(schema.Enum, enumValue(value.Yaml))
+	}
+	if typeName == "array" && items != nil {
+		schema.Items = primitivesSchemaV2(items.Type, items.Format, items.Items, nil)
+	}
+	return schema
+}
+
+// Returns the Discovery schema for an OpenAPI 2.0 schema.
+func (e *exporter) exportSchemaV2(path string, schema *openapi_v2.Schema) *Schema {
+	if schema == nil {
+		return &Schema{Type: "any"}
+	}
+	if schema.XRef != "" {
+		return &Schema{Ref: refName(schema.XRef)}
+	}
+	if len(schema.AllOf) == 1 && schema.Properties == nil {
+		return e.exportSchemaV2(path, schema.AllOf[0])
+	}
+	s := &Schema{
+		Format:      schema.Format,
+		Description: schema.Description,
+		Pattern:     schema.Pattern,
+		Minimum:     bound(schema.Minimum),
+		Maximum:     bound(schema.Maximum),
+		ReadOnly:    schema.ReadOnly.GetValue(),
+	}
+	if schema.Type != nil && len(schema.Type.Value) > 0 {
+		s.Type = schema.Type.Value[0]
+	}
+	if len(schema.AllOf) > 0 {
+		e.warn(path, "schemas that combine other schemas are written as schemas of any type")
+		s.Type = "any"
+		return s
+	}
+	for _, value := range schema.Enum {
+		s.Enum = append(s.Enum, enumValue(value.Yaml))
+	}
+	if schema.Properties != nil {
+		s.Properties = make(map[string]*Schema)
+		for _, pair := range schema.Properties.AdditionalProperties {
+			s.Properties[pair.Name] = e.exportSchemaV2(path+"."+pair.Name, pair.Value)
+		}
+		if s.Type == "" {
+			s.Type = "object"
+		}
+	}
+	if values := schema.AdditionalProperties.GetSchema(); values != nil {
+		s.AdditionalProperties = e.exportSchemaV2(path+".additionalProperties", values)
+		if s.Type == "" {
+			s.Type = "object"
+		}
+	}
+	if schema.Items != nil && len(schema.Items.Schema) > 0 {
+		s.Items = e.exportSchemaV2(path+".items", schema.Items.Schema[0])
+		if s.Type == "" {
+			s.Type = "array"
+		}
+	}
+	if s.Type == "" {
+		s.Type = "any"
+	}
+	return s
+}
@@ -0,0 +1,343 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"gopkg.in/yaml.v3"
+)
+
+// exportV3 converts an OpenAPI 3.0 document to a Discovery document.
+// Parameters of the components that every operation refers to become
+// the parameters of the document, which apply to all methods.
+func (c *converter) exportV3(document *openapi_v3.Document, options *Options) *Discovery {
+	info := document.Info
+	if info == nil {
+		info = &openapi_v3.Info{}
+	}
+	e := newExporter(c, info.Title, info.Version, info.Description, options)
+	if document.ExternalDocs != nil {
+		e.discovery.DocumentationLink = document.ExternalDocs.Url
+	}
+	if len(document.Servers) > 0 {
+		e.setURL(serverURL(document.Servers[0]))
+	}
+	components := document.Components
+	if components == nil {
+		components = &openapi_v3.Components{}
+	}
+	if components.Schemas != nil {
+		for _, pair := range components.Schemas.AdditionalProperties {
+			e.addSchema(pair.Name, e.exportSchemaV3("components.schemas."+pair.Name, pair.Value))
+		}
+	}
+	oauth2 := make(map[string]bool)
+	if components.SecuritySchemes != nil {
+		for _, pair := range components.SecuritySchemes.AdditionalProperties {
+			if pair.Value.Type != "oauth2" || pair.Value.Flow == nil {
+				e.warn("components.securitySchemes."+pair.Name, "%s security schemes aren't supported by Discovery documents", pair.Value.Type)
+				continue
+			}
+			oauth2[pair.Name] = true
+			flows := pair.Value.Flow
+			for _, flow := range []*openapi_v3.OauthFlow{flows.Implicit, flows.Password, flows.ClientCredentials, flows.AuthorizationCode} {
+				if flow != nil && flow.Scopes != nil {
+					for _, scope := range flow.Scopes.Name {
+						e.addScope(scope.Name, enumValue(scope.Value.Yaml))
+					}
+				}
+			}
+		}
+	}
+
+	type operation struct {
+		path, method string
+		operation    *openapi_v3.Operation
+		parameters   []*openapi_v3.ParameterOrReference
+	}
+	operations := make([]operation, 0)
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			item := pair.Value
+			for _, o := range []operation{
+				{pair.Name, "GET", item.Get, nil},
+				{pair.Name, "PUT", item.Put, nil},
+				{pair.Name, "POST", item.Post, nil},
+				{pair.Name, "DELETE", item.Delete, nil},
+				{pair.Name, "OPTIONS", item.Options, nil},
+				{pair.Name, "HEAD", item.Head, nil},
+				{pair.Name, "PATCH", item.Patch, nil},
+				{pair.Name, "TRACE", item.Trace, nil},
+			} {
+				if o.operation != nil {
+					o.parameters = append(append([]*openapi_v3.ParameterOrReference{}, item.Parameters...), o.operation.Parameters...)
+					operations = append(operations, o)
+				}
+			}
+		}
+	}
+
+	// parameters that every operation refers to apply to the whole document
+	counts := make(map[string]int)
+	for _, o := range operations {
+		seen := make(map[string]bool)
+		for _, p := range o.parameters {
+			if ref := p.GetReference(); ref != nil && !seen[ref.XRef] {
+				seen[ref.XRef] = true
+				counts[ref.XRef]++
+			}
+		}
+	}
+	shared := make(map[string]bool)
+	if components.Parameters != nil && len(operations) > 0 {
+		for _, pair := range components.Parameters.AdditionalProperties {
+			ref := "#/components/parameters/" + pair.Name
+			if counts[ref] == len(operations) && pair.Value.In == "query" {
+				shared[ref] = true
+				if e.discovery.Parameters == nil {
+					e.discovery.Parameters = make(map[string]*Schema)
+				}
+				e.discovery.Parameters[pair.Value.Name] = e.exportParameterV3("components.parameters."+pair.Name, pair.Value)
+			}
+		}
+	}
+
+	for _, o := range operations {
+		path := o.path + "." + strings.ToLower(o.method)
+		method := &Method{
+			Path:        strings.TrimPrefix(o.path, "/"),
+			HTTPMethod:  o.method,
+			Description: o.operation.Description,
+		}
+		if method.Description == "" {
+			method.Description = o.operation.Summary
+		}
+		for _, p := range o.parameters {
+			parameter := p.GetParameter()
+			if ref := p.GetReference(); ref != nil {
+				if shared[ref.XRef] {
+					continue
+				}
+				parameter = componentParameterV3(components, ref.XRef)
+				if parameter == nil {
+					e.warn(path, "parameter %s isn't in the components and is skipped", ref.XRef)
+					continue
+				}
+			}
+			if parameter.In != "path" && parameter.In != "query" {
+				e.warn(path, "%s parameter %s is skipped because Discovery methods only have path and query parameters", parameter.In, parameter.Name)
+				continue
+			}
+			if method.Parameters == nil {
+				method.Parameters = make(map[string]*Schema)
+			}
+			method.Parameters[parameter.Name] = e.exportParameterV3(path+".parameters."+parameter.Name, parameter)
+		}
+		method.ParameterOrder = parameterOrder(method)
+		tag := ""
+		if len(o.operation.Tags) > 0 {
+			tag = o.operation.Tags[0]
+		}
+		e.addMethod(path, o.operation.OperationId, tag, method)
+		if body := o.operation.RequestBody; body != nil {
+			requestBody := body.GetRequestBody()
+			if ref := body.GetReference(); ref != nil {
+				requestBody = componentRequestBodyV3(components, ref.XRef)
+			}
+			if requestBody != nil {
+				method.Request = e.messageSchema(e.jsonContentV3(path+".requestBody", requestBody.Content), messageName(method.ID, "Request"))
+			}
+		}
+		if response := successResponseV3(components, o.operation.Responses); response != nil {
+			method.Response = e.messageSchema(e.jsonContentV3(path+".responses", response.Content), messageName(method.ID, "Response"))
+		}
+		security := o.operation.Security
+		if security == nil {
+			security = document.Security
+		}
+		for _, requirement := range security {
+			for _, pair := range requirement.Name {
+				if !oauth2[pair.Name] {
+					continue
+				}
+				var scopes []string
+				yaml.Unmarshal([]byte(pair.Value.Yaml), &scopes)
+				method.Scopes = appendUnique(method.Scopes, scopes...)
+			}
+		}
+	}
+	return e.discovery
+}
+
+// Returns the URL of a server with the default values of its variables.
+func serverURL(server *openapi_v3.Server) string {
+	url := server.Url
+	if server.Variables != nil {
+		for _, pair := range server.Variables.Name {
+			if pair.Value.Default != nil {
+				url = strings.Replace(url, "{"+pair.Name+"}", pair.Value.Default.GetString_(), -1)
+			}
+		}
+	}
+	return url
+}
+
+func componentParameterV3(components *openapi_v3.Components, ref string) *openapi_v3.Parameter {
+	if components.Parameters != nil {
+		for _, pair := range components.Parameters.AdditionalProperties {
+			if "#/components/parameters/"+pair.Name == ref {
+				return pair.Value
+			}
+		}
+	}
+	return nil
+}
+
+func componentRequestBodyV3(components *openapi_v3.Components, ref string) *openapi_v3.RequestBody {
+	if components.RequestBodies != nil {
+		for _, pair := range components.RequestBodies.AdditionalProperties {
+			if "#/components/requestBodies/"+pair.Name == ref {
+				return pair.Value
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the first successful response of an operation, in the order of
+// their status codes.
+func successResponseV3(components *openapi_v3.Components, responses *openapi_v3.Responses) *openapi_v3.Response {
+	if responses == nil {
+		return nil
+	}
+	codes := make([]*openapi_v3.NamedResponseOrReference, 0)
+	for _, pair := range responses.ResponseCode {
+		if strings.HasPrefix(pair.Name, "2") {
+			codes = append(codes, pair)
+		}
+	}
+	sort.SliceStable(codes, func(i, j int) bool { return codes[i].Name < codes[j].Name })
+	for _, pair := range codes {
+		if response := pair.Value.GetResponse(); response != nil {
+			return response
+		}
+		if ref := pair.Value.GetReference(); ref != nil && components.Responses != nil {
+			for _, component := range components.Responses.ResponseCode {
+				if "#/components/responses/"+component.Name == ref.XRef {
+					return component.Value.GetResponse()
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the schema of the JSON content of a request or response.
+func (e *exporter) jsonContentV3(path string, content *openapi_v3.Content) *Schema {
+	if content == nil {
+		return nil
+	}
+	for _, pair := range content.MediaType {
+		if isJSON(pair.Name) && pair.Value.Schema != nil {
+			return e.exportSchemaOrReferenceV3(path, pair.Value.Schema)
+		}
+	}
+	if len(content.MediaType) > 0 {
+		e.warn(path, "content of type %s is skipped because Discovery methods only have JSON messages", content.MediaType[0].Name)
+	}
+	return nil
+}
+
+func (e *exporter) exportParameterV3(path string, parameter *openapi_v3.Parameter) *Schema {
+	schema := &Schema{Type: "string"}
+	if parameter.Schema != nil {
+		schema = e.exportSchemaOrReferenceV3(path, parameter.Schema)
+	}
+	return parameterSchema(schema, parameter.In, parameter.Description, parameter.Required.GetValue())
+}
+
+func (e *exporter) exportSchemaOrReferenceV3(path string, schema *openapi_v3.SchemaOrReference) *Schema {
+	if ref := schema.GetReference(); ref != nil {
+		return &Schema{Ref: refName(ref.XRef)}
+	}
+	return e.exportSchemaV3(path, schema.GetSchema())
+}
+
+// Returns the Discovery schema for an OpenAPI schema. Schemas that combine
+// other schemas can't be described, and are written as schemas of any type.
+func (e *exporter) exportSchemaV3(path string, schema *openapi_v3.Schema) *Schema {
+	if schema == nil {
+		return &Schema{Type: "any"}
+	}
+	if len(schema.AllOf) == 1 && schema.Properties == nil {
+		return e.exportSchemaOrReferenceV3(path, schema.AllOf[0])
+	}
+	s := &Schema{
+		Type:        schema.Type,
+		Format:      schema.Format,
+		Description: schema.Description,
+		Pattern:     schema.Pattern,
+		Minimum:     bound(schema.Minimum),
+		Maximum:     bound(schema.Maximum),
+		ReadOnly:    schema.ReadOnly.GetValue(),
+	}
+	if len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		e.warn(path, "schemas that combine other schemas are written as schemas of any type")
+		s.Type = "any"
+		return s
+	}
+	for _, value := range schema.Enum {
+		s.Enum = append(s.Enum, enumValue(value.Yaml))
+	}
+	if schema.Properties != nil {
+		s.Properties = make(map[string]*Schema)
+		for _, pair := range schema.Properties.AdditionalProperties {
+			s.Properties[pair.Name] = e.exportSchemaV3(path+"."+pair.Name, pair.Value)
+		}
+		if s.Type == "" {
+			s.Type = "object"
+		}
+	}
+	if schema.Items != nil && len(schema.Items.SchemaOrReference) > 0 {
+		s.Items = e.exportSchemaOrReferenceV3(path+".items", schema.Items.SchemaOrReference[0])
+		if s.Type == "" {
+			s.Type = "array"
+		}
+	}
+	if s.Type == "" {
+		s.Type = "any"
+	}
+	return s
+}
+
+// Appends values that a list doesn't already contain.
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, v := range list {
+			if v == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
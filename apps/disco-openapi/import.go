@@ -0,0 +1,271 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/builder/v3"
+)
+
+// The URLs that Google's OAuth 2.0 authorization server uses, which the
+// scopes of Discovery documents are granted by.
+const (
+	googleAuthorizationURL = "https://accounts.google.com/o/oauth2/auth"
+	googleTokenURL         = "https://accounts.google.com/o/oauth2/token"
+)
+
+// The name of the security scheme that holds the scopes of a Discovery document.
+const oauth2SchemeName = "Oauth2"
+
+// A converter converts between Discovery documents and OpenAPI models.
+// Fields that can't be converted are dropped, and each change is recorded
+// as a warning.
+type converter struct {
+	warnings []string
+}
+
+func (c *converter) warn(path string, format string, args ...interface{}) {
+	c.warnings = append(c.warnings, fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// importDiscovery converts a Discovery document to an OpenAPI 3.0 document.
+// Resources become tags, methods become operations identified by their ids,
+// and the parameters that apply to every method become components that
+// each operation refers to.
+func (c *converter) importDiscovery(d *Discovery) *openapi_v3.Document {
+	title := d.Title
+	if title == "" {
+		title = d.Name
+	}
+	b := builder_v3.NewDocument(title, d.Version).Description(d.Description)
+	if d.DocumentationLink != "" {
+		b.ExternalDocs(d.DocumentationLink, "")
+	}
+	if url := d.BaseURL; url != "" || d.RootURL != "" {
+		if url == "" {
+			url = d.RootURL + d.ServicePath
+		}
+		b.Server(url, "")
+	}
+	for _, name := range sortedKeys(d.Schemas) {
+		b.Schema(name, c.importSchema("schemas."+name, d.Schemas[name]))
+	}
+	parameters := sortedKeys(d.Parameters)
+	for _, name := range parameters {
+		b.Parameter(name, c.importParameter("parameters."+name, name, d.Parameters[name]))
+	}
+	if d.Auth != nil && d.Auth.OAuth2 != nil {
+		scopes := make(map[string]string, len(d.Auth.OAuth2.Scopes))
+		for url, scope := range d.Auth.OAuth2.Scopes {
+			scopes[url] = scope.Description
+		}
+		b.SecurityScheme(oauth2SchemeName, builder_v3.OAuth2Scheme(nil, nil, nil,
+			builder_v3.OAuth2Flow(googleAuthorizationURL, googleTokenURL, scopes)))
+	}
+	c.importMethods(b, "", d.Methods, parameters)
+	c.importResources(b, "", d.Resources, parameters)
+	return b.Build()
+}
+
+// Adds the methods of resources and their subresources. Operations are
+// tagged with the names of top-level resources.
+func (c *converter) importResources(b *builder_v3.DocumentBuilder, tag string, resources map[string]*Resource, parameters []string) {
+	for _, name := range sortedKeys(resources) {
+		t := tag
+		if t == "" {
+			t = name
+			b.Tag(name, "")
+		}
+		c.importMethods(b, t, resources[name].Methods, parameters)
+		c.importResources(b, t, resources[name].Resources, parameters)
+	}
+}
+
+func (c *converter) importMethods(b *builder_v3.DocumentBuilder, tag string, methods map[string]*Method, parameters []string) {
+	for _, name := range sortedKeys(methods) {
+		method := methods[name]
+		path := method.ID
+		if path == "" {
+			path = name
+		}
+		operation := builder_v3.NewOperation(method.ID).Description(method.Description)
+		if tag != "" {
+			operation.Tags(tag)
+		}
+		for _, parameter := range parameters {
+			operation.ParameterRef(parameter)
+		}
+		for _, parameterName := range methodParameterNames(method) {
+			operation.Parameter(c.importParameter(path+".parameters."+parameterName, parameterName, method.Parameters[parameterName]))
+		}
+		if method.Request != nil {
+			operation.RequestBody("application/json", c.importSchemaOrReference(path+".request", method.Request), true)
+		}
+		if method.Response != nil {
+			operation.Response("200", "Successful response", "application/json", c.importSchemaOrReference(path+".response", method.Response))
+		} else {
+			operation.Response("200", "Successful response", "", nil)
+		}
+		// a method can be called with any one of its scopes
+		for _, scope := range method.Scopes {
+			operation.Security(builder_v3.Requirement(oauth2SchemeName, scope))
+		}
+		c.addOperation(b, path, method, operation)
+	}
+}
+
+// Returns the names of the parameters of a method, with the parameters
+// in its parameterOrder first.
+func methodParameterNames(method *Method) []string {
+	names := make([]string, 0, len(method.Parameters))
+	seen := make(map[string]bool)
+	for _, name := range method.ParameterOrder {
+		if _, ok := method.Parameters[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	for _, name := range sortedKeys(method.Parameters) {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Adds an operation at the path of a method. Discovery paths are relative
+// to the service path and can use reserved expansions such as "{+name}",
+// which are written as simple path parameters.
+func (c *converter) addOperation(b *builder_v3.DocumentBuilder, path string, method *Method, operation *builder_v3.OperationBuilder) {
+	methodPath := "/" + strings.TrimPrefix(method.Path, "/")
+	if strings.Contains(methodPath, "{+") {
+		c.warn(path, "reserved expansions in %s are written as path parameters, which can't contain slashes", method.Path)
+		methodPath = strings.Replace(methodPath, "{+", "{", -1)
+	}
+	switch strings.ToUpper(method.HTTPMethod) {
+	case "GET":
+		b.Get(methodPath, operation)
+	case "PUT":
+		b.Put(methodPath, operation)
+	case "POST":
+		b.Post(methodPath, operation)
+	case "DELETE":
+		b.Delete(methodPath, operation)
+	case "OPTIONS":
+		b.Options(methodPath, operation)
+	case "HEAD":
+		b.Head(methodPath, operation)
+	case "PATCH":
+		b.Patch(methodPath, operation)
+	default:
+		c.warn(path, "method with unsupported HTTP method %q is skipped", method.HTTPMethod)
+	}
+}
+
+func (c *converter) importParameter(path, name string, parameter *Schema) *openapi_v3.Parameter {
+	in := parameter.Location
+	if in != "path" && in != "query" {
+		if in != "" {
+			c.warn(path, "parameter in %s is written as a query parameter", in)
+		}
+		in = "query"
+	}
+	schema := c.importSchema(path, &Schema{
+		Type:             parameter.Type,
+		Ref:              parameter.Ref,
+		Format:           parameter.Format,
+		Default:          parameter.Default,
+		Pattern:          parameter.Pattern,
+		Minimum:          parameter.Minimum,
+		Maximum:          parameter.Maximum,
+		Enum:             parameter.Enum,
+		EnumDescriptions: parameter.EnumDescriptions,
+		Items:            parameter.Items,
+	})
+	if parameter.Repeated {
+		schema = builder_v3.Array(builder_v3.Inline(schema))
+	}
+	return (&openapi_v3.Parameter{}).
+		SetName(name).
+		SetIn(in).
+		SetDescription(parameter.Description).
+		SetRequired(parameter.Required || in == "path").
+		SetSchema(builder_v3.Inline(schema))
+}
+
+// Returns a reference for a schema that refers to another, and the
+// converted schema otherwise.
+func (c *converter) importSchemaOrReference(path string, schema *Schema) *openapi_v3.SchemaOrReference {
+	if schema.Ref != "" {
+		return builder_v3.Ref(schema.Ref)
+	}
+	return builder_v3.Inline(c.importSchema(path, schema))
+}
+
+// Returns the OpenAPI schema for a Discovery schema. References can't be
+// written in the properties of this version of the model, so they are
+// written as allOf schemas with a single reference.
+func (c *converter) importSchema(path string, schema *Schema) *openapi_v3.Schema {
+	if schema.Ref != "" {
+		return builder_v3.AllOf(builder_v3.Ref(schema.Ref))
+	}
+	s := &openapi_v3.Schema{}
+	if schema.Type != "any" {
+		s.SetType(schema.Type)
+	}
+	s.SetFormat(schema.Format).SetDescription(schema.Description).SetPattern(schema.Pattern).SetReadOnly(schema.ReadOnly)
+	if schema.Minimum != "" {
+		if value, err := strconv.ParseFloat(schema.Minimum, 64); err == nil {
+			s.SetMinimum(value)
+		}
+	}
+	if schema.Maximum != "" {
+		if value, err := strconv.ParseFloat(schema.Maximum, 64); err == nil {
+			s.SetMaximum(value)
+		}
+	}
+	if schema.Default != "" {
+		c.warn(path, "default values aren't supported by the OpenAPI model and are dropped")
+	}
+	descriptions := make([]string, 0)
+	for i, value := range schema.Enum {
+		s.AddEnum(builder_v3.Value(value))
+		if i < len(schema.EnumDescriptions) && schema.EnumDescriptions[i] != "" {
+			descriptions = append(descriptions, fmt.Sprintf("- %s: %s", value, schema.EnumDescriptions[i]))
+		}
+	}
+	if len(descriptions) > 0 {
+		// OpenAPI has no field for the descriptions of values, so they are listed in the description
+		s.SetDescription(strings.TrimSpace(schema.Description + "\n\n" + strings.Join(descriptions, "\n")))
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		if s.Properties == nil {
+			s.SetProperties(&openapi_v3.Properties{})
+		}
+		s.Properties.AddAdditionalProperties(name, c.importSchema(path+"."+name, schema.Properties[name]))
+	}
+	if schema.Items != nil {
+		s.SetItems(&openapi_v3.ItemsItem{SchemaOrReference: []*openapi_v3.SchemaOrReference{
+			c.importSchemaOrReference(path+".items", schema.Items)}})
+	}
+	if schema.AdditionalProperties != nil {
+		c.warn(path, "additionalProperties aren't supported by the OpenAPI model, so the map is written as a free-form object")
+	}
+	return s
+}
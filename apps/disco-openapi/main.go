@@ -0,0 +1,132 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// disco-openapi converts between Google API Discovery documents and
+// OpenAPI descriptions.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+	"github.com/googleapis/gnostic/lib"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] FILE
+
+Converts a Google API Discovery document to an OpenAPI 3.0 description,
+or an OpenAPI 2.0 or 3.0 description to a Discovery document. The
+direction is chosen by the contents of FILE. Parts that can't be
+converted are dropped and reported as warnings.
+
+Options:
+  --name=NAME  Name of the API in Discovery documents (default: derived
+               from the title of the description).
+  --json       Write OpenAPI descriptions as JSON instead of YAML.
+  --out=FILE   File to write (default: standard output).
+`, path.Base(os.Args[0]))
+}
+
+// Converts the description in a file, returning the converted document.
+func convert(c *converter, filename string, writeJSON bool, options *Options) ([]byte, error) {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if isDiscovery(bytes) {
+		discovery := &Discovery{}
+		if err = json.Unmarshal(bytes, discovery); err != nil {
+			return nil, err
+		}
+		document := c.importDiscovery(discovery)
+		if writeJSON {
+			return jsonwriter.Marshal(document.ToRawInfo())
+		}
+		return compiler.Marshal(document.ToRawInfo()), nil
+	}
+	// references aren't resolved, since they become the references of the Discovery document
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		return nil, err
+	}
+	version, err := gnostic.DetectVersion(info)
+	if err != nil {
+		return nil, err
+	}
+	var discovery *Discovery
+	switch version {
+	case gnostic.OpenAPIv2:
+		document, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+		if err != nil {
+			return nil, err
+		}
+		discovery = c.exportV2(document, options)
+	case gnostic.OpenAPIv3:
+		document, err := openapi_v3.NewDocument(info, compiler.NewContext("$root", nil))
+		if err != nil {
+			return nil, err
+		}
+		discovery = c.exportV3(document, options)
+	default:
+		return nil, errors.New("unsupported OpenAPI version")
+	}
+	output, err := json.MarshalIndent(discovery, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(output, '\n'), nil
+}
+
+func main() {
+	options := &Options{}
+	flag.StringVar(&options.Name, "name", "", "Name of the API in Discovery documents.")
+	writeJSON := flag.Bool("json", false, "Write OpenAPI descriptions as JSON instead of YAML.")
+	out := flag.String("out", "", "File to write.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+	c := &converter{}
+	output, err := convert(c, args[0], *writeJSON, options)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	for _, warning := range c.warnings {
+		fmt.Fprintf(os.Stderr, "WARNING %s\n", warning)
+	}
+	if *out == "" {
+		os.Stdout.Write(output)
+		return
+	}
+	if err = ioutil.WriteFile(*out, output, 0644); err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+)
+
+// A ServiceConfig is the subset of a google.api.Service that Cloud Endpoints
+// uses to configure the gateways of APIs.
+type ServiceConfig struct {
+	Type             string                  `yaml:"type"`
+	ConfigVersion    int                     `yaml:"config_version"`
+	Name             string                  `yaml:"name"`
+	Title            string                  `yaml:"title,omitempty"`
+	Backend          *BackendConfig          `yaml:"backend,omitempty"`
+	Authentication   *AuthenticationConfig   `yaml:"authentication,omitempty"`
+	Usage            *UsageConfig            `yaml:"usage,omitempty"`
+	SystemParameters *SystemParametersConfig `yaml:"system_parameters,omitempty"`
+	Metrics          []*MetricDescriptor     `yaml:"metrics,omitempty"`
+	Quota            *QuotaConfig            `yaml:"quota,omitempty"`
+}
+
+type BackendConfig struct {
+	Rules []*BackendRule `yaml:"rules"`
+}
+
+type BackendRule struct {
+	Selector        string  `yaml:"selector"`
+	Address         string  `yaml:"address"`
+	PathTranslation string  `yaml:"path_translation,omitempty"`
+	Deadline        float64 `yaml:"deadline,omitempty"`
+	Protocol        string  `yaml:"protocol,omitempty"`
+	JwtAudience     string  `yaml:"jwt_audience,omitempty"`
+	DisableAuth     bool    `yaml:"disable_auth,omitempty"`
+}
+
+type AuthenticationConfig struct {
+	Providers []*AuthProvider       `yaml:"providers,omitempty"`
+	Rules     []*AuthenticationRule `yaml:"rules,omitempty"`
+}
+
+type AuthProvider struct {
+	Id        string `yaml:"id"`
+	Issuer    string `yaml:"issuer"`
+	JwksUri   string `yaml:"jwks_uri,omitempty"`
+	Audiences string `yaml:"audiences,omitempty"`
+}
+
+type AuthenticationRule struct {
+	Selector     string             `yaml:"selector"`
+	Requirements []*AuthRequirement `yaml:"requirements"`
+}
+
+type AuthRequirement struct {
+	ProviderId string `yaml:"provider_id"`
+}
+
+type UsageConfig struct {
+	Rules []*UsageRule `yaml:"rules"`
+}
+
+type UsageRule struct {
+	Selector               string `yaml:"selector"`
+	AllowUnregisteredCalls bool   `yaml:"allow_unregistered_calls"`
+}
+
+type SystemParametersConfig struct {
+	Rules []*SystemParameterRule `yaml:"rules"`
+}
+
+type SystemParameterRule struct {
+	Selector   string             `yaml:"selector"`
+	Parameters []*SystemParameter `yaml:"parameters"`
+}
+
+type SystemParameter struct {
+	Name              string `yaml:"name"`
+	HttpHeader        string `yaml:"http_header,omitempty"`
+	UrlQueryParameter string `yaml:"url_query_parameter,omitempty"`
+}
+
+type MetricDescriptor struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display_name,omitempty"`
+	ValueType   string `yaml:"value_type"`
+	MetricKind  string `yaml:"metric_kind"`
+}
+
+type QuotaConfig struct {
+	Limits      []*QuotaLimit `yaml:"limits,omitempty"`
+	MetricRules []*MetricRule `yaml:"metric_rules,omitempty"`
+}
+
+type MetricRule struct {
+	Selector    string           `yaml:"selector"`
+	MetricCosts map[string]int64 `yaml:"metric_costs"`
+}
+
+// Returns the name of the API that contains the methods of a gateway,
+// which Cloud Endpoints derives from the name of the service.
+func (g *Gateway) apiName() string {
+	return "1." + strings.Replace(g.Name, ".", "_", -1)
+}
+
+// Returns a selector that identifies the method of a route.
+func (g *Gateway) selector(route *Route) string {
+	return g.apiName() + "." + route.Operation
+}
+
+// ServiceConfig returns the Cloud Endpoints service configuration of a gateway.
+// API keys identify the projects of callers: routes that accept them don't
+// allow unregistered calls, and keys passed in nonstandard parameters are
+// declared as system parameters.
+func (g *Gateway) ServiceConfig() *ServiceConfig {
+	config := &ServiceConfig{
+		Type:          "google.api.Service",
+		ConfigVersion: 3,
+		Name:          g.Name,
+		Title:         g.Title,
+	}
+	providers := make(map[string]*Provider, 0)
+	authentication := &AuthenticationConfig{}
+	for _, provider := range g.Providers {
+		providers[provider.Name] = provider
+		if provider.Type == "jwt" && provider.Issuer != "" {
+			authentication.Providers = append(authentication.Providers, &AuthProvider{
+				Id:        provider.Name,
+				Issuer:    provider.Issuer,
+				JwksUri:   provider.JwksURI,
+				Audiences: provider.Audiences,
+			})
+		}
+	}
+
+	backend := &BackendConfig{}
+	usage := &UsageConfig{}
+	parameters := &SystemParametersConfig{}
+	quota := &QuotaConfig{}
+	if g.Quota != nil {
+		quota.Limits = g.Quota.Limits
+	}
+	for _, route := range g.Routes {
+		selector := g.selector(route)
+		if route.Backend != nil {
+			backend.Rules = append(backend.Rules, &BackendRule{
+				Selector:        selector,
+				Address:         route.Backend.Address,
+				PathTranslation: route.Backend.PathTranslation,
+				Deadline:        route.Backend.Deadline,
+				Protocol:        route.Backend.Protocol,
+				JwtAudience:     route.Backend.JwtAudience,
+				DisableAuth:     route.Backend.DisableAuth,
+			})
+		}
+		requirements := make([]*AuthRequirement, 0)
+		usesKeys := false
+		keyParameters := make([]*SystemParameter, 0)
+		for _, requirement := range route.Security {
+			for _, name := range requirement {
+				provider := providers[name]
+				if provider == nil {
+					continue
+				}
+				switch provider.Type {
+				case "jwt":
+					if provider.Issuer != "" {
+						requirements = append(requirements, &AuthRequirement{ProviderId: name})
+					}
+				case "apiKey":
+					usesKeys = true
+					if parameter := keyParameter(provider); parameter != nil {
+						keyParameters = append(keyParameters, parameter)
+					}
+				}
+			}
+		}
+		if len(requirements) > 0 {
+			authentication.Rules = append(authentication.Rules, &AuthenticationRule{
+				Selector:     selector,
+				Requirements: requirements,
+			})
+		}
+		usage.Rules = append(usage.Rules, &UsageRule{
+			Selector:               selector,
+			AllowUnregisteredCalls: !usesKeys,
+		})
+		if len(keyParameters) > 0 {
+			parameters.Rules = append(parameters.Rules, &SystemParameterRule{
+				Selector:   selector,
+				Parameters: keyParameters,
+			})
+		}
+		if len(route.MetricCosts) > 0 {
+			quota.MetricRules = append(quota.MetricRules, &MetricRule{
+				Selector:    selector,
+				MetricCosts: route.MetricCosts,
+			})
+		}
+	}
+	if g.AllowAll {
+		usage.Rules = append(usage.Rules, &UsageRule{Selector: g.apiName() + ".*", AllowUnregisteredCalls: true})
+	}
+
+	if len(backend.Rules) > 0 {
+		config.Backend = backend
+	}
+	if len(authentication.Providers) > 0 {
+		config.Authentication = authentication
+	}
+	if len(usage.Rules) > 0 {
+		config.Usage = usage
+	}
+	if len(parameters.Rules) > 0 {
+		config.SystemParameters = parameters
+	}
+	for _, metric := range g.Metrics {
+		config.Metrics = append(config.Metrics, &MetricDescriptor{
+			Name:        metric.Name,
+			DisplayName: metric.DisplayName,
+			ValueType:   metric.ValueType,
+			MetricKind:  metric.MetricKind,
+		})
+	}
+	if len(quota.Limits) > 0 || len(quota.MetricRules) > 0 {
+		config.Quota = quota
+	}
+	return config
+}
+
+// Returns the system parameter that holds the keys of an API key provider,
+// or nil if keys are passed in the parameters that Cloud Endpoints checks
+// by default ("key" and "api_key" queries and the "x-api-key" header).
+func keyParameter(provider *Provider) *SystemParameter {
+	switch provider.In {
+	case "query":
+		if provider.Parameter == "key" || provider.Parameter == "api_key" {
+			return nil
+		}
+		return &SystemParameter{Name: "api_key", UrlQueryParameter: provider.Parameter}
+	case "header":
+		if strings.EqualFold(provider.Parameter, "x-api-key") {
+			return nil
+		}
+		return &SystemParameter{Name: "api_key", HttpHeader: provider.Parameter}
+	}
+	return nil
+}
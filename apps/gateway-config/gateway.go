@@ -0,0 +1,336 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/lib"
+	"gopkg.in/yaml.v3"
+)
+
+// A Gateway describes the configuration of a gateway that serves an API.
+// It is read from the model of an OpenAPI description and the extensions
+// that Cloud Endpoints uses to configure backends, authentication, and quotas.
+type Gateway struct {
+	Name      string
+	Title     string
+	Version   string
+	Routes    []*Route
+	Providers []*Provider
+	Metrics   []*Metric
+	Quota     *QuotaLimitsList
+	// AllowAll is set when calls to paths that aren't described are forwarded.
+	AllowAll bool
+}
+
+// A Route describes the handling of an operation.
+type Route struct {
+	Operation string
+	Method    string
+	Path      string
+	Backend   *Backend
+	// Security lists alternative requirements. Each requirement names the
+	// providers that must all authenticate a call.
+	Security [][]string
+	// MetricCosts are the amounts that a call adds to quota metrics.
+	MetricCosts map[string]int64
+}
+
+// A Backend is a service that receives the calls of routes (x-google-backend).
+type Backend struct {
+	Address         string  `yaml:"address"`
+	PathTranslation string  `yaml:"path_translation"`
+	Deadline        float64 `yaml:"deadline"`
+	Protocol        string  `yaml:"protocol"`
+	JwtAudience     string  `yaml:"jwt_audience"`
+	DisableAuth     bool    `yaml:"disable_auth"`
+}
+
+// A Provider authenticates calls. Providers are read from security schemes.
+type Provider struct {
+	Name string
+	// Type is "jwt", "apiKey", or "basic".
+	Type      string
+	Issuer    string
+	JwksURI   string
+	Audiences string
+	// In and Parameter locate the keys of "apiKey" providers.
+	In        string
+	Parameter string
+}
+
+// A Metric is a quota metric (x-google-management.metrics).
+type Metric struct {
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"displayName"`
+	ValueType   string `yaml:"valueType"`
+	MetricKind  string `yaml:"metricKind"`
+}
+
+// QuotaLimitsList holds the limits of quota metrics (x-google-management.quota).
+type QuotaLimitsList struct {
+	Limits []*QuotaLimit `yaml:"limits"`
+}
+
+// A QuotaLimit is the maximum amount of a metric that consumers can use.
+type QuotaLimit struct {
+	Name   string           `yaml:"name"`
+	Metric string           `yaml:"metric"`
+	Unit   string           `yaml:"unit"`
+	Values map[string]int64 `yaml:"values"`
+}
+
+// Returns the gateway configuration described by a document.
+func newGateway(document *gnostic.Document) (*Gateway, error) {
+	switch document.Version {
+	case gnostic.OpenAPIv2:
+		return newGatewayV2(document.V2)
+	case gnostic.OpenAPIv3:
+		return newGatewayV3(document.V3)
+	}
+	return nil, errors.New("unsupported OpenAPI version")
+}
+
+var methods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+func newGatewayV2(document *openapi_v2.Document) (*Gateway, error) {
+	g := &Gateway{Name: document.Host}
+	if document.Info != nil {
+		g.Title = document.Info.Title
+		g.Version = document.Info.Version
+	}
+	var management struct {
+		Metrics []*Metric        `yaml:"metrics"`
+		Quota   *QuotaLimitsList `yaml:"quota"`
+	}
+	if err := extensionV2(document.VendorExtension, "x-google-management", &management); err != nil {
+		return nil, err
+	}
+	g.Metrics, g.Quota = management.Metrics, management.Quota
+	var allow string
+	if err := extensionV2(document.VendorExtension, "x-google-allow", &allow); err != nil {
+		return nil, err
+	}
+	g.AllowAll = allow == "all"
+	var defaultBackend *Backend
+	if err := extensionV2(document.VendorExtension, "x-google-backend", &defaultBackend); err != nil {
+		return nil, err
+	}
+	if defaultBackend != nil && defaultBackend.PathTranslation == "" {
+		defaultBackend.PathTranslation = "APPEND_PATH_TO_ADDRESS"
+	}
+
+	if document.SecurityDefinitions != nil {
+		for _, pair := range document.SecurityDefinitions.AdditionalProperties {
+			provider, err := newProviderV2(pair.Name, pair.Value)
+			if err != nil {
+				return nil, err
+			}
+			g.Providers = append(g.Providers, provider)
+		}
+	}
+
+	if document.Paths == nil {
+		return g, nil
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		operations := []*openapi_v2.Operation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch, nil}
+		for i, operation := range operations {
+			if operation == nil {
+				continue
+			}
+			route := &Route{
+				Operation: operation.OperationId,
+				Method:    methods[i],
+				Path:      strings.TrimSuffix(document.BasePath, "/") + pair.Name,
+			}
+			if route.Operation == "" {
+				route.Operation = operationName(route.Method, route.Path)
+			}
+			if err := extensionV2(operation.VendorExtension, "x-google-backend", &route.Backend); err != nil {
+				return nil, err
+			}
+			if route.Backend == nil {
+				route.Backend = defaultBackend
+			} else if route.Backend.PathTranslation == "" {
+				route.Backend.PathTranslation = "CONSTANT_ADDRESS"
+			}
+			security := document.Security
+			if operation.Security != nil {
+				security = operation.Security
+			}
+			for _, requirement := range security {
+				names := make([]string, 0)
+				for _, scheme := range requirement.AdditionalProperties {
+					names = append(names, scheme.Name)
+				}
+				route.Security = append(route.Security, names)
+			}
+			var quota struct {
+				MetricCosts map[string]int64 `yaml:"metricCosts"`
+			}
+			if err := extensionV2(operation.VendorExtension, "x-google-quota", &quota); err != nil {
+				return nil, err
+			}
+			route.MetricCosts = quota.MetricCosts
+			g.Routes = append(g.Routes, route)
+		}
+	}
+	return g, nil
+}
+
+func newProviderV2(name string, item *openapi_v2.SecurityDefinitionsItem) (*Provider, error) {
+	provider := &Provider{Name: name}
+	var extensions []*openapi_v2.NamedAny
+	switch {
+	case item.GetApiKeySecurity() != nil:
+		security := item.GetApiKeySecurity()
+		provider.Type, provider.In, provider.Parameter = "apiKey", security.In, security.Name
+		extensions = security.VendorExtension
+	case item.GetBasicAuthenticationSecurity() != nil:
+		provider.Type = "basic"
+		extensions = item.GetBasicAuthenticationSecurity().VendorExtension
+	case item.GetOauth2ImplicitSecurity() != nil:
+		provider.Type, extensions = "jwt", item.GetOauth2ImplicitSecurity().VendorExtension
+	case item.GetOauth2PasswordSecurity() != nil:
+		provider.Type, extensions = "jwt", item.GetOauth2PasswordSecurity().VendorExtension
+	case item.GetOauth2ApplicationSecurity() != nil:
+		provider.Type, extensions = "jwt", item.GetOauth2ApplicationSecurity().VendorExtension
+	case item.GetOauth2AccessCodeSecurity() != nil:
+		provider.Type, extensions = "jwt", item.GetOauth2AccessCodeSecurity().VendorExtension
+	}
+	for key, value := range map[string]*string{
+		"x-google-issuer":    &provider.Issuer,
+		"x-google-jwks_uri":  &provider.JwksURI,
+		"x-google-audiences": &provider.Audiences,
+	} {
+		if err := extensionV2(extensions, key, value); err != nil {
+			return nil, err
+		}
+	}
+	return provider, nil
+}
+
+// Reads the value of a vendor extension into a value. The value is unchanged
+// if the extension isn't present.
+func extensionV2(extensions []*openapi_v2.NamedAny, name string, value interface{}) error {
+	for _, extension := range extensions {
+		if extension.Name == name && extension.Value != nil {
+			if err := yaml.Unmarshal([]byte(extension.Value.Yaml), value); err != nil {
+				return errors.New(fmt.Sprintf("invalid %s: %s", name, err.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+// Specification extensions in OpenAPI v3 models hold scalar values, so
+// gateways read from v3 descriptions only have the routes and providers
+// that can be described with scalars.
+func newGatewayV3(document *openapi_v3.Document) (*Gateway, error) {
+	g := &Gateway{}
+	if document.Info != nil {
+		g.Title = document.Info.Title
+		g.Version = document.Info.Version
+	}
+	basePath := ""
+	if len(document.Servers) > 0 {
+		if u, err := url.Parse(document.Servers[0].Url); err == nil {
+			g.Name = u.Hostname()
+			basePath = strings.TrimSuffix(u.Path, "/")
+		}
+	}
+	g.AllowAll = extensionV3(document.SpecificationExtension, "x-google-allow") == "all"
+
+	if document.Components != nil && document.Components.SecuritySchemes != nil {
+		for _, pair := range document.Components.SecuritySchemes.AdditionalProperties {
+			scheme := pair.Value
+			provider := &Provider{Name: pair.Name}
+			switch {
+			case scheme.Type == "apiKey":
+				provider.Type, provider.In, provider.Parameter = "apiKey", scheme.In, scheme.Name
+			case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+				provider.Type = "basic"
+			default:
+				provider.Type = "jwt"
+			}
+			provider.Issuer = extensionV3(scheme.SpecificationExtension, "x-google-issuer")
+			provider.JwksURI = extensionV3(scheme.SpecificationExtension, "x-google-jwks_uri")
+			provider.Audiences = extensionV3(scheme.SpecificationExtension, "x-google-audiences")
+			g.Providers = append(g.Providers, provider)
+		}
+	}
+
+	if document.Paths == nil {
+		return g, nil
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		operations := []*openapi_v3.Operation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch, item.Trace}
+		for i, operation := range operations {
+			if operation == nil {
+				continue
+			}
+			route := &Route{
+				Operation: operation.OperationId,
+				Method:    methods[i],
+				Path:      basePath + pair.Name,
+			}
+			if route.Operation == "" {
+				route.Operation = operationName(route.Method, route.Path)
+			}
+			security := document.Security
+			if operation.Security != nil {
+				security = operation.Security
+			}
+			for _, requirement := range security {
+				names := make([]string, 0)
+				for _, scheme := range requirement.Name {
+					names = append(names, scheme.Name)
+				}
+				route.Security = append(route.Security, names)
+			}
+			g.Routes = append(g.Routes, route)
+		}
+	}
+	return g, nil
+}
+
+// Returns the string value of a specification extension, or "" if it isn't present.
+func extensionV3(extensions []*openapi_v3.NamedSpecificationExtension, name string) string {
+	for _, extension := range extensions {
+		if extension.Name == name && extension.Value != nil {
+			if value, ok := extension.Value.Oneof.(*openapi_v3.SpecificationExtension_String_); ok {
+				return value.String_
+			}
+		}
+	}
+	return ""
+}
+
+var nonIdentifier = regexp.MustCompile("[^A-Za-z0-9]+")
+
+// Returns a name for an operation that has no operation id.
+func operationName(method, path string) string {
+	return strings.ToLower(method) + strings.TrimRight(nonIdentifier.ReplaceAllString(path, "_"), "_")
+}
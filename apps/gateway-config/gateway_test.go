@@ -0,0 +1,143 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/googleapis/gnostic/lib"
+)
+
+const description = `
+swagger: "2.0"
+info:
+  title: Bookstore
+  version: 1.0.0
+host: bookstore.endpoints.example.cloud.goog
+basePath: /v1
+x-google-backend:
+  address: https://backend.example.com
+x-google-management:
+  metrics:
+    - name: read-requests
+      displayName: Read requests
+      valueType: INT64
+      metricKind: DELTA
+  quota:
+    limits:
+      - name: read-limit
+        metric: read-requests
+        unit: 1/min/{project}
+        values:
+          STANDARD: 100
+securityDefinitions:
+  api_key:
+    type: apiKey
+    name: X-Key
+    in: header
+  google_id_token:
+    type: oauth2
+    flow: implicit
+    authorizationUrl: ""
+    x-google-issuer: https://accounts.google.com
+    x-google-jwks_uri: https://www.googleapis.com/oauth2/v3/certs
+paths:
+  /shelves:
+    get:
+      operationId: listShelves
+      security:
+        - api_key: []
+      x-google-quota:
+        metricCosts:
+          read-requests: 1
+      responses:
+        200:
+          description: OK
+  /shelves/{shelf}:
+    delete:
+      parameters:
+        - name: shelf
+          in: path
+          required: true
+          type: string
+      security:
+        - google_id_token: []
+      x-google-backend:
+        address: https://admin.example.com/delete
+        deadline: 5.0
+      responses:
+        200:
+          description: OK
+`
+
+const expectedServiceConfig = `type: google.api.Service
+config_version: 3
+name: bookstore.endpoints.example.cloud.goog
+title: Bookstore
+backend:
+  rules:
+    - selector: 1.bookstore_endpoints_example_cloud_goog.listShelves
+      address: https://backend.example.com
+      path_translation: APPEND_PATH_TO_ADDRESS
+    - selector: 1.bookstore_endpoints_example_cloud_goog.delete_v1_shelves_shelf
+      address: https://admin.example.com/delete
+      path_translation: CONSTANT_ADDRESS
+      deadline: 5
+authentication:
+  providers:
+    - id: google_id_token
+      issuer: https://accounts.google.com
+      jwks_uri: https://www.googleapis.com/oauth2/v3/certs
+  rules:
+    - selector: 1.bookstore_endpoints_example_cloud_goog.delete_v1_shelves_shelf
+      requirements:
+        - provider_id: google_id_token
+usage:
+  rules:
+    - selector: 1.bookstore_endpoints_example_cloud_goog.listShelves
+      allow_unregistered_calls: false
+    - selector: 1.bookstore_endpoints_example_cloud_goog.delete_v1_shelves_shelf
+      allow_unregistered_calls: true
+system_parameters:
+  rules:
+    - selector: 1.bookstore_endpoints_example_cloud_goog.listShelves
+      parameters:
+        - name: api_key
+          http_header: X-Key
+metrics:
+  - name: read-requests
+    display_name: Read requests
+    value_type: INT64
+    metric_kind: DELTA
+quota:
+  limits:
+    - name: read-limit
+      metric: read-requests
+      unit: 1/min/{project}
+      values:
+        STANDARD: 100
+  metric_rules:
+    - selector: 1.bookstore_endpoints_example_cloud_goog.listShelves
+      metric_costs:
+        read-requests: 1
+`
+
+func TestServiceConfig(t *testing.T) {
+	document, err := gnostic.ReadDocumentFromBytes([]byte(description))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	gateway, err := newGateway(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	bytes, err := marshal(gateway.ServiceConfig())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if string(bytes) != expectedServiceConfig {
+		t.Errorf("unexpected service configuration:\n%s", string(bytes))
+	}
+	routes := gateway.RouteConfig().Routes
+	if len(routes) != 2 || routes[1].Path != "/v1/shelves/{shelf}" || routes[1].Method != "DELETE" {
+		t.Errorf("unexpected routes: %+v", routes)
+	}
+}
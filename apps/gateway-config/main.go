@@ -0,0 +1,116 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gateway-config writes the configuration of API gateways from OpenAPI
+// descriptions, using the extensions that Cloud Endpoints defines for
+// backends (x-google-backend), authentication (x-google-issuer,
+// x-google-jwks_uri, x-google-audiences), and quotas (x-google-management,
+// x-google-quota).
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/googleapis/gnostic/lib"
+	"gopkg.in/yaml.v3"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] OPENAPI_FILE
+
+Writes the gateway configuration of an OpenAPI 2.0 or 3.0 description.
+
+Options:
+  --format=FORMAT  "endpoints" for a Cloud Endpoints service configuration
+                   or "routes" for a generic route configuration
+                   (default: endpoints).
+  --name=NAME      Name of the service (default: the host of the API).
+  --out=FILE       File to write (default: standard output).
+`, path.Base(os.Args[0]))
+}
+
+// Returns a value as YAML, indented by two spaces.
+func marshal(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func main() {
+	format := flag.String("format", "endpoints", "Format of the configuration.")
+	name := flag.String("name", "", "Name of the service.")
+	out := flag.String("out", "", "File to write.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+	document, err := gnostic.ReadDocument(args[0])
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	gateway, err := newGateway(document)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	if *name != "" {
+		gateway.Name = *name
+	}
+	if gateway.Name == "" {
+		fmt.Printf("%s has no host; use --name to name the service.\n", args[0])
+		os.Exit(-1)
+	}
+
+	var config interface{}
+	switch *format {
+	case "endpoints":
+		config = gateway.ServiceConfig()
+	case "routes":
+		config = gateway.RouteConfig()
+	default:
+		fmt.Printf("Unknown format: %s\n", *format)
+		os.Exit(-1)
+	}
+	output, err := marshal(config)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	if *out == "" {
+		os.Stdout.Write(output)
+		return
+	}
+	if err = ioutil.WriteFile(*out, output, 0644); err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+}
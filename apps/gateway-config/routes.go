@@ -0,0 +1,103 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+// A RouteConfig is a generic gateway configuration that lists the routes
+// of an API with their backends, authentication, and quota costs.
+// It can be translated into the configuration formats of other gateways.
+type RouteConfig struct {
+	Name      string           `yaml:"name"`
+	Title     string           `yaml:"title,omitempty"`
+	Version   string           `yaml:"version,omitempty"`
+	Routes    []*RouteEntry    `yaml:"routes"`
+	Providers []*ProviderEntry `yaml:"providers,omitempty"`
+	Metrics   []*Metric        `yaml:"metrics,omitempty"`
+	Limits    []*QuotaLimit    `yaml:"limits,omitempty"`
+	AllowAll  bool             `yaml:"allow_unmatched,omitempty"`
+}
+
+type RouteEntry struct {
+	Operation string `yaml:"operation"`
+	Method    string `yaml:"method"`
+	Path      string `yaml:"path"`
+	// Backend is nil if the route has no backend.
+	Backend *BackendEntry `yaml:"backend,omitempty"`
+	// Each entry lists providers that must all authenticate a call; a call
+	// is accepted if any entry is satisfied.
+	Authentication [][]string       `yaml:"authentication,omitempty"`
+	Quota          map[string]int64 `yaml:"quota,omitempty"`
+}
+
+type BackendEntry struct {
+	Address         string  `yaml:"address"`
+	PathTranslation string  `yaml:"path_translation,omitempty"`
+	Deadline        float64 `yaml:"deadline,omitempty"`
+	Protocol        string  `yaml:"protocol,omitempty"`
+}
+
+type ProviderEntry struct {
+	Name      string `yaml:"name"`
+	Type      string `yaml:"type"`
+	Issuer    string `yaml:"issuer,omitempty"`
+	JwksURI   string `yaml:"jwks_uri,omitempty"`
+	Audiences string `yaml:"audiences,omitempty"`
+	In        string `yaml:"in,omitempty"`
+	Parameter string `yaml:"parameter,omitempty"`
+}
+
+// RouteConfig returns the generic route configuration of a gateway.
+func (g *Gateway) RouteConfig() *RouteConfig {
+	config := &RouteConfig{
+		Name:     g.Name,
+		Title:    g.Title,
+		Version:  g.Version,
+		Routes:   make([]*RouteEntry, 0),
+		Metrics:  g.Metrics,
+		AllowAll: g.AllowAll,
+	}
+	if g.Quota != nil {
+		config.Limits = g.Quota.Limits
+	}
+	for _, route := range g.Routes {
+		entry := &RouteEntry{
+			Operation:      route.Operation,
+			Method:         route.Method,
+			Path:           route.Path,
+			Authentication: route.Security,
+			Quota:          route.MetricCosts,
+		}
+		if route.Backend != nil {
+			entry.Backend = &BackendEntry{
+				Address:         route.Backend.Address,
+				PathTranslation: route.Backend.PathTranslation,
+				Deadline:        route.Backend.Deadline,
+				Protocol:        route.Backend.Protocol,
+			}
+		}
+		config.Routes = append(config.Routes, entry)
+	}
+	for _, provider := range g.Providers {
+		config.Providers = append(config.Providers, &ProviderEntry{
+			Name:      provider.Name,
+			Type:      provider.Type,
+			Issuer:    provider.Issuer,
+			JwksURI:   provider.JwksURI,
+			Audiences: provider.Audiences,
+			In:        provider.In,
+			Parameter: provider.Parameter,
+		})
+	}
+	return config
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// grpc-openapi describes the HTTP interface of a running gRPC server with an
+// OpenAPI v3 document. It reads the server's descriptors from its reflection
+// service and describes the methods that have google.api.http annotations,
+// which are the ones that are transcoded from HTTP requests.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/googleapis/gnostic/apps/protoc-gen-openapi/generator"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] ADDRESS [SERVICE...]
+
+Reads the descriptors of a gRPC server at ADDRESS (such as localhost:8080)
+with its reflection service and writes an OpenAPI v3 document that describes
+the HTTP interface of the named services, or of all of its services.
+
+Options:
+  --plaintext   Connect without TLS.
+  --insecure    Don't verify the server's certificate.
+  --title=TITLE The title of the document.
+  --version=VERSION The version of the API.
+  --json        Write JSON instead of YAML.
+  --out=FILE    Write the document to FILE instead of the standard output.
+`, path.Base(os.Args[0]))
+}
+
+func main() {
+	plaintext := flag.Bool("plaintext", false, "Connect without TLS.")
+	insecure := flag.Bool("insecure", false, "Don't verify the server's certificate.")
+	title := flag.String("title", "", "The title of the document.")
+	version := flag.String("version", "", "The version of the API.")
+	writeJSON := flag.Bool("json", false, "Write JSON instead of YAML.")
+	out := flag.String("out", "", "Write the document to a file.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+
+	client := newReflectionClient(args[0], *plaintext, *insecure)
+	services := args[1:]
+	options := &generator.Options{Title: *title, Version: *version, Services: services}
+	if len(services) == 0 {
+		names, err := client.listServices()
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+		for _, name := range names {
+			if !isGRPCService(name) {
+				services = append(services, name)
+			}
+		}
+	}
+	files, serviceFiles, err := client.descriptorsForServices(services)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	document, err := generator.NewDocument(files, serviceFiles, options)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+
+	var bytes []byte
+	if *writeJSON {
+		bytes, err = jsonwriter.Marshal(document.ToRawInfo())
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+	} else {
+		bytes = compiler.Marshal(document.ToRawInfo())
+	}
+	if *out == "" {
+		os.Stdout.Write(bytes)
+	} else if err = ioutil.WriteFile(*out, bytes, 0644); err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+}
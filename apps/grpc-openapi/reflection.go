@@ -0,0 +1,280 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The messages of the grpc.reflection.v1alpha.ServerReflection service,
+// declared with the field numbers of reflection.proto. Fields of oneofs
+// are declared as separate fields, which is equivalent on the wire.
+
+type serverReflectionRequest struct {
+	Host                 string `protobuf:"bytes,1,opt,name=host,proto3"`
+	FileByFilename       string `protobuf:"bytes,3,opt,name=file_by_filename,json=fileByFilename,proto3"`
+	FileContainingSymbol string `protobuf:"bytes,4,opt,name=file_containing_symbol,json=fileContainingSymbol,proto3"`
+	ListServices         string `protobuf:"bytes,7,opt,name=list_services,json=listServices,proto3"`
+}
+
+func (m *serverReflectionRequest) Reset()         { *m = serverReflectionRequest{} }
+func (m *serverReflectionRequest) String() string { return proto.CompactTextString(m) }
+func (*serverReflectionRequest) ProtoMessage()    {}
+
+type serverReflectionResponse struct {
+	ValidHost              string                  `protobuf:"bytes,1,opt,name=valid_host,json=validHost,proto3"`
+	FileDescriptorResponse *fileDescriptorResponse `protobuf:"bytes,4,opt,name=file_descriptor_response,json=fileDescriptorResponse,proto3"`
+	ListServicesResponse   *listServiceResponse    `protobuf:"bytes,6,opt,name=list_services_response,json=listServicesResponse,proto3"`
+	ErrorResponse          *errorResponse          `protobuf:"bytes,7,opt,name=error_response,json=errorResponse,proto3"`
+}
+
+func (m *serverReflectionResponse) Reset()         { *m = serverReflectionResponse{} }
+func (m *serverReflectionResponse) String() string { return proto.CompactTextString(m) }
+func (*serverReflectionResponse) ProtoMessage()    {}
+
+type fileDescriptorResponse struct {
+	FileDescriptorProto [][]byte `protobuf:"bytes,1,rep,name=file_descriptor_proto,json=fileDescriptorProto,proto3"`
+}
+
+func (m *fileDescriptorResponse) Reset()         { *m = fileDescriptorResponse{} }
+func (m *fileDescriptorResponse) String() string { return proto.CompactTextString(m) }
+func (*fileDescriptorResponse) ProtoMessage()    {}
+
+type listServiceResponse struct {
+	Service []*serviceResponse `protobuf:"bytes,1,rep,name=service,proto3"`
+}
+
+func (m *listServiceResponse) Reset()         { *m = listServiceResponse{} }
+func (m *listServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*listServiceResponse) ProtoMessage()    {}
+
+type serviceResponse struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3"`
+}
+
+func (m *serviceResponse) Reset()         { *m = serviceResponse{} }
+func (m *serviceResponse) String() string { return proto.CompactTextString(m) }
+func (*serviceResponse) ProtoMessage()    {}
+
+type errorResponse struct {
+	ErrorCode    int32  `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3"`
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3"`
+}
+
+func (m *errorResponse) Reset()         { *m = errorResponse{} }
+func (m *errorResponse) String() string { return proto.CompactTextString(m) }
+func (*errorResponse) ProtoMessage()    {}
+
+const reflectionMethod = "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo"
+
+// reflectionClient reads descriptors from the reflection service of a
+// gRPC server. Each request is sent on a stream of its own, so the
+// client only needs to exchange single messages with the server.
+type reflectionClient struct {
+	address string // the base URL of the server
+	client  *http.Client
+}
+
+// Creates a client for a server at an address such as "localhost:8080".
+// Connections use TLS unless plaintext is set.
+func newReflectionClient(address string, plaintext bool, insecure bool) *reflectionClient {
+	protocols := &http.Protocols{}
+	transport := &http.Transport{Protocols: protocols}
+	scheme := "https"
+	if plaintext {
+		protocols.SetUnencryptedHTTP2(true)
+		scheme = "http"
+	} else {
+		protocols.SetHTTP2(true)
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecure}
+	}
+	return &reflectionClient{
+		address: scheme + "://" + address,
+		client:  &http.Client{Transport: transport},
+	}
+}
+
+// Sends a request to the reflection service and returns its response.
+func (c *reflectionClient) send(request *serverReflectionRequest) (*serverReflectionResponse, error) {
+	message, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	// messages are prefixed by a compression flag and their length
+	body := make([]byte, 5+len(message))
+	binary.BigEndian.PutUint32(body[1:5], uint32(len(message)))
+	copy(body[5:], message)
+	httpRequest, err := http.NewRequest("POST", c.address+reflectionMethod, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/grpc+proto")
+	httpRequest.Header.Set("TE", "trailers")
+	httpResponse, err := c.client.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("reflection request failed with HTTP status %s", httpResponse.Status))
+	}
+	data, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+	if status := grpcStatus(httpResponse); status != "" && status != "0" {
+		return nil, errors.New(fmt.Sprintf("reflection request failed with gRPC status %s: %s",
+			status, httpResponse.Trailer.Get("Grpc-Message")))
+	}
+	if len(data) < 5 || data[0] != 0 {
+		return nil, errors.New("invalid reflection response")
+	}
+	length := binary.BigEndian.Uint32(data[1:5])
+	if uint32(len(data)-5) < length {
+		return nil, io.ErrUnexpectedEOF
+	}
+	response := &serverReflectionResponse{}
+	if err = proto.Unmarshal(data[5:5+length], response); err != nil {
+		return nil, err
+	}
+	if e := response.ErrorResponse; e != nil {
+		return nil, errors.New(fmt.Sprintf("reflection error %d: %s", e.ErrorCode, e.ErrorMessage))
+	}
+	return response, nil
+}
+
+// Returns the gRPC status of a response, which is in its trailers
+// or, for responses without messages, in its headers.
+func grpcStatus(response *http.Response) string {
+	if status := response.Trailer.Get("Grpc-Status"); status != "" {
+		return status
+	}
+	return response.Header.Get("Grpc-Status")
+}
+
+// Returns the names of the services of the server.
+func (c *reflectionClient) listServices() ([]string, error) {
+	response, err := c.send(&serverReflectionRequest{ListServices: "*"})
+	if err != nil {
+		return nil, err
+	}
+	if response.ListServicesResponse == nil {
+		return nil, errors.New("invalid response to a request for services")
+	}
+	names := make([]string, 0)
+	for _, service := range response.ListServicesResponse.Service {
+		names = append(names, service.Name)
+	}
+	return names, nil
+}
+
+// Returns the descriptors in a reflection response.
+func fileDescriptors(response *serverReflectionResponse) ([]*descriptor.FileDescriptorProto, error) {
+	if response.FileDescriptorResponse == nil {
+		return nil, errors.New("invalid response to a request for descriptors")
+	}
+	files := make([]*descriptor.FileDescriptorProto, 0)
+	for _, data := range response.FileDescriptorResponse.FileDescriptorProto {
+		file := &descriptor.FileDescriptorProto{}
+		if err := proto.Unmarshal(data, file); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// Returns the descriptors of the files that define services and
+// of all of the files that they import, and the names of the files
+// that define the services.
+func (c *reflectionClient) descriptorsForServices(services []string) ([]*descriptor.FileDescriptorProto, []string, error) {
+	files := make(map[string]*descriptor.FileDescriptorProto, 0)
+	order := make([]string, 0)
+	add := func(received []*descriptor.FileDescriptorProto) {
+		for _, file := range received {
+			if files[file.GetName()] == nil {
+				files[file.GetName()] = file
+				order = append(order, file.GetName())
+			}
+		}
+	}
+	serviceFiles := make([]string, 0)
+	for _, service := range services {
+		response, err := c.send(&serverReflectionRequest{FileContainingSymbol: service})
+		if err != nil {
+			return nil, nil, err
+		}
+		received, err := fileDescriptors(response)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(received) == 0 {
+			return nil, nil, errors.New(fmt.Sprintf("no descriptors were returned for %s", service))
+		}
+		// the first file defines the symbol
+		if name := received[0].GetName(); !contains(serviceFiles, name) {
+			serviceFiles = append(serviceFiles, name)
+		}
+		add(received)
+	}
+	// servers usually send the files that a file imports with it,
+	// but the ones that they don't send are requested by name
+	for i := 0; i < len(order); i++ {
+		for _, dependency := range files[order[i]].Dependency {
+			if files[dependency] != nil {
+				continue
+			}
+			response, err := c.send(&serverReflectionRequest{FileByFilename: dependency})
+			if err != nil {
+				return nil, nil, err
+			}
+			received, err := fileDescriptors(response)
+			if err != nil {
+				return nil, nil, err
+			}
+			add(received)
+		}
+	}
+	result := make([]*descriptor.FileDescriptorProto, 0)
+	for _, name := range order {
+		result = append(result, files[name])
+	}
+	return result, serviceFiles, nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if a service is part of gRPC rather than the server's API.
+func isGRPCService(name string) bool {
+	return strings.HasPrefix(name, "grpc.")
+}
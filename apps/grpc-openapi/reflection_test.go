@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/googleapis/gnostic/apps/protoc-gen-openapi/generator"
+)
+
+// Returns descriptors for a service in one file that imports another.
+func testDescriptors() map[string]*descriptor.FileDescriptorProto {
+	options := &descriptor.MethodOptions{}
+	proto.SetExtension(options, generator.E_Http, &generator.HttpRule{Get: "/v1/{name=shelves/*}"})
+	return map[string]*descriptor.FileDescriptorProto{
+		"messages.proto": {
+			Name:    proto.String("messages.proto"),
+			Package: proto.String("library"),
+			MessageType: []*descriptor.DescriptorProto{{
+				Name: proto.String("Shelf"),
+				Field: []*descriptor.FieldDescriptorProto{{
+					Name:   proto.String("name"),
+					Number: proto.Int32(1),
+					Type:   descriptor.FieldDescriptorProto_TYPE_STRING.Enum(),
+				}},
+			}},
+		},
+		"library.proto": {
+			Name:       proto.String("library.proto"),
+			Package:    proto.String("library"),
+			Dependency: []string{"messages.proto"},
+			Service: []*descriptor.ServiceDescriptorProto{{
+				Name: proto.String("Library"),
+				Method: []*descriptor.MethodDescriptorProto{{
+					Name:       proto.String("GetShelf"),
+					InputType:  proto.String(".library.Shelf"),
+					OutputType: proto.String(".library.Shelf"),
+					Options:    options,
+				}},
+			}},
+		},
+	}
+}
+
+// A reflection service that sends files without the files that they import.
+func reflectionHandler(t *testing.T) http.HandlerFunc {
+	files := testDescriptors()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != reflectionMethod || r.ProtoMajor != 2 {
+			t.Errorf("Unexpected request: %s %s", r.Proto, r.URL.Path)
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		request := &serverReflectionRequest{}
+		if err := proto.Unmarshal(data[5:], request); err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		response := &serverReflectionResponse{}
+		var file *descriptor.FileDescriptorProto
+		switch {
+		case request.ListServices != "":
+			response.ListServicesResponse = &listServiceResponse{Service: []*serviceResponse{
+				{Name: "grpc.reflection.v1alpha.ServerReflection"},
+				{Name: "library.Library"},
+			}}
+		case request.FileContainingSymbol == "library.Library":
+			file = files["library.proto"]
+		case request.FileByFilename != "":
+			file = files[request.FileByFilename]
+		}
+		if file != nil {
+			bytes, _ := proto.Marshal(file)
+			response.FileDescriptorResponse = &fileDescriptorResponse{FileDescriptorProto: [][]byte{bytes}}
+		} else if response.ListServicesResponse == nil {
+			response.ErrorResponse = &errorResponse{ErrorCode: 5, ErrorMessage: "not found"}
+		}
+		message, _ := proto.Marshal(response)
+		frame := make([]byte, 5+len(message))
+		binary.BigEndian.PutUint32(frame[1:5], uint32(len(message)))
+		copy(frame[5:], message)
+		w.Header().Set("Content-Type", "application/grpc+proto")
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.Write(frame)
+		w.Header().Set("Grpc-Status", "0")
+	}
+}
+
+func TestReflectionClient(t *testing.T) {
+	server := httptest.NewUnstartedServer(reflectionHandler(t))
+	server.Config.Protocols = &http.Protocols{}
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	defer server.Close()
+
+	client := newReflectionClient(strings.TrimPrefix(server.URL, "http://"), true, false)
+	services, err := client.listServices()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if len(services) != 2 || !isGRPCService(services[0]) || isGRPCService(services[1]) {
+		t.Errorf("Unexpected services: %+v", services)
+	}
+	files, serviceFiles, err := client.descriptorsForServices([]string{"library.Library"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if len(files) != 2 || len(serviceFiles) != 1 || serviceFiles[0] != "library.proto" {
+		t.Fatalf("Unexpected files: %+v %+v", files, serviceFiles)
+	}
+	document, err := generator.NewDocument(files, serviceFiles, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if document.Paths.Get("/v1/{name}").GetGet() == nil || document.Components.Schemas.Get("Shelf") == nil {
+		t.Errorf("Unexpected document: %+v", document)
+	}
+	if _, _, err = client.descriptorsForServices([]string{"library.Unknown"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected an error for an unknown service, got %v", err)
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/builder/v3"
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Options control how traffic is drafted into a description.
+type Options struct {
+	Title   string
+	Version string
+	// Host selects the entries that are described. If it is empty,
+	// the most frequently called host is described.
+	Host string
+	// All includes entries without JSON bodies, which are usually
+	// pages and assets rather than API calls.
+	All bool
+	// MaxEnumValues is passed to jsonschema.InferSchema.
+	MaxEnumValues int
+}
+
+// An operation collects the calls of a method on a path template.
+type operation struct {
+	method     string
+	template   string
+	parameters []string
+	pathValues map[string][]string
+	queries    []*url.URL
+	bodies     []*yaml.Node
+	bodyType   string
+	forms      [][]*NameValue
+	responses  map[int][]*yaml.Node
+	statuses   []int
+}
+
+var (
+	uuidPattern  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexPattern   = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+	tokenPattern = regexp.MustCompile(`^[0-9A-Za-z_-]{20,}$`)
+	digitPattern = regexp.MustCompile(`[0-9]`)
+)
+
+// Returns true if a path segment looks like an identifier rather than a
+// fixed part of a path: a number, a UUID, or a long token with digits.
+func isIdentifier(segment string) bool {
+	if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+		return true
+	}
+	return uuidPattern.MatchString(segment) ||
+		hexPattern.MatchString(segment) && digitPattern.MatchString(segment) ||
+		tokenPattern.MatchString(segment) && digitPattern.MatchString(segment)
+}
+
+// Returns the template of a path, the names of its parameters, and their values.
+// Parameters are named for the segments that precede them, so that the
+// identifier in "/users/42" is "userId".
+func pathTemplate(path string) (string, []string, []string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	names := make([]string, 0)
+	values := make([]string, 0)
+	for i, segment := range segments {
+		if !isIdentifier(segment) {
+			continue
+		}
+		name := "id"
+		if i > 0 && !strings.HasPrefix(segments[i-1], "{") {
+			name = strings.TrimSuffix(nonIdentifier.ReplaceAllString(segments[i-1], ""), "s") + "Id"
+		}
+		for n := 2; contains(names, name); n++ {
+			name = fmt.Sprintf("%s%d", strings.TrimRight(name, "0123456789"), n)
+		}
+		names = append(names, name)
+		values = append(values, segment)
+		segments[i] = "{" + name + "}"
+	}
+	return "/" + strings.Join(segments, "/"), names, values
+}
+
+var nonIdentifier = regexp.MustCompile("[^A-Za-z0-9]+")
+
+func isJSON(mimeType string) bool {
+	mediaType := strings.TrimSpace(strings.Split(mimeType, ";")[0])
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// Returns the parsed value of a JSON body, or nil if it isn't JSON.
+func jsonValue(text string) *yaml.Node {
+	var node yaml.Node
+	if strings.TrimSpace(text) == "" || yaml.Unmarshal([]byte(text), &node) != nil || len(node.Content) != 1 {
+		return nil
+	}
+	return node.Content[0]
+}
+
+// Returns a node for a string value, with a tag for the type that it looks like.
+func scalarValue(value string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	node.Tag = node.ShortTag()
+	if node.Tag == "!!null" {
+		node.Tag = "!!str"
+	}
+	return node
+}
+
+// Returns the most frequently called host of a list of entries.
+func mostFrequentHost(entries []*Entry) string {
+	counts := make(map[string]int, 0)
+	best := ""
+	for _, entry := range entries {
+		if u, err := url.Parse(entry.Request.URL); err == nil {
+			counts[u.Host]++
+			if counts[u.Host] > counts[best] || counts[u.Host] == counts[best] && u.Host < best {
+				best = u.Host
+			}
+		}
+	}
+	return best
+}
+
+// Draft returns an OpenAPI v3 description of the API calls in a list of
+// HAR entries. Paths are grouped into templates, and the schemas of
+// parameters and bodies are inferred from their values. Drafts describe
+// only the calls that were captured, so they are starting points for
+// hand-written descriptions.
+func Draft(entries []*Entry, options *Options) *openapi_v3.Document {
+	host := options.Host
+	if host == "" {
+		host = mostFrequentHost(entries)
+	}
+	scheme := "https"
+	operations := make([]*operation, 0)
+	byKey := make(map[string]*operation, 0)
+	for _, entry := range entries {
+		if entry.Request == nil || entry.Response == nil {
+			continue
+		}
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil || u.Host != host {
+			continue
+		}
+		scheme = u.Scheme
+		request, response := entry.Request, entry.Response
+		requestJSON := request.PostData != nil && isJSON(request.PostData.MimeType)
+		responseJSON := response.Content != nil && isJSON(response.Content.MimeType)
+		if !options.All && !requestJSON && !responseJSON {
+			continue
+		}
+		template, names, values := pathTemplate(u.Path)
+		method := strings.ToUpper(request.Method)
+		key := method + " " + template
+		op := byKey[key]
+		if op == nil {
+			op = &operation{
+				method:     method,
+				template:   template,
+				parameters: names,
+				pathValues: make(map[string][]string, 0),
+				responses:  make(map[int][]*yaml.Node, 0),
+			}
+			byKey[key] = op
+			operations = append(operations, op)
+		}
+		for i, name := range names {
+			op.pathValues[name] = append(op.pathValues[name], values[i])
+		}
+		op.queries = append(op.queries, u)
+		if request.PostData != nil {
+			if requestJSON {
+				if body := jsonValue(request.PostData.Text); body != nil {
+					op.bodies = append(op.bodies, body)
+					op.bodyType = "application/json"
+				}
+			} else if strings.HasPrefix(request.PostData.MimeType, "application/x-www-form-urlencoded") {
+				params := request.PostData.Params
+				if len(params) == 0 {
+					if form, err := url.ParseQuery(request.PostData.Text); err == nil {
+						for name, values := range form {
+							params = append(params, &NameValue{Name: name, Value: values[0]})
+						}
+					}
+				}
+				op.forms = append(op.forms, params)
+			}
+		}
+		if _, ok := op.responses[response.Status]; !ok {
+			op.statuses = append(op.statuses, response.Status)
+			op.responses[response.Status] = make([]*yaml.Node, 0)
+		}
+		if responseJSON {
+			if body := jsonValue(response.Content.text()); body != nil {
+				op.responses[response.Status] = append(op.responses[response.Status], body)
+			}
+		}
+	}
+
+	title := options.Title
+	if title == "" {
+		title = host
+	}
+	b := builder_v3.NewDocument(title, options.Version).Server(scheme+"://"+host, "")
+	sort.SliceStable(operations, func(i, j int) bool { return operations[i].template < operations[j].template })
+	for _, op := range operations {
+		b = op.add(b, options)
+	}
+	return b.Build()
+}
+
+// Adds an operation to a document.
+func (op *operation) add(b *builder_v3.DocumentBuilder, options *Options) *builder_v3.DocumentBuilder {
+	o := builder_v3.NewOperation(op.operationID())
+	infer := func(values []*yaml.Node) *openapi_v3.Schema {
+		return schemaForInferred(jsonschema.InferSchema(values, options.MaxEnumValues))
+	}
+	for _, name := range op.parameters {
+		values := make([]*yaml.Node, 0)
+		for _, value := range op.pathValues[name] {
+			values = append(values, scalarValue(value))
+		}
+		schema := infer(values)
+		if schema.Type == "string" && allMatch(op.pathValues[name], uuidPattern) {
+			schema.Format = "uuid"
+		}
+		o.PathParameter(name, "", builder_v3.Inline(schema))
+	}
+	// queries are described in the order that their names first appear
+	names := make([]string, 0)
+	values := make(map[string][]*yaml.Node, 0)
+	counts := make(map[string]int, 0)
+	for _, u := range op.queries {
+		query := u.Query()
+		seen := make([]string, 0)
+		for _, pair := range strings.Split(u.RawQuery, "&") {
+			name, err := url.QueryUnescape(strings.SplitN(pair, "=", 2)[0])
+			if err != nil || name == "" || contains(seen, name) {
+				continue
+			}
+			seen = append(seen, name)
+			if _, ok := values[name]; !ok {
+				names = append(names, name)
+			}
+			counts[name]++
+			for _, value := range query[name] {
+				values[name] = append(values[name], scalarValue(value))
+			}
+		}
+	}
+	for _, name := range names {
+		o.QueryParameter(name, "", counts[name] == len(op.queries), builder_v3.Inline(infer(values[name])))
+	}
+	if len(op.bodies) > 0 {
+		o.RequestBody(op.bodyType, builder_v3.Inline(infer(op.bodies)), true)
+	} else if len(op.forms) > 0 {
+		forms := make([]*yaml.Node, 0)
+		for _, params := range op.forms {
+			form := &yaml.Node{Kind: yaml.MappingNode}
+			for _, param := range params {
+				form.Content = append(form.Content, scalarValue(param.Name), scalarValue(param.Value))
+			}
+			forms = append(forms, form)
+		}
+		o.RequestBody("application/x-www-form-urlencoded", builder_v3.Inline(infer(forms)), true)
+	}
+	sort.Ints(op.statuses)
+	for _, status := range op.statuses {
+		description := http.StatusText(status)
+		if description == "" {
+			description = "Response"
+		}
+		if bodies := op.responses[status]; len(bodies) > 0 {
+			o.Response(strconv.Itoa(status), description, "application/json", builder_v3.Inline(infer(bodies)))
+		} else {
+			o.Response(strconv.Itoa(status), description, "", nil)
+		}
+	}
+	switch op.method {
+	case "GET":
+		return b.Get(op.template, o)
+	case "PUT":
+		return b.Put(op.template, o)
+	case "POST":
+		return b.Post(op.template, o)
+	case "DELETE":
+		return b.Delete(op.template, o)
+	case "OPTIONS":
+		return b.Options(op.template, o)
+	case "HEAD":
+		return b.Head(op.template, o)
+	case "PATCH":
+		return b.Patch(op.template, o)
+	case "TRACE":
+		return b.Trace(op.template, o)
+	}
+	return b
+}
+
+// Returns an operation id made of the method and the fixed segments of the
+// path, such as "getUsersPosts" for "GET /users/{userId}/posts".
+func (op *operation) operationID() string {
+	id := strings.ToLower(op.method)
+	for _, segment := range strings.Split(op.template, "/") {
+		if strings.HasPrefix(segment, "{") {
+			continue
+		}
+		for _, word := range nonIdentifier.Split(segment, -1) {
+			if word != "" {
+				id += strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+	}
+	return id
+}
+
+// Returns an OpenAPI v3 schema for an inferred JSON schema. Types that
+// include null are nullable, and values with several types have no type.
+func schemaForInferred(schema *jsonschema.Schema) *openapi_v3.Schema {
+	result := &openapi_v3.Schema{}
+	types := make([]string, 0)
+	if schema.Type != nil {
+		if schema.Type.String != nil {
+			types = append(types, *schema.Type.String)
+		} else if schema.Type.StringArray != nil {
+			types = append(types, *schema.Type.StringArray...)
+		}
+	}
+	nonNull := make([]string, 0)
+	for _, t := range types {
+		if t == "null" {
+			result.Nullable = &wrappers.BoolValue{Value: true}
+		} else {
+			nonNull = append(nonNull, t)
+		}
+	}
+	if len(nonNull) == 1 {
+		result.Type = nonNull[0]
+	}
+	if schema.Properties != nil {
+		result.Properties = &openapi_v3.Properties{}
+		for _, property := range *schema.Properties {
+			result.Properties.AdditionalProperties = append(result.Properties.AdditionalProperties,
+				&openapi_v3.NamedSchema{Name: property.Name, Value: schemaForInferred(property.Value)})
+		}
+	}
+	if schema.Required != nil {
+		result.Required = *schema.Required
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		result.Items = &openapi_v3.ItemsItem{
+			SchemaOrReference: []*openapi_v3.SchemaOrReference{builder_v3.Inline(schemaForInferred(schema.Items.Schema))},
+		}
+	} else if result.Type == "array" {
+		result.Items = &openapi_v3.ItemsItem{
+			SchemaOrReference: []*openapi_v3.SchemaOrReference{builder_v3.Inline(&openapi_v3.Schema{})},
+		}
+	}
+	if schema.Enumeration != nil {
+		for _, value := range *schema.Enumeration {
+			if value.String != nil {
+				result.Enum = append(result.Enum, &openapi_v3.Any{Yaml: strconv.Quote(*value.String)})
+			}
+		}
+	}
+	return result
+}
+
+func allMatch(values []string, pattern *regexp.Regexp) bool {
+	for _, value := range values {
+		if !pattern.MatchString(value) {
+			return false
+		}
+	}
+	return len(values) > 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
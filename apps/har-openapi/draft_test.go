@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/googleapis/gnostic/compiler"
+)
+
+const archive = `{"log": {"entries": [
+  {"request": {"method": "GET", "url": "https://api.example.com/users/42?verbose=true"},
+   "response": {"status": 200, "content": {"mimeType": "application/json",
+     "text": "{\"id\": 42, \"name\": \"Ada\", \"role\": \"admin\"}"}}},
+  {"request": {"method": "GET", "url": "https://api.example.com/users/7"},
+   "response": {"status": 200, "content": {"mimeType": "application/json; charset=utf-8",
+     "text": "{\"id\": 7, \"name\": \"Grace\", \"role\": \"admin\", \"manager\": null}"}}},
+  {"request": {"method": "POST", "url": "https://api.example.com/users",
+     "postData": {"mimeType": "application/json", "text": "{\"name\": \"Alan\"}"}},
+   "response": {"status": 201, "content": {"mimeType": "application/json", "text": "{\"id\": 8}"}}},
+  {"request": {"method": "GET", "url": "https://cdn.example.com/logo.png"},
+   "response": {"status": 200, "content": {"mimeType": "image/png", "text": ""}}}
+]}}`
+
+const expected = `openapi: 3.0.0
+info:
+  title: api.example.com
+  version: 0.0.1
+servers:
+  - url: https://api.example.com
+paths:
+  /users:
+    post:
+      operationId: postUsers
+      requestBody:
+        content:
+          application/json:
+            schema:
+              required:
+                - name
+              type: object
+              properties:
+                name:
+                  type: string
+        required: true
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                required:
+                  - id
+                type: object
+                properties:
+                  id:
+                    type: integer
+  /users/{userId}:
+    get:
+      operationId: getUsers
+      parameters:
+        - name: userId
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: verbose
+          in: query
+          required: false
+          schema:
+            type: boolean
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                required:
+                  - id
+                  - name
+                  - role
+                type: object
+                properties:
+                  id:
+                    type: integer
+                  name:
+                    type: string
+                  role:
+                    enum:
+                      - admin
+                    type: string
+                  manager:
+                    nullable: true
+`
+
+func TestDraft(t *testing.T) {
+	var har HAR
+	if err := json.Unmarshal([]byte(archive), &har); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document := Draft(har.Log.Entries, &Options{Version: "0.0.1", MaxEnumValues: 5})
+	actual := string(compiler.Marshal(document.ToRawInfo()))
+	if actual != expected {
+		t.Errorf("unexpected description:\n%s", actual)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	template, names, _ := pathTemplate("/orgs/acme/repos/1234/commits/3f786850e387550fdab836ed7e6dc881de23001b")
+	if template != "/orgs/acme/repos/{repoId}/commits/{commitId}" || len(names) != 2 {
+		t.Errorf("unexpected template: %s %+v", template, names)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// HAR is an HTTP Archive, the format that browsers and proxies use to save
+// captured traffic. Only the fields that describe APIs are read.
+type HAR struct {
+	Log struct {
+		Entries []*Entry `json:"entries"`
+	} `json:"log"`
+}
+
+// An Entry is a request and its response.
+type Entry struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+type Request struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	Headers     []*NameValue `json:"headers"`
+	QueryString []*NameValue `json:"queryString"`
+	PostData    *PostData    `json:"postData"`
+}
+
+type Response struct {
+	Status  int          `json:"status"`
+	Headers []*NameValue `json:"headers"`
+	Content *Content     `json:"content"`
+}
+
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type PostData struct {
+	MimeType string       `json:"mimeType"`
+	Text     string       `json:"text"`
+	Params   []*NameValue `json:"params"`
+}
+
+type Content struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding"`
+}
+
+// Returns the text of a response, decoding it if it was saved in base64.
+func (content *Content) text() string {
+	if content.Encoding == "base64" {
+		if data, err := base64.StdEncoding.DecodeString(content.Text); err == nil {
+			return string(data)
+		}
+	}
+	return content.Text
+}
+
+// Reads the entries of an HTTP Archive.
+func readHAR(filename string) ([]*Entry, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var har HAR
+	if err = json.Unmarshal(data, &har); err != nil {
+		return nil, errors.New(fmt.Sprintf("%s is not an HTTP Archive: %s", filename, err.Error()))
+	}
+	return har.Log.Entries, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// har-openapi drafts OpenAPI descriptions of APIs from HTTP Archive (HAR)
+// captures of their traffic.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] HAR_FILE...
+
+Writes a draft OpenAPI 3.0 description of the API calls captured in
+HTTP Archive files. Paths are grouped into templates by replacing
+segments that look like identifiers with parameters, and the schemas
+of parameters and JSON bodies are inferred from the captured values.
+
+Options:
+  --host=HOST      Host of the API (default: the most frequently called host).
+  --title=TITLE    Title of the description (default: the host).
+  --version=NAME   Version of the description (default: 0.0.1).
+  --enum=N         Describe strings with at most N distinct values with an
+                   enumeration (default 5, 0 to disable).
+  --all            Include calls without JSON bodies.
+  --json           Write JSON instead of YAML.
+  --out=FILE       File to write (default: standard output).
+`, path.Base(os.Args[0]))
+}
+
+func main() {
+	options := &Options{}
+	flag.StringVar(&options.Host, "host", "", "Host of the API.")
+	flag.StringVar(&options.Title, "title", "", "Title of the description.")
+	flag.StringVar(&options.Version, "version", "0.0.1", "Version of the description.")
+	flag.IntVar(&options.MaxEnumValues, "enum", 5, "Maximum number of distinct values to describe with an enumeration.")
+	flag.BoolVar(&options.All, "all", false, "Include calls without JSON bodies.")
+	writeJSON := flag.Bool("json", false, "Write JSON instead of YAML.")
+	out := flag.String("out", "", "File to write.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	if len(flag.Args()) == 0 {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+	entries := make([]*Entry, 0)
+	for _, filename := range flag.Args() {
+		e, err := readHAR(filename)
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+		entries = append(entries, e...)
+	}
+	document := Draft(entries, options)
+	var output []byte
+	if *writeJSON {
+		var err error
+		if output, err = jsonwriter.Marshal(document.ToRawInfo()); err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+	} else {
+		output = compiler.Marshal(document.ToRawInfo())
+	}
+	if *out == "" {
+		os.Stdout.Write(output)
+		return
+	}
+	if err := ioutil.WriteFile(*out, output, 0644); err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+}
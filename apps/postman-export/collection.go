@@ -0,0 +1,251 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+const collectionSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// A Collection is a Postman Collection (v2.1).
+type Collection struct {
+	Info     *Info       `json:"info"`
+	Item     []*Item     `json:"item"`
+	Variable []*KeyValue `json:"variable,omitempty"`
+}
+
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// An Item is a folder of items or a request.
+type Item struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Item        []*Item  `json:"item,omitempty"`
+	Request     *Request `json:"request,omitempty"`
+}
+
+type Request struct {
+	Method      string      `json:"method"`
+	Header      []*KeyValue `json:"header"`
+	Body        *Body       `json:"body,omitempty"`
+	URL         *URL        `json:"url"`
+	Description string      `json:"description,omitempty"`
+}
+
+type URL struct {
+	Raw      string      `json:"raw"`
+	Host     []string    `json:"host"`
+	Path     []string    `json:"path"`
+	Query    []*KeyValue `json:"query,omitempty"`
+	Variable []*KeyValue `json:"variable,omitempty"`
+}
+
+type Body struct {
+	Mode       string      `json:"mode"`
+	Raw        string      `json:"raw,omitempty"`
+	URLEncoded []*KeyValue `json:"urlencoded,omitempty"`
+	FormData   []*KeyValue `json:"formdata,omitempty"`
+	Options    *Options    `json:"options,omitempty"`
+}
+
+type Options struct {
+	Raw *RawOptions `json:"raw"`
+}
+
+type RawOptions struct {
+	Language string `json:"language"`
+}
+
+// A KeyValue is a header, query parameter, form field, or variable.
+type KeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// A parameter is a part of a request that is described by an operation.
+type parameter struct {
+	In          string
+	Name        string
+	Description string
+	Required    bool
+	Value       string
+	File        bool
+}
+
+// A collector adds the requests of operations to a collection, in folders
+// named by the first tags of the operations.
+type collector struct {
+	collection *Collection
+	folders    map[string]*Item
+}
+
+func newCollector(name, description, version string) *collector {
+	return &collector{
+		collection: &Collection{
+			Info: &Info{
+				Name:        name,
+				Description: description,
+				Version:     version,
+				Schema:      collectionSchema,
+			},
+			Item: make([]*Item, 0),
+		},
+		folders: make(map[string]*Item, 0),
+	}
+}
+
+// Adds an empty folder for a tag. Folders are listed in the order that
+// their tags are declared, followed by folders for undeclared tags.
+func (c *collector) addFolder(name, description string) {
+	if c.folders[name] != nil {
+		return
+	}
+	folder := &Item{Name: name, Description: description, Item: make([]*Item, 0)}
+	c.folders[name] = folder
+	c.collection.Item = append(c.collection.Item, folder)
+}
+
+// Adds a variable to the collection.
+func (c *collector) addVariable(key, value, description string) {
+	c.collection.Variable = append(c.collection.Variable, &KeyValue{Key: key, Value: value, Description: description})
+}
+
+// Adds the request of an operation. Requests of operations without
+// tags are added at the top level of the collection.
+func (c *collector) addRequest(tags []string, item *Item) {
+	if len(tags) == 0 {
+		c.collection.Item = append(c.collection.Item, item)
+		return
+	}
+	c.addFolder(tags[0], "")
+	folder := c.folders[tags[0]]
+	folder.Item = append(folder.Item, item)
+}
+
+// Removes the folders that have no requests.
+func (c *collector) finish() *Collection {
+	items := make([]*Item, 0)
+	for _, item := range c.collection.Item {
+		if item.Request != nil || len(item.Item) > 0 {
+			items = append(items, item)
+		}
+	}
+	c.collection.Item = items
+	return c.collection
+}
+
+var pathParameter = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Returns the request of an operation. Paths are relative to the
+// collection's baseUrl variable, and path parameters are written
+// in Postman's ":name" form.
+func newRequest(name, description, method, path string, parameters []*parameter, body *Body, contentType string) *Item {
+	request := &Request{
+		Method:      strings.ToUpper(method),
+		Header:      make([]*KeyValue, 0),
+		Body:        body,
+		Description: description,
+	}
+	path = pathParameter.ReplaceAllString(path, ":$1")
+	url := &URL{
+		Host: []string{"{{baseUrl}}"},
+		Path: make([]string, 0),
+	}
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment != "" {
+			url.Path = append(url.Path, segment)
+		}
+	}
+	query := make([]string, 0)
+	for _, p := range parameters {
+		switch p.In {
+		case "path":
+			url.Variable = append(url.Variable, &KeyValue{Key: p.Name, Value: p.Value, Description: p.Description})
+		case "query":
+			url.Query = append(url.Query, &KeyValue{Key: p.Name, Value: p.Value, Description: p.Description, Disabled: !p.Required})
+			if p.Required {
+				query = append(query, p.Name+"="+p.Value)
+			}
+		case "header":
+			request.Header = append(request.Header, &KeyValue{Key: p.Name, Value: p.Value, Description: p.Description, Disabled: !p.Required})
+		}
+	}
+	if body != nil && contentType != "" {
+		request.Header = append(request.Header, &KeyValue{Key: "Content-Type", Value: contentType})
+	}
+	url.Raw = "{{baseUrl}}/" + strings.Join(url.Path, "/")
+	if len(query) > 0 {
+		url.Raw += "?" + strings.Join(query, "&")
+	}
+	request.URL = url
+	if name == "" {
+		name = request.Method + " " + path
+	}
+	return &Item{Name: name, Request: request}
+}
+
+// Returns a form body with a field for each form parameter.
+func formBody(parameters []*parameter, multipart bool) *Body {
+	fields := make([]*KeyValue, 0)
+	for _, p := range parameters {
+		if p.In != "formData" {
+			continue
+		}
+		field := &KeyValue{Key: p.Name, Value: p.Value, Description: p.Description, Disabled: !p.Required}
+		if multipart {
+			field.Type = "text"
+			if p.File {
+				field.Type, field.Value = "file", ""
+			}
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	if multipart {
+		return &Body{Mode: "formdata", FormData: fields}
+	}
+	return &Body{Mode: "urlencoded", URLEncoded: fields}
+}
+
+// Returns a raw body with an example of a JSON value.
+func jsonBody(example []byte) *Body {
+	return &Body{Mode: "raw", Raw: string(example), Options: &Options{Raw: &RawOptions{Language: "json"}}}
+}
+
+// Returns a placeholder for a value of a type, in the "<type>" form that
+// Postman users replace before sending requests.
+func placeholder(typeName, format string) string {
+	switch {
+	case format == "int64":
+		return "<long>"
+	case format != "" && typeName == "string":
+		return "<" + format + ">"
+	case typeName == "":
+		return "<string>"
+	}
+	return "<" + typeName + ">"
+}
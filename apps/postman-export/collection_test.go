@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/googleapis/gnostic/lib"
+)
+
+const description = `
+openapi: 3.0.0
+info:
+  title: Widgets
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        default: us
+tags:
+  - name: widgets
+    description: Widget operations
+paths:
+  /widgets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    put:
+      tags: [widgets]
+      operationId: updateWidget
+      parameters:
+        - $ref: '#/components/parameters/Version'
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Widget'
+      responses:
+        200:
+          description: OK
+  /health:
+    get:
+      summary: Check health
+      responses:
+        200:
+          description: OK
+components:
+  parameters:
+    Version:
+      name: version
+      in: query
+      required: true
+      schema:
+        type: integer
+        format: int64
+  schemas:
+    Widget:
+      type: object
+      properties:
+        name:
+          type: string
+        size:
+          type: integer
+        parts:
+          type: array
+          items:
+            $ref: '#/components/schemas/Widget'
+`
+
+func TestCollection(t *testing.T) {
+	document, err := gnostic.ReadDocumentFromBytes([]byte(description))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	collection, err := newCollection(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(collection.Variable) != 2 || collection.Variable[1].Value != "https://{{region}}.example.com/v1" {
+		t.Errorf("unexpected variables: %+v", collection.Variable)
+	}
+	if len(collection.Item) != 2 || collection.Item[0].Name != "widgets" || collection.Item[1].Name != "Check health" {
+		t.Fatalf("unexpected items: %+v", collection.Item)
+	}
+	request := collection.Item[0].Item[0].Request
+	if request.URL.Raw != "{{baseUrl}}/widgets/:id?version=<long>" {
+		t.Errorf("unexpected url: %s", request.URL.Raw)
+	}
+	var body struct {
+		Name  string
+		Size  int
+		Parts []map[string]interface{}
+	}
+	if err := json.Unmarshal([]byte(request.Body.Raw), &body); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if body.Name != "<string>" || len(body.Parts) != 1 || len(body.Parts[0]) != 0 {
+		t.Errorf("unexpected body: %s", request.Body.Raw)
+	}
+	if len(request.Header) != 1 || request.Header[0].Value != "application/json" {
+		t.Errorf("unexpected headers: %+v", request.Header)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// postman-export writes Postman Collections (v2.1) of the operations
+// described in OpenAPI descriptions.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/googleapis/gnostic/lib"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] OPENAPI_FILE
+
+Writes a Postman Collection (v2.1) with a request for each operation
+of an OpenAPI 2.0 or 3.0 description. Requests are grouped in folders
+named by the first tags of their operations.
+
+Options:
+  --out=FILE  File to write (default: standard output).
+`, path.Base(os.Args[0]))
+}
+
+// Returns the collection of a document.
+func newCollection(document *gnostic.Document) (*Collection, error) {
+	switch document.Version {
+	case gnostic.OpenAPIv2:
+		return newCollectionV2(document.V2), nil
+	case gnostic.OpenAPIv3:
+		return newCollectionV3(document.V3), nil
+	}
+	return nil, errors.New("unsupported OpenAPI version")
+}
+
+// Returns a collection as indented JSON. Placeholders such as "<string>"
+// are written without escaping.
+func marshal(collection *Collection) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(collection); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func main() {
+	out := flag.String("out", "", "File to write.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+	document, err := gnostic.ReadDocument(args[0])
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	collection, err := newCollection(document)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	output, err := marshal(collection)
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	if *out == "" {
+		os.Stdout.Write(output)
+		return
+	}
+	if err = ioutil.WriteFile(*out, output, 0644); err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+	"gopkg.in/yaml.v3"
+)
+
+// Returns a collection with a request for each operation of an OpenAPI v2 document.
+func newCollectionV2(document *openapi_v2.Document) *Collection {
+	info := document.Info
+	if info == nil {
+		info = &openapi_v2.Info{}
+	}
+	c := newCollector(info.Title, info.Description, info.Version)
+	scheme := "https"
+	if len(document.Schemes) > 0 {
+		scheme = document.Schemes[0]
+	}
+	baseURL := ""
+	if document.Host != "" {
+		baseURL = scheme + "://" + document.Host
+	}
+	c.addVariable("baseUrl", baseURL+strings.TrimSuffix(document.BasePath, "/"), "")
+	for _, tag := range document.Tags {
+		c.addFolder(tag.Name, tag.Description)
+	}
+	if document.Paths == nil {
+		return c.finish()
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		operations := []*openapi_v2.Operation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch}
+		for i, operation := range operations {
+			if operation == nil {
+				continue
+			}
+			method := []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH"}[i]
+			parameters := make([]*parameter, 0)
+			var body *Body
+			contentType := ""
+			for _, p := range append(append([]*openapi_v2.ParametersItem{}, item.Parameters...), operation.Parameters...) {
+				p = parameterV2(document, p)
+				if bodyParameter := p.GetParameter().GetBodyParameter(); bodyParameter != nil {
+					if example, err := jsonwriter.Marshal(exampleV2(document, bodyParameter.Schema, nil)); err == nil {
+						body = jsonBody(example)
+						contentType = "application/json"
+					}
+				} else if nonBody := p.GetParameter().GetNonBodyParameter(); nonBody != nil {
+					parameters = append(parameters, nonBodyParameterV2(nonBody))
+				}
+			}
+			if body == nil {
+				consumes := document.Consumes
+				if operation.Consumes != nil {
+					consumes = operation.Consumes
+				}
+				multipart := false
+				contentType = "application/x-www-form-urlencoded"
+				for _, mediaType := range consumes {
+					if mediaType == "multipart/form-data" {
+						multipart, contentType = true, mediaType
+					}
+				}
+				body = formBody(parameters, multipart)
+			}
+			name := operation.Summary
+			if name == "" {
+				name = operation.OperationId
+			}
+			request := newRequest(name, operation.Description, method, pair.Name, parameters, body, contentType)
+			c.addRequest(operation.Tags, request)
+		}
+	}
+	return c.finish()
+}
+
+// Returns the parameter that a parameter refers to, or the parameter if it isn't a reference.
+func parameterV2(document *openapi_v2.Document, item *openapi_v2.ParametersItem) *openapi_v2.ParametersItem {
+	reference := item.GetJsonReference()
+	if reference == nil || document.Parameters == nil {
+		return item
+	}
+	for _, pair := range document.Parameters.AdditionalProperties {
+		if reference.XRef == "#/parameters/"+compiler.PointerEscape(pair.Name) {
+			return &openapi_v2.ParametersItem{Oneof: &openapi_v2.ParametersItem_Parameter{Parameter: pair.Value}}
+		}
+	}
+	return item
+}
+
+func nonBodyParameterV2(p *openapi_v2.NonBodyParameter) *parameter {
+	value := func(typeName, format string, defaultValue *openapi_v2.Any, enum []*openapi_v2.Any) string {
+		if defaultValue != nil {
+			return defaultValue.ToRawInfo().Value
+		}
+		if len(enum) > 0 {
+			return enum[0].ToRawInfo().Value
+		}
+		return placeholder(typeName, format)
+	}
+	switch {
+	case p.GetQueryParameterSubSchema() != nil:
+		q := p.GetQueryParameterSubSchema()
+		return &parameter{In: "query", Name: q.Name, Description: q.Description, Required: q.Required.GetValue(),
+			Value: value(q.Type, q.Format, q.Default, q.Enum)}
+	case p.GetPathParameterSubSchema() != nil:
+		q := p.GetPathParameterSubSchema()
+		return &parameter{In: "path", Name: q.Name, Description: q.Description, Required: true,
+			Value: value(q.Type, q.Format, q.Default, q.Enum)}
+	case p.GetHeaderParameterSubSchema() != nil:
+		q := p.GetHeaderParameterSubSchema()
+		return &parameter{In: "header", Name: q.Name, Description: q.Description, Required: q.Required.GetValue(),
+			Value: value(q.Type, q.Format, q.Default, q.Enum)}
+	case p.GetFormDataParameterSubSchema() != nil:
+		q := p.GetFormDataParameterSubSchema()
+		return &parameter{In: "formData", Name: q.Name, Description: q.Description, Required: q.Required.GetValue(),
+			Value: value(q.Type, q.Format, q.Default, q.Enum), File: q.Type == "file"}
+	}
+	return &parameter{}
+}
+
+// Returns an example of a value of a schema. Examples and defaults are
+// used when schemas have them. References that are being expanded are
+// listed in seen, and recursive references are written as empty objects.
+func exampleV2(document *openapi_v2.Document, schema *openapi_v2.Schema, seen []string) *yaml.Node {
+	if schema == nil {
+		return compiler.NewMappingNode()
+	}
+	if schema.XRef != "" {
+		for _, ref := range seen {
+			if ref == schema.XRef {
+				return compiler.NewMappingNode()
+			}
+		}
+		if document.Definitions != nil {
+			for _, pair := range document.Definitions.AdditionalProperties {
+				if schema.XRef == "#/definitions/"+compiler.PointerEscape(pair.Name) {
+					return exampleV2(document, pair.Value, append(seen, schema.XRef))
+				}
+			}
+		}
+		return compiler.NewMappingNode()
+	}
+	switch {
+	case schema.Example != nil:
+		return schema.Example.ToRawInfo()
+	case schema.Default != nil:
+		return schema.Default.ToRawInfo()
+	case len(schema.Enum) > 0:
+		return schema.Enum[0].ToRawInfo()
+	}
+	typeName := ""
+	if schema.Type != nil && len(schema.Type.Value) > 0 {
+		typeName = schema.Type.Value[0]
+	}
+	if len(schema.AllOf) > 0 {
+		node := compiler.NewMappingNode()
+		for _, member := range schema.AllOf {
+			if example := exampleV2(document, member, seen); example.Kind == yaml.MappingNode {
+				node.Content = append(node.Content, example.Content...)
+			}
+		}
+		return node
+	}
+	switch {
+	case typeName == "array" || schema.Items != nil:
+		node := compiler.NewSequenceNode()
+		if schema.Items != nil && len(schema.Items.Schema) > 0 {
+			node.Content = append(node.Content, exampleV2(document, schema.Items.Schema[0], seen))
+		}
+		return node
+	case typeName == "object" || schema.Properties != nil:
+		node := compiler.NewMappingNode()
+		if schema.Properties != nil {
+			for _, pair := range schema.Properties.AdditionalProperties {
+				node.Content = append(node.Content,
+					compiler.NewScalarNodeForString(pair.Name), exampleV2(document, pair.Value, seen))
+			}
+		}
+		return node
+	}
+	return scalarExample(typeName, schema.Format)
+}
+
+// Returns an example of a scalar value.
+func scalarExample(typeName, format string) *yaml.Node {
+	switch typeName {
+	case "integer", "number":
+		return compiler.NewScalarNodeForInt(0)
+	case "boolean":
+		return compiler.NewScalarNodeForBool(true)
+	}
+	return compiler.NewScalarNodeForString(placeholder(typeName, format))
+}
@@ -0,0 +1,259 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+	"gopkg.in/yaml.v3"
+)
+
+// Returns a collection with a request for each operation of an OpenAPI v3 document.
+// The baseUrl variable is the URL of the document's first server, and the
+// variables of that server are added as collection variables.
+func newCollectionV3(document *openapi_v3.Document) *Collection {
+	info := document.Info
+	if info == nil {
+		info = &openapi_v3.Info{}
+	}
+	c := newCollector(info.Title, info.Description, info.Version)
+	baseURL := ""
+	if len(document.Servers) > 0 {
+		server := document.Servers[0]
+		baseURL = pathParameter.ReplaceAllString(strings.TrimSuffix(server.Url, "/"), "{{$1}}")
+		if server.Variables != nil {
+			for _, pair := range server.Variables.Name {
+				c.addVariable(pair.Name, primitiveString(pair.Value.Default), pair.Value.Description)
+			}
+		}
+	}
+	c.addVariable("baseUrl", baseURL, "")
+	for _, tag := range document.Tags {
+		c.addFolder(tag.Name, tag.Description)
+	}
+	if document.Paths == nil {
+		return c.finish()
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		operations := []*openapi_v3.Operation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch, item.Trace}
+		for i, operation := range operations {
+			if operation == nil {
+				continue
+			}
+			method := []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}[i]
+			parameters := make([]*parameter, 0)
+			for _, p := range append(append([]*openapi_v3.ParameterOrReference{}, item.Parameters...), operation.Parameters...) {
+				if p := parameterV3(document, p); p != nil {
+					value := "<string>"
+					if schema := schemaV3(document, p.Schema); schema != nil {
+						value = placeholder(schema.Type, schema.Format)
+						if len(schema.Enum) > 0 {
+							value = schema.Enum[0].ToRawInfo().Value
+						}
+					}
+					parameters = append(parameters, &parameter{
+						In:          p.In,
+						Name:        p.Name,
+						Description: p.Description,
+						Required:    p.Required.GetValue() || p.In == "path",
+						Value:       value,
+					})
+				}
+			}
+			body, contentType := requestBodyV3(document, operation.RequestBody)
+			name := operation.Summary
+			if name == "" {
+				name = operation.OperationId
+			}
+			request := newRequest(name, operation.Description, method, pair.Name, parameters, body, contentType)
+			c.addRequest(operation.Tags, request)
+		}
+	}
+	return c.finish()
+}
+
+// Returns the body of a request and its content type. JSON bodies are
+// preferred, followed by forms; other bodies are left empty.
+func requestBodyV3(document *openapi_v3.Document, item *openapi_v3.RequestBodyOrReference) (*Body, string) {
+	requestBody := item.GetRequestBody()
+	if reference := item.GetReference(); reference != nil && document.Components != nil && document.Components.RequestBodies != nil {
+		for _, pair := range document.Components.RequestBodies.AdditionalProperties {
+			if reference.XRef == "#/components/requestBodies/"+compiler.PointerEscape(pair.Name) {
+				requestBody = pair.Value
+			}
+		}
+	}
+	if requestBody == nil || requestBody.Content == nil {
+		return nil, ""
+	}
+	for _, pair := range requestBody.Content.MediaType {
+		if pair.Name == "application/json" || strings.HasSuffix(pair.Name, "+json") {
+			example, err := jsonwriter.Marshal(exampleV3(document, pair.Value.Schema, nil))
+			if err == nil {
+				return jsonBody(example), pair.Name
+			}
+		}
+	}
+	for _, pair := range requestBody.Content.MediaType {
+		multipart := pair.Name == "multipart/form-data"
+		if pair.Name != "application/x-www-form-urlencoded" && !multipart {
+			continue
+		}
+		fields := make([]*parameter, 0)
+		if schema := schemaV3(document, pair.Value.Schema); schema != nil && schema.Properties != nil {
+			for _, property := range schema.Properties.AdditionalProperties {
+				fields = append(fields, &parameter{
+					In:          "formData",
+					Name:        property.Name,
+					Description: property.Value.Description,
+					Required:    contains(schema.Required, property.Name),
+					Value:       placeholder(property.Value.Type, property.Value.Format),
+					File:        property.Value.Format == "binary",
+				})
+			}
+		}
+		if body := formBody(fields, multipart); body != nil {
+			return body, pair.Name
+		}
+	}
+	for _, pair := range requestBody.Content.MediaType {
+		return &Body{Mode: "raw"}, pair.Name
+	}
+	return nil, ""
+}
+
+// Returns the parameter that a parameter refers to, or nil if it can't be found.
+func parameterV3(document *openapi_v3.Document, item *openapi_v3.ParameterOrReference) *openapi_v3.Parameter {
+	if p := item.GetParameter(); p != nil {
+		return p
+	}
+	if reference := item.GetReference(); reference != nil && document.Components != nil && document.Components.Parameters != nil {
+		for _, pair := range document.Components.Parameters.AdditionalProperties {
+			if reference.XRef == "#/components/parameters/"+compiler.PointerEscape(pair.Name) {
+				return pair.Value
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the schema that a schema refers to, or nil if it can't be found.
+func schemaV3(document *openapi_v3.Document, item *openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	if schema := item.GetSchema(); schema != nil {
+		return schema
+	}
+	if reference := item.GetReference(); reference != nil && document.Components != nil && document.Components.Schemas != nil {
+		for _, pair := range document.Components.Schemas.AdditionalProperties {
+			if reference.XRef == "#/components/schemas/"+compiler.PointerEscape(pair.Name) {
+				return pair.Value
+			}
+		}
+	}
+	return nil
+}
+
+// Returns an example of a value of a schema. References that are being
+// expanded are listed in seen, and recursive references are written as
+// empty objects.
+func exampleV3(document *openapi_v3.Document, item *openapi_v3.SchemaOrReference, seen []string) *yaml.Node {
+	if reference := item.GetReference(); reference != nil {
+		for _, ref := range seen {
+			if ref == reference.XRef {
+				return compiler.NewMappingNode()
+			}
+		}
+		seen = append(seen, reference.XRef)
+	}
+	schema := schemaV3(document, item)
+	if schema == nil {
+		return compiler.NewMappingNode()
+	}
+	return exampleForSchemaV3(document, schema, seen)
+}
+
+func exampleForSchemaV3(document *openapi_v3.Document, schema *openapi_v3.Schema, seen []string) *yaml.Node {
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0].ToRawInfo()
+	}
+	alternatives := schema.OneOf
+	if len(alternatives) == 0 {
+		alternatives = schema.AnyOf
+	}
+	if len(alternatives) > 0 {
+		return exampleV3(document, alternatives[0], seen)
+	}
+	if len(schema.AllOf) > 0 {
+		node := compiler.NewMappingNode()
+		for _, member := range schema.AllOf {
+			if example := exampleV3(document, member, seen); example.Kind == yaml.MappingNode {
+				node.Content = append(node.Content, example.Content...)
+			}
+		}
+		if schema.Properties == nil {
+			return node
+		}
+		if properties := exampleForSchemaV3(document, &openapi_v3.Schema{Properties: schema.Properties}, seen); properties.Kind == yaml.MappingNode {
+			node.Content = append(node.Content, properties.Content...)
+		}
+		return node
+	}
+	switch {
+	case schema.Type == "array" || schema.Items != nil:
+		node := compiler.NewSequenceNode()
+		if schema.Items != nil && len(schema.Items.SchemaOrReference) > 0 {
+			node.Content = append(node.Content, exampleV3(document, schema.Items.SchemaOrReference[0], seen))
+		}
+		return node
+	case schema.Type == "object" || schema.Properties != nil:
+		node := compiler.NewMappingNode()
+		if schema.Properties != nil {
+			for _, pair := range schema.Properties.AdditionalProperties {
+				node.Content = append(node.Content,
+					compiler.NewScalarNodeForString(pair.Name), exampleForSchemaV3(document, pair.Value, seen))
+			}
+		}
+		return node
+	}
+	return scalarExample(schema.Type, schema.Format)
+}
+
+// Returns the value of a primitive as a string.
+func primitiveString(value *openapi_v3.Primitive) string {
+	switch v := value.GetOneof().(type) {
+	case *openapi_v3.Primitive_String_:
+		return v.String_
+	case *openapi_v3.Primitive_Integer:
+		return fmt.Sprintf("%d", v.Integer)
+	case *openapi_v3.Primitive_Number:
+		return fmt.Sprintf("%g", v.Number)
+	case *openapi_v3.Primitive_Boolean:
+		return fmt.Sprintf("%t", v.Boolean)
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
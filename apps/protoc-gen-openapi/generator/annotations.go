@@ -0,0 +1,97 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// HttpRule is the google.api.HttpRule message, which describes the HTTP
+// request that a method is transcoded from. It is declared here with the
+// field numbers of google/api/http.proto so that the annotations can be
+// read without depending on the generated Go package for the Google APIs.
+// The fields of the pattern oneof are declared as separate fields, which
+// is equivalent on the wire.
+type HttpRule struct {
+	Selector           string             `protobuf:"bytes,1,opt,name=selector,proto3"`
+	Get                string             `protobuf:"bytes,2,opt,name=get,proto3"`
+	Put                string             `protobuf:"bytes,3,opt,name=put,proto3"`
+	Post               string             `protobuf:"bytes,4,opt,name=post,proto3"`
+	Delete             string             `protobuf:"bytes,5,opt,name=delete,proto3"`
+	Patch              string             `protobuf:"bytes,6,opt,name=patch,proto3"`
+	Body               string             `protobuf:"bytes,7,opt,name=body,proto3"`
+	Custom             *CustomHttpPattern `protobuf:"bytes,8,opt,name=custom,proto3"`
+	AdditionalBindings []*HttpRule        `protobuf:"bytes,11,rep,name=additional_bindings,json=additionalBindings,proto3"`
+	ResponseBody       string             `protobuf:"bytes,12,opt,name=response_body,json=responseBody,proto3"`
+}
+
+func (m *HttpRule) Reset()         { *m = HttpRule{} }
+func (m *HttpRule) String() string { return proto.CompactTextString(m) }
+func (*HttpRule) ProtoMessage()    {}
+
+// Method returns the HTTP method and path template of a rule,
+// or empty strings if it has no pattern.
+func (m *HttpRule) Method() (string, string) {
+	switch {
+	case m.Get != "":
+		return "GET", m.Get
+	case m.Put != "":
+		return "PUT", m.Put
+	case m.Post != "":
+		return "POST", m.Post
+	case m.Delete != "":
+		return "DELETE", m.Delete
+	case m.Patch != "":
+		return "PATCH", m.Patch
+	case m.Custom != nil:
+		return m.Custom.Kind, m.Custom.Path
+	}
+	return "", ""
+}
+
+// CustomHttpPattern is the google.api.CustomHttpPattern message,
+// which describes a rule with an HTTP method that has no field of its own.
+type CustomHttpPattern struct {
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3"`
+	Path string `protobuf:"bytes,2,opt,name=path,proto3"`
+}
+
+func (m *CustomHttpPattern) Reset()         { *m = CustomHttpPattern{} }
+func (m *CustomHttpPattern) String() string { return proto.CompactTextString(m) }
+func (*CustomHttpPattern) ProtoMessage()    {}
+
+// E_Http is the google.api.http extension of method options.
+var E_Http = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.MethodOptions)(nil),
+	ExtensionType: (*HttpRule)(nil),
+	Field:         72295728,
+	Name:          "google.api.http",
+	Tag:           "bytes,72295728,opt,name=http",
+	Filename:      "google/api/annotations.proto",
+}
+
+// Returns the HTTP rule of a method, or nil if it has none.
+func httpRuleForMethod(method *descriptor.MethodDescriptorProto) *HttpRule {
+	if method.Options == nil || !proto.HasExtension(method.Options, E_Http) {
+		return nil
+	}
+	extension, err := proto.GetExtension(method.Options, E_Http)
+	if err != nil {
+		return nil
+	}
+	rule, _ := extension.(*HttpRule)
+	return rule
+}
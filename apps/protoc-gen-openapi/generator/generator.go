@@ -0,0 +1,469 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generator describes protocol buffer services with OpenAPI v3
+// documents. Each method that has a google.api.http annotation becomes an
+// operation on the path that the method is transcoded from, and the messages
+// that the operations use become schemas, following the JSON mapping of
+// protocol buffers.
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/builder/v3"
+)
+
+// Options configure the documents that are generated.
+type Options struct {
+	Title    string   // defaults to the names of the services
+	Version  string   // defaults to "0.0.1"
+	Services []string // the fully-qualified names of the services to describe, or all if empty
+}
+
+// NewDocument returns a document that describes the services of the files
+// with the specified names. The files must include all of the files that
+// they import, as in the requests that protoc sends to plugins. If no names
+// are specified, the services of all of the files are described.
+func NewDocument(files []*descriptor.FileDescriptorProto, filenames []string, options *Options) (*openapi_v3.Document, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	g := &generator{
+		messages:   make(map[string]*message, 0),
+		enums:      make(map[string]*descriptor.EnumDescriptorProto, 0),
+		referenced: make(map[string]bool, 0),
+	}
+	selected := make(map[string]bool, 0)
+	for _, filename := range filenames {
+		selected[filename] = true
+	}
+	services := make([]*service, 0)
+	for _, file := range files {
+		comments := commentsForFile(file)
+		prefix := "."
+		if file.GetPackage() != "" {
+			prefix += file.GetPackage() + "."
+		}
+		for i, m := range file.MessageType {
+			g.addMessage(m, prefix, "", []int32{4, int32(i)}, comments)
+		}
+		for _, e := range file.EnumType {
+			g.enums[prefix+e.GetName()] = e
+		}
+		if len(selected) > 0 && !selected[file.GetName()] {
+			continue
+		}
+		for i, s := range file.Service {
+			if len(options.Services) > 0 && !contains(options.Services, prefix[1:]+s.GetName()) {
+				continue
+			}
+			services = append(services, &service{
+				descriptor: s,
+				path:       []int32{6, int32(i)},
+				comments:   comments,
+			})
+		}
+	}
+	if len(services) == 0 {
+		return nil, errors.New("no services were found")
+	}
+
+	title := options.Title
+	if title == "" {
+		names := make([]string, 0)
+		for _, s := range services {
+			names = append(names, s.descriptor.GetName())
+		}
+		title = strings.Join(names, ", ")
+	}
+	version := options.Version
+	if version == "" {
+		version = "0.0.1"
+	}
+	g.document = builder_v3.NewDocument(title, version)
+	if len(services) == 1 {
+		if description := services[0].comment(); description != "" {
+			g.document.Description(description)
+		}
+	}
+	for _, s := range services {
+		if err := g.addService(s); err != nil {
+			return nil, err
+		}
+	}
+	g.addSchemas()
+	return g.document.Build(), nil
+}
+
+// A message and its location, which are used to name it and find its comments.
+type message struct {
+	descriptor *descriptor.DescriptorProto
+	name       string // the name of its schema
+	path       []int32
+	comments   map[string]string
+}
+
+// A service and its location.
+type service struct {
+	descriptor *descriptor.ServiceDescriptorProto
+	path       []int32
+	comments   map[string]string
+}
+
+func (s *service) comment() string {
+	return s.comments[pathKey(s.path)]
+}
+
+type generator struct {
+	document   *builder_v3.DocumentBuilder
+	messages   map[string]*message // indexed by fully-qualified name
+	enums      map[string]*descriptor.EnumDescriptorProto
+	referenced map[string]bool // messages that schemas are needed for
+}
+
+// Indexes a message and the messages and enums that are nested in it.
+func (g *generator) addMessage(m *descriptor.DescriptorProto, prefix string, parent string, path []int32, comments map[string]string) {
+	name := m.GetName()
+	if parent != "" {
+		name = parent + "." + name
+	}
+	g.messages[prefix+name] = &message{descriptor: m, name: name, path: path, comments: comments}
+	for i, nested := range m.NestedType {
+		g.addMessage(nested, prefix, name, appendPath(path, 3, int32(i)), comments)
+	}
+	for _, e := range m.EnumType {
+		g.enums[prefix+name+"."+e.GetName()] = e
+	}
+}
+
+// Adds the operations of the methods of a service.
+func (g *generator) addService(s *service) error {
+	for i, method := range s.descriptor.Method {
+		rule := httpRuleForMethod(method)
+		if rule == nil {
+			continue
+		}
+		input := g.messages[method.GetInputType()]
+		output := g.messages[method.GetOutputType()]
+		if input == nil || output == nil {
+			return errors.New(fmt.Sprintf("unknown message types for method %s", method.GetName()))
+		}
+		description := s.comments[pathKey(appendPath(s.path, 2, int32(i)))]
+		operationID := s.descriptor.GetName() + "_" + method.GetName()
+		rules := append([]*HttpRule{rule}, rule.AdditionalBindings...)
+		for j, binding := range rules {
+			id := operationID
+			if j > 0 {
+				id = fmt.Sprintf("%s_%d", operationID, j)
+			}
+			operation := builder_v3.NewOperation(id).Tags(s.descriptor.GetName())
+			if description != "" {
+				operation.Description(description)
+			}
+			httpMethod, template := binding.Method()
+			path, parameters := pathForTemplate(template)
+			if err := g.addParameters(operation, input, parameters, binding.Body); err != nil {
+				return err
+			}
+			response := g.schemaForMessage(output)
+			if binding.ResponseBody != "" {
+				field := fieldWithName(output.descriptor, binding.ResponseBody)
+				if field == nil {
+					return errors.New(fmt.Sprintf("unknown response body field %s for method %s", binding.ResponseBody, method.GetName()))
+				}
+				response = g.schemaForField(field)
+			}
+			operation.Response("200", "OK", "application/json", response)
+			switch strings.ToUpper(httpMethod) {
+			case "GET":
+				g.document.Get(path, operation)
+			case "PUT":
+				g.document.Put(path, operation)
+			case "POST":
+				g.document.Post(path, operation)
+			case "DELETE":
+				g.document.Delete(path, operation)
+			case "PATCH":
+				g.document.Patch(path, operation)
+			case "HEAD":
+				g.document.Head(path, operation)
+			case "OPTIONS":
+				g.document.Options(path, operation)
+			case "TRACE":
+				g.document.Trace(path, operation)
+			default:
+				return errors.New(fmt.Sprintf("unsupported HTTP method %q for method %s", httpMethod, method.GetName()))
+			}
+		}
+	}
+	return nil
+}
+
+// Adds the path and query parameters and the request body of an operation.
+// Fields of the input message that aren't in the path or body are
+// query parameters.
+func (g *generator) addParameters(operation *builder_v3.OperationBuilder, input *message, pathParameters []string, body string) error {
+	inPath := make(map[string]bool, 0)
+	for _, name := range pathParameters {
+		field := g.fieldForPath(input, name)
+		if field == nil {
+			return errors.New(fmt.Sprintf("unknown path parameter %s in %s", name, input.name))
+		}
+		inPath[strings.Split(name, ".")[0]] = true
+		operation.PathParameter(name, "", g.schemaForField(field))
+	}
+	switch body {
+	case "*":
+		operation.RequestBody("application/json", g.schemaForMessage(input), true)
+		return nil
+	case "":
+	default:
+		field := fieldWithName(input.descriptor, body)
+		if field == nil {
+			return errors.New(fmt.Sprintf("unknown body field %s in %s", body, input.name))
+		}
+		inPath[body] = true
+		operation.RequestBody("application/json", g.schemaForField(field), true)
+	}
+	for i, field := range input.descriptor.Field {
+		if inPath[field.GetName()] || field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE && !g.isScalarMessage(field) {
+			continue
+		}
+		description := input.comments[pathKey(appendPath(input.path, 2, int32(i)))]
+		operation.QueryParameter(jsonName(field), description, false, g.schemaForField(field))
+	}
+	return nil
+}
+
+// Returns the field of a message that a dotted path names.
+func (g *generator) fieldForPath(m *message, path string) *descriptor.FieldDescriptorProto {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field := fieldWithName(m.descriptor, name)
+		if field == nil || i == len(names)-1 {
+			return field
+		}
+		if m = g.messages[field.GetTypeName()]; m == nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+func fieldWithName(m *descriptor.DescriptorProto, name string) *descriptor.FieldDescriptorProto {
+	for _, field := range m.Field {
+		if field.GetName() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// Returns a reference to the schema for a message and records that it is needed.
+func (g *generator) schemaForMessage(m *message) *openapi_v3.SchemaOrReference {
+	g.referenced[m.name] = true
+	return builder_v3.Ref(m.name)
+}
+
+// Adds the schemas of the messages that are referred to,
+// including those that are only referred to by other schemas.
+func (g *generator) addSchemas() {
+	messages := make(map[string]*message, 0)
+	for _, m := range g.messages {
+		messages[m.name] = m
+	}
+	schemas := make(map[string]*openapi_v3.Schema, 0)
+	for len(schemas) < len(g.referenced) {
+		for name := range g.referenced {
+			if schemas[name] == nil {
+				schemas[name] = g.schemaForMessageFields(messages[name])
+			}
+		}
+	}
+	names := make([]string, 0)
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.document.Schema(name, schemas[name])
+	}
+}
+
+// Returns the schema for the fields of a message.
+func (g *generator) schemaForMessageFields(m *message) *openapi_v3.Schema {
+	object := builder_v3.NewObject()
+	if description := m.comments[pathKey(m.path)]; description != "" {
+		object.Description(description)
+	}
+	for i, field := range m.descriptor.Field {
+		schema := propertySchema(g.schemaForField(field))
+		if description := m.comments[pathKey(appendPath(m.path, 2, int32(i)))]; description != "" {
+			schema.SetDescription(description)
+		}
+		object.Property(jsonName(field), schema)
+	}
+	return object.Build()
+}
+
+// The schemas of well-known message types, which have special JSON mappings.
+var wellKnownSchemas = map[string][2]string{
+	".google.protobuf.Timestamp":   {"string", "date-time"},
+	".google.protobuf.Duration":    {"string", ""},
+	".google.protobuf.FieldMask":   {"string", ""},
+	".google.protobuf.Empty":       {"object", ""},
+	".google.protobuf.Struct":      {"object", ""},
+	".google.protobuf.Any":         {"object", ""},
+	".google.protobuf.ListValue":   {"array", ""},
+	".google.protobuf.Value":       {"", ""},
+	".google.protobuf.DoubleValue": {"number", "double"},
+	".google.protobuf.FloatValue":  {"number", "float"},
+	".google.protobuf.Int64Value":  {"string", "int64"},
+	".google.protobuf.UInt64Value": {"string", "uint64"},
+	".google.protobuf.Int32Value":  {"integer", "int32"},
+	".google.protobuf.UInt32Value": {"integer", "uint32"},
+	".google.protobuf.BoolValue":   {"boolean", ""},
+	".google.protobuf.StringValue": {"string", ""},
+	".google.protobuf.BytesValue":  {"string", "byte"},
+}
+
+// Returns true if a message-typed field is written as a JSON scalar.
+func (g *generator) isScalarMessage(field *descriptor.FieldDescriptorProto) bool {
+	s, found := wellKnownSchemas[field.GetTypeName()]
+	return found && s[0] != "object" && s[0] != "array" && s[0] != ""
+}
+
+// Returns the schema for the values of a field.
+func (g *generator) schemaForField(field *descriptor.FieldDescriptorProto) *openapi_v3.SchemaOrReference {
+	var schema *openapi_v3.SchemaOrReference
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+		if s, found := wellKnownSchemas[field.GetTypeName()]; found {
+			schema = builder_v3.Inline(builder_v3.Scalar(s[0], s[1]))
+		} else if m := g.messages[field.GetTypeName()]; m == nil {
+			schema = builder_v3.Inline(builder_v3.Scalar("object", ""))
+		} else if m.descriptor.GetOptions().GetMapEntry() {
+			// maps are objects, and repeated map entries are not arrays
+			return builder_v3.Inline(builder_v3.Scalar("object", ""))
+		} else {
+			schema = g.schemaForMessage(m)
+		}
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		s := builder_v3.Scalar("string", "")
+		if e := g.enums[field.GetTypeName()]; e != nil {
+			for _, value := range e.Value {
+				s.AddEnum(&openapi_v3.Any{Yaml: value.GetName()})
+			}
+		}
+		schema = builder_v3.Inline(s)
+	default:
+		s := scalarSchemas[field.GetType()]
+		schema = builder_v3.Inline(builder_v3.Scalar(s[0], s[1]))
+	}
+	if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return builder_v3.Inline(builder_v3.Array(schema))
+	}
+	return schema
+}
+
+// The JSON types and formats of scalar fields. 64-bit integers are written as strings.
+var scalarSchemas = map[descriptor.FieldDescriptorProto_Type][2]string{
+	descriptor.FieldDescriptorProto_TYPE_DOUBLE:   {"number", "double"},
+	descriptor.FieldDescriptorProto_TYPE_FLOAT:    {"number", "float"},
+	descriptor.FieldDescriptorProto_TYPE_INT64:    {"string", "int64"},
+	descriptor.FieldDescriptorProto_TYPE_SINT64:   {"string", "int64"},
+	descriptor.FieldDescriptorProto_TYPE_SFIXED64: {"string", "int64"},
+	descriptor.FieldDescriptorProto_TYPE_UINT64:   {"string", "uint64"},
+	descriptor.FieldDescriptorProto_TYPE_FIXED64:  {"string", "uint64"},
+	descriptor.FieldDescriptorProto_TYPE_INT32:    {"integer", "int32"},
+	descriptor.FieldDescriptorProto_TYPE_SINT32:   {"integer", "int32"},
+	descriptor.FieldDescriptorProto_TYPE_SFIXED32: {"integer", "int32"},
+	descriptor.FieldDescriptorProto_TYPE_UINT32:   {"integer", "uint32"},
+	descriptor.FieldDescriptorProto_TYPE_FIXED32:  {"integer", "uint32"},
+	descriptor.FieldDescriptorProto_TYPE_BOOL:     {"boolean", ""},
+	descriptor.FieldDescriptorProto_TYPE_STRING:   {"string", ""},
+	descriptor.FieldDescriptorProto_TYPE_BYTES:    {"string", "byte"},
+}
+
+// Returns a schema that can be used as a property. Properties can't be
+// references in the OpenAPI v3 model, so references are wrapped in allOf.
+func propertySchema(schema *openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	if s := schema.GetSchema(); s != nil {
+		return s
+	}
+	return (&openapi_v3.Schema{}).AddAllOf(schema)
+}
+
+// Returns the JSON name of a field.
+func jsonName(field *descriptor.FieldDescriptorProto) string {
+	if field.JsonName != nil {
+		return field.GetJsonName()
+	}
+	parts := strings.Split(field.GetName(), "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// Matches the variables of path templates, such as {name=shelves/*}.
+var templateVariable = regexp.MustCompile(`{([^}=]+)(=[^}]*)?}`)
+
+// Returns an OpenAPI path for a path template and the names of its variables.
+func pathForTemplate(template string) (string, []string) {
+	names := make([]string, 0)
+	path := templateVariable.ReplaceAllStringFunc(template, func(variable string) string {
+		name := templateVariable.FindStringSubmatch(variable)[1]
+		names = append(names, name)
+		return "{" + name + "}"
+	})
+	return path, names
+}
+
+// Returns the comments of a file, indexed by the paths of their locations.
+func commentsForFile(file *descriptor.FileDescriptorProto) map[string]string {
+	comments := make(map[string]string, 0)
+	for _, location := range file.GetSourceCodeInfo().GetLocation() {
+		if comment := strings.TrimSpace(location.GetLeadingComments()); comment != "" {
+			comments[pathKey(location.Path)] = comment
+		}
+	}
+	return comments
+}
+
+func pathKey(path []int32) string {
+	return fmt.Sprint(path)
+}
+
+func appendPath(path []int32, values ...int32) []int32 {
+	return append(append([]int32{}, path...), values...)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
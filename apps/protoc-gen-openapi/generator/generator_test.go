@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func field(name string, number int32, fieldType descriptor.FieldDescriptorProto_Type, typeName string) *descriptor.FieldDescriptorProto {
+	f := &descriptor.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Type:   fieldType.Enum(),
+		Label:  descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func method(name, input, output string, rule *HttpRule) *descriptor.MethodDescriptorProto {
+	options := &descriptor.MethodOptions{}
+	if err := proto.SetExtension(options, E_Http, rule); err != nil {
+		panic(err)
+	}
+	return &descriptor.MethodDescriptorProto{
+		Name:       proto.String(name),
+		InputType:  proto.String(input),
+		OutputType: proto.String(output),
+		Options:    options,
+	}
+}
+
+func libraryFile() *descriptor.FileDescriptorProto {
+	books := field("books", 1, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".library.Book")
+	books.Label = descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return &descriptor.FileDescriptorProto{
+		Name:    proto.String("library.proto"),
+		Package: proto.String("library"),
+		MessageType: []*descriptor.DescriptorProto{
+			{
+				Name: proto.String("Book"),
+				Field: []*descriptor.FieldDescriptorProto{
+					field("name", 1, descriptor.FieldDescriptorProto_TYPE_STRING, ""),
+					field("page_count", 2, descriptor.FieldDescriptorProto_TYPE_INT64, ""),
+					field("genre", 3, descriptor.FieldDescriptorProto_TYPE_ENUM, ".library.Genre"),
+				},
+			},
+			{
+				Name: proto.String("GetBookRequest"),
+				Field: []*descriptor.FieldDescriptorProto{
+					field("name", 1, descriptor.FieldDescriptorProto_TYPE_STRING, ""),
+					field("view", 2, descriptor.FieldDescriptorProto_TYPE_INT32, ""),
+				},
+			},
+			{
+				Name: proto.String("CreateBookRequest"),
+				Field: []*descriptor.FieldDescriptorProto{
+					field("parent", 1, descriptor.FieldDescriptorProto_TYPE_STRING, ""),
+					field("book", 2, descriptor.FieldDescriptorProto_TYPE_MESSAGE, ".library.Book"),
+				},
+			},
+			{
+				Name:  proto.String("ListBooksResponse"),
+				Field: []*descriptor.FieldDescriptorProto{books},
+			},
+		},
+		EnumType: []*descriptor.EnumDescriptorProto{
+			{
+				Name: proto.String("Genre"),
+				Value: []*descriptor.EnumValueDescriptorProto{
+					{Name: proto.String("FICTION"), Number: proto.Int32(0)},
+					{Name: proto.String("POETRY"), Number: proto.Int32(1)},
+				},
+			},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Library"),
+				Method: []*descriptor.MethodDescriptorProto{
+					method("GetBook", ".library.GetBookRequest", ".library.Book",
+						&HttpRule{Get: "/v1/{name=shelves/*/books/*}"}),
+					method("CreateBook", ".library.CreateBookRequest", ".library.Book",
+						&HttpRule{Post: "/v1/{parent=shelves/*}/books", Body: "book"}),
+					method("ListBooks", ".library.CreateBookRequest", ".library.ListBooksResponse",
+						&HttpRule{Get: "/v1/{parent=shelves/*}/books"}),
+				},
+			},
+		},
+		SourceCodeInfo: &descriptor.SourceCodeInfo{
+			Location: []*descriptor.SourceCodeInfo_Location{
+				{Path: []int32{6, 0}, LeadingComments: proto.String(" Manages books.\n")},
+				{Path: []int32{4, 0, 2, 1}, LeadingComments: proto.String(" The number of pages.\n")},
+			},
+		},
+		Syntax: proto.String("proto3"),
+	}
+}
+
+func TestNewDocument(t *testing.T) {
+	// descriptors are read from bytes, as they are when protoc runs plugins
+	bytes, err := proto.Marshal(libraryFile())
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	file := &descriptor.FileDescriptorProto{}
+	if err = proto.Unmarshal(bytes, file); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err := NewDocument([]*descriptor.FileDescriptorProto{file}, []string{"library.proto"}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if document.Info.Title != "Library" || document.Info.Description != "Manages books." {
+		t.Errorf("Unexpected info: %+v", document.Info)
+	}
+
+	// the document is a valid description
+	info, err := compiler.ReadInfoFromBytes("", compiler.Marshal(document.ToRawInfo()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err = openapi_v3.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Generated document is invalid: %+v", err)
+	}
+
+	get := document.Paths.Get("/v1/{name}").GetGet()
+	if get == nil || get.OperationId != "Library_GetBook" || len(get.Parameters) != 2 {
+		t.Fatalf("Unexpected operation: %+v", get)
+	}
+	if p := get.Parameters[0].GetParameter(); p.In != "path" || p.Name != "name" || !p.Required.GetValue() {
+		t.Errorf("Unexpected path parameter: %+v", p)
+	}
+	if p := get.Parameters[1].GetParameter(); p.In != "query" || p.Name != "view" || p.Schema.GetSchema().Type != "integer" {
+		t.Errorf("Unexpected query parameter: %+v", p)
+	}
+	books := document.Paths.Get("/v1/{parent}/books")
+	if books.GetPost() == nil || books.GetGet() == nil {
+		t.Fatalf("Unexpected path item: %+v", books)
+	}
+	body := books.Post.RequestBody.GetRequestBody().Content.MediaType[0].Value.Schema
+	if body.GetReference().GetXRef() != "#/components/schemas/Book" || len(books.Post.Parameters) != 1 {
+		t.Errorf("Unexpected request: %+v", books.Post)
+	}
+	// the body of a GET is its query parameters, and messages aren't parameters
+	if len(books.Get.Parameters) != 1 || books.Get.RequestBody != nil {
+		t.Errorf("Unexpected request: %+v", books.Get)
+	}
+
+	schemas := document.Components.Schemas
+	if len(schemas.AdditionalProperties) != 2 || schemas.Get("ListBooksResponse") == nil {
+		t.Fatalf("Unexpected schemas: %+v", schemas)
+	}
+	book := schemas.Get("Book")
+	pageCount := book.Properties.Get("pageCount")
+	if pageCount == nil || pageCount.Type != "string" || pageCount.Format != "int64" || pageCount.Description != "The number of pages." {
+		t.Errorf("Unexpected property: %+v", pageCount)
+	}
+	if genre := book.Properties.Get("genre"); genre == nil || len(genre.Enum) != 2 {
+		t.Errorf("Unexpected enum property: %+v", genre)
+	}
+	items := schemas.Get("ListBooksResponse").Properties.Get("books").Items.SchemaOrReference[0]
+	if items.GetReference().GetXRef() != "#/components/schemas/Book" {
+		t.Errorf("Unexpected items: %+v", items)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// protoc-gen-openapi is a plugin for the protocol buffer compiler that
+// describes annotated services with OpenAPI v3 documents. Run it by
+// building this program, putting it in your path, and running protoc:
+//
+//	protoc --openapi_out=. --openapi_opt=title=Library,version=1.0 library.proto
+//
+// The options are written after the output directory, separated by commas:
+//
+//	title=TITLE         the title of the document
+//	version=VERSION     the version of the API
+//	filename=FILENAME   the name of the file to write (default "openapi.yaml")
+//	format=json         write JSON instead of YAML
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/googleapis/gnostic/apps/protoc-gen-openapi/generator"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+)
+
+// Returns the file that describes the services of a request.
+func generate(request *plugin.CodeGeneratorRequest) (*plugin.CodeGeneratorResponse_File, error) {
+	options := &generator.Options{}
+	filename := "openapi.yaml"
+	format := "yaml"
+	for _, parameter := range strings.Split(request.GetParameter(), ",") {
+		if parameter == "" {
+			continue
+		}
+		pair := strings.SplitN(parameter, "=", 2)
+		if len(pair) != 2 {
+			return nil, errors.New(fmt.Sprintf("invalid option %q", parameter))
+		}
+		switch pair[0] {
+		case "title":
+			options.Title = pair[1]
+		case "version":
+			options.Version = pair[1]
+		case "filename":
+			filename = pair[1]
+		case "format":
+			format = pair[1]
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown option %q", pair[0]))
+		}
+	}
+	document, err := generator.NewDocument(request.ProtoFile, request.FileToGenerate, options)
+	if err != nil {
+		return nil, err
+	}
+	var bytes []byte
+	switch format {
+	case "yaml":
+		bytes = compiler.Marshal(document.ToRawInfo())
+	case "json":
+		bytes, err = jsonwriter.Marshal(document.ToRawInfo())
+	default:
+		err = errors.New(fmt.Sprintf("unknown format %q", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.CodeGeneratorResponse_File{
+		Name:    proto.String(filename),
+		Content: proto.String(string(bytes)),
+	}, nil
+}
+
+func main() {
+	data, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+	request := &plugin.CodeGeneratorRequest{}
+	if err = proto.Unmarshal(data, request); err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+	response := &plugin.CodeGeneratorResponse{}
+	file, err := generate(request)
+	if err != nil {
+		response.Error = proto.String(err.Error())
+	} else {
+		response.File = []*plugin.CodeGeneratorResponse_File{file}
+	}
+	data, err = proto.Marshal(response)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(data)
+}
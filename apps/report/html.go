@@ -0,0 +1,521 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	pb "github.com/googleapis/gnostic/OpenAPIv2"
+)
+
+// The parts of a document that are shown in an HTML report.
+type htmlReport struct {
+	Title       string
+	Version     string
+	Description string
+	URL         string
+	Tags        []*htmlTag
+	Definitions []*htmlSchema
+	Parameters  []*htmlParameter
+	Responses   []*htmlResponse
+}
+
+// Operations that share a tag.
+type htmlTag struct {
+	Name        string
+	Anchor      string
+	Description string
+	Operations  []*htmlOperation
+}
+
+type htmlOperation struct {
+	Anchor      string
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Parameters  []*htmlParameter
+	Responses   []*htmlResponse
+}
+
+type htmlParameter struct {
+	Name        string
+	Anchor      string
+	In          string
+	Required    bool
+	Description string
+	Type        template.HTML
+}
+
+type htmlResponse struct {
+	Code        string
+	Anchor      string
+	Description string
+	Type        template.HTML
+}
+
+type htmlSchema struct {
+	Name        string
+	Anchor      string
+	Description string
+	Type        template.HTML
+	Properties  []*htmlProperty
+}
+
+type htmlProperty struct {
+	Name        string
+	Required    bool
+	Description string
+	Type        template.HTML
+}
+
+// The getters that the subschemas of non-body parameters have in common.
+type nonBodyParameter interface {
+	GetName() string
+	GetIn() string
+	GetDescription() string
+	GetRequired() *wrappers.BoolValue
+	GetType() string
+	GetFormat() string
+	GetItems() *pb.PrimitivesItems
+}
+
+var anchorPattern = regexp.MustCompile("[^A-Za-z0-9_-]+")
+
+// Returns an id for an element that is safe to use in attributes and URLs.
+func anchor(prefix, name string) string {
+	return prefix + "-" + anchorPattern.ReplaceAllString(name, "_")
+}
+
+// Returns a link to an element of the report for a local reference, such as
+// "#/definitions/Pet". References to other files are shown as text.
+func refLink(ref string) template.HTML {
+	prefixes := map[string]string{
+		"#/definitions/": "definition",
+		"#/parameters/":  "parameter",
+		"#/responses/":   "response",
+	}
+	for prefix, kind := range prefixes {
+		if strings.HasPrefix(ref, prefix) {
+			name := strings.TrimPrefix(ref, prefix)
+			return template.HTML(fmt.Sprintf("<a href=\"#%s\">%s</a>",
+				anchor(kind, name), template.HTMLEscapeString(name)))
+		}
+	}
+	return template.HTML(template.HTMLEscapeString(ref))
+}
+
+// Returns a primitive type with its format, such as "integer (int64)".
+func primitiveType(typeName, format string) string {
+	if format != "" {
+		return typeName + " (" + format + ")"
+	}
+	return typeName
+}
+
+// Returns a description of the type of a schema, with links to the
+// definitions that it refers to.
+func schemaType(schema *pb.Schema) template.HTML {
+	if schema == nil {
+		return ""
+	}
+	if schema.XRef != "" {
+		return refLink(schema.XRef)
+	}
+	if len(schema.AllOf) > 0 {
+		parts := make([]string, 0, len(schema.AllOf))
+		for _, s := range schema.AllOf {
+			parts = append(parts, string(schemaType(s)))
+		}
+		return template.HTML(strings.Join(parts, " and "))
+	}
+	typeName := ""
+	if schema.Type != nil {
+		typeName = strings.Join(schema.Type.Value, " or ")
+	}
+	switch {
+	case typeName == "array" && schema.Items != nil && len(schema.Items.Schema) > 0:
+		return "array of " + schemaType(schema.Items.Schema[0])
+	case schema.AdditionalProperties != nil && schema.AdditionalProperties.GetSchema() != nil:
+		return "map of " + schemaType(schema.AdditionalProperties.GetSchema())
+	case typeName == "" && schema.Properties != nil:
+		typeName = "object"
+	}
+	return template.HTML(template.HTMLEscapeString(primitiveType(typeName, schema.Format)))
+}
+
+// Returns the type of a non-body parameter, including the types of array items.
+func primitivesType(typeName, format string, items *pb.PrimitivesItems) template.HTML {
+	if typeName == "array" && items != nil {
+		return "array of " + primitivesType(items.Type, items.Format, items.Items)
+	}
+	return template.HTML(template.HTMLEscapeString(primitiveType(typeName, format)))
+}
+
+func newHTMLParameter(parameter *pb.Parameter) *htmlParameter {
+	if body := parameter.GetBodyParameter(); body != nil {
+		return &htmlParameter{
+			Name:        body.Name,
+			In:          body.In,
+			Required:    body.Required.GetValue(),
+			Description: body.Description,
+			Type:        schemaType(body.Schema),
+		}
+	}
+	var p nonBodyParameter
+	if nonBody := parameter.GetNonBodyParameter(); nonBody != nil {
+		switch {
+		case nonBody.GetHeaderParameterSubSchema() != nil:
+			p = nonBody.GetHeaderParameterSubSchema()
+		case nonBody.GetFormDataParameterSubSchema() != nil:
+			p = nonBody.GetFormDataParameterSubSchema()
+		case nonBody.GetQueryParameterSubSchema() != nil:
+			p = nonBody.GetQueryParameterSubSchema()
+		case nonBody.GetPathParameterSubSchema() != nil:
+			p = nonBody.GetPathParameterSubSchema()
+		}
+	}
+	if p == nil {
+		return &htmlParameter{}
+	}
+	return &htmlParameter{
+		Name:        p.GetName(),
+		In:          p.GetIn(),
+		Required:    p.GetRequired().GetValue(),
+		Description: p.GetDescription(),
+		Type:        primitivesType(p.GetType(), p.GetFormat(), p.GetItems()),
+	}
+}
+
+func newHTMLResponse(code string, response *pb.Response) *htmlResponse {
+	r := &htmlResponse{Code: code, Description: response.Description}
+	if response.Schema != nil {
+		if schema := response.Schema.GetSchema(); schema != nil {
+			r.Type = schemaType(schema)
+		} else if response.Schema.GetFileSchema() != nil {
+			r.Type = "file"
+		}
+	}
+	return r
+}
+
+func newHTMLOperation(method, path string, shared []*pb.ParametersItem, operation *pb.Operation) *htmlOperation {
+	o := &htmlOperation{
+		Method:      method,
+		Path:        path,
+		OperationID: operation.OperationId,
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		Deprecated:  operation.Deprecated.GetValue(),
+	}
+	for _, item := range append(shared, operation.Parameters...) {
+		if ref := item.GetJsonReference(); ref != nil {
+			o.Parameters = append(o.Parameters, &htmlParameter{Name: ref.XRef[strings.LastIndex(ref.XRef, "/")+1:], Type: refLink(ref.XRef)})
+		} else if parameter := item.GetParameter(); parameter != nil {
+			o.Parameters = append(o.Parameters, newHTMLParameter(parameter))
+		}
+	}
+	if operation.Responses != nil {
+		for _, pair := range operation.Responses.ResponseCode {
+			if ref := pair.Value.GetJsonReference(); ref != nil {
+				o.Responses = append(o.Responses, &htmlResponse{Code: pair.Name, Type: refLink(ref.XRef)})
+			} else if response := pair.Value.GetResponse(); response != nil {
+				o.Responses = append(o.Responses, newHTMLResponse(pair.Name, response))
+			}
+		}
+	}
+	return o
+}
+
+func newHTMLSchema(name string, schema *pb.Schema) *htmlSchema {
+	s := &htmlSchema{
+		Name:        name,
+		Anchor:      anchor("definition", name),
+		Description: schema.Description,
+		Type:        schemaType(schema),
+	}
+	if schema.Properties != nil {
+		required := make(map[string]bool, len(schema.Required))
+		for _, name := range schema.Required {
+			required[name] = true
+		}
+		for _, pair := range schema.Properties.AdditionalProperties {
+			s.Properties = append(s.Properties, &htmlProperty{
+				Name:        pair.Name,
+				Required:    required[pair.Name],
+				Description: pair.Value.Description,
+				Type:        schemaType(pair.Value),
+			})
+		}
+	}
+	return s
+}
+
+// Returns the parts of a document that are shown in an HTML report.
+// Operations are grouped by their tags, in the order that the tags are
+// declared, followed by the tags that are only used by operations.
+// Operations without tags are listed last.
+func newHTMLReport(document *pb.Document) *htmlReport {
+	report := &htmlReport{}
+	if document.Info != nil {
+		report.Title = document.Info.Title
+		report.Version = document.Info.Version
+		report.Description = document.Info.Description
+	}
+	if document.Host != "" {
+		scheme := "https"
+		if len(document.Schemes) > 0 {
+			scheme = document.Schemes[0]
+		}
+		report.URL = scheme + "://" + document.Host + document.BasePath
+	}
+	tags := make(map[string]*htmlTag)
+	addTag := func(name, description string) *htmlTag {
+		if tag, ok := tags[name]; ok {
+			return tag
+		}
+		tag := &htmlTag{Name: name, Anchor: anchor("tag", name), Description: description}
+		tags[name] = tag
+		report.Tags = append(report.Tags, tag)
+		return tag
+	}
+	for _, tag := range document.Tags {
+		addTag(tag.Name, tag.Description)
+	}
+	var untagged []*htmlOperation
+	if document.Paths != nil {
+		for _, pair := range document.Paths.Path {
+			item := pair.Value
+			operations := []struct {
+				method    string
+				operation *pb.Operation
+			}{
+				{"GET", item.Get},
+				{"PUT", item.Put},
+				{"POST", item.Post},
+				{"DELETE", item.Delete},
+				{"OPTIONS", item.Options},
+				{"HEAD", item.Head},
+				{"PATCH", item.Patch},
+			}
+			for _, o := range operations {
+				if o.operation == nil {
+					continue
+				}
+				if len(o.operation.Tags) == 0 {
+					operation := newHTMLOperation(o.method, pair.Name, item.Parameters, o.operation)
+					operation.Anchor = anchor("operation", fmt.Sprintf("%s-%s", o.method, pair.Name))
+					untagged = append(untagged, operation)
+				}
+				for _, name := range o.operation.Tags {
+					tag := addTag(name, "")
+					// each tag gets its own copy so that anchors are unique
+					operation := newHTMLOperation(o.method, pair.Name, item.Parameters, o.operation)
+					operation.Anchor = anchor("operation", fmt.Sprintf("%s-%s-%s", name, o.method, pair.Name))
+					tag.Operations = append(tag.Operations, operation)
+				}
+			}
+		}
+	}
+	if len(untagged) > 0 {
+		report.Tags = append(report.Tags, &htmlTag{Name: "Other operations", Anchor: "untagged", Operations: untagged})
+	}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			report.Definitions = append(report.Definitions, newHTMLSchema(pair.Name, pair.Value))
+		}
+	}
+	if document.Parameters != nil {
+		for _, pair := range document.Parameters.AdditionalProperties {
+			parameter := newHTMLParameter(pair.Value)
+			parameter.Anchor = anchor("parameter", pair.Name)
+			report.Parameters = append(report.Parameters, parameter)
+		}
+	}
+	if document.Responses != nil {
+		for _, pair := range document.Responses.AdditionalProperties {
+			response := newHTMLResponse(pair.Name, pair.Value)
+			response.Anchor = anchor("response", pair.Name)
+			report.Responses = append(report.Responses, response)
+		}
+	}
+	return report
+}
+
+// renderHTML returns a static HTML page that documents an API. The page has
+// a table of contents, operations grouped by tag, and definitions that
+// references link to, and a search box that filters the operations and
+// definitions that are shown.
+func renderHTML(document *pb.Document) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := htmlTemplate.Execute(&buffer, newHTMLReport(document))
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} {{.Version}}</title>
+<style>
+body { font-family: sans-serif; margin: 0; display: flex; }
+nav { width: 16em; padding: 1em; border-right: 1px solid #ddd; height: 100vh; overflow: auto; position: sticky; top: 0; }
+nav ul { list-style: none; padding-left: 1em; }
+main { flex: 1; padding: 1em 2em; }
+.operation, .definition { border: 1px solid #ddd; border-radius: 4px; padding: 0.5em 1em; margin: 1em 0; }
+.method { font-weight: bold; font-family: monospace; }
+.path { font-family: monospace; }
+.deprecated { text-decoration: line-through; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: 0.2em 1em 0.2em 0; vertical-align: top; }
+#search { width: 100%; box-sizing: border-box; }
+</style>
+</head>
+<body>
+<nav>
+<input id="search" type="search" placeholder="Search">
+<ul>
+{{- range .Tags}}
+<li><a href="#{{.Anchor}}">{{.Name}}</a></li>
+{{- end}}
+{{- if .Definitions}}
+<li><a href="#definitions">Definitions</a>
+<ul>
+{{- range .Definitions}}
+<li data-search="{{.Name}}"><a href="#{{.Anchor}}">{{.Name}}</a></li>
+{{- end}}
+</ul>
+</li>
+{{- end}}
+</ul>
+</nav>
+<main>
+<h1>{{.Title}} <small>{{.Version}}</small></h1>
+{{- if .URL}}
+<p><code>{{.URL}}</code></p>
+{{- end}}
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- range .Tags}}
+<section id="{{.Anchor}}">
+<h2>{{.Name}}</h2>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- range .Operations}}
+<div class="operation" id="{{.Anchor}}" data-search="{{.Method}} {{.Path}} {{.OperationID}} {{.Summary}}">
+<h3{{if .Deprecated}} class="deprecated"{{end}}><span class="method">{{.Method}}</span> <span class="path">{{.Path}}</span></h3>
+{{- if .Summary}}
+<p><strong>{{.Summary}}</strong></p>
+{{- end}}
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .OperationID}}
+<p>Operation ID: <code>{{.OperationID}}</code></p>
+{{- end}}
+{{- if .Parameters}}
+<h4>Parameters</h4>
+<table>
+<tr><th>Name</th><th>In</th><th>Type</th><th>Description</th></tr>
+{{- range .Parameters}}
+<tr><td>{{.Name}}{{if .Required}} *{{end}}</td><td>{{.In}}</td><td>{{.Type}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Responses}}
+<h4>Responses</h4>
+<table>
+<tr><th>Code</th><th>Type</th><th>Description</th></tr>
+{{- range .Responses}}
+<tr><td>{{.Code}}</td><td>{{.Type}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</div>
+{{- end}}
+</section>
+{{- end}}
+{{- if .Definitions}}
+<section id="definitions">
+<h2>Definitions</h2>
+{{- range .Definitions}}
+<div class="definition" id="{{.Anchor}}" data-search="{{.Name}}">
+<h3>{{.Name}}</h3>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .Properties}}
+<table>
+<tr><th>Property</th><th>Type</th><th>Description</th></tr>
+{{- range .Properties}}
+<tr><td>{{.Name}}{{if .Required}} *{{end}}</td><td>{{.Type}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>{{.Type}}</p>
+{{- end}}
+</div>
+{{- end}}
+</section>
+{{- end}}
+{{- if .Parameters}}
+<section id="parameters">
+<h2>Parameters</h2>
+<table>
+<tr><th>Name</th><th>In</th><th>Type</th><th>Description</th></tr>
+{{- range .Parameters}}
+<tr id="{{.Anchor}}"><td>{{.Name}}{{if .Required}} *{{end}}</td><td>{{.In}}</td><td>{{.Type}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+{{- if .Responses}}
+<section id="responses">
+<h2>Responses</h2>
+<table>
+<tr><th>Name</th><th>Type</th><th>Description</th></tr>
+{{- range .Responses}}
+<tr id="{{.Anchor}}"><td>{{.Code}}</td><td>{{.Type}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+<p>* required</p>
+</main>
+<script>
+document.getElementById("search").addEventListener("input", function (event) {
+  var query = event.target.value.toLowerCase();
+  var elements = document.querySelectorAll("[data-search]");
+  for (var i = 0; i < elements.length; i++) {
+    var text = elements[i].getAttribute("data-search").toLowerCase();
+    elements[i].style.display = text.indexOf(query) === -1 ? "none" : "";
+  }
+});
+</script>
+</body>
+</html>
+`))
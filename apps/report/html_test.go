@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func TestHTML(t *testing.T) {
+	info, err := compiler.ReadInfoFromBytes("", []byte(`
+swagger: "2.0"
+info:
+  title: Petstore
+  version: 1.0.0
+  description: <script>alert(1)</script>
+tags:
+- name: pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+      - $ref: "#/parameters/limit"
+      responses:
+        200:
+          description: Pets
+          schema:
+            type: array
+            items:
+              $ref: "#/definitions/Pet"
+    delete:
+      operationId: deletePets
+      responses:
+        204:
+          description: Deleted
+parameters:
+  limit:
+    name: limit
+    in: query
+    type: integer
+    format: int32
+definitions:
+  Pet:
+    required: [name]
+    properties:
+      name:
+        type: string
+      tags:
+        type: object
+        additionalProperties:
+          type: string
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := pb.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	bytes, err := renderHTML(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	page := string(bytes)
+	for _, expected := range []string{
+		`<section id="tag-pets">`,
+		`<div class="operation" id="operation-pets-GET-_pets"`,
+		`<h2>Other operations</h2>`,
+		`<td>array of <a href="#definition-Pet">Pet</a></td>`,
+		`<td>limit</td><td></td><td><a href="#parameter-limit">limit</a></td>`,
+		`<tr id="parameter-limit"><td>limit</td><td>query</td><td>integer (int32)</td>`,
+		`<div class="definition" id="definition-Pet" data-search="Pet">`,
+		`<td>name *</td><td>string</td>`,
+		`<td>tags</td><td>map of string</td>`,
+		`&lt;script&gt;alert(1)&lt;/script&gt;`,
+	} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("Expected %s in:\n%s", expected, page)
+		}
+	}
+}
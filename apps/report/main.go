@@ -221,16 +221,27 @@ func printVendorExtension(code *printer.Code, vendorExtension []*pb.NamedAny) {
 }
 
 func main() {
+	html := flag.Bool("html", false, "Write the report as a static HTML page.")
 	flag.Parse()
 	args := flag.Args()
 
 	if len(args) != 1 {
-		fmt.Printf("Usage: report <file.pb>\n")
+		fmt.Printf("Usage: report [--html] <file.pb>\n")
 		return
 	}
 
 	document := readDocumentFromFileWithName(args[0])
 
+	if *html {
+		bytes, err := renderHTML(document)
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+		os.Stdout.Write(bytes)
+		return
+	}
+
 	code := &printer.Code{}
 	code.Print("API REPORT")
 	code.Print("----------")
@@ -0,0 +1,196 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"regexp"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+var avroName = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// Returns an Avro schema for a schema that has been dereferenced and
+// flattened. Objects are written as records, which are named for the paths
+// of their properties, and optional and nullable values are written as
+// unions with null. The outermost record or enum is in the converter's
+// namespace.
+func (c *converter) avro(schema *jsonschema.Schema) *yaml.Node {
+	return c.avroType(schema, validName(c.name), "")
+}
+
+func (c *converter) appendNamespace(node *yaml.Node, path string) {
+	if path == "" && c.namespace != "" {
+		appendPair(node, "namespace", compiler.NewScalarNodeForString(c.namespace))
+	}
+}
+
+func avroPrimitive(name string) *yaml.Node {
+	return compiler.NewScalarNodeForString(name)
+}
+
+func avroLogical(name string, logicalType string) *yaml.Node {
+	node := compiler.NewMappingNode()
+	appendPair(node, "type", avroPrimitive(name))
+	appendPair(node, "logicalType", compiler.NewScalarNodeForString(logicalType))
+	return node
+}
+
+// Returns a union of null and a type.
+func avroNullable(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.SequenceNode {
+		return node
+	}
+	union := compiler.NewSequenceNode()
+	union.Content = append(union.Content, avroPrimitive("null"), node)
+	return union
+}
+
+// Returns the Avro type of a schema. Records and enums are given the
+// specified name.
+func (c *converter) avroType(schema *jsonschema.Schema, name string, path string) *yaml.Node {
+	if schema == nil {
+		c.warn(path, "missing schema is written as a string")
+		return avroPrimitive("string")
+	}
+	if schema.Ref != nil {
+		if *schema.Ref == "#/definitions/"+escape(c.name) {
+			return avroPrimitive(validName(c.name))
+		}
+		c.warn(path, "recursive reference to %s is written as a JSON string", *schema.Ref)
+		return avroPrimitive("string")
+	}
+	if schema.AnyOf != nil || schema.OneOf != nil || schema.AllOf != nil {
+		c.warn(path, "alternatives are written as a JSON string")
+		return avroPrimitive("string")
+	}
+	typeName, nullable := typeForSchema(schema)
+	var node *yaml.Node
+	switch typeName {
+	case "object":
+		node = c.avroObject(schema, name, path)
+	case "array":
+		node = compiler.NewMappingNode()
+		appendPair(node, "type", avroPrimitive("array"))
+		appendPair(node, "items", c.avroType(itemsSchema(schema), name+"Item", path+"/items"))
+	case "string":
+		node = c.avroString(schema, name, path)
+	case "integer":
+		if stringValue(schema.Format) == "int32" {
+			node = avroPrimitive("int")
+		} else {
+			node = avroPrimitive("long")
+		}
+	case "number":
+		if stringValue(schema.Format) == "float" {
+			node = avroPrimitive("float")
+		} else {
+			node = avroPrimitive("double")
+		}
+	case "boolean":
+		node = avroPrimitive("boolean")
+	case "null":
+		node = avroPrimitive("null")
+	default:
+		c.warn(path, "value of any type is written as a JSON string")
+		node = avroPrimitive("string")
+	}
+	if nullable {
+		node = avroNullable(node)
+	}
+	return node
+}
+
+func (c *converter) avroString(schema *jsonschema.Schema, name string, path string) *yaml.Node {
+	if symbols := stringEnum(schema); symbols != nil {
+		for _, symbol := range symbols {
+			if !avroName.MatchString(symbol) {
+				c.warn(path, "enum with the value %q is written as a string", symbol)
+				return avroPrimitive("string")
+			}
+		}
+		node := compiler.NewMappingNode()
+		appendPair(node, "type", avroPrimitive("enum"))
+		appendPair(node, "name", compiler.NewScalarNodeForString(name))
+		c.appendNamespace(node, path)
+		if schema.Description != nil {
+			appendPair(node, "doc", compiler.NewScalarNodeForString(*schema.Description))
+		}
+		appendPair(node, "symbols", compiler.NewSequenceNodeForStringArray(symbols))
+		return node
+	}
+	switch stringValue(schema.Format) {
+	case "date":
+		return avroLogical("int", "date")
+	case "date-time":
+		return avroLogical("long", "timestamp-millis")
+	case "time":
+		return avroLogical("int", "time-millis")
+	case "uuid":
+		return avroLogical("string", "uuid")
+	case "byte", "binary":
+		return avroPrimitive("bytes")
+	}
+	return avroPrimitive("string")
+}
+
+func (c *converter) avroObject(schema *jsonschema.Schema, name string, path string) *yaml.Node {
+	if values := mapValues(schema); values != nil {
+		node := compiler.NewMappingNode()
+		appendPair(node, "type", avroPrimitive("map"))
+		appendPair(node, "values", c.avroType(values, name+"Value", path+"/additionalProperties"))
+		return node
+	}
+	if schema.Properties == nil || len(*schema.Properties) == 0 {
+		c.warn(path, "object without properties is written as a JSON string")
+		return avroPrimitive("string")
+	}
+	fields := compiler.NewSequenceNode()
+	for _, property := range *schema.Properties {
+		propertyPath := path + "/properties/" + escape(property.Name)
+		field := compiler.NewMappingNode()
+		fieldName := validName(property.Name)
+		if fieldName != property.Name {
+			c.warn(propertyPath, "property is renamed %s", fieldName)
+		}
+		appendPair(field, "name", compiler.NewScalarNodeForString(fieldName))
+		if property.Value != nil && property.Value.Description != nil {
+			appendPair(field, "doc", compiler.NewScalarNodeForString(*property.Value.Description))
+		}
+		fieldType := c.avroType(property.Value, typeName(name, property.Name), propertyPath)
+		if !required(schema, property.Name) {
+			fieldType = avroNullable(fieldType)
+		}
+		appendPair(field, "type", fieldType)
+		if fieldType.Kind == yaml.SequenceNode {
+			// the defaults of unions must have the type of their first member
+			appendPair(field, "default", scalarNode("null", "!!null"))
+		} else if property.Value != nil && property.Value.Default != nil {
+			appendPair(field, "default", property.Value.Default)
+		}
+		fields.Content = append(fields.Content, field)
+	}
+	node := compiler.NewMappingNode()
+	appendPair(node, "type", avroPrimitive("record"))
+	appendPair(node, "name", compiler.NewScalarNodeForString(name))
+	c.appendNamespace(node, path)
+	if schema.Description != nil {
+		appendPair(node, "doc", compiler.NewScalarNodeForString(*schema.Description))
+	}
+	appendPair(node, "fields", fields)
+	return node
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Returns the fields of a BigQuery table schema for a schema that has been
+// dereferenced and flattened. The schema must be an object, and each of its
+// properties is a column. Nested objects are records, arrays are repeated
+// fields, and values that BigQuery can't describe are JSON columns.
+func (c *converter) bigQuery(schema *jsonschema.Schema) (*yaml.Node, error) {
+	if typeName, _ := typeForSchema(schema); typeName != "object" || schema.Properties == nil {
+		return nil, errors.New(fmt.Sprintf("schema %s is not an object with properties", c.name))
+	}
+	return c.bigQueryFields(schema, ""), nil
+}
+
+func (c *converter) bigQueryFields(schema *jsonschema.Schema, path string) *yaml.Node {
+	fields := compiler.NewSequenceNode()
+	for _, property := range *schema.Properties {
+		propertyPath := path + "/properties/" + escape(property.Name)
+		fieldName := validName(property.Name)
+		if fieldName != property.Name {
+			c.warn(propertyPath, "property is renamed %s", fieldName)
+		}
+		fieldType, nested, repeated := c.bigQueryType(property.Value, propertyPath)
+		_, nullable := typeForSchema(property.Value)
+		mode := "NULLABLE"
+		if repeated {
+			mode = "REPEATED"
+		} else if required(schema, property.Name) && !nullable {
+			mode = "REQUIRED"
+		}
+		field := compiler.NewMappingNode()
+		appendPair(field, "name", compiler.NewScalarNodeForString(fieldName))
+		appendPair(field, "type", compiler.NewScalarNodeForString(fieldType))
+		appendPair(field, "mode", compiler.NewScalarNodeForString(mode))
+		if property.Value != nil && property.Value.Description != nil {
+			appendPair(field, "description", compiler.NewScalarNodeForString(*property.Value.Description))
+		}
+		if nested != nil {
+			appendPair(field, "fields", nested)
+		}
+		fields.Content = append(fields.Content, field)
+	}
+	return fields
+}
+
+// Returns the BigQuery type of a schema, the fields of records, and whether
+// the schema is an array that is written as a repeated field.
+func (c *converter) bigQueryType(schema *jsonschema.Schema, path string) (string, *yaml.Node, bool) {
+	if schema == nil {
+		c.warn(path, "missing schema is written as JSON")
+		return "JSON", nil, false
+	}
+	if schema.Ref != nil {
+		c.warn(path, "recursive reference to %s is written as JSON", *schema.Ref)
+		return "JSON", nil, false
+	}
+	if schema.AnyOf != nil || schema.OneOf != nil || schema.AllOf != nil {
+		c.warn(path, "alternatives are written as JSON")
+		return "JSON", nil, false
+	}
+	typeName, _ := typeForSchema(schema)
+	switch typeName {
+	case "object":
+		if schema.Properties == nil || len(*schema.Properties) == 0 {
+			return "JSON", nil, false
+		}
+		return "RECORD", c.bigQueryFields(schema, path), false
+	case "array":
+		items := itemsSchema(schema)
+		if items == nil {
+			c.warn(path, "array without an items schema is written as JSON")
+			return "JSON", nil, false
+		}
+		if itemsType, _ := typeForSchema(items); itemsType == "array" {
+			c.warn(path, "array of arrays is written as JSON")
+			return "JSON", nil, false
+		}
+		fieldType, nested, _ := c.bigQueryType(items, path+"/items")
+		return fieldType, nested, true
+	case "string":
+		switch stringValue(schema.Format) {
+		case "date":
+			return "DATE", nil, false
+		case "date-time":
+			return "TIMESTAMP", nil, false
+		case "time":
+			return "TIME", nil, false
+		case "byte", "binary":
+			return "BYTES", nil, false
+		}
+		return "STRING", nil, false
+	case "integer":
+		return "INTEGER", nil, false
+	case "number":
+		return "FLOAT", nil, false
+	case "boolean":
+		return "BOOLEAN", nil, false
+	}
+	c.warn(path, "value of any type is written as JSON")
+	return "JSON", nil, false
+}
+
+func appendPair(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, compiler.NewScalarNodeForString(key), value)
+}
+
+func escape(name string) string {
+	return strings.Replace(strings.Replace(name, "~", "~0", -1), "/", "~1", -1)
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/gnostic/jsonschema"
+)
+
+// A converter writes schemas as the schemas of a data format. Schemas that
+// can't be expressed in the format are written as strings or JSON values,
+// and each change is recorded as a warning.
+type converter struct {
+	// The name of the schema being converted, which recursive references
+	// can refer to.
+	name string
+	// The namespace of Avro schemas.
+	namespace string
+	warnings  []string
+}
+
+func (c *converter) warn(path string, format string, args ...interface{}) {
+	if path == "" {
+		path = "/"
+	}
+	c.warnings = append(c.warnings, fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// Returns the type of a schema and whether it also accepts null. Types
+// are inferred from the keywords of schemas that don't specify them.
+func typeForSchema(schema *jsonschema.Schema) (string, bool) {
+	if schema == nil {
+		return "", false
+	}
+	types := make([]string, 0)
+	if schema.Type != nil {
+		if schema.Type.String != nil {
+			types = append(types, *schema.Type.String)
+		} else if schema.Type.StringArray != nil {
+			types = append(types, *schema.Type.StringArray...)
+		}
+	}
+	nullable := false
+	typeName := ""
+	for _, t := range types {
+		if t == "null" {
+			nullable = true
+		} else if typeName == "" {
+			typeName = t
+		} else if typeName == "integer" && t == "number" || typeName == "number" && t == "integer" {
+			typeName = "number"
+		} else {
+			typeName = "multiple"
+		}
+	}
+	if typeName == "" {
+		switch {
+		case schema.Properties != nil || schema.AdditionalProperties != nil:
+			typeName = "object"
+		case schema.Items != nil:
+			typeName = "array"
+		}
+	}
+	return typeName, nullable
+}
+
+// Returns the schema of the values of a map, or nil if a schema is not a map.
+func mapValues(schema *jsonschema.Schema) *jsonschema.Schema {
+	if schema.AdditionalProperties == nil || schema.AdditionalProperties.Schema == nil {
+		return nil
+	}
+	if schema.Properties != nil && len(*schema.Properties) > 0 {
+		return nil
+	}
+	return schema.AdditionalProperties.Schema
+}
+
+// Returns the schema of the items of an array.
+func itemsSchema(schema *jsonschema.Schema) *jsonschema.Schema {
+	if schema.Items == nil {
+		return nil
+	}
+	if schema.Items.Schema != nil {
+		return schema.Items.Schema
+	}
+	return nil
+}
+
+// Returns the values of an enum, if all of them are strings.
+func stringEnum(schema *jsonschema.Schema) []string {
+	if schema.Enumeration == nil {
+		return nil
+	}
+	values := make([]string, 0)
+	for _, value := range *schema.Enumeration {
+		if value.String == nil {
+			return nil
+		}
+		values = append(values, *value.String)
+	}
+	return values
+}
+
+func required(schema *jsonschema.Schema, name string) bool {
+	if schema.Required == nil {
+		return false
+	}
+	for _, r := range *schema.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+var invalidNameCharacters = regexp.MustCompile("[^A-Za-z0-9_]")
+
+// Returns a name that Avro and BigQuery accept, with invalid characters
+// replaced by underscores.
+func validName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// Returns a name in upper camel case for the records of nested objects.
+func typeName(parts ...string) string {
+	result := ""
+	for _, part := range parts {
+		for _, word := range invalidNameCharacters.Split(part, -1) {
+			if word != "" {
+				result += strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+	}
+	return validName(result)
+}
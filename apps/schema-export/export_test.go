@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const description = `
+definitions:
+  Pet:
+    type: object
+    required: [id, name]
+    properties:
+      id:
+        type: integer
+      name:
+        type: string
+      status:
+        type: string
+        enum: [available, sold]
+      owner:
+        $ref: '#/definitions/Owner'
+      parent:
+        $ref: '#/definitions/Pet'
+      tags:
+        type: array
+        items:
+          type: string
+  Owner:
+    type: object
+    properties:
+      since:
+        type: string
+        format: date
+`
+
+func exportPet(t *testing.T, format string) (interface{}, []string) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(description), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	c := &converter{name: "Pet"}
+	output, err := export(c, format, "#/definitions", mapValue(node.Content[0], "definitions"))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	var result interface{}
+	if err = json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return result, c.warnings
+}
+
+func TestAvro(t *testing.T) {
+	result, warnings := exportPet(t, "avro")
+	var expected interface{}
+	json.Unmarshal([]byte(`{
+  "type": "record",
+  "name": "Pet",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "name", "type": "string"},
+    {"name": "status", "type": ["null", {"type": "enum", "name": "PetStatus", "symbols": ["available", "sold"]}], "default": null},
+    {"name": "owner", "type": ["null", {"type": "record", "name": "PetOwner", "fields": [
+      {"name": "since", "type": ["null", {"type": "int", "logicalType": "date"}], "default": null}
+    ]}], "default": null},
+    {"name": "parent", "type": ["null", "Pet"], "default": null},
+    {"name": "tags", "type": ["null", {"type": "array", "items": "string"}], "default": null}
+  ]
+}`), &expected)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("unexpected Avro schema: %+v", result)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %+v", warnings)
+	}
+}
+
+func TestBigQuery(t *testing.T) {
+	result, warnings := exportPet(t, "bigquery")
+	var expected interface{}
+	json.Unmarshal([]byte(`[
+  {"name": "id", "type": "INTEGER", "mode": "REQUIRED"},
+  {"name": "name", "type": "STRING", "mode": "REQUIRED"},
+  {"name": "status", "type": "STRING", "mode": "NULLABLE"},
+  {"name": "owner", "type": "RECORD", "mode": "NULLABLE", "fields": [
+    {"name": "since", "type": "DATE", "mode": "NULLABLE"}
+  ]},
+  {"name": "parent", "type": "JSON", "mode": "NULLABLE"},
+  {"name": "tags", "type": "STRING", "mode": "REPEATED"}
+]`), &expected)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("unexpected BigQuery schema: %+v", result)
+	}
+	if len(warnings) != 1 || warnings[0] != "/properties/parent: recursive reference to #/definitions/Pet is written as JSON" {
+		t.Errorf("unexpected warnings: %+v", warnings)
+	}
+}
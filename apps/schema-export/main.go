@@ -0,0 +1,129 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// schema-export writes the named schemas of OpenAPI descriptions as Avro
+// schemas and BigQuery table schemas, so that the payloads of APIs can be
+// stored in data pipelines and warehouses with matching structures.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/googleapis/gnostic/jsonwriter"
+	"gopkg.in/yaml.v3"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] OPENAPI_FILE
+
+Writes named schemas of an OpenAPI 2.0 or 3.0 description as Avro
+schemas or BigQuery table schemas. References are inlined and allOfs
+are merged; values that can't be described in the target format are
+written as strings or JSON, and each change is reported as a warning.
+
+Options:
+  --format=FORMAT     avro or bigquery (required).
+  --schema=NAMES      Comma-separated names of the schemas to export (required).
+  --namespace=NAME    Namespace of Avro schemas.
+  --out=PATH          File to write, or with more than one schema, the directory
+                      to write NAME.avsc or NAME.json files to (default: standard
+                      output, for a single schema).
+`, path.Base(os.Args[0]))
+}
+
+// Returns the exported form of a schema.
+func export(c *converter, format string, prefix string, schemas *yaml.Node) ([]byte, error) {
+	schema, err := resolvedSchema(prefix, schemas, c.name)
+	if err != nil {
+		return nil, err
+	}
+	var node *yaml.Node
+	switch format {
+	case "avro":
+		node = c.avro(schema)
+	case "bigquery":
+		node, err = c.bigQuery(schema)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown format %s", format))
+	}
+	return jsonwriter.Marshal(node)
+}
+
+func main() {
+	format := flag.String("format", "", "avro or bigquery.")
+	schemaNames := flag.String("schema", "", "Comma-separated names of the schemas to export.")
+	namespace := flag.String("namespace", "", "Namespace of Avro schemas.")
+	out := flag.String("out", "", "File or directory to write.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 || *schemaNames == "" || (*format != "avro" && *format != "bigquery") {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+	names := strings.Split(*schemaNames, ",")
+	if len(names) > 1 && *out == "" {
+		fmt.Printf("--out is required to export more than one schema\n")
+		os.Exit(-1)
+	}
+
+	prefix, schemas, err := readSchemasFromFile(args[0])
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	extension := ".json"
+	if *format == "avro" {
+		extension = ".avsc"
+	}
+	for _, name := range names {
+		c := &converter{name: name, namespace: *namespace}
+		output, err := export(c, *format, prefix, schemas)
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+		for _, warning := range c.warnings {
+			fmt.Fprintf(os.Stderr, "WARNING %s%s\n", name, warning)
+		}
+		filename := *out
+		if len(names) > 1 {
+			if err = os.MkdirAll(*out, 0755); err != nil {
+				fmt.Printf("%+v\n", err)
+				os.Exit(-1)
+			}
+			filename = filepath.Join(*out, name+extension)
+		}
+		if filename == "" {
+			os.Stdout.Write(output)
+			continue
+		}
+		if err = ioutil.WriteFile(filename, output, 0644); err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// schema-infer writes a draft JSON schema that describes a set of example
+// JSON or YAML documents.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] EXAMPLE_FILE...
+
+Writes a draft JSON schema that accepts each of the example files.
+
+Options:
+  --enum=N  Describe string fields with at most N distinct values
+            with an enumeration (default 5, 0 to disable).
+`, path.Base(os.Args[0]))
+}
+
+func main() {
+	maxEnumValues := flag.Int("enum", 5, "Maximum number of distinct values to describe with an enumeration.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	if len(flag.Args()) == 0 {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+	examples := make([]*yaml.Node, 0)
+	for _, filename := range flag.Args() {
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			fmt.Printf("File error: %v\n", err)
+			os.Exit(1)
+		}
+		var example yaml.Node
+		err = yaml.Unmarshal(data, &example)
+		if err != nil {
+			fmt.Printf("Error reading %s: %v\n", filename, err)
+			os.Exit(1)
+		}
+		examples = append(examples, &example)
+	}
+	fmt.Println(jsonschema.InferSchema(examples, *maxEnumValues).JSONString())
+}
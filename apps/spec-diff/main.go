@@ -0,0 +1,241 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// spec-diff compares the schemas in two versions of an OpenAPI description
+// and reports changes that might break existing clients.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonschema"
+	"github.com/googleapis/gnostic/lib"
+	"gopkg.in/yaml.v3"
+)
+
+func usage() string {
+	return fmt.Sprintf(`
+Usage: %s [OPTIONS] OLD_OPENAPI_FILE NEW_OPENAPI_FILE
+
+Compares the schemas defined in two OpenAPI 2.0 or 3.0 descriptions.
+Exits with a nonzero status if any breaking changes are found.
+
+Options:
+  --breaking      Only report changes that might break existing clients.
+  --fields        Report every field that differs between the compiled
+                  descriptions, including changes outside of schemas.
+                  Named values are matched by name, so reordering them
+                  isn't a change.
+  --side-by-side  Show the old and new versions of changed operations and
+                  schemas side by side, with the changes to each schema.
+  --context=N     Number of unchanged lines shown around changes in the
+                  side-by-side view (default 3).
+  --width=N       Width of the side-by-side view (default: $COLUMNS or 160).
+  --color=WHEN    Color the side-by-side view: auto, always, or never
+                  (default auto, which colors output to terminals).
+`, path.Base(os.Args[0]))
+}
+
+// The parts of an OpenAPI description that are compared.
+type description struct {
+	// The location of the named schemas, such as "#/definitions".
+	prefix  string
+	schemas *yaml.Node
+	paths   *yaml.Node
+}
+
+// Returns the named schemas and paths of an OpenAPI description.
+func readDescriptionFromFile(filename string) (*description, error) {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		return nil, err
+	}
+	document, ok := compiler.UnpackMap(info)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("%s is not an OpenAPI description", filename))
+	}
+	paths, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "paths"))
+	if compiler.MapValueForKey(document, "swagger") != nil {
+		schemas, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "definitions"))
+		return &description{prefix: "#/definitions", schemas: schemas, paths: paths}, nil
+	}
+	if compiler.MapValueForKey(document, "openapi") != nil {
+		components, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "components"))
+		schemas, _ := compiler.UnpackMap(compiler.MapValueForKey(components, "schemas"))
+		return &description{prefix: "#/components/schemas", schemas: schemas, paths: paths}, nil
+	}
+	return nil, errors.New(fmt.Sprintf("unable to determine the OpenAPI version of %s", filename))
+}
+
+func schemaWithName(schemas *yaml.Node, name string) *jsonschema.Schema {
+	value := compiler.MapValueForKey(schemas, name)
+	if value == nil {
+		return nil
+	}
+	return jsonschema.NewSchemaFromObject(value)
+}
+
+// Returns the names of the keys of maps, in the order that they first appear.
+func keyNames(maps ...*yaml.Node) []string {
+	names := make([]string, 0)
+	seen := make(map[string]bool, 0)
+	for _, m := range maps {
+		if m == nil {
+			continue
+		}
+		for i := 0; i < len(m.Content); i += 2 {
+			name, ok := compiler.KeyForNode(m.Content[i])
+			if ok && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// Returns the changes between the named schemas of two OpenAPI descriptions.
+func diffSchemas(prefix string, oldSchemas *yaml.Node, newSchemas *yaml.Node) []*jsonschema.Change {
+	names := keyNames(oldSchemas, newSchemas)
+	changes := make([]*jsonschema.Change, 0)
+	for _, name := range names {
+		oldSchema := schemaWithName(oldSchemas, name)
+		if oldSchema == nil {
+			// adding a named schema doesn't affect existing messages
+			changes = append(changes, &jsonschema.Change{
+				Path:   prefix + "/" + name,
+				Kind:   jsonschema.KeywordAdded,
+				Effect: jsonschema.EffectNone,
+			})
+			continue
+		}
+		for _, change := range jsonschema.Diff(oldSchema, schemaWithName(newSchemas, name)) {
+			change.Path = prefix + "/" + name + change.Path
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// Returns the fields that differ between the models of two OpenAPI descriptions.
+func diffModels(oldFilename string, newFilename string) ([]*compiler.Difference, error) {
+	oldDocument, err := gnostic.ReadDocument(oldFilename)
+	if err != nil {
+		return nil, err
+	}
+	newDocument, err := gnostic.ReadDocument(newFilename)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case oldDocument.Version != newDocument.Version:
+		return nil, errors.New("unable to compare descriptions with different OpenAPI versions")
+	case oldDocument.Version == gnostic.OpenAPIv2:
+		return oldDocument.V2.Diff(newDocument.V2), nil
+	default:
+		return oldDocument.V3.Diff(newDocument.V3), nil
+	}
+}
+
+func main() {
+	breakingOnly := flag.Bool("breaking", false, "Only report breaking changes.")
+	fields := flag.Bool("fields", false, "Report every field that differs between the descriptions.")
+	sideBySide := flag.Bool("side-by-side", false, "Show changed operations and schemas side by side.")
+	context := flag.Int("context", 3, "Number of unchanged lines shown around changes.")
+	width := flag.Int("width", 0, "Width of the side-by-side view.")
+	color := flag.String("color", "auto", "Color the side-by-side view: auto, always, or never.")
+	flag.Usage = func() { fmt.Print(usage()) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 2 {
+		fmt.Print(usage())
+		os.Exit(-1)
+	}
+
+	if *fields {
+		differences, err := diffModels(args[0], args[1])
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+			os.Exit(-1)
+		}
+		for _, difference := range differences {
+			fmt.Printf("%s\n", difference)
+		}
+		return
+	}
+
+	oldDescription, err := readDescriptionFromFile(args[0])
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	newDescription, err := readDescriptionFromFile(args[1])
+	if err != nil {
+		fmt.Printf("%+v\n", err)
+		os.Exit(-1)
+	}
+	if oldDescription.prefix != newDescription.prefix {
+		fmt.Printf("Unable to compare descriptions with different OpenAPI versions.\n")
+		os.Exit(-1)
+	}
+
+	changes := diffSchemas(newDescription.prefix, oldDescription.schemas, newDescription.schemas)
+	breaking := jsonschema.BreakingChanges(changes)
+	if *breakingOnly {
+		changes = breaking
+	}
+	if *sideBySide {
+		v := &viewer{out: os.Stdout, width: *width, context: *context}
+		if v.width <= 0 {
+			v.width, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+		}
+		if v.width <= 0 {
+			v.width = 160
+		}
+		switch *color {
+		case "always":
+			v.color = true
+		case "never":
+			v.color = false
+		case "auto":
+			v.color = isTerminal(os.Stdout)
+		default:
+			fmt.Printf("Unknown --color value %q.\n", *color)
+			os.Exit(-1)
+		}
+		v.view(oldDescription, newDescription, changes, *breakingOnly)
+	} else {
+		for _, change := range changes {
+			if change.IsBreaking() {
+				fmt.Printf("BREAKING %s\n", change)
+			} else {
+				fmt.Printf("%s\n", change)
+			}
+		}
+	}
+	if len(breaking) > 0 {
+		os.Exit(1)
+	}
+}
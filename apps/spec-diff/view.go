@@ -0,0 +1,324 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Escape sequences that color the output of terminals.
+const (
+	colorReset = "\x1b[0m"
+	colorBold  = "\x1b[1m"
+	colorDim   = "\x1b[2m"
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorCyan  = "\x1b[36m"
+)
+
+// A viewer writes changed parts of descriptions side by side, with the old
+// version on the left and the new version on the right.
+type viewer struct {
+	out io.Writer
+	// Width is the total width of each line.
+	width int
+	// Context is the number of unchanged lines shown around each change.
+	context int
+	color   bool
+}
+
+// The kinds of lines in a line diff.
+const (
+	lineEqual = iota
+	lineDeleted
+	lineInserted
+)
+
+type diffLine struct {
+	kind int
+	text string
+}
+
+// Returns the differences between two lists of lines, computed from their
+// longest common subsequence. Descriptions of schemas and operations are
+// short enough that the quadratic table doesn't matter.
+func diffLines(a, b []string) []diffLine {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	lines := make([]diffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{lineEqual, a[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			lines = append(lines, diffLine{lineDeleted, a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{lineInserted, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{lineDeleted, a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{lineInserted, b[j]})
+	}
+	return lines
+}
+
+// A row of a side-by-side view. Line numbers are zero on sides without
+// a line.
+type row struct {
+	oldNumber, newNumber int
+	oldText, newText     string
+	changed              bool
+}
+
+// Returns the rows of a side-by-side view of a line diff. Runs of deleted
+// and inserted lines are paired, so that replaced lines appear together.
+func sideBySideRows(lines []diffLine) []row {
+	rows := make([]row, 0, len(lines))
+	oldNumber, newNumber := 0, 0
+	for i := 0; i < len(lines); {
+		if lines[i].kind == lineEqual {
+			oldNumber++
+			newNumber++
+			rows = append(rows, row{oldNumber, newNumber, lines[i].text, lines[i].text, false})
+			i++
+			continue
+		}
+		deleted, inserted := make([]string, 0), make([]string, 0)
+		for ; i < len(lines) && lines[i].kind != lineEqual; i++ {
+			if lines[i].kind == lineDeleted {
+				deleted = append(deleted, lines[i].text)
+			} else {
+				inserted = append(inserted, lines[i].text)
+			}
+		}
+		for k := 0; k < len(deleted) || k < len(inserted); k++ {
+			r := row{changed: true}
+			if k < len(deleted) {
+				oldNumber++
+				r.oldNumber, r.oldText = oldNumber, deleted[k]
+			}
+			if k < len(inserted) {
+				newNumber++
+				r.newNumber, r.newText = newNumber, inserted[k]
+			}
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// Returns the text wrapped in an escape sequence if colors are enabled.
+func (v *viewer) paint(color, text string) string {
+	if !v.color || text == "" {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// Returns text truncated or padded to a width in runes.
+func fit(text string, width int) string {
+	text = strings.Replace(text, "\t", "    ", -1)
+	if n := utf8.RuneCountInString(text); n > width {
+		runes := []rune(text)
+		if width < 1 {
+			return ""
+		}
+		return string(runes[:width-1]) + "…"
+	} else if n < width {
+		return text + strings.Repeat(" ", width-n)
+	}
+	return text
+}
+
+// Header writes the title of a changed part of the descriptions.
+func (v *viewer) header(title string) {
+	title = "── " + title + " "
+	if n := v.width - 1 - utf8.RuneCountInString(title); n > 0 {
+		title += strings.Repeat("─", n)
+	}
+	fmt.Fprintf(v.out, "\n%s\n", v.paint(colorBold+colorCyan, title))
+}
+
+// Note writes a line that explains a change, such as a classified schema change.
+func (v *viewer) note(breaking bool, text string) {
+	if breaking {
+		fmt.Fprintf(v.out, "  %s %s\n", v.paint(colorBold+colorRed, "BREAKING"), text)
+	} else {
+		fmt.Fprintf(v.out, "  %s\n", text)
+	}
+}
+
+// Compare writes the old and new versions of some text side by side,
+// showing the context of each change. Either version may be empty, as
+// they are for added and removed parts.
+func (v *viewer) compare(oldText, newText string) {
+	rows := sideBySideRows(diffLines(splitLines(oldText), splitLines(newText)))
+	// each side has a line number, a space, the text, and the separator
+	columnWidth := (v.width - 3) / 2
+	textWidth := columnWidth - 5
+	if textWidth < 10 {
+		textWidth = 10
+	}
+	visible := make([]bool, len(rows))
+	for i, r := range rows {
+		if !r.changed {
+			continue
+		}
+		for j := i - v.context; j <= i+v.context; j++ {
+			if j >= 0 && j < len(rows) {
+				visible[j] = true
+			}
+		}
+	}
+	gap := false
+	for i, r := range rows {
+		if !visible[i] {
+			gap = true
+			continue
+		}
+		if gap {
+			fmt.Fprintf(v.out, "%s\n", v.paint(colorDim, fit("   ⋮", textWidth+5)+" │"))
+			gap = false
+		}
+		left := fit(r.oldText, textWidth)
+		right := strings.TrimRight(fit(r.newText, textWidth), " ")
+		if r.changed {
+			left, right = v.paint(colorRed, left), v.paint(colorGreen, right)
+		}
+		fmt.Fprintf(v.out, "%s %s %s %s %s\n",
+			v.paint(colorDim, lineNumber(r.oldNumber)), left,
+			v.paint(colorDim, "│"),
+			v.paint(colorDim, lineNumber(r.newNumber)), right)
+	}
+}
+
+func lineNumber(n int) string {
+	if n == 0 {
+		return "    "
+	}
+	return fmt.Sprintf("%4d", n)
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// The methods of operations in path items, in the order that they are shown.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Returns YAML text for a node, or "" if it is missing.
+func text(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+	return string(compiler.Marshal(node))
+}
+
+// Returns the state of a part that is in either or both versions.
+func state(oldText, newText string) string {
+	switch {
+	case oldText == "":
+		return "added"
+	case newText == "":
+		return "removed"
+	}
+	return "changed"
+}
+
+// View writes the operations and schemas that differ between two
+// descriptions side by side. Each schema is followed by its classified
+// changes. If breakingOnly is set, only the schemas with breaking changes
+// are shown, since changes to operations aren't classified.
+func (v *viewer) view(oldDescription, newDescription *description, changes []*jsonschema.Change, breakingOnly bool) {
+	shown := 0
+	if !breakingOnly {
+		for _, path := range keyNames(oldDescription.paths, newDescription.paths) {
+			oldItem := compiler.MapValueForKey(oldDescription.paths, path)
+			newItem := compiler.MapValueForKey(newDescription.paths, path)
+			for _, method := range methods {
+				oldText := text(compiler.MapValueForKey(oldItem, method))
+				newText := text(compiler.MapValueForKey(newItem, method))
+				if oldText == newText {
+					continue
+				}
+				v.header(fmt.Sprintf("%s %s (%s)", strings.ToUpper(method), path, state(oldText, newText)))
+				v.compare(oldText, newText)
+				shown++
+			}
+		}
+	}
+	for _, name := range keyNames(oldDescription.schemas, newDescription.schemas) {
+		path := newDescription.prefix + "/" + name
+		schemaChanges := make([]*jsonschema.Change, 0)
+		for _, change := range changes {
+			if change.Path == path || strings.HasPrefix(change.Path, path+"/") {
+				schemaChanges = append(schemaChanges, change)
+			}
+		}
+		oldText := text(compiler.MapValueForKey(oldDescription.schemas, name))
+		newText := text(compiler.MapValueForKey(newDescription.schemas, name))
+		if oldText == newText || breakingOnly && len(schemaChanges) == 0 {
+			continue
+		}
+		v.header(fmt.Sprintf("schema %s (%s)", name, state(oldText, newText)))
+		for _, change := range schemaChanges {
+			v.note(change.IsBreaking(), change.String())
+		}
+		v.compare(oldText, newText)
+		shown++
+	}
+	if shown == 0 {
+		fmt.Fprintf(v.out, "No changes.\n")
+	}
+}
+
+// Returns true if a file is a terminal, which colored output can be written to.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
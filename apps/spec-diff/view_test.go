@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSideBySideRows(t *testing.T) {
+	rows := sideBySideRows(diffLines(
+		[]string{"a", "b", "c", "d"},
+		[]string{"a", "B", "c", "d", "e"}))
+	expected := []row{
+		{1, 1, "a", "a", false},
+		{2, 2, "b", "B", true},
+		{3, 3, "c", "c", false},
+		{4, 4, "d", "d", false},
+		{0, 5, "", "e", true},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("Unexpected rows: %+v", rows)
+	}
+	for i := range rows {
+		if rows[i] != expected[i] {
+			t.Errorf("Row %d: expected %+v, got %+v", i, expected[i], rows[i])
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	var buffer bytes.Buffer
+	v := &viewer{out: &buffer, width: 43, context: 1}
+	v.compare("one\ntwo\nthree\nfour\nfive\n", "one\ntwo\nthree\nfour\nFIVE\n")
+	expected := `   ⋮                 │
+   4 four            │    4 four
+   5 five            │    5 FIVE
+`
+	if buffer.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buffer.String())
+	}
+
+	buffer.Reset()
+	v.color = true
+	v.compare("", "added\n")
+	if !strings.Contains(buffer.String(), colorGreen+"added"+colorReset) {
+		t.Errorf("Expected colored insertion, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+// The benchmark corpus is the examples directory, along with any
+// descriptions in the directories listed in GNOSTIC_BENCHMARK_CORPUS.
+// Larger real-world descriptions can be added this way to measure the
+// compiler on inputs that are too big to keep in the repository. See the
+// README for an example.
+func benchmarkCorpus(b *testing.B, extensions ...string) []string {
+	dirs := []string{
+		"examples/v2.0/json",
+		"examples/v2.0/yaml",
+		"examples/v3.0/json",
+		"examples/v3.0/yaml",
+	}
+	if corpus := os.Getenv("GNOSTIC_BENCHMARK_CORPUS"); corpus != "" {
+		dirs = append(dirs, filepath.SplitList(corpus)...)
+	}
+	filenames := make([]string, 0)
+	for _, dir := range dirs {
+		for _, extension := range extensions {
+			matches, err := filepath.Glob(filepath.Join(dir, "*"+extension))
+			if err != nil {
+				b.Fatalf("%s", err.Error())
+			}
+			filenames = append(filenames, matches...)
+		}
+	}
+	sort.Strings(filenames)
+	return filenames
+}
+
+// Returns the name of the sub-benchmark for a file in the corpus.
+func benchmarkName(filename string) string {
+	return strings.Replace(filepath.ToSlash(filename), "/", "_", -1)
+}
+
+func benchmarkFiles(b *testing.B, filenames []string, run func(b *testing.B, g *Gnostic, bytes []byte)) {
+	for _, filename := range filenames {
+		bytes, err := ioutil.ReadFile(filename)
+		if err != nil {
+			b.Fatalf("%s", err.Error())
+		}
+		b.Run(benchmarkName(filename), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bytes)))
+			for i := 0; i < b.N; i++ {
+				// each compilation starts with an empty cache
+				g := newGnostic()
+				g.sourceName = filename
+				run(b, g, bytes)
+			}
+		})
+	}
+}
+
+// BenchmarkCompile measures parsing and compiling descriptions into models.
+func BenchmarkCompile(b *testing.B) {
+	benchmarkFiles(b, benchmarkCorpus(b, ".json", ".yaml"), func(b *testing.B, g *Gnostic, bytes []byte) {
+		if _, err := g.readOpenAPIText(bytes); err != nil {
+			b.Fatalf("%s: %s", g.sourceName, err.Error())
+		}
+	})
+}
+
+// BenchmarkCompileStream measures compiling JSON descriptions in chunks as
+// they are read from their files.
+func BenchmarkCompileStream(b *testing.B) {
+	benchmarkFiles(b, benchmarkCorpus(b, ".json"), func(b *testing.B, g *Gnostic, bytes []byte) {
+		if _, err := g.readOpenAPIJSONInChunks(); err != nil {
+			b.Fatalf("%s: %s", g.sourceName, err.Error())
+		}
+	})
+}
+
+// BenchmarkResolveReferences measures compiling descriptions and resolving their references.
+func BenchmarkResolveReferences(b *testing.B) {
+	filenames := append(benchmarkCorpus(b, ".json", ".yaml"),
+		"examples/v2.0/json/petstore-separate/spec/swagger.json",
+		"examples/v2.0/yaml/petstore-separate/spec/swagger.yaml")
+	benchmarkFiles(b, filenames, func(b *testing.B, g *Gnostic, bytes []byte) {
+		g.resolveReferences = true
+		message, err := g.readOpenAPIText(bytes)
+		if err != nil {
+			b.Fatalf("%s: %s", g.sourceName, err.Error())
+		}
+		if err = g.performActions(message); err != nil {
+			b.Fatalf("%s: %s", g.sourceName, err.Error())
+		}
+	})
+}
+
+// BenchmarkCompileWithArena measures compiling descriptions with messages allocated from an arena.
+func BenchmarkCompileWithArena(b *testing.B) {
+	arenaV2 := openapi_v2.NewArena(0)
+	arenaV3 := openapi_v3.NewArena(0)
+	benchmarkFiles(b, benchmarkCorpus(b, ".json", ".yaml"), func(b *testing.B, g *Gnostic, bytes []byte) {
+		info, err := compiler.ReadInfoFromBytesWithOptions(g.sourceName, bytes, g.compilerOptions)
+		if err != nil {
+			b.Fatalf("%s: %s", g.sourceName, err.Error())
+		}
+		context := compiler.NewContext("$root", nil)
+		version, _ := getOpenAPIVersionFromInfo(info)
+		switch version {
+		case OpenAPIv2:
+			context.Allocator = arenaV2
+			_, err = openapi_v2.NewDocument(info, context)
+			arenaV2.Reset()
+		case OpenAPIv3:
+			context.Allocator = arenaV3
+			_, err = openapi_v3.NewDocument(info, context)
+			arenaV3.Reset()
+		}
+		if err != nil {
+			b.Fatalf("%s: %s", g.sourceName, err.Error())
+		}
+	})
+}
@@ -0,0 +1,350 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package builder_v2 builds OpenAPI 2.0 descriptions in Go code.
+//
+// Builders assemble descriptions — info, paths, operations, parameters,
+// responses, schemas, security schemes and requirements, tags, and vendor
+// extensions — with chained calls, and produce a model that can be written
+// as YAML or JSON or used like any other model compiled by gnostic. Fields
+// that builders don't set can be set directly on the models returned by
+// Build, or on models passed to builders, using the SetX and AddX methods
+// of the model types.
+package builder_v2
+
+import (
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+)
+
+// DocumentBuilder builds an OpenAPI 2.0 document.
+type DocumentBuilder struct {
+	document *openapi_v2.Document
+}
+
+// NewDocument creates a DocumentBuilder for an API with a title and version.
+func NewDocument(title, version string) *DocumentBuilder {
+	return &DocumentBuilder{
+		document: (&openapi_v2.Document{}).
+			SetSwagger("2.0").
+			SetInfo((&openapi_v2.Info{}).SetTitle(title).SetVersion(version)).
+			SetPaths(&openapi_v2.Paths{}),
+	}
+}
+
+// Description sets the description of the API.
+func (b *DocumentBuilder) Description(description string) *DocumentBuilder {
+	b.document.Info.SetDescription(description)
+	return b
+}
+
+// Host sets the host that serves the API.
+func (b *DocumentBuilder) Host(host string) *DocumentBuilder {
+	b.document.SetHost(host)
+	return b
+}
+
+// BasePath sets the path of the API relative to its host.
+func (b *DocumentBuilder) BasePath(basePath string) *DocumentBuilder {
+	b.document.SetBasePath(basePath)
+	return b
+}
+
+// Schemes adds transfer protocols of the API, such as "https".
+func (b *DocumentBuilder) Schemes(schemes ...string) *DocumentBuilder {
+	b.document.AddSchemes(schemes...)
+	return b
+}
+
+// Consumes adds media types that operations can consume.
+func (b *DocumentBuilder) Consumes(mediaTypes ...string) *DocumentBuilder {
+	b.document.AddConsumes(mediaTypes...)
+	return b
+}
+
+// Produces adds media types that operations can produce.
+func (b *DocumentBuilder) Produces(mediaTypes ...string) *DocumentBuilder {
+	b.document.AddProduces(mediaTypes...)
+	return b
+}
+
+// Returns the item for a path, adding it if it doesn't exist.
+func (b *DocumentBuilder) pathItem(path string) *openapi_v2.PathItem {
+	if item := b.document.Paths.Get(path); item != nil {
+		return item
+	}
+	item := &openapi_v2.PathItem{}
+	b.document.Paths.AddPath(path, item)
+	return item
+}
+
+// Get adds a GET operation for a path.
+func (b *DocumentBuilder) Get(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetGet(operation.Build())
+	return b
+}
+
+// Put adds a PUT operation for a path.
+func (b *DocumentBuilder) Put(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetPut(operation.Build())
+	return b
+}
+
+// Post adds a POST operation for a path.
+func (b *DocumentBuilder) Post(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetPost(operation.Build())
+	return b
+}
+
+// Delete adds a DELETE operation for a path.
+func (b *DocumentBuilder) Delete(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetDelete(operation.Build())
+	return b
+}
+
+// Options adds an OPTIONS operation for a path.
+func (b *DocumentBuilder) Options(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetOptions(operation.Build())
+	return b
+}
+
+// Head adds a HEAD operation for a path.
+func (b *DocumentBuilder) Head(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetHead(operation.Build())
+	return b
+}
+
+// Patch adds a PATCH operation for a path.
+func (b *DocumentBuilder) Patch(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetPatch(operation.Build())
+	return b
+}
+
+// Definition adds a schema to the definitions of the document,
+// where it can be referred to with Ref.
+func (b *DocumentBuilder) Definition(name string, schema *openapi_v2.Schema) *DocumentBuilder {
+	if b.document.Definitions == nil {
+		b.document.SetDefinitions(&openapi_v2.Definitions{})
+	}
+	b.document.Definitions.AddAdditionalProperties(name, schema)
+	return b
+}
+
+// Build returns the document.
+func (b *DocumentBuilder) Build() *openapi_v2.Document {
+	return b.document
+}
+
+// YAML returns the document as YAML.
+func (b *DocumentBuilder) YAML() []byte {
+	return compiler.Marshal(b.document.ToRawInfo())
+}
+
+// JSON returns the document as JSON.
+func (b *DocumentBuilder) JSON() ([]byte, error) {
+	return jsonwriter.Marshal(b.document.ToRawInfo())
+}
+
+// OperationBuilder builds an operation.
+type OperationBuilder struct {
+	operation *openapi_v2.Operation
+}
+
+// NewOperation creates an OperationBuilder for an operation with an id.
+func NewOperation(operationID string) *OperationBuilder {
+	return &OperationBuilder{
+		operation: (&openapi_v2.Operation{}).
+			SetOperationId(operationID).
+			SetResponses(&openapi_v2.Responses{}),
+	}
+}
+
+// Summary sets the summary of the operation.
+func (b *OperationBuilder) Summary(summary string) *OperationBuilder {
+	b.operation.SetSummary(summary)
+	return b
+}
+
+// Description sets the description of the operation.
+func (b *OperationBuilder) Description(description string) *OperationBuilder {
+	b.operation.SetDescription(description)
+	return b
+}
+
+// Tags adds tags to the operation.
+func (b *OperationBuilder) Tags(tags ...string) *OperationBuilder {
+	b.operation.AddTags(tags...)
+	return b
+}
+
+// Deprecated marks the operation as deprecated.
+func (b *OperationBuilder) Deprecated() *OperationBuilder {
+	b.operation.SetDeprecated(true)
+	return b
+}
+
+// Adds a parameter that isn't in the body of requests.
+func (b *OperationBuilder) addNonBodyParameter(parameter *openapi_v2.NonBodyParameter) *OperationBuilder {
+	b.operation.AddParameters(openapi_v2.NewParametersItemWithParameter(
+		openapi_v2.NewParameterWithNonBodyParameter(parameter)))
+	return b
+}
+
+// QueryParameter adds a query parameter with a primitive type, such as "integer".
+func (b *OperationBuilder) QueryParameter(name, typeName, description string, required bool) *OperationBuilder {
+	return b.addNonBodyParameter(openapi_v2.NewNonBodyParameterWithQueryParameterSubSchema(
+		(&openapi_v2.QueryParameterSubSchema{}).
+			SetName(name).
+			SetIn("query").
+			SetType(typeName).
+			SetDescription(description).
+			SetRequired(required)))
+}
+
+// PathParameter adds a path parameter with a primitive type. Path parameters are required.
+func (b *OperationBuilder) PathParameter(name, typeName, description string) *OperationBuilder {
+	return b.addNonBodyParameter(openapi_v2.NewNonBodyParameterWithPathParameterSubSchema(
+		(&openapi_v2.PathParameterSubSchema{}).
+			SetName(name).
+			SetIn("path").
+			SetType(typeName).
+			SetDescription(description).
+			SetRequired(true)))
+}
+
+// HeaderParameter adds a header parameter with a primitive type.
+func (b *OperationBuilder) HeaderParameter(name, typeName, description string, required bool) *OperationBuilder {
+	return b.addNonBodyParameter(openapi_v2.NewNonBodyParameterWithHeaderParameterSubSchema(
+		(&openapi_v2.HeaderParameterSubSchema{}).
+			SetName(name).
+			SetIn("header").
+			SetType(typeName).
+			SetDescription(description).
+			SetRequired(required)))
+}
+
+// BodyParameter adds a required parameter for the body of requests.
+func (b *OperationBuilder) BodyParameter(name, description string, schema *openapi_v2.Schema) *OperationBuilder {
+	b.operation.AddParameters(openapi_v2.NewParametersItemWithParameter(
+		openapi_v2.NewParameterWithBodyParameter(
+			(&openapi_v2.BodyParameter{}).
+				SetName(name).
+				SetIn("body").
+				SetDescription(description).
+				SetRequired(true).
+				SetSchema(schema))))
+	return b
+}
+
+// Response adds a response for a status code, such as "200" or "default".
+// The schema of the response may be nil.
+func (b *OperationBuilder) Response(code, description string, schema *openapi_v2.Schema) *OperationBuilder {
+	response := (&openapi_v2.Response{}).SetDescription(description)
+	if schema != nil {
+		response.SetSchema(openapi_v2.NewSchemaItemWithSchema(schema))
+	}
+	b.operation.Responses.AddResponseCode(code, openapi_v2.NewResponseValueWithResponse(response))
+	return b
+}
+
+// Build returns the operation.
+func (b *OperationBuilder) Build() *openapi_v2.Operation {
+	return b.operation
+}
+
+// Ref returns a schema that refers to a definition of the document.
+func Ref(name string) *openapi_v2.Schema {
+	return (&openapi_v2.Schema{}).SetXRef("#/definitions/" + name)
+}
+
+// Scalar returns a schema for a primitive type and an optional format,
+// such as "integer" and "int64".
+func Scalar(typeName, format string) *openapi_v2.Schema {
+	return (&openapi_v2.Schema{}).
+		SetType(&openapi_v2.TypeItem{Value: []string{typeName}}).
+		SetFormat(format)
+}
+
+// Array returns a schema for an array of items.
+func Array(items *openapi_v2.Schema) *openapi_v2.Schema {
+	return (&openapi_v2.Schema{}).
+		SetType(&openapi_v2.TypeItem{Value: []string{"array"}}).
+		SetItems(&openapi_v2.ItemsItem{Schema: []*openapi_v2.Schema{items}})
+}
+
+// Enum returns a schema for a primitive type with a list of allowed values.
+func Enum(typeName string, values ...interface{}) *openapi_v2.Schema {
+	schema := Scalar(typeName, "")
+	for _, value := range values {
+		schema.AddEnum(Value(value))
+	}
+	return schema
+}
+
+// Map returns a schema for an object with any properties that match a schema.
+func Map(values *openapi_v2.Schema) *openapi_v2.Schema {
+	return (&openapi_v2.Schema{}).
+		SetType(&openapi_v2.TypeItem{Value: []string{"object"}}).
+		SetAdditionalProperties(openapi_v2.NewAdditionalPropertiesItemWithSchema(values))
+}
+
+// AllOf returns a schema that combines other schemas, which are often
+// references to definitions that are extended.
+func AllOf(schemas ...*openapi_v2.Schema) *openapi_v2.Schema {
+	return (&openapi_v2.Schema{}).AddAllOf(schemas...)
+}
+
+// ObjectBuilder builds a schema for an object.
+type ObjectBuilder struct {
+	schema *openapi_v2.Schema
+}
+
+// NewObject creates an ObjectBuilder.
+func NewObject() *ObjectBuilder {
+	return &ObjectBuilder{
+		schema: (&openapi_v2.Schema{}).
+			SetType(&openapi_v2.TypeItem{Value: []string{"object"}}).
+			SetProperties(&openapi_v2.Properties{}),
+	}
+}
+
+// Description sets the description of the object.
+func (b *ObjectBuilder) Description(description string) *ObjectBuilder {
+	b.schema.SetDescription(description)
+	return b
+}
+
+// Property adds an optional property to the object.
+func (b *ObjectBuilder) Property(name string, schema *openapi_v2.Schema) *ObjectBuilder {
+	b.schema.Properties.AddAdditionalProperties(name, schema)
+	return b
+}
+
+// RequiredProperty adds a required property to the object.
+func (b *ObjectBuilder) RequiredProperty(name string, schema *openapi_v2.Schema) *ObjectBuilder {
+	b.schema.AddRequired(name)
+	return b.Property(name, schema)
+}
+
+// Extension adds a vendor extension to the object.
+func (b *ObjectBuilder) Extension(name string, value interface{}) *ObjectBuilder {
+	b.schema.AddVendorExtension(name, Value(value))
+	return b
+}
+
+// Build returns the schema.
+func (b *ObjectBuilder) Build() *openapi_v2.Schema {
+	return b.schema
+}
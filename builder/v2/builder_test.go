@@ -0,0 +1,106 @@
+package builder_v2
+
+import (
+	"testing"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func TestBuilder(t *testing.T) {
+	pet := NewObject().
+		RequiredProperty("id", Scalar("integer", "int64")).
+		RequiredProperty("name", Scalar("string", "")).
+		Property("tag", Scalar("string", "")).
+		Build()
+	builder := NewDocument("Swagger Petstore", "1.0.0").
+		Host("petstore.swagger.io").
+		BasePath("/v1").
+		Schemes("http").
+		Consumes("application/json").
+		Produces("application/json").
+		Definition("Pet", pet).
+		Definition("Pets", Array(Ref("Pet"))).
+		Get("/pets", NewOperation("listPets").
+			Summary("List all pets").
+			Tags("pets").
+			QueryParameter("limit", "integer", "How many items to return at one time (max 100)", false).
+			Response("200", "An paged array of pets", Ref("Pets"))).
+		Post("/pets", NewOperation("createPets").
+			BodyParameter("pet", "The pet to create", Ref("Pet")).
+			Response("201", "Null response", nil)).
+		Get("/pets/{petId}", NewOperation("showPetById").
+			PathParameter("petId", "string", "The id of the pet to retrieve").
+			Response("200", "Expected response to a valid request", Ref("Pets")))
+
+	info, err := compiler.ReadInfoFromBytes("", builder.YAML())
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Built document is invalid: %+v", err)
+	}
+	pets := document.Paths.Get("/pets")
+	if pets == nil || pets.Get.OperationId != "listPets" || pets.Post.OperationId != "createPets" {
+		t.Errorf("Unexpected operations: %+v", pets)
+	}
+	if schema := document.Definitions.AdditionalProperties[0].Value; len(schema.Required) != 2 {
+		t.Errorf("Unexpected definition: %+v", schema)
+	}
+	if _, err = builder.JSON(); err != nil {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+}
+
+func TestComponents(t *testing.T) {
+	builder := NewDocument("Petstore", "1.0.0").
+		TermsOfService("https://example.com/terms").
+		Contact("API Support", "https://example.com/support", "support@example.com").
+		License("MIT", "").
+		Tag("pets", "Everything about pets").
+		Extension("x-audience", "public").
+		SecurityDefinition("api_key", APIKeySecurity("api_key", "header", "")).
+		SecurityDefinition("oauth", OAuth2ImplicitSecurity("https://example.com/oauth", map[string]string{
+			"write:pets": "modify pets",
+			"read:pets":  "read pets",
+		})).
+		Security(Requirement("api_key")).
+		Definition("Pet", NewObject().RequiredProperty("status", Enum("string", "available", "sold")).Build()).
+		Parameter("petId", openapi_v2.NewParameterWithNonBodyParameter(
+			openapi_v2.NewNonBodyParameterWithPathParameterSubSchema(
+				(&openapi_v2.PathParameterSubSchema{}).SetName("petId").SetIn("path").SetRequired(true).SetType("string")))).
+		Response("NotFound", NewResponse("Not found")).
+		Path("/pets/{petId}", NewPathItem().
+			ParameterRef("petId").
+			Get(NewOperation("showPetById").
+				Security(Requirement("oauth", "read:pets")).
+				AddResponse("200", NewResponse("A pet").
+					Schema(Ref("Pet")).
+					Header("x-rate-limit", "integer", "Requests left")).
+				ResponseRef("404", "NotFound")))
+
+	info, err := compiler.ReadInfoFromBytes("", builder.YAML())
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Built document is invalid: %+v", err)
+	}
+	if document.Info.License.Name != "MIT" || len(document.Tags) != 1 || len(document.VendorExtension) != 1 {
+		t.Errorf("Unexpected info: %+v", document.Info)
+	}
+	schemes := document.SecurityDefinitions.AdditionalProperties
+	if len(schemes) != 2 || schemes[1].Value.GetOauth2ImplicitSecurity().Scopes.AdditionalProperties[0].Name != "read:pets" {
+		t.Errorf("Unexpected security definitions: %+v", schemes)
+	}
+	item := document.Paths.Get("/pets/{petId}")
+	if item == nil || item.Parameters[0].GetJsonReference().XRef != "#/parameters/petId" {
+		t.Fatalf("Unexpected path item: %+v", item)
+	}
+	responses := item.Get.Responses.ResponseCode
+	if len(responses) != 2 || responses[1].Value.GetJsonReference().XRef != "#/responses/NotFound" {
+		t.Errorf("Unexpected responses: %+v", responses)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder_v2
+
+import (
+	"sort"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"gopkg.in/yaml.v3"
+)
+
+// TermsOfService sets the URL of the terms of service of the API.
+func (b *DocumentBuilder) TermsOfService(url string) *DocumentBuilder {
+	b.document.Info.SetTermsOfService(url)
+	return b
+}
+
+// Contact sets the contact information of the API.
+func (b *DocumentBuilder) Contact(name, url, email string) *DocumentBuilder {
+	b.document.Info.SetContact((&openapi_v2.Contact{}).SetName(name).SetUrl(url).SetEmail(email))
+	return b
+}
+
+// License sets the license of the API.
+func (b *DocumentBuilder) License(name, url string) *DocumentBuilder {
+	b.document.Info.SetLicense((&openapi_v2.License{}).SetName(name).SetUrl(url))
+	return b
+}
+
+// Tag adds a tag that describes the operations that use it.
+func (b *DocumentBuilder) Tag(name, description string) *DocumentBuilder {
+	b.document.AddTags((&openapi_v2.Tag{}).SetName(name).SetDescription(description))
+	return b
+}
+
+// ExternalDocs sets the location of external documentation of the API.
+func (b *DocumentBuilder) ExternalDocs(url, description string) *DocumentBuilder {
+	b.document.SetExternalDocs((&openapi_v2.ExternalDocs{}).SetUrl(url).SetDescription(description))
+	return b
+}
+
+// Extension adds a vendor extension, such as "x-logo", to the document.
+// The value is written as it would be by a YAML encoder.
+func (b *DocumentBuilder) Extension(name string, value interface{}) *DocumentBuilder {
+	b.document.AddVendorExtension(name, Value(value))
+	return b
+}
+
+// Path adds a path item, replacing any operations that were added for the path.
+func (b *DocumentBuilder) Path(path string, item *PathItemBuilder) *DocumentBuilder {
+	b.document.Paths.SetPath(path, item.Build())
+	return b
+}
+
+// Parameter adds a parameter to the parameter definitions of the document,
+// where it can be referred to with ParameterRef.
+func (b *DocumentBuilder) Parameter(name string, parameter *openapi_v2.Parameter) *DocumentBuilder {
+	if b.document.Parameters == nil {
+		b.document.SetParameters(&openapi_v2.ParameterDefinitions{})
+	}
+	b.document.Parameters.AddAdditionalProperties(name, parameter)
+	return b
+}
+
+// Response adds a response to the response definitions of the document,
+// where it can be referred to with ResponseRef.
+func (b *DocumentBuilder) Response(name string, response *ResponseBuilder) *DocumentBuilder {
+	if b.document.Responses == nil {
+		b.document.SetResponses(&openapi_v2.ResponseDefinitions{})
+	}
+	b.document.Responses.AddAdditionalProperties(name, response.Build())
+	return b
+}
+
+// SecurityDefinition adds a security scheme that requirements can refer to
+// by name. Schemes are created with BasicSecurity, APIKeySecurity, and the
+// OAuth2 functions.
+func (b *DocumentBuilder) SecurityDefinition(name string, scheme *openapi_v2.SecurityDefinitionsItem) *DocumentBuilder {
+	if b.document.SecurityDefinitions == nil {
+		b.document.SetSecurityDefinitions(&openapi_v2.SecurityDefinitions{})
+	}
+	b.document.SecurityDefinitions.AddAdditionalProperties(name, scheme)
+	return b
+}
+
+// Security adds alternative security requirements for all operations.
+func (b *DocumentBuilder) Security(requirements ...*openapi_v2.SecurityRequirement) *DocumentBuilder {
+	b.document.AddSecurity(requirements...)
+	return b
+}
+
+// Requirement returns a security requirement for a scheme with a list of
+// the scopes that it requires. Any other schemes that are required together
+// with it can be added to the requirement with AddAdditionalProperties.
+func Requirement(name string, scopes ...string) *openapi_v2.SecurityRequirement {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return (&openapi_v2.SecurityRequirement{}).
+		AddAdditionalProperties(name, &openapi_v2.StringArray{Value: scopes})
+}
+
+// BasicSecurity returns a scheme for HTTP basic authentication.
+func BasicSecurity(description string) *openapi_v2.SecurityDefinitionsItem {
+	return openapi_v2.NewSecurityDefinitionsItemWithBasicAuthenticationSecurity(
+		(&openapi_v2.BasicAuthenticationSecurity{}).SetType("basic").SetDescription(description))
+}
+
+// APIKeySecurity returns a scheme for API keys sent in a query parameter or
+// header, according to in, with the specified name.
+func APIKeySecurity(name, in, description string) *openapi_v2.SecurityDefinitionsItem {
+	return openapi_v2.NewSecurityDefinitionsItemWithApiKeySecurity(
+		(&openapi_v2.ApiKeySecurity{}).SetType("apiKey").SetName(name).SetIn(in).SetDescription(description))
+}
+
+// Returns OAuth2 scopes, which map the names of scopes to their descriptions.
+func oauth2Scopes(scopes map[string]string) *openapi_v2.Oauth2Scopes {
+	names := make([]string, 0, len(scopes))
+	for name := range scopes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := &openapi_v2.Oauth2Scopes{}
+	for _, name := range names {
+		result.AddAdditionalProperties(name, scopes[name])
+	}
+	return result
+}
+
+// OAuth2ImplicitSecurity returns a scheme for the OAuth2 implicit flow.
+func OAuth2ImplicitSecurity(authorizationURL string, scopes map[string]string) *openapi_v2.SecurityDefinitionsItem {
+	return openapi_v2.NewSecurityDefinitionsItemWithOauth2ImplicitSecurity(
+		(&openapi_v2.Oauth2ImplicitSecurity{}).
+			SetType("oauth2").
+			SetFlow("implicit").
+			SetAuthorizationUrl(authorizationURL).
+			SetScopes(oauth2Scopes(scopes)))
+}
+
+// OAuth2PasswordSecurity returns a scheme for the OAuth2 password flow.
+func OAuth2PasswordSecurity(tokenURL string, scopes map[string]string) *openapi_v2.SecurityDefinitionsItem {
+	return openapi_v2.NewSecurityDefinitionsItemWithOauth2PasswordSecurity(
+		(&openapi_v2.Oauth2PasswordSecurity{}).
+			SetType("oauth2").
+			SetFlow("password").
+			SetTokenUrl(tokenURL).
+			SetScopes(oauth2Scopes(scopes)))
+}
+
+// OAuth2ApplicationSecurity returns a scheme for the OAuth2 application
+// (client credentials) flow.
+func OAuth2ApplicationSecurity(tokenURL string, scopes map[string]string) *openapi_v2.SecurityDefinitionsItem {
+	return openapi_v2.NewSecurityDefinitionsItemWithOauth2ApplicationSecurity(
+		(&openapi_v2.Oauth2ApplicationSecurity{}).
+			SetType("oauth2").
+			SetFlow("application").
+			SetTokenUrl(tokenURL).
+			SetScopes(oauth2Scopes(scopes)))
+}
+
+// OAuth2AccessCodeSecurity returns a scheme for the OAuth2 access code
+// (authorization code) flow.
+func OAuth2AccessCodeSecurity(authorizationURL, tokenURL string, scopes map[string]string) *openapi_v2.SecurityDefinitionsItem {
+	return openapi_v2.NewSecurityDefinitionsItemWithOauth2AccessCodeSecurity(
+		(&openapi_v2.Oauth2AccessCodeSecurity{}).
+			SetType("oauth2").
+			SetFlow("accessCode").
+			SetAuthorizationUrl(authorizationURL).
+			SetTokenUrl(tokenURL).
+			SetScopes(oauth2Scopes(scopes)))
+}
+
+// Value returns a value for an extension, example, default, or enum, as it
+// would be written by a YAML encoder. Values that can't be encoded are null.
+func Value(value interface{}) *openapi_v2.Any {
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return &openapi_v2.Any{Yaml: "null\n"}
+	}
+	return &openapi_v2.Any{Yaml: string(bytes)}
+}
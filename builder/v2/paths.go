@@ -0,0 +1,230 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder_v2
+
+import (
+	"github.com/googleapis/gnostic/OpenAPIv2"
+)
+
+// PathItemBuilder builds the item for a path, with its operations and the
+// parameters that they share.
+type PathItemBuilder struct {
+	item *openapi_v2.PathItem
+}
+
+// NewPathItem creates a PathItemBuilder.
+func NewPathItem() *PathItemBuilder {
+	return &PathItemBuilder{item: &openapi_v2.PathItem{}}
+}
+
+// Get sets the GET operation of the path.
+func (b *PathItemBuilder) Get(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetGet(operation.Build())
+	return b
+}
+
+// Put sets the PUT operation of the path.
+func (b *PathItemBuilder) Put(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetPut(operation.Build())
+	return b
+}
+
+// Post sets the POST operation of the path.
+func (b *PathItemBuilder) Post(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetPost(operation.Build())
+	return b
+}
+
+// Delete sets the DELETE operation of the path.
+func (b *PathItemBuilder) Delete(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetDelete(operation.Build())
+	return b
+}
+
+// Options sets the OPTIONS operation of the path.
+func (b *PathItemBuilder) Options(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetOptions(operation.Build())
+	return b
+}
+
+// Head sets the HEAD operation of the path.
+func (b *PathItemBuilder) Head(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetHead(operation.Build())
+	return b
+}
+
+// Patch sets the PATCH operation of the path.
+func (b *PathItemBuilder) Patch(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetPatch(operation.Build())
+	return b
+}
+
+// Parameter adds a parameter that is shared by the operations of the path.
+func (b *PathItemBuilder) Parameter(parameter *openapi_v2.Parameter) *PathItemBuilder {
+	b.item.AddParameters(openapi_v2.NewParametersItemWithParameter(parameter))
+	return b
+}
+
+// ParameterRef adds a reference to a parameter definition of the document
+// that is shared by the operations of the path.
+func (b *PathItemBuilder) ParameterRef(name string) *PathItemBuilder {
+	b.item.AddParameters(parameterRef(name))
+	return b
+}
+
+// Extension adds a vendor extension to the path item.
+func (b *PathItemBuilder) Extension(name string, value interface{}) *PathItemBuilder {
+	b.item.AddVendorExtension(name, Value(value))
+	return b
+}
+
+// Build returns the path item.
+func (b *PathItemBuilder) Build() *openapi_v2.PathItem {
+	return b.item
+}
+
+// FormDataParameter adds a form parameter with a primitive type, such as
+// "string" or "file". Operations with form parameters should consume
+// "application/x-www-form-urlencoded" or "multipart/form-data".
+func (b *OperationBuilder) FormDataParameter(name, typeName, description string, required bool) *OperationBuilder {
+	return b.addNonBodyParameter(openapi_v2.NewNonBodyParameterWithFormDataParameterSubSchema(
+		(&openapi_v2.FormDataParameterSubSchema{}).
+			SetName(name).
+			SetIn("formData").
+			SetType(typeName).
+			SetDescription(description).
+			SetRequired(required)))
+}
+
+// Parameter adds a parameter, which can be created with the SetX methods
+// of the parameter models to use fields that the other methods don't set.
+func (b *OperationBuilder) Parameter(parameter *openapi_v2.Parameter) *OperationBuilder {
+	b.operation.AddParameters(openapi_v2.NewParametersItemWithParameter(parameter))
+	return b
+}
+
+// Returns a reference to a parameter definition.
+func parameterRef(name string) *openapi_v2.ParametersItem {
+	return openapi_v2.NewParametersItemWithJsonReference(
+		(&openapi_v2.JsonReference{}).SetXRef("#/parameters/" + name))
+}
+
+// ParameterRef adds a reference to a parameter definition of the document.
+func (b *OperationBuilder) ParameterRef(name string) *OperationBuilder {
+	b.operation.AddParameters(parameterRef(name))
+	return b
+}
+
+// AddResponse adds a response built with a ResponseBuilder for a status code.
+func (b *OperationBuilder) AddResponse(code string, response *ResponseBuilder) *OperationBuilder {
+	b.operation.Responses.AddResponseCode(code, openapi_v2.NewResponseValueWithResponse(response.Build()))
+	return b
+}
+
+// ResponseRef adds a reference to a response definition of the document
+// for a status code.
+func (b *OperationBuilder) ResponseRef(code, name string) *OperationBuilder {
+	b.operation.Responses.AddResponseCode(code, openapi_v2.NewResponseValueWithJsonReference(
+		(&openapi_v2.JsonReference{}).SetXRef("#/responses/"+name)))
+	return b
+}
+
+// Consumes adds media types that the operation accepts, overriding those of the document.
+func (b *OperationBuilder) Consumes(mediaTypes ...string) *OperationBuilder {
+	b.operation.AddConsumes(mediaTypes...)
+	return b
+}
+
+// Produces adds media types that the operation returns, overriding those of the document.
+func (b *OperationBuilder) Produces(mediaTypes ...string) *OperationBuilder {
+	b.operation.AddProduces(mediaTypes...)
+	return b
+}
+
+// Schemes adds transfer protocols of the operation, overriding those of the document.
+func (b *OperationBuilder) Schemes(schemes ...string) *OperationBuilder {
+	b.operation.AddSchemes(schemes...)
+	return b
+}
+
+// Security adds alternative security requirements of the operation,
+// overriding those of the document.
+func (b *OperationBuilder) Security(requirements ...*openapi_v2.SecurityRequirement) *OperationBuilder {
+	b.operation.AddSecurity(requirements...)
+	return b
+}
+
+// ExternalDocs sets the location of external documentation of the operation.
+func (b *OperationBuilder) ExternalDocs(url, description string) *OperationBuilder {
+	b.operation.SetExternalDocs((&openapi_v2.ExternalDocs{}).SetUrl(url).SetDescription(description))
+	return b
+}
+
+// Extension adds a vendor extension to the operation.
+func (b *OperationBuilder) Extension(name string, value interface{}) *OperationBuilder {
+	b.operation.AddVendorExtension(name, Value(value))
+	return b
+}
+
+// ResponseBuilder builds a response with headers and examples.
+type ResponseBuilder struct {
+	response *openapi_v2.Response
+}
+
+// NewResponse creates a ResponseBuilder for a response with a description.
+func NewResponse(description string) *ResponseBuilder {
+	return &ResponseBuilder{response: (&openapi_v2.Response{}).SetDescription(description)}
+}
+
+// Schema sets the schema of the response.
+func (b *ResponseBuilder) Schema(schema *openapi_v2.Schema) *ResponseBuilder {
+	b.response.SetSchema(openapi_v2.NewSchemaItemWithSchema(schema))
+	return b
+}
+
+// File sets the schema of the response to a file.
+func (b *ResponseBuilder) File() *ResponseBuilder {
+	b.response.SetSchema(openapi_v2.NewSchemaItemWithFileSchema((&openapi_v2.FileSchema{}).SetType("file")))
+	return b
+}
+
+// Header adds a header with a primitive type to the response.
+func (b *ResponseBuilder) Header(name, typeName, description string) *ResponseBuilder {
+	if b.response.Headers == nil {
+		b.response.SetHeaders(&openapi_v2.Headers{})
+	}
+	b.response.Headers.AddAdditionalProperties(name, (&openapi_v2.Header{}).SetType(typeName).SetDescription(description))
+	return b
+}
+
+// Example adds an example of the response for a media type.
+func (b *ResponseBuilder) Example(mediaType string, value interface{}) *ResponseBuilder {
+	if b.response.Examples == nil {
+		b.response.SetExamples(&openapi_v2.Examples{})
+	}
+	b.response.Examples.AddAdditionalProperties(mediaType, Value(value))
+	return b
+}
+
+// Extension adds a vendor extension to the response.
+func (b *ResponseBuilder) Extension(name string, value interface{}) *ResponseBuilder {
+	b.response.AddVendorExtension(name, Value(value))
+	return b
+}
+
+// Build returns the response.
+func (b *ResponseBuilder) Build() *openapi_v2.Response {
+	return b.response
+}
@@ -0,0 +1,332 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package builder_v3 builds OpenAPI 3.0 descriptions in Go code.
+//
+// Builders assemble descriptions — info, servers, paths, operations,
+// parameters, request bodies, responses, headers, links, callbacks, schemas,
+// security schemes and requirements, tags, and specification extensions —
+// with chained calls, and produce a model that can be written as YAML or
+// JSON or used like any other model compiled by gnostic. Fields that
+// builders don't set can be set directly on the models returned by Build,
+// or on models passed to builders, using the SetX and AddX methods of the
+// model types.
+package builder_v3
+
+import (
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+)
+
+// DocumentBuilder builds an OpenAPI 3.0 document.
+type DocumentBuilder struct {
+	document *openapi_v3.Document
+}
+
+// NewDocument creates a DocumentBuilder for an API with a title and version.
+func NewDocument(title, version string) *DocumentBuilder {
+	return &DocumentBuilder{
+		document: (&openapi_v3.Document{}).
+			SetOpenapi("3.0.0").
+			SetInfo((&openapi_v3.Info{}).SetTitle(title).SetVersion(version)).
+			SetPaths(&openapi_v3.Paths{}),
+	}
+}
+
+// Description sets the description of the API.
+func (b *DocumentBuilder) Description(description string) *DocumentBuilder {
+	b.document.Info.SetDescription(description)
+	return b
+}
+
+// Server adds a server that serves the API.
+func (b *DocumentBuilder) Server(url, description string) *DocumentBuilder {
+	b.document.AddServers((&openapi_v3.Server{}).SetUrl(url).SetDescription(description))
+	return b
+}
+
+// Returns the item for a path, adding it if it doesn't exist.
+func (b *DocumentBuilder) pathItem(path string) *openapi_v3.PathItem {
+	if item := b.document.Paths.Get(path); item != nil {
+		return item
+	}
+	item := &openapi_v3.PathItem{}
+	b.document.Paths.AddPath(path, item)
+	return item
+}
+
+// Get adds a GET operation for a path.
+func (b *DocumentBuilder) Get(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetGet(operation.Build())
+	return b
+}
+
+// Put adds a PUT operation for a path.
+func (b *DocumentBuilder) Put(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetPut(operation.Build())
+	return b
+}
+
+// Post adds a POST operation for a path.
+func (b *DocumentBuilder) Post(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetPost(operation.Build())
+	return b
+}
+
+// Delete adds a DELETE operation for a path.
+func (b *DocumentBuilder) Delete(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetDelete(operation.Build())
+	return b
+}
+
+// Options adds an OPTIONS operation for a path.
+func (b *DocumentBuilder) Options(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetOptions(operation.Build())
+	return b
+}
+
+// Head adds a HEAD operation for a path.
+func (b *DocumentBuilder) Head(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetHead(operation.Build())
+	return b
+}
+
+// Patch adds a PATCH operation for a path.
+func (b *DocumentBuilder) Patch(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetPatch(operation.Build())
+	return b
+}
+
+// Trace adds a TRACE operation for a path.
+func (b *DocumentBuilder) Trace(path string, operation *OperationBuilder) *DocumentBuilder {
+	b.pathItem(path).SetTrace(operation.Build())
+	return b
+}
+
+// Schema adds a schema to the components of the document,
+// where it can be referred to with Ref.
+func (b *DocumentBuilder) Schema(name string, schema *openapi_v3.Schema) *DocumentBuilder {
+	if b.document.Components == nil {
+		b.document.SetComponents(&openapi_v3.Components{})
+	}
+	if b.document.Components.Schemas == nil {
+		b.document.Components.SetSchemas(&openapi_v3.Schemas{})
+	}
+	b.document.Components.Schemas.AddAdditionalProperties(name, schema)
+	return b
+}
+
+// Build returns the document.
+func (b *DocumentBuilder) Build() *openapi_v3.Document {
+	return b.document
+}
+
+// YAML returns the document as YAML.
+func (b *DocumentBuilder) YAML() []byte {
+	return compiler.Marshal(b.document.ToRawInfo())
+}
+
+// JSON returns the document as JSON.
+func (b *DocumentBuilder) JSON() ([]byte, error) {
+	return jsonwriter.Marshal(b.document.ToRawInfo())
+}
+
+// OperationBuilder builds an operation.
+type OperationBuilder struct {
+	operation *openapi_v3.Operation
+}
+
+// NewOperation creates an OperationBuilder for an operation with an id.
+func NewOperation(operationID string) *OperationBuilder {
+	return &OperationBuilder{
+		operation: (&openapi_v3.Operation{}).
+			SetOperationId(operationID).
+			SetResponses(&openapi_v3.Responses{}),
+	}
+}
+
+// Summary sets the summary of the operation.
+func (b *OperationBuilder) Summary(summary string) *OperationBuilder {
+	b.operation.SetSummary(summary)
+	return b
+}
+
+// Description sets the description of the operation.
+func (b *OperationBuilder) Description(description string) *OperationBuilder {
+	b.operation.SetDescription(description)
+	return b
+}
+
+// Tags adds tags to the operation.
+func (b *OperationBuilder) Tags(tags ...string) *OperationBuilder {
+	b.operation.AddTags(tags...)
+	return b
+}
+
+// Deprecated marks the operation as deprecated.
+func (b *OperationBuilder) Deprecated() *OperationBuilder {
+	b.operation.SetDeprecated(true)
+	return b
+}
+
+// Adds a parameter with a schema.
+func (b *OperationBuilder) addParameter(name, in, description string, required bool, schema *openapi_v3.SchemaOrReference) *OperationBuilder {
+	b.operation.AddParameters(openapi_v3.NewParameterOrReferenceWithParameter(
+		(&openapi_v3.Parameter{}).
+			SetName(name).
+			SetIn(in).
+			SetDescription(description).
+			SetRequired(required).
+			SetSchema(schema)))
+	return b
+}
+
+// QueryParameter adds a query parameter.
+func (b *OperationBuilder) QueryParameter(name, description string, required bool, schema *openapi_v3.SchemaOrReference) *OperationBuilder {
+	return b.addParameter(name, "query", description, required, schema)
+}
+
+// PathParameter adds a path parameter. Path parameters are required.
+func (b *OperationBuilder) PathParameter(name, description string, schema *openapi_v3.SchemaOrReference) *OperationBuilder {
+	return b.addParameter(name, "path", description, true, schema)
+}
+
+// HeaderParameter adds a header parameter.
+func (b *OperationBuilder) HeaderParameter(name, description string, required bool, schema *openapi_v3.SchemaOrReference) *OperationBuilder {
+	return b.addParameter(name, "header", description, required, schema)
+}
+
+// Returns content with a single media type.
+func content(mediaType string, schema *openapi_v3.SchemaOrReference) *openapi_v3.Content {
+	return (&openapi_v3.Content{}).AddMediaType(mediaType, (&openapi_v3.MediaType{}).SetSchema(schema))
+}
+
+// RequestBody sets the body of requests, with the media type of its content
+// such as "application/json".
+func (b *OperationBuilder) RequestBody(mediaType string, schema *openapi_v3.SchemaOrReference, required bool) *OperationBuilder {
+	b.operation.SetRequestBody(openapi_v3.NewRequestBodyOrReferenceWithRequestBody(
+		(&openapi_v3.RequestBody{}).
+			SetContent(content(mediaType, schema)).
+			SetRequired(required)))
+	return b
+}
+
+// Response adds a response for a status code, such as "200" or "default".
+// If the schema of the response is nil, the response has no content.
+func (b *OperationBuilder) Response(code, description, mediaType string, schema *openapi_v3.SchemaOrReference) *OperationBuilder {
+	response := (&openapi_v3.Response{}).SetDescription(description)
+	if schema != nil {
+		response.SetContent(content(mediaType, schema))
+	}
+	b.operation.Responses.AddResponseCode(code, openapi_v3.NewResponseOrReferenceWithResponse(response))
+	return b
+}
+
+// Build returns the operation.
+func (b *OperationBuilder) Build() *openapi_v3.Operation {
+	return b.operation
+}
+
+// Ref returns a reference to a schema in the components of the document.
+func Ref(name string) *openapi_v3.SchemaOrReference {
+	return openapi_v3.NewSchemaOrReferenceWithReference(
+		(&openapi_v3.Reference{}).SetXRef("#/components/schemas/" + name))
+}
+
+// Inline returns a schema that is written where it is used.
+func Inline(schema *openapi_v3.Schema) *openapi_v3.SchemaOrReference {
+	return openapi_v3.NewSchemaOrReferenceWithSchema(schema)
+}
+
+// Scalar returns a schema for a primitive type and an optional format,
+// such as "integer" and "int64".
+func Scalar(typeName, format string) *openapi_v3.Schema {
+	return (&openapi_v3.Schema{}).SetType(typeName).SetFormat(format)
+}
+
+// Array returns a schema for an array of items.
+func Array(items *openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	return (&openapi_v3.Schema{}).
+		SetType("array").
+		SetItems(&openapi_v3.ItemsItem{SchemaOrReference: []*openapi_v3.SchemaOrReference{items}})
+}
+
+// Enum returns a schema for a primitive type with a list of allowed values.
+func Enum(typeName string, values ...interface{}) *openapi_v3.Schema {
+	schema := Scalar(typeName, "")
+	for _, value := range values {
+		schema.AddEnum(Value(value))
+	}
+	return schema
+}
+
+// AllOf returns a schema that combines other schemas, which are often
+// references to schemas that are extended.
+func AllOf(schemas ...*openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	return (&openapi_v3.Schema{}).AddAllOf(schemas...)
+}
+
+// OneOf returns a schema for values that match exactly one of some schemas.
+func OneOf(schemas ...*openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	return (&openapi_v3.Schema{}).AddOneOf(schemas...)
+}
+
+// AnyOf returns a schema for values that match any of some schemas.
+func AnyOf(schemas ...*openapi_v3.SchemaOrReference) *openapi_v3.Schema {
+	return (&openapi_v3.Schema{}).AddAnyOf(schemas...)
+}
+
+// ObjectBuilder builds a schema for an object.
+type ObjectBuilder struct {
+	schema *openapi_v3.Schema
+}
+
+// NewObject creates an ObjectBuilder.
+func NewObject() *ObjectBuilder {
+	return &ObjectBuilder{
+		schema: (&openapi_v3.Schema{}).
+			SetType("object").
+			SetProperties(&openapi_v3.Properties{}),
+	}
+}
+
+// Description sets the description of the object.
+func (b *ObjectBuilder) Description(description string) *ObjectBuilder {
+	b.schema.SetDescription(description)
+	return b
+}
+
+// Property adds an optional property to the object.
+func (b *ObjectBuilder) Property(name string, schema *openapi_v3.Schema) *ObjectBuilder {
+	b.schema.Properties.AddAdditionalProperties(name, schema)
+	return b
+}
+
+// RequiredProperty adds a required property to the object.
+func (b *ObjectBuilder) RequiredProperty(name string, schema *openapi_v3.Schema) *ObjectBuilder {
+	b.schema.AddRequired(name)
+	return b.Property(name, schema)
+}
+
+// Extension adds a specification extension to the object's schema.
+func (b *ObjectBuilder) Extension(name string, value interface{}) *ObjectBuilder {
+	b.schema.AddSpecificationExtension(name, extension(value))
+	return b
+}
+
+// Build returns the schema.
+func (b *ObjectBuilder) Build() *openapi_v3.Schema {
+	return b.schema
+}
@@ -0,0 +1,114 @@
+package builder_v3
+
+import (
+	"testing"
+
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func TestBuilder(t *testing.T) {
+	pet := NewObject().
+		RequiredProperty("id", Scalar("integer", "int64")).
+		RequiredProperty("name", Scalar("string", "")).
+		Property("tag", Scalar("string", "")).
+		Build()
+	builder := NewDocument("OpenAPI Petstore", "1.0.0").
+		Server("https://petstore.openapis.org/v1", "Development server").
+		Schema("Pet", pet).
+		Schema("Pets", Array(Ref("Pet"))).
+		Get("/pets", NewOperation("listPets").
+			Summary("List all pets").
+			Tags("pets").
+			QueryParameter("limit", "How many items to return at one time (max 100)", false, Inline(Scalar("integer", "int32"))).
+			Response("200", "An paged array of pets", "application/json", Ref("Pets"))).
+		Post("/pets", NewOperation("createPets").
+			RequestBody("application/json", Ref("Pet"), true).
+			Response("201", "Null response", "", nil)).
+		Get("/pets/{petId}", NewOperation("showPetById").
+			PathParameter("petId", "The id of the pet to retrieve", Inline(Scalar("string", ""))).
+			Response("200", "Expected response to a valid request", "application/json", Ref("Pets")))
+
+	info, err := compiler.ReadInfoFromBytes("", builder.YAML())
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err := openapi_v3.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Built document is invalid: %+v", err)
+	}
+	pets := document.Paths.Get("/pets")
+	if pets == nil || pets.Get.OperationId != "listPets" || pets.Post.RequestBody.GetRequestBody() == nil {
+		t.Errorf("Unexpected operations: %+v", pets)
+	}
+	if schemas := document.Components.Schemas.AdditionalProperties; len(schemas) != 2 || schemas[1].Name != "Pets" {
+		t.Errorf("Unexpected schemas: %+v", schemas)
+	}
+	if _, err = builder.JSON(); err != nil {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+}
+
+func TestComponents(t *testing.T) {
+	builder := NewDocument("Petstore", "1.0.0").
+		TermsOfService("https://example.com/terms").
+		License("MIT", "").
+		Tag("pets", "Everything about pets").
+		Extension("x-audience", "public").
+		AddServer(NewServer("https://{region}.example.com").Variable("region", "us", "", "us", "eu")).
+		SecurityScheme("api_key", APIKeyScheme("X-API-Key", "header", "")).
+		SecurityScheme("oauth", OAuth2Scheme(nil, nil, nil, OAuth2Flow(
+			"https://example.com/oauth", "https://example.com/token", map[string]string{
+				"write:pets": "modify pets",
+				"read:pets":  "read pets",
+			}))).
+		Security(Requirement("api_key")).
+		Schema("Pet", NewObject().RequiredProperty("status", Enum("string", "available", "sold")).Build()).
+		Schema("Animal", OneOf(Ref("Pet"))).
+		Parameter("petId", (&openapi_v3.Parameter{}).SetName("petId").SetIn("path").SetRequired(true)).
+		RequestBody("Pet", "application/json", Ref("Pet"), true).
+		Response("404", NewResponse("Not found")).
+		Path("/pets/{petId}", NewPathItem().
+			Summary("A pet").
+			ParameterRef("petId").
+			Put(NewOperation("updatePet").
+				Security(Requirement("oauth", "write:pets")).
+				RequestBodyRef("Pet").
+				Callback("updated", NewCallback().Expression("{$request.body#/callbackUrl}",
+					NewPathItem().Post(NewOperation("petUpdated").Response("200", "OK", "", nil)))).
+				AddResponse("200", NewResponse("A pet").
+					Content("application/json", Ref("Pet")).
+					Header("X-Rate-Limit", "Requests left", Inline(Scalar("integer", ""))).
+					Link("self", "showPetById", "")).
+				ResponseRef("404", "404")))
+
+	info, err := compiler.ReadInfoFromBytes("", builder.YAML())
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err := openapi_v3.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("Built document is invalid: %+v", err)
+	}
+	if document.Info.License.Name != "MIT" || len(document.Tags) != 1 || len(document.SpecificationExtension) != 1 {
+		t.Errorf("Unexpected info: %+v", document.Info)
+	}
+	if variables := document.Servers[0].Variables.Name; len(variables) != 1 || len(variables[0].Value.Enum) != 2 {
+		t.Errorf("Unexpected server variables: %+v", variables)
+	}
+	schemes := document.Components.SecuritySchemes.AdditionalProperties
+	if len(schemes) != 2 || schemes[1].Value.Flow.AuthorizationCode.Scopes.Name[0].Name != "read:pets" {
+		t.Errorf("Unexpected security schemes: %+v", schemes)
+	}
+	item := document.Paths.Get("/pets/{petId}")
+	if item == nil || item.Parameters[0].GetReference().XRef != "#/components/parameters/petId" {
+		t.Fatalf("Unexpected path item: %+v", item)
+	}
+	if item.Put.RequestBody.GetReference().XRef != "#/components/requestBodies/Pet" || item.Put.Callbacks == nil {
+		t.Errorf("Unexpected operation: %+v", item.Put)
+	}
+	responses := item.Put.Responses.ResponseCode
+	if len(responses) != 2 || responses[1].Value.GetReference().XRef != "#/components/responses/404" {
+		t.Errorf("Unexpected responses: %+v", responses)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder_v3
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"gopkg.in/yaml.v3"
+)
+
+// TermsOfService sets the URL of the terms of service of the API.
+func (b *DocumentBuilder) TermsOfService(url string) *DocumentBuilder {
+	b.document.Info.SetTermsOfService(url)
+	return b
+}
+
+// Contact sets the contact information of the API.
+func (b *DocumentBuilder) Contact(name, url, email string) *DocumentBuilder {
+	b.document.Info.SetContact((&openapi_v3.Contact{}).SetName(name).SetUrl(url).SetEmail(email))
+	return b
+}
+
+// License sets the license of the API.
+func (b *DocumentBuilder) License(name, url string) *DocumentBuilder {
+	b.document.Info.SetLicense((&openapi_v3.License{}).SetName(name).SetUrl(url))
+	return b
+}
+
+// AddServer adds a server built with a ServerBuilder.
+func (b *DocumentBuilder) AddServer(server *ServerBuilder) *DocumentBuilder {
+	b.document.AddServers(server.Build())
+	return b
+}
+
+// Tag adds a tag that describes the operations that use it.
+func (b *DocumentBuilder) Tag(name, description string) *DocumentBuilder {
+	b.document.AddTags((&openapi_v3.Tag{}).SetName(name).SetDescription(description))
+	return b
+}
+
+// ExternalDocs sets the location of external documentation of the API.
+func (b *DocumentBuilder) ExternalDocs(url, description string) *DocumentBuilder {
+	b.document.SetExternalDocs((&openapi_v3.ExternalDocs{}).SetUrl(url).SetDescription(description))
+	return b
+}
+
+// Extension adds a specification extension, such as "x-logo", to the
+// document. Extensions of OpenAPI 3.0 models hold booleans, strings,
+// integers, and numbers; other values are written as YAML strings.
+func (b *DocumentBuilder) Extension(name string, value interface{}) *DocumentBuilder {
+	b.document.AddSpecificationExtension(name, extension(value))
+	return b
+}
+
+// Path adds a path item, replacing any operations that were added for the path.
+func (b *DocumentBuilder) Path(path string, item *PathItemBuilder) *DocumentBuilder {
+	b.document.Paths.SetPath(path, item.Build())
+	return b
+}
+
+// Returns the components of the document, adding them if necessary.
+func (b *DocumentBuilder) components() *openapi_v3.Components {
+	if b.document.Components == nil {
+		b.document.SetComponents(&openapi_v3.Components{})
+	}
+	return b.document.Components
+}
+
+// Parameter adds a parameter to the components of the document,
+// where it can be referred to with ParameterRef.
+func (b *DocumentBuilder) Parameter(name string, parameter *openapi_v3.Parameter) *DocumentBuilder {
+	components := b.components()
+	if components.Parameters == nil {
+		components.SetParameters(&openapi_v3.Parameters{})
+	}
+	components.Parameters.AddAdditionalProperties(name, parameter)
+	return b
+}
+
+// RequestBody adds a request body to the components of the document,
+// where it can be referred to with RequestBodyRef.
+func (b *DocumentBuilder) RequestBody(name, mediaType string, schema *openapi_v3.SchemaOrReference, required bool) *DocumentBuilder {
+	components := b.components()
+	if components.RequestBodies == nil {
+		components.SetRequestBodies(&openapi_v3.RequestBodies{})
+	}
+	components.RequestBodies.AddAdditionalProperties(name,
+		(&openapi_v3.RequestBody{}).SetContent(content(mediaType, schema)).SetRequired(required))
+	return b
+}
+
+// Response adds a response to the components of the document,
+// where it can be referred to with ResponseRef. The model types these
+// components like the responses of an operation, so their names must be
+// status codes such as "404" or "default".
+func (b *DocumentBuilder) Response(name string, response *ResponseBuilder) *DocumentBuilder {
+	components := b.components()
+	if components.Responses == nil {
+		components.SetResponses(&openapi_v3.Responses{})
+	}
+	components.Responses.AddResponseCode(name, openapi_v3.NewResponseOrReferenceWithResponse(response.Build()))
+	return b
+}
+
+// Header adds a header to the components of the document.
+func (b *DocumentBuilder) Header(name, description string, schema *openapi_v3.SchemaOrReference) *DocumentBuilder {
+	components := b.components()
+	if components.Headers == nil {
+		components.SetHeaders(&openapi_v3.Headers{})
+	}
+	components.Headers.AddName(name, openapi_v3.NewHeaderOrReferenceWithHeader(header(description, schema)))
+	return b
+}
+
+// Link adds a link to the components of the document.
+func (b *DocumentBuilder) Link(name, operationID, description string) *DocumentBuilder {
+	components := b.components()
+	if components.Links == nil {
+		components.SetLinks(&openapi_v3.Links{})
+	}
+	components.Links.AddName(name, openapi_v3.NewLinkOrReferenceWithLink(
+		(&openapi_v3.Link{}).SetOperationId(operationID).SetDescription(description)))
+	return b
+}
+
+// Callback adds a callback to the components of the document.
+func (b *DocumentBuilder) Callback(name string, callback *CallbackBuilder) *DocumentBuilder {
+	components := b.components()
+	if components.Callbacks == nil {
+		components.SetCallbacks(&openapi_v3.Callbacks{})
+	}
+	components.Callbacks.AddName(name, openapi_v3.NewCallbackOrReferenceWithCallback(callback.Build()))
+	return b
+}
+
+// SecurityScheme adds a security scheme that requirements can refer to by
+// name. Schemes are created with APIKeyScheme, HTTPScheme, OAuth2Scheme,
+// and OpenIDConnectScheme.
+func (b *DocumentBuilder) SecurityScheme(name string, scheme *openapi_v3.SecurityScheme) *DocumentBuilder {
+	components := b.components()
+	if components.SecuritySchemes == nil {
+		components.SetSecuritySchemes(&openapi_v3.SecuritySchemes{})
+	}
+	components.SecuritySchemes.AddAdditionalProperties(name, scheme)
+	return b
+}
+
+// Security adds alternative security requirements for all operations.
+func (b *DocumentBuilder) Security(requirements ...*openapi_v3.SecurityRequirement) *DocumentBuilder {
+	b.document.AddSecurity(requirements...)
+	return b
+}
+
+// Requirement returns a security requirement for a scheme with a list of
+// the scopes that it requires. Any other schemes that are required together
+// with it can be added to the requirement with AddName.
+func Requirement(name string, scopes ...string) *openapi_v3.SecurityRequirement {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return (&openapi_v3.SecurityRequirement{}).AddName(name, Value(scopes))
+}
+
+// APIKeyScheme returns a scheme for API keys sent in a query parameter,
+// header, or cookie, according to in, with the specified name.
+func APIKeyScheme(name, in, description string) *openapi_v3.SecurityScheme {
+	return (&openapi_v3.SecurityScheme{}).SetType("apiKey").SetName(name).SetIn(in).SetDescription(description)
+}
+
+// HTTPScheme returns a scheme for HTTP authentication, such as "basic" or
+// "bearer". The format of bearer tokens, such as "JWT", is optional.
+func HTTPScheme(scheme, bearerFormat, description string) *openapi_v3.SecurityScheme {
+	return (&openapi_v3.SecurityScheme{}).SetType("http").SetScheme(scheme).SetBearerFormat(bearerFormat).SetDescription(description)
+}
+
+// OAuth2Scheme returns a scheme for OAuth2 with flows created by OAuth2Flow.
+// Flows that are nil aren't supported.
+func OAuth2Scheme(implicit, password, clientCredentials, authorizationCode *openapi_v3.OauthFlow) *openapi_v3.SecurityScheme {
+	return (&openapi_v3.SecurityScheme{}).SetType("oauth2").SetFlow((&openapi_v3.OauthFlows{}).
+		SetImplicit(implicit).
+		SetPassword(password).
+		SetClientCredentials(clientCredentials).
+		SetAuthorizationCode(authorizationCode))
+}
+
+// OAuth2Flow returns an OAuth2 flow with the URLs that it uses and its
+// scopes, which map the names of scopes to their descriptions.
+func OAuth2Flow(authorizationURL, tokenURL string, scopes map[string]string) *openapi_v3.OauthFlow {
+	names := make([]string, 0, len(scopes))
+	for name := range scopes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	s := &openapi_v3.Scopes{}
+	for _, name := range names {
+		s.AddName(name, Value(scopes[name]))
+	}
+	return (&openapi_v3.OauthFlow{}).SetAuthorizationUrl(authorizationURL).SetTokenUrl(tokenURL).SetScopes(s)
+}
+
+// OpenIDConnectScheme returns a scheme for OpenID Connect discovery.
+func OpenIDConnectScheme(url, description string) *openapi_v3.SecurityScheme {
+	return (&openapi_v3.SecurityScheme{}).SetType("openIdConnect").SetOpenIdConnectUrl(url).SetDescription(description)
+}
+
+// ServerBuilder builds a server with variables.
+type ServerBuilder struct {
+	server *openapi_v3.Server
+}
+
+// NewServer creates a ServerBuilder for a server with a URL, which can
+// contain variables in braces, such as "https://{region}.example.com".
+func NewServer(url string) *ServerBuilder {
+	return &ServerBuilder{server: (&openapi_v3.Server{}).SetUrl(url)}
+}
+
+// Description sets the description of the server.
+func (b *ServerBuilder) Description(description string) *ServerBuilder {
+	b.server.SetDescription(description)
+	return b
+}
+
+// Variable adds a variable of the server's URL with a default value and
+// an optional list of allowed values.
+func (b *ServerBuilder) Variable(name, defaultValue, description string, values ...string) *ServerBuilder {
+	if b.server.Variables == nil {
+		b.server.SetVariables(&openapi_v3.ServerVariables{})
+	}
+	variable := (&openapi_v3.ServerVariable{}).
+		SetDefault(openapi_v3.NewPrimitiveWithString_(defaultValue)).
+		SetDescription(description)
+	for _, value := range values {
+		variable.AddEnum(openapi_v3.NewPrimitiveWithString_(value))
+	}
+	b.server.Variables.AddName(name, variable)
+	return b
+}
+
+// Build returns the server.
+func (b *ServerBuilder) Build() *openapi_v3.Server {
+	return b.server
+}
+
+// Returns a specification extension for a value.
+func extension(value interface{}) *openapi_v3.SpecificationExtension {
+	switch v := value.(type) {
+	case bool:
+		return openapi_v3.NewSpecificationExtensionWithBoolean(v)
+	case string:
+		return openapi_v3.NewSpecificationExtensionWithString_(v)
+	case int:
+		return openapi_v3.NewSpecificationExtensionWithInteger(int64(v))
+	case int64:
+		return openapi_v3.NewSpecificationExtensionWithInteger(v)
+	case float64:
+		return openapi_v3.NewSpecificationExtensionWithNumber(v)
+	}
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return openapi_v3.NewSpecificationExtensionWithString_(fmt.Sprintf("%v", value))
+	}
+	return openapi_v3.NewSpecificationExtensionWithString_(string(bytes))
+}
+
+// Value returns a value for a schema's enum or a security requirement's
+// scopes, as it would be written by a YAML encoder. Values that can't be
+// encoded are null.
+func Value(value interface{}) *openapi_v3.Any {
+	bytes, err := yaml.Marshal(value)
+	if err != nil {
+		return &openapi_v3.Any{Yaml: "null\n"}
+	}
+	return &openapi_v3.Any{Yaml: string(bytes)}
+}
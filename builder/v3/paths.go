@@ -0,0 +1,277 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder_v3
+
+import (
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// PathItemBuilder builds a path item with the operations of a path and the
+// parameters that they share.
+type PathItemBuilder struct {
+	item *openapi_v3.PathItem
+}
+
+// NewPathItem creates a PathItemBuilder.
+func NewPathItem() *PathItemBuilder {
+	return &PathItemBuilder{item: &openapi_v3.PathItem{}}
+}
+
+// Summary sets the summary of the path item.
+func (b *PathItemBuilder) Summary(summary string) *PathItemBuilder {
+	b.item.SetSummary(summary)
+	return b
+}
+
+// Description sets the description of the path item.
+func (b *PathItemBuilder) Description(description string) *PathItemBuilder {
+	b.item.SetDescription(description)
+	return b
+}
+
+// Server sets a server that serves the operations of the path.
+func (b *PathItemBuilder) Server(server *ServerBuilder) *PathItemBuilder {
+	b.item.SetServers(server.Build())
+	return b
+}
+
+// Get sets the GET operation of the path item.
+func (b *PathItemBuilder) Get(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetGet(operation.Build())
+	return b
+}
+
+// Put sets the PUT operation of the path item.
+func (b *PathItemBuilder) Put(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetPut(operation.Build())
+	return b
+}
+
+// Post sets the POST operation of the path item.
+func (b *PathItemBuilder) Post(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetPost(operation.Build())
+	return b
+}
+
+// Delete sets the DELETE operation of the path item.
+func (b *PathItemBuilder) Delete(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetDelete(operation.Build())
+	return b
+}
+
+// Options sets the OPTIONS operation of the path item.
+func (b *PathItemBuilder) Options(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetOptions(operation.Build())
+	return b
+}
+
+// Head sets the HEAD operation of the path item.
+func (b *PathItemBuilder) Head(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetHead(operation.Build())
+	return b
+}
+
+// Patch sets the PATCH operation of the path item.
+func (b *PathItemBuilder) Patch(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetPatch(operation.Build())
+	return b
+}
+
+// Trace sets the TRACE operation of the path item.
+func (b *PathItemBuilder) Trace(operation *OperationBuilder) *PathItemBuilder {
+	b.item.SetTrace(operation.Build())
+	return b
+}
+
+// Parameter adds a parameter that is shared by the operations of the path.
+func (b *PathItemBuilder) Parameter(parameter *openapi_v3.Parameter) *PathItemBuilder {
+	b.item.AddParameters(openapi_v3.NewParameterOrReferenceWithParameter(parameter))
+	return b
+}
+
+// ParameterRef adds a reference to a parameter in the components of the
+// document that is shared by the operations of the path.
+func (b *PathItemBuilder) ParameterRef(name string) *PathItemBuilder {
+	b.item.AddParameters(parameterRef(name))
+	return b
+}
+
+// Extension adds a specification extension to the path item.
+func (b *PathItemBuilder) Extension(name string, value interface{}) *PathItemBuilder {
+	b.item.AddSpecificationExtension(name, extension(value))
+	return b
+}
+
+// Build returns the path item.
+func (b *PathItemBuilder) Build() *openapi_v3.PathItem {
+	return b.item
+}
+
+// CookieParameter adds a cookie parameter.
+func (b *OperationBuilder) CookieParameter(name, description string, required bool, schema *openapi_v3.SchemaOrReference) *OperationBuilder {
+	return b.addParameter(name, "cookie", description, required, schema)
+}
+
+// Parameter adds a parameter that was built directly, for parameters that
+// use fields that the other methods don't set, such as style and explode.
+func (b *OperationBuilder) Parameter(parameter *openapi_v3.Parameter) *OperationBuilder {
+	b.operation.AddParameters(openapi_v3.NewParameterOrReferenceWithParameter(parameter))
+	return b
+}
+
+// Returns a reference to a parameter in the components of the document.
+func parameterRef(name string) *openapi_v3.ParameterOrReference {
+	return openapi_v3.NewParameterOrReferenceWithReference(
+		(&openapi_v3.Reference{}).SetXRef("#/components/parameters/" + name))
+}
+
+// ParameterRef adds a reference to a parameter in the components of the document.
+func (b *OperationBuilder) ParameterRef(name string) *OperationBuilder {
+	b.operation.AddParameters(parameterRef(name))
+	return b
+}
+
+// RequestBodyRef sets the body of requests to a reference to a request body
+// in the components of the document.
+func (b *OperationBuilder) RequestBodyRef(name string) *OperationBuilder {
+	b.operation.SetRequestBody(openapi_v3.NewRequestBodyOrReferenceWithReference(
+		(&openapi_v3.Reference{}).SetXRef("#/components/requestBodies/" + name)))
+	return b
+}
+
+// AddResponse adds a response built with a ResponseBuilder for a status code.
+func (b *OperationBuilder) AddResponse(code string, response *ResponseBuilder) *OperationBuilder {
+	b.operation.Responses.AddResponseCode(code, openapi_v3.NewResponseOrReferenceWithResponse(response.Build()))
+	return b
+}
+
+// ResponseRef adds a reference to a response in the components of the
+// document for a status code.
+func (b *OperationBuilder) ResponseRef(code, name string) *OperationBuilder {
+	b.operation.Responses.AddResponseCode(code, openapi_v3.NewResponseOrReferenceWithReference(
+		(&openapi_v3.Reference{}).SetXRef("#/components/responses/"+name)))
+	return b
+}
+
+// Callback adds a callback that the API makes when the operation is called.
+func (b *OperationBuilder) Callback(name string, callback *CallbackBuilder) *OperationBuilder {
+	if b.operation.Callbacks == nil {
+		b.operation.SetCallbacks(&openapi_v3.Callbacks{})
+	}
+	b.operation.Callbacks.AddName(name, openapi_v3.NewCallbackOrReferenceWithCallback(callback.Build()))
+	return b
+}
+
+// Security adds alternative security requirements that replace those of
+// the document for the operation.
+func (b *OperationBuilder) Security(requirements ...*openapi_v3.SecurityRequirement) *OperationBuilder {
+	b.operation.AddSecurity(requirements...)
+	return b
+}
+
+// Server sets a server that serves the operation.
+func (b *OperationBuilder) Server(server *ServerBuilder) *OperationBuilder {
+	b.operation.SetServers(server.Build())
+	return b
+}
+
+// ExternalDocs sets the location of external documentation of the operation.
+func (b *OperationBuilder) ExternalDocs(url, description string) *OperationBuilder {
+	b.operation.SetExternalDocs((&openapi_v3.ExternalDocs{}).SetUrl(url).SetDescription(description))
+	return b
+}
+
+// Extension adds a specification extension to the operation.
+func (b *OperationBuilder) Extension(name string, value interface{}) *OperationBuilder {
+	b.operation.AddSpecificationExtension(name, extension(value))
+	return b
+}
+
+// ResponseBuilder builds a response with content, headers, and links.
+type ResponseBuilder struct {
+	response *openapi_v3.Response
+}
+
+// NewResponse creates a ResponseBuilder for a response with a description.
+func NewResponse(description string) *ResponseBuilder {
+	return &ResponseBuilder{response: (&openapi_v3.Response{}).SetDescription(description)}
+}
+
+// Content adds the schema of the response's content for a media type.
+func (b *ResponseBuilder) Content(mediaType string, schema *openapi_v3.SchemaOrReference) *ResponseBuilder {
+	if b.response.Content == nil {
+		b.response.SetContent(&openapi_v3.Content{})
+	}
+	b.response.Content.AddMediaType(mediaType, (&openapi_v3.MediaType{}).SetSchema(schema))
+	return b
+}
+
+// Returns a header with a schema.
+func header(description string, schema *openapi_v3.SchemaOrReference) *openapi_v3.Header {
+	return (&openapi_v3.Header{}).SetDescription(description).SetSchema(schema)
+}
+
+// Header adds a header that is sent with the response.
+func (b *ResponseBuilder) Header(name, description string, schema *openapi_v3.SchemaOrReference) *ResponseBuilder {
+	if b.response.Headers == nil {
+		b.response.SetHeaders(&openapi_v3.Headers{})
+	}
+	b.response.Headers.AddName(name, openapi_v3.NewHeaderOrReferenceWithHeader(header(description, schema)))
+	return b
+}
+
+// Link adds a link to an operation that can use values of the response.
+func (b *ResponseBuilder) Link(name, operationID, description string) *ResponseBuilder {
+	if b.response.Links == nil {
+		b.response.SetLinks(&openapi_v3.Links{})
+	}
+	b.response.Links.AddName(name, openapi_v3.NewLinkOrReferenceWithLink(
+		(&openapi_v3.Link{}).SetOperationId(operationID).SetDescription(description)))
+	return b
+}
+
+// Extension adds a specification extension to the response.
+func (b *ResponseBuilder) Extension(name string, value interface{}) *ResponseBuilder {
+	b.response.AddSpecificationExtension(name, extension(value))
+	return b
+}
+
+// Build returns the response.
+func (b *ResponseBuilder) Build() *openapi_v3.Response {
+	return b.response
+}
+
+// CallbackBuilder builds a callback, which maps expressions for the URLs
+// that are called back to the path items that describe the calls.
+type CallbackBuilder struct {
+	callback *openapi_v3.Callback
+}
+
+// NewCallback creates a CallbackBuilder.
+func NewCallback() *CallbackBuilder {
+	return &CallbackBuilder{callback: &openapi_v3.Callback{}}
+}
+
+// Expression adds a path item for an expression, such as
+// "{$request.body#/callbackUrl}".
+func (b *CallbackBuilder) Expression(expression string, item *PathItemBuilder) *CallbackBuilder {
+	b.callback.AddExpression(expression, item.Build())
+	return b
+}
+
+// Build returns the callback.
+func (b *CallbackBuilder) Build() *openapi_v3.Callback {
+	return b.callback
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	gnostic "github.com/googleapis/gnostic/lib"
+	"github.com/googleapis/gnostic/linter"
+	plugins "github.com/googleapis/gnostic/plugins"
+	"github.com/googleapis/gnostic/plugins/gnostic-analyze/statistics"
+	"github.com/googleapis/gnostic/plugins/gnostic-go-generator/generator"
+)
+
+// A builtinPlugin is a plugin that runs in the gnostic process. It receives
+// the same request as an external plugin, along with the compiled model
+// so that the model doesn't need to be unmarshaled again, and returns
+// its files and errors in a response.
+type builtinPlugin func(request *plugins.Request, document proto.Message) *plugins.Response
+
+// The plugins that are run with "--plugin builtin:NAME". Their outputs
+// match those of the gnostic-analyze and gnostic-go-generator plugins.
+var builtinPlugins = map[string]builtinPlugin{
+	"summary":      summaryPlugin,
+	"linter":       linterPlugin,
+	"go-generator": goGeneratorPlugin("client.go", "server.go", "provider.go", "types.go"),
+	"go-client":    goGeneratorPlugin("client.go", "types.go"),
+	"go-server":    goGeneratorPlugin("server.go", "provider.go", "types.go"),
+}
+
+// Returns the names of the builtin plugins for use in messages.
+func builtinPluginNames() string {
+	names := make([]string, 0, len(builtinPlugins))
+	for name := range builtinPlugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Returns a plugin response that reports an error.
+func pluginErrorResponse(err error) *plugins.Response {
+	return &plugins.Response{Errors: []string{err.Error()}}
+}
+
+// Reports the statistics that gnostic-analyze reports in a file named summary.json.
+func summaryPlugin(request *plugins.Request, document proto.Message) *plugins.Response {
+	v2, ok := document.(*openapi_v2.Document)
+	if !ok {
+		return pluginErrorResponse(errors.New("builtin:summary requires an OpenAPI v2 description."))
+	}
+	stats := statistics.NewDocumentStatistics(request.Wrapper.Name, v2)
+	file := &plugins.File{}
+	file.Name = strings.Replace(stats.Name, path.Base(stats.Name), "summary.json", -1)
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return pluginErrorResponse(err)
+	}
+	file.Data = append(data, []byte("\n")...)
+	return &plugins.Response{Files: []*plugins.File{file}}
+}
+
+// Returns the rulesets that the linter checks: the default ruleset and the named ones.
+func lintRulesets(names []string) ([]string, error) {
+	rulesets := []string{linter.DefaultRuleset}
+	for _, name := range names {
+		if !linter.IsRuleset(name) {
+			return nil, errors.New(fmt.Sprintf("Unknown ruleset %s.", name))
+		}
+		rulesets = append(rulesets, name)
+	}
+	return rulesets, nil
+}
+
+// Reports the problems found by the linter in a file named lint.json.
+// Problems don't cause the plugin to fail. Other rulesets than the
+// default one are checked when they are named by "ruleset" parameters.
+func linterPlugin(request *plugins.Request, document proto.Message) *plugins.Response {
+	names := make([]string, 0)
+	for _, parameter := range request.Parameters {
+		if parameter.Name == "ruleset" {
+			names = append(names, parameter.Value)
+		}
+	}
+	rulesets, err := lintRulesets(names)
+	if err != nil {
+		return pluginErrorResponse(err)
+	}
+	d := &gnostic.Document{}
+	switch document := document.(type) {
+	case *openapi_v2.Document:
+		d.Version, d.V2 = OpenAPIv2, document
+	case *openapi_v3.Document:
+		d.Version, d.V3 = OpenAPIv3, document
+	}
+	problems := make([]*serveError, 0)
+	for _, problem := range linter.LintWithRulesets(d, rulesets...) {
+		problems = append(problems, serveErrors(problem)...)
+	}
+	data, err := json.MarshalIndent(struct {
+		Name     string        `json:"name"`
+		Problems []*serveError `json:"problems"`
+	}{request.Wrapper.Name, problems}, "", "  ")
+	if err != nil {
+		return pluginErrorResponse(err)
+	}
+	file := &plugins.File{Name: "lint.json", Data: append(data, []byte("\n")...)}
+	return &plugins.Response{Files: []*plugins.File{file}}
+}
+
+// Returns a plugin that generates the named Go files, like gnostic-go-generator.
+// The package of the generated code is named by the "package" parameter
+// and defaults to the name of the output directory.
+func goGeneratorPlugin(files ...string) builtinPlugin {
+	return func(request *plugins.Request, document proto.Message) *plugins.Response {
+		v2, ok := document.(*openapi_v2.Document)
+		if !ok {
+			return pluginErrorResponse(errors.New(fmt.Sprintf("Unsupported OpenAPI version %s", request.Wrapper.Version)))
+		}
+		packageName := request.OutputPath
+		for _, parameter := range request.Parameters {
+			if parameter.Name == "package" {
+				packageName = parameter.Value
+			}
+		}
+		renderer, err := generator.NewServiceRenderer(v2, packageName)
+		if err != nil {
+			return pluginErrorResponse(err)
+		}
+		response := &plugins.Response{}
+		if err = renderer.Generate(response, files); err != nil {
+			response.Errors = append(response.Errors, err.Error())
+		}
+		return response
+	}
+}
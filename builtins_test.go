@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/compiler"
+	plugins "github.com/googleapis/gnostic/plugins"
+)
+
+func runBuiltin(t *testing.T, name string, filename string, parameters ...*plugins.Parameter) *plugins.Response {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := compileDescription(bytes)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	request := &plugins.Request{
+		Wrapper:    &plugins.Wrapper{Name: filename},
+		OutputPath: "output",
+		Parameters: parameters,
+	}
+	return builtinPlugins[name](request, document.Message())
+}
+
+func TestBuiltinSummary(t *testing.T) {
+	response := runBuiltin(t, "summary", "examples/v2.0/yaml/petstore.yaml")
+	if len(response.Errors) != 0 || len(response.Files) != 1 || response.Files[0].Name != "examples/v2.0/yaml/summary.json" {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	var stats struct{ Operations map[string]int }
+	if err := json.Unmarshal(response.Files[0].Data, &stats); err != nil || stats.Operations["get"] != 2 {
+		t.Errorf("unexpected summary %s", response.Files[0].Data)
+	}
+	response = runBuiltin(t, "summary", "examples/v3.0/yaml/petstore.yaml")
+	if len(response.Errors) != 1 {
+		t.Errorf("expected v3 descriptions to be rejected, found %+v", response)
+	}
+}
+
+func TestBuiltinLinter(t *testing.T) {
+	response := runBuiltin(t, "linter", "examples/v3.0/yaml/petstore.yaml")
+	if len(response.Errors) != 0 || len(response.Files) != 1 || response.Files[0].Name != "lint.json" {
+		t.Fatalf("unexpected response %+v", response)
+	}
+	var result struct{ Problems []serveError }
+	if err := json.Unmarshal(response.Files[0].Data, &result); err != nil || len(result.Problems) != 0 {
+		t.Errorf("unexpected problems %s", response.Files[0].Data)
+	}
+}
+
+func TestBuiltinGoGenerator(t *testing.T) {
+	response := runBuiltin(t, "go-client", "plugins/gnostic-go-generator/examples/v2.0/bookstore/bookstore.json",
+		&plugins.Parameter{Name: "package", Value: "bookstore"})
+	if len(response.Errors) != 0 || len(response.Files) != 2 {
+		t.Fatalf("unexpected response %+v", response.Errors)
+	}
+	for _, file := range response.Files {
+		if !strings.HasPrefix(string(file.Data), "// GENERATED FILE: DO NOT EDIT!\n\npackage bookstore\n") {
+			t.Errorf("unexpected contents of %s", file.Name)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Expands the aliases and merge keys in a parsed file, so that each alias
+// is replaced by a copy of the value that it refers to and the keys of
+// merged maps are added to the maps that merge them. Compilers and
+// writers then see the same values that YAML decoders would.
+// Since the values of anchors precede their aliases, a value that is
+// being expanded when one of its aliases is reached contains that alias,
+// and is reported as an error instead of being expanded forever.
+func expandAliases(node *yaml.Node) (*yaml.Node, error) {
+	return (&aliasExpander{expanding: make(map[*yaml.Node]bool)}).expand(node)
+}
+
+type aliasExpander struct {
+	expanding map[*yaml.Node]bool
+}
+
+func (e *aliasExpander) expand(node *yaml.Node) (*yaml.Node, error) {
+	if node.Kind == yaml.AliasNode {
+		if node.Alias == nil {
+			return nil, errors.New(fmt.Sprintf("unknown anchor *%s at line %d, column %d", node.Value, node.Line, node.Column))
+		}
+		if e.expanding[node.Alias] {
+			return nil, errors.New(fmt.Sprintf("alias *%s at line %d, column %d refers to a value that contains it", node.Value, node.Line, node.Column))
+		}
+		return copyNode(node.Alias), nil
+	}
+	e.expanding[node] = true
+	defer delete(e.expanding, node)
+	for i, child := range node.Content {
+		expanded, err := e.expand(child)
+		if err != nil {
+			return nil, err
+		}
+		node.Content[i] = expanded
+	}
+	if node.Kind == yaml.MappingNode {
+		content, err := mergeKeys(node)
+		if err != nil {
+			return nil, err
+		}
+		node.Content = content
+	}
+	return node, nil
+}
+
+// Returns a copy of a node tree without anchors.
+func copyNode(node *yaml.Node) *yaml.Node {
+	out := *node
+	out.Anchor = ""
+	out.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		out.Content[i] = copyNode(child)
+	}
+	return &out
+}
+
+func isMergeKey(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!merge"
+}
+
+// Returns the contents of a map with its merge keys replaced by the keys of
+// the maps that they merge. Keys of the map take precedence over merged keys,
+// and when a list of maps is merged, the keys of earlier maps take precedence.
+func mergeKeys(node *yaml.Node) ([]*yaml.Node, error) {
+	defined := make(map[string]bool)
+	merges := false
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if isMergeKey(node.Content[i]) {
+			merges = true
+		} else {
+			defined[node.Content[i].Value] = true
+		}
+	}
+	if !merges {
+		return node.Content, nil
+	}
+	content := make([]*yaml.Node, 0, len(node.Content))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !isMergeKey(key) {
+			content = append(content, key, value)
+			continue
+		}
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, source := range sources {
+			if source.Kind != yaml.MappingNode {
+				return nil, errors.New(fmt.Sprintf("merge key at line %d, column %d must refer to a map or a list of maps", key.Line, key.Column))
+			}
+			for j := 0; j+1 < len(source.Content); j += 2 {
+				if name := source.Content[j].Value; !defined[name] {
+					defined[name] = true
+					content = append(content, source.Content[j], source.Content[j+1])
+				}
+			}
+		}
+	}
+	return content, nil
+}
+
+// Maps and sequences with fewer nodes than this aren't replaced by aliases,
+// since aliases of small values are harder to read than the values.
+const minimumAnchoredSize = 8
+
+// AddAnchors replaces the values in a node tree that repeat earlier values
+// with aliases of the earlier values, which are given anchors named after
+// their keys. Descriptions that repeat large values, such as the parameters
+// and responses of similar operations, are much smaller when they are
+// written as YAML with anchors. Since JSON has no aliases, they are
+// expanded when node trees are written as JSON.
+func AddAnchors(node *yaml.Node) {
+	a := &anchorer{
+		hashes:  make(map[*yaml.Node]string),
+		sizes:   make(map[*yaml.Node]int),
+		first:   make(map[string]*yaml.Node),
+		anchors: make(map[string]bool),
+	}
+	a.measure(node)
+	a.replace(node, "")
+}
+
+type anchorer struct {
+	hashes  map[*yaml.Node]string // hashes of the contents of nodes
+	sizes   map[*yaml.Node]int    // numbers of nodes in trees
+	first   map[string]*yaml.Node // the first node with each hash
+	anchors map[string]bool       // the names of the anchors that have been added
+}
+
+// Computes the hashes and sizes of the nodes in a tree.
+func (a *anchorer) measure(node *yaml.Node) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d %s %q", node.Kind, node.ShortTag(), node.Value)
+	size := 1
+	for _, child := range node.Content {
+		a.measure(child)
+		hash.Write([]byte(a.hashes[child]))
+		size += a.sizes[child]
+	}
+	a.hashes[node] = string(hash.Sum(nil))
+	a.sizes[node] = size
+}
+
+// Replaces the repeated values in the contents of a node with aliases.
+func (a *anchorer) replace(node *yaml.Node, key string) {
+	for i, child := range node.Content {
+		childKey := key
+		if node.Kind == yaml.MappingNode && i%2 == 1 {
+			childKey = node.Content[i-1].Value
+		} else if node.Kind == yaml.MappingNode {
+			continue
+		}
+		if (child.Kind == yaml.MappingNode || child.Kind == yaml.SequenceNode) && a.sizes[child] >= minimumAnchoredSize {
+			hash := a.hashes[child]
+			if first, ok := a.first[hash]; ok {
+				if first.Anchor == "" {
+					first.Anchor = a.anchorName(childKey)
+				}
+				node.Content[i] = &yaml.Node{Kind: yaml.AliasNode, Value: first.Anchor, Alias: first}
+				continue
+			}
+			a.first[hash] = child
+		}
+		a.replace(child, childKey)
+	}
+}
+
+var anchorCharacters = regexp.MustCompile("[^A-Za-z0-9_-]+")
+
+// Returns a unique anchor name for the value of a key.
+func (a *anchorer) anchorName(key string) string {
+	base := strings.Trim(anchorCharacters.ReplaceAllString(key, "-"), "-")
+	if base == "" {
+		base = "value"
+	}
+	name := base
+	for i := 2; a.anchors[name]; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	a.anchors[name] = true
+	return name
+}
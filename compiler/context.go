@@ -14,22 +14,93 @@
 
 package compiler
 
+import (
+	"strconv"
+	"strings"
+)
+
 type Context struct {
 	Parent            *Context
 	Name              string
 	ExtensionHandlers *[]ExtensionHandler
+	// Allocator allocates the messages of a model, such as an Arena
+	// of a generated model package. If it is nil, messages are
+	// allocated individually. Child contexts share their parent's.
+	Allocator interface{}
+	// Options configure the compilation; child contexts share their parent's.
+	Options *CompilerOptions
+	// set for the contexts of the targets of references
+	reference bool
+	// set for contexts that don't correspond to values in the document
+	wrapper bool
+	// the index of an array item, or -1 for contexts that aren't array items
+	index int
 }
 
 func NewContextWithExtensions(name string, parent *Context, extensionHandlers *[]ExtensionHandler) *Context {
-	return &Context{Name: name, Parent: parent, ExtensionHandlers: extensionHandlers}
+	context := &Context{Name: name, Parent: parent, ExtensionHandlers: extensionHandlers, index: -1}
+	if parent != nil {
+		context.Allocator = parent.Allocator
+		context.Options = parent.Options
+	}
+	return context
 }
 
 func NewContext(name string, parent *Context) *Context {
 	if parent != nil {
-		return &Context{Name: name, Parent: parent, ExtensionHandlers: parent.ExtensionHandlers}
+		return &Context{Name: name, Parent: parent, ExtensionHandlers: parent.ExtensionHandlers, Allocator: parent.Allocator, Options: parent.Options, index: -1}
 	} else {
-		return &Context{Name: name, Parent: parent, ExtensionHandlers: nil}
+		return &Context{Name: name, Parent: parent, ExtensionHandlers: nil, index: -1}
+	}
+}
+
+// NewReferenceContext creates the context of the target of a reference
+// that is followed when references are resolved. Chains of these contexts
+// are limited by the MaxReferenceDepth option.
+func NewReferenceContext(ref string, parent *Context) *Context {
+	context := NewContext(ref, parent)
+	context.reference = true
+	return context
+}
+
+// NewItemContext creates the context of an item of an array.
+// Its description is the name of the array.
+func NewItemContext(name string, index int, parent *Context) *Context {
+	context := NewContext(name, parent)
+	context.index = index
+	return context
+}
+
+// NewWrapperContext creates the context of a message that wraps one of the
+// possible values of a field. It is described by the name of the wrapped
+// type but doesn't correspond to a value in the document.
+func NewWrapperContext(name string, parent *Context) *Context {
+	context := NewContext(name, parent)
+	context.wrapper = true
+	return context
+}
+
+// IsFollowing returns true if a reference is one of the references that
+// were followed to reach a context. Resolving it again would never end, so
+// recursive references, such as the items of a tree, are left unresolved.
+func (context *Context) IsFollowing(ref string) bool {
+	for c := context; c != nil; c = c.Parent {
+		if c.reference && c.Name == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the number of references that were followed to reach a context.
+func (context *Context) referenceDepth() int {
+	depth := 0
+	for c := context; c != nil; c = c.Parent {
+		if c.reference {
+			depth++
+		}
 	}
+	return depth
 }
 
 func (context *Context) Description() string {
@@ -39,3 +110,42 @@ func (context *Context) Description() string {
 		return context.Name
 	}
 }
+
+// Pointer returns the location of a context in its document as a JSON Pointer.
+// For the targets of references, it is the location in the referenced file.
+func (context *Context) Pointer() string {
+	segments := make([]string, 0)
+	for c := context; c != nil; c = c.Parent {
+		if c.reference {
+			// the name of a reference context is the reference
+			if i := strings.Index(c.Name, "#"); i >= 0 {
+				segments = append(segments, strings.TrimPrefix(c.Name[i+1:], "/"))
+			}
+			break
+		}
+		if c.Parent == nil {
+			break // the root context is the document itself
+		}
+		if c.index >= 0 {
+			segments = append(segments, strconv.Itoa(c.index))
+		}
+		if !c.wrapper {
+			segments = append(segments, pointerEscaper.Replace(c.Name))
+		}
+	}
+	pointer := ""
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] != "" {
+			pointer += "/" + segments[i]
+		}
+	}
+	return pointer
+}
+
+// escapes the characters that have special meanings in JSON Pointers
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// PointerEscape escapes a name for use as a segment of a JSON Pointer.
+func PointerEscape(name string) string {
+	return pointerEscaper.Replace(name)
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The ANSI escape sequences that color the severities of diagnostics.
+var severityColors = map[Severity]string{
+	SeverityError:   "\x1b[1;31m",
+	SeverityWarning: "\x1b[1;33m",
+	SeverityInfo:    "\x1b[1;36m",
+}
+
+const (
+	colorReset = "\x1b[0m"
+	colorDim   = "\x1b[2m"
+)
+
+// FormatDiagnostics describes the errors in an error like Error does, and
+// follows each error that has a position in the source of the description
+// with the line that it is on and a caret under its column, the way that
+// compilers describe the errors in programs. If color is true, severities
+// are colored with ANSI escape sequences. Errors in the targets of
+// references have no excerpts, since they are in other files.
+func FormatDiagnostics(err error, source []byte, color bool) string {
+	var lines []string
+	if len(source) > 0 {
+		lines = strings.Split(string(source), "\n")
+	}
+	var b strings.Builder
+	for i, e := range ErrorList(err) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		message := e.Error()
+		if color {
+			severity := e.Severity.String()
+			message = severityColors[e.Severity] + severity + colorReset + strings.TrimPrefix(message, severity)
+		}
+		b.WriteString(message)
+		if e.Line > 0 && e.Line <= len(lines) && !e.InReference() {
+			b.WriteString(excerpt(lines[e.Line-1], e.Line, e.Column, e.Severity, color))
+		}
+	}
+	return b.String()
+}
+
+// Returns the excerpt of a line that marks a column with a caret.
+func excerpt(line string, number int, column int, severity Severity, color bool) string {
+	line = strings.TrimRight(line, "\r")
+	gutter := fmt.Sprintf("%d", number)
+	// tabs are kept so that the caret lines up with the text above it
+	var indent strings.Builder
+	for i, r := range line {
+		if utf8.RuneCountInString(line[:i]) >= column-1 {
+			break
+		}
+		if r == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+	caret := "^"
+	bar := " | "
+	if color {
+		caret = severityColors[severity] + caret + colorReset
+		bar = colorDim + bar + colorReset
+	}
+	return fmt.Sprintf("\n  %s%s%s\n  %s%s%s%s", gutter, bar, line, strings.Repeat(" ", len(gutter)), bar, indent.String(), caret)
+}
+
+// InReference returns true if an error was found in the target of a
+// reference, so that its position is in another file.
+func (err *Error) InReference() bool {
+	for context := err.Context; context != nil; context = context.Parent {
+		if context.reference {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeEnd returns the position just past the end of a node in its source
+// file. The parser records only where nodes begin, so their ends are found
+// from their values: the end of a collection is the end of its last value,
+// since the positions of the brackets that close flow collections are
+// unknown. The column is zero when only the line of the end is known, as
+// for block scalars, and both are zero for nodes that have no position.
+func NodeEnd(node *yaml.Node) (line int, column int) {
+	if node == nil || node.Line == 0 {
+		return 0, 0
+	}
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.MappingNode, yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			// empty collections are written as "{}" or "[]"
+			return node.Line, node.Column + 2
+		}
+		return NodeEnd(node.Content[len(node.Content)-1])
+	case yaml.AliasNode:
+		return node.Line, node.Column + 1 + utf8.RuneCountInString(node.Value)
+	}
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		// the text of a block scalar begins on the line after its indicator
+		return node.Line + strings.Count(strings.TrimRight(node.Value, "\n"), "\n") + 1, 0
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		return node.Line, node.Column + doubleQuotedWidth(node.Value)
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		// single quotes are escaped by doubling them
+		return node.Line, node.Column + 2 + utf8.RuneCountInString(node.Value) + strings.Count(node.Value, "'")
+	}
+	return node.Line, node.Column + utf8.RuneCountInString(node.Value)
+}
+
+// Returns the number of characters in a string that is written in double
+// quotes, with its special characters escaped the way that JSON escapes them.
+func doubleQuotedWidth(s string) int {
+	width := 2
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\' || r == '\n' || r == '\r' || r == '\t' || r == '\b' || r == '\f':
+			width += 2
+		case r < 0x20:
+			width += 6
+		default:
+			width++
+		}
+	}
+	return width
+}
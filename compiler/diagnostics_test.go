@@ -0,0 +1,77 @@
+package compiler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestFormatDiagnostics(t *testing.T) {
+	source := []byte("swagger: \"2.0\"\ninfo:\n\ttitle: Pets\n  version: 1\n")
+	err := &ErrorGroup{Errors: []error{
+		&Error{Context: NewContext("info", NewContext("$root", nil)), Message: "has a tab", Line: 3, Column: 2},
+		&Error{Message: "is odd", Severity: SeverityWarning, Line: 4, Column: 3},
+		&Error{Context: NewReferenceContext("other.yaml#/Pet", nil), Message: "is elsewhere", Line: 1, Column: 1},
+		errors.New("has no position"),
+	}}
+	expected := "ERROR $root.info has a tab\n" +
+		"  3 | \ttitle: Pets\n" +
+		"    | \t^\n" +
+		"WARNING is odd\n" +
+		"  4 |   version: 1\n" +
+		"    |   ^\n" +
+		"ERROR other.yaml#/Pet is elsewhere\n" +
+		"ERROR has no position"
+	if text := FormatDiagnostics(err, source, false); text != expected {
+		t.Errorf("Unexpected diagnostics:\n%s\nexpected:\n%s", text, expected)
+	}
+	colored := FormatDiagnostics(err, source, true)
+	if !strings.HasPrefix(colored, "\x1b[1;31mERROR\x1b[0m $root.info has a tab") || !strings.Contains(colored, "\x1b[1;33mWARNING\x1b[0m is odd") {
+		t.Errorf("Unexpected colored diagnostics:\n%q", colored)
+	}
+}
+
+func TestNodeEnd(t *testing.T) {
+	source := `plain: value
+quoted: "a \"b\""
+single: 'it''s'
+empty: {}
+list: [a, bc]
+block: |
+  one
+  two
+last: 1
+`
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(source), &document); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	root := document.Content[0]
+	for _, expected := range []struct {
+		key    string
+		line   int
+		column int
+	}{
+		{"plain", 1, 13},
+		{"quoted", 2, 18},
+		{"single", 3, 16},
+		{"empty", 4, 10},
+		{"list", 5, 13},
+		{"block", 8, 0},
+		{"last", 9, 8},
+	} {
+		line, column := NodeEnd(MapValueForKey(root, expected.key))
+		if line != expected.line || column != expected.column {
+			t.Errorf("Expected %s to end at %d:%d, found %d:%d", expected.key, expected.line, expected.column, line, column)
+		}
+	}
+	// collections end with their last values
+	if line, column := NodeEnd(&document); line != 9 || column != 8 {
+		t.Errorf("Expected the document to end at 9:8, found %d:%d", line, column)
+	}
+	if line, column := NodeEnd(NewScalarNodeForString("unplaced")); line != 0 || column != 0 {
+		t.Errorf("Expected nodes without positions to have no ends, found %d:%d", line, column)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+// Difference describes a value that differs between two models. It is
+// returned by the generated Diff methods.
+type Difference struct {
+	// Path is the location of the value, in the form used in error messages.
+	Path string
+	// Old is the value in the first model, or nil if the value was added.
+	Old interface{}
+	// New is the value in the second model, or nil if the value was removed.
+	New interface{}
+}
+
+// NewDifference creates a Difference. Nil pointers and slices are
+// stored as nil, so that added and removed values can be recognized,
+// and wrapped scalars are stored as their values.
+func NewDifference(path string, oldValue interface{}, newValue interface{}) *Difference {
+	return &Difference{Path: path, Old: nilIfEmpty(oldValue), New: nilIfEmpty(newValue)}
+}
+
+func nilIfEmpty(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		if v.IsNil() || (v.Kind() == reflect.Slice && v.Len() == 0) {
+			return nil
+		}
+	}
+	switch w := value.(type) {
+	case *wrappers.BoolValue:
+		return w.Value
+	case *wrappers.Int64Value:
+		return w.Value
+	case *wrappers.DoubleValue:
+		return w.Value
+	}
+	return value
+}
+
+// String describes a difference. Scalar values are included in the
+// description; messages are not.
+func (d *Difference) String() string {
+	switch {
+	case d.Old == nil:
+		return fmt.Sprintf("%s was added", d.Path)
+	case d.New == nil:
+		return fmt.Sprintf("%s was removed", d.Path)
+	case reflect.ValueOf(d.Old).Kind() == reflect.Ptr || reflect.ValueOf(d.New).Kind() == reflect.Ptr:
+		return fmt.Sprintf("%s was changed", d.Path)
+	}
+	return fmt.Sprintf("%s was changed from %s to %s", d.Path, displayValue(d.Old), displayValue(d.New))
+}
+
+// Returns a short description of a scalar value or a list of scalars.
+func displayValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// EqualAny returns true if two protocol buffer Any values are equal.
+// It is used by the generated Diff methods.
+func EqualAny(a *any.Any, b *any.Any) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.TypeUrl == b.TypeUrl && bytes.Equal(a.Value, b.Value)
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Returns the root nodes of the documents in a YAML stream, with their
+// aliases expanded and their keys converted to strings, and the warnings
+// about the keys that were converted. Streams in other encodings are read
+// as UTF-8. Streams that are empty are read as one empty document.
+func readDocuments(data []byte) ([]*yaml.Node, []*Error, error) {
+	data, err := NormalizeEncoding(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	infos := make([]*yaml.Node, 0)
+	warnings := make([]*Error, 0)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		expanded, err := expandAliases(&document)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = append(warnings, normalizeKeys(expanded)...)
+		info := expanded
+		if expanded.Kind == yaml.DocumentNode && len(expanded.Content) == 1 {
+			info = expanded.Content[0]
+		}
+		infos = append(infos, info)
+	}
+	if len(infos) == 0 {
+		infos = append(infos, &yaml.Node{})
+	}
+	return infos, warnings, nil
+}
+
+// Merges the documents of a YAML stream into one document. Pipelines that
+// concatenate fragments of a description, such as its paths and its
+// components, produce streams that are merged into the complete description.
+// Maps are merged recursively and lists are concatenated, except that items
+// that are already in a list, such as the tags and parameters of fragments
+// that overlap, are added once. Other values can appear in more than one
+// document only if they are the same in each.
+func mergeDocuments(infos []*yaml.Node) (*yaml.Node, error) {
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i, info := range infos {
+		if info.Kind == 0 {
+			// empty documents, such as those after a trailing "---", add nothing
+			continue
+		}
+		if info.Kind != yaml.MappingNode {
+			return nil, errors.New(fmt.Sprintf("document %d at line %d can't be merged because it isn't a map", i+1, info.Line))
+		}
+		if err := mergeMaps(merged, info); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// Adds the entries of one map to another.
+func mergeMaps(target, source *yaml.Node) error {
+	for i := 0; i+1 < len(source.Content); i += 2 {
+		key, value := source.Content[i], source.Content[i+1]
+		existing := MapValueForKey(target, key.Value)
+		switch {
+		case existing == nil:
+			target.Content = append(target.Content, key, copyNode(value))
+		case existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			if err := mergeMaps(existing, value); err != nil {
+				return err
+			}
+		case existing.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+			items := make(map[string]bool)
+			for _, item := range existing.Content {
+				items[string(Marshal(item))] = true
+			}
+			for _, item := range value.Content {
+				if text := string(Marshal(item)); !items[text] {
+					items[text] = true
+					existing.Content = append(existing.Content, copyNode(item))
+				}
+			}
+		case existing.Kind == yaml.ScalarNode && value.Kind == yaml.ScalarNode && existing.Value == value.Value:
+		default:
+			return errors.New(fmt.Sprintf("%s at line %d, column %d conflicts with the value at line %d, column %d", key.Value, key.Line, key.Column, existing.Line, existing.Column))
+		}
+	}
+	return nil
+}
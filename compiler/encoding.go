@@ -0,0 +1,90 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf32BEBOM = []byte{0x00, 0x00, 0xFE, 0xFF}
+	utf32LEBOM = []byte{0xFF, 0xFE, 0x00, 0x00}
+)
+
+// NormalizeEncoding returns the text of a description as UTF-8 without a byte
+// order mark. Descriptions that are exported by some tools are written in
+// UTF-16 or UTF-32, or in UTF-8 with a byte order mark, which JSON decoders
+// reject. The encoding is detected from the byte order mark, or for UTF-16
+// text without one, from the zero bytes of its first character, since
+// descriptions begin with ASCII characters. Text in UTF-8 is returned as it is.
+func NormalizeEncoding(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		return data[len(utf8BOM):], nil
+	case bytes.HasPrefix(data, utf32BEBOM):
+		return decodeUTF32(data[len(utf32BEBOM):], binary.BigEndian)
+	case bytes.HasPrefix(data, utf32LEBOM):
+		return decodeUTF32(data[len(utf32LEBOM):], binary.LittleEndian)
+	case bytes.HasPrefix(data, utf16BEBOM):
+		return decodeUTF16(data[len(utf16BEBOM):], binary.BigEndian)
+	case bytes.HasPrefix(data, utf16LEBOM):
+		return decodeUTF16(data[len(utf16LEBOM):], binary.LittleEndian)
+	case len(data) >= 2 && data[0] == 0 && data[1] != 0:
+		return decodeUTF16(data, binary.BigEndian)
+	case len(data) >= 2 && data[0] != 0 && data[1] == 0:
+		return decodeUTF16(data, binary.LittleEndian)
+	}
+	return data, nil
+}
+
+// Returns true if the first bytes of a description show that it is written
+// in UTF-16 or UTF-32, which must be decoded by NormalizeEncoding.
+func isWideEncoding(prefix []byte) bool {
+	return bytes.HasPrefix(prefix, utf16BEBOM) || bytes.HasPrefix(prefix, utf16LEBOM) || bytes.IndexByte(prefix, 0) >= 0
+}
+
+func decodeUTF16(data []byte, order binary.ByteOrder) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, errors.New(fmt.Sprintf("UTF-16 text has an odd number of bytes (%d)", len(data)))
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return []byte(string(utf16.Decode(units))), nil
+}
+
+func decodeUTF32(data []byte, order binary.ByteOrder) ([]byte, error) {
+	if len(data)%4 != 0 {
+		return nil, errors.New(fmt.Sprintf("UTF-32 text has a number of bytes (%d) that isn't a multiple of 4", len(data)))
+	}
+	result := make([]byte, 0, len(data)/4)
+	for i := 0; i < len(data); i += 4 {
+		r := rune(order.Uint32(data[i:]))
+		if !utf8.ValidRune(r) {
+			return nil, errors.New(fmt.Sprintf("UTF-32 text has an invalid character %#x at byte %d", r, i))
+		}
+		result = append(result, string(r)...)
+	}
+	return result, nil
+}
@@ -14,21 +14,117 @@
 
 package compiler
 
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity indicates how serious a reported problem is.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (severity Severity) String() string {
+	switch severity {
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityInfo:
+		return "INFO"
+	default:
+		return "ERROR"
+	}
+}
+
+// Codes that identify the kinds of errors that compilers report.
+const (
+	ErrorCodeUnexpectedValue     = "unexpected-value"
+	ErrorCodeMissingProperty     = "missing-property"
+	ErrorCodeInvalidProperty     = "invalid-property"
+	ErrorCodeUnresolvedReference = "unresolved-reference"
+	ErrorCodeReferenceDepth      = "reference-depth"
+	ErrorCodeNonStringKey        = "non-string-key"
+	ErrorCodeNonScalarKey        = "non-scalar-key"
+)
+
 // basic error type
 type Error struct {
-	Context *Context
-	Message string
+	Context  *Context
+	Message  string
+	Severity Severity
+	// Code identifies the kind of error, such as ErrorCodeMissingProperty.
+	// It is empty for errors that don't have a code.
+	Code string
+	// Line and Column are the position of the value that caused the error
+	// in its source file. They are zero when the position is unknown.
+	Line   int
+	Column int
+	// EndLine and EndColumn are the position just past the end of that value,
+	// as found by NodeEnd. They are zero when the end is unknown.
+	EndLine   int
+	EndColumn int
 }
 
 func NewError(context *Context, message string) *Error {
 	return &Error{Context: context, Message: message}
 }
 
+// NewErrorForNode creates an error with a code and the position
+// of the node that caused it. The node may be nil.
+func NewErrorForNode(context *Context, node *yaml.Node, code string, message string) *Error {
+	err := &Error{Context: context, Message: message, Code: code}
+	if node != nil {
+		err.Line, err.Column = node.Line, node.Column
+		err.EndLine, err.EndColumn = NodeEnd(node)
+	}
+	return err
+}
+
 func (err *Error) Error() string {
 	if err.Context != nil {
-		return "ERROR " + err.Context.Description() + " " + err.Message
+		return err.Severity.String() + " " + err.Context.Description() + " " + err.Message
 	} else {
-		return "ERROR " + err.Message
+		return err.Severity.String() + " " + err.Message
+	}
+}
+
+// Path returns the location of the value in which an error was found as a
+// JSON Pointer. Errors about the properties of an object are located at the
+// object; their Line and Column are the position of the property's value,
+// or of the key of the first property that isn't allowed.
+func (err *Error) Path() string {
+	return err.Context.Pointer()
+}
+
+// Position returns the location of an error in its source file
+// as "line:column", or an empty string if it is unknown.
+func (err *Error) Position() string {
+	if err.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", err.Line, err.Column)
+}
+
+// ErrorList returns the errors in an error and any groups that it contains.
+// Errors that were not reported by a compiler are converted to compiler errors.
+func ErrorList(err error) []*Error {
+	switch err := err.(type) {
+	case nil:
+		return nil
+	case *Error:
+		return []*Error{err}
+	case *ErrorGroup:
+		list := make([]*Error, 0, len(err.Errors))
+		for _, e := range err.Errors {
+			list = append(list, ErrorList(e)...)
+		}
+		return list
+	default:
+		return []*Error{&Error{Message: err.Error()}}
 	}
 }
 
@@ -26,21 +26,21 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/any"
 	ext_plugin "github.com/googleapis/gnostic/extensions"
-	yaml "gopkg.in/yaml.v2"
+	yaml "gopkg.in/yaml.v3"
 )
 
 type ExtensionHandler struct {
 	Name string
 }
 
-func HandleExtension(context *Context, in interface{}, extensionName string) (bool, *any.Any, error) {
+func HandleExtension(context *Context, in *yaml.Node, extensionName string) (bool, *any.Any, error) {
 	handled := false
 	var errFromPlugin error
 	var outFromPlugin *any.Any
 
 	if context.ExtensionHandlers != nil && len(*(context.ExtensionHandlers)) != 0 {
 		for _, customAnyProtoGenerator := range *(context.ExtensionHandlers) {
-			outFromPlugin, errFromPlugin = customAnyProtoGenerator.handle(in, extensionName)
+			outFromPlugin, errFromPlugin = customAnyProtoGenerator.handle(in, extensionName, OptionsForContext(context))
 			if outFromPlugin == nil {
 				continue
 			} else {
@@ -52,9 +52,9 @@ func HandleExtension(context *Context, in interface{}, extensionName string) (bo
 	return handled, outFromPlugin, errFromPlugin
 }
 
-func (extensionHandlers *ExtensionHandler) handle(in interface{}, extensionName string) (*any.Any, error) {
+func (extensionHandlers *ExtensionHandler) handle(in *yaml.Node, extensionName string, options *CompilerOptions) (*any.Any, error) {
 	if extensionHandlers.Name != "" {
-		binary, _ := yaml.Marshal(in)
+		binary := Marshal(in)
 
 		request := &ext_plugin.ExtensionHandlerRequest{}
 
@@ -76,21 +76,20 @@ func (extensionHandlers *ExtensionHandler) handle(in interface{}, extensionName
 		output, err := cmd.Output()
 
 		if err != nil {
-			fmt.Printf("Error: %+v\n", err)
+			options.logf("Error running %s: %+v", extensionHandlers.Name, err)
 			return nil, err
 		}
 		response := &ext_plugin.ExtensionHandlerResponse{}
 		err = proto.Unmarshal(output, response)
 		if err != nil {
-			fmt.Printf("Error: %+v\n", err)
-			fmt.Printf("%s\n", string(output))
+			options.logf("Error reading the response of %s: %+v\n%s", extensionHandlers.Name, err, string(output))
 			return nil, err
 		}
 		if !response.Handled {
 			return nil, nil
 		}
 		if len(response.Error) != 0 {
-			message := fmt.Sprintf("Errors when parsing: %+v for field %s by vendor extension handler %s. Details %+v", in, extensionName, extensionHandlers.Name, strings.Join(response.Error, ","))
+			message := fmt.Sprintf("Errors when parsing: %+v for field %s by vendor extension handler %s. Details %+v", Display(in), extensionName, extensionHandlers.Name, strings.Join(response.Error, ","))
 			return nil, errors.New(message)
 		}
 		return response.Value, nil
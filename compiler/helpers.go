@@ -15,58 +15,276 @@
 package compiler
 
 import (
+	"bytes"
 	"fmt"
-	"gopkg.in/yaml.v2"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"gopkg.in/yaml.v3"
 )
 
 // compiler helper functions, usually called from generated code
 
-func UnpackMap(in interface{}) (yaml.MapSlice, bool) {
-	m, ok := in.(yaml.MapSlice)
-	if ok {
-		return m, ok
-	} else {
-		// do we have an empty array?
-		a, ok := in.([]interface{})
-		if ok && len(a) == 0 {
-			// if so, return an empty map
-			return yaml.MapSlice{}, ok
-		} else {
-			return nil, ok
+// Returns the node that a node represents, following aliases
+// and unwrapping documents.
+func resolveNode(in *yaml.Node) *yaml.Node {
+	for in != nil {
+		switch in.Kind {
+		case yaml.AliasNode:
+			in = in.Alias
+		case yaml.DocumentNode:
+			if len(in.Content) == 0 {
+				return nil
+			}
+			in = in.Content[0]
+		default:
+			return in
+		}
+	}
+	return nil
+}
+
+// UnpackMap returns a node if it is a mapping node.
+func UnpackMap(in *yaml.Node) (*yaml.Node, bool) {
+	in = resolveNode(in)
+	if in == nil {
+		return nil, false
+	}
+	if in.Kind == yaml.MappingNode {
+		return in, true
+	}
+	// do we have an empty array?
+	if in.Kind == yaml.SequenceNode && len(in.Content) == 0 {
+		// if so, return an empty map
+		return NewMappingNode(), true
+	}
+	return nil, false
+}
+
+// SequenceNodeForNode returns a node if it is a sequence node.
+func SequenceNodeForNode(in *yaml.Node) (*yaml.Node, bool) {
+	in = resolveNode(in)
+	if in == nil || in.Kind != yaml.SequenceNode {
+		return nil, false
+	}
+	return in, true
+}
+
+// Returns the members of a sequence node, following aliases.
+func SequenceItems(in *yaml.Node) []*yaml.Node {
+	in, ok := SequenceNodeForNode(in)
+	if !ok {
+		return make([]*yaml.Node, 0)
+	}
+	items := make([]*yaml.Node, 0, len(in.Content))
+	for _, item := range in.Content {
+		items = append(items, resolveNode(item))
+	}
+	return items
+}
+
+// Returns a scalar node and its resolved tag.
+func scalarNodeAndTag(in *yaml.Node) (*yaml.Node, string) {
+	in = resolveNode(in)
+	if in == nil || in.Kind != yaml.ScalarNode {
+		return nil, ""
+	}
+	return in, in.ShortTag()
+}
+
+// StringForScalarNode returns the value of a string scalar.
+func StringForScalarNode(in *yaml.Node) (string, bool) {
+	in, tag := scalarNodeAndTag(in)
+	if tag != "!!str" {
+		return "", false
+	}
+	return in.Value, true
+}
+
+// TypeForNode returns the type of a schema and whether the schema is nullable.
+// The type is either a string or, as in OpenAPI 3.1, a list with one type that
+// may be accompanied by "null", which makes the schema nullable.
+func TypeForNode(in *yaml.Node) (string, bool, bool) {
+	if s, ok := StringForScalarNode(in); ok {
+		return s, false, true
+	}
+	in, ok := SequenceNodeForNode(in)
+	if !ok {
+		return "", false, false
+	}
+	typeName := ""
+	nullable := false
+	for _, item := range in.Content {
+		s, ok := StringForScalarNode(item)
+		if !ok {
+			return "", false, false
+		}
+		if s == "null" {
+			nullable = true
+		} else if typeName == "" {
+			typeName = s
+		} else if s != typeName {
+			return "", false, false
+		}
+	}
+	if typeName == "" {
+		return "", false, false
+	}
+	return typeName, nullable, true
+}
+
+// KeyForNode returns the key of a map entry as a string. YAML allows keys of
+// any type, and descriptions often use unquoted numbers such as response codes
+// as keys, so scalar keys of every type are converted to strings with the text
+// that appears in the document. Keys that aren't scalars can't be converted.
+func KeyForNode(in *yaml.Node) (string, bool) {
+	in, tag := scalarNodeAndTag(in)
+	if tag == "" {
+		return "", false
+	}
+	return in.Value, true
+}
+
+// BoolForScalarNode returns the value of a boolean scalar.
+func BoolForScalarNode(in *yaml.Node) (bool, bool) {
+	in, tag := scalarNodeAndTag(in)
+	if tag != "!!bool" {
+		return false, false
+	}
+	var b bool
+	if err := in.Decode(&b); err != nil {
+		return false, false
+	}
+	return b, true
+}
+
+// IntForScalarNode returns the value of an integer scalar.
+func IntForScalarNode(in *yaml.Node) (int64, bool) {
+	in, tag := scalarNodeAndTag(in)
+	if tag != "!!int" {
+		return 0, false
+	}
+	var i int64
+	if err := in.Decode(&i); err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+// FloatForScalarNode returns the value of a numeric scalar.
+func FloatForScalarNode(in *yaml.Node) (float64, bool) {
+	in, tag := scalarNodeAndTag(in)
+	if tag != "!!float" && tag != "!!int" {
+		return 0, false
+	}
+	var f float64
+	if err := in.Decode(&f); err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// StringArrayForSequenceNode returns the string values in a sequence node.
+func StringArrayForSequenceNode(in *yaml.Node) []string {
+	in, ok := SequenceNodeForNode(in)
+	if !ok {
+		return make([]string, 0)
+	}
+	stringArray := make([]string, 0, len(in.Content))
+	for _, item := range in.Content {
+		if v, ok := StringForScalarNode(item); ok {
+			stringArray = append(stringArray, v)
 		}
 	}
+	return stringArray
 }
 
-func SortedKeysForMap(m yaml.MapSlice) []string {
-	keys := make([]string, 0)
-	for _, item := range m {
-		keys = append(keys, item.Key.(string))
+func SortedKeysForMap(m *yaml.Node) []string {
+	m, ok := UnpackMap(m)
+	if !ok {
+		return make([]string, 0)
+	}
+	keys := make([]string, 0, len(m.Content)/2)
+	for i := 0; i < len(m.Content); i += 2 {
+		if key, ok := KeyForNode(m.Content[i]); ok {
+			keys = append(keys, key)
+		}
 	}
 	sort.Strings(keys)
 	return keys
 }
 
-func MapHasKey(m yaml.MapSlice, key string) bool {
-	for _, item := range m {
-		itemKey, ok := item.Key.(string)
+func MapHasKey(m *yaml.Node, key string) bool {
+	return MapValueForKey(m, key) != nil
+}
+
+func MapValueForKey(m *yaml.Node, key string) *yaml.Node {
+	m, ok := UnpackMap(m)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < len(m.Content); i += 2 {
+		itemKey, ok := KeyForNode(m.Content[i])
 		if ok && key == itemKey {
-			return true
+			return resolveNode(m.Content[i+1])
 		}
 	}
-	return false
+	return nil
 }
 
-func MapValueForKey(m yaml.MapSlice, key string) interface{} {
-	for _, item := range m {
-		itemKey, ok := item.Key.(string)
-		if ok && key == itemKey {
-			return item.Value
+// Maps with no more than this many entries are scanned rather than indexed,
+// since building an index for them costs more than it saves.
+const mapIndexThreshold = 8
+
+// MapIndex finds the values of a mapping node by key. Generated compilers
+// look up each field of a type in the node that they are compiling, so for
+// nodes with many entries, an index is built once instead of scanning the
+// node for every field.
+type MapIndex struct {
+	node   *yaml.Node
+	values map[string]*yaml.Node
+}
+
+// NewMapIndex creates a MapIndex for a mapping node.
+func NewMapIndex(m *yaml.Node) MapIndex {
+	index := MapIndex{node: m}
+	if m != nil && len(m.Content)/2 > mapIndexThreshold {
+		index.values = make(map[string]*yaml.Node, len(m.Content)/2)
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			key, ok := KeyForNode(m.Content[i])
+			if !ok {
+				continue
+			}
+			// like MapValueForKey, use the first value for a repeated key
+			if _, found := index.values[key]; !found {
+				index.values[key] = resolveNode(m.Content[i+1])
+			}
 		}
 	}
-	return nil
+	return index
+}
+
+// ValueForKey returns the value of a key in the indexed node, or nil if there is none.
+func (index *MapIndex) ValueForKey(key string) *yaml.Node {
+	if index.values != nil {
+		return index.values[key]
+	}
+	return MapValueForKey(index.node, key)
+}
+
+// MissingKeys returns the keys in a list that are not in the indexed node.
+func (index *MapIndex) MissingKeys(requiredKeys []string) []string {
+	missingKeys := make([]string, 0)
+	for _, k := range requiredKeys {
+		if index.ValueForKey(k) == nil {
+			missingKeys = append(missingKeys, k)
+		}
+	}
+	return missingKeys
 }
 
 func ConvertInterfaceArrayToStringArray(interfaceArray []interface{}) []string {
@@ -80,23 +298,44 @@ func ConvertInterfaceArrayToStringArray(interfaceArray []interface{}) []string {
 	return stringArray
 }
 
-func PatternMatches(pattern string, value string) bool {
-	// if pattern contains a subpattern like "{path}", replace it with ".*"
-	if pattern[0] != '^' {
-		subpatternPattern := regexp.MustCompile("^.*(\\{.*\\}).*$")
-		if matches := subpatternPattern.FindSubmatch([]byte(pattern)); matches != nil {
-			match := string(matches[1])
-			pattern = strings.Replace(pattern, match, ".*", -1)
+// matches patterns that contain a subpattern like "{path}"
+var subpatternPattern = regexp.MustCompile("^.*(\\{.*\\}).*$")
+
+// compiled patterns, keyed by pattern
+var pattern_cache = make(map[string]*regexp.Regexp, 0)
+var pattern_mutex sync.Mutex
+
+// PatternExpression returns the regular expression for a pattern that is matched
+// against map keys. If a pattern doesn't begin with "^", it may contain a subpattern
+// like "{path}", which is replaced with an expression that matches any string.
+func PatternExpression(pattern string) string {
+	if pattern != "" && pattern[0] != '^' {
+		if matches := subpatternPattern.FindStringSubmatch(pattern); matches != nil {
+			pattern = strings.Replace(pattern, matches[1], ".*", -1)
 		}
 	}
-	matched, err := regexp.Match(pattern, []byte(value))
-	if err != nil {
-		panic(err)
+	return pattern
+}
+
+// Returns the compiled expression for a pattern, compiling each pattern only once.
+func compiledPattern(pattern string) *regexp.Regexp {
+	pattern_mutex.Lock()
+	defer pattern_mutex.Unlock()
+	r, ok := pattern_cache[pattern]
+	if !ok {
+		r = regexp.MustCompile(PatternExpression(pattern))
+		pattern_cache[pattern] = r
 	}
-	return matched
+	return r
+}
+
+// PatternMatches returns true if a value matches a pattern.
+// Generated compilers use precompiled patterns instead.
+func PatternMatches(pattern string, value string) bool {
+	return compiledPattern(pattern).MatchString(value)
 }
 
-func MissingKeysInMap(m yaml.MapSlice, requiredKeys []string) []string {
+func MissingKeysInMap(m *yaml.Node, requiredKeys []string) []string {
 	missingKeys := make([]string, 0)
 	for _, k := range requiredKeys {
 		if !MapHasKey(m, k) {
@@ -106,10 +345,17 @@ func MissingKeysInMap(m yaml.MapSlice, requiredKeys []string) []string {
 	return missingKeys
 }
 
-func InvalidKeysInMap(m yaml.MapSlice, allowedKeys []string, allowedPatterns []string) []string {
+// InvalidKeysInMap returns the keys of a map that are neither allowed keys
+// nor match any of the allowed patterns. Keys that aren't scalars are
+// reported by NonScalarKeyErrors instead.
+func InvalidKeysInMap(m *yaml.Node, allowedKeys []string, allowedPatterns []*regexp.Regexp) []string {
 	invalidKeys := make([]string, 0)
-	for _, item := range m {
-		itemKey, ok := item.Key.(string)
+	m, ok := UnpackMap(m)
+	if !ok {
+		return invalidKeys
+	}
+	for i := 0; i < len(m.Content); i += 2 {
+		itemKey, ok := KeyForNode(m.Content[i])
 		if ok {
 			key := itemKey
 			found := false
@@ -123,7 +369,7 @@ func InvalidKeysInMap(m yaml.MapSlice, allowedKeys []string, allowedPatterns []s
 			if !found {
 				// does the key match an allowed pattern?
 				for _, allowedPattern := range allowedPatterns {
-					if PatternMatches(allowedPattern, key) {
+					if allowedPattern.MatchString(key) {
 						found = true
 						break
 					}
@@ -137,7 +383,40 @@ func InvalidKeysInMap(m yaml.MapSlice, allowedKeys []string, allowedPatterns []s
 	return invalidKeys
 }
 
-// describe a map (for debugging purposes)
+// NodeForInvalidKeys returns the node of the first of the invalid keys of a
+// map that were found by InvalidKeysInMap, so that errors about them are
+// reported at its position. If it can't be found, the map is returned.
+func NodeForInvalidKeys(m *yaml.Node, invalidKeys []string) *yaml.Node {
+	unpacked, ok := UnpackMap(m)
+	if !ok || len(invalidKeys) == 0 {
+		return m
+	}
+	for i := 0; i < len(unpacked.Content); i += 2 {
+		if key, ok := KeyForNode(unpacked.Content[i]); ok && key == invalidKeys[0] {
+			return unpacked.Content[i]
+		}
+	}
+	return m
+}
+
+// NonScalarKeyErrors returns an error for each key of a map that isn't a
+// scalar, such as a key written as a list or a map. These keys can't be
+// converted to the strings that name OpenAPI fields, so they are reported
+// at their own positions and are otherwise ignored.
+func NonScalarKeyErrors(m *yaml.Node, context *Context) []error {
+	var errors []error
+	m, ok := UnpackMap(m)
+	if !ok {
+		return errors
+	}
+	for i := 0; i < len(m.Content); i += 2 {
+		if _, ok := KeyForNode(m.Content[i]); !ok {
+			errors = append(errors, NewErrorForNode(context, m.Content[i], ErrorCodeNonScalarKey, "map key is not a scalar"))
+		}
+	}
+	return errors
+}
+
 func DescribeMap(in interface{}, indent string) string {
 	description := ""
 	m, ok := in.(map[string]interface{})
@@ -191,3 +470,137 @@ func StringArrayContainsValues(array []string, values []string) bool {
 	}
 	return true
 }
+
+// Display returns a description of a node that is suitable for error messages.
+func Display(in *yaml.Node) string {
+	in = resolveNode(in)
+	if in == nil {
+		return "<nil> (<nil>)"
+	}
+	if in.Kind == yaml.ScalarNode {
+		switch in.ShortTag() {
+		case "!!str":
+			return fmt.Sprintf("%s (string)", in.Value)
+		case "!!int":
+			return fmt.Sprintf("%s (int)", in.Value)
+		case "!!float":
+			return fmt.Sprintf("%s (float64)", in.Value)
+		case "!!bool":
+			return fmt.Sprintf("%s (bool)", in.Value)
+		case "!!null":
+			return "<nil> (<nil>)"
+		}
+	}
+	var value interface{}
+	if err := in.Decode(&value); err != nil {
+		return fmt.Sprintf("%+v (%T)", in, in)
+	}
+	return fmt.Sprintf("%+v (%T)", value, value)
+}
+
+// Marshal returns the YAML text for a node.
+// The node is written in block style regardless of the style that it was read with.
+func Marshal(in *yaml.Node) []byte {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	err := encoder.Encode(copyWithoutStyle(in))
+	if err != nil {
+		return nil
+	}
+	encoder.Close()
+	return buffer.Bytes()
+}
+
+// Returns a copy of a node tree with all presentation styles removed.
+// Aliases are kept if the anchors that they refer to precede them in the
+// tree, and are replaced by copies of the values that they refer to otherwise.
+func copyWithoutStyle(in *yaml.Node) *yaml.Node {
+	return copyWithoutStyleAndAnchors(in, make(map[*yaml.Node]bool))
+}
+
+func copyWithoutStyleAndAnchors(in *yaml.Node, anchored map[*yaml.Node]bool) *yaml.Node {
+	if in != nil && in.Kind == yaml.AliasNode && anchored[in.Alias] {
+		return &yaml.Node{Kind: yaml.AliasNode, Value: in.Alias.Anchor, Alias: in.Alias}
+	}
+	in = resolveNode(in)
+	if in == nil {
+		return NewNullNode()
+	}
+	out := &yaml.Node{Kind: in.Kind, Tag: in.Tag, Value: in.Value}
+	if in.Kind == yaml.ScalarNode && in.ShortTag() == "!!str" && strings.Contains(in.Value, "\n") {
+		// multi-line strings are easier to read in literal style
+		out.Style = yaml.LiteralStyle
+	}
+	if in.Anchor != "" && !anchored[in] {
+		out.Anchor = in.Anchor
+		anchored[in] = true
+	}
+	for _, child := range in.Content {
+		out.Content = append(out.Content, copyWithoutStyleAndAnchors(child, anchored))
+	}
+	return out
+}
+
+// NewNullNode creates a new null node.
+func NewNullNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+}
+
+// NewMappingNode creates a new, empty mapping node.
+func NewMappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Content: make([]*yaml.Node, 0)}
+}
+
+// NewSequenceNode creates a new, empty sequence node.
+func NewSequenceNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Content: make([]*yaml.Node, 0)}
+}
+
+// NewScalarNodeForString creates a new string scalar node.
+func NewScalarNodeForString(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+// NewSequenceNodeForStringArray creates a new sequence of string scalar nodes.
+func NewSequenceNodeForStringArray(strings []string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Content: make([]*yaml.Node, 0, len(strings))}
+	for _, s := range strings {
+		node.Content = append(node.Content, NewScalarNodeForString(s))
+	}
+	return node
+}
+
+// NewScalarNodeForBool creates a new boolean scalar node.
+func NewScalarNodeForBool(b bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(b)}
+}
+
+// NewScalarNodeForFloat creates a new floating-point scalar node.
+// Numbers without fractions, such as 100, are written as integers.
+func NewScalarNodeForFloat(f float64) *yaml.Node {
+	value := strconv.FormatFloat(f, 'g', -1, 64)
+	if strings.IndexAny(value, ".eIN") < 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: value}
+}
+
+// NewScalarNodeForInt creates a new integer scalar node.
+func NewScalarNodeForInt(i int64) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(i, 10)}
+}
+
+// CloneAny returns a copy of a protocol buffer Any that doesn't share
+// its bytes with the original. It is used by the generated Clone methods.
+func CloneAny(value *any.Any) *any.Any {
+	if value == nil {
+		return nil
+	}
+	c := &any.Any{TypeUrl: value.TypeUrl}
+	if value.Value != nil {
+		c.Value = make([]byte, len(value.Value))
+		copy(c.Value, value.Value)
+	}
+	return c
+}
@@ -0,0 +1,131 @@
+package compiler
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestHelpers(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("{tags: [a, b], name: pet}"), &node); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	m := node.Content[0]
+	tags := MapValueForKey(m, "tags")
+	if items := SequenceItems(tags); len(items) != 2 || items[1].Value != "b" {
+		t.Errorf("Unexpected items: %v", items)
+	}
+	if strings := StringArrayForSequenceNode(tags); !reflect.DeepEqual(strings, []string{"a", "b"}) {
+		t.Errorf("Unexpected strings: %v", strings)
+	}
+	if keys := SortedKeysForMap(m); !reflect.DeepEqual(keys, []string{"name", "tags"}) {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+	// nodes of the wrong kinds have empty results, not nil ones
+	name := MapValueForKey(m, "name")
+	if items := SequenceItems(name); items == nil || len(items) != 0 {
+		t.Errorf("Unexpected items: %v", items)
+	}
+	if strings := StringArrayForSequenceNode(nil); strings == nil || len(strings) != 0 {
+		t.Errorf("Unexpected strings: %v", strings)
+	}
+	if keys := SortedKeysForMap(name); keys == nil || len(keys) != 0 {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+	if sequence := NewSequenceNodeForStringArray([]string{"a", "b"}); !reflect.DeepEqual(StringArrayForSequenceNode(sequence), []string{"a", "b"}) {
+		t.Errorf("Unexpected sequence: %v", sequence)
+	}
+}
+
+func TestMapIndex(t *testing.T) {
+	for _, text := range []string{
+		// small maps are scanned
+		"{name: pet, type: object, name: repeated, true: boolean}",
+		// large maps are indexed
+		"{name: pet, type: object, name: repeated, true: boolean, a: 1, b: 2, c: 3, d: 4, e: 5, f: 6, g: 7}",
+	} {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(text), &node); err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		index := NewMapIndex(node.Content[0])
+		// like MapValueForKey, the first value of a repeated key is used
+		if value := index.ValueForKey("name"); value == nil || value.Value != "pet" {
+			t.Errorf("Unexpected value for name in %s: %v", text, value)
+		}
+		if value := index.ValueForKey("type"); value == nil || value.Value != "object" {
+			t.Errorf("Unexpected value for type in %s: %v", text, value)
+		}
+		if value := index.ValueForKey("missing"); value != nil {
+			t.Errorf("Unexpected value for a missing key in %s: %v", text, value)
+		}
+		if missing := index.MissingKeys([]string{"name", "title", "type", "version"}); !reflect.DeepEqual(missing, []string{"title", "version"}) {
+			t.Errorf("Unexpected missing keys in %s: %v", text, missing)
+		}
+	}
+	index := NewMapIndex(nil)
+	if index.ValueForKey("name") != nil || len(index.MissingKeys([]string{"name"})) != 1 {
+		t.Errorf("Expected an index of a nil node to be empty")
+	}
+}
+
+func TestPatterns(t *testing.T) {
+	for _, test := range []struct{ pattern, expression string }{
+		{"^x-", "^x-"},
+		{"{path}", ".*"},
+		{"/{path}", "/.*"},
+		{"^([0-9]{3})$", "^([0-9]{3})$"},
+	} {
+		if expression := PatternExpression(test.pattern); expression != test.expression {
+			t.Errorf("Expected %q for %q, got %q", test.expression, test.pattern, expression)
+		}
+	}
+	// patterns are compiled once and can be matched concurrently
+	done := make(chan bool)
+	for i := 0; i < 4; i++ {
+		go func() {
+			done <- PatternMatches("/{path}", "/pets") && !PatternMatches("/{path}", "pets") && PatternMatches("^x-", "x-owner")
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		if !<-done {
+			t.Errorf("Unexpected pattern match")
+		}
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("{name: pet, x-owner: admin, colour: blue, /pets: {}}"), &node); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	patterns := []*regexp.Regexp{regexp.MustCompile("^x-"), regexp.MustCompile("^/")}
+	if invalid := InvalidKeysInMap(node.Content[0], []string{"name"}, patterns); !reflect.DeepEqual(invalid, []string{"colour"}) {
+		t.Errorf("Unexpected invalid keys: %v", invalid)
+	}
+}
+
+func TestNonScalarKeyErrors(t *testing.T) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("name: pet\n? [1, 2]\n: list\n? {a: b}\n: map\n"), &node); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	m := node.Content[0]
+	// keys that aren't scalars aren't invalid keys, since they have no names
+	if invalid := InvalidKeysInMap(m, []string{"name"}, nil); len(invalid) != 0 {
+		t.Errorf("Unexpected invalid keys: %v", invalid)
+	}
+	errors := NonScalarKeyErrors(m, NewContext("$root", nil))
+	if len(errors) != 2 {
+		t.Fatalf("Unexpected errors: %v", errors)
+	}
+	for i, position := range []string{"2:3", "4:3"} {
+		e := errors[i].(*Error)
+		if e.Code != ErrorCodeNonScalarKey || e.Position() != position || e.Error() != "ERROR $root map key is not a scalar" {
+			t.Errorf("Unexpected error: %v at %s", e, e.Position())
+		}
+	}
+	if errors := NonScalarKeyErrors(NewScalarNodeForString("pet"), nil); len(errors) != 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+}
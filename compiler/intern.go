@@ -0,0 +1,89 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"reflect"
+)
+
+// StringPool holds a single copy of each of a set of strings.
+// Large descriptions repeat the same strings, such as formats, content
+// types, and descriptions, many times. When the strings in a model are
+// interned, repeated strings share one copy, and the copies that were
+// read from source documents can be reclaimed once the YAML nodes that
+// held them are released.
+type StringPool struct {
+	strings map[string]string
+}
+
+// NewStringPool creates an empty StringPool.
+func NewStringPool() *StringPool {
+	return &StringPool{strings: make(map[string]string, 0)}
+}
+
+// Intern returns the pool's copy of a string, adding it to the pool if necessary.
+func (pool *StringPool) Intern(s string) string {
+	if interned, ok := pool.strings[s]; ok {
+		return interned
+	}
+	pool.strings[s] = s
+	return s
+}
+
+// Len returns the number of distinct strings in the pool.
+func (pool *StringPool) Len() int {
+	return len(pool.strings)
+}
+
+// InternStrings replaces every string in a model with the pool's copy.
+// The model is a pointer to a generated message, and all of the messages
+// that it contains are interned, including the values of oneofs.
+func (pool *StringPool) InternStrings(model interface{}) {
+	pool.internValue(reflect.ValueOf(model))
+}
+
+func (pool *StringPool) internValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			pool.internValue(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Field(i); field.CanSet() {
+				pool.internValue(field)
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return // []byte values are not strings
+		}
+		for i := 0; i < v.Len(); i++ {
+			pool.internValue(v.Index(i))
+		}
+	case reflect.Map:
+		// map values can't be set in place, so each value is copied
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			pool.internValue(value)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(pool.Intern(v.String()))
+		}
+	}
+}
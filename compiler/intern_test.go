@@ -0,0 +1,72 @@
+package compiler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// Returns the address of the bytes of a string.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+// Types shaped like generated messages.
+type internedOneof interface{ isInternedOneof() }
+
+type internedString struct{ Value string }
+
+func (*internedString) isInternedOneof() {}
+
+type internedMessage struct {
+	Name     string
+	Tags     []string
+	Children []*internedMessage
+	Named    map[string]*internedMessage
+	Oneof    internedOneof
+	Bytes    []byte
+	private  string
+}
+
+func TestStringPool(t *testing.T) {
+	pool := NewStringPool()
+	a := pool.Intern(strings.Repeat("pet", 2))
+	b := pool.Intern(strings.Repeat("pet", 2))
+	if a != "petpet" || stringData(a) != stringData(b) {
+		t.Errorf("Expected interned strings to share one copy")
+	}
+	pool.Intern("owner")
+	if n := pool.Len(); n != 2 {
+		t.Errorf("Expected 2 strings in the pool, found %d", n)
+	}
+}
+
+func TestInternStrings(t *testing.T) {
+	// each value is a separate copy of the same string
+	pet := func() string { return strings.Repeat("pet", 2) }
+	model := &internedMessage{
+		Name:     pet(),
+		Tags:     []string{pet(), "owner"},
+		Children: []*internedMessage{{Name: pet()}, nil},
+		Named:    map[string]*internedMessage{"first": {Name: pet()}},
+		Oneof:    &internedString{Value: pet()},
+		Bytes:    []byte("petpet"),
+		private:  pet(),
+	}
+	pool := NewStringPool()
+	pool.InternStrings(model)
+	shared := stringData(pool.Intern("petpet"))
+	for _, s := range []string{model.Name, model.Tags[0], model.Children[0].Name, model.Named["first"].Name, model.Oneof.(*internedString).Value} {
+		if s != "petpet" || stringData(s) != shared {
+			t.Errorf("Expected %q to be interned", s)
+		}
+	}
+	// unexported fields can't be set, so they aren't interned
+	if stringData(model.private) == shared {
+		t.Errorf("Unexpected interned unexported field")
+	}
+	if string(model.Bytes) != "petpet" || model.Tags[1] != "owner" || model.Children[1] != nil {
+		t.Errorf("Unexpected model %+v", model)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"fmt"
+	"strconv"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Names of the types of scalars in warnings about keys.
+var keyTypeNames = map[string]string{
+	"!!int":   "an integer",
+	"!!float": "a number",
+	"!!bool":  "a boolean",
+	"!!null":  "null",
+}
+
+// Converts the scalar keys of the maps in a parsed file to strings. YAML
+// authors often write response codes without quotes, which makes them
+// integer keys, but OpenAPI keys are always strings. Integers and booleans
+// are converted to their canonical forms, so 0x1F8 is read as "504", and
+// other scalars keep the text that they were written with. Since the
+// description doesn't mean what it says, a warning is returned for each
+// conversion. Keys that aren't scalars are left for compilers to report.
+func normalizeKeys(node *yaml.Node) []*Error {
+	warnings := make([]*Error, 0)
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode || key.ShortTag() == "!!str" || key.ShortTag() == "!!merge" {
+				continue
+			}
+			tag, value := key.ShortTag(), canonicalKey(key)
+			typeName, ok := keyTypeNames[tag]
+			if !ok {
+				typeName = "a " + tag + " value"
+			}
+			message := fmt.Sprintf("key %s is %s and is read as the string %q", key.Value, typeName, value)
+			warning := NewErrorForNode(nil, key, ErrorCodeNonStringKey, message)
+			warning.Severity = SeverityWarning
+			warnings = append(warnings, warning)
+			key.Tag, key.Value, key.Style = "!!str", value, 0
+		}
+	}
+	for _, child := range node.Content {
+		warnings = append(warnings, normalizeKeys(child)...)
+	}
+	return warnings
+}
+
+// Returns the canonical text of a scalar key.
+func canonicalKey(key *yaml.Node) string {
+	switch key.ShortTag() {
+	case "!!int":
+		var i int64
+		if key.Decode(&i) == nil {
+			return strconv.FormatInt(i, 10)
+		}
+	case "!!bool":
+		var b bool
+		if key.Decode(&b) == nil {
+			return strconv.FormatBool(b)
+		}
+	}
+	return key.Value
+}
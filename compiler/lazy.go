@@ -0,0 +1,116 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CompileFunction compiles a node into a model.
+type CompileFunction func(in *yaml.Node, context *Context) (interface{}, error)
+
+// LazyCompiler holds a parsed document and compiles the entries of its maps
+// when they are first requested. Tools that only need a few entries of a
+// large document, such as a single path or schema, can use it to avoid
+// compiling the rest. Compiled entries are saved, so each is compiled once.
+// A LazyCompiler can be used from multiple goroutines.
+type LazyCompiler struct {
+	info     *yaml.Node
+	context  *Context
+	mutex    sync.Mutex
+	compiled map[string]*lazyEntry
+}
+
+// The result of compiling a node. Entries are added before their nodes are
+// compiled, so that callers that request a node while it is being compiled
+// wait for it instead of compiling it again.
+type lazyEntry struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// NewLazyCompiler creates a LazyCompiler for a parsed document.
+func NewLazyCompiler(info *yaml.Node, context *Context) *LazyCompiler {
+	return &LazyCompiler{info: info, context: context, compiled: make(map[string]*lazyEntry, 0)}
+}
+
+// Info returns the parsed document.
+func (c *LazyCompiler) Info() *yaml.Node {
+	return c.info
+}
+
+// Returns the node at a path of keys in the document, or nil if there is none.
+func (c *LazyCompiler) nodeForPath(path []string) *yaml.Node {
+	node := c.info
+	for _, key := range path {
+		m, ok := UnpackMap(node)
+		if !ok {
+			return nil
+		}
+		node = MapValueForKey(m, key)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Keys returns the keys of the map at a path in the document, in the order
+// that they appear. It returns nil if there is no map at the path.
+func (c *LazyCompiler) Keys(path ...string) []string {
+	m, ok := UnpackMap(c.nodeForPath(path))
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(m.Content)/2)
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if key, ok := KeyForNode(m.Content[i]); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Compile returns the model for the node at a path in the document, compiling
+// it with the specified function if it has not already been compiled. Errors
+// are reported with the same context as they would be when compiling the
+// entire document. If there is no node at the path, found is false. Nodes
+// at different paths are compiled concurrently.
+func (c *LazyCompiler) Compile(path []string, compile CompileFunction) (value interface{}, found bool, err error) {
+	node := c.nodeForPath(path)
+	if node == nil {
+		return nil, false, nil
+	}
+	key := strings.Join(path, "\x00")
+	c.mutex.Lock()
+	entry, ok := c.compiled[key]
+	if !ok {
+		entry = &lazyEntry{}
+		c.compiled[key] = entry
+	}
+	c.mutex.Unlock()
+	entry.once.Do(func() {
+		context := c.context
+		for _, name := range path {
+			context = NewContext(name, context)
+		}
+		entry.value, entry.err = compile(node, context)
+	})
+	return entry.value, true, entry.err
+}
@@ -0,0 +1,93 @@
+package compiler
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func readLazyCompiler(t *testing.T, text string) *LazyCompiler {
+	info, err := ReadInfoFromBytesWithOptions("", []byte(text), &CompilerOptions{Cache: NewCache()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	return NewLazyCompiler(info, NewContext("$root", nil))
+}
+
+// Compiles a node to its context's description.
+func describeContext(in *yaml.Node, context *Context) (interface{}, error) {
+	return context.Description(), nil
+}
+
+func TestLazyCompiler(t *testing.T) {
+	c := readLazyCompiler(t, `
+paths:
+  /pets: {get: {}}
+  /pets/{id}: {get: {}}
+`)
+	if keys := c.Keys("paths"); len(keys) != 2 || keys[0] != "/pets" || keys[1] != "/pets/{id}" {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+	if keys := c.Keys("paths", "/pets", "get", "missing"); keys != nil {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+	value, found, err := c.Compile([]string{"paths", "/pets/{id}"}, describeContext)
+	if !found || err != nil || value != "$root.paths./pets/{id}" {
+		t.Errorf("Unexpected result: %v, %t, %v", value, found, err)
+	}
+	value, found, err = c.Compile([]string{"paths", "/owners"}, describeContext)
+	if found || err != nil || value != nil {
+		t.Errorf("Expected no value for a missing path, got %v, %t, %v", value, found, err)
+	}
+}
+
+func TestLazyCompilerConcurrency(t *testing.T) {
+	c := readLazyCompiler(t, `
+schemas:
+  Pet: {type: object}
+  Owner: {type: object}
+`)
+	// each node is compiled once, however many callers request it
+	var count int32
+	var wait sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			value, _, _ := c.Compile([]string{"schemas", "Pet"}, func(in *yaml.Node, context *Context) (interface{}, error) {
+				atomic.AddInt32(&count, 1)
+				return describeContext(in, context)
+			})
+			if value != "$root.schemas.Pet" {
+				t.Errorf("Unexpected value: %v", value)
+			}
+		}()
+	}
+	wait.Wait()
+	if count != 1 {
+		t.Errorf("Expected one compilation, found %d", count)
+	}
+	// nodes at different paths are compiled at the same time
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		c.Compile([]string{"schemas", "Owner"}, func(in *yaml.Node, context *Context) (interface{}, error) {
+			close(started)
+			<-done
+			return nil, nil
+		})
+	}()
+	<-started
+	go func() {
+		c.Compile([]string{"schemas", "Pet", "type"}, describeContext)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Compilations of different paths were serialized")
+	}
+}
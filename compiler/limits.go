@@ -0,0 +1,65 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+)
+
+// Returns an error if a file is larger than the maximum size allowed by a compilation's options.
+func (options *CompilerOptions) checkSize(filename string, size int64) error {
+	if options == nil || options.MaxFileSize <= 0 || size <= options.MaxFileSize {
+		return nil
+	}
+	return errors.New(fmt.Sprintf("unable to read %s: it is larger than the maximum size of %d bytes", filename, options.MaxFileSize))
+}
+
+// Reads the contents of a file or response, stopping with an error when
+// it is larger than the maximum size. Since the sizes that are reported
+// by servers can't be trusted, at most one byte more than the maximum
+// is read. A maximum of math.MaxInt64 is the same as no maximum.
+func (options *CompilerOptions) readAll(filename string, r io.Reader) ([]byte, error) {
+	if options == nil || options.MaxFileSize <= 0 || options.MaxFileSize == math.MaxInt64 {
+		return ioutil.ReadAll(r)
+	}
+	bytes, err := ioutil.ReadAll(io.LimitReader(r, options.MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if err := options.checkSize(filename, int64(len(bytes))); err != nil {
+		return nil, err
+	}
+	return bytes, nil
+}
+
+// Reads a local file, checking its size before it is read.
+func (options *CompilerOptions) readFile(filename string) ([]byte, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if fileInfo, err := file.Stat(); err == nil {
+		if err := options.checkSize(filename, fileInfo.Size()); err != nil {
+			return nil, err
+		}
+	}
+	return options.readAll(filename, file)
+}
@@ -0,0 +1,47 @@
+package compiler
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxFileSize(t *testing.T) {
+	options := func() *CompilerOptions {
+		return &CompilerOptions{Cache: NewCache(), MaxFileSize: 100, AllowPrivateNetworks: true}
+	}
+	// local files are checked before they are read
+	if _, err := ReadBytesForFileWithOptions("../examples/v2.0/yaml/petstore.yaml", options()); err == nil || !strings.Contains(err.Error(), "larger than the maximum size of 100 bytes") {
+		t.Errorf("Expected an error reading a large file, got %v", err)
+	}
+	// responses are checked as they are read, since their sizes may not be known
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			w.(http.Flusher).Flush()
+		}
+		w.Write([]byte(strings.Repeat("#", 200)))
+	}))
+	defer server.Close()
+	for _, path := range []string{"/sized", "/chunked"} {
+		if _, err := ReadBytesForFileWithOptions(server.URL+path, options()); err == nil || !strings.Contains(err.Error(), "larger than the maximum size") {
+			t.Errorf("Expected an error fetching %s, got %v", path, err)
+		}
+	}
+	readFile := options()
+	readFile.ReadFile = func(filename string) ([]byte, error) { return make([]byte, 101), nil }
+	if _, err := ReadBytesForFileWithOptions("api.yaml", readFile); err == nil {
+		t.Errorf("Expected an error reading a large file with ReadFile")
+	}
+	for _, size := range []int64{1 << 20, math.MaxInt64} {
+		large := options()
+		large.MaxFileSize = size
+		if _, err := ReadBytesForFileWithOptions("../examples/v2.0/yaml/petstore.yaml", large); err != nil {
+			t.Errorf("Unexpected error: %+v", err)
+		}
+		if bytes, err := ReadBytesForFileWithOptions(server.URL+"/chunked", large); err != nil || len(bytes) != 200 {
+			t.Errorf("Expected 200 bytes with a maximum size of %d, got %d, %v", size, len(bytes), err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// The networks that references aren't fetched from unless the
+// AllowPrivateNetworks option is set: loopback, link-local, private,
+// shared (carrier-grade NAT), and unspecified addresses.
+var privateNetworks = parseNetworks(
+	"127.0.0.0/8", "::1/128",
+	"169.254.0.0/16", "fe80::/10",
+	"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7",
+	"100.64.0.0/10",
+	"0.0.0.0/8", "::/128",
+)
+
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// Returns true if an address is in one of the private networks.
+func isPrivateAddress(ip net.IP) bool {
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Refuses connections to private addresses. It is called with the address
+// that a host name was resolved to, so names that resolve to private
+// addresses, including those of redirects, are also refused.
+func refusePrivateAddresses(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isPrivateAddress(ip) {
+		return errors.New(fmt.Sprintf("references to the private network address %s are not allowed", host))
+	}
+	return nil
+}
+
+// Returns the proxy of a request. It is a variable so that tests can set
+// proxies without changing the environment, which is read only once.
+var proxyForRequest = http.ProxyFromEnvironment
+
+// The key of the context value that holds the address of the proxy of a request.
+type proxyAddressKey struct{}
+
+// Returns the address of a proxy in the form that it is dialed by transports.
+func proxyAddress(proxy *url.URL) string {
+	if proxy.Port() != "" {
+		return proxy.Host
+	}
+	port := "80"
+	switch proxy.Scheme {
+	case "https":
+		port = "443"
+	case "socks5":
+		port = "1080"
+	}
+	return net.JoinHostPort(proxy.Hostname(), port)
+}
+
+// Returns an error if a host name resolves to a private address.
+func refusePrivateHost(ctx context.Context, host string) error {
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		if isPrivateAddress(address.IP) {
+			return errors.New(fmt.Sprintf("references to the private network address %s are not allowed", address.IP))
+		}
+	}
+	return nil
+}
+
+// A publicTransport makes requests to public addresses only. Since requests
+// that are sent through proxies are dialed to the proxies and not to the
+// hosts of their URLs, the hosts of these requests are checked before they
+// are sent, and their proxies are dialed without being checked.
+type publicTransport struct {
+	transport *http.Transport
+}
+
+func (t *publicTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	proxy, err := t.transport.Proxy(request)
+	if err != nil {
+		return nil, err
+	}
+	if proxy != nil {
+		if err := refusePrivateHost(request.Context(), request.URL.Hostname()); err != nil {
+			return nil, err
+		}
+		request = request.WithContext(context.WithValue(request.Context(), proxyAddressKey{}, proxyAddress(proxy)))
+	}
+	return t.transport.RoundTrip(request)
+}
+
+// Returns a client that fetches files with the specified TLS configuration,
+// refusing connections to private addresses if public is true.
+func newClient(public bool, config *tls.Config) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	transport := &http.Transport{
+		Proxy:                 func(request *http.Request) (*url.URL, error) { return proxyForRequest(request) },
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       config,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if !public {
+		return &http.Client{Transport: transport}
+	}
+	publicDialer := *dialer
+	publicDialer.Control = refusePrivateAddresses
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if proxy, ok := ctx.Value(proxyAddressKey{}).(string); ok && proxy == address {
+			return dialer.DialContext(ctx, network, address)
+		}
+		return publicDialer.DialContext(ctx, network, address)
+	}
+	return &http.Client{Transport: &publicTransport{transport: transport}}
+}
+
+// A client that fetches references from public addresses only.
+var publicClient = newClient(true, nil)
+
+// Returns the client that fetches a file. References are fetched from
+// public addresses only unless private networks are allowed; files that
+// are named directly, such as the descriptions that are compiled, are
+// fetched from any address. Files on hosts with TLS configurations are
+// fetched with their certificates.
+func (options *CompilerOptions) httpClient(reference bool, fileurl string) (*http.Client, error) {
+	public := reference && (options == nil || !options.AllowPrivateNetworks)
+	if config := options.tlsConfigForURL(fileurl); config != nil {
+		return config.client(public)
+	}
+	if public {
+		return publicClient, nil
+	}
+	return http.DefaultClient, nil
+}
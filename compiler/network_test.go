@@ -0,0 +1,90 @@
+package compiler
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestIsPrivateAddress(t *testing.T) {
+	for address, expected := range map[string]bool{
+		"127.0.0.1":       true,
+		"::1":             true,
+		"169.254.169.254": true,
+		"10.1.2.3":        true,
+		"172.20.0.1":      true,
+		"192.168.1.1":     true,
+		"100.64.0.1":      true,
+		"100.127.255.255": true,
+		"fd00::1":         true,
+		"0.0.0.0":         true,
+		"::ffff:10.0.0.1": true,
+		"100.128.0.1":     false,
+		"8.8.8.8":         false,
+		"2001:4860::8888": false,
+	} {
+		if private := isPrivateAddress(net.ParseIP(address)); private != expected {
+			t.Errorf("isPrivateAddress(%s) = %t, expected %t", address, private, expected)
+		}
+	}
+}
+
+func TestPrivateNetworkReferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Pet: {type: object}"))
+	}))
+	defer server.Close()
+	// the test server listens on a loopback address
+	_, err := ReadBytesForReferenceWithOptions(server.URL+"/pet.yaml", &CompilerOptions{Cache: NewCache()})
+	if err == nil || !strings.Contains(err.Error(), "private network address 127.0.0.1") {
+		t.Errorf("Expected an error fetching a reference from a private address, got %v", err)
+	}
+	if _, err := ReadBytesForReferenceWithOptions(server.URL+"/pet.yaml", &CompilerOptions{Cache: NewCache(), AllowPrivateNetworks: true}); err != nil {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+	// files that are read directly are fetched from any address
+	if _, err := ReadBytesForFileWithOptions(server.URL+"/pet.yaml", &CompilerOptions{Cache: NewCache()}); err != nil {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+}
+
+func TestPrivateNetworkReferencesWithProxy(t *testing.T) {
+	var mutex sync.Mutex
+	var proxied []string
+	// the proxy listens on a loopback address and answers all requests itself
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		proxied = append(proxied, r.URL.String())
+		mutex.Unlock()
+		w.Write([]byte("Pet: {type: object}"))
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyForRequest = http.ProxyURL(proxyURL)
+	defer func() { proxyForRequest = http.ProxyFromEnvironment }()
+
+	// references to private addresses are refused before they are sent to the proxy
+	for _, fileurl := range []string{"http://127.0.0.1:8080/pet.yaml", "http://10.0.0.1/pet.yaml", "http://localhost/pet.yaml"} {
+		_, err := ReadBytesForReferenceWithOptions(fileurl, &CompilerOptions{Cache: NewCache()})
+		if err == nil || !strings.Contains(err.Error(), "private network address") {
+			t.Errorf("Expected an error fetching %s through a proxy, got %v", fileurl, err)
+		}
+	}
+	if len(proxied) != 0 {
+		t.Errorf("Expected no requests to be sent to the proxy, found %v", proxied)
+	}
+	// references to public addresses are fetched through proxies on private addresses
+	if _, err := ReadBytesForReferenceWithOptions("http://93.184.216.34/pet.yaml", &CompilerOptions{Cache: NewCache()}); err != nil {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://93.184.216.34/pet.yaml" {
+		t.Errorf("Expected the reference to be fetched through the proxy, found %v", proxied)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	yaml "gopkg.in/yaml.v3"
+)
+
+// RestoreNumbers writes the numbers in a node with the text that they have
+// in the source that it was compiled from. Models hold some numbers, such
+// as the maximums of schemas and the values of extensions that are too
+// large for int64s, as float64s, which can't represent every integer, so
+// 9223372036854775807 would be written as 9.223372036854776e+18. Numbers
+// are restored when their values as float64s are the same as the values in
+// the source, so numbers that were changed in the model are kept. Values
+// that models hold as YAML, such as examples and defaults, keep their text
+// without this.
+func RestoreNumbers(node *yaml.Node, source *yaml.Node) {
+	if node == nil || source == nil {
+		return
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if source.Kind == yaml.DocumentNode && len(source.Content) > 0 {
+		source = source.Content[0]
+	}
+	switch {
+	case node.Kind == yaml.ScalarNode && source.Kind == yaml.ScalarNode:
+		if node.Value == source.Value {
+			return
+		}
+		if value, ok := FloatForScalarNode(node); ok {
+			if sourceValue, ok := FloatForScalarNode(source); ok && value == sourceValue {
+				node.Tag, node.Value = source.Tag, source.Value
+			}
+		}
+	case node.Kind == yaml.MappingNode && source.Kind == yaml.MappingNode:
+		values := make(map[string]*yaml.Node, len(source.Content)/2)
+		for i := 0; i+1 < len(source.Content); i += 2 {
+			values[source.Content[i].Value] = source.Content[i+1]
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			RestoreNumbers(node.Content[i+1], values[node.Content[i].Value])
+		}
+	case node.Kind == yaml.SequenceNode && source.Kind == yaml.SequenceNode:
+		for i := 0; i < len(node.Content) && i < len(source.Content); i++ {
+			RestoreNumbers(node.Content[i], source.Content[i])
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"log"
+)
+
+// CompilerOptions configure the compilation of OpenAPI descriptions and the
+// resolution of their references. Options are set on the root context of a
+// compilation with NewContextWithOptions and are shared by all of its child
+// contexts. The zero value compiles descriptions the way that gnostic always
+// has: unknown fields are errors, references are followed without limit, and
+// files are read from the file system or fetched with HTTP GET.
+type CompilerOptions struct {
+	// ExtensionHandlers process the specification extensions in a description.
+	ExtensionHandlers *[]ExtensionHandler
+	// MaxReferenceDepth limits the length of chains of references that are
+	// followed when references are resolved. Recursive references are never
+	// followed again, so this limits the expansion of deeply nested
+	// definitions. If it is zero, chains are not limited.
+	MaxReferenceDepth int
+	// Offline prevents files from being fetched from remote servers.
+	// References to remote files are reported as errors.
+	Offline bool
+	// LocalReferencesOnly prevents the files and URLs that references name
+	// from being read, so that only references within a description are
+	// resolved. References to other files are reported as unresolved.
+	LocalReferencesOnly bool
+	// Lenient ignores fields that are not allowed by the specification
+	// instead of reporting them as errors.
+	Lenient bool
+	// Logger receives the messages that describe the progress of the
+	// compilation, such as the files that are fetched and the errors of
+	// extension handlers. If it is nil, the messages are discarded.
+	Logger Logger
+	// Verbose logs the activity of the reader and its caches. If there
+	// is no Logger, these messages are written to the standard logger.
+	Verbose bool
+	// Cache holds the files read by the compilation. If it is nil, a cache
+	// that is shared by the whole process is used.
+	Cache *Cache
+	// ReadFile reads the bytes of a file or URL. If it is nil, files are read
+	// from the file system and URLs are fetched with HTTP GET.
+	ReadFile func(filename string) ([]byte, error)
+	// Registry fetches the descriptions named by registry:// URLs. If it is
+	// nil, these URLs are reported as errors.
+	Registry *Registry
+	// MergeDocuments merges the documents of files that are YAML streams into
+	// one document. If it is false, the first document of a stream is read.
+	MergeDocuments bool
+	// AllowPrivateNetworks allows references to be fetched from loopback,
+	// link-local, and private network addresses. These are refused by
+	// default so that services that compile descriptions from untrusted
+	// sources can't be made to send requests to their internal servers.
+	AllowPrivateNetworks bool
+	// MaxFileSize limits the sizes in bytes of the files that are read and
+	// the responses that are fetched, so that very large or corrupt inputs
+	// are reported as errors instead of exhausting memory. If it is zero,
+	// sizes are not limited.
+	MaxFileSize int64
+	// TLS configures the certificates that are used to fetch files over HTTPS,
+	// either for specific hosts or for all of them. If it is empty, servers
+	// are verified with the system's certificate authorities.
+	TLS []*TLSConfig
+	// Throttle limits the number of files that are fetched at a time and the
+	// rate at which they are fetched from each host. If it is nil, fetches
+	// are not limited.
+	Throttle *Throttle
+	// Progress counts the files that are read and the bytes in them. If it
+	// is nil, reads are not counted.
+	Progress *Progress
+}
+
+// Logger is the interface of the loggers that receive the messages of
+// compilations. It is implemented by *log.Logger.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// A Logger that discards all messages.
+type discardLogger struct{}
+
+func (discardLogger) Printf(format string, args ...interface{}) {}
+
+// The options used by compilations that don't specify any.
+var defaultOptions = &CompilerOptions{}
+
+// NewContextWithOptions creates the root context of a compilation that uses the specified options.
+func NewContextWithOptions(name string, options *CompilerOptions) *Context {
+	context := &Context{Name: name, Options: options, index: -1}
+	if options != nil {
+		context.ExtensionHandlers = options.ExtensionHandlers
+	}
+	return context
+}
+
+// OptionsForContext returns the options of a compilation.
+// Contexts without options use the default options.
+func OptionsForContext(context *Context) *CompilerOptions {
+	if context == nil || context.Options == nil {
+		return defaultOptions
+	}
+	return context.Options
+}
+
+// Returns the logger of a compilation.
+func (options *CompilerOptions) logger() Logger {
+	if options == nil || options.Logger == nil {
+		return discardLogger{}
+	}
+	return options.Logger
+}
+
+// Returns the registry of a compilation, or nil if it has none.
+func (options *CompilerOptions) registry() *Registry {
+	if options == nil {
+		return nil
+	}
+	return options.Registry
+}
+
+// Logs a message about the progress of a compilation.
+func (options *CompilerOptions) logf(format string, args ...interface{}) {
+	options.logger().Printf(format, args...)
+}
+
+// Logs the activity of the reader when verbose logging is enabled.
+func (options *CompilerOptions) verbosef(format string, args ...interface{}) {
+	if !VERBOSE_READER && (options == nil || !options.Verbose) {
+		return
+	}
+	if options == nil || options.Logger == nil {
+		log.Printf(format, args...)
+	} else {
+		options.Logger.Printf(format, args...)
+	}
+}
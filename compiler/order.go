@@ -0,0 +1,71 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"sort"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// OrderLike reorders the keys of the maps in a node to match their order in
+// the source that it was compiled from. Models are written with their fixed
+// fields in the order of the specification, so without this, fields such as
+// the default response or extensions move when a description is read and
+// written. Keys that aren't in the source keep their place after the keys
+// that preceded them, and maps and sequences are reordered recursively.
+func OrderLike(node *yaml.Node, source *yaml.Node) {
+	if node == nil || source == nil {
+		return
+	}
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if source.Kind == yaml.DocumentNode && len(source.Content) > 0 {
+		source = source.Content[0]
+	}
+	switch {
+	case node.Kind == yaml.MappingNode && source.Kind == yaml.MappingNode:
+		positions := make(map[string]int, len(source.Content)/2)
+		for i := 0; i < len(source.Content); i += 2 {
+			positions[source.Content[i].Value] = i / 2
+		}
+		type pair struct {
+			key, value *yaml.Node
+			position   int
+		}
+		pairs := make([]pair, 0, len(node.Content)/2)
+		position := -1
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if p, ok := positions[key.Value]; ok {
+				position = p
+				OrderLike(value, source.Content[2*p+1])
+			}
+			pairs = append(pairs, pair{key: key, value: value, position: position})
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return pairs[i].position < pairs[j].position
+		})
+		node.Content = node.Content[:0]
+		for _, pair := range pairs {
+			node.Content = append(node.Content, pair.key, pair.value)
+		}
+	case node.Kind == yaml.SequenceNode && source.Kind == yaml.SequenceNode:
+		for i := 0; i < len(node.Content) && i < len(source.Content); i++ {
+			OrderLike(node.Content[i], source.Content[i])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Matches paths that begin with Windows drive letters, such as "C:\api" and "C:/api".
+var windowsDrivePath = regexp.MustCompile(`^[A-Za-z]:[\\/]`)
+
+// Returns true if a filename is a URL. Windows paths like "C:\api\spec.yaml"
+// parse as URLs with one-letter schemes, so these aren't URLs, and neither
+// are file:// URLs, which name local files.
+func isURL(filename string) bool {
+	fileurl, err := url.Parse(filename)
+	return err == nil && len(fileurl.Scheme) > 1 && fileurl.Scheme != "file"
+}
+
+// Returns true if a filename is a file:// URL.
+func isFileURL(filename string) bool {
+	return strings.HasPrefix(strings.ToLower(filename), "file:")
+}
+
+// Returns the path of a local file named by a filename or a file:// URL.
+// The paths of URLs like "file:///C:/api/spec.yaml" lose their leading slash,
+// and URLs with hosts other than localhost name UNC paths like "//host/share".
+func localPath(filename string) string {
+	if !isFileURL(filename) {
+		return filename
+	}
+	fileurl, err := url.Parse(filename)
+	if err != nil {
+		return filename
+	}
+	p := fileurl.Path
+	if fileurl.Host != "" && fileurl.Host != "localhost" {
+		p = "//" + fileurl.Host + p
+	} else if strings.HasPrefix(p, "/") && windowsDrivePath.MatchString(p[1:]) {
+		p = p[1:]
+	}
+	return filepath.FromSlash(p)
+}
+
+// FileForRef returns the name of the file that contains the target of a $ref
+// in the specified file. Refs in a file that was reached by following another
+// ref are relative to that file, not to the file where resolution started.
+// Refs are URI references, but refs to local files are often written with the
+// separators of the system where they were written, so backslashes in refs
+// and in the names of local files are treated as separators.
+func FileForRef(basefile string, ref string) string {
+	parts := strings.Split(ref, "#")
+	switch {
+	case parts[0] == "":
+		return basefile
+	case isFileURL(parts[0]):
+		return localPath(parts[0])
+	case isURL(parts[0]):
+		// refs to URLs, such as descriptions in registries, don't depend on the base file
+		return parts[0]
+	}
+	refpath := strings.Replace(parts[0], `\`, "/", -1)
+	if isURL(basefile) {
+		base, err := url.Parse(basefile)
+		relative, err2 := url.Parse(refpath)
+		if err == nil && err2 == nil {
+			return base.ResolveReference(relative).String()
+		}
+		return basefile[:strings.LastIndex(basefile, "/")+1] + refpath
+	}
+	if path.IsAbs(refpath) || windowsDrivePath.MatchString(refpath) {
+		return filepath.FromSlash(refpath)
+	}
+	basepath := strings.Replace(localPath(basefile), `\`, "/", -1)
+	return filepath.FromSlash(path.Join(path.Dir(basepath), refpath))
+}
@@ -0,0 +1,49 @@
+package compiler
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFileForRef(t *testing.T) {
+	for _, test := range []struct {
+		base, ref, expected string
+	}{
+		{"spec/swagger.yaml", "Pet.yaml", "spec/Pet.yaml"},
+		{"spec/swagger.yaml", "#/definitions/Pet", "spec/swagger.yaml"},
+		{"spec/swagger.yaml", "../schemas/pet.yaml#/Pet", "schemas/pet.yaml"},
+		{"spec/swagger.yaml", `..\schemas\pet.yaml`, "schemas/pet.yaml"},
+		{`C:\api\spec.yaml`, `..\schemas\pet.yaml`, "C:/schemas/pet.yaml"},
+		{`C:\api\spec.yaml`, "common/pet.yaml", "C:/api/common/pet.yaml"},
+		{"spec.yaml", `D:\schemas\pet.yaml`, "D:/schemas/pet.yaml"},
+		{"file:///C:/api/spec.yaml", "pet.yaml", "C:/api/pet.yaml"},
+		{"file:///home/api/spec.yaml", "../pet.yaml", "/home/pet.yaml"},
+		{"spec.yaml", "file:///C:/api/pet.yaml#/Pet", "C:/api/pet.yaml"},
+		{"spec.yaml", "file://server/share/pet.yaml", "//server/share/pet.yaml"},
+		{"https://example.com/api/v1/spec.yaml", `..\common\pet.yaml`, "https://example.com/api/common/pet.yaml"},
+		{"https://example.com/api/spec.yaml", "https://example.org/pet.yaml#/Pet", "https://example.org/pet.yaml"},
+	} {
+		expected := test.expected
+		if !isURL(expected) {
+			expected = filepath.FromSlash(expected)
+		}
+		if file := FileForRef(test.base, test.ref); file != expected {
+			t.Errorf("FileForRef(%q, %q) = %q, expected %q", test.base, test.ref, file, expected)
+		}
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	for filename, expected := range map[string]bool{
+		"https://example.com/spec.yaml": true,
+		"registry://acme/pets/v1":       true,
+		`C:\api\spec.yaml`:              false,
+		"C:/api/spec.yaml":              false,
+		"file:///C:/api/spec.yaml":      false,
+		"spec.yaml":                     false,
+	} {
+		if isURL(filename) != expected {
+			t.Errorf("isURL(%q) is %t", filename, !expected)
+		}
+	}
+}
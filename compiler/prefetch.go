@@ -0,0 +1,111 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PrefetchReferences reads the files that are the targets of $refs in a file,
+// along with the files that they refer to, using up to "workers" concurrent
+// readers. Files that are read are parsed and cached for ReadInfoForRef, so
+// references to files on remote servers can be resolved without waiting for
+// each file to be fetched in turn. The file itself must have been read with
+// ReadInfoFromBytes, or with ReadInfoFromBytesWithOptions using the same
+// options. Errors are ignored here; they are reported when the references
+// are resolved.
+func PrefetchReferences(filename string, workers int) {
+	PrefetchReferencesWithOptions(filename, workers, nil)
+}
+
+// PrefetchReferencesWithOptions reads the targets of $refs with the reader
+// and restrictions specified in a compilation's options.
+func PrefetchReferencesWithOptions(filename string, workers int, options *CompilerOptions) {
+	ReferencedFilesWithOptions(filename, workers, options)
+}
+
+// ReferencedFilesWithOptions reads the targets of $refs like
+// PrefetchReferencesWithOptions and returns the names of all of the files
+// that are referred to, directly or through other files, in order. These
+// are the files that are read when the references are resolved. Files that
+// can't be read are included, but the files that they refer to can't be found.
+func ReferencedFilesWithOptions(filename string, workers int, options *CompilerOptions) []string {
+	if workers < 1 {
+		workers = 1
+	}
+	cache := options.cache()
+	seen := map[string]bool{filename: true}
+	wave := []string{filename}
+	for len(wave) > 0 {
+		// find the files referenced by the files that were just read
+		targets := make([]string, 0)
+		for _, file := range wave {
+			info, ok := cache.cachedInfo(file)
+			if !ok || info == nil {
+				continue
+			}
+			for _, ref := range refsInNode(info) {
+				if strings.HasPrefix(ref, "#") {
+					continue
+				}
+				// refs are relative to the file that contains them
+				target := FileForRef(file, ref)
+				if !seen[target] {
+					seen[target] = true
+					targets = append(targets, target)
+				}
+			}
+		}
+		sort.Strings(targets)
+		// read them concurrently
+		queue := make(chan string)
+		var wg sync.WaitGroup
+		for i := 0; i < workers && i < len(targets); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for target := range queue {
+					cache.readInfoForFile(target, options)
+				}
+			}()
+		}
+		for _, target := range targets {
+			queue <- target
+		}
+		close(queue)
+		wg.Wait()
+		wave = targets
+	}
+	delete(seen, filename)
+	files := make([]string, 0, len(seen))
+	for file := range seen {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Returns the values of all of the $refs in a node and its children.
+func refsInNode(node *yaml.Node) []string {
+	refs := make([]string, 0)
+	for _, value := range ReferenceNodes(node) {
+		refs = append(refs, value.Value)
+	}
+	return refs
+}
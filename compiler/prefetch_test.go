@@ -0,0 +1,80 @@
+package compiler
+
+import (
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Returns options that read files from a map and count the times that each file is read.
+func countingOptions(files map[string]string) (*CompilerOptions, map[string]int) {
+	var mutex sync.Mutex
+	reads := make(map[string]int)
+	options := &CompilerOptions{
+		Cache: NewCache(),
+		ReadFile: func(filename string) ([]byte, error) {
+			mutex.Lock()
+			reads[filename]++
+			mutex.Unlock()
+			return []byte(files[filename]), nil
+		},
+	}
+	return options, reads
+}
+
+func TestPrefetchLocalReferencesOnly(t *testing.T) {
+	files := map[string]string{
+		"api.yaml": "Pet: {$ref: 'pet.yaml#/Pet'}\nOwner: {$ref: 'https://example.com/owner.yaml#/Owner'}\n",
+		"pet.yaml": "Pet: {type: object}\n",
+	}
+	options, reads := countingOptions(files)
+	options.LocalReferencesOnly = true
+	if _, err := ReadInfoFromBytesWithOptions("api.yaml", []byte(files["api.yaml"]), options); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	PrefetchReferencesWithOptions("api.yaml", 4, options)
+	if len(reads) != 0 {
+		t.Errorf("Expected no files to be read, read %v", reads)
+	}
+}
+
+func TestPrefetchReferences(t *testing.T) {
+	files := map[string]string{
+		"api.yaml": "Pet: {$ref: 'a.yaml#/A'}\nOwner: {$ref: 'b.yaml#/B'}\nLocal: {$ref: '#/Pet'}\n",
+		"a.yaml":   "A: {properties: {b: {$ref: 'b.yaml#/B'}, c: {$ref: 'c.yaml#/C'}}}\n",
+		"b.yaml":   "B: {properties: {c: {$ref: 'c.yaml#/C'}}}\n",
+		"c.yaml":   "C: {properties: {d: {$ref: 'sub/d.yaml#/D'}}}\n",
+		// refs are relative to the files that contain them
+		filepath.Join("sub", "d.yaml"): "D: {properties: {a: {$ref: '../a.yaml#/A'}}}\n",
+	}
+	options, reads := countingOptions(files)
+	if _, err := ReadInfoFromBytesWithOptions("api.yaml", []byte(files["api.yaml"]), options); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	referenced := ReferencedFilesWithOptions("api.yaml", 4, options)
+	expected := []string{"a.yaml", "b.yaml", "c.yaml", filepath.Join("sub", "d.yaml")}
+	if !reflect.DeepEqual(referenced, expected) {
+		t.Errorf("Expected referenced files %v, found %v", expected, referenced)
+	}
+	for _, file := range expected {
+		if reads[file] != 1 {
+			t.Errorf("Expected %s to be read once, read %d times", file, reads[file])
+		}
+	}
+	// prefetched files aren't read again when references are resolved
+	context := NewContextWithOptions("$root", options)
+	for _, ref := range []string{"a.yaml#/A", "b.yaml#/B", "c.yaml#/C", "sub/d.yaml#/D"} {
+		if _, err := ReadInfoForRefInContext("api.yaml", ref, context); err != nil {
+			t.Errorf("Unexpected error: %+v", err)
+		}
+	}
+	if len(reads) != len(expected) {
+		t.Errorf("Unexpected reads %v", reads)
+	}
+	for file, count := range reads {
+		if count != 1 {
+			t.Errorf("Expected %s to be read once, read %d times", file, count)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"sync"
+)
+
+// ProgressEvent describes the progress of a compilation.
+type ProgressEvent struct {
+	Stage string `json:"stage"`          // the stage of the compilation, as set by the program running it
+	Files int    `json:"files"`          // the number of files that have been read
+	Bytes int64  `json:"bytes"`          // the number of bytes in those files
+	File  string `json:"file,omitempty"` // the name of the last file that was read
+}
+
+// A Progress counts the files that compilations read, so that programs can
+// report the progress of long compilations, such as those of descriptions
+// with many remote references. Progresses are safe for concurrent use
+// and can be shared by compilations to count their combined reads.
+type Progress struct {
+	mutex sync.Mutex
+	event ProgressEvent
+}
+
+// NewProgress creates a progress that hasn't counted any files.
+func NewProgress() *Progress {
+	return &Progress{}
+}
+
+// SetStage sets the stage that is reported in the events of a progress.
+func (progress *Progress) SetStage(stage string) {
+	if progress == nil {
+		return
+	}
+	progress.mutex.Lock()
+	progress.event.Stage = stage
+	progress.mutex.Unlock()
+}
+
+// Event returns the current state of a progress.
+func (progress *Progress) Event() ProgressEvent {
+	if progress == nil {
+		return ProgressEvent{}
+	}
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+	return progress.event
+}
+
+// Counts a file that has been read. A nil progress counts nothing.
+func (progress *Progress) read(filename string, size int) {
+	if progress == nil {
+		return
+	}
+	progress.mutex.Lock()
+	progress.event.Files++
+	progress.event.Bytes += int64(size)
+	progress.event.File = filename
+	progress.mutex.Unlock()
+}
+
+// Returns the progress of a compilation, or nil if it has none.
+func (options *CompilerOptions) progress() *Progress {
+	if options == nil {
+		return nil
+	}
+	return options.Progress
+}
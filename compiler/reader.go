@@ -15,52 +15,230 @@
 package compiler
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"gopkg.in/yaml.v2"
+	"io"
 	"io/ioutil"
-	"log"
-	"net/http"
-	"net/url"
+	"os"
 	"path/filepath"
 	"strings"
-)
+	"sync"
+	"time"
 
-var file_cache map[string][]byte
-var info_cache map[string]interface{}
-var count int64
+	"gopkg.in/yaml.v3"
+)
 
+// VERBOSE_READER enables verbose logging for all compilations.
+// Prefer the Verbose option, which applies to a single compilation.
 var VERBOSE_READER = false
 
-func initializeFileCache() {
-	if file_cache == nil {
-		file_cache = make(map[string][]byte, 0)
+// A Cache holds the files that have been read by compilations, their parsed
+// contents, and the targets of the $refs into them. Compilations that are
+// unrelated, such as those of different descriptions or with different
+// readers, can use separate caches by setting the Cache option; the others
+// share a cache for the whole process. Caches can be used concurrently.
+type Cache struct {
+	mutex sync.Mutex
+	files map[string][]byte
+	infos map[string]*infoCacheEntry
+	refs  map[string]*refCacheEntry
+	stats CacheStats
+}
+
+// NewCache creates an empty Cache.
+func NewCache() *Cache {
+	cache := &Cache{}
+	cache.initialize()
+	return cache
+}
+
+// the cache used by compilations that don't specify one
+var defaultCache = NewCache()
+
+// Returns the cache of a compilation.
+func (options *CompilerOptions) cache() *Cache {
+	if options == nil || options.Cache == nil {
+		return defaultCache
+	}
+	return options.Cache
+}
+
+// The parsed contents of a file, keyed by the file's absolute location.
+// The hash of the file's contents is saved so that changes can be detected.
+// For local files, the size and modification time of the file are saved
+// so that unchanged files don't have to be read again to be checked.
+type infoCacheEntry struct {
+	hash     string
+	info     *yaml.Node
+	infos    []*yaml.Node // the documents of the file, if it is a YAML stream
+	warnings []*Error     // the problems found when the file was parsed
+	chunks   *chunkedInfo // the entries that were handled when the file was read in chunks
+	size     int64
+	modTime  time.Time
+}
+
+// The node that a $ref refers to, keyed by the absolute location of its file
+// and its fragment, along with the hash of the file that it was read from.
+type refCacheEntry struct {
+	hash string
+	info *yaml.Node
+}
+
+// CacheStats counts the work done by a cache.
+type CacheStats struct {
+	FileHits      int64 // remote files that were found in the cache
+	FileMisses    int64 // remote files that were fetched
+	InfoHits      int64 // files whose parsed contents were found in the cache
+	InfoMisses    int64 // files that were parsed
+	RefHits       int64 // $refs whose targets were found in the cache
+	RefMisses     int64 // $refs whose targets were read from their files
+	Invalidations int64 // cached files that were replaced because their contents changed
+	Files         int   // remote files that are currently cached
+	Infos         int   // parsed files that are currently cached
+	Refs          int   // $ref targets that are currently cached
+}
+
+// Creates the maps of a cache; the caller must hold its mutex.
+func (cache *Cache) initialize() {
+	cache.files = make(map[string][]byte, 0)
+	cache.infos = make(map[string]*infoCacheEntry, 0)
+	cache.refs = make(map[string]*refCacheEntry, 0)
+	cache.stats = CacheStats{}
+}
+
+// Statistics returns the counts of a cache's activity since it was
+// last cleared, along with its current size.
+func (cache *Cache) Statistics() CacheStats {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	stats := cache.stats
+	stats.Files = len(cache.files)
+	stats.Infos = len(cache.infos)
+	stats.Refs = len(cache.refs)
+	return stats
+}
+
+// Clear releases all of the files and infos in a cache.
+// Models that were built from cached infos are unaffected, but references
+// that are resolved after the cache is cleared cause files to be read again.
+func (cache *Cache) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.initialize()
+}
+
+// InvalidateFile removes a file, its parsed contents, and the targets of
+// all $refs into it from a cache. Local files that change are detected
+// automatically, but remote files are fetched only once, so long-running
+// programs must call this when a remote file may have changed.
+func (cache *Cache) InvalidateFile(filename string) {
+	location := locationForFile(filename)
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	delete(cache.files, filename)
+	delete(cache.files, location)
+	delete(cache.infos, location)
+	cache.invalidateRefsForLocation(location)
+}
+
+// Removes the cached targets of $refs into a file; the caller must hold the cache's mutex.
+func (cache *Cache) invalidateRefsForLocation(location string) {
+	prefix := location + "#"
+	for key := range cache.refs {
+		if strings.HasPrefix(key, prefix) {
+			delete(cache.refs, key)
+		}
 	}
 }
 
-func initializeInfoCache() {
-	if info_cache == nil {
-		info_cache = make(map[string]interface{}, 0)
+// CacheStatistics returns the statistics of the cache shared by compilations
+// that don't specify one.
+func CacheStatistics() CacheStats {
+	return defaultCache.Statistics()
+}
+
+// ClearCaches clears the cache shared by compilations that don't specify one.
+func ClearCaches() {
+	defaultCache.Clear()
+}
+
+// InvalidateFile removes a file from the cache shared by compilations that
+// don't specify one.
+func InvalidateFile(filename string) {
+	defaultCache.InvalidateFile(filename)
+}
+
+// Returns the absolute location of a file, which is used as its cache key.
+func locationForFile(filename string) string {
+	filename = localPath(filename)
+	if isURL(filename) {
+		return filename
+	}
+	if location, err := filepath.Abs(filename); err == nil {
+		return location
 	}
+	return filename
+}
+
+// Returns the hash of the contents of a file.
+func contentHash(bytes []byte) string {
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:])
 }
 
 func FetchFile(fileurl string) ([]byte, error) {
-	initializeFileCache()
-	bytes, ok := file_cache[fileurl]
+	return defaultCache.fetchFile(fileurl, nil, false)
+}
+
+// Fetches a remote file, or returns its bytes if it has already been fetched.
+// Files that are the targets of references are fetched from public addresses only,
+// unless the options allow private networks.
+func (cache *Cache) fetchFile(fileurl string, options *CompilerOptions, reference bool) ([]byte, error) {
+	cache.mutex.Lock()
+	bytes, ok := cache.files[fileurl]
 	if ok {
-		if VERBOSE_READER {
-			log.Printf("Cache hit %s", fileurl)
-		}
+		cache.stats.FileHits++
+	} else {
+		cache.stats.FileMisses++
+	}
+	cache.mutex.Unlock()
+	if ok {
+		options.verbosef("Cache hit %s", fileurl)
 		return bytes, nil
 	}
-	log.Printf("Fetching %s", fileurl)
-	response, err := http.Get(fileurl)
+	options.logf("Fetching %s", fileurl)
+	if isRegistryURL(fileurl) {
+		bytes, err := options.registry().fetch(fileurl, options)
+		if err == nil {
+			cache.mutex.Lock()
+			cache.files[fileurl] = bytes
+			cache.mutex.Unlock()
+		}
+		return bytes, err
+	}
+	client, err := options.httpClient(reference, fileurl)
+	if err != nil {
+		return nil, err
+	}
+	done := options.throttle().wait(fileurl)
+	defer done()
+	response, err := client.Get(fileurl)
 	if err != nil {
 		return nil, err
 	} else {
 		defer response.Body.Close()
-		bytes, err := ioutil.ReadAll(response.Body)
+		if err := options.checkSize(fileurl, response.ContentLength); err != nil {
+			return nil, err
+		}
+		bytes, err := options.readAll(fileurl, response.Body)
 		if err == nil {
-			file_cache[fileurl] = bytes
+			cache.mutex.Lock()
+			cache.files[fileurl] = bytes
+			cache.mutex.Unlock()
 		}
 		return bytes, err
 	}
@@ -68,18 +246,117 @@ func FetchFile(fileurl string) ([]byte, error) {
 
 // read the bytes of a file
 func ReadBytesForFile(filename string) ([]byte, error) {
+	return ReadBytesForFileWithOptions(filename, nil)
+}
+
+// ReadBytesForFileWithOptions reads the bytes of a file with the reader
+// and restrictions specified in a compilation's options.
+func ReadBytesForFileWithOptions(filename string, options *CompilerOptions) ([]byte, error) {
+	return readBytesForFile(filename, options, false)
+}
+
+// ReadBytesForReferenceWithOptions reads the bytes of a file that is the
+// target of a reference. Unlike the files read by ReadBytesForFileWithOptions,
+// these are fetched from public network addresses only, unless the options
+// allow private networks.
+func ReadBytesForReferenceWithOptions(filename string, options *CompilerOptions) ([]byte, error) {
+	return readBytesForFile(filename, options, true)
+}
+
+// Reads the bytes of a file, which is the target of a reference if reference is true.
+func readBytesForFile(filename string, options *CompilerOptions, reference bool) ([]byte, error) {
+	bytes, err := readRawBytesForFile(filename, options, reference)
+	if err != nil {
+		return nil, err
+	}
+	options.progress().read(filename, len(bytes))
+	// descriptions in other encodings are read as UTF-8
+	normalized, err := NormalizeEncoding(bytes)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to read %s: %s", filename, err.Error()))
+	}
+	return normalized, nil
+}
+
+// OpenFileWithOptions opens a file to be read as a stream, with the reader
+// and restrictions specified in a compilation's options. Local files in UTF-8
+// are read as they are used; other files are read into memory like the files
+// read by ReadBytesForFileWithOptions, since they must be fetched or decoded first.
+func OpenFileWithOptions(filename string, options *CompilerOptions) (io.ReadCloser, error) {
+	if options == nil {
+		options = defaultOptions
+	}
+	path := localPath(filename)
+	if options.ReadFile == nil && !isURL(path) {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		fileInfo, err := file.Stat()
+		if err == nil {
+			err = options.checkSize(filename, fileInfo.Size())
+		}
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		reader := bufio.NewReader(file)
+		prefix, _ := reader.Peek(len(utf32BEBOM))
+		if !isWideEncoding(prefix) {
+			if bytes.HasPrefix(prefix, utf8BOM) {
+				reader.Discard(len(utf8BOM))
+			}
+			options.progress().read(filename, int(fileInfo.Size()))
+			return &fileReader{Reader: reader, file: file}, nil
+		}
+		file.Close()
+	}
+	data, err := readBytesForFile(filename, options, false)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+// A buffered reader for an open file.
+type fileReader struct {
+	*bufio.Reader
+	file *os.File
+}
+
+func (r *fileReader) Close() error {
+	return r.file.Close()
+}
+
+func readRawBytesForFile(filename string, options *CompilerOptions, reference bool) ([]byte, error) {
+	if options == nil {
+		options = defaultOptions
+	}
+	filename = localPath(filename)
+	if options.Offline && isURL(filename) {
+		return nil, errors.New(fmt.Sprintf("unable to fetch %s when reading offline", filename))
+	}
+	if options.ReadFile != nil {
+		bytes, err := options.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		if err := options.checkSize(filename, int64(len(bytes))); err != nil {
+			return nil, err
+		}
+		return bytes, nil
+	}
 	// is the filename a url?
-	fileurl, _ := url.Parse(filename)
-	if fileurl.Scheme != "" {
+	if isURL(filename) {
 		// yes, fetch it
-		bytes, err := FetchFile(filename)
+		bytes, err := options.cache().fetchFile(filename, options, reference)
 		if err != nil {
 			return nil, err
 		}
 		return bytes, nil
 	} else {
 		// no, it's a local filename
-		bytes, err := ioutil.ReadFile(filename)
+		bytes, err := options.readFile(filename)
 		if err != nil {
 			return nil, err
 		}
@@ -87,83 +364,261 @@ func ReadBytesForFile(filename string) ([]byte, error) {
 	}
 }
 
-// unmarshal a file as a yaml.MapSlice
-func ReadInfoFromBytes(filename string, bytes []byte) (interface{}, error) {
-	initializeInfoCache()
-	info, ok := info_cache[filename]
-	if ok {
-		if VERBOSE_READER {
-			log.Printf("Cache hit info for file %s", filename)
-		}
-		return info, nil
-	} else {
-		var info yaml.MapSlice
-		if VERBOSE_READER {
-			log.Printf("Reading info for file %s", filename)
+// Returns the size and modification time of a local file, or nil if they are unavailable.
+func statForFile(filename string) os.FileInfo {
+	if isURL(filename) {
+		return nil
+	}
+	fileInfo, err := os.Stat(localPath(filename))
+	if err != nil {
+		return nil
+	}
+	return fileInfo
+}
+
+// unmarshal a file as a yaml.Node
+func ReadInfoFromBytes(filename string, bytes []byte) (*yaml.Node, error) {
+	return ReadInfoFromBytesWithOptions(filename, bytes, nil)
+}
+
+// ReadInfoFromBytesWithOptions unmarshals a file as a yaml.Node and saves it
+// in the cache of a compilation, where it is found when references into the
+// file are resolved.
+func ReadInfoFromBytesWithOptions(filename string, bytes []byte, options *CompilerOptions) (*yaml.Node, error) {
+	info, _, err := options.cache().readInfoForLocation(locationForFile(filename), bytes, statForFile(filename), options)
+	return info, err
+}
+
+// ReadInfosFromBytesWithOptions unmarshals each of the documents of a YAML
+// stream, which are separated by "---" lines. Files that contain one
+// document are read as streams of one document. If the MergeDocuments
+// option is set, the documents are merged and the merged document is the
+// only one that is returned. The file is cached like ReadInfoFromBytesWithOptions
+// caches it; references into a stream of separate documents are resolved
+// in its first document.
+func ReadInfosFromBytesWithOptions(filename string, bytes []byte, options *CompilerOptions) ([]*yaml.Node, error) {
+	infos, _, err := options.cache().readInfosForLocation(locationForFile(filename), bytes, statForFile(filename), options)
+	return infos, err
+}
+
+// SetInfo replaces the cached contents of a file, so that references into the
+// file are resolved in the specified node. Tools that compile the documents of
+// a YAML stream separately use it to resolve the references of each document
+// in the document that contains them.
+func (cache *Cache) SetInfo(filename string, info *yaml.Node) {
+	// the hash of the node's contents distinguishes it from the files and nodes that it replaces
+	entry := &infoCacheEntry{hash: contentHash(Marshal(info)), info: info, infos: []*yaml.Node{info}}
+	cache.setEntry(filename, entry)
+}
+
+// Replaces the cached contents of a file with an entry.
+func (cache *Cache) setEntry(filename string, entry *infoCacheEntry) {
+	location := locationForFile(filename)
+	if fileInfo := statForFile(filename); fileInfo != nil {
+		entry.size, entry.modTime = fileInfo.Size(), fileInfo.ModTime()
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if _, ok := cache.infos[location]; ok {
+		cache.invalidateRefsForLocation(location)
+	}
+	cache.infos[location] = entry
+}
+
+// Returns the parsed contents of a file and the hash of the bytes that they were parsed from.
+// If the hash matches the hash of a cached file, the cached contents are returned;
+// otherwise the cached file and the $refs into it are replaced.
+func (cache *Cache) readInfoForLocation(location string, bytes []byte, fileInfo os.FileInfo, options *CompilerOptions) (*yaml.Node, string, error) {
+	infos, hash, err := cache.readInfosForLocation(location, bytes, fileInfo, options)
+	if err != nil {
+		return nil, hash, err
+	}
+	// the info for a file is the root node of its first document
+	return infos[0], hash, nil
+}
+
+// Returns the parsed documents of a file, which are cached like the contents
+// returned by readInfoForLocation.
+func (cache *Cache) readInfosForLocation(location string, bytes []byte, fileInfo os.FileInfo, options *CompilerOptions) ([]*yaml.Node, string, error) {
+	hash := contentHash(bytes)
+	if options != nil && options.MergeDocuments {
+		// merged and unmerged documents are cached separately
+		hash += "+merged"
+	}
+	cache.mutex.Lock()
+	entry, ok := cache.infos[location]
+	if ok && entry.hash == hash {
+		cache.stats.InfoHits++
+		if fileInfo != nil {
+			entry.size, entry.modTime = fileInfo.Size(), fileInfo.ModTime()
 		}
-		err := yaml.Unmarshal(bytes, &info)
+		cache.mutex.Unlock()
+		options.verbosef("Cache hit info for file %s", location)
+		return entry.infos, hash, nil
+	}
+	if ok {
+		cache.stats.Invalidations++
+		delete(cache.infos, location)
+		cache.invalidateRefsForLocation(location)
+	}
+	cache.stats.InfoMisses++
+	cache.mutex.Unlock()
+	options.verbosef("Reading info for file %s", location)
+	infos, warnings, err := readDocuments(bytes)
+	if err != nil {
+		return nil, hash, err
+	}
+	if options != nil && options.MergeDocuments && len(infos) > 1 {
+		merged, err := mergeDocuments(infos)
 		if err != nil {
-			return nil, err
+			return nil, hash, err
 		}
-		info_cache[filename] = info
-		return info, nil
+		infos = []*yaml.Node{merged}
+	}
+	entry = &infoCacheEntry{hash: hash, info: infos[0], infos: infos, warnings: warnings}
+	if fileInfo != nil {
+		entry.size, entry.modTime = fileInfo.Size(), fileInfo.ModTime()
 	}
+	cache.mutex.Lock()
+	cache.infos[location] = entry
+	cache.mutex.Unlock()
+	return infos, hash, nil
 }
 
-// read a file and return the fragment needed to resolve a $ref
-func ReadInfoForRef(basefile string, ref string) (interface{}, error) {
-	initializeInfoCache()
-	{
-		info, ok := info_cache[ref]
-		if ok {
-			if VERBOSE_READER {
-				log.Printf("Cache hit for ref %s#%s", basefile, ref)
-			}
-			return info, nil
-		}
+// Returns the parsed contents of a file and their hash. Local files
+// are read again only if their size or modification time has changed.
+// If only local references are resolved, files are never read, so only
+// the files that have already been read can be returned.
+func (cache *Cache) readInfoForFile(filename string, options *CompilerOptions) (*yaml.Node, string, error) {
+	localOnly := options != nil && options.LocalReferencesOnly
+	location := locationForFile(filename)
+	fileInfo := statForFile(filename)
+	cache.mutex.Lock()
+	entry, ok := cache.infos[location]
+	if ok && (fileInfo == nil || localOnly ||
+		(entry.size == fileInfo.Size() && entry.modTime.Equal(fileInfo.ModTime()))) {
+		// remote files and files that can't be checked are used until they are invalidated
+		cache.stats.InfoHits++
+		cache.mutex.Unlock()
+		return entry.info, entry.hash, nil
 	}
-	if VERBOSE_READER {
-		log.Printf("Reading info for ref %s#%s", basefile, ref)
+	cache.mutex.Unlock()
+	if localOnly {
+		return nil, "", errors.New(fmt.Sprintf("unable to read %s: references to other files are not resolved", filename))
+	}
+	bytes, err := readBytesForFile(filename, options, true)
+	if err != nil {
+		return nil, "", err
 	}
-	count = count + 1
-	basedir, _ := filepath.Split(basefile)
+	return cache.readInfoForLocation(location, bytes, fileInfo, options)
+}
+
+// WarningsForFileWithOptions returns the warnings that were found when a file
+// was parsed, such as warnings about keys that were converted to strings.
+// Files that haven't been read have no warnings.
+func WarningsForFileWithOptions(filename string, options *CompilerOptions) []*Error {
+	cache := options.cache()
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if entry, ok := cache.infos[locationForFile(filename)]; ok {
+		return append([]*Error(nil), entry.warnings...)
+	}
+	return nil
+}
+
+// Returns the cached info for a file.
+func (cache *Cache) cachedInfo(filename string) (*yaml.Node, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	entry, ok := cache.infos[locationForFile(filename)]
+	if !ok {
+		return nil, false
+	}
+	return entry.info, true
+}
+
+// Returns the entries that were handled when a file was read in chunks,
+// or nil if the cached file wasn't read in chunks.
+func (cache *Cache) cachedChunks(filename string) *chunkedInfo {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if entry, ok := cache.infos[locationForFile(filename)]; ok {
+		return entry.chunks
+	}
+	return nil
+}
+
+// read a file and return the fragment needed to resolve a $ref
+func ReadInfoForRef(basefile string, ref string) (*yaml.Node, error) {
+	return ReadInfoForRefInContext(basefile, ref, nil)
+}
+
+// ReadInfoForRefInContext reads the fragment needed to resolve a $ref
+// using the options of the compilation that the context belongs to.
+func ReadInfoForRefInContext(basefile string, ref string, context *Context) (*yaml.Node, error) {
+	options := OptionsForContext(context)
+	if options.MaxReferenceDepth > 0 && context.referenceDepth() >= options.MaxReferenceDepth {
+		message := fmt.Sprintf("could not resolve %s: more than %d references were followed", ref, options.MaxReferenceDepth)
+		return nil, NewErrorForNode(context, nil, ErrorCodeReferenceDepth, message)
+	}
+	cache := options.cache()
 	parts := strings.Split(ref, "#")
-	var filename string
-	if parts[0] != "" {
-		filename = basedir + parts[0]
-	} else {
-		filename = basefile
+	filename := FileForRef(basefile, ref)
+	if options.LocalReferencesOnly && filename != basefile {
+		message := fmt.Sprintf("could not resolve %s: references to other files are not resolved", ref)
+		return nil, NewErrorForNode(context, nil, ErrorCodeUnresolvedReference, message)
 	}
-	bytes, err := ReadBytesForFile(filename)
+	info, hash, err := cache.readInfoForFile(filename, options)
 	if err != nil {
 		return nil, err
 	}
-	info, err := ReadInfoFromBytes(filename, bytes)
-	if err != nil {
-		log.Printf("File error: %v\n", err)
-	} else {
-		if len(parts) > 1 {
-			path := strings.Split(parts[1], "/")
-			for i, key := range path {
-				if i > 0 {
-					m, ok := info.(yaml.MapSlice)
-					if ok {
-						found := false
-						for _, section := range m {
-							if section.Key == key {
-								info = section.Value
-								found = true
-							}
-						}
-						if !found {
-							info_cache[ref] = nil
-							return nil, NewError(nil, fmt.Sprintf("could not resolve %s", ref))
-						}
+	// refs are cached by the location of their files, so the same ref
+	// in different directories and refs into changed files are distinct
+	key := locationForFile(filename) + "#"
+	if len(parts) > 1 {
+		key += parts[1]
+	}
+	cache.mutex.Lock()
+	if entry, ok := cache.refs[key]; ok && entry.hash == hash {
+		cache.stats.RefHits++
+		cache.mutex.Unlock()
+		options.verbosef("Cache hit for ref %s#%s", basefile, ref)
+		return entry.info, nil
+	}
+	cache.stats.RefMisses++
+	cache.mutex.Unlock()
+	options.verbosef("Reading info for ref %s#%s", basefile, ref)
+	if len(parts) > 1 {
+		chunks := cache.cachedChunks(filename)
+		path := strings.Split(parts[1], "/")
+		keys := make([]string, 0, len(path))
+		for i, key := range path {
+			if i > 0 {
+				m, ok := UnpackMap(info)
+				if ok {
+					// keys in JSON pointers are escaped, so "/pets" is written as "~1pets"
+					key = strings.Replace(strings.Replace(key, "~1", "/", -1), "~0", "~", -1)
+					section := MapValueForKey(m, key)
+					if section == nil {
+						// entries that were handled in chunks aren't in the cached info
+						section = chunks.entry(keys, key)
+					}
+					keys = append(keys, key)
+					if section != nil {
+						info = section
+					} else {
+						info = nil
+						break
 					}
 				}
 			}
 		}
 	}
-	info_cache[ref] = info
+	cache.mutex.Lock()
+	cache.refs[key] = &refCacheEntry{hash: hash, info: info}
+	cache.mutex.Unlock()
+	if info == nil {
+		return nil, NewErrorForNode(nil, nil, ErrorCodeUnresolvedReference, fmt.Sprintf("could not resolve %s", ref))
+	}
 	return info, nil
 }
@@ -0,0 +1,97 @@
+package compiler
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheInvalidation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	base := filepath.Join(dir, "api.yaml")
+	pet := filepath.Join(dir, "pet.yaml")
+	write := func(text string, modTime time.Time) {
+		if err := ioutil.WriteFile(pet, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(pet, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cache := NewCache()
+	context := NewContextWithOptions("$root", &CompilerOptions{Cache: cache})
+	read := func() string {
+		info, err := ReadInfoForRefInContext(base, "pet.yaml#/Pet/type", context)
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		return info.Value
+	}
+	expectStats := func(step string, expected CacheStats) {
+		if stats := cache.Statistics(); stats != expected {
+			t.Errorf("%s: expected %+v, found %+v", step, expected, stats)
+		}
+	}
+	modTime := time.Now().Add(-time.Hour)
+	write("Pet: {type: object}\n", modTime)
+	if value := read(); value != "object" {
+		t.Errorf("Unexpected value %s", value)
+	}
+	expectStats("first read", CacheStats{InfoMisses: 1, RefMisses: 1, Infos: 1, Refs: 1})
+	// unchanged files are not read again
+	read()
+	expectStats("unchanged file", CacheStats{InfoMisses: 1, InfoHits: 1, RefMisses: 1, RefHits: 1, Infos: 1, Refs: 1})
+	// files that are touched are read again, but their contents are not parsed again
+	write("Pet: {type: object}\n", modTime.Add(time.Minute))
+	read()
+	expectStats("touched file", CacheStats{InfoMisses: 1, InfoHits: 2, RefMisses: 1, RefHits: 2, Infos: 1, Refs: 1})
+	// files with new contents are parsed again, and the refs into them are replaced
+	write("Pet: {type: string}\n", modTime.Add(2*time.Minute))
+	if value := read(); value != "string" {
+		t.Errorf("Expected the changed file to be read again, found %s", value)
+	}
+	expectStats("changed file", CacheStats{InfoMisses: 2, InfoHits: 2, RefMisses: 2, RefHits: 2, Invalidations: 1, Infos: 1, Refs: 1})
+	cache.Clear()
+	expectStats("cleared cache", CacheStats{})
+}
+
+func TestCacheRemoteFiles(t *testing.T) {
+	contents := "Pet: {type: object}\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(contents))
+	}))
+	defer server.Close()
+	cache := NewCache()
+	context := NewContextWithOptions("$root", &CompilerOptions{Cache: cache, AllowPrivateNetworks: true})
+	read := func() string {
+		info, err := ReadInfoForRefInContext(server.URL+"/api.yaml", "pet.yaml#/Pet/type", context)
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		return info.Value
+	}
+	read()
+	// remote files are used until they are invalidated
+	contents = "Pet: {type: string}\n"
+	if value := read(); value != "object" {
+		t.Errorf("Expected the cached file, found %s", value)
+	}
+	if stats := cache.Statistics(); stats.FileMisses != 1 || stats.InfoHits != 1 {
+		t.Errorf("Unexpected statistics %+v", stats)
+	}
+	cache.InvalidateFile(server.URL + "/pet.yaml")
+	if value := read(); value != "string" {
+		t.Errorf("Expected the invalidated file to be fetched again, found %s", value)
+	}
+	if stats := cache.Statistics(); stats.FileMisses != 2 || stats.Refs != 1 {
+		t.Errorf("Unexpected statistics %+v", stats)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RenameReferences changes the references in a model that refer to one
+// location so that they refer to another, and returns the number of
+// references that were changed. References to locations within the
+// renamed one are also changed, so renaming "#/definitions/Pet" to
+// "#/definitions/Animal" changes "#/definitions/Pet/properties/id" too.
+// Programs that rename or move the parts of a model that references refer
+// to can use this to keep its references consistent. As with
+// StripDocumentation, the model is a pointer to a generated message.
+func RenameReferences(model interface{}, from, to string) int {
+	return renameValue(reflect.ValueOf(model), from, to)
+}
+
+func renameValue(v reflect.Value, from, to string) int {
+	count := 0
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			count += renameValue(v.Elem(), from, to)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if t.Field(i).Name == "XRef" && field.Kind() == reflect.String {
+				if ref := field.String(); ref == from || strings.HasPrefix(ref, from+"/") {
+					field.SetString(to + strings.TrimPrefix(ref, from))
+					count++
+				}
+			} else {
+				count += renameValue(field, from, to)
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return 0
+		}
+		for i := 0; i < v.Len(); i++ {
+			count += renameValue(v.Index(i), from, to)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			count += renameValue(value, from, to)
+			v.SetMapIndex(key, value)
+		}
+	}
+	return count
+}
+
+// A ReferenceRule maps references that begin with one location to another.
+// A * in From matches any text, which replaces the * in To, so the rule
+// from "https://schemas.example.com/*" to "vendor/schemas/*" changes
+// "https://schemas.example.com/pet.yaml#/Pet" to "vendor/schemas/pet.yaml#/Pet".
+// Rules without a * change the references to the file or location in From,
+// along with references to locations within it.
+type ReferenceRule struct {
+	From string
+	To   string
+}
+
+// NewReferenceRule creates a rule that maps references from one location to another.
+func NewReferenceRule(from, to string) (*ReferenceRule, error) {
+	if from == "" || to == "" {
+		return nil, errors.New("reference rules require a location to map from and to")
+	}
+	if strings.Count(from, "*") > 1 || strings.Count(to, "*") > 1 {
+		return nil, errors.New(fmt.Sprintf("invalid reference rule from %s to %s, only one * can be used", from, to))
+	}
+	if strings.Contains(to, "*") && !strings.Contains(from, "*") {
+		return nil, errors.New(fmt.Sprintf("invalid reference rule from %s to %s, the * in %s must match a * in %s", from, to, to, from))
+	}
+	return &ReferenceRule{From: from, To: to}, nil
+}
+
+// ParseReferenceRule reads a rule written as FROM=TO. Since locations
+// in references rarely contain "=", the first one separates them.
+func ParseReferenceRule(text string) (*ReferenceRule, error) {
+	i := strings.Index(text, "=")
+	if i < 0 {
+		return nil, errors.New(fmt.Sprintf("invalid reference rule %q, rules are written as FROM=TO", text))
+	}
+	return NewReferenceRule(text[:i], text[i+1:])
+}
+
+// Rewrite returns the reference that a rule changes a reference to, and
+// whether the rule applies to it.
+func (rule *ReferenceRule) Rewrite(ref string) (string, bool) {
+	if i := strings.Index(rule.From, "*"); i >= 0 {
+		prefix, suffix := rule.From[:i], rule.From[i+1:]
+		if len(ref) < len(prefix)+len(suffix) || !strings.HasPrefix(ref, prefix) || !strings.HasSuffix(ref, suffix) {
+			return "", false
+		}
+		return strings.Replace(rule.To, "*", ref[len(prefix):len(ref)-len(suffix)], 1), true
+	}
+	if ref == rule.From || strings.HasPrefix(ref, rule.From+"#") || strings.HasPrefix(ref, rule.From+"/") {
+		return rule.To + strings.TrimPrefix(ref, rule.From), true
+	}
+	return "", false
+}
+
+// Returns the result of the first rule that applies to a reference.
+func rewriteReference(ref string, rules []*ReferenceRule) (string, bool) {
+	for _, rule := range rules {
+		if rewritten, ok := rule.Rewrite(ref); ok {
+			return rewritten, rewritten != ref
+		}
+	}
+	return "", false
+}
+
+// RewriteReferences changes the references in a model with the first of
+// the rules that applies to each of them, and returns the number of
+// references that were changed. Like RenameReferences, it can be used
+// to move the files that references refer to, such as when remote
+// schemas are copied to local files or local files are published.
+func RewriteReferences(model interface{}, rules []*ReferenceRule) int {
+	return rewriteValue(reflect.ValueOf(model), rules)
+}
+
+func rewriteValue(v reflect.Value, rules []*ReferenceRule) int {
+	count := 0
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			count += rewriteValue(v.Elem(), rules)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if t.Field(i).Name == "XRef" && field.Kind() == reflect.String {
+				if ref, ok := rewriteReference(field.String(), rules); ok {
+					field.SetString(ref)
+					count++
+				}
+			} else {
+				count += rewriteValue(field, rules)
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return 0
+		}
+		for i := 0; i < v.Len(); i++ {
+			count += rewriteValue(v.Index(i), rules)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			count += rewriteValue(value, rules)
+			v.SetMapIndex(key, value)
+		}
+	}
+	return count
+}
+
+// RewriteReferencesInNode changes the $refs in a parsed file like
+// RewriteReferences changes those in a model. Files that aren't complete
+// descriptions, such as files of shared schemas, can be changed with it.
+// It returns the references that were changed, before they were rewritten.
+func RewriteReferencesInNode(node *yaml.Node, rules []*ReferenceRule) []string {
+	changed := make([]string, 0)
+	for _, value := range ReferenceNodes(node) {
+		if ref, ok := rewriteReference(value.Value, rules); ok {
+			changed = append(changed, value.Value)
+			value.Value = ref
+		}
+	}
+	return changed
+}
+
+// ReferenceNodes returns the values of the $refs in a node and its
+// children. Values that are reached through several aliases are
+// returned once.
+func ReferenceNodes(node *yaml.Node) []*yaml.Node {
+	refs := make([]*yaml.Node, 0)
+	visited := make(map[*yaml.Node]bool, 0)
+	var collect func(node *yaml.Node)
+	collect = func(node *yaml.Node) {
+		if node == nil || visited[node] {
+			return
+		}
+		visited[node] = true
+		if node.Kind == yaml.AliasNode {
+			collect(node.Alias)
+			return
+		}
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == "$ref" {
+					if _, ok := StringForScalarNode(node.Content[i+1]); ok {
+						refs = append(refs, resolveNode(node.Content[i+1]))
+					}
+				}
+			}
+		}
+		for _, child := range node.Content {
+			collect(child)
+		}
+	}
+	collect(node)
+	return refs
+}
+
+// unescapes the segments of JSON Pointers
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// ReferenceTokens returns the unescaped segments of the JSON Pointer in a
+// reference to a location in the same document, so "#/paths/~1pets" has the
+// segments "paths" and "/pets". References to other files are errors.
+func ReferenceTokens(ref string) ([]string, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, errors.New(fmt.Sprintf("unable to resolve %s, only references within a document can be resolved", ref))
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid reference %s: %s", ref, err.Error()))
+	}
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New(fmt.Sprintf("invalid reference %s, JSON Pointers begin with /", ref))
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RegistryScheme is the scheme of URLs that name descriptions published in
+// an API registry, such as "registry://acme/pets/v1". These URLs can be read
+// as inputs and used in $refs when a compilation's Registry option is set.
+const RegistryScheme = "registry"
+
+// A Registry is an HTTP service that publishes API descriptions by name.
+// The description named by registry://ORG/API/VERSION is fetched with a GET
+// request for the registry's URL followed by /ORG/API/VERSION.
+type Registry struct {
+	// URL is the location of the registry's API, such as "https://apis.example.com/v1".
+	URL string
+	// Token, if it is set, is sent as a bearer token with each request.
+	Token string
+	// Client sends the registry's requests. If it is nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Returns true if a filename is a URL that names a description in a registry.
+func isRegistryURL(filename string) bool {
+	return strings.HasPrefix(filename, RegistryScheme+"://")
+}
+
+// Location returns the URL in a registry's API of a description named by a registry URL.
+func (registry *Registry) Location(name string) (string, error) {
+	if !isRegistryURL(name) {
+		return "", errors.New(fmt.Sprintf("%s is not a %s URL", name, RegistryScheme))
+	}
+	path := strings.TrimPrefix(name, RegistryScheme+"://")
+	if i := strings.Index(path, "#"); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" {
+		return "", errors.New(fmt.Sprintf("%s does not name a description", name))
+	}
+	return strings.TrimSuffix(registry.URL, "/") + "/" + path, nil
+}
+
+// Fetches a description named by a registry URL.
+func (registry *Registry) fetch(name string, options *CompilerOptions) ([]byte, error) {
+	if registry == nil || registry.URL == "" {
+		return nil, errors.New(fmt.Sprintf("unable to fetch %s: no API registry is configured", name))
+	}
+	location, err := registry.Location(name)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/yaml, application/json")
+	if registry.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+registry.Token)
+	}
+	client := registry.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	done := options.throttle().wait(location)
+	defer done()
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("unable to fetch %s: the registry returned %s", name, response.Status))
+	}
+	if err := options.checkSize(name, response.ContentLength); err != nil {
+		return nil, err
+	}
+	return options.readAll(name, response.Body)
+}
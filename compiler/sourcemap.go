@@ -0,0 +1,126 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourcePosition is the location of a value in a source file.
+type SourcePosition struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// SourceMap maps the paths of the values in a compiled model to their
+// positions in the files that they were read from. Paths are the names of the
+// fields, keys, and indices that lead to values, separated by dots like the
+// descriptions of contexts, such as "paths./pets.get.responses.200". Values
+// in maps are placed at their keys.
+type SourceMap map[string]*SourcePosition
+
+// NewSourceMap returns the source map of a description that was parsed from
+// a file. If followReferences is true, the values that $refs refer to are
+// mapped at the paths of the $refs, as they are in models whose references
+// have been resolved, and the files that they are in are read with the options
+// of the context. References that refer to values that contain them are not
+// followed again.
+func NewSourceMap(filename string, info *yaml.Node, followReferences bool, context *Context) SourceMap {
+	m := &sourceMapper{
+		sourceMap:        make(SourceMap),
+		followReferences: followReferences,
+		context:          context,
+		following:        make(map[string]bool),
+	}
+	if info != nil && info.Kind == yaml.DocumentNode && len(info.Content) > 0 {
+		info = info.Content[0]
+	}
+	if info != nil {
+		m.mapNode(filename, "", info)
+	}
+	return m.sourceMap
+}
+
+type sourceMapper struct {
+	sourceMap        SourceMap
+	followReferences bool
+	context          *Context
+	following        map[string]bool // the references that are being followed
+}
+
+func (m *sourceMapper) add(filename, path string, node *yaml.Node) {
+	if path != "" {
+		m.sourceMap[path] = &SourcePosition{File: filename, Line: node.Line, Column: node.Column}
+	}
+}
+
+// Maps the values in a node, which has been added to the map.
+func (m *sourceMapper) mapNode(filename, path string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if m.followReferences {
+			if ref := MapValueForKey(node, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+				m.followReference(filename, path, ref.Value)
+				return
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, _ := KeyForNode(node.Content[i])
+			child := joinPath(path, key)
+			m.add(filename, child, node.Content[i])
+			m.mapNode(filename, child, node.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			child := joinPath(path, strconv.Itoa(i))
+			m.add(filename, child, item)
+			m.mapNode(filename, child, item)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			m.mapNode(filename, path, node.Alias)
+		}
+	}
+}
+
+// Maps the values of the target of a reference at the path of the reference.
+func (m *sourceMapper) followReference(filename, path, ref string) {
+	target := FileForRef(filename, ref)
+	key := target + "#"
+	if i := strings.Index(ref, "#"); i >= 0 {
+		key = target + ref[i:]
+	}
+	if m.following[key] {
+		return
+	}
+	info, err := ReadInfoForRefInContext(filename, ref, m.context)
+	if err != nil || info == nil {
+		return
+	}
+	m.following[key] = true
+	m.mapNode(target, path, info)
+	delete(m.following, key)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
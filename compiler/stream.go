@@ -0,0 +1,197 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChunkHandler is called for each entry of a map that is read in chunks.
+// It receives the keys of the map's location in the document, the name of
+// the entry, and its value. If it returns true, the entry is considered
+// handled and is released; otherwise the entry is kept in the document.
+type ChunkHandler func(path []string, name string, value *yaml.Node) bool
+
+// ReadInfoFromJSONInChunks reads a JSON document and returns it as a node,
+// handing the entries of some of its maps to a handler as soon as they are read.
+// Each chunked map is identified by the keys of its location in the document,
+// such as {"paths"} or {"components", "schemas"}. Entries that are handled are
+// not added to the returned node, so the memory that they use can be reclaimed
+// as soon as the handler has compiled them. Chunked maps remain in the returned
+// node, holding only the entries that were not handled.
+// Nodes that are read this way do not have line or column numbers.
+func ReadInfoFromJSONInChunks(reader io.Reader, chunked [][]string, handler ChunkHandler) (*yaml.Node, error) {
+	r := &chunkReader{decoder: json.NewDecoder(reader), chunked: chunked, handler: handler}
+	r.decoder.UseNumber()
+	node, err := r.readValue([]string{})
+	if err != nil {
+		return nil, err
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, errors.New(fmt.Sprintf("document has unexpected value: %s", Display(node)))
+	}
+	return node, nil
+}
+
+// ChunkResolver returns the value of an entry of a map that was read in chunks,
+// given the keys of the map's location and the name of the entry, or nil if
+// the map has no such entry. Entries are typically rebuilt from their models.
+type ChunkResolver func(path []string, name string) *yaml.Node
+
+// ReadInfoFromJSONFileInChunksWithOptions reads a JSON file in chunks like
+// ReadInfoFromJSONInChunks, reading the file as its entries are handled instead
+// of reading all of it into memory. The returned node is cached as the contents
+// of the file, so references into the file are resolved without reading it
+// again. References to the entries that were handled are resolved with the
+// resolver, which is only called after the file has been read.
+func ReadInfoFromJSONFileInChunksWithOptions(filename string, chunked [][]string, handler ChunkHandler, resolver ChunkResolver, options *CompilerOptions) (*yaml.Node, error) {
+	reader, err := OpenFileWithOptions(filename, options)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	// the hash of the file is computed as it is read
+	hash := sha256.New()
+	info, err := ReadInfoFromJSONInChunks(io.TeeReader(reader, hash), chunked, handler)
+	if err != nil {
+		return nil, err
+	}
+	entry := &infoCacheEntry{
+		hash:   hex.EncodeToString(hash.Sum(nil)),
+		info:   info,
+		infos:  []*yaml.Node{info},
+		chunks: &chunkedInfo{chunked: chunked, resolver: resolver},
+	}
+	options.cache().setEntry(filename, entry)
+	return info, nil
+}
+
+// The maps of a file that were read in chunks, whose handled entries
+// aren't in the file's cached info and are found with a resolver.
+type chunkedInfo struct {
+	chunked  [][]string
+	resolver ChunkResolver
+}
+
+// Returns the value of an entry of a map that was read in chunks, or nil
+// if the map at the path wasn't read in chunks or has no such entry.
+func (chunks *chunkedInfo) entry(path []string, name string) *yaml.Node {
+	if chunks == nil || chunks.resolver == nil || !isChunked(chunks.chunked, path) {
+		return nil
+	}
+	return chunks.resolver(path, name)
+}
+
+// Returns true if the map at a path is one of the chunked maps.
+func isChunked(chunked [][]string, path []string) bool {
+	for _, chunkedPath := range chunked {
+		if strings.Join(chunkedPath, "\x00") == strings.Join(path, "\x00") {
+			return true
+		}
+	}
+	return false
+}
+
+type chunkReader struct {
+	decoder *json.Decoder
+	chunked [][]string
+	handler ChunkHandler
+}
+
+// Reads the next JSON value and returns it as a node.
+func (r *chunkReader) readValue(path []string) (*yaml.Node, error) {
+	token, err := r.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			return r.readObject(path)
+		case '[':
+			return r.readArray(path)
+		}
+		return nil, errors.New(fmt.Sprintf("unexpected delimiter %s", t))
+	case string:
+		return NewScalarNodeForString(t), nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(string(t), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: string(t)}, nil
+	case bool:
+		return NewScalarNodeForBool(t), nil
+	case nil:
+		return NewNullNode(), nil
+	}
+	return nil, errors.New(fmt.Sprintf("unexpected token %v", token))
+}
+
+// Reads the members of a JSON object; the opening delimiter has already been read.
+func (r *chunkReader) readObject(path []string) (*yaml.Node, error) {
+	node := NewMappingNode()
+	chunked := isChunked(r.chunked, path)
+	for r.decoder.More() {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("unexpected object key %v", token))
+		}
+		value, err := r.readValue(append(path[:len(path):len(path)], key))
+		if err != nil {
+			return nil, err
+		}
+		if chunked && r.handler(path, key, value) {
+			continue
+		}
+		node.Content = append(node.Content, NewScalarNodeForString(key), value)
+	}
+	// read the closing delimiter
+	if _, err := r.decoder.Token(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// Reads the items of a JSON array; the opening delimiter has already been read.
+// Maps that are inside of arrays are never read in chunks.
+func (r *chunkReader) readArray(path []string) (*yaml.Node, error) {
+	node := NewSequenceNode()
+	for r.decoder.More() {
+		value, err := r.readValue(append(path[:len(path):len(path)], "[]"))
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, value)
+	}
+	// read the closing delimiter
+	if _, err := r.decoder.Token(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
@@ -0,0 +1,136 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A reader that writes a JSON document with many definitions as it is read,
+// so that the document is never held in memory by the test.
+type definitionsReader struct {
+	count  int
+	next   int
+	buffer bytes.Buffer
+	size   int
+}
+
+func (r *definitionsReader) Read(p []byte) (int, error) {
+	for r.buffer.Len() < len(p) && r.next <= r.count+1 {
+		switch {
+		case r.next == 0:
+			r.buffer.WriteString(`{"swagger":"2.0","definitions":{`)
+		case r.next <= r.count:
+			if r.next > 1 {
+				r.buffer.WriteString(",")
+			}
+			fmt.Fprintf(&r.buffer, `"Schema%d":{"type":"object","properties":{"name":{"type":"string"},"size":{"type":"integer","minimum":%d}}}`, r.next, r.next)
+		default:
+			r.buffer.WriteString("}}")
+		}
+		r.next++
+	}
+	n, err := r.buffer.Read(p)
+	r.size += n
+	return n, err
+}
+
+// Returns the number of bytes of memory that are in use after a collection.
+func heapInUse() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+func TestReadInfoFromJSONInChunksMemory(t *testing.T) {
+	const count = 50000
+	reader := &definitionsReader{count: count}
+	before := heapInUse()
+	var peak uint64
+	handled := 0
+	handler := func(path []string, name string, value *yaml.Node) bool {
+		handled++
+		if handled%5000 == 0 {
+			if used := heapInUse(); used > peak {
+				peak = used
+			}
+		}
+		return true
+	}
+	info, err := ReadInfoFromJSONInChunks(reader, [][]string{{"definitions"}}, handler)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if handled != count {
+		t.Errorf("Expected %d definitions to be handled, found %d", count, handled)
+	}
+	if definitions := MapValueForKey(info, "definitions"); definitions == nil || len(definitions.Content) != 0 {
+		t.Errorf("Expected the handled definitions to be released")
+	}
+	// a reader that holds the whole document would use more memory than its text
+	if peak > before && peak-before > uint64(reader.size/10) {
+		t.Errorf("Unexpected memory use: %d bytes were in use while reading %d bytes", peak-before, reader.size)
+	}
+}
+
+func TestReadInfoFromJSONFileInChunks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "api.json")
+	text := `{"swagger": "2.0",
+"parameters": {"limit": {"name": "limit", "in": "query", "type": "integer"}},
+"definitions": {
+  "Pet": {"type": "object"},
+  "Pets": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}}}`
+	if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache := NewCache()
+	options := &CompilerOptions{Cache: cache}
+	// the handled definitions are kept as they would be kept by a model
+	definitions := make(map[string]*yaml.Node)
+	handler := func(path []string, name string, value *yaml.Node) bool {
+		definitions[name] = value
+		return true
+	}
+	resolver := func(path []string, name string) *yaml.Node {
+		return definitions[name]
+	}
+	info, err := ReadInfoFromJSONFileInChunksWithOptions(filename, [][]string{{"definitions"}}, handler, resolver, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if len(definitions) != 2 || MapValueForKey(info, "parameters") == nil {
+		t.Errorf("Unexpected document: %s", Marshal(info))
+	}
+	context := NewContextWithOptions("$root", options)
+	for ref, expected := range map[string]string{
+		"#/definitions/Pet/type":        "object",
+		"#/definitions/Pets/items/$ref": "#/definitions/Pet",
+		"#/parameters/limit/in":         "query",
+	} {
+		value, err := ReadInfoForRefInContext(filename, ref, context)
+		if err != nil {
+			t.Errorf("Unexpected error: %+v", err)
+		} else if value.Value != expected {
+			t.Errorf("Expected %s to refer to %q, found %q", ref, expected, value.Value)
+		}
+	}
+	if _, err := ReadInfoForRefInContext(filename, "#/definitions/Dog", context); err == nil {
+		t.Errorf("Expected a reference to a missing definition to fail")
+	}
+	// references are resolved without reading and parsing the file again
+	if stats := cache.Statistics(); stats.InfoMisses != 0 || stats.InfoHits != 4 {
+		t.Errorf("Unexpected cache statistics: %+v", stats)
+	}
+}
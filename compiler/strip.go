@@ -0,0 +1,75 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"reflect"
+)
+
+// documentationFields are the names of the fields that StripDocumentation clears.
+var documentationFields = map[string]bool{
+	"Description": true,
+	"Summary":     true,
+	"Example":     true,
+	"Examples":    true,
+}
+
+// StripDocumentation removes the descriptions, summaries, and examples from a
+// model. These often make up most of the size of a compiled description, and
+// consumers that only need its structure, such as gateways and validators,
+// can use the smaller model. Since some descriptions are required, the
+// stripped model may not be valid when it is written as an OpenAPI
+// description. The model is a pointer to a generated message,
+// and all of the messages that it contains are stripped.
+func StripDocumentation(model interface{}) {
+	stripValue(reflect.ValueOf(model))
+}
+
+func stripValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			stripValue(v.Elem())
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if documentationFields[t.Field(i).Name] {
+				field.Set(reflect.Zero(field.Type()))
+			} else {
+				stripValue(field)
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			stripValue(v.Index(i))
+		}
+	case reflect.Map:
+		// map values can't be changed in place, so each value is copied
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			stripValue(value)
+			v.SetMapIndex(key, value)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"net/url"
+	"sync"
+	"time"
+)
+
+// A Throttle limits the requests that compilations make to remote servers,
+// so that descriptions with many references to one server don't overload it
+// or trip the firewalls that protect it. Throttles can be shared by
+// compilations to limit their combined requests.
+type Throttle struct {
+	slots    chan struct{} // holds a value for each request in progress, or nil if they are unlimited
+	interval time.Duration // the time between the starts of requests to a host, or zero if it is unlimited
+	mutex    sync.Mutex
+	next     map[string]time.Time // the times that the next requests to hosts can start
+	now      func() time.Time
+	sleep    func(time.Duration)
+}
+
+// NewThrottle creates a throttle that allows at most maxConcurrent requests
+// at a time and at most requestsPerSecond requests to each host. Limits that
+// are zero are not applied.
+func NewThrottle(maxConcurrent int, requestsPerSecond float64) *Throttle {
+	throttle := &Throttle{next: make(map[string]time.Time), now: time.Now, sleep: time.Sleep}
+	if maxConcurrent > 0 {
+		throttle.slots = make(chan struct{}, maxConcurrent)
+	}
+	if requestsPerSecond > 0 {
+		throttle.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	return throttle
+}
+
+// Waits until a request to a URL is allowed and returns a function that
+// ends the request. A nil throttle allows all requests.
+func (throttle *Throttle) wait(fileurl string) func() {
+	if throttle == nil {
+		return func() {}
+	}
+	if throttle.interval > 0 {
+		host := fileurl
+		if u, err := url.Parse(fileurl); err == nil {
+			host = u.Host
+		}
+		throttle.mutex.Lock()
+		now := throttle.now()
+		start := throttle.next[host]
+		if start.Before(now) {
+			start = now
+		}
+		throttle.next[host] = start.Add(throttle.interval)
+		throttle.mutex.Unlock()
+		throttle.sleep(start.Sub(now))
+	}
+	if throttle.slots == nil {
+		return func() {}
+	}
+	throttle.slots <- struct{}{}
+	return func() { <-throttle.slots }
+}
+
+// Returns the throttle of a compilation, or nil if it has none.
+func (options *CompilerOptions) throttle() *Throttle {
+	if options == nil {
+		return nil
+	}
+	return options.Throttle
+}
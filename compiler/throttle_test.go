@@ -0,0 +1,95 @@
+package compiler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestThrottleRate(t *testing.T) {
+	throttle := NewThrottle(0, 50)
+	// the clock only moves when the throttle sleeps
+	clock := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	throttle.now = func() time.Time { return clock }
+	throttle.sleep = func(d time.Duration) { clock = clock.Add(d) }
+	var starts []time.Duration
+	for i := 0; i < 4; i++ {
+		before := clock
+		throttle.wait(fmt.Sprintf("https://pets.example.com/pet%d.yaml", i))()
+		starts = append(starts, clock.Sub(before))
+	}
+	// requests to other hosts aren't delayed
+	before := clock
+	throttle.wait("https://owners.example.com/owner.yaml")()
+	starts = append(starts, clock.Sub(before))
+	expected := []time.Duration{0, 20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond, 0}
+	if fmt.Sprint(starts) != fmt.Sprint(expected) {
+		t.Errorf("Expected delays of %v, found %v", expected, starts)
+	}
+	// a host that hasn't been used recently isn't delayed
+	clock = clock.Add(time.Second)
+	before = clock
+	throttle.wait("https://pets.example.com/pet.yaml")()
+	if delay := clock.Sub(before); delay != 0 {
+		t.Errorf("Expected no delay after an idle second, found %s", delay)
+	}
+}
+
+func TestThrottleConcurrency(t *testing.T) {
+	var mutex sync.Mutex
+	active, maxActive, barrier := 0, 0, 0
+	var release chan struct{}
+	// requests wait until "barrier" of them are in progress at once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		if active == barrier {
+			close(release)
+		}
+		wait := release
+		mutex.Unlock()
+		select {
+		case <-wait:
+		case <-time.After(10 * time.Second):
+			t.Errorf("Expected %d concurrent fetches", barrier)
+		}
+		mutex.Lock()
+		active--
+		mutex.Unlock()
+		w.Write([]byte("Pet: {type: object}"))
+	}))
+	defer server.Close()
+	fetch := func(throttle *Throttle, concurrent int) int {
+		mutex.Lock()
+		active, maxActive, barrier = 0, 0, concurrent
+		release = make(chan struct{})
+		mutex.Unlock()
+		options := &CompilerOptions{Cache: NewCache(), AllowPrivateNetworks: true, Throttle: throttle}
+		var wait sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wait.Add(1)
+			go func(i int) {
+				defer wait.Done()
+				if _, err := ReadBytesForReferenceWithOptions(fmt.Sprintf("%s/pet%d.yaml", server.URL, i), options); err != nil {
+					t.Errorf("Unexpected error: %+v", err)
+				}
+			}(i)
+		}
+		wait.Wait()
+		mutex.Lock()
+		defer mutex.Unlock()
+		return maxActive
+	}
+	if n := fetch(NewThrottle(2, 0), 2); n != 2 {
+		t.Errorf("Expected at most 2 concurrent fetches, found %d", n)
+	}
+	if n := fetch(nil, 6); n != 6 {
+		t.Errorf("Expected unthrottled fetches to run concurrently, found %d", n)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiler
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// A TLSConfig names the certificates that are used to fetch files over
+// HTTPS, such as the schemas that organizations serve from hosts with
+// certificates issued by their own certificate authorities.
+type TLSConfig struct {
+	// Host is the host name, with an optional port, of the servers that the
+	// configuration is used for. If it is empty, the configuration is used
+	// for all servers that don't have their own.
+	Host string
+	// CAFile is a PEM file of the certificates of the authorities that are
+	// trusted in addition to the system's.
+	CAFile string
+	// CertFile and KeyFile are PEM files of the certificate and private key
+	// that authenticate the client to servers that require it.
+	CertFile string
+	KeyFile  string
+
+	once    sync.Once
+	clients [2]*http.Client // indexed by public
+	err     error
+}
+
+// Returns the TLS configuration of the host of a URL, or nil if it has none.
+func (options *CompilerOptions) tlsConfigForURL(fileurl string) *TLSConfig {
+	if options == nil || len(options.TLS) == 0 {
+		return nil
+	}
+	u, err := url.Parse(fileurl)
+	if err != nil {
+		return nil
+	}
+	var defaultConfig *TLSConfig
+	for _, config := range options.TLS {
+		if config.Host != "" && config.isForHost(u) {
+			return config
+		}
+		if config.Host == "" && defaultConfig == nil {
+			defaultConfig = config
+		}
+	}
+	return defaultConfig
+}
+
+// Returns true if a configuration is for the host of a URL.
+func (config *TLSConfig) isForHost(u *url.URL) bool {
+	return config.Host == u.Host || config.Host == u.Hostname()
+}
+
+// Refuses redirects to hosts other than the host of a configuration, so that
+// its client certificate isn't presented to them and its certificate
+// authorities aren't trusted for them.
+func (config *TLSConfig) checkRedirect(request *http.Request, via []*http.Request) error {
+	if !config.isForHost(request.URL) {
+		return errors.New(fmt.Sprintf("unable to follow the redirect to %s: the TLS configuration of %s is not used for other hosts", request.URL, config.Host))
+	}
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return nil
+}
+
+// Returns a client that fetches files with the certificates of a configuration,
+// which are read when it is first used.
+func (config *TLSConfig) client(public bool) (*http.Client, error) {
+	config.once.Do(func() {
+		var tlsConfig *tls.Config
+		tlsConfig, config.err = config.tlsConfig()
+		if config.err == nil {
+			config.clients[0] = newClient(false, tlsConfig)
+			config.clients[1] = newClient(true, tlsConfig)
+			if config.Host != "" {
+				config.clients[0].CheckRedirect = config.checkRedirect
+				config.clients[1].CheckRedirect = config.checkRedirect
+			}
+		}
+	})
+	if config.err != nil {
+		return nil, config.err
+	}
+	if public {
+		return config.clients[1], nil
+	}
+	return config.clients[0], nil
+}
+
+// Reads the certificates of a configuration.
+func (config *TLSConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if config.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		bytes, err := ioutil.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(bytes) {
+			return nil, errors.New(fmt.Sprintf("no certificates were found in %s", config.CAFile))
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.CertFile != "" || config.KeyFile != "" {
+		if config.CertFile == "" || config.KeyFile == "" {
+			return nil, errors.New("client certificates require both a certificate file and a key file")
+		}
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("unable to read the client certificate %s: %s", config.CertFile, err.Error()))
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,118 @@
+package compiler
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	clientCert, clientKey := writeCertificate(t, dir, "client")
+	clientPool := x509.NewCertPool()
+	clientPool.AppendCertsFromPEM(readTestFile(t, clientCert))
+
+	// another server that requests are redirected to
+	other := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("Pet: {type: object}"))
+	}))
+	defer other.Close()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved.yaml":
+			http.Redirect(w, r, "/pet.yaml", http.StatusFound)
+		case "/elsewhere.yaml":
+			http.Redirect(w, r, other.URL+"/pet.yaml", http.StatusFound)
+		default:
+			w.Write([]byte("Pet: {type: object}"))
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientPool}
+	server.StartTLS()
+	defer server.Close()
+	caFile := filepath.Join(dir, "ca.pem")
+	ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0644)
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	for _, test := range []struct {
+		name   string
+		config []*TLSConfig
+		path   string
+		err    string
+	}{
+		{"no configuration", nil, "/pet.yaml", "certificate"},
+		{"no client certificate", []*TLSConfig{{CAFile: caFile}}, "/pet.yaml", "certificate"},
+		{"other host", []*TLSConfig{{Host: "example.com", CAFile: caFile, CertFile: clientCert, KeyFile: clientKey}}, "/pet.yaml", "certificate"},
+		{"all hosts", []*TLSConfig{{CAFile: caFile, CertFile: clientCert, KeyFile: clientKey}}, "/pet.yaml", ""},
+		{"host", []*TLSConfig{{CAFile: clientCert}, {Host: host, CAFile: caFile, CertFile: clientCert, KeyFile: clientKey}}, "/pet.yaml", ""},
+		{"redirect", []*TLSConfig{{Host: host, CAFile: caFile, CertFile: clientCert, KeyFile: clientKey}}, "/moved.yaml", ""},
+		{"redirect to other host", []*TLSConfig{{Host: host, CAFile: caFile, CertFile: clientCert, KeyFile: clientKey}}, "/elsewhere.yaml", "unable to follow the redirect"},
+		{"missing key", []*TLSConfig{{CAFile: caFile, CertFile: clientCert}}, "/pet.yaml", "both a certificate file and a key file"},
+		{"invalid bundle", []*TLSConfig{{CAFile: clientKey}}, "/pet.yaml", "no certificates were found"},
+	} {
+		options := &CompilerOptions{Cache: NewCache(), TLS: test.config}
+		_, err := ReadBytesForFileWithOptions(server.URL+test.path, options)
+		if test.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %+v", test.name, err)
+		} else if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+// Writes a self-signed certificate and its key to PEM files and returns their names.
+func writeCertificate(t *testing.T, dir string, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0644)
+	ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	return certFile, keyFile
+}
+
+// Reads a file that was written by a test.
+func readTestFile(t *testing.T, filename string) []byte {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes
+}
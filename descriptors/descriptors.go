@@ -0,0 +1,182 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package descriptors provides the Protocol Buffer descriptors of the gnostic
+// models, so that tools that work with messages dynamically, such as generic
+// inspectors, Any unpackers, and transforms that are driven by descriptors,
+// can use gnostic models without importing their generated Go types.
+//
+// Importing this package registers all of the gnostic models with the
+// github.com/golang/protobuf/proto registry.
+//
+//	set, err := descriptors.FileDescriptorSet()
+//	...
+//	message, err := descriptors.NewMessage("openapi.v3.Document")
+package descriptors
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/extensions"
+	"github.com/googleapis/gnostic/plugins"
+)
+
+// Generated messages return their files' gzipped descriptors.
+type generatedMessage interface {
+	Descriptor() ([]byte, []int)
+}
+
+// Messages of each of the gnostic models. Their descriptors name the files
+// that the models are registered in.
+var models = []generatedMessage{
+	&openapi_v2.Document{},
+	&openapi_v3.Document{},
+	&openapiextension_v1.ExtensionHandlerRequest{},
+	&openapi_plugin_v1.Request{},
+}
+
+// FileNames returns the names of the files that define the gnostic models,
+// as they are registered with the proto package.
+func FileNames() []string {
+	names := make([]string, 0, len(models))
+	for _, model := range models {
+		names = append(names, fileName(model))
+	}
+	return names
+}
+
+// Returns the name of the file that defines a generated message.
+func fileName(message generatedMessage) string {
+	gz, _ := message.Descriptor()
+	file, err := decompress(gz)
+	if err != nil {
+		return ""
+	}
+	return file.GetName()
+}
+
+// File returns the descriptor of a registered file, which can be one of
+// the files that define the gnostic models or a file that they import.
+func File(name string) (*descriptor.FileDescriptorProto, error) {
+	gz := proto.FileDescriptor(name)
+	if gz == nil {
+		return nil, fmt.Errorf("no file named %s is registered", name)
+	}
+	return decompress(gz)
+}
+
+// Decompresses a gzipped FileDescriptorProto.
+func decompress(gz []byte) (*descriptor.FileDescriptorProto, error) {
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	file := &descriptor.FileDescriptorProto{}
+	if err = proto.Unmarshal(b, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// FileDescriptorSet returns the descriptors of the files that define the
+// gnostic models and of the files that they import. Files follow the files
+// that they import, which is the order that is needed to build registries
+// of descriptors from the set.
+func FileDescriptorSet() (*descriptor.FileDescriptorSet, error) {
+	set := &descriptor.FileDescriptorSet{}
+	added := make(map[string]bool)
+	var add func(name string) error
+	add = func(name string) error {
+		if added[name] {
+			return nil
+		}
+		added[name] = true
+		file, err := File(name)
+		if err != nil {
+			return err
+		}
+		for _, dependency := range file.Dependency {
+			if err = add(dependency); err != nil {
+				return err
+			}
+		}
+		set.File = append(set.File, file)
+		return nil
+	}
+	for _, name := range FileNames() {
+		if err := add(name); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// Message returns the descriptor of a message of one of the gnostic models,
+// which is named with its full name, such as "openapi.v3.Schema".
+func Message(name string) (*descriptor.DescriptorProto, error) {
+	for _, fileName := range FileNames() {
+		file, err := File(fileName)
+		if err != nil {
+			return nil, err
+		}
+		prefix := file.GetPackage() + "."
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if message := messageWithName(file.MessageType, strings.TrimPrefix(name, prefix)); message != nil {
+			return message, nil
+		}
+	}
+	return nil, fmt.Errorf("no message named %s is defined by the gnostic models", name)
+}
+
+// Returns the message with a name relative to a list of messages,
+// searching the types that are nested in them.
+func messageWithName(messages []*descriptor.DescriptorProto, name string) *descriptor.DescriptorProto {
+	for _, message := range messages {
+		if message.GetName() == name {
+			return message
+		}
+		if strings.HasPrefix(name, message.GetName()+".") {
+			if nested := messageWithName(message.NestedType, strings.TrimPrefix(name, message.GetName()+".")); nested != nil {
+				return nested
+			}
+		}
+	}
+	return nil
+}
+
+// NewMessage creates an empty message of a registered type, which is named
+// with its full name. Messages can be unpacked into it from Anys with
+// ptypes.UnmarshalAny or read with proto.Unmarshal.
+func NewMessage(name string) (proto.Message, error) {
+	t := proto.MessageType(name)
+	if t == nil {
+		return nil, fmt.Errorf("no message named %s is registered", name)
+	}
+	return reflect.New(t.Elem()).Interface().(proto.Message), nil
+}
@@ -0,0 +1,74 @@
+package descriptors
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+func TestFileDescriptorSet(t *testing.T) {
+	set, err := FileDescriptorSet()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	seen := make(map[string]bool)
+	for _, file := range set.File {
+		for _, dependency := range file.Dependency {
+			if !seen[dependency] {
+				t.Errorf("%s is before %s, which it imports", file.GetName(), dependency)
+			}
+		}
+		seen[file.GetName()] = true
+	}
+	for _, name := range append(FileNames(), "google/protobuf/any.proto") {
+		if !seen[name] {
+			t.Errorf("Expected %s in the set", name)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	message, err := Message("openapi.v3.Schema")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	found := false
+	for _, field := range message.Field {
+		if field.GetName() == "all_of" && field.GetTypeName() == ".openapi.v3.SchemaOrReference" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected an all_of field in %+v", message)
+	}
+	if _, err := Message("openapi.v3.Unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown message")
+	}
+}
+
+func TestNewMessage(t *testing.T) {
+	// messages are unpacked from Anys without using their Go types
+	packed, err := ptypes.MarshalAny(&openapi_v3.Info{Title: "Pets"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	name, err := ptypes.AnyMessageName(packed)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	message, err := NewMessage(name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if err = ptypes.UnmarshalAny(packed, message); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if !proto.Equal(message, &openapi_v3.Info{Title: "Pets"}) {
+		t.Errorf("Unexpected message %+v", message)
+	}
+	if _, err := NewMessage("openapi.v3.Unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown message")
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/linter"
+	"github.com/googleapis/gnostic/validator"
+	"gopkg.in/yaml.v3"
+)
+
+// The formats that errors and warnings can be written in.
+const (
+	errorFormatText  = "text"
+	errorFormatJSON  = "json"
+	errorFormatSARIF = "sarif"
+)
+
+// The version and schema of the SARIF logs that are written.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// A SARIF log, with the properties that gnostic writes.
+type sarifLog struct {
+	Version string      `json:"version"`
+	Schema  string      `json:"$schema"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId,omitempty"`
+	Level     string           `json:"level"`
+	Message   sarifMessage     `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation   `json:"physicalLocation"`
+	LogicalLocations []*sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// Sets the positions of problems that were found in a compiled model to
+// the positions of the values at their paths in the parsed source. Problems
+// with values that aren't in the source, such as missing fields, are placed
+// at the nearest value that contains them. Problems with the values of maps
+// begin at their keys and end at the ends of their values.
+func locateProblems(root *yaml.Node, problems []*compiler.Error) {
+	if root == nil {
+		return
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	for _, problem := range problems {
+		if problem.Line > 0 || problem.Context == nil {
+			continue
+		}
+		node, position := root, root
+		for _, segment := range pointerSegments(problem.Path()) {
+			child := childNode(node, segment)
+			if child == nil {
+				break
+			}
+			// values in maps are placed at their keys
+			position = child
+			if key := keyNode(node, segment); key != nil {
+				position = key
+			}
+			node = child
+		}
+		problem.Line, problem.Column = position.Line, position.Column
+		problem.EndLine, problem.EndColumn = compiler.NodeEnd(node)
+	}
+}
+
+// Returns the node of a key in a map, or nil if it isn't found.
+func keyNode(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if k, ok := compiler.KeyForNode(node.Content[i]); ok && k == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// The extension that names the rules whose problems are not reported in
+// an object and the values that it contains.
+const ignoreExtension = "x-gnostic-ignore"
+
+// Returns the rules named by the ignore extension of a map.
+func ignoredRules(node *yaml.Node) []string {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	value := compiler.MapValueForKey(node, ignoreExtension)
+	if value == nil {
+		return nil
+	}
+	if value.Kind == yaml.ScalarNode {
+		return []string{value.Value}
+	}
+	return compiler.StringArrayForSequenceNode(value)
+}
+
+// Returns the problems that aren't suppressed by the ignore extensions
+// of the objects that contain them in the parsed source.
+func suppressProblems(root *yaml.Node, problems []*compiler.Error) []*compiler.Error {
+	if root == nil {
+		return problems
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	reported := make([]*compiler.Error, 0)
+	for _, problem := range problems {
+		ignored := false
+		segments := pointerSegments(problem.Path())
+		for i, node := 0, root; node != nil && !ignored; i++ {
+			ignored = containsString(ignoredRules(node), problem.Code)
+			if i == len(segments) {
+				break
+			}
+			node = childNode(node, segments[i])
+		}
+		if !ignored {
+			reported = append(reported, problem)
+		}
+	}
+	return reported
+}
+
+// Returns the entry of a problem in a baseline. Problems are identified by
+// their rules and paths, which don't change when lines are added to a file.
+func baselineEntry(problem *compiler.Error) string {
+	if problem.Context == nil {
+		return problem.Code
+	}
+	return problem.Code + " " + problem.Path()
+}
+
+// Reads the entries of a baseline file, which has one entry on each line.
+// Lines that begin with # are comments. A missing file is an empty baseline
+// when it is going to be written.
+func readBaseline(filename string, create bool) (map[string]bool, error) {
+	baseline := make(map[string]bool)
+	bytes, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) && create {
+		return baseline, nil
+	} else if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to read the baseline %s: %s", filename, err.Error()))
+	}
+	for _, line := range strings.Split(string(bytes), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			baseline[line] = true
+		}
+	}
+	return baseline, nil
+}
+
+// Writes the entries of a baseline file in order.
+func writeBaseline(filename string, baseline map[string]bool) error {
+	entries := make([]string, 0, len(baseline))
+	for entry := range baseline {
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+	text := "# Problems that gnostic doesn't report, as RULE PATH.\n" + strings.Join(entries, "\n") + "\n"
+	if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+		return errors.New(fmt.Sprintf("unable to write the baseline %s: %s", filename, err.Error()))
+	}
+	return nil
+}
+
+// Returns true if a list contains a string.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the SARIF level of a severity.
+func sarifLevel(severity compiler.Severity) string {
+	switch severity {
+	case compiler.SeverityWarning:
+		return "warning"
+	case compiler.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+// Returns the descriptions of the codes of the linter's rules and the validator's checks.
+func ruleDescriptions() map[string]string {
+	descriptions := make(map[string]string)
+	for _, rule := range linter.Rules {
+		descriptions[rule.Name] = rule.Description
+	}
+	for _, check := range validator.Checks {
+		descriptions[check.Name] = check.Description
+	}
+	return descriptions
+}
+
+// Returns a SARIF log of the problems found in a file.
+func sarifBytes(source string, problems []*compiler.Error) ([]byte, error) {
+	descriptions := ruleDescriptions()
+	driver := sarifDriver{Name: "gnostic", InformationURI: "https://github.com/googleapis/gnostic", Rules: make([]*sarifRule, 0)}
+	described := make(map[string]bool)
+	results := make([]*sarifResult, 0)
+	for _, problem := range problems {
+		if problem.Code != "" && !described[problem.Code] {
+			described[problem.Code] = true
+			description := descriptions[problem.Code]
+			if description == "" {
+				description = problem.Code
+			}
+			driver.Rules = append(driver.Rules, &sarifRule{ID: problem.Code, ShortDescription: sarifMessage{Text: description}})
+		}
+		location := &sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(source)}},
+		}
+		// the positions of problems in referenced files are in those files
+		if problem.Line > 0 && !problem.InReference() {
+			location.PhysicalLocation.Region = &sarifRegion{
+				StartLine:   problem.Line,
+				StartColumn: problem.Column,
+				EndLine:     problem.EndLine,
+				EndColumn:   problem.EndColumn,
+			}
+		}
+		if problem.Context != nil {
+			location.LogicalLocations = []*sarifLogicalLocation{{FullyQualifiedName: problem.Path()}}
+		}
+		results = append(results, &sarifResult{
+			RuleID:    problem.Code,
+			Level:     sarifLevel(problem.Severity),
+			Message:   sarifMessage{Text: problem.Message},
+			Locations: []*sarifLocation{location},
+		})
+	}
+	log := &sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []*sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// Returns a JSON report of the problems found in a file. Problems are
+// described like the errors and problems of the service's responses.
+func jsonDiagnosticBytes(source string, documentNumber int, problems []*compiler.Error) ([]byte, error) {
+	items := make([]*serveError, 0)
+	for _, problem := range problems {
+		items = append(items, serveErrors(problem)...)
+	}
+	return json.MarshalIndent(struct {
+		Source      string        `json:"source"`
+		Document    int           `json:"document,omitempty"`
+		Diagnostics []*serveError `json:"diagnostics"`
+	}{source, documentNumber, items}, "", "  ")
+}
@@ -0,0 +1,463 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+	"github.com/googleapis/gnostic/query"
+	"gopkg.in/yaml.v3"
+)
+
+const exploreUsage = `
+Usage: gnostic explore OPENAPI_SOURCE
+  Compile an OpenAPI description and navigate its model interactively.
+  Press tab to complete commands and keys.
+`
+
+const exploreHelp = `Commands:
+  ls [KEY]            List the keys or items of the current value.
+  cd KEY              Move to a key or item of the current value. Keys can
+                      contain slashes, so "cd /pets" moves into a path, and
+                      keys that aren't found are tried as "KEY/KEY/...".
+  cd ..               Move to the parent of the current value.
+  cd /                Move to the root of the document.
+  cd #/POINTER        Move to the value at a JSON pointer, such as a $ref.
+  pwd                 Print the JSON pointer of the current value.
+  show [json] [KEY]   Print the current value or one of its keys as YAML or JSON.
+  ref                 Move to the target of the current value's $ref.
+  refs                List the $refs to the current value.
+  query EXPRESSION    List the values selected by a query, which can start
+                      with @ to query the current value.
+  help                Print this message.
+  quit                Leave the explorer.
+`
+
+var exploreCommands = []string{"cd", "help", "ls", "pwd", "query", "quit", "ref", "refs", "show"}
+
+// explorer holds the state of an interactive session.
+type explorer struct {
+	root    *yaml.Node
+	pointer string // the location of the current value
+	out     io.Writer
+}
+
+func newExplorer(root *yaml.Node, out io.Writer) *explorer {
+	return &explorer{root: root, out: out}
+}
+
+// Returns the segments of a JSON pointer, unescaped.
+func pointerSegments(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = strings.Replace(strings.Replace(segment, "~1", "/", -1), "~0", "~", -1)
+	}
+	return segments
+}
+
+// Returns the value of a node that has a key or index.
+func childNode(node *yaml.Node, key string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return compiler.MapValueForKey(node, key)
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(node.Content) {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// Returns the value at a JSON pointer, or nil if there isn't one.
+func (e *explorer) nodeAt(pointer string) *yaml.Node {
+	node := e.root
+	for _, segment := range pointerSegments(pointer) {
+		if node = childNode(node, segment); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Returns the keys of a map or the indices of a sequence.
+func childKeys(node *yaml.Node) []string {
+	keys := make([]string, 0)
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			key, _ := compiler.KeyForNode(node.Content[i])
+			keys = append(keys, key)
+		}
+	case yaml.SequenceNode:
+		for i := range node.Content {
+			keys = append(keys, strconv.Itoa(i))
+		}
+	}
+	return keys
+}
+
+// Returns a short description of a value for listings.
+func summary(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if ref := compiler.MapValueForKey(node, "$ref"); ref != nil {
+			return "$ref " + ref.Value
+		}
+		return fmt.Sprintf("{%d}", len(node.Content)/2)
+	case yaml.SequenceNode:
+		return fmt.Sprintf("[%d]", len(node.Content))
+	}
+	value := strings.Replace(node.Value, "\n", " ", -1)
+	if len(value) > 60 {
+		value = value[:57] + "..."
+	}
+	return value
+}
+
+// Returns the location of a value relative to the current value.
+func (e *explorer) resolvePath(path string) (string, bool) {
+	switch {
+	case path == "" || path == ".":
+		return e.pointer, true
+	case path == "/":
+		return "", true
+	case path == "..":
+		if i := strings.LastIndex(e.pointer, "/"); i >= 0 {
+			return e.pointer[:i], true
+		}
+		return "", true
+	case strings.HasPrefix(path, "#"):
+		pointer := strings.TrimPrefix(path, "#")
+		return pointer, e.nodeAt(pointer) != nil
+	}
+	pointer := e.pointer + "/" + compiler.PointerEscape(path)
+	if e.nodeAt(pointer) != nil {
+		return pointer, true
+	}
+	// keys that aren't found are tried as paths of several keys, and since
+	// keys can contain slashes, the longest key that is found is used at each step
+	pointer, node := e.pointer, e.nodeAt(e.pointer)
+	parts := strings.Split(path, "/")
+	for len(parts) > 0 {
+		n := len(parts)
+		for ; n > 0; n-- {
+			if child := childNode(node, strings.Join(parts[:n], "/")); child != nil {
+				node = child
+				break
+			}
+		}
+		if n == 0 {
+			return "", false
+		}
+		pointer += "/" + compiler.PointerEscape(strings.Join(parts[:n], "/"))
+		parts = parts[n:]
+	}
+	return pointer, true
+}
+
+// Returns the completions of the last word of a line.
+func (e *explorer) complete(line string) []string {
+	fields := strings.SplitN(line, " ", 2)
+	if len(fields) == 1 {
+		return matchingWords(exploreCommands, fields[0], "", " ")
+	}
+	command, argument := fields[0], strings.TrimLeft(fields[1], " ")
+	prefix := line[:len(line)-len(argument)]
+	switch command {
+	case "cd", "ls", "show":
+		if command == "show" && strings.HasPrefix(argument, "json ") {
+			prefix += "json "
+			argument = strings.TrimPrefix(argument, "json ")
+		}
+		node := e.nodeAt(e.pointer)
+		if node == nil {
+			return nil
+		}
+		return matchingWords(childKeys(node), argument, prefix, "")
+	}
+	return nil
+}
+
+// Returns the words that start with a prefix, with text added before and after them.
+func matchingWords(words []string, partial string, before string, after string) []string {
+	matches := make([]string, 0)
+	for _, word := range words {
+		if strings.HasPrefix(word, partial) {
+			matches = append(matches, before+word+after)
+		}
+	}
+	return matches
+}
+
+// Writes a value as YAML or JSON.
+func (e *explorer) show(node *yaml.Node, json bool) {
+	if json {
+		bytes, err := jsonwriter.Marshal(node)
+		if err != nil {
+			fmt.Fprintf(e.out, "%+v\n", err)
+			return
+		}
+		e.out.Write(bytes)
+		return
+	}
+	if node.Kind == yaml.ScalarNode {
+		fmt.Fprintln(e.out, node.Value)
+		return
+	}
+	e.out.Write(compiler.Marshal(node))
+}
+
+// Runs a command and returns false if the session should end.
+func (e *explorer) execute(line string) bool {
+	line = strings.TrimSpace(line)
+	fields := strings.SplitN(line, " ", 2)
+	command, argument := fields[0], ""
+	if len(fields) > 1 {
+		argument = strings.TrimSpace(fields[1])
+	}
+	current := e.nodeAt(e.pointer)
+	switch command {
+	case "":
+	case "quit", "exit":
+		return false
+	case "help":
+		fmt.Fprint(e.out, exploreHelp)
+	case "pwd":
+		fmt.Fprintln(e.out, "#"+e.pointer)
+	case "ls":
+		pointer, ok := e.resolvePath(argument)
+		if !ok {
+			fmt.Fprintf(e.out, "%s not found\n", argument)
+			break
+		}
+		node := e.nodeAt(pointer)
+		for _, key := range childKeys(node) {
+			fmt.Fprintf(e.out, "%-30s %s\n", key, summary(childNode(node, key)))
+		}
+	case "cd":
+		pointer, ok := e.resolvePath(argument)
+		if !ok {
+			fmt.Fprintf(e.out, "%s not found\n", argument)
+			break
+		}
+		e.pointer = pointer
+	case "show":
+		json := argument == "json" || strings.HasPrefix(argument, "json ")
+		if json {
+			argument = strings.TrimSpace(strings.TrimPrefix(argument, "json"))
+		}
+		pointer, ok := e.resolvePath(argument)
+		if !ok {
+			fmt.Fprintf(e.out, "%s not found\n", argument)
+			break
+		}
+		e.show(e.nodeAt(pointer), json)
+	case "ref":
+		ref := compiler.MapValueForKey(current, "$ref")
+		if ref == nil {
+			fmt.Fprintln(e.out, "the current value has no $ref")
+		} else if !strings.HasPrefix(ref.Value, "#") {
+			fmt.Fprintf(e.out, "%s is in another file\n", ref.Value)
+		} else if e.nodeAt(strings.TrimPrefix(ref.Value, "#")) == nil {
+			fmt.Fprintf(e.out, "%s not found\n", ref.Value)
+		} else {
+			e.pointer = strings.TrimPrefix(ref.Value, "#")
+		}
+	case "refs":
+		target := "#" + e.pointer
+		pointers := make([]string, 0)
+		for _, match := range query.MustCompile("$..['$ref']").Evaluate(e.root) {
+			if match.Node.Value == target {
+				pointers = append(pointers, "#"+strings.TrimSuffix(match.Pointer, "/$ref"))
+			}
+		}
+		sort.Strings(pointers)
+		for _, pointer := range pointers {
+			fmt.Fprintln(e.out, pointer)
+		}
+	case "query":
+		expression := argument
+		root := e.root
+		if strings.HasPrefix(expression, "@") {
+			expression, root = "$"+expression[1:], current
+		}
+		q, err := query.Compile(expression)
+		if err != nil {
+			fmt.Fprintf(e.out, "%+v\n", err)
+			break
+		}
+		for _, match := range q.Evaluate(root) {
+			pointer := match.Pointer
+			if root == current {
+				pointer = e.pointer + pointer
+			}
+			fmt.Fprintf(e.out, "%-40s %s\n", "#"+pointer, summary(match.Node))
+		}
+	default:
+		fmt.Fprintf(e.out, "Unknown command %s. Type help for a list of commands.\n", command)
+	}
+	return true
+}
+
+func (e *explorer) prompt() string {
+	return "#" + e.pointer + "> "
+}
+
+// Runs commands read from a reader until it ends or a quit command is read.
+func (e *explorer) run(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(e.out, e.prompt())
+		if !scanner.Scan() {
+			fmt.Fprintln(e.out)
+			return
+		}
+		if !e.execute(scanner.Text()) {
+			return
+		}
+	}
+}
+
+// Runs commands typed at a terminal, with tab completion. The terminal is
+// put in character mode with stty while the session runs.
+func (e *explorer) runInTerminal(terminal *os.File) {
+	stty := func(args ...string) error {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = terminal
+		return cmd.Run()
+	}
+	if stty("-icanon", "-echo", "min", "1") != nil {
+		e.run(terminal)
+		return
+	}
+	defer stty("icanon", "echo")
+	reader := bufio.NewReader(terminal)
+	for {
+		line, ok := e.readLine(reader)
+		if !ok || !e.execute(line) {
+			return
+		}
+	}
+}
+
+// Reads a line from a terminal in character mode, completing words when tab is pressed.
+func (e *explorer) readLine(reader *bufio.Reader) (string, bool) {
+	line := ""
+	fmt.Fprint(e.out, e.prompt())
+	for {
+		c, err := reader.ReadByte()
+		if err != nil {
+			fmt.Fprintln(e.out)
+			return "", false
+		}
+		switch c {
+		case '\r', '\n':
+			fmt.Fprintln(e.out)
+			return line, true
+		case 4: // control-D
+			if line == "" {
+				fmt.Fprintln(e.out)
+				return "", false
+			}
+		case 3: // control-C
+			fmt.Fprintln(e.out)
+			line = ""
+			fmt.Fprint(e.out, e.prompt())
+		case 127, 8: // backspace
+			if line != "" {
+				line = line[:len(line)-1]
+				fmt.Fprint(e.out, "\b \b")
+			}
+		case '\t':
+			completions := e.complete(line)
+			if len(completions) == 1 {
+				fmt.Fprint(e.out, completions[0][len(line):])
+				line = completions[0]
+			} else if len(completions) > 1 {
+				common := commonPrefix(completions)
+				if len(common) > len(line) {
+					fmt.Fprint(e.out, common[len(line):])
+					line = common
+				} else {
+					fmt.Fprintln(e.out)
+					for _, completion := range completions {
+						fmt.Fprintln(e.out, "  "+strings.TrimPrefix(completion, completionBase(line)))
+					}
+					fmt.Fprint(e.out, e.prompt()+line)
+				}
+			}
+		case 27: // escape sequences, such as arrow keys, are ignored
+			if next, err := reader.ReadByte(); err == nil && next == '[' {
+				reader.ReadByte()
+			}
+		default:
+			if c >= 32 {
+				line += string(c)
+				e.out.Write([]byte{c})
+			}
+		}
+	}
+}
+
+// Returns the text of a line that precedes the word being completed.
+func completionBase(line string) string {
+	if i := strings.Index(line, " "); i >= 0 {
+		return line[:i+1]
+	}
+	return ""
+}
+
+// Returns the longest prefix shared by a list of strings.
+func commonPrefix(list []string) string {
+	prefix := list[0]
+	for _, s := range list[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// Run the explorer with the specified command-line arguments.
+func explore(args []string) {
+	if len(args) != 1 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprint(os.Stderr, exploreUsage)
+		os.Exit(-1)
+	}
+	document, err := queryDocument(args[0], false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Errors reading %s\n%+v\n", args[0], err)
+		os.Exit(-1)
+	}
+	e := newExplorer(document, os.Stdout)
+	fmt.Fprintf(e.out, "Exploring %s. Type help for a list of commands.\n", args[0])
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		e.runInTerminal(os.Stdin)
+	} else {
+		e.run(os.Stdin)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestExplorer(t *testing.T) (*explorer, *bytes.Buffer) {
+	document, err := queryDocument("examples/v3.0/yaml/petstore.yaml", false)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	out := &bytes.Buffer{}
+	return newExplorer(document, out), out
+}
+
+func TestExploreCommands(t *testing.T) {
+	e, out := newTestExplorer(t)
+	e.run(strings.NewReader("cd paths\ncd /pets/get/responses/200\npwd\nquit\npwd\n"))
+	if !strings.Contains(out.String(), "#/paths/~1pets/get/responses/200\n") {
+		t.Errorf("unexpected output %s", out.String())
+	}
+	if strings.Count(out.String(), "> ") != 4 {
+		t.Errorf("commands were run after quit: %s", out.String())
+	}
+
+	e.pointer = "/components/schemas/Pet"
+	out.Reset()
+	e.execute("refs")
+	if out.String() != "#/components/schemas/Pets/items\n" {
+		t.Errorf("unexpected refs %s", out.String())
+	}
+
+	e.pointer = "/components/schemas/Pets/items"
+	e.execute("ref")
+	if e.pointer != "/components/schemas/Pet" {
+		t.Errorf("ref moved to %s", e.pointer)
+	}
+
+	out.Reset()
+	e.execute("show json required")
+	if out.String() != "[\n  \"id\",\n  \"name\"\n]\n" {
+		t.Errorf("unexpected json %s", out.String())
+	}
+
+	out.Reset()
+	e.execute("cd missing")
+	if out.String() != "missing not found\n" || e.pointer != "/components/schemas/Pet" {
+		t.Errorf("unexpected result of cd %s %s", out.String(), e.pointer)
+	}
+}
+
+func TestExploreCompletion(t *testing.T) {
+	e, _ := newTestExplorer(t)
+	for _, test := range []struct {
+		pointer     string
+		line        string
+		completions []string
+	}{
+		{"", "re", []string{"ref ", "refs "}},
+		{"", "cd pa", []string{"cd paths"}},
+		{"/paths", "cd /pets", []string{"cd /pets", "cd /pets/{petId}"}},
+		{"/components/schemas/Pet", "show json ", []string{"show json required", "show json properties"}},
+		{"", "pwd ", nil},
+	} {
+		e.pointer = test.pointer
+		if completions := e.complete(test.line); !reflect.DeepEqual(completions, test.completions) {
+			t.Errorf("completions of %q: %v", test.line, completions)
+		}
+	}
+	if prefix := commonPrefix([]string{"cd /pets", "cd /pets/{petId}"}); prefix != "cd /pets" {
+		t.Errorf("unexpected common prefix %s", prefix)
+	}
+}
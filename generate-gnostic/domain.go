@@ -33,6 +33,9 @@ type Domain struct {
 	ObjectTypeRequests map[string]*TypeRequest // anonymous types implied by type instantiation
 	MapTypeRequests    map[string]string       // "NamedObject" types that will be used to implement ordered maps
 	Version            string                  // OpenAPI Version ("v2" or "v3")
+	FieldNumbers       FieldNumbers            // field numbers assigned to the fields of generated messages
+	WrapperTypes       bool                    // if true, optional numeric and boolean fields are held in wrapper messages
+	MapFields          bool                    // if true, properties that hold named values are generated as map fields
 }
 
 func NewDomain(schema *jsonschema.Schema, version string) *Domain {
@@ -41,6 +44,7 @@ func NewDomain(schema *jsonschema.Schema, version string) *Domain {
 	cc.PatternNames = make(map[string]string, 0)
 	cc.ObjectTypeRequests = make(map[string]*TypeRequest, 0)
 	cc.MapTypeRequests = make(map[string]string, 0)
+	cc.FieldNumbers = make(FieldNumbers, 0)
 	cc.Schema = schema
 	cc.Version = version
 	return cc
@@ -249,12 +253,16 @@ func (domain *Domain) buildTypeRequirements(typeModel *TypeModel, schema *jsonsc
 func (domain *Domain) buildPatternPropertyAccessors(typeModel *TypeModel, schema *jsonschema.Schema) {
 	if schema.PatternProperties != nil {
 		typeModel.OpenPatterns = make([]string, 0)
-		for _, pair := range *(schema.PatternProperties) {
+		for i, pair := range *(schema.PatternProperties) {
 			propertyPattern := pair.Name
 			propertySchema := pair.Value
 			typeModel.OpenPatterns = append(typeModel.OpenPatterns, propertyPattern)
 			typeName := "Any"
-			propertyName := domain.PatternNames[propertyPattern]
+			propertyName, ok := domain.PatternNames[propertyPattern]
+			if !ok {
+				// patterns without a configured name get a name based on their position
+				propertyName = fmt.Sprintf("patternProperty%d", i+1)
+			}
 			if propertySchema.Ref != nil {
 				typeName = domain.typeNameForReference(*propertySchema.Ref)
 			}
@@ -507,8 +515,8 @@ func (domain *Domain) buildTypeForDefinitionObject(
 }
 
 func (domain *Domain) Build() (err error) {
-	if (domain.Schema == nil) || (domain.Schema.Definitions == nil) {
-		return errors.New("missing definitions section")
+	if domain.Schema == nil {
+		return errors.New("missing schema")
 	}
 	// create a type for the top-level schema
 	typeName := domain.Prefix + "Document"
@@ -0,0 +1,133 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/googleapis/gnostic/printer"
+	"gopkg.in/yaml.v3"
+)
+
+// FieldNumbers records the field numbers that have been assigned to the
+// fields of each generated message, keyed by message name and then by
+// field name. Numbers are never reassigned, so models that are generated
+// from later versions of a schema remain wire-compatible with earlier ones.
+type FieldNumbers map[string]map[string]int
+
+// Reads field number assignments from a YAML file.
+// A missing file is not an error; it produces an empty set of assignments.
+func NewFieldNumbersFromFile(filename string) (FieldNumbers, error) {
+	fieldNumbers := make(FieldNumbers, 0)
+	bytes, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return fieldNumbers, nil
+	} else if err != nil {
+		return nil, err
+	}
+	err = yaml.Unmarshal(bytes, &fieldNumbers)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("%s: %s", filename, err.Error()))
+	}
+	for messageName, fields := range fieldNumbers {
+		used := make(map[int]string, 0)
+		for fieldName, number := range fields {
+			if number <= 0 {
+				return nil, errors.New(fmt.Sprintf("%s: %s.%s has invalid field number %d",
+					filename, messageName, fieldName, number))
+			}
+			if other, ok := used[number]; ok {
+				return nil, errors.New(fmt.Sprintf("%s: %s.%s and %s.%s have the same field number %d",
+					filename, messageName, other, messageName, fieldName, number))
+			}
+			used[number] = fieldName
+		}
+	}
+	return fieldNumbers, nil
+}
+
+// Returns the number of a field, assigning the next unused number
+// if the field has not been numbered before.
+func (fieldNumbers FieldNumbers) numberForField(messageName string, fieldName string) int {
+	fields := fieldNumbers[messageName]
+	if fields == nil {
+		fields = make(map[string]int, 0)
+		fieldNumbers[messageName] = fields
+	}
+	if number, ok := fields[fieldName]; ok {
+		return number
+	}
+	number := 1
+	for _, n := range fields {
+		if n >= number {
+			number = n + 1
+		}
+	}
+	fields[fieldName] = number
+	return number
+}
+
+// Returns the sorted numbers of fields that were assigned previously
+// but are not among the current fields of a message.
+func (fieldNumbers FieldNumbers) reservedNumbers(messageName string, fieldNames []string) []int {
+	current := make(map[string]bool, 0)
+	for _, fieldName := range fieldNames {
+		current[fieldName] = true
+	}
+	reserved := make([]int, 0)
+	for fieldName, number := range fieldNumbers[messageName] {
+		if !current[fieldName] {
+			reserved = append(reserved, number)
+		}
+	}
+	sort.Ints(reserved)
+	return reserved
+}
+
+// Writes field number assignments to a YAML file.
+// Messages are sorted by name and fields are sorted by number so that
+// changes to the file are easy to review.
+func (fieldNumbers FieldNumbers) WriteToFile(filename string) error {
+	code := &printer.Code{}
+	code.Print("# Field numbers assigned to the messages of a generated model.")
+	code.Print("# THIS FILE IS AUTOMATICALLY UPDATED; entries must not be changed or removed.")
+	messageNames := make([]string, 0)
+	for messageName := range fieldNumbers {
+		messageNames = append(messageNames, messageName)
+	}
+	sort.Strings(messageNames)
+	for _, messageName := range messageNames {
+		fields := fieldNumbers[messageName]
+		if len(fields) == 0 {
+			continue
+		}
+		fieldNames := make([]string, 0)
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Slice(fieldNames, func(i, j int) bool {
+			return fields[fieldNames[i]] < fields[fieldNames[j]]
+		})
+		code.Print("%s:", messageName)
+		for _, fieldName := range fieldNames {
+			code.Print("  %s: %d", fieldName, fields[fieldName])
+		}
+	}
+	return ioutil.WriteFile(filename, []byte(code.String()), 0644)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFieldNumbers(t *testing.T) {
+	fieldNumbers := FieldNumbers{"Pet": {"name": 1, "species": 2}}
+	for _, test := range []struct {
+		fieldName string
+		expected  int
+	}{
+		{"name", 1},
+		{"age", 3},
+		{"species", 2},
+		{"weight", 4},
+		{"age", 3},
+	} {
+		if number := fieldNumbers.numberForField("Pet", test.fieldName); number != test.expected {
+			t.Errorf("Expected %s to be number %d, got %d", test.fieldName, test.expected, number)
+		}
+	}
+	if number := fieldNumbers.numberForField("Owner", "name"); number != 1 {
+		t.Errorf("Expected the first field of a new message to be number 1, got %d", number)
+	}
+	if reserved := fieldNumbers.reservedNumbers("Pet", []string{"name", "weight"}); !reflect.DeepEqual(reserved, []int{2, 3}) {
+		t.Errorf("Unexpected reserved numbers: %v", reserved)
+	}
+}
+
+func TestFieldNumbersFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "field-numbers")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, "numbers.yaml")
+	// a missing file has no assignments
+	fieldNumbers, err := NewFieldNumbersFromFile(filename)
+	if err != nil || len(fieldNumbers) != 0 {
+		t.Fatalf("Unexpected result: %v, %v", fieldNumbers, err)
+	}
+	fieldNumbers = FieldNumbers{"Pet": {"weight": 3, "name": 1}, "Owner": {"name": 1}}
+	if err := fieldNumbers.WriteToFile(filename); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if !strings.HasSuffix(string(bytes), "Owner:\n  name: 1\nPet:\n  name: 1\n  weight: 3\n") {
+		t.Errorf("Unexpected file:\n%s", string(bytes))
+	}
+	read, err := NewFieldNumbersFromFile(filename)
+	if err != nil || !reflect.DeepEqual(read, fieldNumbers) {
+		t.Errorf("Unexpected result: %v, %v", read, err)
+	}
+	for text, message := range map[string]string{
+		"Pet: {name: 1, age: 1}": "have the same field number 1",
+		"Pet: {name: 0}":         "has invalid field number 0",
+	} {
+		if err := ioutil.WriteFile(filename, []byte(text), 0644); err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		if _, err := NewFieldNumbersFromFile(filename); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected an error containing %q, got %v", message, err)
+		}
+	}
+}
+
+func TestGenerateModelWithFieldNumbers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "field-numbers")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	defer os.RemoveAll(dir)
+	// Pet had a species field that was removed from the schema
+	filename := path.Join(dir, "numbers.yaml")
+	if err := ioutil.WriteFile(filename, []byte("Pet: {name: 1, species: 2, age: 3}\n"), 0644); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	proto, _ := generateTestModel(t, &ModelConfig{Name: "PetStore", FieldNumbers: filename})
+	expected := `message Pet {
+  string name = 1;
+  int64 age = 3;
+  double weight = 4;
+  bool vaccinated = 5;
+  reserved 2;
+}`
+	if !strings.Contains(proto, expected) {
+		t.Errorf("Expected generated message:\n%s\nin:\n%s", expected, proto)
+	}
+	fieldNumbers, err := NewFieldNumbersFromFile(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if pet := fieldNumbers["Pet"]; pet["species"] != 2 || pet["weight"] != 4 || pet["vaccinated"] != 5 {
+		t.Errorf("Unexpected field numbers: %v", pet)
+	}
+}
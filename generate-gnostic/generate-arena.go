@@ -0,0 +1,96 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Returns the name of the arena fields that hold the messages of a type.
+func arenaFieldName(typeName string) string {
+	return "blocksFor" + typeName
+}
+
+// Returns an expression that allocates a message in a generated constructor.
+// Messages are allocated from the compilation's arena if it has one.
+func arenaAllocation(typeName string) string {
+	return "arenaForContext(context).new" + typeName + "()"
+}
+
+// Generates the Arena type, which allocates the messages of models in blocks.
+func (domain *Domain) generateArena(code *printer.Code) {
+	typeNames := domain.sortedTypeNames()
+
+	code.Print("// Arena allocates the messages of models in blocks. Compilations that")
+	code.Print("// use an arena make far fewer allocations, and when the models that they")
+	code.Print("// built are no longer needed, Reset releases all of their messages at once")
+	code.Print("// so that the memory can be reused by later compilations. To compile with")
+	code.Print("// an arena, set it as the Allocator of the compilation's context.")
+	code.Print("// Models must not be used after their arena is reset, and an arena must")
+	code.Print("// not be used by more than one compilation at a time.")
+	code.Print("type Arena struct {")
+	code.Print("  blockSize int")
+	for _, typeName := range typeNames {
+		code.Print("  %s [][]%s", arenaFieldName(typeName), typeName)
+		code.Print("  usedFor%s int", typeName)
+	}
+	code.Print("}\n")
+
+	code.Print("// NewArena creates an Arena that allocates messages in blocks of the specified")
+	code.Print("// size. If the size is not positive, a default size is used.")
+	code.Print("func NewArena(blockSize int) *Arena {")
+	code.Print("  if blockSize < 1 {")
+	code.Print("    blockSize = 64")
+	code.Print("  }")
+	code.Print("  return &Arena{blockSize: blockSize}")
+	code.Print("}\n")
+
+	code.Print("// Returns the arena of a compilation, or nil if messages are allocated individually.")
+	code.Print("func arenaForContext(context *compiler.Context) *Arena {")
+	code.Print("  if context != nil {")
+	code.Print("    if arena, ok := context.Allocator.(*Arena); ok {")
+	code.Print("      return arena")
+	code.Print("    }")
+	code.Print("  }")
+	code.Print("  return nil")
+	code.Print("}\n")
+
+	code.Print("// Reset releases all of the messages allocated by an arena.")
+	code.Print("// They are cleared so that they no longer refer to other values.")
+	code.Print("func (a *Arena) Reset() {")
+	for _, typeName := range typeNames {
+		fieldName := arenaFieldName(typeName)
+		code.Print("  for i := 0; i < a.usedFor%s; i++ {", typeName)
+		code.Print("    a.%s[i/a.blockSize][i%%a.blockSize] = %s{}", fieldName, typeName)
+		code.Print("  }")
+		code.Print("  a.usedFor%s = 0", typeName)
+	}
+	code.Print("}\n")
+
+	for _, typeName := range typeNames {
+		fieldName := arenaFieldName(typeName)
+		code.Print("func (a *Arena) new%s() *%s {", typeName, typeName)
+		code.Print("  if a == nil {")
+		code.Print("    return &%s{}", typeName)
+		code.Print("  }")
+		code.Print("  block, i := a.usedFor%s/a.blockSize, a.usedFor%s%%a.blockSize", typeName, typeName)
+		code.Print("  if block == len(a.%s) {", fieldName)
+		code.Print("    a.%s = append(a.%s, make([]%s, a.blockSize))", fieldName, fieldName, typeName)
+		code.Print("  }")
+		code.Print("  a.usedFor%s++", typeName)
+		code.Print("  return &a.%s[block][i]", fieldName)
+		code.Print("}\n")
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Returns the Go type used for a scalar property, or "" if the property is not a scalar.
+func goTypeForScalarProperty(propertyType string) string {
+	switch propertyType {
+	case "string":
+		return "string"
+	case "bool":
+		return "bool"
+	case "int", "int64":
+		return "int64"
+	case "float":
+		return "float64"
+	}
+	return ""
+}
+
+// Builder methods make it easier to construct models in code.
+// Oneof wrappers get one constructor for each of their possible values;
+// other messages get Set and Add methods that return the receiver so
+// that calls can be chained.
+func (domain *Domain) generateBuildersForType(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	if typeModel.IsPair || typeModel.IsStringArray || typeModel.IsItemArray || typeModel.IsBlob ||
+		typeName == "StringArray" {
+		return
+	}
+	if typeName == "Primitive" || typeName == "SpecificationExtension" {
+		for _, oneof := range []struct{ name, goType string }{
+			{"Boolean", "bool"},
+			{"String_", "string"},
+			{"Integer", "int64"},
+			{"Number", "float64"},
+		} {
+			code.Print("// New%sWith%s creates a %s that holds a %s.", typeName, oneof.name, typeName, oneof.goType)
+			code.Print("func New%sWith%s(value %s) *%s {", typeName, oneof.name, oneof.goType, typeName)
+			code.Print("  return &%s{Oneof: &%s_%s{%s: value}}", typeName, typeName, oneof.name, oneof.name)
+			code.Print("}\n")
+		}
+		return
+	}
+	if typeModel.OneOfWrapper {
+		for _, propertyModel := range typeModel.Properties {
+			if propertyModel.Type == "bool" {
+				code.Print("// New%sWithBoolean creates a %s that holds a bool.", typeName, typeName)
+				code.Print("func New%sWithBoolean(value bool) *%s {", typeName, typeName)
+				code.Print("  return &%s{Oneof: &%s_Boolean{Boolean: value}}", typeName, typeName)
+				code.Print("}\n")
+			} else if _, typeFound := domain.TypeModels[propertyModel.Type]; typeFound {
+				propertyType := propertyModel.Type
+				code.Print("// New%sWith%s creates a %s that holds a %s.", typeName, propertyType, typeName, propertyType)
+				code.Print("func New%sWith%s(value *%s) *%s {", typeName, propertyType, propertyType, typeName)
+				code.Print("  return &%s{Oneof: &%s_%s{%s: value}}", typeName, typeName, propertyType, propertyType)
+				code.Print("}\n")
+			}
+		}
+		return
+	}
+	for _, propertyModel := range typeModel.Properties {
+		propertyName := propertyModel.Name
+		if propertyName == "value" {
+			continue
+		}
+		fieldName := propertyModel.FieldName()
+		propertyType := propertyModel.Type
+		propertyTypeModel, typeFound := domain.TypeModels[propertyType]
+		if typeFound && !propertyTypeModel.IsPair {
+			if propertyModel.Repeated {
+				code.Print("// Add%s appends values to the %s of a %s.", fieldName, propertyName, typeName)
+				code.Print("func (m *%s) Add%s(values ...*%s) *%s {", typeName, fieldName, propertyType, typeName)
+				code.Print("  m.%s = append(m.%s, values...)", fieldName, fieldName)
+			} else {
+				code.Print("// Set%s sets the %s of a %s.", fieldName, propertyName, typeName)
+				code.Print("func (m *%s) Set%s(value *%s) *%s {", typeName, fieldName, propertyType, typeName)
+				code.Print("  m.%s = value", fieldName)
+			}
+		} else if goType := goTypeForScalarProperty(propertyType); goType != "" {
+			if propertyModel.Repeated {
+				code.Print("// Add%s appends values to the %s of a %s.", fieldName, propertyName, typeName)
+				code.Print("func (m *%s) Add%s(values ...%s) *%s {", typeName, fieldName, goType, typeName)
+				code.Print("  m.%s = append(m.%s, values...)", fieldName, fieldName)
+			} else {
+				code.Print("// Set%s sets the %s of a %s.", fieldName, propertyName, typeName)
+				code.Print("func (m *%s) Set%s(value %s) *%s {", typeName, fieldName, goType, typeName)
+				if wrapperType := domain.wrapperTypeForProperty(typeModel, propertyModel); wrapperType != "" {
+					code.Print("  m.%s = &wrappers.%s{Value: value}", fieldName, wrapperType)
+				} else {
+					code.Print("  m.%s = value", fieldName)
+				}
+			}
+		} else if mapTypeName := propertyModel.MapType; mapTypeName != "" {
+			valueType := "*" + mapTypeName
+			pairTypeName := "Named" + mapTypeName
+			if mapTypeName == "string" {
+				valueType = "string"
+				pairTypeName = "NamedString"
+			}
+			code.Print("// Add%s adds a named value to the %s of a %s.", fieldName, propertyName, typeName)
+			code.Print("func (m *%s) Add%s(name string, value %s) *%s {", typeName, fieldName, valueType, typeName)
+			if domain.usesMapField(propertyModel) {
+				code.Print("  if m.%s == nil {", fieldName)
+				code.Print("    m.%s = make(%s, 0)", fieldName, mapFieldGoType(propertyModel))
+				code.Print("  }")
+				code.Print("  m.%s[name] = value", fieldName)
+			} else {
+				code.Print("  m.%s = append(m.%s, &%s{Name: name, Value: value})", fieldName, fieldName, pairTypeName)
+			}
+			code.Print("  return m")
+			code.Print("}\n")
+			domain.generateNamedValueEditors(code, typeName, propertyModel, valueType, pairTypeName)
+			continue
+		} else {
+			continue
+		}
+		code.Print("  return m")
+		code.Print("}\n")
+	}
+}
+
+// Generates methods that replace and remove the named values of a map property.
+// Unlike Add, they keep names unique, so values can be edited in models that
+// were read from documents without writing duplicate keys.
+func (domain *Domain) generateNamedValueEditors(code *printer.Code, typeName string, propertyModel *TypeProperty, valueType string, pairTypeName string) {
+	fieldName := propertyModel.FieldName()
+	propertyName := propertyModel.Name
+	code.Print("// Set%s replaces the value with a name in the %s of a %s,", fieldName, propertyName, typeName)
+	code.Print("// or adds it if there is none.")
+	code.Print("func (m *%s) Set%s(name string, value %s) *%s {", typeName, fieldName, valueType, typeName)
+	if domain.usesMapField(propertyModel) {
+		code.Print("  return m.Add%s(name, value)", fieldName)
+	} else {
+		code.Print("  for _, pair := range m.%s {", fieldName)
+		code.Print("    if pair.Name == name {")
+		code.Print("      pair.Value = value")
+		code.Print("      return m")
+		code.Print("    }")
+		code.Print("  }")
+		code.Print("  m.%s = append(m.%s, &%s{Name: name, Value: value})", fieldName, fieldName, pairTypeName)
+		code.Print("  return m")
+	}
+	code.Print("}\n")
+	code.Print("// Remove%s removes the value with a name from the %s of a %s.", fieldName, propertyName, typeName)
+	code.Print("func (m *%s) Remove%s(name string) *%s {", typeName, fieldName, typeName)
+	if domain.usesMapField(propertyModel) {
+		code.Print("  delete(m.%s, name)", fieldName)
+	} else {
+		code.Print("  for i, pair := range m.%s {", fieldName)
+		code.Print("    if pair.Name == name {")
+		code.Print("      m.%s = append(m.%s[:i], m.%s[i+1:]...)", fieldName, fieldName, fieldName)
+		code.Print("      break")
+		code.Print("    }")
+		code.Print("  }")
+	}
+	code.Print("  return m")
+	code.Print("}\n")
+}
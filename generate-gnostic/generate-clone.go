@@ -0,0 +1,93 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Generates Clone() methods, which make deep copies of messages.
+// Copies share no messages, slices, or maps with their originals,
+// so tools that transform models can change a copy of a subtree
+// without changing the model that it came from.
+func (domain *Domain) generateCloneMethodsForType(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	code.Print("// Clone returns a deep copy of a %s.", typeName)
+	code.Print("func (m *%s) Clone() *%s {", typeName, typeName)
+	code.Print("if m == nil {")
+	code.Print("  return nil")
+	code.Print("}")
+	code.Print("c := *m")
+	if typeName == "Primitive" || typeName == "SpecificationExtension" {
+		code.Print("switch x := m.Oneof.(type) {")
+		for _, name := range []string{"Boolean", "String_", "Integer", "Number"} {
+			code.Print("case *%s_%s:", typeName, name)
+			code.Print("  c.Oneof = &%s_%s{%s: x.%s}", typeName, name, name, name)
+		}
+		code.Print("}")
+	} else if typeModel.OneOfWrapper {
+		code.Print("switch x := m.Oneof.(type) {")
+		for _, propertyModel := range typeModel.Properties {
+			if propertyModel.Type == "bool" {
+				code.Print("case *%s_Boolean:", typeName)
+				code.Print("  c.Oneof = &%s_Boolean{Boolean: x.Boolean}", typeName)
+			} else if _, typeFound := domain.TypeModels[propertyModel.Type]; typeFound {
+				propertyType := propertyModel.Type
+				code.Print("case *%s_%s:", typeName, propertyType)
+				code.Print("  c.Oneof = &%s_%s{%s: x.%s.Clone()}", typeName, propertyType, propertyType, propertyType)
+			}
+		}
+		code.Print("}")
+	} else {
+		for _, propertyModel := range typeModel.Properties {
+			fieldName := propertyModel.FieldName()
+			_, typeFound := domain.TypeModels[propertyModel.Type]
+			if domain.usesMapField(propertyModel) {
+				code.Print("if m.%s != nil {", fieldName)
+				code.Print("  c.%s = make(%s, len(m.%s))", fieldName, mapFieldGoType(propertyModel), fieldName)
+				code.Print("  for k, v := range m.%s {", fieldName)
+				if propertyModel.MapType == "string" {
+					code.Print("    c.%s[k] = v", fieldName)
+				} else {
+					code.Print("    c.%s[k] = v.Clone()", fieldName)
+				}
+				code.Print("  }")
+				code.Print("}")
+			} else if typeFound && propertyModel.Repeated {
+				code.Print("if m.%s != nil {", fieldName)
+				code.Print("  c.%s = make([]*%s, len(m.%s))", fieldName, propertyModel.Type, fieldName)
+				code.Print("  for i, item := range m.%s {", fieldName)
+				code.Print("    c.%s[i] = item.Clone()", fieldName)
+				code.Print("  }")
+				code.Print("}")
+			} else if typeFound {
+				code.Print("c.%s = m.%s.Clone()", fieldName, fieldName)
+			} else if propertyModel.Type == "google.protobuf.Any" {
+				code.Print("c.%s = compiler.CloneAny(m.%s)", fieldName, fieldName)
+			} else if wrapperType := domain.wrapperTypeForProperty(typeModel, propertyModel); wrapperType != "" {
+				code.Print("if m.%s != nil {", fieldName)
+				code.Print("  c.%s = &wrappers.%s{Value: m.%s.Value}", fieldName, wrapperType, fieldName)
+				code.Print("}")
+			} else if goType := goTypeForScalarProperty(propertyModel.Type); goType != "" && propertyModel.Repeated {
+				code.Print("if m.%s != nil {", fieldName)
+				code.Print("  c.%s = make([]%s, len(m.%s))", fieldName, goType, fieldName)
+				code.Print("  copy(c.%s, m.%s)", fieldName, fieldName)
+				code.Print("}")
+			}
+		}
+	}
+	code.Print("return &c")
+	code.Print("}\n")
+}
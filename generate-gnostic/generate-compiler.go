@@ -41,8 +41,14 @@ func (domain *Domain) GenerateCompiler(packageName string, license string, impor
 	code.Print("  return \"%s\"", packageName)
 	code.Print("}\n")
 
+	// generate variables for the patterns that map keys are matched against
+	domain.generateKeyPatterns(code)
+
 	typeNames := domain.sortedTypeNames()
 
+	// generate an Arena type that allocates messages in blocks
+	domain.generateArena(code)
+
 	// generate NewX() constructor functions for each type
 	for _, typeName := range typeNames {
 		domain.generateConstructorForType(code, typeName)
@@ -58,11 +64,78 @@ func (domain *Domain) GenerateCompiler(packageName string, license string, impor
 		domain.generateToRawInfoMethodForType(code, typeName)
 	}
 
+	// generate MarshalJSON() and ToYAML() methods for each type
+	for _, typeName := range typeNames {
+		domain.generateMarshalingMethodsForType(code, typeName)
+	}
+
+	// generate builder methods for each type
+	for _, typeName := range typeNames {
+		domain.generateBuildersForType(code, typeName)
+	}
+
+	// generate lookup methods for each type
+	for _, typeName := range typeNames {
+		domain.generateLookupMethodsForType(code, typeName)
+	}
+
+	// generate a Visitor type and Walk() methods for each type
+	domain.generateVisitor(code)
+	for _, typeName := range typeNames {
+		domain.generateWalkMethodsForType(code, typeName)
+	}
+
+	// generate resolve() methods for each type and ResolveReference() for documents
+	for _, typeName := range typeNames {
+		domain.generateResolveMethodsForType(code, typeName)
+	}
+
+	// generate a Rewriter type and Rewrite() methods for each type
+	domain.generateRewriter(code)
+	for _, typeName := range typeNames {
+		domain.generateRewriteMethodsForType(code, typeName)
+	}
+
+	// generate Clone() methods for each type
+	for _, typeName := range typeNames {
+		domain.generateCloneMethodsForType(code, typeName)
+	}
+
+	// generate Equal() and Diff() methods for each type
+	for _, typeName := range typeNames {
+		domain.generateDiffMethodsForType(code, typeName)
+	}
+
 	return code.String()
 }
 
+// Returns true if the constructor for a type looks up keys in the map that
+// it compiles, which it does for required keys and for fields that are not
+// oneofs or maps. These lookups use an index of the map.
+func (domain *Domain) usesMapIndex(typeModel *TypeModel) bool {
+	if len(typeModel.Required) > 0 {
+		return true
+	}
+	for _, propertyModel := range typeModel.Properties {
+		propertyType := propertyModel.Type
+		if propertyType == "int" {
+			propertyType = "int64"
+		}
+		if propertyTypeModel, typeFound := domain.TypeModels[propertyType]; typeFound && !propertyTypeModel.IsPair {
+			if propertyModel.Repeated || !typeModel.OneOfWrapper {
+				return true
+			}
+		} else if propertyType == "string" || propertyType == "float" || propertyType == "int64" {
+			return true
+		} else if propertyType == "bool" && !typeModel.OneOfWrapper {
+			return true
+		}
+	}
+	return false
+}
+
 func (domain *Domain) generateConstructorForType(code *printer.Code, typeName string) {
-	code.Print("func New%s(in interface{}, context *compiler.Context) (*%s, error) {", typeName, typeName)
+	code.Print("func New%s(in *yaml.Node, context *compiler.Context) (*%s, error) {", typeName, typeName)
 	code.Print("errors := make([]error, 0)")
 
 	typeModel := domain.TypeModels[typeName]
@@ -70,35 +143,34 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 
 	if typeModel.IsStringArray {
 		code.Print("x := &TypeItem{}")
-		code.Print("switch in := in.(type) {")
-		code.Print("case string:")
+		code.Print("if value, ok := compiler.StringForScalarNode(in); ok {")
 		code.Print("  x.Value = make([]string, 0)")
-		code.Print("  x.Value = append(x.Value, in)")
-		code.Print("case []interface{}:")
+		code.Print("  x.Value = append(x.Value, value)")
+		code.Print("} else if a, ok := compiler.SequenceNodeForNode(in); ok {")
 		code.Print("  x.Value = make([]string, 0)")
-		code.Print("  for _, v := range in {")
-		code.Print("    value, ok := v.(string)")
+		code.Print("  for _, v := range a.Content {")
+		code.Print("    value, ok := compiler.StringForScalarNode(v)")
 		code.Print("    if ok {")
 		code.Print("      x.Value = append(x.Value, value)")
 		code.Print("    } else {")
-		code.Print("      message := fmt.Sprintf(\"has unexpected value for string array element: %%+v (%%T)\", value, value)")
-		code.Print("      errors = append(errors, compiler.NewError(context, message))")
+		code.Print("      message := fmt.Sprintf(\"has unexpected value for string array element: %%s\", compiler.Display(v))")
+		code.Print("      errors = append(errors, compiler.NewErrorForNode(context, v, compiler.ErrorCodeUnexpectedValue, message))")
 		code.Print("    }")
 		code.Print("  }")
-		code.Print("default:")
-		code.Print("  message := fmt.Sprintf(\"has unexpected value for string array: %%+v (%%T)\", in, in)")
-		code.Print("  errors = append(errors, compiler.NewError(context, message))")
+		code.Print("} else {")
+		code.Print("  message := fmt.Sprintf(\"has unexpected value for string array: %%s\", compiler.Display(in))")
+		code.Print("  errors = append(errors, compiler.NewErrorForNode(context, in, compiler.ErrorCodeUnexpectedValue, message))")
 		code.Print("}")
 	} else if typeModel.IsItemArray {
 		if domain.Version == "v2" {
 			code.Print("x := &ItemsItem{}")
 			code.Print("m, ok := compiler.UnpackMap(in)")
 			code.Print("if !ok {")
-			code.Print("  message := fmt.Sprintf(\"has unexpected value for item array: %%+v (%%T)\", in, in)")
-			code.Print("  errors = append(errors, compiler.NewError(context, message))")
+			code.Print("  message := fmt.Sprintf(\"has unexpected value for item array: %%s\", compiler.Display(in))")
+			code.Print("  errors = append(errors, compiler.NewErrorForNode(context, in, compiler.ErrorCodeUnexpectedValue, message))")
 			code.Print("} else {")
 			code.Print("  x.Schema = make([]*Schema, 0)")
-			code.Print("  y, err := NewSchema(m, compiler.NewContext(\"<array>\", context))")
+			code.Print("  y, err := NewSchema(m, compiler.NewWrapperContext(\"<array>\", context))")
 			code.Print("  if err != nil {")
 			code.Print("    return nil, err")
 			code.Print("  }")
@@ -108,11 +180,11 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 			code.Print("x := &ItemsItem{}")
 			code.Print("m, ok := compiler.UnpackMap(in)")
 			code.Print("if !ok {")
-			code.Print("  message := fmt.Sprintf(\"has unexpected value for item array: %%+v (%%T)\", in, in)")
-			code.Print("  errors = append(errors, compiler.NewError(context, message))")
+			code.Print("  message := fmt.Sprintf(\"has unexpected value for item array: %%s\", compiler.Display(in))")
+			code.Print("  errors = append(errors, compiler.NewErrorForNode(context, in, compiler.ErrorCodeUnexpectedValue, message))")
 			code.Print("} else {")
 			code.Print("  x.SchemaOrReference = make([]*SchemaOrReference, 0)")
-			code.Print("  y, err := NewSchemaOrReference(m, compiler.NewContext(\"<array>\", context))")
+			code.Print("  y, err := NewSchemaOrReference(m, compiler.NewWrapperContext(\"<array>\", context))")
 			code.Print("  if err != nil {")
 			code.Print("    return nil, err")
 			code.Print("  }")
@@ -121,74 +193,31 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 		}
 	} else if typeModel.IsBlob {
 		code.Print("x := &Any{}")
-		code.Print("bytes, _ := yaml.Marshal(in)")
+		code.Print("bytes := compiler.Marshal(in)")
 		code.Print("x.Yaml = string(bytes)")
 	} else if typeModel.Name == "StringArray" {
 		code.Print("x := &StringArray{}")
-		code.Print("a, ok := in.([]interface{})")
+		code.Print("a, ok := compiler.SequenceNodeForNode(in)")
 		code.Print("if !ok {")
-		code.Print("  message := fmt.Sprintf(\"has unexpected value for StringArray: %%+v (%%T)\", in, in)")
-		code.Print("  errors = append(errors, compiler.NewError(context, message))")
+		code.Print("  message := fmt.Sprintf(\"has unexpected value for StringArray: %%s\", compiler.Display(in))")
+		code.Print("  errors = append(errors, compiler.NewErrorForNode(context, in, compiler.ErrorCodeUnexpectedValue, message))")
 		code.Print("} else {")
-		code.Print("  x.Value = make([]string, 0)")
-		code.Print("  for _, s := range a {")
-		code.Print("    x.Value = append(x.Value, s.(string))")
-		code.Print("  }")
+		code.Print("  x.Value = compiler.StringArrayForSequenceNode(a)")
 		code.Print("}")
-	} else if typeModel.Name == "Primitive" {
-		code.Print("	x := &Primitive{}")
+	} else if typeModel.Name == "Primitive" || typeModel.Name == "SpecificationExtension" {
+		code.Print("	x := %s", arenaAllocation(typeName))
 		code.Print("	matched := false")
-		code.Print("	switch in := in.(type) {")
-		code.Print("	case bool:")
-		code.Print("		x.Oneof = &Primitive_Boolean{Boolean: in}")
-		code.Print("		matched = true")
-		code.Print("	case string:")
-		code.Print("		x.Oneof = &Primitive_String_{String_: in}")
-		code.Print("		matched = true")
-		code.Print("	case int64:")
-		code.Print("		x.Oneof = &Primitive_Integer{Integer: in}")
-		code.Print("		matched = true")
-		code.Print("	case int32:")
-		code.Print("		x.Oneof = &Primitive_Integer{Integer: int64(in)}")
+		code.Print("	if v, ok := compiler.BoolForScalarNode(in); ok {")
+		code.Print("		x.Oneof = &%s_Boolean{Boolean: v}", typeName)
 		code.Print("		matched = true")
-		code.Print("	case int:")
-		code.Print("		x.Oneof = &Primitive_Integer{Integer: int64(in)}")
+		code.Print("	} else if v, ok := compiler.StringForScalarNode(in); ok {")
+		code.Print("		x.Oneof = &%s_String_{String_: v}", typeName)
 		code.Print("		matched = true")
-		code.Print("	case float64:")
-		code.Print("		x.Oneof = &Primitive_Number{Number: in}")
+		code.Print("	} else if v, ok := compiler.IntForScalarNode(in); ok {")
+		code.Print("		x.Oneof = &%s_Integer{Integer: v}", typeName)
 		code.Print("		matched = true")
-		code.Print("	case float32:")
-		code.Print("		x.Oneof = &Primitive_Number{Number: float64(in)}")
-		code.Print("		matched = true")
-		code.Print("	}")
-		code.Print("	if matched {")
-		code.Print("		// since the oneof matched one of its possibilities, discard any matching errors")
-		code.Print("		errors = make([]error, 0)")
-		code.Print("	}")
-	} else if typeModel.Name == "SpecificationExtension" {
-		code.Print("	x := &SpecificationExtension{}")
-		code.Print("	matched := false")
-		code.Print("	switch in := in.(type) {")
-		code.Print("	case bool:")
-		code.Print("		x.Oneof = &SpecificationExtension_Boolean{Boolean: in}")
-		code.Print("		matched = true")
-		code.Print("	case string:")
-		code.Print("		x.Oneof = &SpecificationExtension_String_{String_: in}")
-		code.Print("		matched = true")
-		code.Print("	case int64:")
-		code.Print("		x.Oneof = &SpecificationExtension_Integer{Integer: in}")
-		code.Print("		matched = true")
-		code.Print("	case int32:")
-		code.Print("		x.Oneof = &SpecificationExtension_Integer{Integer: int64(in)}")
-		code.Print("		matched = true")
-		code.Print("	case int:")
-		code.Print("		x.Oneof = &SpecificationExtension_Integer{Integer: int64(in)}")
-		code.Print("		matched = true")
-		code.Print("	case float64:")
-		code.Print("		x.Oneof = &SpecificationExtension_Number{Number: in}")
-		code.Print("		matched = true")
-		code.Print("	case float32:")
-		code.Print("		x.Oneof = &SpecificationExtension_Number{Number: float64(in)}")
+		code.Print("	} else if v, ok := compiler.FloatForScalarNode(in); ok {")
+		code.Print("		x.Oneof = &%s_Number{Number: v}", typeName)
 		code.Print("		matched = true")
 		code.Print("	}")
 		code.Print("	if matched {")
@@ -198,7 +227,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 	} else {
 		oneOfWrapper := typeModel.OneOfWrapper
 
-		code.Print("x := &%s{}", typeName)
+		code.Print("x := %s", arenaAllocation(typeName))
 
 		if oneOfWrapper {
 			code.Print("matched := false")
@@ -208,9 +237,13 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 		if unpackAtTop {
 			code.Print("m, ok := compiler.UnpackMap(in)")
 			code.Print("if !ok {")
-			code.Print("  message := fmt.Sprintf(\"has unexpected value: %%+v (%%T)\", in, in)")
-			code.Print("  errors = append(errors, compiler.NewError(context, message))")
+			code.Print("  message := fmt.Sprintf(\"has unexpected value: %%s\", compiler.Display(in))")
+			code.Print("  errors = append(errors, compiler.NewErrorForNode(context, in, compiler.ErrorCodeUnexpectedValue, message))")
 			code.Print("} else {")
+			if domain.usesMapIndex(typeModel) {
+				code.Print("index := compiler.NewMapIndex(m)")
+			}
+			code.Print("errors = append(errors, compiler.NonScalarKeyErrors(m, context)...)")
 		}
 		if len(typeModel.Required) > 0 {
 			// verify that map includes all required keys
@@ -225,10 +258,10 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				keyString += "\""
 			}
 			code.Print("requiredKeys := []string{%s}", keyString)
-			code.Print("missingKeys := compiler.MissingKeysInMap(m, requiredKeys)")
+			code.Print("missingKeys := index.MissingKeys(requiredKeys)")
 			code.Print("if len(missingKeys) > 0 {")
 			code.Print("  message := fmt.Sprintf(\"is missing required %%s: %%+v\", compiler.PluralProperties(len(missingKeys)), strings.Join(missingKeys, \", \"))")
-			code.Print("  errors = append(errors, compiler.NewError(context, message))")
+			code.Print("  errors = append(errors, compiler.NewErrorForNode(context, m, compiler.ErrorCodeMissingProperty, message))")
 			code.Print("}")
 		}
 
@@ -256,18 +289,20 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 					if allowedPatternString != "" {
 						allowedPatternString += ","
 					}
-					allowedPatternString += "\""
-					allowedPatternString += pattern
-					allowedPatternString += "\""
+					allowedPatternString += domain.keyPatternVariable(pattern)
 				}
 			}
 			// verify that map includes only allowed keys and patterns
 			code.Print("allowedKeys := []string{%s}", allowedKeyString)
-			code.Print("allowedPatterns := []string{%s}", allowedPatternString)
-			code.Print("invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)")
-			code.Print("if len(invalidKeys) > 0 {")
+			if allowedPatternString != "" {
+				code.Print("allowedPatterns := []*regexp.Regexp{%s}", allowedPatternString)
+				code.Print("invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, allowedPatterns)")
+			} else {
+				code.Print("invalidKeys := compiler.InvalidKeysInMap(m, allowedKeys, nil)")
+			}
+			code.Print("if len(invalidKeys) > 0 && !compiler.OptionsForContext(context).Lenient {")
 			code.Print("  message := fmt.Sprintf(\"has invalid %%s: %%+v\", compiler.PluralProperties(len(invalidKeys)), strings.Join(invalidKeys, \", \"))")
-			code.Print("  errors = append(errors, compiler.NewError(context, message))")
+			code.Print("  errors = append(errors, compiler.NewErrorForNode(context, compiler.NodeForInvalidKeys(m, invalidKeys), compiler.ErrorCodeInvalidProperty, message))")
 			code.Print("}")
 		}
 
@@ -288,7 +323,11 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 			}
 			displayName = camelCaseToSnakeCase(displayName)
 
-			var line = fmt.Sprintf("%s %s = %d;", propertyType, displayName, fieldNumber)
+			var fieldType = propertyType
+			if wrapperType := domain.wrapperTypeForProperty(typeModel, propertyModel); wrapperType != "" {
+				fieldType = "google.protobuf." + wrapperType
+			}
+			var line = fmt.Sprintf("%s %s = %d;", fieldType, displayName, fieldNumber)
 			if propertyModel.Repeated {
 				line = "repeated " + line
 			}
@@ -302,14 +341,14 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 			typeModel, typeFound := domain.TypeModels[propertyType]
 			if typeFound && !typeModel.IsPair {
 				if propertyModel.Repeated {
-					code.Print("v%d := compiler.MapValueForKey(m, \"%s\")", fieldNumber, propertyName)
+					code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
 					code.Print("if (v%d != nil) {", fieldNumber)
 					code.Print("  // repeated %s", typeModel.Name)
 					code.Print("  x.%s = make([]*%s, 0)", fieldName, typeModel.Name)
-					code.Print("  a, ok := v%d.([]interface{})", fieldNumber)
+					code.Print("  a, ok := compiler.SequenceNodeForNode(v%d)", fieldNumber)
 					code.Print("  if ok {")
-					code.Print("    for _, item := range a {")
-					code.Print("      y, err := New%s(item, compiler.NewContext(\"%s\", context))", typeModel.Name, propertyName)
+					code.Print("    for i, item := range a.Content {")
+					code.Print("      y, err := New%s(item, compiler.NewItemContext(\"%s\", i, context))", typeModel.Name, propertyName)
 					code.Print("      if err != nil {")
 					code.Print("        errors = append(errors, err)")
 					code.Print("      }")
@@ -325,7 +364,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 							code.Print("  if ok {")
 						}
 						code.Print("    // errors might be ok here, they mean we just don't have the right subtype")
-						code.Print("    t, matching_error := New%s(m, compiler.NewContext(\"%s\", context))", typeModel.Name, propertyName)
+						code.Print("    t, matching_error := New%s(m, compiler.NewWrapperContext(\"%s\", context))", typeModel.Name, propertyName)
 						code.Print("    if matching_error == nil {")
 						code.Print("      x.Oneof = &%s_%s{%s: t}", parentTypeName, typeModel.Name, typeModel.Name)
 						code.Print("      matched = true")
@@ -337,7 +376,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 						}
 						code.Print("}")
 					} else {
-						code.Print("v%d := compiler.MapValueForKey(m, \"%s\")", fieldNumber, propertyName)
+						code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
 						code.Print("if (v%d != nil) {", fieldNumber)
 						code.Print("  var err error")
 						code.Print("  x.%s, err = New%s(v%d, compiler.NewContext(\"%s\", context))",
@@ -350,14 +389,14 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				}
 			} else if propertyType == "string" {
 				if propertyModel.Repeated {
-					code.Print("v%d := compiler.MapValueForKey(m, \"%s\")", fieldNumber, propertyName)
+					code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
 					code.Print("if (v%d != nil) {", fieldNumber)
-					code.Print("  v, ok := v%d.([]interface{})", fieldNumber)
+					code.Print("  v, ok := compiler.SequenceNodeForNode(v%d)", fieldNumber)
 					code.Print("  if ok {")
-					code.Print("    x.%s = compiler.ConvertInterfaceArrayToStringArray(v)", fieldName)
+					code.Print("    x.%s = compiler.StringArrayForSequenceNode(v)", fieldName)
 					code.Print("  } else {")
-					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%+v (%%T)\", v%d, v%d)", propertyName, fieldNumber, fieldNumber)
-					code.Print("    errors = append(errors, compiler.NewError(context, message))")
+					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+					code.Print("    errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
 					code.Print("}")
 
 					if propertyModel.StringEnumValues != nil {
@@ -373,19 +412,37 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 						}
 						stringArrayLiteral += "}"
 						code.Print("if ok && !compiler.StringArrayContainsValues(%s, x.%s) {", stringArrayLiteral, fieldName)
-						code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%+v\", v%d)", propertyName, fieldNumber)
-						code.Print("  errors = append(errors, compiler.NewError(context, message))")
+						code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+						code.Print("  errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
 						code.Print("}")
 					}
 
+					code.Print("}")
+				} else if propertyName == "type" && domain.TypeModels[parentTypeName].hasFieldNamed("Nullable") {
+					// types can also be lists like [string, "null"] that make schemas nullable
+					code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
+					code.Print("if (v%d != nil) {", fieldNumber)
+					code.Print("  var nullable bool")
+					code.Print("  x.%s, nullable, ok = compiler.TypeForNode(v%d)", fieldName, fieldNumber)
+					code.Print("  if nullable {")
+					if domain.wrapperTypeForField(domain.TypeModels[parentTypeName], "Nullable") != "" {
+						code.Print("    x.Nullable = &wrappers.BoolValue{Value: true}")
+					} else {
+						code.Print("    x.Nullable = true")
+					}
+					code.Print("  }")
+					code.Print("  if !ok {")
+					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+					code.Print("    errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
+					code.Print("  }")
 					code.Print("}")
 				} else {
-					code.Print("v%d := compiler.MapValueForKey(m, \"%s\")", fieldNumber, propertyName)
+					code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
 					code.Print("if (v%d != nil) {", fieldNumber)
-					code.Print("  x.%s, ok = v%d.(string)", fieldName, fieldNumber)
+					code.Print("  x.%s, ok = compiler.StringForScalarNode(v%d)", fieldName, fieldNumber)
 					code.Print("  if !ok {")
-					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%+v (%%T)\", v%d, v%d)", propertyName, fieldNumber, fieldNumber)
-					code.Print("    errors = append(errors, compiler.NewError(context, message))")
+					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+					code.Print("    errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
 					code.Print("  }")
 
 					if propertyModel.StringEnumValues != nil {
@@ -402,60 +459,67 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 						stringArrayLiteral += "}"
 
 						code.Print("if ok && !compiler.StringArrayContainsValue(%s, x.%s) {", stringArrayLiteral, fieldName)
-						code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%+v (%%T)\", v%d, v%d)", propertyName, fieldNumber, fieldNumber)
-						code.Print("  errors = append(errors, compiler.NewError(context, message))")
+						code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+						code.Print("  errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
 						code.Print("}")
 					}
 					code.Print("}")
 				}
 			} else if propertyType == "float" {
-				code.Print("v%d := compiler.MapValueForKey(m, \"%s\")", fieldNumber, propertyName)
+				code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
 				code.Print("if (v%d != nil) {", fieldNumber)
-				code.Print("  switch v%d := v%d.(type) {", fieldNumber, fieldNumber)
-				code.Print("  case float64:")
-				code.Print("    x.%s = v%d", fieldName, fieldNumber)
-				code.Print("  case float32:")
-				code.Print("    x.%s = float64(v%d)", fieldName, fieldNumber)
-				code.Print("  case uint64:")
-				code.Print("    x.%s = float64(v%d)", fieldName, fieldNumber)
-				code.Print("  case uint32:")
-				code.Print("    x.%s = float64(v%d)", fieldName, fieldNumber)
-				code.Print("  case int64:")
-				code.Print("    x.%s = float64(v%d)", fieldName, fieldNumber)
-				code.Print("  case int32:")
-				code.Print("    x.%s = float64(v%d)", fieldName, fieldNumber)
-				code.Print("  case int:")
-				code.Print("    x.%s = float64(v%d)", fieldName, fieldNumber)
-				code.Print("  default:")
-				code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%+v (%%T)\", v%d, v%d)", propertyName, fieldNumber, fieldNumber)
-				code.Print("    errors = append(errors, compiler.NewError(context, message))")
+				code.Print("  v, ok := compiler.FloatForScalarNode(v%d)", fieldNumber)
+				code.Print("  if ok {")
+				if wrapperType := domain.wrapperTypeForProperty(domain.TypeModels[parentTypeName], propertyModel); wrapperType != "" {
+					code.Print("    x.%s = &wrappers.%s{Value: v}", fieldName, wrapperType)
+				} else {
+					code.Print("    x.%s = v", fieldName)
+				}
+				code.Print("  } else {")
+				code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+				code.Print("    errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
 				code.Print("  }")
 				code.Print("}")
 			} else if propertyType == "int64" {
-				code.Print("v%d := compiler.MapValueForKey(m, \"%s\")", fieldNumber, propertyName)
+				code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
 				code.Print("if (v%d != nil) {", fieldNumber)
-				code.Print("  t, ok := v%d.(int)", fieldNumber)
+				code.Print("  t, ok := compiler.IntForScalarNode(v%d)", fieldNumber)
 				code.Print("  if ok {")
-				code.Print("    x.%s = int64(t)", fieldName)
+				if wrapperType := domain.wrapperTypeForProperty(domain.TypeModels[parentTypeName], propertyModel); wrapperType != "" {
+					code.Print("    x.%s = &wrappers.%s{Value: t}", fieldName, wrapperType)
+				} else {
+					code.Print("    x.%s = t", fieldName)
+				}
 				code.Print("  } else {")
-				code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%+v (%%T)\", v%d, v%d)", propertyName, fieldNumber, fieldNumber)
-				code.Print("    errors = append(errors, compiler.NewError(context, message))")
+				code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+				code.Print("    errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
 				code.Print("  }")
 				code.Print("}")
 			} else if propertyType == "bool" {
 				if oneOfWrapper {
 					propertyName := "Boolean"
-					code.Print("boolValue, ok := in.(bool)")
+					code.Print("boolValue, ok := compiler.BoolForScalarNode(in)")
 					code.Print("if ok {")
 					code.Print("  x.Oneof = &%s_%s{%s: boolValue}", parentTypeName, propertyName, propertyName)
 					code.Print("}")
+				} else if wrapperType := domain.wrapperTypeForProperty(domain.TypeModels[parentTypeName], propertyModel); wrapperType != "" {
+					code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
+					code.Print("if (v%d != nil) {", fieldNumber)
+					code.Print("  b, ok := compiler.BoolForScalarNode(v%d)", fieldNumber)
+					code.Print("  if ok {")
+					code.Print("    x.%s = &wrappers.%s{Value: b}", fieldName, wrapperType)
+					code.Print("  } else {")
+					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+					code.Print("    errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
+					code.Print("  }")
+					code.Print("}")
 				} else {
-					code.Print("v%d := compiler.MapValueForKey(m, \"%s\")", fieldNumber, propertyName)
+					code.Print("v%d := index.ValueForKey(\"%s\")", fieldNumber, propertyName)
 					code.Print("if (v%d != nil) {", fieldNumber)
-					code.Print("  x.%s, ok = v%d.(bool)", fieldName, fieldNumber)
+					code.Print("  x.%s, ok = compiler.BoolForScalarNode(v%d)", fieldName, fieldNumber)
 					code.Print("  if !ok {")
-					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%+v (%%T)\", v%d, v%d)", propertyName, fieldNumber, fieldNumber)
-					code.Print("    errors = append(errors, compiler.NewError(context, message))")
+					code.Print("    message := fmt.Sprintf(\"has unexpected value for %s: %%s\", compiler.Display(v%d))", propertyName, fieldNumber)
+					code.Print("    errors = append(errors, compiler.NewErrorForNode(context, v%d, compiler.ErrorCodeUnexpectedValue, message))", fieldNumber)
 					code.Print("  }")
 					code.Print("}")
 				}
@@ -463,24 +527,26 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 				mapTypeName := propertyModel.MapType
 				if mapTypeName != "" {
 					code.Print("// MAP: %s %s", mapTypeName, propertyModel.Pattern)
-					if mapTypeName == "string" {
+					if domain.usesMapField(propertyModel) {
+						code.Print("x.%s = make(%s, 0)", fieldName, mapFieldGoType(propertyModel))
+					} else if mapTypeName == "string" {
 						code.Print("x.%s = make([]*NamedString, 0)", fieldName)
 					} else {
 						code.Print("x.%s = make([]*Named%s, 0)", fieldName, mapTypeName)
 					}
-					code.Print("for _, item := range m {")
-					code.Print("k, ok := item.Key.(string)")
+					code.Print("for i := 0; i < len(m.Content); i += 2 {")
+					code.Print("k, ok := compiler.KeyForNode(m.Content[i])")
 					code.Print("if ok {")
-					code.Print("v := item.Value")
+					code.Print("v := m.Content[i+1]")
 					if propertyModel.Pattern != "" {
-						code.Print("if compiler.PatternMatches(\"%s\", k) {", propertyModel.Pattern)
+						code.Print("if %s.MatchString(k) {", domain.keyPatternVariable(propertyModel.Pattern))
 					}
 
-					code.Print("pair := &Named" + strings.Title(mapTypeName) + "{}")
+					code.Print("pair := %s", arenaAllocation("Named"+strings.Title(mapTypeName)))
 					code.Print("pair.Name = k")
 
 					if mapTypeName == "string" {
-						code.Print("pair.Value = v.(string)")
+						code.Print("pair.Value, _ = compiler.StringForScalarNode(v)")
 					} else if mapTypeName == "Any" {
 						code.Print("result := &Any{}")
 						code.Print("handled, resultFromExt, err := compiler.HandleExtension(context, v, k)")
@@ -488,7 +554,7 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 						code.Print("	if err != nil {")
 						code.Print("		errors = append(errors, err)")
 						code.Print("	} else {")
-						code.Print("		bytes, _ := yaml.Marshal(v)")
+						code.Print("		bytes := compiler.Marshal(v)")
 						code.Print("		result.Yaml = string(bytes)")
 						code.Print("		result.Value = resultFromExt")
 						code.Print("		pair.Value = result")
@@ -507,7 +573,11 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 						code.Print("  errors = append(errors, err)")
 						code.Print("}")
 					}
-					code.Print("x.%s = append(x.%s, pair)", fieldName, fieldName)
+					if domain.usesMapField(propertyModel) {
+						code.Print("x.%s[pair.Name] = pair.Value", fieldName)
+					} else {
+						code.Print("x.%s = append(x.%s, pair)", fieldName, fieldName)
+					}
 					if propertyModel.Pattern != "" {
 						code.Print("}")
 					}
@@ -534,9 +604,27 @@ func (domain *Domain) generateConstructorForType(code *printer.Code, typeName st
 	code.Print("}\n")
 }
 
+// The properties beside a $ref that override the properties of its target.
+var referenceOverrideProperties = map[string]bool{"summary": true, "description": true}
+
+// Returns the names of the fields of a type that override the fields of the
+// value that its $ref refers to when the reference is resolved.
+func referenceOverrides(typeModel *TypeModel) []string {
+	names := make([]string, 0)
+	for _, propertyModel := range typeModel.Properties {
+		if referenceOverrideProperties[propertyModel.Name] && propertyModel.Type == "string" && !propertyModel.Repeated {
+			names = append(names, strings.Title(propertyModel.Name))
+		}
+	}
+	return names
+}
+
 // ResolveReferences() methods
 func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code, typeName string) {
-	code.Print("func (m *%s) ResolveReferences(root string) (interface{}, error) {", typeName)
+	code.Print("func (m *%s) ResolveReferences(root string) (*yaml.Node, error) {", typeName)
+	code.Print("  return m.ResolveReferencesInContext(root, nil)")
+	code.Print("}\n")
+	code.Print("func (m *%s) ResolveReferencesInContext(root string, context *compiler.Context) (*yaml.Node, error) {", typeName)
 	code.Print("errors := make([]error, 0)")
 
 	typeModel := domain.TypeModels[typeName]
@@ -550,11 +638,11 @@ func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code
 				code.Print("p, ok := m.Oneof.(*%s_%s)", typeName, propertyType)
 				code.Print("if ok {")
 				if propertyType == "JsonReference" { // Special case for OpenAPI
-					code.Print("info, err := p.%s.ResolveReferences(root)", propertyType)
+					code.Print("info, err := p.%s.ResolveReferencesInContext(root, context)", propertyType)
 					code.Print("if err != nil {")
 					code.Print("  return nil, err")
 					code.Print("} else if info != nil {")
-					code.Print("  n, err := New%s(info, nil)", typeName)
+					code.Print("  n, err := New%s(info, context)", typeName)
 					code.Print("  if err != nil {")
 					code.Print("    return nil, err")
 					code.Print("  } else if n != nil {")
@@ -563,7 +651,7 @@ func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code
 					code.Print("  }")
 					code.Print("}")
 				} else {
-					code.Print("_, err := p.%s.ResolveReferences(root)", propertyType)
+					code.Print("_, err := p.%s.ResolveReferencesInContext(root, context)", propertyType)
 					code.Print("if err != nil {")
 					code.Print("	return nil, err")
 					code.Print("}")
@@ -589,7 +677,7 @@ func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code
 				fieldName = "XRef"
 				code.Print("if m.XRef != \"\" {")
 				//code.Print("log.Printf(\"%s reference to resolve %%+v\", m.XRef)", typeName)
-				code.Print("info, err := compiler.ReadInfoForRef(root, m.XRef)")
+				code.Print("info, err := compiler.ReadInfoForRefInContext(root, m.XRef, context)")
 
 				code.Print("if err != nil {")
 				code.Print("	return nil, err")
@@ -597,11 +685,21 @@ func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code
 				//code.Print("log.Printf(\"%%+v\", info)")
 
 				if len(typeModel.Properties) > 1 {
-					code.Print("if info != nil {")
-					code.Print("  replacement, err := New%s(info, nil)", typeName)
+					// recursive references are left unresolved so that resolution ends
+					code.Print("if info != nil && !context.IsFollowing(m.XRef) {")
+					code.Print("  refContext := compiler.NewReferenceContext(m.XRef, context)")
+					// references in the replacement are relative to the file that contains it
+					code.Print("  refRoot := compiler.FileForRef(root, m.XRef)")
+					code.Print("  replacement, err := New%s(info, refContext)", typeName)
 					code.Print("  if err == nil {")
+					// summaries and descriptions beside a $ref override those of its target
+					for _, overrideName := range referenceOverrides(typeModel) {
+						code.Print("    if m.%s != \"\" {", overrideName)
+						code.Print("      replacement.%s = m.%s", overrideName, overrideName)
+						code.Print("    }")
+					}
 					code.Print("    *m = *replacement")
-					code.Print("    return m.ResolveReferences(root)")
+					code.Print("    return m.ResolveReferencesInContext(refRoot, refContext)")
 					code.Print("  }")
 					code.Print("}")
 				}
@@ -615,7 +713,7 @@ func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code
 				typeModel, typeFound := domain.TypeModels[propertyType]
 				if typeFound && !typeModel.IsPair {
 					code.Print("if m.%s != nil {", fieldName)
-					code.Print("    _, err := m.%s.ResolveReferences(root)", fieldName)
+					code.Print("    _, err := m.%s.ResolveReferencesInContext(root, context)", fieldName)
 					code.Print("    if err != nil {")
 					code.Print("       errors = append(errors, err)")
 					code.Print("    }")
@@ -624,10 +722,12 @@ func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code
 			} else {
 				propertyType := propertyModel.Type
 				_, typeFound := domain.TypeModels[propertyType]
-				if typeFound {
+				if domain.usesMapField(propertyModel) && propertyModel.MapType == "string" {
+					// string values have no references to resolve
+				} else if typeFound {
 					code.Print("for _, item := range m.%s {", fieldName)
 					code.Print("if item != nil {")
-					code.Print("  _, err := item.ResolveReferences(root)")
+					code.Print("  _, err := item.ResolveReferencesInContext(root, context)")
 					code.Print("  if err != nil {")
 					code.Print("     errors = append(errors, err)")
 					code.Print("  }")
@@ -644,22 +744,44 @@ func (domain *Domain) generateResolveReferencesMethodsForType(code *printer.Code
 
 // ToRawInfo() methods
 func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeName string) {
-	code.Print("func (m *%s) ToRawInfo() interface{} {", typeName)
+	code.Print("func (m *%s) ToRawInfo() *yaml.Node {", typeName)
 	typeModel := domain.TypeModels[typeName]
 	if typeName == "Any" {
 		code.Print("var err error")
-		code.Print("var info1 []yaml.MapSlice")
-		code.Print("err = yaml.Unmarshal([]byte(m.Yaml), &info1)")
-		code.Print("if err == nil {return info1}")
-		code.Print("var info2 yaml.MapSlice")
-		code.Print("err = yaml.Unmarshal([]byte(m.Yaml), &info2)")
-		code.Print("if err == nil {return info2}")
-		code.Print("var info3 interface{}")
-		code.Print("err = yaml.Unmarshal([]byte(m.Yaml), &info3)")
-		code.Print("if err == nil {return info3}")
-		code.Print("return nil")
+		code.Print("var node yaml.Node")
+		code.Print("err = yaml.Unmarshal([]byte(m.Yaml), &node)")
+		code.Print("if err == nil && len(node.Content) == 1 {")
+		code.Print("  return node.Content[0]")
+		code.Print("}")
+		code.Print("return compiler.NewNullNode()")
 	} else if typeName == "StringArray" {
-		code.Print("return m.Value")
+		code.Print("return compiler.NewSequenceNodeForStringArray(m.Value)")
+	} else if typeName == "Primitive" || typeName == "SpecificationExtension" {
+		code.Print("switch v := m.Oneof.(type) {")
+		code.Print("case *%s_Boolean:", typeName)
+		code.Print("  return compiler.NewScalarNodeForBool(v.Boolean)")
+		code.Print("case *%s_String_:", typeName)
+		code.Print("  return compiler.NewScalarNodeForString(v.String_)")
+		code.Print("case *%s_Integer:", typeName)
+		code.Print("  return compiler.NewScalarNodeForInt(v.Integer)")
+		code.Print("case *%s_Number:", typeName)
+		code.Print("  return compiler.NewScalarNodeForFloat(v.Number)")
+		code.Print("}")
+		code.Print("return compiler.NewNullNode()")
+	} else if typeModel.IsPair {
+		// pairs are written as maps with a single entry
+		code.Print("info := compiler.NewMappingNode()")
+		code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Name))")
+		if typeModel.PairValueType == "string" {
+			code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Value))")
+		} else {
+			code.Print("if m.Value != nil {")
+			code.Print("  info.Content = append(info.Content, m.Value.ToRawInfo())")
+			code.Print("} else {")
+			code.Print("  info.Content = append(info.Content, compiler.NewNullNode())")
+			code.Print("}")
+		}
+		code.Print("return info")
 	} else if typeModel.OneOfWrapper {
 		code.Print("// ONE OF WRAPPER")
 		code.Print("// %+v", typeModel)
@@ -671,58 +793,35 @@ func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeNam
 				code.Print(" return v%d.ToRawInfo()", i)
 				code.Print("}")
 			} else {
-				code.Print("// unhandled boolean")
+				code.Print("if v%d, ok := m.GetOneof().(*%s_Boolean); ok {", i, typeName)
+				code.Print("  return compiler.NewScalarNodeForBool(v%d.Boolean)", i)
+				code.Print("}")
 			}
 		}
-		code.Print("return nil")
+		code.Print("return compiler.NewNullNode()")
 	} else {
-		code.Print("info := yaml.MapSlice{}")
+		code.Print("info := compiler.NewMappingNode()")
 		for _, propertyModel := range typeModel.Properties {
 			switch propertyModel.Type {
 			case "string":
 				propertyName := propertyModel.Name
 				if !propertyModel.Repeated {
 					code.Print("if m.%s != \"\" {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s})", propertyName, propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
+					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(m.%s))", propertyModel.FieldName())
 					code.Print("}")
 				} else {
 					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s})", propertyName, propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
+					code.Print("info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.%s))", propertyModel.FieldName())
 					code.Print("}")
 				}
 			case "bool":
-				propertyName := propertyModel.Name
-				if !propertyModel.Repeated {
-					code.Print("if m.%s != false {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s})", propertyName, propertyModel.FieldName())
-					code.Print("}")
-				} else {
-					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s})", propertyName, propertyModel.FieldName())
-					code.Print("}")
-				}
+				domain.generateToRawInfoForScalar(code, typeModel, propertyModel, "m.%s != false", "Bool")
 			case "int":
-				propertyName := propertyModel.Name
-				if !propertyModel.Repeated {
-					code.Print("if m.%s != 0 {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s})", propertyName, propertyModel.FieldName())
-					code.Print("}")
-				} else {
-					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s})", propertyName, propertyModel.FieldName())
-					code.Print("}")
-				}
+				domain.generateToRawInfoForScalar(code, typeModel, propertyModel, "m.%s != 0", "Int")
 			case "float":
-				propertyName := propertyModel.Name
-				if !propertyModel.Repeated {
-					code.Print("if m.%s != 0.0 {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s})", propertyName, propertyModel.FieldName())
-					code.Print("}")
-				} else {
-					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s})", propertyName, propertyModel.FieldName())
-					code.Print("}")
-				}
+				domain.generateToRawInfoForScalar(code, typeModel, propertyModel, "m.%s != 0.0", "Float")
 			default:
 				propertyName := propertyModel.Name
 				if propertyName == "value" {
@@ -730,39 +829,69 @@ func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeNam
 				} else if !propertyModel.Repeated {
 					code.Print("if m.%s != nil {", propertyModel.FieldName())
 					if propertyModel.Type == "TypeItem" {
+						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"type\"))")
 						code.Print("if len(m.Type.Value) == 1 {")
-						code.Print("info = append(info, yaml.MapItem{\"type\", m.Type.Value[0]})")
+						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(m.Type.Value[0]))")
 						code.Print("} else {")
-						code.Print("info = append(info, yaml.MapItem{\"type\", m.Type.Value})")
+						code.Print("info.Content = append(info.Content, compiler.NewSequenceNodeForStringArray(m.Type.Value))")
 						code.Print("}")
 					} else if propertyModel.Type == "ItemsItem" {
-						code.Print("items := make([]interface{}, 0)")
-						code.Print("for _, item := range m.Items.Schema {")
-						code.Print("	items = append(items, item.ToRawInfo())")
+						itemsFieldName := "Schema"
+						if domain.Version == "v3" {
+							itemsFieldName = "SchemaOrReference"
+						}
+						code.Print("items := compiler.NewSequenceNode()")
+						code.Print("for _, item := range m.Items.%s {", itemsFieldName)
+						code.Print("	items.Content = append(items.Content, item.ToRawInfo())")
+						code.Print("}")
+						code.Print("if len(items.Content) == 1 {")
+						code.Print("	items = items.Content[0]")
 						code.Print("}")
-						code.Print("info = append(info, yaml.MapItem{\"items\", items[0]})")
+						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"items\"))")
+						code.Print("info.Content = append(info.Content, items)")
 					} else {
-						code.Print("info = append(info, yaml.MapItem{\"%s\", m.%s.ToRawInfo()})",
-							propertyName, propertyModel.FieldName())
+						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
+						code.Print("info.Content = append(info.Content, m.%s.ToRawInfo())", propertyModel.FieldName())
 					}
 					code.Print("}")
 					code.Print("// %+v", propertyModel)
+				} else if domain.usesMapField(propertyModel) {
+					code.Print("if m.%s != nil {", propertyModel.FieldName())
+					generateSortedKeysForMapField(code, "m."+propertyModel.FieldName())
+					code.Print("for _, k := range keys {")
+					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(k))")
+					if propertyModel.MapType == "string" {
+						code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(m.%s[k]))", propertyModel.FieldName())
+					} else {
+						code.Print("info.Content = append(info.Content, m.%s[k].ToRawInfo())", propertyModel.FieldName())
+					}
+					code.Print("}")
+					code.Print("}")
+					code.Print("// %+v", propertyModel)
 				} else if propertyModel.MapType == "string" {
+					code.Print("if m.%s != nil {", propertyModel.FieldName())
+					code.Print("for _, item := range m.%s {", propertyModel.FieldName())
+					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))")
+					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Value))")
+					code.Print("}")
+					code.Print("}")
 					code.Print("// %+v", propertyModel)
 				} else if propertyModel.MapType != "" {
 					code.Print("if m.%s != nil {", propertyModel.FieldName())
 					code.Print("for _, item := range m.%s {", propertyModel.FieldName())
-					code.Print("info = append(info, yaml.MapItem{item.Name, item.Value.ToRawInfo()})")
+					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(item.Name))")
+					code.Print("info.Content = append(info.Content, item.Value.ToRawInfo())")
 					code.Print("}")
 					code.Print("}")
 					code.Print("// %+v", propertyModel)
 				} else {
 					code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
-					code.Print("items := make([]interface{}, 0)")
+					code.Print("items := compiler.NewSequenceNode()")
 					code.Print("for _, item := range m.%s {", propertyModel.FieldName())
-					code.Print("items = append(items, item.ToRawInfo())")
+					code.Print("items.Content = append(items.Content, item.ToRawInfo())")
 					code.Print("}")
-					code.Print("info = append(info, yaml.MapItem{\"%s\", items})", propertyName)
+					code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
+					code.Print("info.Content = append(info.Content, items)")
 					code.Print("}")
 					code.Print("// %+v", propertyModel)
 				}
@@ -772,3 +901,29 @@ func (domain *Domain) generateToRawInfoMethodForType(code *printer.Code, typeNam
 	}
 	code.Print("}\n")
 }
+
+// Generates the ToRawInfo() code for a bool, int, or float property.
+// The condition is a format string that tests whether the field has a nonzero value.
+func (domain *Domain) generateToRawInfoForScalar(code *printer.Code, typeModel *TypeModel, propertyModel *TypeProperty, condition string, nodeType string) {
+	propertyName := propertyModel.Name
+	if domain.wrapperTypeForProperty(typeModel, propertyModel) != "" {
+		code.Print("if m.%s != nil {", propertyModel.FieldName())
+		code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
+		code.Print("info.Content = append(info.Content, compiler.NewScalarNodeFor%s(m.%s.Value))", nodeType, propertyModel.FieldName())
+		code.Print("}")
+	} else if !propertyModel.Repeated {
+		code.Print("if "+condition+" {", propertyModel.FieldName())
+		code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
+		code.Print("info.Content = append(info.Content, compiler.NewScalarNodeFor%s(m.%s))", nodeType, propertyModel.FieldName())
+		code.Print("}")
+	} else {
+		code.Print("if len(m.%s) != 0 {", propertyModel.FieldName())
+		code.Print("items := compiler.NewSequenceNode()")
+		code.Print("for _, item := range m.%s {", propertyModel.FieldName())
+		code.Print("items.Content = append(items.Content, compiler.NewScalarNodeFor%s(item))", nodeType)
+		code.Print("}")
+		code.Print("info.Content = append(info.Content, compiler.NewScalarNodeForString(\"%s\"))", propertyName)
+		code.Print("info.Content = append(info.Content, items)")
+		code.Print("}")
+	}
+}
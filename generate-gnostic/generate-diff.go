@@ -0,0 +1,201 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Generates Equal() and Diff() methods, which compare messages field by field.
+// Named values are matched by name, so values that are only in a different
+// order are equal, just as they are in the maps of source documents.
+func (domain *Domain) generateDiffMethodsForType(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	code.Print("// Equal returns true if two %s messages have the same values.", typeName)
+	code.Print("func (m *%s) Equal(other *%s) bool {", typeName, typeName)
+	code.Print("  return len(m.diff(other, \"$root\", nil)) == 0")
+	code.Print("}\n")
+
+	code.Print("// Diff returns the values that differ between two %s messages.", typeName)
+	code.Print("func (m *%s) Diff(other *%s) []*compiler.Difference {", typeName, typeName)
+	code.Print("  return m.diff(other, \"$root\", nil)")
+	code.Print("}\n")
+
+	code.Print("func (m *%s) diff(other *%s, path string, differences []*compiler.Difference) []*compiler.Difference {", typeName, typeName)
+	code.Print("if m == nil || other == nil {")
+	code.Print("  if m != other {")
+	code.Print("    differences = append(differences, compiler.NewDifference(path, m, other))")
+	code.Print("  }")
+	code.Print("  return differences")
+	code.Print("}")
+	if typeModel.IsBlob {
+		code.Print("if m.Yaml != other.Yaml || !compiler.EqualAny(m.Value, other.Value) {")
+		code.Print("  differences = append(differences, compiler.NewDifference(path, m, other))")
+		code.Print("}")
+	} else if typeName == "StringArray" {
+		generateDiffForScalarArray(code, "m.Value", "other.Value", "path", "m", "other")
+	} else if typeName == "Primitive" || typeName == "SpecificationExtension" {
+		code.Print("switch x := m.Oneof.(type) {")
+		code.Print("case nil:")
+		code.Print("  if other.Oneof == nil {")
+		code.Print("    return differences")
+		code.Print("  }")
+		for _, name := range []string{"Boolean", "String_", "Integer", "Number"} {
+			code.Print("case *%s_%s:", typeName, name)
+			code.Print("  if y, ok := other.Oneof.(*%s_%s); ok && x.%s == y.%s {", typeName, name, name, name)
+			code.Print("    return differences")
+			code.Print("  }")
+		}
+		code.Print("}")
+		code.Print("differences = append(differences, compiler.NewDifference(path, m, other))")
+	} else if typeModel.OneOfWrapper {
+		code.Print("switch x := m.Oneof.(type) {")
+		code.Print("case nil:")
+		code.Print("  if other.Oneof == nil {")
+		code.Print("    return differences")
+		code.Print("  }")
+		for _, propertyModel := range typeModel.Properties {
+			if propertyModel.Type == "bool" {
+				code.Print("case *%s_Boolean:", typeName)
+				code.Print("  if y, ok := other.Oneof.(*%s_Boolean); ok && x.Boolean == y.Boolean {", typeName)
+				code.Print("    return differences")
+				code.Print("  }")
+			} else if _, typeFound := domain.TypeModels[propertyModel.Type]; typeFound {
+				propertyType := propertyModel.Type
+				code.Print("case *%s_%s:", typeName, propertyType)
+				code.Print("  if y, ok := other.Oneof.(*%s_%s); ok {", typeName, propertyType)
+				code.Print("    return x.%s.diff(y.%s, path+\".%s\", differences)", propertyType, propertyType, propertyModel.Name)
+				code.Print("  }")
+			}
+		}
+		code.Print("}")
+		code.Print("differences = append(differences, compiler.NewDifference(path, m, other))")
+	} else {
+		for _, propertyModel := range typeModel.Properties {
+			propertyName := propertyModel.Name
+			fieldName := propertyModel.FieldName()
+			propertyTypeModel, typeFound := domain.TypeModels[propertyModel.Type]
+			if domain.usesMapField(propertyModel) {
+				code.Print("{")
+				generateSortedKeysForMapField(code, "m."+fieldName)
+				code.Print("for _, k := range keys {")
+				code.Print("  if v, ok := other.%s[k]; !ok {", fieldName)
+				code.Print("    differences = append(differences, compiler.NewDifference(path+\".\"+k, m.%s[k], nil))", fieldName)
+				if propertyModel.MapType == "string" {
+					code.Print("  } else if m.%s[k] != v {", fieldName)
+					code.Print("    differences = append(differences, compiler.NewDifference(path+\".\"+k, m.%s[k], v))", fieldName)
+				} else {
+					code.Print("  } else {")
+					code.Print("    differences = m.%s[k].diff(v, path+\".\"+k, differences)", fieldName)
+				}
+				code.Print("  }")
+				code.Print("}")
+				code.Print("}")
+				code.Print("{")
+				generateSortedKeysForMapField(code, "other."+fieldName)
+				code.Print("for _, k := range keys {")
+				code.Print("  if _, ok := m.%s[k]; !ok {", fieldName)
+				code.Print("    differences = append(differences, compiler.NewDifference(path+\".\"+k, nil, other.%s[k]))", fieldName)
+				code.Print("  }")
+				code.Print("}")
+				code.Print("}")
+			} else if typeFound && propertyTypeModel.IsPair {
+				code.Print("differences = diff%sPairs(m.%s, other.%s, path, differences)", propertyModel.Type, fieldName, fieldName)
+			} else if typeFound && propertyModel.Repeated {
+				code.Print("for i := 0; i < len(m.%s) || i < len(other.%s); i++ {", fieldName, fieldName)
+				code.Print("  var a, b *%s", propertyModel.Type)
+				code.Print("  if i < len(m.%s) {", fieldName)
+				code.Print("    a = m.%s[i]", fieldName)
+				code.Print("  }")
+				code.Print("  if i < len(other.%s) {", fieldName)
+				code.Print("    b = other.%s[i]", fieldName)
+				code.Print("  }")
+				code.Print("  differences = a.diff(b, fmt.Sprintf(\"%%s.%s[%%d]\", path, i), differences)", propertyName)
+				code.Print("}")
+			} else if typeFound {
+				code.Print("differences = m.%s.diff(other.%s, path+\".%s\", differences)", fieldName, fieldName, propertyName)
+			} else if propertyModel.Type == "google.protobuf.Any" {
+				code.Print("if !compiler.EqualAny(m.%s, other.%s) {", fieldName, fieldName)
+				code.Print("  differences = append(differences, compiler.NewDifference(path+\".%s\", m.%s, other.%s))", propertyName, fieldName, fieldName)
+				code.Print("}")
+			} else if domain.wrapperTypeForProperty(typeModel, propertyModel) != "" {
+				code.Print("if (m.%s == nil) != (other.%s == nil) || m.%s.GetValue() != other.%s.GetValue() {", fieldName, fieldName, fieldName, fieldName)
+				code.Print("  differences = append(differences, compiler.NewDifference(path+\".%s\", m.%s, other.%s))", propertyName, fieldName, fieldName)
+				code.Print("}")
+			} else if goTypeForScalarProperty(propertyModel.Type) != "" && propertyModel.Repeated {
+				generateDiffForScalarArray(code, "m."+fieldName, "other."+fieldName, "path+\"."+propertyName+"\"", "m."+fieldName, "other."+fieldName)
+			} else if goTypeForScalarProperty(propertyModel.Type) != "" {
+				code.Print("if m.%s != other.%s {", fieldName, fieldName)
+				code.Print("  differences = append(differences, compiler.NewDifference(path+\".%s\", m.%s, other.%s))", propertyName, fieldName, fieldName)
+				code.Print("}")
+			}
+		}
+	}
+	code.Print("return differences")
+	code.Print("}\n")
+
+	if typeModel.IsPair {
+		domain.generateDiffFunctionForPairs(code, typeModel)
+	}
+}
+
+// Generates code that reports a difference if two arrays of scalars differ.
+func generateDiffForScalarArray(code *printer.Code, a, b, path, oldValue, newValue string) {
+	code.Print("for i := 0; i < len(%s) || i < len(%s); i++ {", a, b)
+	code.Print("  if i >= len(%s) || i >= len(%s) || %s[i] != %s[i] {", a, b, a, b)
+	code.Print("    differences = append(differences, compiler.NewDifference(%s, %s, %s))", path, oldValue, newValue)
+	code.Print("    break")
+	code.Print("  }")
+	code.Print("}")
+}
+
+// Generates a function that compares two lists of pairs. Pairs are matched by
+// name, and when a name is repeated, only its first pair is compared.
+func (domain *Domain) generateDiffFunctionForPairs(code *printer.Code, typeModel *TypeModel) {
+	typeName := typeModel.Name
+	code.Print("// Adds the differences between two lists of %s pairs, which are matched by name.", typeName)
+	code.Print("func diff%sPairs(m []*%s, other []*%s, path string, differences []*compiler.Difference) []*compiler.Difference {", typeName, typeName, typeName)
+	code.Print("others := make(map[string]*%s, len(other))", typeName)
+	code.Print("for _, pair := range other {")
+	code.Print("  if _, found := others[pair.Name]; !found {")
+	code.Print("    others[pair.Name] = pair")
+	code.Print("  }")
+	code.Print("}")
+	code.Print("seen := make(map[string]bool, len(m))")
+	code.Print("for _, pair := range m {")
+	code.Print("  if seen[pair.Name] {")
+	code.Print("    continue")
+	code.Print("  }")
+	code.Print("  seen[pair.Name] = true")
+	code.Print("  if o, found := others[pair.Name]; !found {")
+	code.Print("    differences = append(differences, compiler.NewDifference(path+\".\"+pair.Name, pair.Value, nil))")
+	if _, typeFound := domain.TypeModels[typeModel.PairValueType]; typeFound {
+		code.Print("  } else {")
+		code.Print("    differences = pair.Value.diff(o.Value, path+\".\"+pair.Name, differences)")
+	} else {
+		code.Print("  } else if pair.Value != o.Value {")
+		code.Print("    differences = append(differences, compiler.NewDifference(path+\".\"+pair.Name, pair.Value, o.Value))")
+	}
+	code.Print("  }")
+	code.Print("}")
+	code.Print("for _, pair := range other {")
+	code.Print("  if !seen[pair.Name] {")
+	code.Print("    seen[pair.Name] = true")
+	code.Print("    differences = append(differences, compiler.NewDifference(path+\".\"+pair.Name, nil, pair.Value))")
+	code.Print("  }")
+	code.Print("}")
+	code.Print("return differences")
+	code.Print("}\n")
+}
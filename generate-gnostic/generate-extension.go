@@ -67,12 +67,12 @@ const additionalCompilerCodeWithMain = "" +
 
 const caseStringForObjectTypes = "\n" +
 	"case \"%s\":\n" +
-	"var info yaml.MapSlice\n" +
+	"var info yaml.Node\n" +
 	"err := yaml.Unmarshal([]byte(yamlInput), &info)\n" +
 	"if err != nil {\n" +
 	"  return true, nil, err\n" +
 	"}\n" +
-	"newObject, err := %s.New%s(info, compiler.NewContext(\"$root\", nil))\n" +
+	"newObject, err := %s.New%s(&info, compiler.NewContext(\"$root\", nil))\n" +
 	"return true, newObject, err"
 
 const caseStringForWrapperTypes = "\n" +
@@ -260,12 +260,21 @@ func GenerateExtension(schemaFile string, outDir string) error {
 	}
 
 	// generate the compiler
-	compiler := cc.GenerateCompiler(goPackageName, LICENSE, []string{
+	goImports := []string{
 		"fmt",
 		"strings",
 		"github.com/googleapis/gnostic/compiler",
-		"gopkg.in/yaml.v2",
-	})
+		"github.com/golang/protobuf/jsonpb",
+		"github.com/googleapis/gnostic/jsonwriter",
+		"gopkg.in/yaml.v3",
+	}
+	if cc.usesRepeatedMessages() {
+		goImports = append(goImports, "strconv")
+	}
+	if len(cc.keyPatterns()) > 0 {
+		goImports = append(goImports, "regexp")
+	}
+	compiler := cc.GenerateCompiler(goPackageName, LICENSE, goImports)
 	goFilename := path.Join(protoOutDirectory, outFileBaseName+".go")
 	err = ioutil.WriteFile(goFilename, []byte(compiler), 0644)
 	if err != nil {
@@ -298,7 +307,7 @@ func GenerateExtension(schemaFile string, outDir string) error {
 		"github.com/golang/protobuf/proto",
 		"github.com/googleapis/gnostic/extensions",
 		"github.com/googleapis/gnostic/compiler",
-		"gopkg.in/yaml.v2",
+		"gopkg.in/yaml.v3",
 		outDirRelativeToGoPathSrc + "/" + "proto",
 	}
 	if wrapperTypeIncluded {
@@ -0,0 +1,262 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"runtime"
+	"strings"
+	"unicode"
+
+	"github.com/googleapis/gnostic/jsonschema"
+	"github.com/googleapis/gnostic/printer"
+)
+
+// GenerateGoTypes returns Go source code containing a struct for each type in the domain.
+// Unlike the generated protocol buffer models, these are plain Go types that carry
+// json and yaml field tags and use native Go maps for map-like properties.
+func (domain *Domain) GenerateGoTypes(packageName string, license string) string {
+	code := &printer.Code{}
+	code.Print(license)
+	code.Print("// THIS FILE IS AUTOMATICALLY GENERATED.\n")
+
+	code.Print("package %s\n", packageName)
+
+	for _, typeName := range domain.sortedTypeNames() {
+		typeModel := domain.TypeModels[typeName]
+		if !domain.typeModelNeedsGoType(typeModel) {
+			continue
+		}
+		if typeModel.Description != "" {
+			code.Print("// %s %s", typeName, typeModel.Description)
+		}
+		code.Print("type %s struct {", typeName)
+		code.Indent()
+		if typeModel.OneOfWrapper {
+			code.Print("// Only one of the following fields should be set.")
+		}
+		inlineCount := 0
+		for _, property := range typeModel.Properties {
+			if property.MapType != "" {
+				inlineCount++
+			}
+		}
+		for _, property := range typeModel.Properties {
+			if property.Description != "" {
+				code.Print("// %s", property.Description)
+			}
+			var tag string
+			if property.MapType != "" {
+				// map-like properties collect keys of the enclosing object, so they
+				// can only be decoded directly when there is exactly one of them.
+				if inlineCount == 1 {
+					tag = "`json:\"-\" yaml:\",inline\"`"
+				} else {
+					tag = "`json:\"-\" yaml:\"-\"`"
+				}
+			} else {
+				tag = fmt.Sprintf("`json:\"%s,omitempty\" yaml:\"%s,omitempty\"`", property.Name, property.Name)
+			}
+			code.Print("%s %s %s", goFieldName(property.Name), domain.goTypeForProperty(property), tag)
+		}
+		code.Outdent()
+		code.Print("}\n")
+		domain.generateGettersForGoType(code, typeModel)
+	}
+	return code.String()
+}
+
+// Generates a getter for each field of a Go type. Like the getters that
+// protoc-gen-go generates, they return zero values for nil receivers, so
+// chains of getters like doc.GetInfo().GetContact().GetEmail() don't need
+// to check each value for nil.
+func (domain *Domain) generateGettersForGoType(code *printer.Code, typeModel *TypeModel) {
+	for _, property := range typeModel.Properties {
+		fieldName := goFieldName(property.Name)
+		goType := domain.goTypeForProperty(property)
+		code.Print("// Get%s returns the %s of a %s, or its zero value if the %s is nil.", fieldName, fieldName, typeModel.Name, typeModel.Name)
+		code.Print("func (m *%s) Get%s() %s {", typeModel.Name, fieldName, goType)
+		code.Print("  if m != nil {")
+		code.Print("    return m.%s", fieldName)
+		code.Print("  }")
+		code.Print("  return %s", zeroValueForGoType(goType))
+		code.Print("}\n")
+	}
+}
+
+// Returns the zero value of a Go type that is used for properties.
+func zeroValueForGoType(goType string) string {
+	switch goType {
+	case "string":
+		return "\"\""
+	case "bool":
+		return "false"
+	case "int64", "float64":
+		return "0"
+	}
+	return "nil"
+}
+
+// Types that are represented with native Go types don't need their own structs.
+func (domain *Domain) typeModelNeedsGoType(typeModel *TypeModel) bool {
+	return !typeModel.IsPair && !typeModel.IsBlob && typeModel.Name != "StringArray"
+}
+
+// Returns the Go type to use for a property.
+func (domain *Domain) goTypeForProperty(property *TypeProperty) string {
+	if property.MapType != "" {
+		return "map[string]" + domain.goTypeForTypeName(property.MapType)
+	}
+	goType := domain.goTypeForTypeName(property.Type)
+	if property.Repeated {
+		return "[]" + goType
+	}
+	return goType
+}
+
+// Returns the Go type that corresponds to a type name used in the domain model.
+func (domain *Domain) goTypeForTypeName(typeName string) string {
+	switch typeName {
+	case "string", "bool":
+		return typeName
+	case "boolean":
+		return "bool"
+	case "int", "integer":
+		return "int64"
+	case "float", "number":
+		return "float64"
+	case "object":
+		return "map[string]interface{}"
+	case "array":
+		return "[]interface{}"
+	case "StringArray":
+		return "[]string"
+	}
+	typeModel, ok := domain.TypeModels[typeName]
+	if !ok || typeModel.IsBlob {
+		return "interface{}"
+	}
+	return "*" + typeName
+}
+
+// Returns an exported Go identifier for a JSON property name.
+func goFieldName(propertyName string) string {
+	if propertyName == "$ref" {
+		return "XRef"
+	}
+	result := ""
+	upper := true
+	for _, r := range propertyName {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			result += string(unicode.ToUpper(r))
+			upper = false
+		} else {
+			result += string(r)
+		}
+	}
+	if result == "" || unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+	return result
+}
+
+// GenerateGoTypesForSchema reads a JSON schema and writes Go types for it into outDir.
+func GenerateGoTypesForSchema(schemaFile string, outDir string, packageName string) error {
+	// the JSON Schema meta-schema is loaded so that references to it can be resolved;
+	// schemas are registered by id when they are read
+	baseSchema, err := jsonschema.MetaSchema()
+	if err != nil {
+		return err
+	}
+	baseSchema.ResolveRefs()
+	baseSchema.ResolveAllOfs()
+
+	schema, err := jsonschema.NewSchemaFromFile(schemaFile)
+	if err != nil {
+		return err
+	}
+	schema.ResolveRefs()
+	schema.ResolveAllOfs()
+
+	// build a simplified model of the types described by the schema
+	cc := NewDomain(schema, "")
+	err = cc.Build()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(outDir, 0755)
+	if err != nil {
+		return err
+	}
+	goFilename := path.Join(outDir, getBaseFileNameWithoutExt(schemaFile)+".go")
+	err = ioutil.WriteFile(goFilename, []byte(cc.GenerateGoTypes(packageName, LICENSE)), 0644)
+	if err != nil {
+		return err
+	}
+	return exec.Command(runtime.GOROOT()+"/bin/gofmt", "-w", goFilename).Run()
+}
+
+func ProcessGoTypesGenCommandline(usage string) error {
+	outDir := ""
+	packageName := ""
+	schemaFile := ""
+
+	paramRegex := regexp.MustCompile("--(.+)=(.+)")
+
+	for i, arg := range os.Args {
+		if i == 0 {
+			continue // skip the tool name
+		}
+		if m := paramRegex.FindSubmatch([]byte(arg)); m != nil {
+			flagName := string(m[1])
+			flagValue := string(m[2])
+			switch flagName {
+			case "out_dir":
+				outDir = flagValue
+			case "package":
+				packageName = flagValue
+			default:
+				return errors.New(fmt.Sprintf("Unknown option: %s.\n%s", arg, usage))
+			}
+		} else if arg == "--go-types" {
+			continue
+		} else if arg[0] == '-' {
+			return errors.New(fmt.Sprintf("Unknown option: %s.\n%s", arg, usage))
+		} else {
+			schemaFile = arg
+		}
+	}
+	if schemaFile == "" {
+		return errors.New(fmt.Sprintf("No input json schema specified.\n%s", usage))
+	}
+	if outDir == "" {
+		outDir = "."
+	}
+	if packageName == "" {
+		packageName = strings.ToLower(goFieldName(getBaseFileNameWithoutExt(schemaFile)))
+	}
+	return GenerateGoTypesForSchema(schemaFile, outDir, packageName)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestGoTypeGetters(t *testing.T) {
+	outDir, err := ioutil.TempDir("", "go-types")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	defer os.RemoveAll(outDir)
+	err = GenerateGoTypesForSchema("../OpenAPIv3/openapi-3.0.json", outDir, "openapi")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	bytes, err := ioutil.ReadFile(path.Join(outDir, "openapi-3.0.go"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	for _, getter := range []string{
+		"func (m *Info) GetContact() *Contact {\n\tif m != nil {\n\t\treturn m.Contact\n\t}\n\treturn nil\n}",
+		"func (m *Contact) GetEmail() string {\n\tif m != nil {\n\t\treturn m.Email\n\t}\n\treturn \"\"\n}",
+		"func (m *Schema) GetMaximum() float64 {\n\tif m != nil {\n\t\treturn m.Maximum\n\t}\n\treturn 0\n}",
+	} {
+		if !strings.Contains(string(bytes), getter) {
+			t.Errorf("Expected generated getter:\n%s", getter)
+		}
+	}
+}
+
+func TestGoTypes(t *testing.T) {
+	outDir, err := ioutil.TempDir("", "go-types")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	defer os.RemoveAll(outDir)
+	// pets.json refers to a definition in the meta-schema
+	err = GenerateGoTypesForSchema("test/go-types/pets.json", outDir, "pets")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	bytes, err := ioutil.ReadFile(path.Join(outDir, "pets.go"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	expected, err := ioutil.ReadFile("test/go-types/pets.go.out")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if string(bytes) != string(expected) {
+		t.Errorf("Unexpected Go types:\n%s", string(bytes))
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Returns true if a property holds vendor or specification extensions.
+func isExtensionProperty(propertyModel *TypeProperty) bool {
+	fieldName := propertyModel.FieldName()
+	return fieldName == "VendorExtension" || fieldName == "SpecificationExtension"
+}
+
+// Returns true if a type has a property with the specified field name.
+func (typeModel *TypeModel) hasFieldNamed(fieldName string) bool {
+	for _, propertyModel := range typeModel.Properties {
+		if propertyModel.FieldName() == fieldName {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the map property of a type that is a container of named values,
+// or nil if the type is not a container. Containers have exactly one map
+// property apart from their extensions.
+func (domain *Domain) containerProperty(typeModel *TypeModel) *TypeProperty {
+	if typeModel == nil || typeModel.OneOfWrapper || typeModel.IsPair {
+		return nil
+	}
+	var result *TypeProperty
+	for _, propertyModel := range typeModel.Properties {
+		if propertyModel.MapType == "" || isExtensionProperty(propertyModel) {
+			continue
+		}
+		if propertyTypeModel, typeFound := domain.TypeModels[propertyModel.Type]; typeFound && !propertyTypeModel.IsPair {
+			continue
+		}
+		if result != nil {
+			return nil
+		}
+		result = propertyModel
+	}
+	return result
+}
+
+// Returns the Go type and zero value of the values in a container property.
+func containerValueType(propertyModel *TypeProperty) (string, string) {
+	if propertyModel.MapType == "string" {
+		return "string", "\"\""
+	}
+	return "*" + propertyModel.MapType, "nil"
+}
+
+// Returns the singular form of a plural field name.
+func singularFieldName(fieldName string) string {
+	if strings.HasSuffix(fieldName, "ies") {
+		return strings.TrimSuffix(fieldName, "ies") + "y"
+	}
+	return strings.TrimSuffix(fieldName, "s")
+}
+
+// Lookup methods find named values without a linear scan in user code.
+// Containers get a Get() method and the types that hold containers get
+// methods named for the singular form of the container property.
+func (domain *Domain) generateLookupMethodsForType(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	if containerProperty := domain.containerProperty(typeModel); containerProperty != nil && !typeModel.hasFieldNamed("Get") {
+		valueType, zeroValue := containerValueType(containerProperty)
+		code.Print("// Get returns the value in a %s with the specified name, or %s if there is none.", typeName, zeroValue)
+		code.Print("func (m *%s) Get(name string) %s {", typeName, valueType)
+		code.Print("  if m != nil {")
+		if domain.usesMapField(containerProperty) {
+			code.Print("    if value, ok := m.%s[name]; ok {", containerProperty.FieldName())
+			code.Print("      return value")
+			code.Print("    }")
+		} else {
+			code.Print("    for _, pair := range m.%s {", containerProperty.FieldName())
+			code.Print("      if pair.Name == name {")
+			code.Print("        return pair.Value")
+			code.Print("      }")
+			code.Print("    }")
+		}
+		code.Print("  }")
+		code.Print("  return %s", zeroValue)
+		code.Print("}\n")
+	}
+	if typeModel.OneOfWrapper || typeModel.IsPair {
+		return
+	}
+	for _, propertyModel := range typeModel.Properties {
+		if propertyModel.Repeated {
+			continue
+		}
+		containerTypeModel, typeFound := domain.TypeModels[propertyModel.Type]
+		if !typeFound || containerTypeModel.hasFieldNamed("Get") {
+			continue
+		}
+		containerProperty := domain.containerProperty(containerTypeModel)
+		if containerProperty == nil {
+			continue
+		}
+		fieldName := propertyModel.FieldName()
+		methodName := singularFieldName(fieldName)
+		if methodName == fieldName || typeModel.hasFieldNamed(methodName) {
+			continue
+		}
+		valueType, zeroValue := containerValueType(containerProperty)
+		code.Print("// Get%s returns the value in the %s of a %s with the specified name, or %s if there is none.",
+			methodName, propertyModel.Name, typeName, zeroValue)
+		code.Print("func (m *%s) Get%s(name string) %s {", typeName, methodName, valueType)
+		code.Print("  return m.Get%s().Get(name)", fieldName)
+		code.Print("}\n")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Marshaling methods write messages in the JSON and YAML forms that are
+// described by the schema, rather than in the forms of their Protocol Buffer
+// representations. They are based on ToRawInfo(), so maps are written as
+// objects and the values of Any messages are inlined. Unmarshaling methods
+// compile messages from the same forms, so messages round-trip through
+// encoding/json, gopkg.in/yaml.v3, and jsonpb.
+func (domain *Domain) generateMarshalingMethodsForType(code *printer.Code, typeName string) {
+	code.Print("// MarshalJSON returns the JSON form of a %s as it would appear in a source document.", typeName)
+	code.Print("func (m *%s) MarshalJSON() ([]byte, error) {", typeName)
+	code.Print("  if m == nil {")
+	code.Print("    return []byte(\"null\"), nil")
+	code.Print("  }")
+	code.Print("  return jsonwriter.Marshal(m.ToRawInfo())")
+	code.Print("}\n")
+
+	code.Print("// ToYAML returns the YAML form of a %s as it would appear in a source document.", typeName)
+	code.Print("func (m *%s) ToYAML() ([]byte, error) {", typeName)
+	code.Print("  if m == nil {")
+	code.Print("    return []byte(\"null\\n\"), nil")
+	code.Print("  }")
+	code.Print("  bytes := compiler.Marshal(m.ToRawInfo())")
+	code.Print("  if bytes == nil {")
+	code.Print("    return nil, fmt.Errorf(\"unable to write %s as YAML\")", typeName)
+	code.Print("  }")
+	code.Print("  return bytes, nil")
+	code.Print("}\n")
+
+	code.Print("// MarshalYAML returns the YAML node of a %s as it would appear in a source document.", typeName)
+	code.Print("// It is called by gopkg.in/yaml.v3 to write a %s in place of its fields.", typeName)
+	code.Print("func (m *%s) MarshalYAML() (interface{}, error) {", typeName)
+	code.Print("  if m == nil {")
+	code.Print("    return nil, nil")
+	code.Print("  }")
+	code.Print("  return m.ToRawInfo(), nil")
+	code.Print("}\n")
+
+	code.Print("// UnmarshalJSON compiles a %s from JSON in the form that it has in source documents.", typeName)
+	code.Print("func (m *%s) UnmarshalJSON(data []byte) error {", typeName)
+	code.Print("  var node yaml.Node")
+	code.Print("  if err := yaml.Unmarshal(data, &node); err != nil {")
+	code.Print("    return err")
+	code.Print("  }")
+	code.Print("  return m.UnmarshalYAML(&node)")
+	code.Print("}\n")
+
+	code.Print("// UnmarshalYAML compiles a %s from a YAML node in the form that it has in source documents.", typeName)
+	code.Print("// It is called by gopkg.in/yaml.v3 to read a %s.", typeName)
+	code.Print("func (m *%s) UnmarshalYAML(node *yaml.Node) error {", typeName)
+	code.Print("  if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {")
+	code.Print("    node = node.Content[0]")
+	code.Print("  }")
+	code.Print("  x, err := New%s(node, compiler.NewContext(\"$root\", nil))", typeName)
+	code.Print("  if err != nil {")
+	code.Print("    return err")
+	code.Print("  }")
+	code.Print("  *m = *x")
+	code.Print("  return nil")
+	code.Print("}\n")
+
+	if domain.TypeModels[typeName].IsBlob {
+		// The Protocol Buffer JSON form of other messages uses their field
+		// names, but values are written as they are in source documents.
+		code.Print("// MarshalJSONPB writes a %s in the Protocol Buffer JSON form of messages that contain it.", typeName)
+		code.Print("// The value is written as it is in source documents instead of as a YAML string.")
+		code.Print("func (m *%s) MarshalJSONPB(marshaler *jsonpb.Marshaler) ([]byte, error) {", typeName)
+		code.Print("  if m == nil {")
+		code.Print("    return []byte(\"null\"), nil")
+		code.Print("  }")
+		code.Print("  return jsonwriter.MarshalCompact(m.ToRawInfo())")
+		code.Print("}\n")
+
+		code.Print("// UnmarshalJSONPB reads a %s that was written with MarshalJSONPB.", typeName)
+		code.Print("func (m *%s) UnmarshalJSONPB(unmarshaler *jsonpb.Unmarshaler, data []byte) error {", typeName)
+		code.Print("  return m.UnmarshalJSON(data)")
+		code.Print("}\n")
+	}
+}
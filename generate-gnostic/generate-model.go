@@ -0,0 +1,281 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// ModelConfig describes a JSON or YAML format for which a Protocol Buffer
+// model and a gnostic-style compiler are generated.
+// Relative paths in a configuration file are interpreted relative to the
+// directory that contains the file.
+type ModelConfig struct {
+	// The JSON schema that describes the format.
+	Schema string `yaml:"schema"`
+	// The base name of the generated .proto and .go files (default: the schema file name).
+	Name string `yaml:"name"`
+	// The Protocol Buffer package of the generated model (default: the name in lower case).
+	ProtoPackage string `yaml:"proto_package"`
+	// The Go package of the generated code (default: the proto package with "." replaced by "_").
+	GoPackage string `yaml:"go_package"`
+	// The directory where generated files are written (default: the current directory).
+	OutDir string `yaml:"out_dir"`
+	// A file containing a comment to place at the top of each generated file (default: the gnostic license).
+	License string `yaml:"license"`
+	// Names of the map fields that are generated for patternProperties, keyed by pattern.
+	PatternNames map[string]string `yaml:"pattern_names"`
+	// Options to add to the generated .proto file.
+	ProtoOptions []ProtoOption `yaml:"proto_options"`
+	// Files to import from the generated .proto file (default: google/protobuf/any.proto).
+	ProtoImports []string `yaml:"proto_imports"`
+	// A file that records the numbers assigned to the fields of generated messages
+	// (default: the name with ".fieldnumbers.yaml" appended, in the output directory).
+	// It is updated when new fields are numbered and should be kept under version control.
+	FieldNumbers string `yaml:"field_numbers"`
+	// If true, optional numeric and boolean fields are held in the wrapper messages
+	// of google/protobuf/wrappers.proto so that zero values can be distinguished
+	// from absent ones (default: false).
+	WrapperTypes bool `yaml:"wrapper_types"`
+	// If true, properties that hold named values, such as paths and definitions,
+	// are generated as map<string, X> fields instead of repeated fields of
+	// NamedX pairs. Map fields are easier to use but don't preserve the order
+	// of values in source documents (default: false).
+	MapFields bool `yaml:"map_fields"`
+	// Additional packages to import from the generated Go file, such as those used by templates.
+	GoImports []string `yaml:"go_imports"`
+	// Templates whose output is appended to the generated Go file.
+	GoTemplates []string `yaml:"go_templates"`
+	// Templates whose output is appended to the generated .proto file.
+	ProtoTemplates []string `yaml:"proto_templates"`
+	// Commands that are run on each generated file after it is written;
+	// the file name is added as the last argument of each command.
+	PostProcess []string `yaml:"post_process"`
+
+	// OpenAPI version ("v2" or "v3"); only used when generating OpenAPI models.
+	version string
+}
+
+// Reads a model generator configuration from a YAML file.
+func NewModelConfigFromFile(filename string) (*ModelConfig, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	config := &ModelConfig{}
+	err = yaml.Unmarshal(bytes, config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Schema == "" {
+		return nil, errors.New(fmt.Sprintf("%s: no schema specified", filename))
+	}
+	// make paths relative to the configuration file
+	dir := filepath.Dir(filename)
+	paths := []*string{&config.Schema, &config.OutDir, &config.License, &config.FieldNumbers}
+	for i := range config.GoTemplates {
+		paths = append(paths, &config.GoTemplates[i])
+	}
+	for i := range config.ProtoTemplates {
+		paths = append(paths, &config.ProtoTemplates[i])
+	}
+	for _, p := range paths {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(dir, *p)
+		}
+	}
+	return config, nil
+}
+
+// Fills in default values for unspecified configuration settings.
+func (config *ModelConfig) setDefaults() {
+	if config.Name == "" {
+		config.Name = getBaseFileNameWithoutExt(config.Schema)
+	}
+	if config.ProtoPackage == "" {
+		config.ProtoPackage = strings.ToLower(strings.Replace(config.Name, "-", "_", -1))
+	}
+	if config.GoPackage == "" {
+		config.GoPackage = strings.Replace(config.ProtoPackage, ".", "_", -1)
+	}
+	if config.OutDir == "" {
+		config.OutDir = "."
+	}
+	if config.ProtoImports == nil {
+		config.ProtoImports = []string{"google/protobuf/any.proto"}
+	}
+	if config.FieldNumbers == "" {
+		config.FieldNumbers = path.Join(config.OutDir, config.Name+".fieldnumbers.yaml")
+	}
+}
+
+// GenerateModel generates a Protocol Buffer model and compiler for a JSON schema.
+func GenerateModel(config *ModelConfig) error {
+	config.setDefaults()
+
+	license := LICENSE
+	if config.License != "" {
+		bytes, err := ioutil.ReadFile(config.License)
+		if err != nil {
+			return err
+		}
+		license = string(bytes)
+	}
+
+	// the JSON Schema meta-schema is registered so that references to it can be resolved
+	base_schema, err := jsonschema.MetaSchema()
+	if err != nil {
+		return err
+	}
+	base_schema.ResolveRefs()
+	base_schema.ResolveAllOfs()
+
+	schema, err := jsonschema.NewSchemaFromFile(config.Schema)
+	if err != nil {
+		return err
+	}
+	schema.ResolveRefs()
+	schema.ResolveAllOfs()
+
+	// build a simplified model of the types described by the schema
+	cc := NewDomain(schema, config.version)
+	// generators will map these patterns to the associated property names
+	cc.PatternNames = config.PatternNames
+	// generated messages will reuse previously-assigned field numbers
+	cc.FieldNumbers, err = NewFieldNumbersFromFile(config.FieldNumbers)
+	if err != nil {
+		return err
+	}
+	cc.WrapperTypes = config.WrapperTypes
+	cc.MapFields = config.MapFields
+	err = cc.Build()
+	if err != nil {
+		return err
+	}
+
+	if true {
+		log.Printf("Type Model:\n%s", cc.Description())
+	}
+
+	// ensure that the target directory exists
+	err = os.MkdirAll(config.OutDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	protoImports := config.ProtoImports
+	goImports := []string{
+		"fmt",
+		"gopkg.in/yaml.v3",
+		"strings",
+		"github.com/googleapis/gnostic/compiler",
+		"github.com/golang/protobuf/jsonpb",
+		"github.com/googleapis/gnostic/jsonwriter",
+	}
+	if cc.usesWrapperTypes() {
+		protoImports = append(protoImports, "google/protobuf/wrappers.proto")
+		goImports = append(goImports, "github.com/golang/protobuf/ptypes/wrappers")
+	}
+	if cc.usesMapFields() {
+		goImports = append(goImports, "sort")
+	}
+	if cc.usesRepeatedMessages() {
+		goImports = append(goImports, "strconv")
+	}
+	if len(cc.keyPatterns()) > 0 {
+		goImports = append(goImports, "regexp")
+	}
+	goImports = append(goImports, config.GoImports...)
+
+	// generate the protocol buffer description
+	proto := cc.GenerateProto(config.ProtoPackage, license, config.ProtoOptions, protoImports)
+	extraProto, err := cc.executeTemplates(config.ProtoTemplates, config.ProtoPackage)
+	if err != nil {
+		return err
+	}
+	proto += extraProto
+	proto_filename := path.Join(config.OutDir, config.Name+".proto")
+	err = ioutil.WriteFile(proto_filename, []byte(proto), 0644)
+	if err != nil {
+		return err
+	}
+	err = cc.FieldNumbers.WriteToFile(config.FieldNumbers)
+	if err != nil {
+		return err
+	}
+
+	// generate the compiler
+	compiler := cc.GenerateCompiler(config.GoPackage, license, goImports)
+	extraGo, err := cc.executeTemplates(config.GoTemplates, config.GoPackage)
+	if err != nil {
+		return err
+	}
+	compiler += extraGo
+	go_filename := path.Join(config.OutDir, config.Name+".go")
+	err = ioutil.WriteFile(go_filename, []byte(compiler), 0644)
+	if err != nil {
+		return err
+	}
+	// format the compiler
+	err = exec.Command(runtime.GOROOT()+"/bin/gofmt", "-w", go_filename).Run()
+	if err != nil {
+		return err
+	}
+
+	// run any post-processing commands on the generated files
+	for _, filename := range []string{proto_filename, go_filename} {
+		err = runPostProcessors(config.PostProcess, filename)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ProcessModelGenCommandline(usage string) error {
+	configFile := ""
+	for i, arg := range os.Args {
+		if i == 0 {
+			continue // skip the tool name
+		}
+		if arg == "--config" {
+			continue
+		} else if arg[0] == '-' {
+			return errors.New(fmt.Sprintf("Unknown option: %s.\n%s", arg, usage))
+		} else {
+			configFile = arg
+		}
+	}
+	if configFile == "" {
+		return errors.New(fmt.Sprintf("No configuration file specified.\n%s", usage))
+	}
+	config, err := NewModelConfigFromFile(configFile)
+	if err != nil {
+		return err
+	}
+	return GenerateModel(config)
+}
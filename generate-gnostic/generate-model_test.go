@@ -0,0 +1,83 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// Generated compilers describe oneof types in comments that include pointers.
+var pointers = regexp.MustCompile(`0x[0-9a-f]+`)
+
+// Generates a model for test/model/pets.json with a configuration and returns
+// the generated .proto and .go files.
+func generateTestModel(t *testing.T, config *ModelConfig) (string, string) {
+	outDir, err := ioutil.TempDir("", "model")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	defer os.RemoveAll(outDir)
+	if config.Schema == "" {
+		config.Schema = "test/model/pets.json"
+	}
+	config.OutDir = outDir
+	if err := GenerateModel(config); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	proto, err := ioutil.ReadFile(path.Join(outDir, config.Name+".proto"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	code, err := ioutil.ReadFile(path.Join(outDir, config.Name+".go"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	return string(proto), string(code)
+}
+
+func TestModelConfig(t *testing.T) {
+	config, err := NewModelConfigFromFile("test/model/pets.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	config.setDefaults()
+	// paths are relative to the configuration file
+	for _, test := range []struct{ got, expected string }{
+		{config.Schema, filepath.Join("test", "model", "pets.json")},
+		{config.OutDir, filepath.Join("test", "model", "out")},
+		{config.Name, "PetStore"},
+		{config.ProtoPackage, "pet.store"},
+		{config.GoPackage, "pet_store"},
+		{config.FieldNumbers, filepath.Join("test", "model", "out", "PetStore.fieldnumbers.yaml")},
+	} {
+		if test.got != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, test.got)
+		}
+	}
+	if _, err := NewModelConfigFromFile("test/model/missing.yaml"); err == nil {
+		t.Errorf("Expected an error reading a missing configuration")
+	}
+}
+
+func TestGenerateModel(t *testing.T) {
+	config, err := NewModelConfigFromFile("test/model/pets.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	proto, code := generateTestModel(t, config)
+	for _, test := range []struct{ got, filename string }{
+		{proto, "test/model/PetStore.proto.out"},
+		{code, "test/model/PetStore.go.out"},
+	} {
+		expected, err := ioutil.ReadFile(test.filename)
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		if pointers.ReplaceAllString(test.got, "0x0") != string(expected) {
+			t.Errorf("Generated code doesn't match %s", test.filename)
+		}
+	}
+}
@@ -71,13 +71,12 @@ func (domain *Domain) GenerateProto(packageName string, license string, options
 			code.Print("oneof oneof {")
 			code.Indent()
 		}
-		var fieldNumber = 0
+		fieldNames := make([]string, 0)
 		for _, propertyModel := range typeModel.Properties {
 			if propertyModel.Description != "" {
 				code.Print("// %s", propertyModel.Description)
 			}
 			propertyName := propertyModel.Name
-			fieldNumber += 1
 			propertyType := propertyModel.Type
 			if propertyType == "int" {
 				propertyType = "int64"
@@ -90,6 +89,9 @@ func (domain *Domain) GenerateProto(packageName string, license string, options
 			if propertyType == "blob" {
 				propertyType = "string"
 			}
+			if wrapperType := domain.wrapperTypeForProperty(typeModel, propertyModel); wrapperType != "" {
+				propertyType = "google.protobuf." + wrapperType
+			}
 
 			var displayName = propertyName
 			if displayName == "$ref" {
@@ -99,9 +101,13 @@ func (domain *Domain) GenerateProto(packageName string, license string, options
 				displayName = "_schema"
 			}
 			displayName = camelCaseToSnakeCase(displayName)
+			fieldNames = append(fieldNames, displayName)
+			fieldNumber := domain.FieldNumbers.numberForField(typeName, displayName)
 
 			var line = fmt.Sprintf("%s %s = %d;", propertyType, displayName, fieldNumber)
-			if propertyModel.Repeated {
+			if domain.usesMapField(propertyModel) {
+				line = fmt.Sprintf("map<string, %s> %s = %d;", propertyModel.MapType, displayName, fieldNumber)
+			} else if propertyModel.Repeated {
 				line = "repeated " + line
 			}
 			code.Print(line)
@@ -110,6 +116,14 @@ func (domain *Domain) GenerateProto(packageName string, license string, options
 			code.Outdent()
 			code.Print("}")
 		}
+		// numbers of removed fields must not be reused
+		if reserved := domain.FieldNumbers.reservedNumbers(typeName, fieldNames); len(reserved) > 0 {
+			numbers := make([]string, 0)
+			for _, number := range reserved {
+				numbers = append(numbers, fmt.Sprintf("%d", number))
+			}
+			code.Print("reserved %s;", strings.Join(numbers, ", "))
+		}
 		code.Outdent()
 		code.Print("}")
 		code.Print()
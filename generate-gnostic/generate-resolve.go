@@ -0,0 +1,143 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Returns true if any type in the domain has a repeated message property.
+// Resolvers use strconv to parse the indexes of these properties.
+func (domain *Domain) usesRepeatedMessages() bool {
+	for _, typeModel := range domain.TypeModels {
+		for _, propertyModel := range typeModel.Properties {
+			if _, typeFound := domain.TypeModels[propertyModel.Type]; typeFound &&
+				propertyModel.Repeated && propertyModel.MapType == "" && !typeModel.OneOfWrapper {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Generates the ResolveReference() method of a Document, which finds the
+// message that a reference refers to and follows any references that it
+// finds there.
+func (domain *Domain) generateResolveReferenceMethod(code *printer.Code) {
+	code.Print("// ResolveReference returns the message at the location in a Document that a")
+	code.Print("// reference refers to, such as \"#/components/schemas/Pet\". If that message")
+	code.Print("// is itself a reference, the reference is followed. Circular references and")
+	code.Print("// references to other files are errors.")
+	code.Print("func (m *Document) ResolveReference(ref string) (interface{}, error) {")
+	code.Print("seen := make(map[string]bool)")
+	code.Print("for {")
+	code.Print("  if seen[ref] {")
+	code.Print("    return nil, fmt.Errorf(\"unable to resolve %%s, the reference is circular\", ref)")
+	code.Print("  }")
+	code.Print("  seen[ref] = true")
+	code.Print("  tokens, err := compiler.ReferenceTokens(ref)")
+	code.Print("  if err != nil {")
+	code.Print("    return nil, err")
+	code.Print("  }")
+	code.Print("  target, ok := m.resolve(tokens)")
+	code.Print("  if !ok {")
+	code.Print("    return nil, fmt.Errorf(\"unable to resolve %%s, there is nothing at that location\", ref)")
+	code.Print("  }")
+	code.Print("  if reference, ok := target.(interface{ GetXRef() string }); ok && reference.GetXRef() != \"\" {")
+	code.Print("    ref = reference.GetXRef()")
+	code.Print("    continue")
+	code.Print("  }")
+	code.Print("  return target, nil")
+	code.Print("}")
+	code.Print("}\n")
+}
+
+// Generates resolve() methods, which return the message at the location of
+// the segments of a JSON Pointer within a message. Oneof wrappers resolve
+// to the messages that they contain.
+func (domain *Domain) generateResolveMethodsForType(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	if typeModel.IsPair {
+		return
+	}
+	if typeName == "Document" {
+		domain.generateResolveReferenceMethod(code)
+	}
+	code.Print("func (m *%s) resolve(tokens []string) (interface{}, bool) {", typeName)
+	code.Print("if m == nil {")
+	code.Print("  return nil, false")
+	code.Print("}")
+	skipped := typeModel.IsStringArray || typeModel.IsBlob || typeName == "StringArray" ||
+		typeName == "Primitive" || typeName == "SpecificationExtension"
+	if typeModel.OneOfWrapper && !skipped {
+		for _, propertyModel := range typeModel.Properties {
+			if _, typeFound := domain.TypeModels[propertyModel.Type]; typeFound {
+				code.Print("if x, ok := m.Oneof.(*%s_%s); ok {", typeName, propertyModel.Type)
+				code.Print("  return x.%s.resolve(tokens)", propertyModel.Type)
+				code.Print("}")
+			}
+		}
+	}
+	code.Print("if len(tokens) == 0 {")
+	code.Print("  return m, true")
+	code.Print("}")
+	if !typeModel.OneOfWrapper && !skipped {
+		messageProperties := make([]*TypeProperty, 0)
+		for _, propertyModel := range typeModel.Properties {
+			if _, typeFound := domain.TypeModels[propertyModel.Type]; typeFound && propertyModel.MapType == "" {
+				messageProperties = append(messageProperties, propertyModel)
+			}
+		}
+		if len(messageProperties) > 0 {
+			code.Print("switch tokens[0] {")
+			for _, propertyModel := range messageProperties {
+				fieldName := propertyModel.FieldName()
+				code.Print("case \"%s\":", propertyModel.Name)
+				if propertyModel.Repeated {
+					code.Print("  if len(tokens) > 1 {")
+					code.Print("    if i, err := strconv.Atoi(tokens[1]); err == nil && i >= 0 && i < len(m.%s) {", fieldName)
+					code.Print("      return m.%s[i].resolve(tokens[2:])", fieldName)
+					code.Print("    }")
+					code.Print("  }")
+				} else {
+					code.Print("  return m.%s.resolve(tokens[1:])", fieldName)
+				}
+			}
+			code.Print("}")
+		}
+		for _, propertyModel := range typeModel.Properties {
+			if propertyModel.MapType == "" || propertyModel.MapType == "string" {
+				continue
+			}
+			fieldName := propertyModel.FieldName()
+			if domain.usesMapField(propertyModel) {
+				code.Print("if value, ok := m.%s[tokens[0]]; ok {", fieldName)
+				code.Print("  return value.resolve(tokens[1:])")
+				code.Print("}")
+			} else if propertyTypeModel, typeFound := domain.TypeModels[propertyModel.Type]; typeFound && propertyTypeModel.IsPair {
+				if _, valueTypeFound := domain.TypeModels[propertyTypeModel.PairValueType]; !valueTypeFound {
+					continue
+				}
+				code.Print("for _, pair := range m.%s {", fieldName)
+				code.Print("  if pair.Name == tokens[0] {")
+				code.Print("    return pair.Value.resolve(tokens[1:])")
+				code.Print("  }")
+				code.Print("}")
+			}
+		}
+	}
+	code.Print("return nil, false")
+	code.Print("}\n")
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// TemplateData is the data that user-supplied templates are executed with.
+type TemplateData struct {
+	Package string       // the package of the generated file
+	Types   []*TypeModel // the types of the domain, sorted by name
+	Domain  *Domain      // the domain that is being generated
+}
+
+// Functions that are available to user-supplied templates.
+var templateFunctions = template.FuncMap{
+	"snakeCase": camelCaseToSnakeCase,
+	"title":     strings.Title,
+	"lower":     strings.ToLower,
+}
+
+// Executes user-supplied templates and returns their combined output.
+// Templates are executed in the order that they are listed, and each one
+// can use the functions in templateFunctions.
+func (domain *Domain) executeTemplates(filenames []string, packageName string) (string, error) {
+	data := &TemplateData{Package: packageName, Domain: domain}
+	for _, typeName := range domain.sortedTypeNames() {
+		data.Types = append(data.Types, domain.TypeModels[typeName])
+	}
+	var buffer bytes.Buffer
+	for _, filename := range filenames {
+		t, err := template.New(filepath.Base(filename)).Funcs(templateFunctions).ParseFiles(filename)
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString("\n")
+		err = t.Execute(&buffer, data)
+		if err != nil {
+			return "", err
+		}
+	}
+	return buffer.String(), nil
+}
+
+// Runs post-processing commands on a generated file.
+// Each command is split into words and the name of the file is added as its
+// last argument. Commands are run in order and the first failure stops processing.
+func runPostProcessors(commands []string, filename string) error {
+	for _, command := range commands {
+		words := strings.Fields(command)
+		if len(words) == 0 {
+			continue
+		}
+		cmd := exec.Command(words[0], append(words[1:], filename)...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return errors.New(fmt.Sprintf("post-processing %s with \"%s\" failed: %s\n%s",
+				filename, command, err.Error(), string(output)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerateModelWithTemplates(t *testing.T) {
+	proto, code := generateTestModel(t, &ModelConfig{
+		Name:           "PetStore",
+		GoTemplates:    []string{"test/model/names.go.tmpl"},
+		ProtoTemplates: []string{"test/model/names.proto.tmpl"},
+		// sed -i.bak works the same way with GNU and BSD sed
+		PostProcess: []string{"sed -i.bak s/AUTOMATICALLY/ALSO-AUTOMATICALLY/"},
+	})
+	// template output is appended to the generated files, which are formatted before they are post-processed
+	expected := `// TypeNames returns the names of the types in the petstore package.
+func TypeNames() []string {
+	return []string{"Any", "Document", "NamedAny", "NamedPetOrReference", "Owner", "Pet", "PetOrReference", "Pets", "Reference", "StringArray"}
+}
+`
+	if !strings.HasSuffix(code, expected) {
+		t.Errorf("Expected generated code to end with:\n%s", expected)
+	}
+	if !strings.HasSuffix(proto, "\n// Types: any document named_any named_pet_or_reference owner pet pet_or_reference pets reference string_array \n") {
+		t.Errorf("Unexpected proto:\n%s", proto)
+	}
+	for _, text := range []string{proto, code} {
+		if !strings.Contains(text, "// THIS FILE IS ALSO-AUTOMATICALLY GENERATED.") {
+			t.Errorf("Expected generated files to be post-processed")
+		}
+	}
+}
+
+func TestGenerateModelWithFailures(t *testing.T) {
+	outDir, err := ioutil.TempDir("", "model")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	defer os.RemoveAll(outDir)
+	for _, test := range []struct {
+		config  *ModelConfig
+		message string
+	}{
+		{&ModelConfig{PostProcess: []string{"false"}}, `with "false" failed`},
+		{&ModelConfig{GoTemplates: []string{"test/model/missing.tmpl"}}, "missing.tmpl"},
+	} {
+		test.config.Schema = "test/model/pets.json"
+		test.config.OutDir = outDir
+		if err := GenerateModel(test.config); err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("Expected an error containing %q, got %v", test.message, err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Returns the names of the types that can be visited by a walker.
+func (domain *Domain) visitableTypeNames() []string {
+	typeNames := make([]string, 0)
+	for _, typeName := range domain.sortedTypeNames() {
+		if !domain.TypeModels[typeName].IsPair {
+			typeNames = append(typeNames, typeName)
+		}
+	}
+	return typeNames
+}
+
+// Generates the Visitor type, which holds a callback for each visitable type.
+func (domain *Domain) generateVisitor(code *printer.Code) {
+	code.Print("// Visitor holds callbacks that are called by Walk() for the messages")
+	code.Print("// of each type. Callbacks are optional; a nil callback is skipped.")
+	code.Print("// Each callback receives a message and its path, which is written in")
+	code.Print("// the form used in compiler error messages. If a callback returns false,")
+	code.Print("// the messages that the visited message contains are not visited.")
+	code.Print("type Visitor struct {")
+	for _, typeName := range domain.visitableTypeNames() {
+		code.Print("  Visit%s func(m *%s, path string) bool", typeName, typeName)
+	}
+	code.Print("}\n")
+}
+
+// Generates Walk() methods, which visit a message and the messages that it contains.
+func (domain *Domain) generateWalkMethodsForType(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	if typeModel.IsPair {
+		return
+	}
+	code.Print("// Walk visits a %s and all of the messages that it contains, in depth-first order.", typeName)
+	code.Print("func (m *%s) Walk(v *Visitor) {", typeName)
+	code.Print("  m.walk(v, \"$root\")")
+	code.Print("}\n")
+
+	code.Print("func (m *%s) walk(v *Visitor, path string) {", typeName)
+	code.Print("if m == nil {")
+	code.Print("  return")
+	code.Print("}")
+	code.Print("if v.Visit%s != nil && !v.Visit%s(m, path) {", typeName, typeName)
+	code.Print("  return")
+	code.Print("}")
+	if !(typeModel.IsStringArray || typeModel.IsBlob || typeName == "StringArray" ||
+		typeName == "Primitive" || typeName == "SpecificationExtension") {
+		for _, propertyModel := range typeModel.Properties {
+			propertyName := propertyModel.Name
+			fieldName := propertyModel.FieldName()
+			propertyTypeModel, typeFound := domain.TypeModels[propertyModel.Type]
+			if typeFound && !propertyTypeModel.IsPair {
+				if typeModel.OneOfWrapper {
+					code.Print("if x, ok := m.Oneof.(*%s_%s); ok {", typeName, propertyModel.Type)
+					code.Print("  x.%s.walk(v, path+\".%s\")", propertyModel.Type, propertyName)
+					code.Print("}")
+				} else if propertyModel.Repeated {
+					code.Print("for i, item := range m.%s {", fieldName)
+					code.Print("  item.walk(v, fmt.Sprintf(\"%%s.%s[%%d]\", path, i))", propertyName)
+					code.Print("}")
+				} else {
+					code.Print("m.%s.walk(v, path+\".%s\")", fieldName, propertyName)
+				}
+			} else if propertyModel.MapType != "" && propertyModel.MapType != "string" && domain.usesMapField(propertyModel) {
+				code.Print("{")
+				generateSortedKeysForMapField(code, "m."+fieldName)
+				code.Print("for _, k := range keys {")
+				code.Print("  m.%s[k].walk(v, path+\".\"+k)", fieldName)
+				code.Print("}")
+				code.Print("}")
+			} else if propertyModel.MapType != "" && propertyModel.MapType != "string" {
+				code.Print("for _, pair := range m.%s {", fieldName)
+				code.Print("  pair.Value.walk(v, path+\".\"+pair.Name)")
+				code.Print("}")
+			}
+		}
+	}
+	code.Print("}\n")
+}
+
+// Generates the Rewriter type, which holds a callback for each type.
+func (domain *Domain) generateRewriter(code *printer.Code) {
+	code.Print("// Rewriter holds callbacks that are called by Rewrite() for the messages")
+	code.Print("// of each type. Callbacks are optional; a nil callback keeps its messages.")
+	code.Print("// Each callback receives a message, after the messages that it contains")
+	code.Print("// have been rewritten, and its path, and returns the message that replaces")
+	code.Print("// it. A callback can return the message that it received, modified or not,")
+	code.Print("// or a new message. If it returns nil, the message is removed; messages in")
+	code.Print("// lists and named values are removed from their lists. Callbacks for")
+	code.Print("// \"NamedX\" pairs can rename values by returning pairs with other names.")
+	code.Print("type Rewriter struct {")
+	for _, typeName := range domain.sortedTypeNames() {
+		code.Print("  Rewrite%s func(m *%s, path string) *%s", typeName, typeName, typeName)
+	}
+	code.Print("}\n")
+}
+
+// Generates Rewrite() methods, which replace the messages in a model with the
+// messages returned by the callbacks of a Rewriter.
+func (domain *Domain) generateRewriteMethodsForType(code *printer.Code, typeName string) {
+	typeModel := domain.TypeModels[typeName]
+	if !typeModel.IsPair {
+		code.Print("// Rewrite rewrites a %s and all of the messages that it contains, in", typeName)
+		code.Print("// depth-first order, and returns the message that replaces it. Messages are")
+		code.Print("// modified in place; use Clone() first to keep the original model.")
+		code.Print("func (m *%s) Rewrite(r *Rewriter) *%s {", typeName, typeName)
+		code.Print("  return m.rewrite(r, \"$root\")")
+		code.Print("}\n")
+	}
+
+	code.Print("func (m *%s) rewrite(r *Rewriter, path string) *%s {", typeName, typeName)
+	code.Print("if m == nil {")
+	code.Print("  return nil")
+	code.Print("}")
+	if typeModel.IsPair {
+		// the value of a pair has the path of the pair, and a pair is removed with its value
+		if _, typeFound := domain.TypeModels[typeModel.PairValueType]; typeFound {
+			code.Print("if m.Value != nil {")
+			code.Print("  if m.Value = m.Value.rewrite(r, path); m.Value == nil {")
+			code.Print("    return nil")
+			code.Print("  }")
+			code.Print("}")
+		}
+	} else if !(typeModel.IsStringArray || typeModel.IsBlob || typeName == "StringArray" ||
+		typeName == "Primitive" || typeName == "SpecificationExtension") {
+		for _, propertyModel := range typeModel.Properties {
+			propertyName := propertyModel.Name
+			fieldName := propertyModel.FieldName()
+			propertyTypeModel, typeFound := domain.TypeModels[propertyModel.Type]
+			if typeFound && typeModel.OneOfWrapper {
+				code.Print("if x, ok := m.Oneof.(*%s_%s); ok {", typeName, propertyModel.Type)
+				code.Print("  if x.%s = x.%s.rewrite(r, path+\".%s\"); x.%s == nil {", propertyModel.Type, propertyModel.Type, propertyName, propertyModel.Type)
+				code.Print("    m.Oneof = nil")
+				code.Print("  }")
+				code.Print("}")
+			} else if propertyModel.MapType != "" && propertyModel.MapType != "string" && domain.usesMapField(propertyModel) {
+				code.Print("{")
+				generateSortedKeysForMapField(code, "m."+fieldName)
+				code.Print("for _, k := range keys {")
+				code.Print("  if value := m.%s[k].rewrite(r, path+\".\"+k); value != nil {", fieldName)
+				code.Print("    m.%s[k] = value", fieldName)
+				code.Print("  } else {")
+				code.Print("    delete(m.%s, k)", fieldName)
+				code.Print("  }")
+				code.Print("}")
+				code.Print("}")
+			} else if typeFound && propertyTypeModel.IsPair {
+				code.Print("if m.%s != nil {", fieldName)
+				code.Print("  pairs := m.%s[:0]", fieldName)
+				code.Print("  for _, pair := range m.%s {", fieldName)
+				code.Print("    if pair = pair.rewrite(r, path+\".\"+pair.GetName()); pair != nil {")
+				code.Print("      pairs = append(pairs, pair)")
+				code.Print("    }")
+				code.Print("  }")
+				code.Print("  m.%s = pairs", fieldName)
+				code.Print("}")
+			} else if typeFound && propertyModel.Repeated {
+				code.Print("if m.%s != nil {", fieldName)
+				code.Print("  items := m.%s[:0]", fieldName)
+				code.Print("  for i, item := range m.%s {", fieldName)
+				code.Print("    if item = item.rewrite(r, fmt.Sprintf(\"%%s.%s[%%d]\", path, i)); item != nil {", propertyName)
+				code.Print("      items = append(items, item)")
+				code.Print("    }")
+				code.Print("  }")
+				code.Print("  m.%s = items", fieldName)
+				code.Print("}")
+			} else if typeFound {
+				code.Print("m.%s = m.%s.rewrite(r, path+\".%s\")", fieldName, fieldName, propertyName)
+			}
+		}
+	}
+	code.Print("if r.Rewrite%s != nil {", typeName)
+	code.Print("  return r.Rewrite%s(m, path)", typeName)
+	code.Print("}")
+	code.Print("return m")
+	code.Print("}\n")
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Returns the patterns that map keys are matched against in generated compilers.
+func (domain *Domain) keyPatterns() []string {
+	patterns := make([]string, 0)
+	seen := make(map[string]bool, 0)
+	add := func(pattern string) {
+		if pattern != "" && !seen[pattern] {
+			seen[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+	for _, typeModel := range domain.TypeModels {
+		for _, pattern := range typeModel.OpenPatterns {
+			add(pattern)
+		}
+		for _, propertyModel := range typeModel.Properties {
+			add(propertyModel.Pattern)
+		}
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// Returns the name of the variable that holds a compiled key pattern.
+func (domain *Domain) keyPatternVariable(pattern string) string {
+	for i, keyPattern := range domain.keyPatterns() {
+		if keyPattern == pattern {
+			return fmt.Sprintf("pattern%d", i)
+		}
+	}
+	panic("unknown key pattern: " + pattern)
+}
+
+// Generates variables holding the key patterns, which are compiled once
+// when the package is initialized instead of each time that they are used.
+func (domain *Domain) generateKeyPatterns(code *printer.Code) {
+	patterns := domain.keyPatterns()
+	if len(patterns) == 0 {
+		return
+	}
+	code.Print("// Patterns that are matched against the keys of maps.")
+	code.Print("var (")
+	for i, pattern := range patterns {
+		if expression := compiler.PatternExpression(pattern); expression != pattern {
+			code.Print("  pattern%d = regexp.MustCompile(%q) // %s", i, expression, pattern)
+		} else {
+			code.Print("  pattern%d = regexp.MustCompile(%q)", i, expression)
+		}
+	}
+	code.Print(")\n")
+}
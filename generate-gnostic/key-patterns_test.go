@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/printer"
+)
+
+func TestKeyPatterns(t *testing.T) {
+	domain := &Domain{TypeModels: map[string]*TypeModel{
+		"Paths": {
+			OpenPatterns: []string{"^x-"},
+			Properties:   []*TypeProperty{{Name: "path", Pattern: "^/"}},
+		},
+		"Callback": {
+			OpenPatterns: []string{"^x-"},
+			Properties:   []*TypeProperty{{Name: "path", Pattern: "{expression}"}, {Name: "name"}},
+		},
+	}}
+	code := &printer.Code{}
+	domain.generateKeyPatterns(code)
+	// patterns are compiled once each, in sorted order
+	expected := `// Patterns that are matched against the keys of maps.
+var (
+  pattern0 = regexp.MustCompile("^/")
+  pattern1 = regexp.MustCompile("^x-")
+  pattern2 = regexp.MustCompile(".*") // {expression}
+)
+
+`
+	if code.String() != expected {
+		t.Errorf("Unexpected patterns:\n%s\nexpected:\n%s", code.String(), expected)
+	}
+	if variable := domain.keyPatternVariable("{expression}"); variable != "pattern2" {
+		t.Errorf("Unexpected variable %s", variable)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for an unknown pattern")
+		}
+	}()
+	domain.keyPatternVariable("^[0-9]+$")
+}
+
+func TestGenerateModelKeyPatterns(t *testing.T) {
+	_, code := generateTestModel(t, &ModelConfig{Name: "PetStore", PatternNames: map[string]string{"^x-": "vendorExtension"}})
+	for _, expected := range []string{
+		"pattern0 = regexp.MustCompile(\"^x-\")",
+		"allowedPatterns := []*regexp.Regexp{pattern0}",
+		"if pattern0.MatchString(k) {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Expected generated code:\n%s", expected)
+		}
+	}
+}
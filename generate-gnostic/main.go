@@ -21,15 +21,9 @@ package main
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"os"
-	"os/exec"
 	"path"
-	"runtime"
 	"strings"
-
-	"github.com/googleapis/gnostic/jsonschema"
 )
 
 const LICENSE = "" +
@@ -109,75 +103,35 @@ func GenerateOpenAPIModel(version string) error {
 
 	project_root := os.Getenv("GOPATH") + "/src/github.com/googleapis/gnostic/"
 
-	base_schema, err := jsonschema.NewSchemaFromFile(project_root + "jsonschema/schema.json")
-	if err != nil {
-		return err
-	}
-	base_schema.ResolveRefs()
-	base_schema.ResolveAllOfs()
-
-	openapi_schema, err := jsonschema.NewSchemaFromFile(project_root + filename + "/" + input)
-	if err != nil {
-		return err
-	}
-	openapi_schema.ResolveRefs()
-	openapi_schema.ResolveAllOfs()
-
-	// build a simplified model of the types described by the schema
-	cc := NewDomain(openapi_schema, version)
-	// generators will map these patterns to the associated property names
-	// these pattern names are a bit of a hack until we find a more automated way to obtain them
-	cc.PatternNames = map[string]string{
-		"^x-": extension_name,
-		// v2
-		"^/": "path",
-		"^([0-9]{3})$|^(default)$": "responseCode",
-		// v3
-		"^([0-9]{3})$": "responseCode",
-		"{property}":   "property",
-		"{name}":       "name",
-		"{expression}": "expression",
-		"/{path}":      "path",
-		"{media-type}": "mediaType",
-	}
-	err = cc.Build()
-	if err != nil {
-		return err
-	}
-
-	if true {
-		log.Printf("Type Model:\n%s", cc.Description())
-	}
-
-	// ensure that the target directory exists
-	err = os.MkdirAll(filename, 0755)
-	if err != nil {
-		return err
-	}
-
-	// generate the protocol buffer description
-	proto := cc.GenerateProto(proto_packagename, LICENSE,
-		proto_options(go_packagename), []string{"google/protobuf/any.proto"})
-	proto_filename := project_root + filename + "/" + filename + ".proto"
-	err = ioutil.WriteFile(proto_filename, []byte(proto), 0644)
-	if err != nil {
-		return err
-	}
-
-	// generate the compiler
-	compiler := cc.GenerateCompiler(go_packagename, LICENSE, []string{
-		"fmt",
-		"gopkg.in/yaml.v2",
-		"strings",
-		"github.com/googleapis/gnostic/compiler",
-	})
-	go_filename := project_root + filename + "/" + filename + ".go"
-	err = ioutil.WriteFile(go_filename, []byte(compiler), 0644)
-	if err != nil {
-		return err
+	config := &ModelConfig{
+		Schema:       project_root + filename + "/" + input,
+		Name:         filename,
+		ProtoPackage: proto_packagename,
+		GoPackage:    go_packagename,
+		OutDir:       project_root + filename,
+		// generators will map these patterns to the associated property names
+		// these pattern names are a bit of a hack until we find a more automated way to obtain them
+		PatternNames: map[string]string{
+			"^x-": extension_name,
+			// v2
+			"^/": "path",
+			"^([0-9]{3})$|^(default)$": "responseCode",
+			// v3
+			"^([0-9X]{3})$": "responseCode",
+			"{property}":    "property",
+			"{name}":        "name",
+			"{expression}":  "expression",
+			"/{path}":       "path",
+			"{media-type}":  "mediaType",
+		},
+		ProtoOptions: proto_options(go_packagename),
+		ProtoImports: []string{"google/protobuf/any.proto"},
+		// optional numbers and booleans are wrapped so that zero values can be
+		// distinguished from absent ones
+		WrapperTypes: true,
+		version:      version,
 	}
-	// format the compiler
-	return exec.Command(runtime.GOROOT()+"/bin/gofmt", "-w", go_filename).Run()
+	return GenerateModel(config)
 }
 
 func usage() string {
@@ -198,12 +152,24 @@ Options:
     supported.
     EXTENSION_OPTIONS
       --out_dir=PATH: Location for writing extension models and support code.
+  --config CONFIG_FILE
+    Generate Protocol Buffer representation and support code for any format
+    that is described by a JSON schema. CONFIG_FILE is a YAML file that
+    names the schema and describes the code to generate (see README.md).
+  --go-types SCHEMA [GO_TYPES_OPTIONS]
+    Generate Go structs for the types described by an arbitrary JSON schema.
+    GO_TYPES_OPTIONS
+      --out_dir=PATH: Location for writing the generated Go file (default ".").
+      --package=NAME: Go package name for the generated file (default is
+                      derived from the schema file name).
 `, path.Base(os.Args[0]))
 }
 
 func main() {
 	var openapi_version = ""
 	var generate_extensions = false
+	var generate_go_types = false
+	var generate_model = false
 
 	for i, arg := range os.Args {
 		if i == 0 {
@@ -216,6 +182,12 @@ func main() {
 		} else if arg == "--extension" {
 			generate_extensions = true
 			break
+		} else if arg == "--go-types" {
+			generate_go_types = true
+			break
+		} else if arg == "--config" {
+			generate_model = true
+			break
 		} else {
 			fmt.Printf("Unknown option: %s.\n%s\n", arg, usage())
 			os.Exit(-1)
@@ -232,6 +204,16 @@ func main() {
 		if err != nil {
 			fmt.Printf("%+v\n", err)
 		}
+	} else if generate_go_types {
+		err := ProcessGoTypesGenCommandline(usage())
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+		}
+	} else if generate_model {
+		err := ProcessModelGenCommandline(usage())
+		if err != nil {
+			fmt.Printf("%+v\n", err)
+		}
 	} else {
 		fmt.Printf("%s\n", usage())
 	}
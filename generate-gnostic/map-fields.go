@@ -0,0 +1,62 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/googleapis/gnostic/printer"
+)
+
+// Returns true if a property is generated as a map field.
+// Properties that hold named values are normally generated as repeated
+// fields of "NamedX" pairs, which preserve the order of the values in
+// source documents. When map fields are enabled, these properties are
+// generated as map<string, X> fields, which are easier to use but lose
+// the source ordering; values are written in sorted order instead.
+func (domain *Domain) usesMapField(propertyModel *TypeProperty) bool {
+	if !domain.MapFields || propertyModel.MapType == "" {
+		return false
+	}
+	propertyTypeModel, typeFound := domain.TypeModels[propertyModel.Type]
+	return !typeFound || propertyTypeModel.IsPair
+}
+
+// Returns true if any property in the domain is generated as a map field.
+func (domain *Domain) usesMapFields() bool {
+	for _, typeModel := range domain.TypeModels {
+		for _, propertyModel := range typeModel.Properties {
+			if domain.usesMapField(propertyModel) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns the Go type of a map field.
+func mapFieldGoType(propertyModel *TypeProperty) string {
+	if propertyModel.MapType == "string" {
+		return "map[string]string"
+	}
+	return "map[string]*" + propertyModel.MapType
+}
+
+// Generates code that sets "keys" to the sorted keys of a map field.
+func generateSortedKeysForMapField(code *printer.Code, fieldExpression string) {
+	code.Print("keys := make([]string, 0, len(%s))", fieldExpression)
+	code.Print("for k := range %s {", fieldExpression)
+	code.Print("  keys = append(keys, k)")
+	code.Print("}")
+	code.Print("sort.Strings(keys)")
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateModelWithMapFields(t *testing.T) {
+	config := &ModelConfig{Name: "PetStore", MapFields: true, PatternNames: map[string]string{"^x-": "vendorExtension"}}
+	proto, code := generateTestModel(t, config)
+	for _, expected := range []string{
+		"map<string, Any> vendor_extension = 7;",
+		"message Pets {\n  map<string, PetOrReference> additional_properties = 1;\n}",
+	} {
+		if !strings.Contains(proto, expected) {
+			t.Errorf("Expected generated proto:\n%s", expected)
+		}
+	}
+	for _, expected := range []string{
+		`"sort"`,
+		// compilers fill maps
+		"x.AdditionalProperties = make(map[string]*PetOrReference, 0)",
+		"x.AdditionalProperties[pair.Name] = pair.Value",
+		// values are written in the order of their names
+		"for k := range m.AdditionalProperties {\n\t\t\tkeys = append(keys, k)\n\t\t}\n\t\tsort.Strings(keys)",
+		// builders and lookups use the maps directly
+		"\tm.AdditionalProperties[name] = value\n",
+		"\tdelete(m.AdditionalProperties, name)\n",
+		"if value, ok := m.AdditionalProperties[name]; ok {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Expected generated code:\n%s", expected)
+		}
+	}
+	// other repeated fields aren't changed
+	if !strings.Contains(proto, "repeated string tags = 6;") {
+		t.Errorf("Expected tags to be a repeated field")
+	}
+}
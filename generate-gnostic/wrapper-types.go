@@ -0,0 +1,58 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+// Returns the name of the well-known wrapper message that holds a property,
+// or "" if the property is represented with a scalar field.
+// When wrapper types are enabled, optional numeric and boolean fields are
+// wrapped so that a field that is set to zero or false can be distinguished
+// from one that is absent.
+func (domain *Domain) wrapperTypeForProperty(typeModel *TypeModel, propertyModel *TypeProperty) string {
+	if !domain.WrapperTypes || typeModel.OneOfWrapper || propertyModel.Repeated {
+		return ""
+	}
+	switch propertyModel.Type {
+	case "bool":
+		return "BoolValue"
+	case "int", "int64":
+		return "Int64Value"
+	case "float":
+		return "DoubleValue"
+	}
+	return ""
+}
+
+// Returns the name of the wrapper message that holds the property of a type
+// with the specified Go field name, or "" if it isn't wrapped.
+func (domain *Domain) wrapperTypeForField(typeModel *TypeModel, fieldName string) string {
+	for _, propertyModel := range typeModel.Properties {
+		if propertyModel.FieldName() == fieldName {
+			return domain.wrapperTypeForProperty(typeModel, propertyModel)
+		}
+	}
+	return ""
+}
+
+// Returns true if any property in the domain is held in a wrapper message.
+func (domain *Domain) usesWrapperTypes() bool {
+	for _, typeModel := range domain.TypeModels {
+		for _, propertyModel := range typeModel.Properties {
+			if domain.wrapperTypeForProperty(typeModel, propertyModel) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateModelWithWrapperTypes(t *testing.T) {
+	proto, code := generateTestModel(t, &ModelConfig{Name: "PetStore", WrapperTypes: true})
+	for _, expected := range []string{
+		`import "google/protobuf/wrappers.proto";`,
+		"message Pet {\n  string name = 1;\n  google.protobuf.Int64Value age = 2;\n  google.protobuf.DoubleValue weight = 3;\n  google.protobuf.BoolValue vaccinated = 4;\n}",
+		// repeated fields and the fields of oneofs aren't wrapped
+		"repeated string tags = 6;",
+		"oneof oneof {\n    Pet pet = 1;",
+	} {
+		if !strings.Contains(proto, expected) {
+			t.Errorf("Expected generated proto:\n%s", expected)
+		}
+	}
+	for _, expected := range []string{
+		`"github.com/golang/protobuf/ptypes/wrappers"`,
+		// compilers, builders, and writers use the values of wrappers
+		"x.Age = &wrappers.Int64Value{Value: t}",
+		"func (m *Pet) SetAge(value int64) *Pet {\n\tm.Age = &wrappers.Int64Value{Value: value}",
+		"if m.Age != nil {",
+		"compiler.NewScalarNodeForInt(m.Age.Value)",
+		// zero values are written when they are set
+		"if m.Vaccinated != nil {",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Errorf("Expected generated code:\n%s", expected)
+		}
+	}
+	// without the option, models don't use wrappers
+	proto, code = generateTestModel(t, &ModelConfig{Name: "PetStore"})
+	if strings.Contains(proto, "wrappers") || strings.Contains(code, "wrappers") {
+		t.Errorf("Unexpected wrapper types")
+	}
+}
@@ -33,14 +33,18 @@ package main
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -48,45 +52,91 @@ import (
 	"github.com/googleapis/gnostic/OpenAPIv3"
 	"github.com/googleapis/gnostic/compiler"
 	"github.com/googleapis/gnostic/jsonwriter"
+	gnostic "github.com/googleapis/gnostic/lib"
+	"github.com/googleapis/gnostic/linter"
 	plugins "github.com/googleapis/gnostic/plugins"
-	"gopkg.in/yaml.v2"
+	"github.com/googleapis/gnostic/transform"
+	"github.com/googleapis/gnostic/validator"
+	"gopkg.in/yaml.v3"
 )
 
 const ( // OpenAPI Version
-	OpenAPIvUnknown = 0
-	OpenAPIv2       = 2
-	OpenAPIv3       = 3
+	OpenAPIvUnknown = gnostic.OpenAPIvUnknown
+	OpenAPIv2       = gnostic.OpenAPIv2
+	OpenAPIv3       = gnostic.OpenAPIv3
 )
 
 // Determine the version of an OpenAPI description read from JSON or YAML.
-func getOpenAPIVersionFromInfo(info interface{}) int {
-	m, ok := compiler.UnpackMap(info)
-	if !ok {
-		return OpenAPIvUnknown
-	}
-	swagger, ok := compiler.MapValueForKey(m, "swagger").(string)
-	if ok && swagger == "2.0" {
-		return OpenAPIv2
+func getOpenAPIVersionFromInfo(info *yaml.Node) (int, error) {
+	return gnostic.DetectVersion(info)
+}
+
+// Determine the version of an OpenAPI description read from JSON
+// without building a tree of nodes for the entire description.
+// Only the fields that identify the version are decoded.
+func getOpenAPIVersionFromJSON(reader io.Reader) (int, error) {
+	decoder := json.NewDecoder(reader)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return gnostic.DetectVersion(nil)
 	}
-	openapi, ok := compiler.MapValueForKey(m, "openapi").(string)
-	if ok && openapi == "3.0" {
-		return OpenAPIv3
+	info := compiler.NewMappingNode()
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return OpenAPIvUnknown, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return OpenAPIvUnknown, err
+		}
+		if key == "swagger" || key == "openapi" {
+			// JSON is YAML, so the value is decoded with its YAML tag.
+			var node yaml.Node
+			if err = yaml.Unmarshal(value, &node); err != nil || len(node.Content) == 0 {
+				return OpenAPIvUnknown, err
+			}
+			info.Content = append(info.Content, compiler.NewScalarNodeForString(key), node.Content[0])
+			return gnostic.DetectVersion(info)
+		}
 	}
-	return OpenAPIvUnknown
+	return gnostic.DetectVersion(info)
 }
 
 const (
 	pluginPrefix    = "gnostic-"
 	extensionPrefix = "gnostic-x-"
+	builtinPrefix   = "builtin:"
 )
 
+// The number of referenced files that are read concurrently.
+const referenceReaders = 8
+
 type PluginCall struct {
 	Name       string
 	Invocation string
 }
 
-// Invokes a plugin.
-func (pluginCall *PluginCall) perform(document proto.Message, openAPIVersion int, sourceName string) error {
+// Returns the wrapper of a compiled model that is sent to plugins.
+func newWrapper(document proto.Message, openAPIVersion int, sourceName string) *plugins.Wrapper {
+	wrapper := &plugins.Wrapper{}
+	wrapper.Name = sourceName
+	switch openAPIVersion {
+	case OpenAPIv2:
+		wrapper.Version = "v2"
+	case OpenAPIv3:
+		wrapper.Version = "v3"
+	default:
+		wrapper.Version = "unknown"
+	}
+	protoBytes, _ := proto.Marshal(document)
+	wrapper.Value = protoBytes
+	return wrapper
+}
+
+// Invokes a plugin with a compiled model and its wrapper, which can be shared
+// by all of the plugins that are called. Returns the files that it produced.
+func (pluginCall *PluginCall) perform(document proto.Message, wrapper *plugins.Wrapper) ([]*plugins.File, error) {
 	if pluginCall.Name != "" {
 		request := &plugins.Request{}
 
@@ -106,7 +156,7 @@ func (pluginCall *PluginCall) perform(document proto.Message, openAPIVersion int
 		//
 		invocation_regex := regexp.MustCompile(`^([\w-_\/\.]+=[\w-_\/\.]+(,[\w-_\/\.]+=[\w-_\/\.]+)*:)?[^,:=]+$`)
 		if !invocation_regex.Match([]byte(pluginCall.Invocation)) {
-			return errors.New(fmt.Sprintf("Invalid invocation of %s: %s", executableName, invocation))
+			return nil, errors.New(fmt.Sprintf("Invalid invocation of %s: %s", executableName, invocation))
 		}
 
 		invocationParts := strings.Split(pluginCall.Invocation, ":")
@@ -136,36 +186,35 @@ func (pluginCall *PluginCall) perform(document proto.Message, openAPIVersion int
 
 		request.OutputPath = outputLocation
 
-		wrapper := &plugins.Wrapper{}
-		wrapper.Name = sourceName
-		switch openAPIVersion {
-		case OpenAPIv2:
-			wrapper.Version = "v2"
-		case OpenAPIv3:
-			wrapper.Version = "v3"
-		default:
-			wrapper.Version = "unknown"
-		}
-		protoBytes, _ := proto.Marshal(document)
-		wrapper.Value = protoBytes
 		request.Wrapper = wrapper
-		requestBytes, _ := proto.Marshal(request)
 
-		cmd := exec.Command(executableName)
-		cmd.Stdin = bytes.NewReader(requestBytes)
-		cmd.Stderr = os.Stderr
-		output, err := cmd.Output()
-		if err != nil {
-			return err
-		}
-		response := &plugins.Response{}
-		err = proto.Unmarshal(output, response)
-		if err != nil {
-			return err
+		var response *plugins.Response
+		if builtinName := strings.TrimPrefix(pluginCall.Name, builtinPrefix); builtinName != pluginCall.Name {
+			// Run a plugin that is built into gnostic.
+			plugin, ok := builtinPlugins[builtinName]
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("Unknown builtin plugin %s. %s are available.", builtinName, builtinPluginNames()))
+			}
+			response = plugin(request, document)
+		} else {
+			requestBytes, _ := proto.Marshal(request)
+
+			cmd := exec.Command(executableName)
+			cmd.Stdin = bytes.NewReader(requestBytes)
+			cmd.Stderr = os.Stderr
+			output, err := cmd.Output()
+			if err != nil {
+				return nil, err
+			}
+			response = &plugins.Response{}
+			err = proto.Unmarshal(output, response)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		if response.Errors != nil {
-			return errors.New(fmt.Sprintf("Plugin error: %+v", response.Errors))
+			return nil, errors.New(fmt.Sprintf("Plugin error: %+v", response.Errors))
 		}
 
 		// Write files to the specified directory.
@@ -179,7 +228,7 @@ func (pluginCall *PluginCall) perform(document proto.Message, openAPIVersion int
 				writer.Write(file.Data)
 			}
 		} else if isFile(outputLocation) {
-			return errors.New(fmt.Sprintf("Error, unable to overwrite %s\n", outputLocation))
+			return nil, errors.New(fmt.Sprintf("Error, unable to overwrite %s\n", outputLocation))
 		} else {
 			if !isDirectory(outputLocation) {
 				os.Mkdir(outputLocation, 0755)
@@ -193,8 +242,9 @@ func (pluginCall *PluginCall) perform(document proto.Message, openAPIVersion int
 				f.Write(file.Data)
 			}
 		}
+		return response.Files, nil
 	}
-	return nil
+	return nil, nil
 }
 
 func isFile(path string) bool {
@@ -220,6 +270,19 @@ func isDirectory(path string) bool {
 //   = writes to stderr
 // If a directory name is given, the file is written there with
 // a name derived from the source and extension arguments.
+// Returns the name of the file that an output is written to. If the output
+// is named by a directory, the file is named for the source.
+func outputFileName(name string, source string, extension string) string {
+	if !isDirectory(name) {
+		return name
+	}
+	base := filepath.Base(source)
+	// Remove the original source extension.
+	base = base[0 : len(base)-len(filepath.Ext(base))]
+	// Build the path that puts the result in the passed-in directory.
+	return name + "/" + base + "." + extension
+}
+
 func writeFile(name string, bytes []byte, source string, extension string) {
 	var writer io.Writer
 	if name == "!" {
@@ -228,17 +291,8 @@ func writeFile(name string, bytes []byte, source string, extension string) {
 		writer = os.Stdout
 	} else if name == "=" {
 		writer = os.Stderr
-	} else if isDirectory(name) {
-		base := filepath.Base(source)
-		// Remove the original source extension.
-		base = base[0 : len(base)-len(filepath.Ext(base))]
-		// Build the path that puts the result in the passed-in directory.
-		filename := name + "/" + base + "." + extension
-		file, _ := os.Create(filename)
-		defer file.Close()
-		writer = file
 	} else {
-		file, _ := os.Create(name)
+		file, _ := os.Create(outputFileName(name, source, extension))
 		defer file.Close()
 		writer = file
 	}
@@ -257,10 +311,42 @@ type Gnostic struct {
 	yamlOutputPath    string
 	jsonOutputPath    string
 	errorOutputPath   string
+	sourceMapPath     string
+	statsPath         string
+	stats             *compilationStats
 	resolveReferences bool
+	streamJSON        bool
+	stripDocs         bool
+	redactSecrets     bool
+	validate          bool
+	dryRun            bool
+	colorMode         string
+	progressMode      string
+	progress          *progressReporter
+	errorFormat       string
+	lintRulesets      []string
+	failOn            compiler.Severity // the least severe problems that are errors
+	baselinePath      string
+	writeBaseline     bool
+	baseline          map[string]bool
+	warnings          []*compiler.Error
+	sourceBytes       []byte
+	maxFetches        int
+	fetchRate         float64
+	signingKeyPath    string
+	signingKey        ed25519.PrivateKey
+	yamlAnchors       bool
+	transformPath     string
+	cpuProfilePath    string
+	memProfilePath    string
+	cpuProfile        *os.File
 	pluginCalls       []*PluginCall
 	extensionHandlers []compiler.ExtensionHandler
+	compilerOptions   *compiler.CompilerOptions
 	openAPIVersion    int
+	sourceInfo        *yaml.Node
+	documentNumber    int    // the position of the document being compiled in a YAML stream
+	apiVersion        string // the version of the API that is being compiled, if it is known
 }
 
 // Initialize a structure to store global application state.
@@ -269,23 +355,127 @@ func newGnostic() *Gnostic {
 	// Option fields initialize to their default values.
 	g.usage = `
 Usage: gnostic OPENAPI_SOURCE [OPTIONS]
-  OPENAPI_SOURCE is the filename or URL of an OpenAPI description to read.
+  OPENAPI_SOURCE is the filename or URL of an OpenAPI description to read,
+  or the name of a description in an API registry (registry://ORG/API/VERSION).
+  Run "gnostic serve --help" to compile descriptions with an HTTP service.
+  Run "gnostic query --help" to select values from compiled descriptions.
+  Run "gnostic explore OPENAPI_SOURCE" to navigate a compiled description.
+  Run "gnostic rewrite-refs --help" to change the $refs of a description.
+  Run "gnostic verify --help" to check the signature of a binary proto.
 Options:
+  The locations of outputs can be templates, such as {dir}/{name}.{version}.pb,
+  with the directory and name of the source, the version of the API, the
+  version of OpenAPI ({openapi}), and the position of the document in a YAML
+  stream ({document}). Directories in templates are created.
   --pb-out=PATH       Write a binary proto to the specified location.
   --text-out=PATH     Write a text proto to the specified location.
   --json-out=PATH     Write a json API description to the specified location.
   --yaml-out=PATH     Write a yaml API description to the specified location.
   --errors-out=PATH   Write compilation errors to the specified location.
+                      Errors written to stderr, the default, are followed
+                      by excerpts of the lines that they are on.
+  --source-map-out=PATH
+                      Write a JSON map of the paths of the values in the
+                      compiled model to their source files, lines, and
+                      columns to the specified location.
+  --stats-out=PATH    Write statistics of the compilation as JSON to the specified
+                      location: the time spent in each stage, the hit rates of
+                      the reference cache, the number of nodes and files that
+                      were read, and the sizes of the outputs.
+  --stats             Write statistics of the compilation to stderr.
   --PLUGIN-out=PATH   Run the plugin named gnostic_PLUGIN and write results
                       to the specified location.
+  --plugin NAME[=PATH]
+                      Run the plugin named gnostic_NAME, or the plugin built
+                      into gnostic if NAME is "builtin:PLUGIN", and write
+                      results to PATH (default "."). The builtin plugins
+                      are summary, linter, go-generator, go-client, and
+                      go-server.
+  --plugins=PATH      Run the plugins listed in the specified YAML file with
+                      their outputs and parameters. All plugins are run with
+                      the same compiled model.
   --x-EXTENSION       Use the extension named gnostic-x-EXTENSION
                       to process OpenAPI specification extensions.
+  --color=WHEN        Color the severities of errors written to stderr
+                      "always", "never", or when stderr is a terminal
+                      ("auto", the default).
+  --progress=WHEN     Show the progress of compilations that take more than a
+                      second on a status line when stderr is a terminal
+                      ("auto", the default), "never", or write it to stderr
+                      as "json" events, one on each line, every second.
+  --error-format=FORMAT
+                      Write errors and warnings as "text", the default, as
+                      "json", or as a "sarif" log for code scanning tools.
+                      Reports in json and sarif are written when there are
+                      no problems.
+  --lint[=RULESET,...]
+                      Report the problems found by the linter's default
+                      rules, and by the rules of the named rulesets, as
+                      warnings.
+  --fail-on=SEVERITY  Fail when the linter or validator reports problems that
+                      are at least as severe as "error", the default,
+                      "warning", or "info". Problems in objects with an
+                      x-gnostic-ignore extension that names their rule, or
+                      a list of rules, are not reported.
+  --baseline=PATH     Don't report the problems that are listed in the
+                      specified baseline file.
+  --write-baseline    Write the problems that are found to the baseline file,
+                      so that only new problems are reported later.
+  --validate          Check the rules of the specification that the compiled
+                      model doesn't enforce, such as the names of the schemes
+                      in security requirements.
+  --dry-run           Find the files and URLs that the source refers to, directly
+                      or through other files, and write their names to stdout
+                      without compiling the source or writing other outputs.
   --resolve-refs      Explicitly resolve $ref references.
-                      This could have problems with recursive definitions.
+                      Recursive references are left unresolved.
+  --stream            Compile JSON descriptions in chunks as they are read
+                      to reduce the memory used for very large descriptions.
+                      Descriptions in other formats can't be streamed.
+  --strip-docs        Omit descriptions, summaries, and examples from
+                      the compiled model.
+  --redact-secrets    Replace likely secrets, such as API keys, tokens, and
+                      passwords in examples and server URLs, with REDACTED.
+  --yaml-anchors      Write repeated values in yaml output once, with
+                      anchors and aliases.
+  --merge-documents   Merge the documents of a YAML stream into one
+                      description. By default each document is compiled
+                      separately, and the outputs of the Nth document are
+                      named with a -N suffix.
+  --sign-key=PATH     Sign the binary proto with the Ed25519 private key in the
+                      specified PEM file, and write the signature to the
+                      proto's name with a .sig extension.
+  --transform=PATH    Change the compiled model with the pipeline of
+                      transforms in the specified YAML file.
+  --allow-private-network
+                      Fetch references from loopback, link-local, and
+                      private network addresses, which are refused by
+                      default.
+  --max-file-size=BYTES
+                      Report files and responses larger than the specified
+                      size as errors instead of reading them.
+  --max-fetches=N     Fetch at most N files at a time.
+  --fetch-rate=N      Fetch at most N files per second from each host.
+  --tls-ca=[HOST=]PATH
+                      Trust the certificate authorities in the specified PEM
+                      file when fetching from HTTPS servers, or from HOST.
+  --tls-cert=[HOST=]CERT,KEY
+                      Authenticate to HTTPS servers, or to HOST, with the
+                      client certificate and key in the specified PEM files.
+  --registry=URL      Fetch registry:// inputs and references from the API
+                      registry at the specified URL, authenticating with
+                      the token in $GNOSTIC_REGISTRY_TOKEN if it is set.
+  --cpuprofile=PATH   Write a CPU profile to the specified file.
+  --memprofile=PATH   Write a memory profile to the specified file.
 `
 	// Initialize internal structures.
 	g.pluginCalls = make([]*PluginCall, 0)
 	g.extensionHandlers = make([]compiler.ExtensionHandler, 0)
+	g.compilerOptions = &compiler.CompilerOptions{
+		ExtensionHandlers: &g.extensionHandlers,
+		Cache:             compiler.NewCache(),
+		Logger:            log.New(os.Stderr, "", log.LstdFlags),
+	}
 	return g
 }
 
@@ -297,12 +487,33 @@ func (g *Gnostic) readOptions() {
 	// extension processing matches patterns of the form "--x-EXTENSION"
 	extension_regex := regexp.MustCompile("--x-(.+)")
 
-	for i, arg := range os.Args {
-		if i == 0 {
-			continue // skip the tool name
-		}
+	for i := 1; i < len(os.Args); i++ { // skip the tool name
+		arg := os.Args[i]
 		var m [][]byte
-		if m = plugin_regex.FindSubmatch([]byte(arg)); m != nil {
+		if arg == "--plugin" || strings.HasPrefix(arg, "--plugin=") {
+			// plugins can also be named with "--plugin NAME[=PATH]", which writes to the current directory by default
+			value := strings.TrimPrefix(arg, "--plugin=")
+			if arg == "--plugin" {
+				if i+1 == len(os.Args) {
+					fmt.Fprintf(os.Stderr, "Missing plugin name.\n%s\n", g.usage)
+					os.Exit(-1)
+				}
+				i++
+				value = os.Args[i]
+			}
+			pluginCall := &PluginCall{Name: value, Invocation: "."}
+			if j := strings.Index(value, "="); j >= 0 {
+				pluginCall.Name, pluginCall.Invocation = value[:j], value[j+1:]
+			}
+			g.pluginCalls = append(g.pluginCalls, pluginCall)
+		} else if strings.HasPrefix(arg, "--plugins=") {
+			pluginCalls, err := readPluginList(strings.TrimPrefix(arg, "--plugins="))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+				os.Exit(-1)
+			}
+			g.pluginCalls = append(g.pluginCalls, pluginCalls...)
+		} else if m = plugin_regex.FindSubmatch([]byte(arg)); m != nil {
 			pluginName := string(m[1])
 			invocation := string(m[2])
 			switch pluginName {
@@ -316,6 +527,10 @@ func (g *Gnostic) readOptions() {
 				g.yamlOutputPath = invocation
 			case "errors":
 				g.errorOutputPath = invocation
+			case "source-map":
+				g.sourceMapPath = invocation
+			case "stats":
+				g.statsPath = invocation
 			default:
 				pluginCall := &PluginCall{Name: pluginName, Invocation: invocation}
 				g.pluginCalls = append(g.pluginCalls, pluginCall)
@@ -326,6 +541,116 @@ func (g *Gnostic) readOptions() {
 			g.extensionHandlers = append(g.extensionHandlers, extensionHandler)
 		} else if arg == "--resolve-refs" {
 			g.resolveReferences = true
+		} else if arg == "--stream" {
+			g.streamJSON = true
+		} else if arg == "--strip-docs" {
+			g.stripDocs = true
+		} else if strings.HasPrefix(arg, "--color=") {
+			g.colorMode = strings.TrimPrefix(arg, "--color=")
+			if g.colorMode != "auto" && g.colorMode != "always" && g.colorMode != "never" {
+				fmt.Fprintf(os.Stderr, "Invalid option: %s.\n%s\n", arg, g.usage)
+				os.Exit(-1)
+			}
+		} else if strings.HasPrefix(arg, "--progress=") {
+			g.progressMode = strings.TrimPrefix(arg, "--progress=")
+			if g.progressMode != "auto" && g.progressMode != "never" && g.progressMode != "json" {
+				fmt.Fprintf(os.Stderr, "Invalid option: %s.\n%s\n", arg, g.usage)
+				os.Exit(-1)
+			}
+		} else if strings.HasPrefix(arg, "--error-format=") {
+			g.errorFormat = strings.TrimPrefix(arg, "--error-format=")
+			if g.errorFormat != errorFormatText && g.errorFormat != errorFormatJSON && g.errorFormat != errorFormatSARIF {
+				fmt.Fprintf(os.Stderr, "Invalid option: %s.\n%s\n", arg, g.usage)
+				os.Exit(-1)
+			}
+		} else if arg == "--lint" || strings.HasPrefix(arg, "--lint=") {
+			names := make([]string, 0)
+			if value := strings.TrimPrefix(arg, "--lint="); value != arg {
+				names = strings.Split(value, ",")
+			}
+			rulesets, err := lintRulesets(names)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s\n%s\n", err.Error(), g.usage)
+				os.Exit(-1)
+			}
+			g.lintRulesets = rulesets
+		} else if strings.HasPrefix(arg, "--fail-on=") {
+			switch strings.TrimPrefix(arg, "--fail-on=") {
+			case "error":
+				g.failOn = compiler.SeverityError
+			case "warning":
+				g.failOn = compiler.SeverityWarning
+			case "info":
+				g.failOn = compiler.SeverityInfo
+			default:
+				fmt.Fprintf(os.Stderr, "Invalid option: %s.\n%s\n", arg, g.usage)
+				os.Exit(-1)
+			}
+		} else if strings.HasPrefix(arg, "--baseline=") {
+			g.baselinePath = strings.TrimPrefix(arg, "--baseline=")
+		} else if arg == "--write-baseline" {
+			g.writeBaseline = true
+		} else if arg == "--stats" {
+			g.statsPath = "="
+		} else if arg == "--dry-run" {
+			g.dryRun = true
+		} else if arg == "--validate" {
+			g.validate = true
+		} else if arg == "--redact-secrets" {
+			g.redactSecrets = true
+		} else if arg == "--yaml-anchors" {
+			g.yamlAnchors = true
+		} else if arg == "--merge-documents" {
+			g.compilerOptions.MergeDocuments = true
+		} else if arg == "--allow-private-network" {
+			g.compilerOptions.AllowPrivateNetworks = true
+		} else if strings.HasPrefix(arg, "--max-file-size=") {
+			size, err := strconv.ParseInt(strings.TrimPrefix(arg, "--max-file-size="), 10, 64)
+			if err != nil || size < 0 {
+				fmt.Fprintf(os.Stderr, "Invalid option: %s.\n%s\n", arg, g.usage)
+				os.Exit(-1)
+			}
+			g.compilerOptions.MaxFileSize = size
+		} else if strings.HasPrefix(arg, "--max-fetches=") {
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--max-fetches="))
+			if err != nil || n < 0 {
+				fmt.Fprintf(os.Stderr, "Invalid option: %s.\n%s\n", arg, g.usage)
+				os.Exit(-1)
+			}
+			g.maxFetches = n
+		} else if strings.HasPrefix(arg, "--fetch-rate=") {
+			rate, err := strconv.ParseFloat(strings.TrimPrefix(arg, "--fetch-rate="), 64)
+			if err != nil || rate < 0 {
+				fmt.Fprintf(os.Stderr, "Invalid option: %s.\n%s\n", arg, g.usage)
+				os.Exit(-1)
+			}
+			g.fetchRate = rate
+		} else if strings.HasPrefix(arg, "--tls-ca=") {
+			host, value := splitHostOption(strings.TrimPrefix(arg, "--tls-ca="))
+			g.tlsConfig(host).CAFile = value
+		} else if strings.HasPrefix(arg, "--tls-cert=") {
+			host, value := splitHostOption(strings.TrimPrefix(arg, "--tls-cert="))
+			files := strings.SplitN(value, ",", 2)
+			if len(files) != 2 {
+				fmt.Fprintf(os.Stderr, "Invalid option: %s.\n%s\n", arg, g.usage)
+				os.Exit(-1)
+			}
+			config := g.tlsConfig(host)
+			config.CertFile, config.KeyFile = files[0], files[1]
+		} else if strings.HasPrefix(arg, "--registry=") {
+			// registry tokens are read from the environment so that they don't appear in process listings
+			g.compilerOptions.Registry = &compiler.Registry{
+				URL:   strings.TrimPrefix(arg, "--registry="),
+				Token: os.Getenv("GNOSTIC_REGISTRY_TOKEN"),
+			}
+		} else if strings.HasPrefix(arg, "--sign-key=") {
+			g.signingKeyPath = strings.TrimPrefix(arg, "--sign-key=")
+		} else if strings.HasPrefix(arg, "--transform=") {
+			g.transformPath = strings.TrimPrefix(arg, "--transform=")
+		} else if strings.HasPrefix(arg, "--cpuprofile=") {
+			g.cpuProfilePath = strings.TrimPrefix(arg, "--cpuprofile=")
+		} else if strings.HasPrefix(arg, "--memprofile=") {
+			g.memProfilePath = strings.TrimPrefix(arg, "--memprofile=")
 		} else if arg[0] == '-' {
 			fmt.Fprintf(os.Stderr, "Unknown option: %s.\n%s\n", arg, g.usage)
 			os.Exit(-1)
@@ -335,6 +660,26 @@ func (g *Gnostic) readOptions() {
 	}
 }
 
+// Splits the value of an option into an optional host and the rest of the value.
+func splitHostOption(value string) (string, string) {
+	if i := strings.Index(value, "="); i >= 0 {
+		return value[:i], value[i+1:]
+	}
+	return "", value
+}
+
+// Returns the TLS configuration for a host, adding it if it doesn't exist.
+func (g *Gnostic) tlsConfig(host string) *compiler.TLSConfig {
+	for _, config := range g.compilerOptions.TLS {
+		if config.Host == host {
+			return config
+		}
+	}
+	config := &compiler.TLSConfig{Host: host}
+	g.compilerOptions.TLS = append(g.compilerOptions.TLS, config)
+	return config
+}
+
 // Validate command-line options.
 func (g *Gnostic) validateOptions() {
 	if g.binaryOutputPath == "" &&
@@ -342,6 +687,11 @@ func (g *Gnostic) validateOptions() {
 		g.yamlOutputPath == "" &&
 		g.jsonOutputPath == "" &&
 		g.errorOutputPath == "" &&
+		g.sourceMapPath == "" &&
+		g.statsPath == "" &&
+		g.lintRulesets == nil &&
+		!g.validate &&
+		!g.dryRun &&
 		len(g.pluginCalls) == 0 {
 		fmt.Fprintf(os.Stderr, "Missing output directives.\n%s\n", g.usage)
 		os.Exit(-1)
@@ -350,37 +700,276 @@ func (g *Gnostic) validateOptions() {
 		fmt.Fprintf(os.Stderr, "No input specified.\n%s\n", g.usage)
 		os.Exit(-1)
 	}
+	if g.streamJSON && strings.ToLower(filepath.Ext(g.sourceName)) != ".json" {
+		fmt.Fprintf(os.Stderr, "--stream can only be used with JSON descriptions.\n%s\n", g.usage)
+		os.Exit(-1)
+	}
+	if g.writeBaseline && g.baselinePath == "" {
+		fmt.Fprintf(os.Stderr, "--write-baseline requires --baseline to name a file.\n%s\n", g.usage)
+		os.Exit(-1)
+	}
+	if g.baselinePath != "" {
+		baseline, err := readBaseline(g.baselinePath, g.writeBaseline)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			os.Exit(-1)
+		}
+		g.baseline = baseline
+	}
+	if g.maxFetches > 0 || g.fetchRate > 0 {
+		g.compilerOptions.Throttle = compiler.NewThrottle(g.maxFetches, g.fetchRate)
+	}
+	if g.signingKeyPath != "" {
+		switch g.binaryOutputPath {
+		case "", "-", "=", "!":
+			fmt.Fprintf(os.Stderr, "--sign-key requires --pb-out to name a file or directory.\n%s\n", g.usage)
+			os.Exit(-1)
+		}
+		key, err := readPrivateKey(g.signingKeyPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+			os.Exit(-1)
+		}
+		g.signingKey = key
+	}
 	// If we get here and the error output is unspecified, write errors to stderr.
 	if g.errorOutputPath == "" {
 		g.errorOutputPath = "="
 	}
 }
 
-// Generate an error message to be written to stderr or a file.
+// Generate an error message to be written to stderr or a file, describing
+// an error, which may be nil, and the warnings that were found. Errors written
+// to stderr as text are followed by excerpts of the source.
 func (g *Gnostic) errorBytes(err error) []byte {
-	return []byte("Errors reading " + g.sourceName + "\n" + err.Error())
+	// the messages are written below the status line of the progress
+	g.progress.clear()
+	if g.errorFormat == errorFormatJSON || g.errorFormat == errorFormatSARIF {
+		problems := append(compiler.ErrorList(err), g.warnings...)
+		var bytes []byte
+		if g.errorFormat == errorFormatJSON {
+			bytes, err = jsonDiagnosticBytes(g.sourceName, g.documentNumber, problems)
+		} else {
+			bytes, err = sarifBytes(g.sourceName, problems)
+		}
+		if err != nil {
+			return []byte(err.Error())
+		}
+		return bytes
+	}
+	heading := "Errors reading "
+	if err == nil {
+		heading = "Warnings reading "
+	}
+	if len(g.warnings) > 0 {
+		list := make([]error, 0)
+		for _, e := range append(compiler.ErrorList(err), g.warnings...) {
+			list = append(list, e)
+		}
+		err = compiler.NewErrorGroupOrNil(list)
+	}
+	text := err.Error()
+	if g.errorOutputPath == "=" {
+		text = compiler.FormatDiagnostics(err, g.sourceBytes, g.useColor())
+	}
+	if g.documentNumber > 0 {
+		return []byte(fmt.Sprintf("%sdocument %d of %s\n%s", heading, g.documentNumber, g.sourceName, text))
+	}
+	return []byte(heading + g.sourceName + "\n" + text)
+}
+
+// Returns true if diagnostics are colored. By default they are colored
+// when stderr is a terminal, unless $NO_COLOR is set.
+func (g *Gnostic) useColor() bool {
+	switch g.colorMode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return stderrIsTerminal()
+}
+
+// Returns true if stderr is a terminal.
+func stderrIsTerminal() bool {
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Start a stage of the compilation, which is reported by the progress
+// reporter and timed by the statistics.
+func (g *Gnostic) stage(name string) {
+	g.stats.startStage(name)
+	g.progress.stage(name)
+}
+
+// Write the statistics of the compilation, if they are enabled, and stop
+// reporting its progress and profiling it.
+func (g *Gnostic) finish() {
+	g.progress.stop()
+	if g.stats != nil {
+		g.stats.addCache(g.compilerOptions.Cache.Statistics())
+		writeFile(g.expandOutputTemplate(g.statsPath), g.stats.bytes(g.compilerOptions.Progress.Event()), g.sourceName, "stats.json")
+		g.stats = nil
+	}
+	g.stopProfiling()
+}
+
+// Start collecting the statistics of the compilation and reporting its
+// progress, if they are enabled. Messages are logged by the reporter so
+// that they don't overwrite its status line.
+func (g *Gnostic) startProgress() {
+	if g.statsPath != "" {
+		g.stats = newCompilationStats(g.sourceName)
+		g.compilerOptions.Progress = compiler.NewProgress()
+	}
+	switch g.progressMode {
+	case "never":
+		return
+	case "json":
+	default:
+		if !stderrIsTerminal() || os.Getenv("TERM") == "dumb" {
+			return
+		}
+	}
+	if g.compilerOptions.Progress == nil {
+		g.compilerOptions.Progress = compiler.NewProgress()
+	}
+	g.progress = newProgressReporter(g.compilerOptions.Progress, os.Stderr, g.progressMode == "json")
+	g.compilerOptions.Logger = g.progress
+}
+
+// Returns the name that outputs are named for. The outputs of the documents
+// of a YAML stream are named with their positions in the stream.
+func (g *Gnostic) outputSourceName() string {
+	if g.documentNumber == 0 {
+		return g.sourceName
+	}
+	extension := filepath.Ext(g.sourceName)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(g.sourceName, extension), g.documentNumber, extension)
+}
+
+// Returns the path that an output is written to. Outputs that are written
+// to directories are named for the source; others are named with the
+// position of their document in a YAML stream, unless they are templates
+// that include it.
+func (g *Gnostic) outputPath(name string) string {
+	expanded := g.expandOutputTemplate(name)
+	if g.documentNumber == 0 || name == "!" || name == "-" || name == "=" || isDirectory(expanded) ||
+		strings.Contains(name, "{document}") {
+		return expanded
+	}
+	name = expanded
+	extension := filepath.Ext(name)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, extension), g.documentNumber, extension)
+}
+
+// The fields of output templates.
+var outputTemplateField = regexp.MustCompile(`{(dir|name|version|openapi|document)}`)
+
+// Returns the path named by an output template, such as
+// "{dir}/{name}.{version}.pb", and creates the directories that contain it.
+// The fields of templates are the directory and name of the source, without
+// its extension; the version of the API and of OpenAPI; and the position of
+// the document in a YAML stream. Other names are returned unchanged.
+func (g *Gnostic) expandOutputTemplate(name string) string {
+	if !outputTemplateField.MatchString(name) {
+		return name
+	}
+	source := g.sourceName
+	if i := strings.Index(source, "://"); i >= 0 {
+		source = source[i+3:]
+	}
+	source = filepath.FromSlash(source)
+	base := filepath.Base(source)
+	expanded := outputTemplateField.ReplaceAllStringFunc(name, func(field string) string {
+		switch field {
+		case "{dir}":
+			return filepath.Dir(source)
+		case "{name}":
+			return strings.TrimSuffix(base, filepath.Ext(base))
+		case "{version}":
+			if g.apiVersion == "" {
+				return "unknown"
+			}
+			return strings.NewReplacer("/", "_", `\`, "_").Replace(g.apiVersion)
+		case "{openapi}":
+			return fmt.Sprintf("v%d", g.openAPIVersion)
+		case "{document}":
+			return strconv.Itoa(g.documentNumber)
+		}
+		return field
+	})
+	// templates that end with a separator name directories
+	if strings.HasSuffix(expanded, "/") {
+		os.MkdirAll(expanded, 0755)
+	} else {
+		os.MkdirAll(filepath.Dir(expanded), 0755)
+	}
+	return expanded
 }
 
 // Read an OpenAPI description from YAML or JSON.
 func (g *Gnostic) readOpenAPIText(bytes []byte) (message proto.Message, err error) {
-	info, err := compiler.ReadInfoFromBytes(g.sourceName, bytes)
+	info, err := compiler.ReadInfoFromBytesWithOptions(g.sourceName, bytes, g.compilerOptions)
 	if err != nil {
 		return nil, err
 	}
+	return g.compileInfo(info)
+}
+
+// Compile a parsed OpenAPI description.
+func (g *Gnostic) compileInfo(info *yaml.Node) (message proto.Message, err error) {
+	g.stage("compiling")
+	g.stats.countNodes(info)
+	g.sourceInfo = info
 	// Determine the OpenAPI version.
-	g.openAPIVersion = getOpenAPIVersionFromInfo(info)
-	if g.openAPIVersion == OpenAPIvUnknown {
-		return nil, errors.New("Unable to identify OpenAPI version.")
+	g.openAPIVersion, err = getOpenAPIVersionFromInfo(info)
+	if err != nil {
+		return nil, err
 	}
 	// Compile to the proto model.
 	if g.openAPIVersion == OpenAPIv2 {
-		document, err := openapi_v2.NewDocument(info, compiler.NewContextWithExtensions("$root", nil, &g.extensionHandlers))
+		document, err := openapi_v2.NewDocument(info, compiler.NewContextWithOptions("$root", g.compilerOptions))
 		if err != nil {
 			return nil, err
 		}
 		message = document
 	} else if g.openAPIVersion == OpenAPIv3 {
-		document, err := openapi_v3.NewDocument(info, compiler.NewContextWithExtensions("$root", nil, &g.extensionHandlers))
+		document, err := openapi_v3.NewDocument(info, compiler.NewContextWithOptions("$root", g.compilerOptions))
+		if err != nil {
+			return nil, err
+		}
+		message = document
+	}
+	return message, err
+}
+
+// Read an OpenAPI description from JSON, compiling its largest parts in chunks
+// as the source is read.
+func (g *Gnostic) readOpenAPIJSONInChunks() (message proto.Message, err error) {
+	// Determine the OpenAPI version from the beginning of the source.
+	reader, err := compiler.OpenFileWithOptions(g.sourceName, g.compilerOptions)
+	if err != nil {
+		return nil, err
+	}
+	g.openAPIVersion, err = getOpenAPIVersionFromJSON(reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+	// Compile to the proto model.
+	if g.openAPIVersion == OpenAPIv2 {
+		document, err := openapi_v2.NewDocumentFromJSONFile(g.sourceName, compiler.NewContextWithOptions("$root", g.compilerOptions))
+		if err != nil {
+			return nil, err
+		}
+		message = document
+	} else if g.openAPIVersion == OpenAPIv3 {
+		document, err := openapi_v3.NewDocumentFromJSONFile(g.sourceName, compiler.NewContextWithOptions("$root", g.compilerOptions))
 		if err != nil {
 			return nil, err
 		}
@@ -394,14 +983,14 @@ func (g *Gnostic) readOpenAPIBinary(data []byte) (message proto.Message, err err
 	// try to read an OpenAPI v3 document
 	document_v3 := &openapi_v3.Document{}
 	err = proto.Unmarshal(data, document_v3)
-	if err == nil && document_v3.Openapi == "3.0" {
+	if err == nil && gnostic.VersionForField("openapi", document_v3.Openapi) == OpenAPIv3 {
 		g.openAPIVersion = OpenAPIv3
 		return document_v3, nil
 	}
 	// if that failed, try to read an OpenAPI v2 document
 	document_v2 := &openapi_v2.Document{}
 	err = proto.Unmarshal(data, document_v2)
-	if err == nil && document_v2.Swagger == "2.0" {
+	if err == nil && gnostic.VersionForField("swagger", document_v2.Swagger) == OpenAPIv2 {
 		g.openAPIVersion = OpenAPIv2
 		return document_v2, nil
 	}
@@ -412,44 +1001,72 @@ func (g *Gnostic) readOpenAPIBinary(data []byte) (message proto.Message, err err
 func (g *Gnostic) writeBinaryOutput(message proto.Message) {
 	protoBytes, err := proto.Marshal(message)
 	if err != nil {
-		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-		defer os.Exit(-1)
+		writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+		defer g.exit(-1)
 	} else {
-		writeFile(g.binaryOutputPath, protoBytes, g.sourceName, "pb")
+		g.stats.addOutput("pb", len(protoBytes))
+		writeFile(g.outputPath(g.binaryOutputPath), protoBytes, g.outputSourceName(), "pb")
+		// Optionally write a detached signature next to the binary proto.
+		if g.signingKey != nil {
+			filename := outputFileName(g.outputPath(g.binaryOutputPath), g.outputSourceName(), "pb")
+			writeFile(filename+signatureExtension, signatureBytes(g.signingKey, protoBytes), g.outputSourceName(), "sig")
+		}
 	}
 }
 
+// Write a source map of the compiled model. Values that references refer to
+// are mapped to their files when references are resolved.
+func (g *Gnostic) writeSourceMap() {
+	if g.sourceInfo == nil {
+		fmt.Fprintf(os.Stderr, "No source map available.\n")
+		return
+	}
+	context := compiler.NewContextWithOptions("$root", g.compilerOptions)
+	sourceMap := compiler.NewSourceMap(g.sourceName, g.sourceInfo, g.resolveReferences, context)
+	bytes, err := json.MarshalIndent(sourceMap, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating source map %s\n", err.Error())
+	}
+	g.stats.addOutput("source-map", len(bytes))
+	writeFile(g.outputPath(g.sourceMapPath), bytes, g.outputSourceName(), "sourcemap.json")
+}
+
 // Write a text pb representation.
 func (g *Gnostic) writeTextOutput(message proto.Message) {
 	bytes := []byte(proto.MarshalTextString(message))
-	writeFile(g.textOutputPath, bytes, g.sourceName, "text")
+	g.stats.addOutput("text", len(bytes))
+	writeFile(g.outputPath(g.textOutputPath), bytes, g.outputSourceName(), "text")
 }
 
 // Write JSON/YAML OpenAPI representations.
 func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
-	// Convert the OpenAPI document into an exportable MapSlice.
-	var rawInfo yaml.MapSlice
-	var ok bool
+	// Convert the OpenAPI document into an exportable yaml.Node.
+	var rawInfo *yaml.Node
 	var err error
 	if g.openAPIVersion == OpenAPIv2 {
 		document := message.(*openapi_v2.Document)
-		rawInfo, ok = document.ToRawInfo().(yaml.MapSlice)
-		if !ok {
-			rawInfo = nil
-		}
+		rawInfo = document.ToRawInfo()
 	} else if g.openAPIVersion == OpenAPIv3 {
-		//document := message.(*openapi_v3.Document)
-		rawInfo = nil
+		document := message.(*openapi_v3.Document)
+		rawInfo = document.ToRawInfo()
 	}
+	// Keep the keys in the order that they had in the source, and numbers
+	// in the form that they had there.
+	compiler.OrderLike(rawInfo, g.sourceInfo)
+	compiler.RestoreNumbers(rawInfo, g.sourceInfo)
 	// Optionally write description in yaml format.
 	if g.yamlOutputPath != "" {
 		var bytes []byte
 		if rawInfo != nil {
-			bytes, err = yaml.Marshal(rawInfo)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error generating yaml output %s\n", err.Error())
+			if g.yamlAnchors {
+				compiler.AddAnchors(rawInfo)
 			}
-			writeFile(g.yamlOutputPath, bytes, g.sourceName, "yaml")
+			bytes = compiler.Marshal(rawInfo)
+			if bytes == nil {
+				fmt.Fprintf(os.Stderr, "Error generating yaml output\n")
+			}
+			g.stats.addOutput("yaml", len(bytes))
+			writeFile(g.outputPath(g.yamlOutputPath), bytes, g.outputSourceName(), "yaml")
 		} else {
 			fmt.Fprintf(os.Stderr, "No yaml output available.\n")
 		}
@@ -458,32 +1075,104 @@ func (g *Gnostic) writeJSONYAMLOutput(message proto.Message) {
 	if g.jsonOutputPath != "" {
 		var bytes []byte
 		if rawInfo != nil {
-			bytes, _ = jsonwriter.Marshal(rawInfo)
+			bytes, err = jsonwriter.Marshal(rawInfo)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating json output %s\n", err.Error())
 			}
-			writeFile(g.jsonOutputPath, bytes, g.sourceName, "json")
+			g.stats.addOutput("json", len(bytes))
+			writeFile(g.outputPath(g.jsonOutputPath), bytes, g.outputSourceName(), "json")
 		} else {
 			fmt.Fprintf(os.Stderr, "No json output available.\n")
 		}
 	}
 }
 
+// Apply the transforms in the pipeline named by the --transform option.
+func (g *Gnostic) transform(message proto.Message) (proto.Message, error) {
+	bytes, err := compiler.ReadBytesForFileWithOptions(g.transformPath, g.compilerOptions)
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := transform.ReadPipeline(bytes)
+	if err != nil {
+		return nil, err
+	}
+	document := &gnostic.Document{Version: g.openAPIVersion, Source: g.sourceInfo}
+	if g.openAPIVersion == OpenAPIv2 {
+		document.V2 = message.(*openapi_v2.Document)
+	} else if g.openAPIVersion == OpenAPIv3 {
+		document.V3 = message.(*openapi_v3.Document)
+	}
+	document, err = pipeline.Apply(document)
+	if err != nil {
+		return nil, err
+	}
+	g.sourceInfo = document.Source
+	return document.Message(), nil
+}
+
 // Perform all actions specified in the command-line options.
 func (g *Gnostic) performActions(message proto.Message) (err error) {
+	switch document := message.(type) {
+	case *openapi_v2.Document:
+		g.apiVersion = document.GetInfo().GetVersion()
+	case *openapi_v3.Document:
+		g.apiVersion = document.GetInfo().GetVersion()
+	}
 	// Optionally resolve internal references.
 	if g.resolveReferences {
+		g.stage("resolving references")
+		// Read referenced files concurrently before resolving references in order.
+		compiler.PrefetchReferencesWithOptions(g.sourceName, referenceReaders, g.compilerOptions)
+		context := compiler.NewContextWithOptions("$root", g.compilerOptions)
 		if g.openAPIVersion == OpenAPIv2 {
 			document := message.(*openapi_v2.Document)
-			_, err = document.ResolveReferences(g.sourceName)
+			_, err = document.ResolveReferencesInContext(g.sourceName, context)
 		} else if g.openAPIVersion == OpenAPIv3 {
 			document := message.(*openapi_v3.Document)
-			_, err = document.ResolveReferences(g.sourceName)
+			_, err = document.ResolveReferencesInContext(g.sourceName, context)
 		}
 		if err != nil {
 			return err
 		}
 	}
+	// Optionally map the paths of the model to their sources, while the
+	// referenced files are still cached.
+	if g.sourceMapPath != "" {
+		g.writeSourceMap()
+	}
+	// Optionally report the problems found by the linter and check the
+	// rules of the specification that the models don't enforce.
+	if g.lintRulesets != nil || g.validate {
+		g.stage("checking")
+		if err = g.checkDocument(message); err != nil {
+			return err
+		}
+	}
+	// Optionally change the model with a pipeline of transforms.
+	if g.transformPath != "" {
+		g.stage("transforming")
+		message, err = g.transform(message)
+		if err != nil {
+			return err
+		}
+	}
+	// The parsed source is no longer needed, so release it and
+	// intern the strings in the model to reduce the memory it uses.
+	g.stats.addCache(g.compilerOptions.Cache.Statistics())
+	g.compilerOptions.Cache.Clear()
+	compiler.NewStringPool().InternStrings(message)
+	// Optionally remove documentation that isn't needed by consumers of the model.
+	if g.stripDocs {
+		compiler.StripDocumentation(message)
+	}
+	// Optionally remove likely secrets before the model is written.
+	if g.redactSecrets {
+		if count := compiler.RedactSecrets(message); count > 0 {
+			g.compilerOptions.Logger.Printf("Redacted %d likely secrets in %s", count, g.sourceName)
+		}
+	}
+	g.stage("writing")
 	// Optionally write proto in binary format.
 	if g.binaryOutputPath != "" {
 		g.writeBinaryOutput(message)
@@ -496,57 +1185,259 @@ func (g *Gnostic) performActions(message proto.Message) (err error) {
 	if g.yamlOutputPath != "" || g.jsonOutputPath != "" {
 		g.writeJSONYAMLOutput(message)
 	}
-	// Call all specified plugins.
+	// Call all specified plugins, even when some have errors. The model is
+	// encoded once for all of them. Structured reports include all of their
+	// errors, and so are written once.
+	pluginErrors := make([]error, 0)
+	var wrapper *plugins.Wrapper
+	if len(g.pluginCalls) > 0 {
+		wrapper = newWrapper(message, g.openAPIVersion, g.outputSourceName())
+	}
 	for _, pluginCall := range g.pluginCalls {
-		err := pluginCall.perform(message, g.openAPIVersion, g.sourceName)
-		if err != nil {
-			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-			defer os.Exit(-1) // run all plugins, even when some have errors
+		g.stage("running " + pluginCall.Name)
+		call := &PluginCall{Name: pluginCall.Name, Invocation: g.expandOutputTemplate(pluginCall.Invocation)}
+		files, err := call.perform(message, wrapper)
+		g.reportPluginResult(pluginCall, files, err)
+		if err == nil {
+			continue
+		}
+		if g.errorFormat == errorFormatJSON || g.errorFormat == errorFormatSARIF {
+			pluginErrors = append(pluginErrors, err)
+		} else {
+			writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+			defer g.exit(-1)
 		}
 	}
+	if len(pluginErrors) > 0 {
+		return compiler.NewErrorGroupOrNil(pluginErrors)
+	}
+	// Report warnings, and write structured reports even when they are empty.
+	if len(g.warnings) > 0 || g.errorFormat == errorFormatJSON || g.errorFormat == errorFormatSARIF {
+		writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(nil), g.outputSourceName(), "errors")
+	}
 	return nil
 }
 
+// Check a compiled model with the linter and the validator. Problems that
+// are suppressed or in the baseline are dropped, problems that are at least
+// as severe as the --fail-on severity are returned as errors, and the others
+// are reported with the outputs as warnings.
+func (g *Gnostic) checkDocument(message proto.Message) error {
+	document := g.document(message)
+	problems := make([]*compiler.Error, 0)
+	if g.lintRulesets != nil {
+		problems = append(problems, linter.LintWithRulesets(document, g.lintRulesets...)...)
+	}
+	if g.validate {
+		problems = append(problems, validator.Validate(document)...)
+	}
+	locateProblems(g.sourceInfo, problems)
+	problems = suppressProblems(g.sourceInfo, problems)
+	if g.writeBaseline {
+		for _, problem := range problems {
+			g.baseline[baselineEntry(problem)] = true
+		}
+		if err := writeBaseline(g.baselinePath, g.baseline); err != nil {
+			return err
+		}
+	}
+	errs := make([]error, 0)
+	for _, problem := range problems {
+		if g.baseline[baselineEntry(problem)] {
+			continue
+		}
+		if problem.Severity <= g.failOn {
+			errs = append(errs, problem)
+		} else {
+			g.warnings = append(g.warnings, problem)
+		}
+	}
+	return compiler.NewErrorGroupOrNil(errs)
+}
+
+// Returns a compiled model as a document of the gnostic library.
+func (g *Gnostic) document(message proto.Message) *gnostic.Document {
+	document := &gnostic.Document{Version: g.openAPIVersion, Source: g.sourceInfo, Filename: g.sourceName}
+	switch message := message.(type) {
+	case *openapi_v2.Document:
+		document.V2 = message
+	case *openapi_v3.Document:
+		document.V3 = message
+	}
+	return document
+}
+
+// Report the results of plugins when more than one is called, so that
+// the outputs of each can be found.
+func (g *Gnostic) reportPluginResult(pluginCall *PluginCall, files []*plugins.File, err error) {
+	size := 0
+	for _, file := range files {
+		size += len(file.Data)
+	}
+	g.stats.addOutput("plugin "+pluginCall.Name, size)
+	if len(g.pluginCalls) < 2 {
+		return
+	}
+	if err != nil {
+		g.compilerOptions.Logger.Printf("Plugin %s failed", pluginCall.Name)
+	} else {
+		noun := "files"
+		if len(files) == 1 {
+			noun = "file"
+		}
+		g.compilerOptions.Logger.Printf("Plugin %s produced %d %s (%d bytes)", pluginCall.Name, len(files), noun, size)
+	}
+}
+
+// Write the names of the source and of the files that it refers to, which are
+// the files that are read when its references are resolved.
+func (g *Gnostic) listReferencedFiles(infos []*yaml.Node) {
+	g.stage("finding references")
+	files := []string{g.sourceName}
+	seen := map[string]bool{g.sourceName: true}
+	for _, info := range infos {
+		g.compilerOptions.Cache.SetInfo(g.sourceName, info)
+		for _, file := range compiler.ReferencedFilesWithOptions(g.sourceName, referenceReaders, g.compilerOptions) {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	for _, file := range files {
+		fmt.Println(file)
+	}
+}
+
+// Compile each of the documents of a YAML stream as a separate description.
+// The references in each document are resolved in that document.
+func (g *Gnostic) compileDocuments(infos []*yaml.Node) {
+	failed := false
+	for i, info := range infos {
+		g.documentNumber = i + 1
+		// the warnings about the stream are reported with its first document
+		if i > 0 {
+			g.warnings = nil
+		}
+		g.compilerOptions.Cache.SetInfo(g.sourceName, info)
+		message, err := g.compileInfo(info)
+		if err == nil {
+			err = g.performActions(message)
+		}
+		if err != nil {
+			writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+			failed = true
+		}
+	}
+	if failed {
+		g.exit(-1)
+	}
+}
+
 func (g *Gnostic) main() {
 	var err error
 	g.readOptions()
 	g.validateOptions()
+	g.startProfiling()
+	g.startProgress()
 	// Read the OpenAPI source.
-	bytes, err := compiler.ReadBytesForFile(g.sourceName)
-	if err != nil {
-		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-		os.Exit(-1)
+	g.stage("reading")
+	var bytes []byte
+	// Sources that are streamed are read as they are compiled.
+	if !g.streamJSON || g.dryRun {
+		bytes, err = compiler.ReadBytesForFileWithOptions(g.sourceName, g.compilerOptions)
+		if err != nil {
+			writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+			g.exit(-1)
+		}
+		g.sourceBytes = bytes
 	}
 	extension := strings.ToLower(filepath.Ext(g.sourceName))
 	var message proto.Message
-	if extension == ".json" || extension == ".yaml" {
-		// Try to read the source as JSON/YAML.
-		message, err = g.readOpenAPIText(bytes)
+	if g.dryRun {
+		// Binary protos don't have references.
+		if extension == ".json" || extension == ".yaml" || strings.HasPrefix(g.sourceName, compiler.RegistryScheme+"://") {
+			g.stage("parsing")
+			infos, err := compiler.ReadInfosFromBytesWithOptions(g.sourceName, bytes, g.compilerOptions)
+			if err != nil {
+				writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+				g.exit(-1)
+			}
+			g.listReferencedFiles(infos)
+		} else {
+			fmt.Println(g.sourceName)
+		}
+		g.finish()
+		return
+	}
+	if g.streamJSON {
+		// Read the source as JSON, compiling it in chunks as it is read.
+		g.stage("parsing")
+		message, err = g.readOpenAPIJSONInChunks()
 		if err != nil {
-			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-			os.Exit(-1)
+			writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+			g.exit(-1)
+		}
+	} else if extension == ".json" || extension == ".yaml" || strings.HasPrefix(g.sourceName, compiler.RegistryScheme+"://") {
+		// Try to read the source as JSON/YAML. Descriptions in registries are read as text.
+		g.stage("parsing")
+		infos, err := compiler.ReadInfosFromBytesWithOptions(g.sourceName, bytes, g.compilerOptions)
+		if err != nil {
+			writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+			g.exit(-1)
+		}
+		g.warnings = compiler.WarningsForFileWithOptions(g.sourceName, g.compilerOptions)
+		if len(infos) > 1 {
+			g.compileDocuments(infos)
+			g.finish()
+			return
+		}
+		message, err = g.compileInfo(infos[0])
+		if err != nil {
+			writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+			g.exit(-1)
 		}
 	} else if extension == ".pb" {
 		// Try to read the source as a binary protocol buffer.
 		message, err = g.readOpenAPIBinary(bytes)
 		if err != nil {
-			writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-			os.Exit(-1)
+			writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+			g.exit(-1)
 		}
 	} else {
 		err = errors.New("Unknown file extension. 'json', 'yaml', and 'pb' are accepted.")
-		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-		os.Exit(-1)
+		writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+		g.exit(-1)
 	}
 	// Perform actions specified by command options.
 	err = g.performActions(message)
 	if err != nil {
-		writeFile(g.errorOutputPath, g.errorBytes(err), g.sourceName, "errors")
-		os.Exit(-1)
+		writeFile(g.outputPath(g.errorOutputPath), g.errorBytes(err), g.outputSourceName(), "errors")
+		g.exit(-1)
 	}
+	g.finish()
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			serve(os.Args[2:])
+			return
+		case "query":
+			runQuery(os.Args[2:])
+			return
+		case "explore":
+			explore(os.Args[2:])
+			return
+		case "rewrite-refs":
+			rewriteRefs(os.Args[2:])
+			return
+		case "verify":
+			verify(os.Args[2:])
+			return
+		}
+	}
 	g := newGnostic()
 	g.main()
 }
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
@@ -9,7 +12,7 @@ import (
 	"testing"
 )
 
-func test_compiler(t *testing.T, input_file string, reference_file string, expect_errors bool) {
+func test_compiler(t *testing.T, input_file string, reference_file string, expect_errors bool, options ...string) {
 	text_file := strings.Replace(filepath.Base(input_file), filepath.Ext(input_file), ".text", 1)
 	errors_file := strings.Replace(filepath.Base(input_file), filepath.Ext(input_file), ".errors", 1)
 	// remove any preexisting output files
@@ -17,12 +20,12 @@ func test_compiler(t *testing.T, input_file string, reference_file string, expec
 	os.Remove(errors_file)
 	// run the compiler
 	var err error
-	var cmd = exec.Command(
-		"gnostic",
+	args := []string{
 		input_file,
 		"--text-out=.",
 		"--errors-out=.",
-		"--resolve-refs")
+		"--resolve-refs"}
+	var cmd = exec.Command("gnostic", append(args, options...)...)
 	//t.Log(cmd.Args)
 	err = cmd.Run()
 	if err != nil && !expect_errors {
@@ -79,6 +82,25 @@ func TestSeparateJSON(t *testing.T) {
 		"test/v2.0/yaml/petstore-separate/spec/swagger.text") // yaml and json results should be identical
 }
 
+func TestStreamPetstoreJSON(t *testing.T) {
+	test_compiler(t,
+		"examples/v2.0/json/petstore.json",
+		"test/v2.0/petstore.text", false, "--stream")
+}
+
+func TestStreamSeparateJSON(t *testing.T) {
+	test_compiler(t,
+		"examples/v2.0/json/petstore-separate/spec/swagger.json",
+		"test/v2.0/yaml/petstore-separate/spec/swagger.text", false, "--stream")
+}
+
+func TestStreamYAML(t *testing.T) {
+	output, err := exec.Command("gnostic", "examples/v2.0/yaml/petstore.yaml", "--pb-out=!", "--stream").CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "--stream can only be used with JSON descriptions") {
+		t.Errorf("Expected streaming a YAML description to fail: %+v\n%s", err, output)
+	}
+}
+
 func TestRemotePetstoreJSON(t *testing.T) {
 	test_normal(t,
 		"https://raw.githubusercontent.com/googleapis/openapi-compiler/master/examples/v2.0/json/petstore.json",
@@ -121,6 +143,12 @@ func TestErrorMissingVersion(t *testing.T) {
 		"test/errors/petstore-missingversion.errors")
 }
 
+func TestErrorUnsupportedVersion(t *testing.T) {
+	test_errors(t,
+		"examples/errors/petstore-unsupportedversion.yaml",
+		"test/errors/petstore-unsupportedversion.errors")
+}
+
 func test_plugin(t *testing.T, plugin string, input_file string, output_file string, reference_file string) {
 	// remove any preexisting output files
 	os.Remove(output_file)
@@ -442,3 +470,457 @@ func TestPetstoreJSON_30(t *testing.T) {
 		"examples/v3.0/json/petstore.json",
 		"test/v3.0/petstore.text")
 }
+
+func TestStreamPetstoreJSON_30(t *testing.T) {
+	test_compiler(t,
+		"examples/v3.0/json/petstore.json",
+		"test/v3.0/petstore.text", false, "--stream")
+}
+
+func TestStripDocs(t *testing.T) {
+	for _, input_file := range []string{
+		"examples/v2.0/yaml/petstore-expanded.yaml",
+		"examples/v3.0/yaml/petstore.yaml",
+	} {
+		command := exec.Command("gnostic", input_file, "--text-out=-", "--strip-docs")
+		output, err := command.Output()
+		if err != nil {
+			t.Logf("Compile failed for command %v: %+v", command, err)
+			t.FailNow()
+		}
+		text := string(output)
+		if !strings.Contains(text, "operation_id") {
+			t.Errorf("Stripped model for %s is missing operations", input_file)
+		}
+		for _, field := range []string{"description:", "summary:", "example:"} {
+			if strings.Contains(text, field) {
+				t.Errorf("Stripped model for %s contains %s", input_file, field)
+			}
+		}
+	}
+}
+
+func TestMultipleDocuments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	stream := `openapi: 3.0.0
+info: {title: One, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Pet: {allOf: [{$ref: '#/components/schemas/One'}]}
+    One: {type: string}
+---
+openapi: 3.0.0
+info: {title: Two, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Pet: {allOf: [{$ref: '#/components/schemas/Two'}]}
+    Two: {type: integer}
+`
+	input_file := filepath.Join(dir, "stream.yaml")
+	_ = ioutil.WriteFile(input_file, []byte(stream), 0644)
+	// each document is compiled separately, with its references resolved in that document
+	command := exec.Command("gnostic", input_file, "--yaml-out="+dir, "--resolve-refs")
+	if output, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("Compile failed: %+v\n%s", err, output)
+	}
+	for i, title := range []string{"One", "Two"} {
+		output, err := ioutil.ReadFile(filepath.Join(dir, fmt.Sprintf("stream-%d.yaml", i+1)))
+		if err != nil || !strings.Contains(string(output), "title: "+title) {
+			t.Errorf("Unexpected output for document %d: %+v\n%s", i+1, err, output)
+		}
+	}
+
+	fragments := `openapi: 3.0.0
+info: {title: Fragments, version: "1.0"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200": {description: Pets}
+---
+paths:
+  /owners:
+    get:
+      responses:
+        "200": {description: Owners}
+`
+	_ = ioutil.WriteFile(input_file, []byte(fragments), 0644)
+	output, err := exec.Command("gnostic", input_file, "--yaml-out=-", "--merge-documents").Output()
+	if err != nil {
+		t.Fatalf("Compile failed: %+v", err)
+	}
+	for _, path := range []string{"/pets:", "/owners:"} {
+		if !strings.Contains(string(output), path) {
+			t.Errorf("Merged description is missing %s:\n%s", path, output)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	description := `swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+securityDefinitions:
+  basic: {type: basic}
+paths:
+  /pets:
+    get:
+      security: [{token: []}]
+      responses:
+        200: {description: Pets}
+`
+	input_file := filepath.Join(dir, "pets.yaml")
+	_ = ioutil.WriteFile(input_file, []byte(description), 0644)
+	// without validation, requirements aren't checked
+	if output, err := exec.Command("gnostic", input_file, "--pb-out=!").CombinedOutput(); err != nil {
+		t.Fatalf("Compile failed: %+v\n%s", err, output)
+	}
+	output, err := exec.Command("gnostic", input_file, "--pb-out=!", "--validate").CombinedOutput()
+	if err == nil {
+		t.Errorf("Expected validation to fail")
+	}
+	for _, expected := range []string{
+		"ERROR $root.paths./pets.get.security.0.token security requirement refers to token, which is not a declared security scheme",
+		"WARNING $root.securityDefinitions.basic security scheme basic is not used",
+	} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+}
+
+func TestErrorFormats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	description := `swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+schemes: [http]
+securityDefinitions:
+  basic: {type: basic}
+paths:
+  /pets:
+    get:
+      security: [{token: []}]
+      responses:
+        200: {description: Pets}
+`
+	input_file := filepath.Join(dir, "pets.yaml")
+	_ = ioutil.WriteFile(input_file, []byte(description), 0644)
+	sarif_file := filepath.Join(dir, "pets.sarif")
+	output, err := exec.Command("gnostic", input_file, "--validate", "--lint=security", "--error-format=sarif", "--errors-out="+sarif_file).CombinedOutput()
+	if err == nil {
+		t.Errorf("Expected validation to fail")
+	}
+	bytes, err := ioutil.ReadFile(sarif_file)
+	if err != nil {
+		t.Fatalf("No SARIF log was written: %+v\n%s", err, output)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(bytes, &log); err != nil {
+		t.Fatalf("Invalid SARIF log: %+v\n%s", err, bytes)
+	}
+	if log.Version != sarifVersion || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log:\n%s", bytes)
+	}
+	results := make(map[string]*sarifResult)
+	for _, result := range log.Runs[0].Results {
+		if results[result.RuleID] == nil {
+			results[result.RuleID] = result
+		}
+	}
+	// regions begin at the keys of values and end at the ends of the values
+	for _, expected := range []struct {
+		rule   string
+		level  string
+		region sarifRegion
+		path   string
+	}{
+		{"security-requirements", "error", sarifRegion{9, 19, 9, 28}, "/paths/~1pets/get/security/0/token"},
+		{"security-insecure-server", "warning", sarifRegion{3, 1, 3, 15}, "/schemes"},
+	} {
+		result := results[expected.rule]
+		if result == nil {
+			t.Errorf("Expected a result for %s:\n%s", expected.rule, bytes)
+			continue
+		}
+		location := result.Locations[0]
+		if result.Level != expected.level ||
+			location.PhysicalLocation.ArtifactLocation.URI != filepath.ToSlash(input_file) ||
+			location.PhysicalLocation.Region == nil ||
+			*location.PhysicalLocation.Region != expected.region ||
+			location.LogicalLocations[0].FullyQualifiedName != expected.path {
+			t.Errorf("Unexpected result for %s:\n%s", expected.rule, bytes)
+		}
+	}
+	// reports in json have the same positions
+	output, _ = exec.Command("gnostic", input_file, "--validate", "--lint=security", "--error-format=json", "--errors-out=-").Output()
+	var problems struct {
+		Diagnostics []*serveError `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(output, &problems); err != nil {
+		t.Fatalf("Invalid json report: %+v\n%s", err, output)
+	}
+	found := false
+	for _, problem := range problems.Diagnostics {
+		if problem.Path == "/paths/~1pets/get/security/0/token" {
+			found = true
+			if problem.Line != 9 || problem.Column != 19 || problem.EndLine != 9 || problem.EndColumn != 28 {
+				t.Errorf("Unexpected position of %s: %+v", problem.Path, problem)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected a diagnostic for the undeclared security scheme:\n%s", output)
+	}
+	// reports in json are written to stdout, even when there are no problems
+	output, err = exec.Command("gnostic", "examples/v2.0/yaml/petstore.yaml", "--validate", "--error-format=json", "--errors-out=-").Output()
+	if err != nil {
+		t.Fatalf("Compile failed: %+v", err)
+	}
+	var report struct {
+		Source      string        `json:"source"`
+		Diagnostics []*serveError `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(output, &report); err != nil || report.Source != "examples/v2.0/yaml/petstore.yaml" || len(report.Diagnostics) != 0 {
+		t.Errorf("Unexpected json report: %+v\n%s", err, output)
+	}
+}
+
+func TestFailOnAndSuppression(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	description := `swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+schemes: [http]
+x-gnostic-ignore: security-insecure-server
+paths:
+  /pets:
+    get:
+      x-gnostic-ignore: [operation-id, security-operation-requirement]
+      responses:
+        "200": {description: Pets}
+    post:
+      responses:
+        "200": {description: Pets}
+`
+	input_file := filepath.Join(dir, "pets.yaml")
+	_ = ioutil.WriteFile(input_file, []byte(description), 0644)
+	lint := func(args ...string) (string, error) {
+		args = append([]string{input_file, "--lint=security", "--errors-out=-"}, args...)
+		output, err := exec.Command("gnostic", args...).Output()
+		return string(output), err
+	}
+	// warnings don't fail by default, and suppressed problems aren't reported
+	output, err := lint()
+	if err != nil {
+		t.Fatalf("Expected warnings to be accepted: %+v\n%s", err, output)
+	}
+	for _, expected := range []string{
+		"WARNING $root.paths./pets.post operation has no operationId",
+		"WARNING $root.paths./pets.post operation has no security requirement",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+	for _, suppressed := range []string{"$root.schemes", "$root.paths./pets.get"} {
+		if strings.Contains(output, suppressed) {
+			t.Errorf("Expected problems in %s to be suppressed:\n%s", suppressed, output)
+		}
+	}
+	if output, err = lint("--fail-on=warning"); err == nil || !strings.HasPrefix(output, "Errors reading") {
+		t.Errorf("Expected warnings to fail: %+v\n%s", err, output)
+	}
+	// problems in the baseline aren't reported
+	baseline_file := filepath.Join(dir, "baseline.txt")
+	if output, err = lint("--baseline="+baseline_file, "--write-baseline"); err != nil || output != "" {
+		t.Fatalf("Writing the baseline failed: %+v\n%s", err, output)
+	}
+	bytes, _ := ioutil.ReadFile(baseline_file)
+	if !strings.Contains(string(bytes), "operation-id /paths/~1pets/post\n") {
+		t.Errorf("Unexpected baseline:\n%s", bytes)
+	}
+	if output, err = lint("--baseline="+baseline_file, "--fail-on=warning"); err != nil || output != "" {
+		t.Errorf("Expected problems in the baseline to be accepted: %+v\n%s", err, output)
+	}
+}
+
+func TestProgressEvents(t *testing.T) {
+	cmd := exec.Command("gnostic", "examples/v2.0/yaml/petstore.yaml", "--pb-out=!", "--resolve-refs", "--progress=json")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Compile failed: %+v\n%s", err, stderr.String())
+	}
+	stages := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+		var event progressEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("Invalid progress event %q: %+v", line, err)
+		}
+		stages = append(stages, event.Stage)
+		if event.Stage == "done" && (event.Files != 1 || event.File != "examples/v2.0/yaml/petstore.yaml") {
+			t.Errorf("Unexpected final event: %s", line)
+		}
+	}
+	expected := "reading,parsing,compiling,resolving references,writing,done"
+	if strings.Join(stages, ",") != expected {
+		t.Errorf("Expected stages %s, found %s", expected, strings.Join(stages, ","))
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	output_file := filepath.Join(dir, "swagger.pb")
+	output, err := exec.Command("gnostic", "examples/v2.0/yaml/petstore-separate/spec/swagger.yaml", "--dry-run", "--pb-out="+output_file).Output()
+	if err != nil {
+		t.Fatalf("Dry run failed: %+v", err)
+	}
+	expected := `examples/v2.0/yaml/petstore-separate/spec/swagger.yaml
+examples/v2.0/yaml/petstore-separate/common/Error.yaml
+examples/v2.0/yaml/petstore-separate/spec/NewPet.yaml
+examples/v2.0/yaml/petstore-separate/spec/Pet.yaml
+examples/v2.0/yaml/petstore-separate/spec/parameters.yaml
+`
+	if string(output) != expected {
+		t.Errorf("Expected files:\n%s\nfound:\n%s", expected, output)
+	}
+	if _, err := os.Stat(output_file); err == nil {
+		t.Errorf("Expected no outputs to be written")
+	}
+}
+
+func TestStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	stats_file := filepath.Join(dir, "stats.json")
+	output, err := exec.Command("gnostic", "examples/v2.0/yaml/petstore-separate/spec/swagger.yaml", "--resolve-refs", "--pb-out="+dir, "--stats-out="+stats_file).CombinedOutput()
+	if err != nil {
+		t.Fatalf("Compile failed: %+v\n%s", err, output)
+	}
+	bytes, err := ioutil.ReadFile(stats_file)
+	if err != nil {
+		t.Fatalf("No statistics were written: %+v", err)
+	}
+	var stats compilationStats
+	if err := json.Unmarshal(bytes, &stats); err != nil {
+		t.Fatalf("Invalid statistics: %+v\n%s", err, bytes)
+	}
+	stages := make([]string, 0)
+	for _, stage := range stats.Stages {
+		stages = append(stages, stage.Name)
+	}
+	if strings.Join(stages, ",") != "reading,parsing,compiling,resolving references,writing" {
+		t.Errorf("Unexpected stages %s", strings.Join(stages, ","))
+	}
+	info, err := os.Stat(filepath.Join(dir, "swagger.pb"))
+	if err != nil {
+		t.Fatalf("No binary proto was written: %+v", err)
+	}
+	if stats.Files != 5 || stats.Nodes == 0 || stats.Cache.RefMisses == 0 || stats.Outputs["pb"] != int(info.Size()) {
+		t.Errorf("Unexpected statistics:\n%s", bytes)
+	}
+}
+
+func TestPluginList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	list := fmt.Sprintf(`plugins:
+- name: builtin:summary
+  output: %s/summary
+- name: builtin:linter
+  output: %s/lint
+  parameters: {ruleset: security, format: json}
+`, dir, dir)
+	list_file := filepath.Join(dir, "plugins.yaml")
+	_ = ioutil.WriteFile(list_file, []byte(list), 0644)
+	pluginCalls, err := readPluginList(list_file)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if len(pluginCalls) != 2 || pluginCalls[1].Invocation != "format=json,ruleset=security:"+dir+"/lint" {
+		t.Errorf("Unexpected plugin calls: %+v", pluginCalls)
+	}
+	output, err := exec.Command("gnostic", "examples/v2.0/yaml/petstore.yaml", "--plugins="+list_file).CombinedOutput()
+	if err != nil {
+		t.Fatalf("Plugins failed: %+v\n%s", err, output)
+	}
+	for _, file := range []string{"summary/examples/v2.0/yaml/summary.json", "lint/lint.json"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("Expected %s to be written: %+v", file, err)
+		}
+	}
+	for _, expected := range []string{"Plugin builtin:summary produced 1 file", "Plugin builtin:linter produced 1 file"} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+	_ = ioutil.WriteFile(list_file, []byte("plugins:\n- output: out\n"), 0644)
+	if _, err := readPluginList(list_file); err == nil || !strings.Contains(err.Error(), "plugin 1 in "+list_file+" has no name") {
+		t.Errorf("Expected an error for a plugin without a name, found %+v", err)
+	}
+}
+
+func TestOutputTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	stream := `swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+---
+openapi: 3.0.0
+info: {title: Pets, version: "2.0"}
+paths: {}
+`
+	input_file := filepath.Join(dir, "pets.yaml")
+	_ = ioutil.WriteFile(input_file, []byte(stream), 0644)
+	output, err := exec.Command("gnostic", input_file,
+		"--pb-out={dir}/out/{name}.{version}.{openapi}.pb",
+		"--json-out={dir}/json/{name}.json",
+		"--plugin=builtin:linter={dir}/lint/{document}/").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Compile failed: %+v\n%s", err, output)
+	}
+	for _, file := range []string{
+		// templates without the position of the document are named with it
+		"out/pets.1.0.v2-1.pb",
+		"out/pets.2.0.v3-2.pb",
+		"json/pets-1.json",
+		"json/pets-2.json",
+		"lint/1/lint.json",
+		"lint/2/lint.json",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("Expected %s to be written: %+v", file, err)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//
+// DEREFERENCING
+// The following functions replace internal references with the Schemas
+// that they refer to. References that would make the result infinite
+// are kept, so recursive Schemas remain recursive.
+//
+
+// Dereference returns a copy of a Schema in which each internal reference
+// (a "$ref" that begins with "#") is replaced by a copy of the Schema that
+// it refers to. A reference to a Schema that encloses it can't be inlined;
+// it is kept unchanged and its value is included in the returned list of
+// recursive references. Because the result keeps its "definitions", each of
+// these references still resolves within the result.
+// References that can't be resolved are also kept and reported in the error.
+func (schema *Schema) Dereference() (*Schema, []string, error) {
+	d := &dereferencer{
+		root:      schema,
+		recursive: make([]string, 0),
+		errors:    make([]string, 0),
+	}
+	result := schema.DeepCopy()
+	d.dereference(result, "#", make([]string, 0))
+	var err error
+	if len(d.errors) > 0 {
+		err = errors.New(strings.Join(d.errors, "\n"))
+	}
+	return result, uniqueSortedStrings(d.recursive), err
+}
+
+type dereferencer struct {
+	root      *Schema
+	recursive []string
+	errors    []string
+}
+
+// Dereferences a Schema found at the specified location. Enclosing
+// locations are listed in ancestors and are used to detect recursion.
+func (d *dereferencer) dereference(schema *Schema, location string, ancestors []string) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != nil && strings.HasPrefix(*(schema.Ref), "#") {
+		ref := *(schema.Ref)
+		for _, ancestor := range ancestors {
+			if ancestor == ref {
+				d.recursive = append(d.recursive, ref)
+				return
+			}
+		}
+		target, err := d.root.SchemaForPointer(ref)
+		if err != nil {
+			d.errors = append(d.errors, fmt.Sprintf("%s: %s", location, err.Error()))
+			return
+		}
+		// in draft-04, "$ref" overrides all other keywords in a Schema
+		*schema = *(target.DeepCopy())
+		d.dereference(schema, ref, append(ancestors, location))
+		return
+	}
+	ancestors = append(ancestors, location)
+	// each branch gets its own copy of the list of ancestors
+	ancestors = ancestors[:len(ancestors):len(ancestors)]
+
+	if schema.AdditionalItems != nil {
+		d.dereference(schema.AdditionalItems.Schema, location+"/additionalItems", ancestors)
+	}
+	if schema.Items != nil {
+		d.dereference(schema.Items.Schema, location+"/items", ancestors)
+		if schema.Items.SchemaArray != nil {
+			for i, s := range *(schema.Items.SchemaArray) {
+				d.dereference(s, fmt.Sprintf("%s/items/%d", location, i), ancestors)
+			}
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		d.dereference(schema.AdditionalProperties.Schema, location+"/additionalProperties", ancestors)
+	}
+	d.dereferenceNamedSchemas(schema.Properties, location+"/properties", ancestors)
+	d.dereferenceNamedSchemas(schema.PatternProperties, location+"/patternProperties", ancestors)
+	if schema.Dependencies != nil {
+		for _, pair := range *(schema.Dependencies) {
+			if pair.Value != nil {
+				d.dereference(pair.Value.Schema, location+"/dependencies/"+escapeJSONPointer(pair.Name), ancestors)
+			}
+		}
+	}
+	d.dereferenceSchemaArray(schema.AllOf, location+"/allOf", ancestors)
+	d.dereferenceSchemaArray(schema.AnyOf, location+"/anyOf", ancestors)
+	d.dereferenceSchemaArray(schema.OneOf, location+"/oneOf", ancestors)
+	d.dereference(schema.Not, location+"/not", ancestors)
+	d.dereferenceNamedSchemas(schema.Definitions, location+"/definitions", ancestors)
+}
+
+func (d *dereferencer) dereferenceNamedSchemas(array *[]*NamedSchema, location string, ancestors []string) {
+	if array != nil {
+		for _, pair := range *array {
+			d.dereference(pair.Value, location+"/"+escapeJSONPointer(pair.Name), ancestors)
+		}
+	}
+}
+
+func (d *dereferencer) dereferenceSchemaArray(array *[]*Schema, location string, ancestors []string) {
+	if array != nil {
+		for i, s := range *array {
+			d.dereference(s, fmt.Sprintf("%s/%d", location, i), ancestors)
+		}
+	}
+}
+
+// SchemaForPointer returns the subschema of a Schema that is identified by
+// a JSON pointer in URI fragment form, such as "#/definitions/pet".
+// Unlike resolveJSONPointer, it only looks within the Schema itself, but
+// it supports any path that leads to a subschema.
+func (schema *Schema) SchemaForPointer(pointer string) (*Schema, error) {
+	if !strings.HasPrefix(pointer, "#") {
+		return nil, errors.New(fmt.Sprintf("UNSUPPORTED POINTER: %+v", pointer))
+	}
+	path := strings.TrimPrefix(pointer, "#")
+	if path == "" {
+		return schema, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, errors.New(fmt.Sprintf("UNSUPPORTED POINTER: %+v", pointer))
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(tokens[i], "~1", "/", -1), "~0", "~", -1)
+	}
+	result := schema
+	for i := 0; i < len(tokens) && result != nil; i++ {
+		token := tokens[i]
+		// returns the next token, which names a member of a collection
+		next := func() string {
+			i++
+			if i < len(tokens) {
+				return tokens[i]
+			}
+			return ""
+		}
+		switch token {
+		case "definitions":
+			result = namedSchemaArrayElementWithName(result.Definitions, next())
+		case "properties":
+			result = namedSchemaArrayElementWithName(result.Properties, next())
+		case "patternProperties":
+			result = namedSchemaArrayElementWithName(result.PatternProperties, next())
+		case "dependencies":
+			name := next()
+			dependency := result
+			result = nil
+			if dependency.Dependencies != nil {
+				for _, pair := range *(dependency.Dependencies) {
+					if pair.Name == name && pair.Value != nil {
+						result = pair.Value.Schema
+					}
+				}
+			}
+		case "additionalProperties":
+			result = result.AdditionalProperties.schemaOrNil()
+		case "additionalItems":
+			result = result.AdditionalItems.schemaOrNil()
+		case "not":
+			result = result.Not
+		case "items":
+			items := result.Items
+			result = nil
+			if items != nil && items.Schema != nil {
+				result = items.Schema
+			} else if items != nil && items.SchemaArray != nil {
+				result = schemaArrayElementAtIndex(items.SchemaArray, next())
+			}
+		case "allOf":
+			result = schemaArrayElementAtIndex(result.AllOf, next())
+		case "anyOf":
+			result = schemaArrayElementAtIndex(result.AnyOf, next())
+		case "oneOf":
+			result = schemaArrayElementAtIndex(result.OneOf, next())
+		default:
+			result = nil
+		}
+	}
+	if result == nil {
+		return nil, errors.New(fmt.Sprintf("UNRESOLVED POINTER: %+v", pointer))
+	}
+	return result, nil
+}
+
+func (object *SchemaOrBoolean) schemaOrNil() *Schema {
+	if object == nil {
+		return nil
+	}
+	return object.Schema
+}
+
+func schemaArrayElementAtIndex(array *[]*Schema, index string) *Schema {
+	i, err := strconv.Atoi(index)
+	if array == nil || err != nil || i < 0 || i >= len(*array) {
+		return nil
+	}
+	return (*array)[i]
+}
@@ -0,0 +1,63 @@
+package jsonschema
+
+import (
+	"testing"
+)
+
+func TestDereferenceKeepsRecursiveRefs(t *testing.T) {
+	s := schemaFromYAML(t, `
+type: object
+properties:
+  root: {$ref: "#/definitions/node"}
+  label: {$ref: "#/definitions/label"}
+definitions:
+  label: {type: string, maxLength: 10}
+  node:
+    type: object
+    properties:
+      name: {$ref: "#/definitions/label"}
+      children:
+        type: array
+        items: {$ref: "#/definitions/node"}
+`)
+	result, recursive, err := s.Dereference()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if len(recursive) != 1 || recursive[0] != "#/definitions/node" {
+		t.Errorf("Unexpected recursive references: %+v", recursive)
+	}
+	expected := schemaFromYAML(t, `
+type: object
+properties:
+  root:
+    type: object
+    properties:
+      name: {type: string, maxLength: 10}
+      children:
+        type: array
+        items: {$ref: "#/definitions/node"}
+  label: {type: string, maxLength: 10}
+`)
+	result.Definitions = nil
+	if !result.IsEquivalent(expected) {
+		t.Errorf("Incorrect dereferenced schema:\n%s", result.CanonicalString())
+	}
+	if s.PropertyWithName("label").Ref == nil {
+		t.Errorf("Dereference() modified its receiver")
+	}
+}
+
+func TestDereferenceReportsUnresolvedRefs(t *testing.T) {
+	s := schemaFromYAML(t, `
+properties:
+  missing: {$ref: "#/definitions/missing"}
+`)
+	result, _, err := s.Dereference()
+	if err == nil {
+		t.Errorf("Expected an error for an unresolved reference")
+	}
+	if result.PropertyWithName("missing").Ref == nil {
+		t.Errorf("Unresolved reference was not kept")
+	}
+}
@@ -0,0 +1,582 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//
+// DIFFING
+// The following functions compare two Schemas keyword-by-keyword.
+// Each reported change describes how the set of instances accepted
+// by the schema is affected, which allows callers to detect changes
+// that may break existing clients.
+//
+
+// ChangeKind describes what happened to a keyword.
+type ChangeKind int
+
+const (
+	KeywordAdded ChangeKind = iota
+	KeywordRemoved
+	KeywordChanged
+)
+
+func (kind ChangeKind) String() string {
+	switch kind {
+	case KeywordAdded:
+		return "added"
+	case KeywordRemoved:
+		return "removed"
+	default:
+		return "changed"
+	}
+}
+
+// ChangeEffect describes how a change affects the instances accepted by a schema.
+type ChangeEffect int
+
+const (
+	// The change has no effect on validation (e.g. a description was changed).
+	EffectNone ChangeEffect = iota
+	// Some instances that were valid are no longer valid.
+	EffectTightened
+	// Some instances that were invalid are now valid.
+	EffectLoosened
+	// The accepted instances changed in a way that is neither a tightening nor a loosening.
+	EffectIncompatible
+)
+
+func (effect ChangeEffect) String() string {
+	switch effect {
+	case EffectTightened:
+		return "tightened"
+	case EffectLoosened:
+		return "loosened"
+	case EffectIncompatible:
+		return "incompatible"
+	default:
+		return "no effect"
+	}
+}
+
+// Change describes a difference between two Schemas.
+type Change struct {
+	Path     string // JSON pointer to the changed keyword, relative to the compared schemas
+	Kind     ChangeKind
+	Effect   ChangeEffect
+	OldValue string // rendered value in the original schema, if it has a simple value
+	NewValue string // rendered value in the new schema, if it has a simple value
+}
+
+// Returns true if the change might cause previously-valid instances to be rejected.
+func (change *Change) IsBreaking() bool {
+	return change.Effect == EffectTightened || change.Effect == EffectIncompatible
+}
+
+func (change *Change) String() string {
+	var description string
+	switch {
+	case change.Kind == KeywordChanged && (change.OldValue != "" || change.NewValue != ""):
+		description = fmt.Sprintf("changed from %s to %s", change.OldValue, change.NewValue)
+	case change.Kind == KeywordAdded && change.NewValue != "":
+		description = fmt.Sprintf("added %s", change.NewValue)
+	case change.Kind == KeywordRemoved && change.OldValue != "":
+		description = fmt.Sprintf("removed %s", change.OldValue)
+	default:
+		description = change.Kind.String()
+	}
+	return fmt.Sprintf("%s: %s (%s)", change.Path, description, change.Effect)
+}
+
+// Diff returns the changes needed to turn schema a into schema b.
+// Both schemas are normalized before they are compared, so differences
+// in spelling that don't affect validation are not reported.
+// Refs are compared by value and are not followed.
+func Diff(a *Schema, b *Schema) []*Change {
+	d := &differ{changes: make([]*Change, 0)}
+	d.diffSchemas("", canonicalOrNil(a), canonicalOrNil(b), false)
+	return d.changes
+}
+
+// Returns only the changes that might break existing clients.
+func BreakingChanges(changes []*Change) []*Change {
+	result := make([]*Change, 0)
+	for _, change := range changes {
+		if change.IsBreaking() {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+func canonicalOrNil(schema *Schema) *Schema {
+	if schema == nil {
+		return nil
+	}
+	return schema.Canonical()
+}
+
+type differ struct {
+	changes []*Change
+}
+
+// Records a change. When inverted is true, the change is inside a "not"
+// and its effect on validation is reversed.
+func (d *differ) add(path string, kind ChangeKind, effect ChangeEffect, oldValue, newValue string, inverted bool) {
+	if inverted {
+		switch effect {
+		case EffectTightened:
+			effect = EffectLoosened
+		case EffectLoosened:
+			effect = EffectTightened
+		}
+	}
+	d.changes = append(d.changes, &Change{
+		Path:     path,
+		Kind:     kind,
+		Effect:   effect,
+		OldValue: oldValue,
+		NewValue: newValue,
+	})
+}
+
+// Records that a subschema was added or removed.
+func (d *differ) addSubschema(path string, a *Schema, b *Schema, addedEffect ChangeEffect, inverted bool) {
+	removedEffect := EffectNone
+	switch addedEffect {
+	case EffectTightened:
+		removedEffect = EffectLoosened
+	case EffectLoosened:
+		removedEffect = EffectTightened
+	case EffectIncompatible:
+		removedEffect = EffectIncompatible
+	}
+	if a == nil {
+		if b.IsEmpty() {
+			addedEffect = EffectNone
+		}
+		d.add(path, KeywordAdded, addedEffect, "", "", inverted)
+	} else {
+		if a.IsEmpty() {
+			removedEffect = EffectNone
+		}
+		d.add(path, KeywordRemoved, removedEffect, "", "", inverted)
+	}
+}
+
+func (d *differ) diffSchemas(path string, a *Schema, b *Schema, inverted bool) {
+	if a == nil && b == nil {
+		return
+	}
+	if a == nil || b == nil {
+		d.addSubschema(path, a, b, EffectTightened, inverted)
+		return
+	}
+
+	// metadata
+	d.diffStrings(path+"/$schema", a.Schema, b.Schema, EffectNone, inverted)
+	d.diffStrings(path+"/id", a.Id, b.Id, EffectNone, inverted)
+	d.diffStrings(path+"/$ref", a.Ref, b.Ref, EffectIncompatible, inverted)
+	d.diffStrings(path+"/title", a.Title, b.Title, EffectNone, inverted)
+	d.diffStrings(path+"/description", a.Description, b.Description, EffectNone, inverted)
+	d.diffValues(path+"/default", a.Default, b.Default, inverted)
+
+	// numeric instances
+	d.diffStrings(path+"/multipleOf", numberString(a.MultipleOf), numberString(b.MultipleOf), EffectIncompatible, inverted)
+	d.diffBounds(path+"/maximum", a.Maximum, b.Maximum, true, inverted)
+	d.diffFlags(path+"/exclusiveMaximum", a.ExclusiveMaximum, b.ExclusiveMaximum, inverted)
+	d.diffBounds(path+"/minimum", a.Minimum, b.Minimum, false, inverted)
+	d.diffFlags(path+"/exclusiveMinimum", a.ExclusiveMinimum, b.ExclusiveMinimum, inverted)
+
+	// strings
+	d.diffLimits(path+"/maxLength", a.MaxLength, b.MaxLength, true, inverted)
+	d.diffLimits(path+"/minLength", a.MinLength, b.MinLength, false, inverted)
+	d.diffStrings(path+"/pattern", a.Pattern, b.Pattern, EffectIncompatible, inverted)
+	d.diffStrings(path+"/format", a.Format, b.Format, EffectIncompatible, inverted)
+
+	// arrays
+	d.diffSchemaOrBooleans(path+"/additionalItems", a.AdditionalItems, b.AdditionalItems, inverted)
+	d.diffItems(path+"/items", a.Items, b.Items, inverted)
+	d.diffLimits(path+"/maxItems", a.MaxItems, b.MaxItems, true, inverted)
+	d.diffLimits(path+"/minItems", a.MinItems, b.MinItems, false, inverted)
+	d.diffFlags(path+"/uniqueItems", a.UniqueItems, b.UniqueItems, inverted)
+
+	// objects
+	d.diffLimits(path+"/maxProperties", a.MaxProperties, b.MaxProperties, true, inverted)
+	d.diffLimits(path+"/minProperties", a.MinProperties, b.MinProperties, false, inverted)
+	d.diffSets(path+"/required", a.Required, b.Required, EffectTightened, inverted)
+	d.diffSchemaOrBooleans(path+"/additionalProperties", a.AdditionalProperties, b.AdditionalProperties, inverted)
+	d.diffNamedSchemas(path+"/properties", a.Properties, b.Properties, inverted)
+	d.diffNamedSchemas(path+"/patternProperties", a.PatternProperties, b.PatternProperties, inverted)
+	d.diffDependencies(path+"/dependencies", a.Dependencies, b.Dependencies, inverted)
+
+	// any instance type
+	d.diffEnumerations(path+"/enum", a.Enumeration, b.Enumeration, inverted)
+	d.diffTypes(path+"/type", a.Type, b.Type, inverted)
+	d.diffSchemaArrays(path+"/allOf", a.AllOf, b.AllOf, EffectTightened, inverted)
+	d.diffSchemaArrays(path+"/anyOf", a.AnyOf, b.AnyOf, EffectLoosened, inverted)
+	d.diffSchemaArrays(path+"/oneOf", a.OneOf, b.OneOf, EffectIncompatible, inverted)
+	d.diffSchemas(path+"/not", a.Not, b.Not, !inverted)
+	d.diffNamedSchemas(path+"/definitions", a.Definitions, b.Definitions, inverted)
+}
+
+// Compares keywords with string values. The effect is used when a value is
+// changed; adding a value is treated as a tightening and removing it as a loosening.
+func (d *differ) diffStrings(path string, a *string, b *string, effect ChangeEffect, inverted bool) {
+	switch {
+	case a == nil && b == nil:
+	case a == nil:
+		if effect == EffectIncompatible {
+			effect = EffectTightened
+		}
+		d.add(path, KeywordAdded, effect, "", *b, inverted)
+	case b == nil:
+		if effect == EffectIncompatible {
+			effect = EffectLoosened
+		}
+		d.add(path, KeywordRemoved, effect, *a, "", inverted)
+	case *a != *b:
+		d.add(path, KeywordChanged, effect, *a, *b, inverted)
+	}
+}
+
+// Compares default values, which have no effect on validation.
+func (d *differ) diffValues(path string, a *yaml.Node, b *yaml.Node, inverted bool) {
+	var sa, sb *string
+	if a != nil {
+		s := renderValue(a, "")
+		sa = &s
+	}
+	if b != nil {
+		s := renderValue(b, "")
+		sb = &s
+	}
+	d.diffStrings(path, sa, sb, EffectNone, inverted)
+}
+
+// Compares boolean keywords whose default value is false and that restrict instances when true.
+func (d *differ) diffFlags(path string, a *bool, b *bool, inverted bool) {
+	va := a != nil && *a
+	vb := b != nil && *b
+	switch {
+	case !va && vb:
+		d.add(path, KeywordAdded, EffectTightened, "", "true", inverted)
+	case va && !vb:
+		d.add(path, KeywordRemoved, EffectLoosened, "true", "", inverted)
+	}
+}
+
+// Compares numeric bounds. For upper bounds, lowering the value is a tightening.
+func (d *differ) diffBounds(path string, a *SchemaNumber, b *SchemaNumber, upper bool, inverted bool) {
+	switch {
+	case a == nil && b == nil:
+	case a == nil:
+		d.add(path, KeywordAdded, EffectTightened, "", *numberString(b), inverted)
+	case b == nil:
+		d.add(path, KeywordRemoved, EffectLoosened, *numberString(a), "", inverted)
+	default:
+		fa, fb := a.floatValue(), b.floatValue()
+		if fa != fb {
+			d.add(path, KeywordChanged, boundEffect(fb < fa, upper), *numberString(a), *numberString(b), inverted)
+		}
+	}
+}
+
+// Compares integer limits. For upper limits, lowering the value is a tightening.
+func (d *differ) diffLimits(path string, a *int64, b *int64, upper bool, inverted bool) {
+	switch {
+	case a == nil && b == nil:
+	case a == nil:
+		d.add(path, KeywordAdded, EffectTightened, "", fmt.Sprintf("%d", *b), inverted)
+	case b == nil:
+		d.add(path, KeywordRemoved, EffectLoosened, fmt.Sprintf("%d", *a), "", inverted)
+	case *a != *b:
+		d.add(path, KeywordChanged, boundEffect(*b < *a, upper), fmt.Sprintf("%d", *a), fmt.Sprintf("%d", *b), inverted)
+	}
+}
+
+func boundEffect(decreased bool, upper bool) ChangeEffect {
+	if decreased == upper {
+		return EffectTightened
+	}
+	return EffectLoosened
+}
+
+// Compares unordered sets of strings. Each added member has the specified effect
+// and each removed member has the opposite effect.
+func (d *differ) diffSets(path string, a *[]string, b *[]string, addedEffect ChangeEffect, inverted bool) {
+	removedEffect := EffectTightened
+	if addedEffect == EffectTightened {
+		removedEffect = EffectLoosened
+	}
+	added, removed := setDifferences(a, b)
+	for _, s := range added {
+		d.add(path, KeywordAdded, addedEffect, "", s, inverted)
+	}
+	for _, s := range removed {
+		d.add(path, KeywordRemoved, removedEffect, s, "", inverted)
+	}
+}
+
+func (d *differ) diffEnumerations(path string, a *[]SchemaEnumValue, b *[]SchemaEnumValue, inverted bool) {
+	switch {
+	case a == nil && b == nil:
+	case a == nil:
+		d.add(path, KeywordAdded, EffectTightened, "", enumerationString(b), inverted)
+	case b == nil:
+		d.add(path, KeywordRemoved, EffectLoosened, enumerationString(a), "", inverted)
+	default:
+		// adding values to an enumeration accepts more instances
+		d.diffSets(path, enumerationKeys(a), enumerationKeys(b), EffectLoosened, inverted)
+	}
+}
+
+func (d *differ) diffTypes(path string, a *StringOrStringArray, b *StringOrStringArray, inverted bool) {
+	ta, tb := typeSet(a), typeSet(b)
+	switch {
+	case ta == nil && tb == nil:
+	case ta == nil:
+		d.add(path, KeywordAdded, EffectTightened, "", b.Description(), inverted)
+	case tb == nil:
+		d.add(path, KeywordRemoved, EffectLoosened, a.Description(), "", inverted)
+	default:
+		added, removed := setDifferences(ta, tb)
+		var effect ChangeEffect
+		switch {
+		case len(added) == 0 && len(removed) == 0:
+			return
+		case len(added) == 0:
+			effect = EffectTightened
+		case len(removed) == 0:
+			effect = EffectLoosened
+		default:
+			effect = EffectIncompatible
+		}
+		d.add(path, KeywordChanged, effect, a.Description(), b.Description(), inverted)
+	}
+}
+
+// Compares "additionalProperties" and "additionalItems", for which a missing
+// value is equivalent to true and false disallows any additional values.
+func (d *differ) diffSchemaOrBooleans(path string, a *SchemaOrBoolean, b *SchemaOrBoolean, inverted bool) {
+	fa := a != nil && a.Boolean != nil && !*(a.Boolean)
+	fb := b != nil && b.Boolean != nil && !*(b.Boolean)
+	var sa, sb *Schema
+	if a != nil {
+		sa = a.Schema
+	}
+	if b != nil {
+		sb = b.Schema
+	}
+	switch {
+	case fa && fb:
+	case !fa && fb:
+		d.add(path, KeywordChanged, EffectTightened, "", "false", inverted)
+	case fa && !fb:
+		d.add(path, KeywordChanged, EffectLoosened, "false", "", inverted)
+	default:
+		d.diffSchemas(path, sa, sb, inverted)
+	}
+}
+
+func (d *differ) diffItems(path string, a *SchemaOrSchemaArray, b *SchemaOrSchemaArray, inverted bool) {
+	switch {
+	case a == nil && b == nil:
+	case a == nil:
+		d.add(path, KeywordAdded, EffectTightened, "", "", inverted)
+	case b == nil:
+		d.add(path, KeywordRemoved, EffectLoosened, "", "", inverted)
+	case a.Schema != nil && b.Schema != nil:
+		d.diffSchemas(path, a.Schema, b.Schema, inverted)
+	case a.SchemaArray != nil && b.SchemaArray != nil:
+		d.diffSchemaArrays(path, a.SchemaArray, b.SchemaArray, EffectTightened, inverted)
+	default:
+		// switching between a single schema and a tuple of schemas
+		d.add(path, KeywordChanged, EffectIncompatible, "", "", inverted)
+	}
+}
+
+// Compares arrays of schemas position-by-position. Adding a member has
+// the specified effect and removing a member has the opposite effect.
+func (d *differ) diffSchemaArrays(path string, a *[]*Schema, b *[]*Schema, addedEffect ChangeEffect, inverted bool) {
+	var la, lb []*Schema
+	if a != nil {
+		la = *a
+	}
+	if b != nil {
+		lb = *b
+	}
+	for i := 0; i < len(la) || i < len(lb); i++ {
+		itemPath := fmt.Sprintf("%s/%d", path, i)
+		switch {
+		case i >= len(la):
+			d.addSubschema(itemPath, nil, lb[i], addedEffect, inverted)
+		case i >= len(lb):
+			d.addSubschema(itemPath, la[i], nil, addedEffect, inverted)
+		default:
+			d.diffSchemas(itemPath, la[i], lb[i], inverted)
+		}
+	}
+}
+
+func (d *differ) diffNamedSchemas(path string, a *[]*NamedSchema, b *[]*NamedSchema, inverted bool) {
+	names := make([]string, 0)
+	for _, array := range []*[]*NamedSchema{a, b} {
+		if array != nil {
+			for _, pair := range *array {
+				names = append(names, pair.Name)
+			}
+		}
+	}
+	for _, name := range uniqueSortedStrings(names) {
+		d.diffSchemas(path+"/"+escapeJSONPointer(name),
+			namedSchemaArrayElementWithName(a, name),
+			namedSchemaArrayElementWithName(b, name),
+			inverted)
+	}
+}
+
+func (d *differ) diffDependencies(path string, a *[]*NamedSchemaOrStringArray, b *[]*NamedSchemaOrStringArray, inverted bool) {
+	da := dependencyMap(a)
+	db := dependencyMap(b)
+	names := make([]string, 0)
+	for name := range da {
+		names = append(names, name)
+	}
+	for name := range db {
+		names = append(names, name)
+	}
+	for _, name := range uniqueSortedStrings(names) {
+		dependencyPath := path + "/" + escapeJSONPointer(name)
+		va, vb := da[name], db[name]
+		switch {
+		case va == nil:
+			d.add(dependencyPath, KeywordAdded, EffectTightened, "", "", inverted)
+		case vb == nil:
+			d.add(dependencyPath, KeywordRemoved, EffectLoosened, "", "", inverted)
+		case va.Schema != nil && vb.Schema != nil:
+			d.diffSchemas(dependencyPath, va.Schema, vb.Schema, inverted)
+		case va.StringArray != nil && vb.StringArray != nil:
+			d.diffSets(dependencyPath, va.StringArray, vb.StringArray, EffectTightened, inverted)
+		default:
+			d.add(dependencyPath, KeywordChanged, EffectIncompatible, "", "", inverted)
+		}
+	}
+}
+
+func dependencyMap(array *[]*NamedSchemaOrStringArray) map[string]*SchemaOrStringArray {
+	result := make(map[string]*SchemaOrStringArray, 0)
+	if array != nil {
+		for _, pair := range *array {
+			if pair.Value != nil {
+				result[pair.Name] = pair.Value
+			}
+		}
+	}
+	return result
+}
+
+// Returns the members of b that aren't in a and the members of a that aren't in b.
+func setDifferences(a *[]string, b *[]string) (added []string, removed []string) {
+	inA := make(map[string]bool, 0)
+	inB := make(map[string]bool, 0)
+	if a != nil {
+		for _, s := range *a {
+			inA[s] = true
+		}
+	}
+	if b != nil {
+		for _, s := range *b {
+			inB[s] = true
+		}
+	}
+	added = make([]string, 0)
+	removed = make([]string, 0)
+	for s := range inB {
+		if !inA[s] {
+			added = append(added, s)
+		}
+	}
+	for s := range inA {
+		if !inB[s] {
+			removed = append(removed, s)
+		}
+	}
+	return uniqueSortedStrings(added), uniqueSortedStrings(removed)
+}
+
+func typeSet(types *StringOrStringArray) *[]string {
+	if types == nil {
+		return nil
+	}
+	if types.String != nil {
+		return &[]string{*(types.String)}
+	}
+	return types.StringArray
+}
+
+func enumerationKeys(enumeration *[]SchemaEnumValue) *[]string {
+	keys := make([]string, 0)
+	for _, value := range *enumeration {
+		keys = append(keys, value.description())
+	}
+	return &keys
+}
+
+func enumerationString(enumeration *[]SchemaEnumValue) string {
+	return "[" + strings.Join(*enumerationKeys(enumeration), ", ") + "]"
+}
+
+// Returns a displayable version of an enum value.
+func (object SchemaEnumValue) description() string {
+	if object.String != nil {
+		return fmt.Sprintf("%q", *(object.String))
+	} else if object.Bool != nil {
+		return fmt.Sprintf("%t", *(object.Bool))
+	}
+	return ""
+}
+
+func numberString(number *SchemaNumber) *string {
+	if number == nil {
+		return nil
+	}
+	var s string
+	if number.Integer != nil {
+		s = fmt.Sprintf("%d", *(number.Integer))
+	} else if number.Float != nil {
+		s = fmt.Sprintf("%g", *(number.Float))
+	}
+	return &s
+}
+
+func (object *SchemaNumber) floatValue() float64 {
+	if object.Integer != nil {
+		return float64(*(object.Integer))
+	} else if object.Float != nil {
+		return *(object.Float)
+	}
+	return 0
+}
+
+// Escapes a name for use as a JSON pointer reference token (RFC 6901).
+func escapeJSONPointer(name string) string {
+	return strings.Replace(strings.Replace(name, "~", "~0", -1), "/", "~1", -1)
+}
@@ -0,0 +1,61 @@
+package jsonschema
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	s1 := schemaFromYAML(t, `
+type: object
+description: a pet
+required: [name]
+properties:
+  name: {type: string, maxLength: 20}
+  tag: {type: string}
+  kind: {enum: [cat, dog]}
+`)
+	s2 := schemaFromYAML(t, `
+type: object
+description: a pet in the store
+required: [id, name]
+properties:
+  id: {type: integer}
+  name: {type: string, maxLength: 10}
+  kind: {enum: [cat, dog, fish]}
+`)
+	expected := map[string]ChangeEffect{
+		"/description":               EffectNone,
+		"/required":                  EffectTightened,
+		"/properties/id":             EffectTightened,
+		"/properties/kind/enum":      EffectLoosened,
+		"/properties/name/maxLength": EffectTightened,
+		"/properties/tag":            EffectLoosened,
+	}
+	changes := Diff(s1, s2)
+	if len(changes) != len(expected) {
+		t.Errorf("Expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+	for _, change := range changes {
+		effect, ok := expected[change.Path]
+		if !ok {
+			t.Errorf("Unexpected change: %s", change)
+		} else if effect != change.Effect {
+			t.Errorf("Incorrect effect for change: %s", change)
+		}
+	}
+	if len(BreakingChanges(changes)) != 3 {
+		t.Errorf("Expected 3 breaking changes, got %+v", BreakingChanges(changes))
+	}
+	if len(Diff(s1, s1)) != 0 {
+		t.Errorf("Expected no changes when a schema is compared to itself")
+	}
+}
+
+func TestDiffInvertsNot(t *testing.T) {
+	s1 := schemaFromYAML(t, `not: {maxLength: 5}`)
+	s2 := schemaFromYAML(t, `not: {maxLength: 3}`)
+	changes := Diff(s1, s2)
+	if len(changes) != 1 || changes[0].Effect != EffectLoosened {
+		t.Errorf("Expected a single loosening, got %+v", changes)
+	}
+}
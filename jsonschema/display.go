@@ -216,7 +216,7 @@ func (schema *Schema) describeSchema(indent string) string {
 	}
 	if schema.Default != nil {
 		result += indent + "default:\n"
-		result += indent + fmt.Sprintf("  %+v\n", *(schema.Default))
+		result += indent + "  " + renderValue(schema.Default, indent+"  ") + "\n"
 	}
 	if schema.Format != nil {
 		result += indent + "format: " + *(schema.Format) + "\n"
@@ -0,0 +1,155 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+//
+// INFERENCE
+// The following functions build draft Schemas from example data.
+// Inferred Schemas describe only what was observed in the examples,
+// so they are intended to be a starting point for hand-written Schemas.
+//
+
+// InferSchema returns a Schema that accepts all of the examples, which are
+// nodes of the kind returned by yaml.Unmarshal.
+// String-valued fields with no more than maxEnumValues distinct values are
+// described with an enumeration, but only if at least one value is repeated
+// in the examples. Set maxEnumValues to zero to disable enumerations.
+func InferSchema(examples []*yaml.Node, maxEnumValues int) *Schema {
+	schema := inferSchema(examples, maxEnumValues)
+	schema.Schema = stringptr("http://json-schema.org/draft-04/schema#")
+	return schema
+}
+
+func inferSchema(examples []*yaml.Node, maxEnumValues int) *Schema {
+	schema := &Schema{}
+	types := make([]string, 0)
+	objects := make([]*yaml.Node, 0)
+	arrays := make([]*yaml.Node, 0)
+	stringValues := make([]string, 0)
+	for _, example := range examples {
+		example = resolveNode(example)
+		if example == nil {
+			continue
+		}
+		switch example.Kind {
+		case yaml.MappingNode:
+			types = append(types, "object")
+			objects = append(objects, example)
+		case yaml.SequenceNode:
+			types = append(types, "array")
+			arrays = append(arrays, example)
+		case yaml.ScalarNode:
+			switch example.ShortTag() {
+			case "!!null":
+				types = append(types, "null")
+			case "!!bool":
+				types = append(types, "boolean")
+			case "!!int":
+				types = append(types, "integer")
+			case "!!float":
+				types = append(types, "number")
+			case "!!str":
+				types = append(types, "string")
+				stringValues = append(stringValues, example.Value)
+			}
+		}
+	}
+	types = uniqueSortedStrings(types)
+	// integers are numbers, so "number" alone describes both
+	if len(types) > 1 {
+		filtered := make([]string, 0)
+		hasNumber := false
+		for _, t := range types {
+			if t == "number" {
+				hasNumber = true
+			}
+		}
+		for _, t := range types {
+			if !(hasNumber && t == "integer") {
+				filtered = append(filtered, t)
+			}
+		}
+		types = filtered
+	}
+	switch len(types) {
+	case 0:
+		return schema
+	case 1:
+		schema.Type = NewStringOrStringArrayWithString(types[0])
+	default:
+		schema.Type = NewStringOrStringArrayWithStringArray(types)
+	}
+
+	if len(objects) > 0 {
+		inferPropertySchemas(schema, objects, maxEnumValues)
+	}
+	if len(arrays) > 0 {
+		items := make([]*yaml.Node, 0)
+		for _, array := range arrays {
+			items = append(items, array.Content...)
+		}
+		if len(items) > 0 {
+			schema.Items = NewSchemaOrSchemaArrayWithSchema(inferSchema(items, maxEnumValues))
+		}
+	}
+	if len(stringValues) > 0 && len(types) == 1 {
+		values := uniqueStrings(stringValues)
+		if len(values) <= maxEnumValues && len(values) < len(stringValues) {
+			enumeration := make([]SchemaEnumValue, 0)
+			for _, value := range values {
+				v := value
+				enumeration = append(enumeration, SchemaEnumValue{String: &v})
+			}
+			schema.Enumeration = &enumeration
+		}
+	}
+	return schema
+}
+
+// Sets the properties of a Schema from a list of example objects.
+// Properties that appear in every example are required.
+func inferPropertySchemas(schema *Schema, objects []*yaml.Node, maxEnumValues int) {
+	names := make([]string, 0)
+	values := make(map[string][]*yaml.Node, 0)
+	for _, object := range objects {
+		for i := 0; i < len(object.Content); i += 2 {
+			key := object.Content[i]
+			if key.Kind != yaml.ScalarNode || key.ShortTag() != "!!str" {
+				continue
+			}
+			name := key.Value
+			if _, ok := values[name]; !ok {
+				names = append(names, name)
+			}
+			values[name] = append(values[name], object.Content[i+1])
+		}
+	}
+	properties := make([]*NamedSchema, 0)
+	required := make([]string, 0)
+	for _, name := range names {
+		properties = append(properties, NewNamedSchema(name, inferSchema(values[name], maxEnumValues)))
+		if len(values[name]) == len(objects) {
+			required = append(required, name)
+		}
+	}
+	schema.Properties = &properties
+	if len(required) > 0 {
+		schema.Required = &required
+	}
+}
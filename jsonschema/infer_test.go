@@ -0,0 +1,37 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestInferSchema(t *testing.T) {
+	examples := make([]*yaml.Node, 0)
+	for _, text := range []string{
+		`{"id": 1, "name": "fido", "kind": "dog", "tags": ["a"]}`,
+		`{"id": 2, "name": "tom", "kind": "cat", "weight": 4.5}`,
+		`{"id": 3, "name": "rex", "kind": "dog", "tags": []}`,
+	} {
+		var example yaml.Node
+		if err := yaml.Unmarshal([]byte(text), &example); err != nil {
+			t.Fatalf("Unmarshal failed: %+v", err)
+		}
+		examples = append(examples, &example)
+	}
+	schema := InferSchema(examples, 2)
+	expected := schemaFromYAML(t, `
+$schema: "http://json-schema.org/draft-04/schema#"
+type: object
+required: [id, name, kind]
+properties:
+  id: {type: integer}
+  name: {type: string}
+  kind: {type: string, enum: [dog, cat]}
+  tags: {type: array, items: {type: string}}
+  weight: {type: number}
+`)
+	if !schema.IsEquivalent(expected) {
+		t.Errorf("Incorrect inferred schema:\n%s", schema.JSONString())
+	}
+}
@@ -0,0 +1,568 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//
+// MERGING
+// The following functions combine Schemas into a single Schema that
+// accepts exactly the instances that are accepted by all of them.
+// Unlike ResolveAllOfs, which lets later members overwrite earlier ones,
+// merging combines constraints and reports combinations that can't be
+// satisfied.
+//
+
+// MergeConflict describes a keyword that has incompatible values in merged Schemas.
+type MergeConflict struct {
+	Path    string // JSON pointer to the conflicting keyword
+	Message string
+}
+
+func (conflict *MergeConflict) String() string {
+	return fmt.Sprintf("%s: %s", conflict.Path, conflict.Message)
+}
+
+// MergeError is returned when merged Schemas contain conflicts.
+// The merged Schema is still returned, but no instance can satisfy it.
+type MergeError struct {
+	Conflicts []*MergeConflict
+}
+
+func (err *MergeError) Error() string {
+	messages := make([]string, 0)
+	for _, conflict := range err.Conflicts {
+		messages = append(messages, conflict.String())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Merge returns a new Schema that combines the constraints of two Schemas.
+// Constraints that can't be combined into a single keyword (such as two
+// different patterns) are kept as members of an "allOf" in the result.
+// Neither of the input Schemas is modified.
+func Merge(a *Schema, b *Schema) (*Schema, error) {
+	m := &merger{conflicts: make([]*MergeConflict, 0)}
+	result := m.merge("", a.DeepCopy(), b.DeepCopy())
+	return result, m.err()
+}
+
+// FlattenAllOfs returns a copy of a Schema in which every "allOf" has been
+// merged into the Schema that contains it.
+func (schema *Schema) FlattenAllOfs() (*Schema, error) {
+	m := &merger{conflicts: make([]*MergeConflict, 0)}
+	result := schema.DeepCopy()
+	m.flatten("", result)
+	return result, m.err()
+}
+
+type merger struct {
+	conflicts []*MergeConflict
+}
+
+func (m *merger) err() error {
+	if len(m.conflicts) == 0 {
+		return nil
+	}
+	return &MergeError{Conflicts: m.conflicts}
+}
+
+func (m *merger) conflict(path string, format string, args ...interface{}) {
+	m.conflicts = append(m.conflicts, &MergeConflict{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Merges the "allOf" members of a Schema and all of its subschemas, working from the leaves up.
+func (m *merger) flatten(path string, schema *Schema) {
+	if schema == nil {
+		return
+	}
+	if schema.AdditionalItems != nil {
+		m.flatten(path+"/additionalItems", schema.AdditionalItems.Schema)
+	}
+	if schema.Items != nil {
+		m.flatten(path+"/items", schema.Items.Schema)
+		if schema.Items.SchemaArray != nil {
+			for i, s := range *(schema.Items.SchemaArray) {
+				m.flatten(fmt.Sprintf("%s/items/%d", path, i), s)
+			}
+		}
+	}
+	if schema.AdditionalProperties != nil {
+		m.flatten(path+"/additionalProperties", schema.AdditionalProperties.Schema)
+	}
+	m.flattenNamedSchemas(path+"/properties", schema.Properties)
+	m.flattenNamedSchemas(path+"/patternProperties", schema.PatternProperties)
+	m.flattenNamedSchemas(path+"/definitions", schema.Definitions)
+	if schema.Dependencies != nil {
+		for _, pair := range *(schema.Dependencies) {
+			if pair.Value != nil {
+				m.flatten(path+"/dependencies/"+escapeJSONPointer(pair.Name), pair.Value.Schema)
+			}
+		}
+	}
+	for _, array := range []struct {
+		name    string
+		schemas *[]*Schema
+	}{{"allOf", schema.AllOf}, {"anyOf", schema.AnyOf}, {"oneOf", schema.OneOf}} {
+		if array.schemas != nil {
+			for i, s := range *(array.schemas) {
+				m.flatten(fmt.Sprintf("%s/%s/%d", path, array.name, i), s)
+			}
+		}
+	}
+	m.flatten(path+"/not", schema.Not)
+
+	if schema.AllOf != nil {
+		members := *(schema.AllOf)
+		schema.AllOf = nil
+		// the fold starts from a copy, since schemas that can't be merged
+		// are combined in an allOf that must not contain the schema itself
+		c := *schema
+		merged := &c
+		for _, member := range members {
+			merged = m.merge(path, merged, member)
+		}
+		*schema = *merged
+	}
+}
+
+func (m *merger) flattenNamedSchemas(path string, array *[]*NamedSchema) {
+	if array != nil {
+		for _, pair := range *array {
+			m.flatten(path+"/"+escapeJSONPointer(pair.Name), pair.Value)
+		}
+	}
+}
+
+// Returns a Schema that combines the constraints of a and b.
+// The inputs may be reused in the result and should not be used afterwards.
+func (m *merger) merge(path string, a *Schema, b *Schema) *Schema {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	// in draft-04, "$ref" overrides all other keywords in a Schema,
+	// so schemas with references are combined without being merged
+	if a.Ref != nil || b.Ref != nil {
+		if a.Ref != nil && b.Ref != nil && *(a.Ref) == *(b.Ref) {
+			return a
+		}
+		return &Schema{AllOf: &[]*Schema{a, b}}
+	}
+
+	result := &Schema{}
+	residual := make([]*Schema, 0)
+
+	// metadata is taken from the first schema that has it
+	result.Schema = firstString(a.Schema, b.Schema)
+	result.Id = firstString(a.Id, b.Id)
+	result.Title = firstString(a.Title, b.Title)
+	result.Description = firstString(a.Description, b.Description)
+	result.Default = a.Default
+	if result.Default == nil {
+		result.Default = b.Default
+	}
+
+	// numeric instances
+	result.Maximum, result.ExclusiveMaximum = mergeBounds(a.Maximum, a.ExclusiveMaximum, b.Maximum, b.ExclusiveMaximum, true)
+	result.Minimum, result.ExclusiveMinimum = mergeBounds(a.Minimum, a.ExclusiveMinimum, b.Minimum, b.ExclusiveMinimum, false)
+	if result.Maximum != nil && result.Minimum != nil {
+		max, min := result.Maximum.floatValue(), result.Minimum.floatValue()
+		exclusive := (result.ExclusiveMaximum != nil && *(result.ExclusiveMaximum)) ||
+			(result.ExclusiveMinimum != nil && *(result.ExclusiveMinimum))
+		if min > max || (min == max && exclusive) {
+			m.conflict(path+"/minimum", "minimum %s is not compatible with maximum %s",
+				*numberString(result.Minimum), *numberString(result.Maximum))
+		}
+	}
+	result.MultipleOf = a.MultipleOf
+	if a.MultipleOf == nil {
+		result.MultipleOf = b.MultipleOf
+	} else if b.MultipleOf != nil && a.MultipleOf.floatValue() != b.MultipleOf.floatValue() {
+		if a.MultipleOf.Integer != nil && b.MultipleOf.Integer != nil {
+			result.MultipleOf = NewSchemaNumberWithInteger(lcm(*(a.MultipleOf.Integer), *(b.MultipleOf.Integer)))
+		} else {
+			residual = append(residual, &Schema{MultipleOf: b.MultipleOf})
+		}
+	}
+
+	// strings
+	result.MaxLength = mergeLimits(a.MaxLength, b.MaxLength, true)
+	result.MinLength = mergeLimits(a.MinLength, b.MinLength, false)
+	m.checkLimits(path+"/minLength", result.MinLength, result.MaxLength)
+	result.Pattern = a.Pattern
+	if a.Pattern == nil {
+		result.Pattern = b.Pattern
+	} else if b.Pattern != nil && *(a.Pattern) != *(b.Pattern) {
+		residual = append(residual, &Schema{Pattern: b.Pattern})
+	}
+	result.Format = firstString(a.Format, b.Format)
+	if a.Format != nil && b.Format != nil && *(a.Format) != *(b.Format) {
+		m.conflict(path+"/format", "format %q is not compatible with format %q", *(a.Format), *(b.Format))
+	}
+
+	// arrays
+	result.AdditionalItems = m.mergeSchemaOrBooleans(path+"/additionalItems", a.AdditionalItems, b.AdditionalItems)
+	result.Items = a.Items
+	if a.Items == nil {
+		result.Items = b.Items
+	} else if b.Items != nil {
+		if a.Items.Schema != nil && b.Items.Schema != nil {
+			result.Items = NewSchemaOrSchemaArrayWithSchema(m.merge(path+"/items", a.Items.Schema, b.Items.Schema))
+		} else if a.Items.SchemaArray != nil && b.Items.SchemaArray != nil {
+			result.Items = NewSchemaOrSchemaArrayWithSchemaArray(m.mergeSchemaArrays(path+"/items", *(a.Items.SchemaArray), *(b.Items.SchemaArray)))
+		} else {
+			residual = append(residual, &Schema{Items: b.Items, AdditionalItems: b.AdditionalItems})
+		}
+	}
+	result.MaxItems = mergeLimits(a.MaxItems, b.MaxItems, true)
+	result.MinItems = mergeLimits(a.MinItems, b.MinItems, false)
+	m.checkLimits(path+"/minItems", result.MinItems, result.MaxItems)
+	result.UniqueItems = mergeFlags(a.UniqueItems, b.UniqueItems)
+
+	// objects
+	result.MaxProperties = mergeLimits(a.MaxProperties, b.MaxProperties, true)
+	result.MinProperties = mergeLimits(a.MinProperties, b.MinProperties, false)
+	m.checkLimits(path+"/minProperties", result.MinProperties, result.MaxProperties)
+	if a.Required != nil || b.Required != nil {
+		required := make([]string, 0)
+		if a.Required != nil {
+			required = append(required, *(a.Required)...)
+		}
+		if b.Required != nil {
+			required = append(required, *(b.Required)...)
+		}
+		required = uniqueStrings(required)
+		result.Required = &required
+	}
+	m.checkClosedProperties(path, a, b)
+	m.checkClosedProperties(path, b, a)
+	result.AdditionalProperties = m.mergeSchemaOrBooleans(path+"/additionalProperties", a.AdditionalProperties, b.AdditionalProperties)
+	result.Properties = m.mergeNamedSchemas(path+"/properties", a.Properties, b.Properties)
+	result.PatternProperties = m.mergeNamedSchemas(path+"/patternProperties", a.PatternProperties, b.PatternProperties)
+	result.Dependencies = m.mergeDependencies(path+"/dependencies", a.Dependencies, b.Dependencies)
+
+	// any instance type
+	result.Enumeration = m.mergeEnumerations(path+"/enum", a.Enumeration, b.Enumeration)
+	result.Type = m.mergeTypes(path+"/type", a.Type, b.Type)
+	result.AnyOf, residual = mergeAlternatives(a.AnyOf, b.AnyOf, "anyOf", residual)
+	result.OneOf, residual = mergeAlternatives(a.OneOf, b.OneOf, "oneOf", residual)
+	if a.Not != nil && b.Not != nil {
+		// an instance that matches neither schema doesn't match either of them
+		result.Not = &Schema{AnyOf: &[]*Schema{a.Not, b.Not}}
+	} else if a.Not != nil {
+		result.Not = a.Not
+	} else {
+		result.Not = b.Not
+	}
+	result.Definitions = m.mergeNamedSchemas(path+"/definitions", a.Definitions, b.Definitions)
+
+	if a.AllOf != nil {
+		residual = append(*(a.AllOf), residual...)
+	}
+	if b.AllOf != nil {
+		residual = append(residual, *(b.AllOf)...)
+	}
+	if len(residual) > 0 {
+		result.AllOf = &residual
+	}
+	return result
+}
+
+func (m *merger) checkLimits(path string, min *int64, max *int64) {
+	if min != nil && max != nil && *min > *max {
+		m.conflict(path, "minimum %d is greater than maximum %d", *min, *max)
+	}
+}
+
+// Reports properties that are defined by one schema but disallowed by
+// the other because it sets "additionalProperties" to false.
+func (m *merger) checkClosedProperties(path string, closed *Schema, other *Schema) {
+	if closed.AdditionalProperties == nil || closed.AdditionalProperties.Boolean == nil ||
+		*(closed.AdditionalProperties.Boolean) || other.Properties == nil {
+		return
+	}
+	for _, pair := range *(other.Properties) {
+		if closed.PropertyWithName(pair.Name) == nil && !closed.matchesPatternProperty(pair.Name) {
+			m.conflict(path+"/properties/"+escapeJSONPointer(pair.Name),
+				"property %q is not allowed by a schema with additionalProperties set to false", pair.Name)
+		}
+	}
+}
+
+// Returns true if a name matches one of the patternProperties of a schema.
+func (schema *Schema) matchesPatternProperty(name string) bool {
+	if schema.PatternProperties == nil {
+		return false
+	}
+	for _, pair := range *(schema.PatternProperties) {
+		if matched, err := regexp.MatchString(pair.Name, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *merger) mergeSchemaOrBooleans(path string, a *SchemaOrBoolean, b *SchemaOrBoolean) *SchemaOrBoolean {
+	if a == nil || (a.Boolean != nil && *(a.Boolean)) {
+		return b
+	}
+	if b == nil || (b.Boolean != nil && *(b.Boolean)) {
+		return a
+	}
+	if a.Boolean != nil {
+		return a
+	}
+	if b.Boolean != nil {
+		return b
+	}
+	return NewSchemaOrBooleanWithSchema(m.merge(path, a.Schema, b.Schema))
+}
+
+func (m *merger) mergeSchemaArrays(path string, a []*Schema, b []*Schema) []*Schema {
+	result := make([]*Schema, 0)
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i >= len(a):
+			result = append(result, b[i])
+		case i >= len(b):
+			result = append(result, a[i])
+		default:
+			result = append(result, m.merge(fmt.Sprintf("%s/%d", path, i), a[i], b[i]))
+		}
+	}
+	return result
+}
+
+func (m *merger) mergeNamedSchemas(path string, a *[]*NamedSchema, b *[]*NamedSchema) *[]*NamedSchema {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	result := make([]*NamedSchema, 0)
+	for _, pair := range *a {
+		other := namedSchemaArrayElementWithName(b, pair.Name)
+		value := m.merge(path+"/"+escapeJSONPointer(pair.Name), pair.Value, other)
+		result = append(result, NewNamedSchema(pair.Name, value))
+	}
+	for _, pair := range *b {
+		if namedSchemaArrayElementWithName(a, pair.Name) == nil {
+			result = append(result, pair)
+		}
+	}
+	return &result
+}
+
+func (m *merger) mergeDependencies(path string, a *[]*NamedSchemaOrStringArray, b *[]*NamedSchemaOrStringArray) *[]*NamedSchemaOrStringArray {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	result := make([]*NamedSchemaOrStringArray, 0)
+	db := dependencyMap(b)
+	for _, pair := range *a {
+		va, vb := pair.Value, db[pair.Name]
+		switch {
+		case vb == nil || va == nil:
+			result = append(result, pair)
+		case va.Schema != nil && vb.Schema != nil:
+			value := m.merge(path+"/"+escapeJSONPointer(pair.Name), va.Schema, vb.Schema)
+			result = append(result, &NamedSchemaOrStringArray{Name: pair.Name, Value: &SchemaOrStringArray{Schema: value}})
+		case va.StringArray != nil && vb.StringArray != nil:
+			names := uniqueStrings(append(append([]string{}, *(va.StringArray)...), *(vb.StringArray)...))
+			result = append(result, &NamedSchemaOrStringArray{Name: pair.Name, Value: &SchemaOrStringArray{StringArray: &names}})
+		default:
+			// a property dependency can be expressed as a schema that requires those properties
+			var schema *Schema
+			var names *[]string
+			if va.Schema != nil {
+				schema, names = va.Schema, vb.StringArray
+			} else {
+				schema, names = vb.Schema, va.StringArray
+			}
+			value := m.merge(path+"/"+escapeJSONPointer(pair.Name), schema, &Schema{Required: names})
+			result = append(result, &NamedSchemaOrStringArray{Name: pair.Name, Value: &SchemaOrStringArray{Schema: value}})
+		}
+	}
+	da := dependencyMap(a)
+	for _, pair := range *b {
+		if da[pair.Name] == nil {
+			result = append(result, pair)
+		}
+	}
+	return &result
+}
+
+func (m *merger) mergeEnumerations(path string, a *[]SchemaEnumValue, b *[]SchemaEnumValue) *[]SchemaEnumValue {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	keys := make(map[string]bool, 0)
+	for _, value := range *b {
+		keys[value.key()] = true
+	}
+	result := make([]SchemaEnumValue, 0)
+	for _, value := range *a {
+		if keys[value.key()] {
+			result = append(result, value)
+		}
+	}
+	if len(result) == 0 {
+		m.conflict(path, "enumerations %s and %s have no values in common", enumerationString(a), enumerationString(b))
+	}
+	return &result
+}
+
+func (m *merger) mergeTypes(path string, a *StringOrStringArray, b *StringOrStringArray) *StringOrStringArray {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	inB := make(map[string]bool, 0)
+	for _, t := range *typeSet(b) {
+		inB[t] = true
+	}
+	types := make([]string, 0)
+	for _, t := range *typeSet(a) {
+		if inB[t] {
+			types = append(types, t)
+		} else if t == "integer" && inB["number"] {
+			types = append(types, t)
+		} else if t == "number" && inB["integer"] {
+			types = append(types, "integer")
+		}
+	}
+	types = uniqueStrings(types)
+	switch len(types) {
+	case 0:
+		m.conflict(path, "type %s is not compatible with type %s", a.Description(), b.Description())
+		return a
+	case 1:
+		return NewStringOrStringArrayWithString(types[0])
+	default:
+		return NewStringOrStringArrayWithStringArray(types)
+	}
+}
+
+// Combines "anyOf" or "oneOf" lists. Only one list can be kept in the
+// result, so when both schemas have one, the second is moved to the residual.
+func mergeAlternatives(a *[]*Schema, b *[]*Schema, keyword string, residual []*Schema) (*[]*Schema, []*Schema) {
+	if a == nil {
+		return b, residual
+	}
+	if b != nil {
+		s := &Schema{}
+		if keyword == "anyOf" {
+			s.AnyOf = b
+		} else {
+			s.OneOf = b
+		}
+		residual = append(residual, s)
+	}
+	return a, residual
+}
+
+// Combines two upper (or lower) bounds by choosing the more restrictive one.
+func mergeBounds(a *SchemaNumber, aExclusive *bool, b *SchemaNumber, bExclusive *bool, upper bool) (*SchemaNumber, *bool) {
+	if a == nil {
+		return b, bExclusive
+	}
+	if b == nil {
+		return a, aExclusive
+	}
+	fa, fb := a.floatValue(), b.floatValue()
+	switch {
+	case fa == fb:
+		return a, mergeFlags(aExclusive, bExclusive)
+	case (fb < fa) == upper:
+		return b, bExclusive
+	default:
+		return a, aExclusive
+	}
+}
+
+// Combines two upper (or lower) limits by choosing the more restrictive one.
+func mergeLimits(a *int64, b *int64, upper bool) *int64 {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if (*b < *a) == upper {
+		return b
+	}
+	return a
+}
+
+// Combines boolean keywords that restrict instances when true.
+func mergeFlags(a *bool, b *bool) *bool {
+	if a != nil && *a {
+		return a
+	}
+	if b != nil && *b {
+		return b
+	}
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+func firstString(a *string, b *string) *string {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// Removes repeated strings, preserving the order of first occurrences.
+func uniqueStrings(input []string) []string {
+	seen := make(map[string]bool, 0)
+	output := make([]string, 0)
+	for _, s := range input {
+		if !seen[s] {
+			seen[s] = true
+			output = append(output, s)
+		}
+	}
+	return output
+}
+
+func lcm(a int64, b int64) int64 {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	if x == 0 {
+		return 0
+	}
+	return a / x * b
+}
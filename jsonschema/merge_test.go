@@ -0,0 +1,109 @@
+package jsonschema
+
+import (
+	"testing"
+)
+
+func TestFlattenAllOfs(t *testing.T) {
+	s := schemaFromYAML(t, `
+allOf:
+- type: object
+  required: [id]
+  properties:
+    id: {type: number, maximum: 100}
+- required: [name]
+  properties:
+    id: {type: integer, maximum: 50, minimum: 1}
+    name: {type: string, pattern: "^a"}
+- properties:
+    name: {pattern: "z$"}
+`)
+	flattened, err := s.FlattenAllOfs()
+	if err != nil {
+		t.Errorf("Unexpected merge error: %+v", err)
+	}
+	expected := schemaFromYAML(t, `
+type: object
+required: [id, name]
+properties:
+  id: {type: integer, maximum: 50, minimum: 1}
+  name:
+    type: string
+    pattern: "^a"
+    allOf:
+    - pattern: "z$"
+`)
+	if !flattened.IsEquivalent(expected) {
+		t.Errorf("Incorrect flattened schema:\n%s", flattened.CanonicalString())
+	}
+	if s.AllOf == nil {
+		t.Errorf("FlattenAllOfs() modified its receiver")
+	}
+}
+
+func TestFlattenAllOfsWithReferences(t *testing.T) {
+	s := schemaFromYAML(t, `
+description: a pet
+allOf:
+- $ref: "#/definitions/Animal"
+- properties:
+    name: {type: string}
+`)
+	flattened, err := s.FlattenAllOfs()
+	if err != nil {
+		t.Errorf("Unexpected merge error: %+v", err)
+	}
+	if flattened.AllOf == nil {
+		t.Fatalf("Expected the reference to remain in an allOf:\n%s", flattened.JSONString())
+	}
+	for _, member := range *(flattened.AllOf) {
+		if member == flattened {
+			t.Fatalf("Flattened schema contains itself")
+		}
+	}
+	expected := schemaFromYAML(t, `
+properties:
+  name: {type: string}
+allOf:
+- description: a pet
+- $ref: "#/definitions/Animal"
+`)
+	if !flattened.IsEquivalent(expected) {
+		t.Errorf("Incorrect flattened schema:\n%s", flattened.JSONString())
+	}
+}
+
+func TestMergeConflicts(t *testing.T) {
+	a := schemaFromYAML(t, `
+type: object
+additionalProperties: false
+properties:
+  id: {type: string, enum: [a, b]}
+  count: {maxLength: 3}
+`)
+	b := schemaFromYAML(t, `
+properties:
+  id: {type: string, enum: [c]}
+  name: {type: string}
+  count: {minLength: 5}
+`)
+	_, err := Merge(a, b)
+	mergeError, ok := err.(*MergeError)
+	if !ok {
+		t.Fatalf("Expected a MergeError, got %+v", err)
+	}
+	expected := map[string]bool{
+		"/properties/id/enum":         true,
+		"/properties/name":            true,
+		"/properties/count/minLength": true,
+	}
+	for _, conflict := range mergeError.Conflicts {
+		if !expected[conflict.Path] {
+			t.Errorf("Unexpected conflict: %s", conflict)
+		}
+		delete(expected, conflict.Path)
+	}
+	for path := range expected {
+		t.Errorf("Missing conflict at %s", path)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate encode-schema schema.json metaschema-text.go
+
+package jsonschema
+
+// The JSON Schema draft 4 meta-schema, which the OpenAPI schemas refer to.
+var metaSchemaJSON = []byte(metaSchemaText)
+
+// MetaSchemaJSON returns the JSON text of the draft 4 meta-schema, the
+// schema that describes JSON Schemas.
+func MetaSchemaJSON() []byte {
+	return append([]byte(nil), metaSchemaJSON...)
+}
+
+// MetaSchema returns the draft 4 meta-schema. Each call reads a new copy,
+// so callers can modify the result.
+func MetaSchema() (*Schema, error) {
+	return NewSchemaFromBytes(metaSchemaJSON)
+}
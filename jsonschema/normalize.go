@@ -0,0 +1,226 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"math"
+	"sort"
+)
+
+//
+// NORMALIZATION
+// The following methods rewrite Schemas into a canonical form.
+// Two schemas that describe the same constraints using different
+// spellings have identical canonical forms, which allows schemas
+// to be compared and deduplicated reliably.
+//
+
+// Rewrites a Schema and all of the Schemas that it contains into canonical form.
+// The schema is modified in place.
+func (schema *Schema) Normalize() {
+	schema.applyToSchemas(
+		func(schema *Schema, context string) {
+			schema.expandShorthand()
+			schema.sortKeys()
+			schema.deduplicateEnumeration()
+			schema.collapseSingleAllOf()
+		}, "normalize")
+}
+
+// Returns a canonical copy of a Schema, leaving the original unchanged.
+func (schema *Schema) Canonical() *Schema {
+	result := schema.DeepCopy()
+	result.Normalize()
+	return result
+}
+
+// Returns a string that is identical for all Schemas with the same canonical form.
+func (schema *Schema) CanonicalString() string {
+	return schema.Canonical().JSONString()
+}
+
+// Returns true if two Schemas have the same canonical form.
+func (schema *Schema) IsEquivalent(schema2 *Schema) bool {
+	return schema.CanonicalString() == schema2.CanonicalString()
+}
+
+// Replaces alternate spellings of keywords with a single preferred one.
+func (schema *Schema) expandShorthand() {
+	// a type array with a single member is the same as a type string
+	// and type arrays are unordered sets
+	if schema.Type != nil && schema.Type.StringArray != nil {
+		types := uniqueSortedStrings(*(schema.Type.StringArray))
+		if len(types) == 1 {
+			schema.Type = NewStringOrStringArrayWithString(types[0])
+		} else {
+			schema.Type = NewStringOrStringArrayWithStringArray(types)
+		}
+	}
+	// exclusive bounds and uniqueItems default to false
+	if schema.ExclusiveMaximum != nil && !*(schema.ExclusiveMaximum) {
+		schema.ExclusiveMaximum = nil
+	}
+	if schema.ExclusiveMinimum != nil && !*(schema.ExclusiveMinimum) {
+		schema.ExclusiveMinimum = nil
+	}
+	if schema.UniqueItems != nil && !*(schema.UniqueItems) {
+		schema.UniqueItems = nil
+	}
+	// an empty required list requires nothing
+	if schema.Required != nil && len(*(schema.Required)) == 0 {
+		schema.Required = nil
+	}
+	// integral numbers are represented as integers
+	schema.MultipleOf = schema.MultipleOf.normalized()
+	schema.Maximum = schema.Maximum.normalized()
+	schema.Minimum = schema.Minimum.normalized()
+}
+
+// Sorts all unordered collections in a Schema.
+func (schema *Schema) sortKeys() {
+	sortNamedSchemas(schema.Properties)
+	sortNamedSchemas(schema.PatternProperties)
+	sortNamedSchemas(schema.Definitions)
+	if schema.Dependencies != nil {
+		dependencies := *(schema.Dependencies)
+		sort.SliceStable(dependencies, func(i, j int) bool {
+			return dependencies[i].Name < dependencies[j].Name
+		})
+		for _, pair := range dependencies {
+			if pair.Value != nil && pair.Value.StringArray != nil {
+				a := uniqueSortedStrings(*(pair.Value.StringArray))
+				pair.Value.StringArray = &a
+			}
+		}
+	}
+	if schema.Required != nil {
+		required := uniqueSortedStrings(*(schema.Required))
+		schema.Required = &required
+	}
+}
+
+// Removes repeated values from an enumeration, keeping the first occurrence of each.
+func (schema *Schema) deduplicateEnumeration() {
+	if schema.Enumeration == nil {
+		return
+	}
+	seen := make(map[string]bool, 0)
+	enumeration := make([]SchemaEnumValue, 0)
+	for _, value := range *(schema.Enumeration) {
+		key := value.key()
+		if !seen[key] {
+			seen[key] = true
+			enumeration = append(enumeration, value)
+		}
+	}
+	schema.Enumeration = &enumeration
+}
+
+// Replaces an "allOf" with a single member by the contents of that member,
+// but only when doing so doesn't overwrite any keyword of the parent.
+// A member with a "$ref" is only collapsed into an otherwise empty parent,
+// because the keywords that appear beside a "$ref" are ignored.
+func (schema *Schema) collapseSingleAllOf() {
+	if schema.AllOf == nil || len(*(schema.AllOf)) != 1 {
+		return
+	}
+	member := (*(schema.AllOf))[0]
+	if member == nil {
+		return
+	}
+	parent := *schema
+	parent.AllOf = nil
+	if member.Ref != nil && !parent.isEmpty() {
+		return
+	}
+	if parent.sharesKeywordsWith(member) {
+		return
+	}
+	schema.AllOf = nil
+	schema.CopyProperties(member)
+}
+
+// Returns true if a Schema doesn't specify any keywords.
+func (schema *Schema) isEmpty() bool {
+	return len(schema.jsonValue().Content) == 0
+}
+
+// Returns true if any keyword is specified in both Schemas.
+func (schema *Schema) sharesKeywordsWith(schema2 *Schema) bool {
+	keys := make(map[string]bool, 0)
+	m := schema.jsonValue()
+	for i := 0; i < len(m.Content); i += 2 {
+		keys[m.Content[i].Value] = true
+	}
+	m2 := schema2.jsonValue()
+	for i := 0; i < len(m2.Content); i += 2 {
+		if keys[m2.Content[i].Value] {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a string that uniquely identifies an enum value.
+func (object SchemaEnumValue) key() string {
+	if object.String != nil {
+		return "s:" + *(object.String)
+	} else if object.Bool != nil {
+		if *(object.Bool) {
+			return "b:true"
+		}
+		return "b:false"
+	}
+	return ""
+}
+
+// Returns an equivalent SchemaNumber that prefers the Integer representation.
+func (object *SchemaNumber) normalized() *SchemaNumber {
+	if object == nil || object.Float == nil {
+		return object
+	}
+	f := *(object.Float)
+	// conversions of values outside the range of int64 are undefined
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return object
+	}
+	if f == float64(int64(f)) {
+		return NewSchemaNumberWithInteger(int64(f))
+	}
+	return object
+}
+
+func sortNamedSchemas(array *[]*NamedSchema) {
+	if array == nil {
+		return
+	}
+	a := *array
+	sort.SliceStable(a, func(i, j int) bool {
+		return a[i].Name < a[j].Name
+	})
+}
+
+func uniqueSortedStrings(input []string) []string {
+	seen := make(map[string]bool, 0)
+	output := make([]string, 0)
+	for _, s := range input {
+		if !seen[s] {
+			seen[s] = true
+			output = append(output, s)
+		}
+	}
+	sort.Strings(output)
+	return output
+}
@@ -0,0 +1,104 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func schemaFromYAML(t *testing.T, text string) *Schema {
+	var info yaml.Node
+	err := yaml.Unmarshal([]byte(text), &info)
+	if err != nil {
+		t.Logf("Unmarshal failed: %+v", err)
+		t.FailNow()
+	}
+	return NewSchemaFromObject(&info)
+}
+
+func TestNormalizeEquivalentSchemas(t *testing.T) {
+	s1 := schemaFromYAML(t, `
+type: [object]
+required: [name, id, name]
+properties:
+  name: {type: string, enum: [a, b, a]}
+  id: {type: integer, maximum: 10.0}
+uniqueItems: false
+`)
+	s2 := schemaFromYAML(t, `
+allOf:
+- type: object
+  properties:
+    id: {maximum: 10, type: integer}
+    name: {enum: [a, b], type: string}
+required: [id, name]
+`)
+	if !s1.IsEquivalent(s2) {
+		t.Logf("Schemas are not equivalent:\n%s\n%s", s1.CanonicalString(), s2.CanonicalString())
+		t.FailNow()
+	}
+	// Canonical() must not modify the original schema.
+	if s2.AllOf == nil {
+		t.Logf("Canonical() modified its receiver")
+		t.FailNow()
+	}
+}
+
+func TestNormalizeKeepsConflictingAllOf(t *testing.T) {
+	s := schemaFromYAML(t, `
+type: object
+allOf:
+- type: string
+`)
+	s.Normalize()
+	if s.AllOf == nil || len(*s.AllOf) != 1 {
+		t.Logf("allOf with a conflicting keyword was collapsed:\n%s", s.JSONString())
+		t.FailNow()
+	}
+}
+
+func TestNormalizeKeepsReferenceInAllOf(t *testing.T) {
+	s := schemaFromYAML(t, `
+type: object
+properties:
+  name: {type: string}
+allOf:
+- $ref: "#/definitions/Pet"
+`)
+	s.Normalize()
+	if s.AllOf == nil || len(*s.AllOf) != 1 || s.Ref != nil {
+		t.Logf("allOf with a reference was collapsed into a parent with other keywords:\n%s", s.JSONString())
+		t.FailNow()
+	}
+	// a reference that is the only constraint is the same as the reference itself
+	s = schemaFromYAML(t, `
+allOf:
+- $ref: "#/definitions/Pet"
+`)
+	if !s.IsEquivalent(schemaFromYAML(t, `$ref: "#/definitions/Pet"`)) {
+		t.Logf("allOf with a single reference was not collapsed:\n%s", s.CanonicalString())
+		t.FailNow()
+	}
+}
+
+func TestNormalizeLargeNumbers(t *testing.T) {
+	s := schemaFromYAML(t, `
+maximum: 1e20
+minimum: -1e20
+multipleOf: 2.5
+`)
+	s.Normalize()
+	for _, n := range []*SchemaNumber{s.Maximum, s.Minimum, s.MultipleOf} {
+		if n.Float == nil || n.Integer != nil {
+			t.Errorf("Number outside the range of integers was normalized to %+v", n)
+		}
+	}
+	if *s.Maximum.Float != 1e20 || *s.Minimum.Float != -1e20 {
+		t.Errorf("Unexpected bounds %v, %v", *s.Maximum.Float, *s.Minimum.Float)
+	}
+	s = schemaFromYAML(t, `maximum: 4096.0`)
+	s.Normalize()
+	if s.Maximum.Integer == nil || *s.Maximum.Integer != 4096 {
+		t.Errorf("Integral number was not normalized to an integer: %+v", s.Maximum)
+	}
+}
@@ -306,7 +306,7 @@ func (schema *Schema) ResolveRefs() {
 
 // Resolves JSON pointers.
 // This current implementation is very crude and custom for OpenAPI 2.0 schemas.
-// It panics for any pointer that it is unable to resolve.
+// It returns an error for any pointer that it is unable to resolve.
 func (root *Schema) resolveJSONPointer(ref string) (schema *Schema, err error) {
 	var result *Schema
 
@@ -318,6 +318,9 @@ func (root *Schema) resolveJSONPointer(ref string) (schema *Schema, err error) {
 		}
 		path := parts[1]
 		document := schemas[documentName]
+		if document == nil {
+			return nil, errors.New(fmt.Sprintf("UNKNOWN DOCUMENT: %+v", ref))
+		}
 		pathParts := strings.Split(path, "/")
 
 		// we currently do a very limited (hard-coded) resolution of certain paths and log errors for missed cases
@@ -327,6 +330,9 @@ func (root *Schema) resolveJSONPointer(ref string) (schema *Schema, err error) {
 			switch pathParts[1] {
 			case "definitions":
 				dictionary := document.Definitions
+				if dictionary == nil {
+					break
+				}
 				for _, pair := range *dictionary {
 					if pair.Name == pathParts[2] {
 						result = pair.Value
@@ -334,6 +340,9 @@ func (root *Schema) resolveJSONPointer(ref string) (schema *Schema, err error) {
 				}
 			case "properties":
 				dictionary := document.Properties
+				if dictionary == nil {
+					break
+				}
 				for _, pair := range *dictionary {
 					if pair.Name == pathParts[2] {
 						result = pair.Value
@@ -392,3 +401,157 @@ func (schema *Schema) CopyOfficialSchemaProperties(names []string) {
 		schema.CopyOfficialSchemaProperty(name)
 	}
 }
+
+// Returns a deep copy of a Schema that shares no pointers with the original.
+func (schema *Schema) DeepCopy() *Schema {
+	if schema == nil {
+		return nil
+	}
+	result := &Schema{}
+	result.Schema = copyString(schema.Schema)
+	result.Id = copyString(schema.Id)
+	result.Ref = copyString(schema.Ref)
+	result.MultipleOf = schema.MultipleOf.deepCopy()
+	result.Maximum = schema.Maximum.deepCopy()
+	result.ExclusiveMaximum = copyBool(schema.ExclusiveMaximum)
+	result.Minimum = schema.Minimum.deepCopy()
+	result.ExclusiveMinimum = copyBool(schema.ExclusiveMinimum)
+	result.MaxLength = copyInt(schema.MaxLength)
+	result.MinLength = copyInt(schema.MinLength)
+	result.Pattern = copyString(schema.Pattern)
+	result.AdditionalItems = schema.AdditionalItems.deepCopy()
+	result.Items = schema.Items.deepCopy()
+	result.MaxItems = copyInt(schema.MaxItems)
+	result.MinItems = copyInt(schema.MinItems)
+	result.UniqueItems = copyBool(schema.UniqueItems)
+	result.MaxProperties = copyInt(schema.MaxProperties)
+	result.MinProperties = copyInt(schema.MinProperties)
+	result.Required = copyStringArray(schema.Required)
+	result.AdditionalProperties = schema.AdditionalProperties.deepCopy()
+	result.Properties = copyNamedSchemaArray(schema.Properties)
+	result.PatternProperties = copyNamedSchemaArray(schema.PatternProperties)
+	if schema.Dependencies != nil {
+		dependencies := make([]*NamedSchemaOrStringArray, 0)
+		for _, pair := range *(schema.Dependencies) {
+			value := &SchemaOrStringArray{}
+			if pair.Value != nil {
+				value.Schema = pair.Value.Schema.DeepCopy()
+				value.StringArray = copyStringArray(pair.Value.StringArray)
+			}
+			dependencies = append(dependencies, &NamedSchemaOrStringArray{Name: pair.Name, Value: value})
+		}
+		result.Dependencies = &dependencies
+	}
+	if schema.Enumeration != nil {
+		enumeration := make([]SchemaEnumValue, 0)
+		for _, value := range *(schema.Enumeration) {
+			enumeration = append(enumeration, SchemaEnumValue{String: copyString(value.String), Bool: copyBool(value.Bool)})
+		}
+		result.Enumeration = &enumeration
+	}
+	if schema.Type != nil {
+		result.Type = &StringOrStringArray{
+			String:      copyString(schema.Type.String),
+			StringArray: copyStringArray(schema.Type.StringArray),
+		}
+	}
+	result.AllOf = copySchemaArray(schema.AllOf)
+	result.AnyOf = copySchemaArray(schema.AnyOf)
+	result.OneOf = copySchemaArray(schema.OneOf)
+	result.Not = schema.Not.DeepCopy()
+	result.Definitions = copyNamedSchemaArray(schema.Definitions)
+	result.Title = copyString(schema.Title)
+	result.Description = copyString(schema.Description)
+	if schema.Default != nil {
+		// default values are treated as immutable and shared
+		v := *(schema.Default)
+		result.Default = &v
+	}
+	result.Format = copyString(schema.Format)
+	return result
+}
+
+func (object *SchemaNumber) deepCopy() *SchemaNumber {
+	if object == nil {
+		return nil
+	}
+	result := &SchemaNumber{}
+	if object.Integer != nil {
+		i := *(object.Integer)
+		result.Integer = &i
+	}
+	if object.Float != nil {
+		f := *(object.Float)
+		result.Float = &f
+	}
+	return result
+}
+
+func (object *SchemaOrBoolean) deepCopy() *SchemaOrBoolean {
+	if object == nil {
+		return nil
+	}
+	return &SchemaOrBoolean{Schema: object.Schema.DeepCopy(), Boolean: copyBool(object.Boolean)}
+}
+
+func (object *SchemaOrSchemaArray) deepCopy() *SchemaOrSchemaArray {
+	if object == nil {
+		return nil
+	}
+	return &SchemaOrSchemaArray{Schema: object.Schema.DeepCopy(), SchemaArray: copySchemaArray(object.SchemaArray)}
+}
+
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+func copyBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	v := *b
+	return &v
+}
+
+func copyInt(i *int64) *int64 {
+	if i == nil {
+		return nil
+	}
+	v := *i
+	return &v
+}
+
+func copyStringArray(a *[]string) *[]string {
+	if a == nil {
+		return nil
+	}
+	v := make([]string, len(*a))
+	copy(v, *a)
+	return &v
+}
+
+func copySchemaArray(a *[]*Schema) *[]*Schema {
+	if a == nil {
+		return nil
+	}
+	v := make([]*Schema, 0)
+	for _, s := range *a {
+		v = append(v, s.DeepCopy())
+	}
+	return &v
+}
+
+func copyNamedSchemaArray(a *[]*NamedSchema) *[]*NamedSchema {
+	if a == nil {
+		return nil
+	}
+	v := make([]*NamedSchema, 0)
+	for _, pair := range *a {
+		v = append(v, NewNamedSchema(pair.Name, pair.Value.DeepCopy()))
+	}
+	return &v
+}
@@ -15,10 +15,12 @@
 package jsonschema
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"strconv"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 )
 
 // This is a global map of all known Schemas.
@@ -32,27 +34,40 @@ func NewSchemaFromFile(filename string) (schema *Schema, err error) {
 	if err != nil {
 		return nil, err
 	}
-	var info yaml.MapSlice
-	err = yaml.Unmarshal(file, &info)
+	return NewSchemaFromBytes(file)
+}
+
+// Reads a schema from JSON or YAML bytes.
+func NewSchemaFromBytes(bytes []byte) (schema *Schema, err error) {
+	var info yaml.Node
+	err = yaml.Unmarshal(bytes, &info)
 	if err != nil {
 		return nil, err
 	}
-	return NewSchemaFromObject(info), nil
+	schema = NewSchemaFromObject(&info)
+	if schema == nil {
+		return nil, errors.New("schema is not an object")
+	}
+	return schema, nil
 }
 
 // Constructs a schema from a parsed JSON object.
 // Due to the complexity of the schema representation, this is a
 // custom reader and not the standard Go JSON reader (encoding/json).
-func NewSchemaFromObject(jsonData interface{}) *Schema {
-	switch t := jsonData.(type) {
-	default:
-		fmt.Printf("schemaValue: unexpected type %T\n", t)
+func NewSchemaFromObject(jsonData *yaml.Node) *Schema {
+	jsonData = resolveNode(jsonData)
+	if jsonData == nil {
+		fmt.Printf("schemaValue: unexpected nil value\n")
 		return nil
-	case yaml.MapSlice:
+	}
+	switch jsonData.Kind {
+	default:
+		fmt.Printf("schemaValue: unexpected node %s\n", nodeDescription(jsonData))
+	case yaml.MappingNode:
 		schema := &Schema{}
-		for _, mapItem := range t {
-			k := mapItem.Key.(string)
-			v := mapItem.Value
+		for i := 0; i < len(jsonData.Content); i += 2 {
+			k := jsonData.Content[i].Value
+			v := resolveNode(jsonData.Content[i+1])
 
 			switch k {
 			case "$schema":
@@ -126,7 +141,7 @@ func NewSchemaFromObject(jsonData interface{}) *Schema {
 				schema.Description = schema.stringValue(v)
 
 			case "default":
-				schema.Default = &v
+				schema.Default = v
 
 			case "format":
 				schema.Format = schema.stringValue(v)
@@ -149,108 +164,154 @@ func NewSchemaFromObject(jsonData interface{}) *Schema {
 	return nil
 }
 
+// Returns the node that a node represents, following aliases
+// and unwrapping documents.
+func resolveNode(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind {
+		case yaml.AliasNode:
+			node = node.Alias
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return nil
+			}
+			node = node.Content[0]
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// Describes a node for use in diagnostic messages.
+func nodeDescription(node *yaml.Node) string {
+	if node == nil {
+		return "<nil>"
+	}
+	if node.Kind == yaml.ScalarNode {
+		return fmt.Sprintf("%s (%s) at line %d", node.Value, node.ShortTag(), node.Line)
+	}
+	kinds := map[yaml.Kind]string{
+		yaml.DocumentNode: "document",
+		yaml.SequenceNode: "sequence",
+		yaml.MappingNode:  "mapping",
+		yaml.AliasNode:    "alias",
+	}
+	return fmt.Sprintf("%s at line %d", kinds[node.Kind], node.Line)
+}
+
+// Returns the nodes in a sequence, following aliases.
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	items := make([]*yaml.Node, 0)
+	for _, item := range node.Content {
+		items = append(items, resolveNode(item))
+	}
+	return items
+}
+
 //
 // BUILDERS
-// The following methods build elements of Schemas from interface{} values.
+// The following methods build elements of Schemas from yaml.Node values.
 // Each returns nil if it is unable to build the desired element.
 //
 
-// Gets the string value of an interface{} value if possible.
-func (schema *Schema) stringValue(v interface{}) *string {
-	switch v := v.(type) {
-	default:
-		fmt.Printf("stringValue: unexpected type %T\n", v)
-	case string:
-		return &v
+// Gets the string value of a node if possible.
+func (schema *Schema) stringValue(v *yaml.Node) *string {
+	if v.Kind == yaml.ScalarNode && v.ShortTag() == "!!str" {
+		s := v.Value
+		return &s
 	}
+	fmt.Printf("stringValue: unexpected node %s\n", nodeDescription(v))
 	return nil
 }
 
-// Gets the numeric value of an interface{} value if possible.
-func (schema *Schema) numberValue(v interface{}) *SchemaNumber {
+// Gets the numeric value of a node if possible.
+func (schema *Schema) numberValue(v *yaml.Node) *SchemaNumber {
 	number := &SchemaNumber{}
-	switch v := v.(type) {
-	default:
-		fmt.Printf("numberValue: unexpected type %T\n", v)
-	case float64:
-		v2 := float64(v)
-		number.Float = &v2
-		return number
-	case float32:
-		v2 := float64(v)
-		number.Float = &v2
-		return number
-	case int:
-		v2 := int64(v)
-		number.Integer = &v2
+	if v.Kind == yaml.ScalarNode {
+		switch v.ShortTag() {
+		case "!!float":
+			if f, err := strconv.ParseFloat(v.Value, 64); err == nil {
+				number.Float = &f
+				return number
+			}
+		case "!!int":
+			var i int64
+			if err := v.Decode(&i); err == nil {
+				number.Integer = &i
+				return number
+			}
+		}
 	}
+	fmt.Printf("numberValue: unexpected node %s\n", nodeDescription(v))
 	return nil
 }
 
-// Gets the integer value of an interface{} value if possible.
-func (schema *Schema) intValue(v interface{}) *int64 {
-	switch v := v.(type) {
-	default:
-		fmt.Printf("intValue: unexpected type %T\n", v)
-	case float64:
-		v2 := int64(v)
-		return &v2
-	case int64:
-		return &v
-	case int:
-		v2 := int64(v)
-		return &v2
+// Gets the integer value of a node if possible.
+func (schema *Schema) intValue(v *yaml.Node) *int64 {
+	if v.Kind == yaml.ScalarNode {
+		switch v.ShortTag() {
+		case "!!float":
+			if f, err := strconv.ParseFloat(v.Value, 64); err == nil {
+				i := int64(f)
+				return &i
+			}
+		case "!!int":
+			var i int64
+			if err := v.Decode(&i); err == nil {
+				return &i
+			}
+		}
 	}
+	fmt.Printf("intValue: unexpected node %s\n", nodeDescription(v))
 	return nil
 }
 
-// Gets the bool value of an interface{} value if possible.
-func (schema *Schema) boolValue(v interface{}) *bool {
-	switch v := v.(type) {
-	default:
-		fmt.Printf("boolValue: unexpected type %T\n", v)
-	case bool:
-		return &v
+// Gets the bool value of a node if possible.
+func (schema *Schema) boolValue(v *yaml.Node) *bool {
+	if v.Kind == yaml.ScalarNode && v.ShortTag() == "!!bool" {
+		var b bool
+		if err := v.Decode(&b); err == nil {
+			return &b
+		}
 	}
+	fmt.Printf("boolValue: unexpected node %s\n", nodeDescription(v))
 	return nil
 }
 
-// Gets a map of Schemas from an interface{} value if possible.
-func (schema *Schema) mapOfSchemasValue(v interface{}) *[]*NamedSchema {
-	switch v := v.(type) {
-	default:
-		fmt.Printf("mapOfSchemasValue: unexpected type %T\n", v)
-	case yaml.MapSlice:
-		m := make([]*NamedSchema, 0)
-		for _, mapItem := range v {
-			k2 := mapItem.Key.(string)
-			v2 := mapItem.Value
-			pair := &NamedSchema{Name: k2, Value: NewSchemaFromObject(v2)}
-			m = append(m, pair)
-		}
-		return &m
+// Gets a map of Schemas from a node if possible.
+func (schema *Schema) mapOfSchemasValue(v *yaml.Node) *[]*NamedSchema {
+	if v.Kind != yaml.MappingNode {
+		fmt.Printf("mapOfSchemasValue: unexpected node %s\n", nodeDescription(v))
+		return nil
 	}
-	return nil
+	m := make([]*NamedSchema, 0)
+	for i := 0; i < len(v.Content); i += 2 {
+		k2 := v.Content[i].Value
+		v2 := v.Content[i+1]
+		pair := &NamedSchema{Name: k2, Value: NewSchemaFromObject(v2)}
+		m = append(m, pair)
+	}
+	return &m
 }
 
-// Gets an array of Schemas from an interface{} value if possible.
-func (schema *Schema) arrayOfSchemasValue(v interface{}) *[]*Schema {
-	switch v := v.(type) {
+// Gets an array of Schemas from a node if possible.
+func (schema *Schema) arrayOfSchemasValue(v *yaml.Node) *[]*Schema {
+	switch v.Kind {
 	default:
-		fmt.Printf("arrayOfSchemasValue: unexpected type %T\n", v)
-	case []interface{}:
+		fmt.Printf("arrayOfSchemasValue: unexpected node %s\n", nodeDescription(v))
+	case yaml.SequenceNode:
 		m := make([]*Schema, 0)
-		for _, v2 := range v {
-			switch v2 := v2.(type) {
-			default:
-				fmt.Printf("arrayOfSchemasValue: unexpected type %T\n", v2)
-			case yaml.MapSlice:
+		for _, v2 := range sequenceItems(v) {
+			if v2.Kind == yaml.MappingNode {
 				s := NewSchemaFromObject(v2)
 				m = append(m, s)
+			} else {
+				fmt.Printf("arrayOfSchemasValue: unexpected node %s\n", nodeDescription(v2))
 			}
 		}
 		return &m
-	case yaml.MapSlice:
+	case yaml.MappingNode:
 		m := make([]*Schema, 0)
 		s := NewSchemaFromObject(v)
 		m = append(m, s)
@@ -259,151 +320,134 @@ func (schema *Schema) arrayOfSchemasValue(v interface{}) *[]*Schema {
 	return nil
 }
 
-// Gets a Schema or an array of Schemas from an interface{} value if possible.
-func (schema *Schema) schemaOrSchemaArrayValue(v interface{}) *SchemaOrSchemaArray {
-	switch v := v.(type) {
+// Gets a Schema or an array of Schemas from a node if possible.
+func (schema *Schema) schemaOrSchemaArrayValue(v *yaml.Node) *SchemaOrSchemaArray {
+	switch v.Kind {
 	default:
-		fmt.Printf("schemaOrSchemaArrayValue: unexpected type %T\n", v)
-	case []interface{}:
+		fmt.Printf("schemaOrSchemaArrayValue: unexpected node %s\n", nodeDescription(v))
+	case yaml.SequenceNode:
 		m := make([]*Schema, 0)
-		for _, v2 := range v {
-			switch v2 := v2.(type) {
-			default:
-				fmt.Printf("schemaOrSchemaArrayValue: unexpected type %T\n", v2)
-			case map[string]interface{}:
+		for _, v2 := range sequenceItems(v) {
+			if v2.Kind == yaml.MappingNode {
 				s := NewSchemaFromObject(v2)
 				m = append(m, s)
+			} else {
+				fmt.Printf("schemaOrSchemaArrayValue: unexpected node %s\n", nodeDescription(v2))
 			}
 		}
 		return &SchemaOrSchemaArray{SchemaArray: &m}
-	case yaml.MapSlice:
+	case yaml.MappingNode:
 		s := NewSchemaFromObject(v)
 		return &SchemaOrSchemaArray{Schema: s}
 	}
 	return nil
 }
 
-// Gets an array of strings from an interface{} value if possible.
-func (schema *Schema) arrayOfStringsValue(v interface{}) *[]string {
-	switch v := v.(type) {
-	default:
-		fmt.Printf("arrayOfStringsValue: unexpected type %T\n", v)
-	case []string:
-		return &v
-	case string:
-		a := []string{v}
-		return &a
-	case []interface{}:
-		a := make([]string, 0)
-		for _, v2 := range v {
-			switch v2 := v2.(type) {
-			default:
-				fmt.Printf("arrayOfStringsValue: unexpected type %T\n", v2)
-			case string:
-				a = append(a, v2)
-			}
+// Gets the strings in a sequence node, reporting any other values.
+func stringsForSequenceNode(v *yaml.Node, caller string) []string {
+	a := make([]string, 0)
+	for _, v2 := range sequenceItems(v) {
+		if v2.Kind == yaml.ScalarNode && v2.ShortTag() == "!!str" {
+			a = append(a, v2.Value)
+		} else {
+			fmt.Printf("%s: unexpected node %s\n", caller, nodeDescription(v2))
+		}
+	}
+	return a
+}
+
+// Gets an array of strings from a node if possible.
+func (schema *Schema) arrayOfStringsValue(v *yaml.Node) *[]string {
+	switch v.Kind {
+	case yaml.ScalarNode:
+		if v.ShortTag() == "!!str" {
+			a := []string{v.Value}
+			return &a
 		}
+	case yaml.SequenceNode:
+		a := stringsForSequenceNode(v, "arrayOfStringsValue")
 		return &a
 	}
+	fmt.Printf("arrayOfStringsValue: unexpected node %s\n", nodeDescription(v))
 	return nil
 }
 
-// Gets a string or an array of strings from an interface{} value if possible.
-func (schema *Schema) stringOrStringArrayValue(v interface{}) *StringOrStringArray {
-	switch v := v.(type) {
-	default:
-		fmt.Printf("arrayOfStringsValue: unexpected type %T\n", v)
-	case []string:
-		s := &StringOrStringArray{}
-		s.StringArray = &v
-		return s
-	case string:
-		s := &StringOrStringArray{}
-		s.String = &v
-		return s
-	case []interface{}:
-		a := make([]string, 0)
-		for _, v2 := range v {
-			switch v2 := v2.(type) {
-			default:
-				fmt.Printf("arrayOfStringsValue: unexpected type %T\n", v2)
-			case string:
-				a = append(a, v2)
-			}
+// Gets a string or an array of strings from a node if possible.
+func (schema *Schema) stringOrStringArrayValue(v *yaml.Node) *StringOrStringArray {
+	switch v.Kind {
+	case yaml.ScalarNode:
+		if v.ShortTag() == "!!str" {
+			s := &StringOrStringArray{}
+			value := v.Value
+			s.String = &value
+			return s
 		}
+	case yaml.SequenceNode:
+		a := stringsForSequenceNode(v, "arrayOfStringsValue")
 		s := &StringOrStringArray{}
 		s.StringArray = &a
 		return s
 	}
+	fmt.Printf("arrayOfStringsValue: unexpected node %s\n", nodeDescription(v))
 	return nil
 }
 
-// Gets an array of enum values from an interface{} value if possible.
-func (schema *Schema) arrayOfEnumValuesValue(v interface{}) *[]SchemaEnumValue {
+// Gets an array of enum values from a node if possible.
+func (schema *Schema) arrayOfEnumValuesValue(v *yaml.Node) *[]SchemaEnumValue {
 	a := make([]SchemaEnumValue, 0)
-	switch v := v.(type) {
-	default:
-		fmt.Printf("arrayOfEnumValuesValue: unexpected type %T\n", v)
-	case []interface{}:
-		for _, v2 := range v {
-			switch v2 := v2.(type) {
-			default:
-				fmt.Printf("arrayOfEnumValuesValue: unexpected type %T\n", v2)
-			case string:
-				a = append(a, SchemaEnumValue{String: &v2})
-			case bool:
-				a = append(a, SchemaEnumValue{Bool: &v2})
-			}
+	if v.Kind != yaml.SequenceNode {
+		fmt.Printf("arrayOfEnumValuesValue: unexpected node %s\n", nodeDescription(v))
+		return &a
+	}
+	for _, v2 := range sequenceItems(v) {
+		switch {
+		case v2.Kind == yaml.ScalarNode && v2.ShortTag() == "!!str":
+			s := v2.Value
+			a = append(a, SchemaEnumValue{String: &s})
+		case v2.Kind == yaml.ScalarNode && v2.ShortTag() == "!!bool":
+			var b bool
+			v2.Decode(&b)
+			a = append(a, SchemaEnumValue{Bool: &b})
+		default:
+			fmt.Printf("arrayOfEnumValuesValue: unexpected node %s\n", nodeDescription(v2))
 		}
 	}
 	return &a
 }
 
-// Gets a map of schemas or string arrays from an interface{} value if possible.
-func (schema *Schema) mapOfSchemasOrStringArraysValue(v interface{}) *[]*NamedSchemaOrStringArray {
+// Gets a map of schemas or string arrays from a node if possible.
+func (schema *Schema) mapOfSchemasOrStringArraysValue(v *yaml.Node) *[]*NamedSchemaOrStringArray {
 	m := make([]*NamedSchemaOrStringArray, 0)
-	switch v := v.(type) {
-	default:
-		fmt.Printf("mapOfSchemasOrStringArraysValue: unexpected type %T %+v\n", v, v)
-	case yaml.MapSlice:
-		for _, mapItem := range v {
-			k2 := mapItem.Key.(string)
-			v2 := mapItem.Value
-			switch v2 := v2.(type) {
-			default:
-				fmt.Printf("mapOfSchemasOrStringArraysValue: unexpected type %T %+v\n", v2, v2)
-			case []interface{}:
-				a := make([]string, 0)
-				for _, v3 := range v2 {
-					switch v3 := v3.(type) {
-					default:
-						fmt.Printf("mapOfSchemasOrStringArraysValue: unexpected type %T %+v\n", v3, v3)
-					case string:
-						a = append(a, v3)
-					}
-				}
-				s := &SchemaOrStringArray{}
-				s.StringArray = &a
-				pair := &NamedSchemaOrStringArray{Name: k2, Value: s}
-				m = append(m, pair)
-			}
+	if v.Kind != yaml.MappingNode {
+		fmt.Printf("mapOfSchemasOrStringArraysValue: unexpected node %s\n", nodeDescription(v))
+		return &m
+	}
+	for i := 0; i < len(v.Content); i += 2 {
+		k2 := v.Content[i].Value
+		v2 := resolveNode(v.Content[i+1])
+		if v2.Kind == yaml.SequenceNode {
+			a := stringsForSequenceNode(v2, "mapOfSchemasOrStringArraysValue")
+			s := &SchemaOrStringArray{}
+			s.StringArray = &a
+			pair := &NamedSchemaOrStringArray{Name: k2, Value: s}
+			m = append(m, pair)
+		} else {
+			fmt.Printf("mapOfSchemasOrStringArraysValue: unexpected node %s\n", nodeDescription(v2))
 		}
 	}
 	return &m
 }
 
-// Gets a schema or a boolean value from an interface{} value if possible.
-func (schema *Schema) schemaOrBooleanValue(v interface{}) *SchemaOrBoolean {
+// Gets a schema or a boolean value from a node if possible.
+func (schema *Schema) schemaOrBooleanValue(v *yaml.Node) *SchemaOrBoolean {
 	schemaOrBoolean := &SchemaOrBoolean{}
-	switch v := v.(type) {
-	case bool:
-		schemaOrBoolean.Boolean = &v
-	case yaml.MapSlice:
+	switch {
+	case v.Kind == yaml.ScalarNode && v.ShortTag() == "!!bool":
+		schemaOrBoolean.Boolean = schema.boolValue(v)
+	case v.Kind == yaml.MappingNode:
 		schemaOrBoolean.Schema = NewSchemaFromObject(v)
 	default:
-		fmt.Printf("schemaOrBooleanValue: unexpected type %T\n", v)
-	case []map[string]interface{}:
-
+		fmt.Printf("schemaOrBooleanValue: unexpected node %s\n", nodeDescription(v))
 	}
 	return schemaOrBoolean
 }
@@ -0,0 +1,448 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+//
+// VALIDATION
+// The following functions check values against the constraints of
+// a Schema. Values are yaml.Nodes, so they can be read from JSON or
+// YAML, and errors report the positions of the values that fail.
+//
+
+// ValidationError describes a value that doesn't satisfy a Schema.
+type ValidationError struct {
+	Path    string // JSON pointer to the value
+	Line    int    // position of the value in its source, if known
+	Column  int
+	Message string
+}
+
+func (e *ValidationError) String() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("%s (line %d, column %d): %s", path, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", path, e.Message)
+}
+
+// ValidationErrors is returned when a value doesn't satisfy a Schema.
+type ValidationErrors struct {
+	Errors []*ValidationError
+}
+
+func (err *ValidationErrors) Error() string {
+	messages := make([]string, 0)
+	for _, e := range err.Errors {
+		messages = append(messages, e.String())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Validator checks values against a Schema. References in the Schema are
+// resolved within the Schema itself or within the other Schemas that the
+// Validator was created with, which are identified by their ids.
+type Validator struct {
+	root      *Schema
+	documents map[string]*Schema
+	patterns  map[string]*regexp.Regexp
+}
+
+// NewValidator creates a Validator for a Schema. Schemas that it refers to,
+// such as the draft 4 meta-schema, can be passed as references.
+func NewValidator(schema *Schema, references ...*Schema) *Validator {
+	v := &Validator{
+		root:      schema,
+		documents: make(map[string]*Schema, 0),
+		patterns:  make(map[string]*regexp.Regexp, 0),
+	}
+	for _, document := range append(references, schema) {
+		if document != nil && document.Id != nil {
+			v.documents[strings.TrimSuffix(*document.Id, "#")] = document
+		}
+	}
+	return v
+}
+
+// Validate checks a value against a Schema that only refers to itself.
+func (schema *Schema) Validate(value *yaml.Node) error {
+	return NewValidator(schema).Validate(value)
+}
+
+// Validate checks a value against the Schema of a Validator and returns
+// a *ValidationErrors that describes each constraint that it fails.
+func (v *Validator) Validate(value *yaml.Node) error {
+	errors := v.validate(v.root, v.root, resolveNode(value), "")
+	if len(errors) == 0 {
+		return nil
+	}
+	return &ValidationErrors{Errors: errors}
+}
+
+// Checks a value against a schema that is part of a document,
+// which is the Schema that local references are resolved in.
+func (v *Validator) validate(schema *Schema, document *Schema, value *yaml.Node, path string) []*ValidationError {
+	errors := make([]*ValidationError, 0)
+	fail := func(format string, args ...interface{}) {
+		e := &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)}
+		if value != nil {
+			e.Line, e.Column = value.Line, value.Column
+		}
+		errors = append(errors, e)
+	}
+	if schema == nil || value == nil {
+		return errors
+	}
+	if schema.Ref != nil {
+		// in draft 4, the other keywords of a schema with a reference are ignored
+		referenced, referencedDocument, err := v.resolveRef(*schema.Ref, document)
+		if err != nil {
+			fail("%s", err.Error())
+			return errors
+		}
+		return v.validate(referenced, referencedDocument, value, path)
+	}
+
+	if schema.Type != nil && !typeMatches(schema.Type, value) {
+		fail("expected %s, got %s", schema.Type.Description(), valueType(value))
+		return errors
+	}
+	if schema.Enumeration != nil && len(*schema.Enumeration) > 0 && !enumerationContains(*schema.Enumeration, value) {
+		fail("value is not one of %s", enumerationString(schema.Enumeration))
+	}
+
+	switch value.Kind {
+	case yaml.ScalarNode:
+		errors = append(errors, v.validateScalar(schema, value, path)...)
+	case yaml.SequenceNode:
+		errors = append(errors, v.validateArray(schema, document, value, path)...)
+	case yaml.MappingNode:
+		errors = append(errors, v.validateObject(schema, document, value, path)...)
+	}
+
+	if schema.AllOf != nil {
+		for _, s := range *schema.AllOf {
+			errors = append(errors, v.validate(s, document, value, path)...)
+		}
+	}
+	if schema.AnyOf != nil {
+		matched := false
+		for _, s := range *schema.AnyOf {
+			if len(v.validate(s, document, value, path)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("value does not match any schema in anyOf")
+		}
+	}
+	if schema.OneOf != nil {
+		matches := 0
+		for _, s := range *schema.OneOf {
+			if len(v.validate(s, document, value, path)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			fail("value matches %d schemas in oneOf, expected exactly 1", matches)
+		}
+	}
+	if schema.Not != nil && len(v.validate(schema.Not, document, value, path)) == 0 {
+		fail("value matches a schema that it must not match")
+	}
+	return errors
+}
+
+// Checks the string and numeric constraints of a schema.
+func (v *Validator) validateScalar(schema *Schema, value *yaml.Node, path string) []*ValidationError {
+	errors := make([]*ValidationError, 0)
+	fail := func(format string, args ...interface{}) {
+		errors = append(errors, &ValidationError{Path: path, Line: value.Line, Column: value.Column, Message: fmt.Sprintf(format, args...)})
+	}
+	switch valueType(value) {
+	case "string":
+		length := int64(utf8.RuneCountInString(value.Value))
+		if schema.MinLength != nil && length < *schema.MinLength {
+			fail("string is shorter than %d characters", *schema.MinLength)
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			fail("string is longer than %d characters", *schema.MaxLength)
+		}
+		if schema.Pattern != nil {
+			if pattern := v.pattern(*schema.Pattern); pattern != nil && !pattern.MatchString(value.Value) {
+				fail("string does not match pattern %q", *schema.Pattern)
+			}
+		}
+	case "integer", "number":
+		f, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			return errors
+		}
+		if schema.Minimum != nil {
+			minimum := schema.Minimum.floatValue()
+			if schema.ExclusiveMinimum != nil && *schema.ExclusiveMinimum {
+				if f <= minimum {
+					fail("value must be greater than %g", minimum)
+				}
+			} else if f < minimum {
+				fail("value must be at least %g", minimum)
+			}
+		}
+		if schema.Maximum != nil {
+			maximum := schema.Maximum.floatValue()
+			if schema.ExclusiveMaximum != nil && *schema.ExclusiveMaximum {
+				if f >= maximum {
+					fail("value must be less than %g", maximum)
+				}
+			} else if f > maximum {
+				fail("value must be at most %g", maximum)
+			}
+		}
+		if schema.MultipleOf != nil {
+			if divisor := schema.MultipleOf.floatValue(); divisor > 0 {
+				if quotient := f / divisor; math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+					fail("value is not a multiple of %g", divisor)
+				}
+			}
+		}
+	}
+	return errors
+}
+
+// Checks the array constraints of a schema.
+func (v *Validator) validateArray(schema *Schema, document *Schema, value *yaml.Node, path string) []*ValidationError {
+	errors := make([]*ValidationError, 0)
+	fail := func(format string, args ...interface{}) {
+		errors = append(errors, &ValidationError{Path: path, Line: value.Line, Column: value.Column, Message: fmt.Sprintf(format, args...)})
+	}
+	items := sequenceItems(value)
+	count := int64(len(items))
+	if schema.MinItems != nil && count < *schema.MinItems {
+		fail("array has fewer than %d items", *schema.MinItems)
+	}
+	if schema.MaxItems != nil && count > *schema.MaxItems {
+		fail("array has more than %d items", *schema.MaxItems)
+	}
+	if schema.UniqueItems != nil && *schema.UniqueItems {
+		seen := make(map[string]bool, 0)
+		for _, item := range items {
+			key := Render(item)
+			if seen[key] {
+				fail("array items are not unique")
+				break
+			}
+			seen[key] = true
+		}
+	}
+	if schema.Items != nil {
+		for i, item := range items {
+			itemPath := path + "/" + strconv.Itoa(i)
+			if schema.Items.Schema != nil {
+				errors = append(errors, v.validate(schema.Items.Schema, document, item, itemPath)...)
+			} else if schema.Items.SchemaArray != nil {
+				if i < len(*schema.Items.SchemaArray) {
+					errors = append(errors, v.validate((*schema.Items.SchemaArray)[i], document, item, itemPath)...)
+				} else if additional := schema.AdditionalItems; additional != nil {
+					if additional.Boolean != nil && !*additional.Boolean {
+						fail("array has more than %d items", len(*schema.Items.SchemaArray))
+						break
+					}
+					errors = append(errors, v.validate(additional.Schema, document, item, itemPath)...)
+				}
+			}
+		}
+	}
+	return errors
+}
+
+// Checks the object constraints of a schema.
+func (v *Validator) validateObject(schema *Schema, document *Schema, value *yaml.Node, path string) []*ValidationError {
+	errors := make([]*ValidationError, 0)
+	fail := func(format string, args ...interface{}) {
+		errors = append(errors, &ValidationError{Path: path, Line: value.Line, Column: value.Column, Message: fmt.Sprintf(format, args...)})
+	}
+	properties := make(map[string]*yaml.Node, 0)
+	names := make([]string, 0)
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		name := value.Content[i].Value
+		if _, found := properties[name]; !found {
+			names = append(names, name)
+		}
+		properties[name] = resolveNode(value.Content[i+1])
+	}
+	count := int64(len(names))
+	if schema.MinProperties != nil && count < *schema.MinProperties {
+		fail("object has fewer than %d properties", *schema.MinProperties)
+	}
+	if schema.MaxProperties != nil && count > *schema.MaxProperties {
+		fail("object has more than %d properties", *schema.MaxProperties)
+	}
+	if schema.Required != nil {
+		for _, name := range *schema.Required {
+			if _, found := properties[name]; !found {
+				fail("missing required property %q", name)
+			}
+		}
+	}
+	for _, name := range names {
+		propertyValue := properties[name]
+		propertyPath := path + "/" + escapeJSONPointer(name)
+		matched := false
+		if property := schema.PropertyWithName(name); property != nil {
+			matched = true
+			errors = append(errors, v.validate(property, document, propertyValue, propertyPath)...)
+		}
+		if schema.PatternProperties != nil {
+			for _, pair := range *schema.PatternProperties {
+				if pattern := v.pattern(pair.Name); pattern != nil && pattern.MatchString(name) {
+					matched = true
+					errors = append(errors, v.validate(pair.Value, document, propertyValue, propertyPath)...)
+				}
+			}
+		}
+		if !matched && schema.AdditionalProperties != nil {
+			if additional := schema.AdditionalProperties; additional.Boolean != nil && !*additional.Boolean {
+				fail("property %q is not allowed", name)
+			} else {
+				errors = append(errors, v.validate(additional.Schema, document, propertyValue, propertyPath)...)
+			}
+		}
+	}
+	if schema.Dependencies != nil {
+		for _, pair := range *schema.Dependencies {
+			if _, found := properties[pair.Name]; !found || pair.Value == nil {
+				continue
+			}
+			if pair.Value.StringArray != nil {
+				for _, name := range *pair.Value.StringArray {
+					if _, found := properties[name]; !found {
+						fail("property %q is required by property %q", name, pair.Name)
+					}
+				}
+			} else {
+				errors = append(errors, v.validate(pair.Value.Schema, document, value, path)...)
+			}
+		}
+	}
+	return errors
+}
+
+// Returns the schema that a reference refers to and the document that contains it.
+func (v *Validator) resolveRef(ref string, document *Schema) (*Schema, *Schema, error) {
+	parts := strings.SplitN(ref, "#", 2)
+	if parts[0] != "" {
+		document = v.documents[parts[0]]
+		if document == nil {
+			return nil, nil, fmt.Errorf("unknown schema %s", parts[0])
+		}
+	}
+	fragment := "#"
+	if len(parts) == 2 {
+		fragment += parts[1]
+	}
+	schema, err := document.SchemaForPointer(fragment)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unresolved reference %s", ref)
+	}
+	return schema, document, nil
+}
+
+// Returns a compiled pattern, or nil if the pattern is invalid.
+// Invalid patterns are ignored, since they can't be matched.
+func (v *Validator) pattern(expression string) *regexp.Regexp {
+	if pattern, found := v.patterns[expression]; found {
+		return pattern
+	}
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		pattern = nil
+	}
+	v.patterns[expression] = pattern
+	return pattern
+}
+
+// Returns the JSON type of a value.
+func valueType(value *yaml.Node) string {
+	switch value.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch value.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+// Returns true if a value has one of the types of a schema.
+// Integers are also numbers.
+func typeMatches(types *StringOrStringArray, value *yaml.Node) bool {
+	typeNames := typeSet(types)
+	if typeNames == nil {
+		return true
+	}
+	actual := valueType(value)
+	for _, typeName := range *typeNames {
+		if typeName == actual || (typeName == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if a value is one of the values of an enumeration.
+// Schemas only hold the string and boolean values of enumerations,
+// so numbers are not checked.
+func enumerationContains(enumeration []SchemaEnumValue, value *yaml.Node) bool {
+	if actual := valueType(value); actual == "integer" || actual == "number" {
+		return true
+	}
+	for _, enumValue := range enumeration {
+		switch {
+		case enumValue.String != nil && valueType(value) == "string":
+			if *enumValue.String == value.Value {
+				return true
+			}
+		case enumValue.Bool != nil && valueType(value) == "boolean":
+			var b bool
+			if err := value.Decode(&b); err == nil && b == *enumValue.Bool {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidate(t *testing.T) {
+	schema, err := NewSchemaFromBytes([]byte(`
+type: object
+required: [name]
+properties:
+  name: {type: string, minLength: 1}
+  size: {type: integer, minimum: 0, exclusiveMinimum: true}
+  tags: {type: array, items: {type: string}, uniqueItems: true}
+  kind: {$ref: "#/definitions/kind"}
+additionalProperties: false
+patternProperties:
+  "^x-": {}
+definitions:
+  kind: {enum: [cat, dog]}
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	valid := `{name: Rex, size: 3, tags: [good, loud], kind: dog, x-owner: Ann}`
+	invalid := `
+size: 0
+tags: [good, good]
+kind: bird
+color: brown
+`
+	var info yaml.Node
+	yaml.Unmarshal([]byte(valid), &info)
+	if err = schema.Validate(&info); err != nil {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+	yaml.Unmarshal([]byte(invalid), &info)
+	err = schema.Validate(&info)
+	errors, ok := err.(*ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected validation errors, got %+v", err)
+	}
+	expected := []string{
+		`/ (line 2, column 1): missing required property "name"`,
+		`/size (line 2, column 7): value must be greater than 0`,
+		`/tags (line 3, column 7): array items are not unique`,
+		`/kind (line 4, column 7): value is not one of ["cat", "dog"]`,
+		`/ (line 2, column 1): property "color" is not allowed`,
+	}
+	if len(errors.Errors) != len(expected) {
+		t.Fatalf("Unexpected errors:\n%s", err.Error())
+	}
+	for i, e := range errors.Errors {
+		if e.String() != expected[i] {
+			t.Errorf("Expected %s, got %s", expected[i], e.String())
+		}
+	}
+}
+
+func TestMetaSchema(t *testing.T) {
+	metaSchema, err := MetaSchema()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	// the meta-schema describes itself
+	var info yaml.Node
+	yaml.Unmarshal(MetaSchemaJSON(), &info)
+	if err = metaSchema.Validate(&info); err != nil {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+	yaml.Unmarshal([]byte(`{type: [string, string], required: []}`), &info)
+	err = metaSchema.Validate(&info)
+	if err == nil || !strings.Contains(err.Error(), "/type") || !strings.Contains(err.Error(), "/required") {
+		t.Errorf("Expected errors for an invalid schema, got %v", err)
+	}
+}
@@ -16,88 +16,77 @@ package jsonschema
 
 import (
 	"fmt"
-	"gopkg.in/yaml.v2"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
 )
 
 const INDENT = "  "
 
-func renderMap(info interface{}, indent string) (result string) {
-	result = "{\n"
-	inner_indent := indent + INDENT
-	switch pairs := info.(type) {
-	case yaml.MapSlice:
-		for i, pair := range pairs {
-			// first print the key
-			result += fmt.Sprintf("%s\"%+v\": ", inner_indent, pair.Key)
-			// then the value
-			switch value := pair.Value.(type) {
-			case string:
-				result += "\"" + value + "\""
-			case bool:
-				if value {
-					result += "true"
-				} else {
-					result += "false"
-				}
-			case []interface{}:
-				result += renderArray(value, inner_indent)
-			case yaml.MapSlice:
-				result += renderMap(value, inner_indent)
-			case int:
-				result += fmt.Sprintf("%d", value)
-			case int64:
-				result += fmt.Sprintf("%d", value)
-			case []string:
-				result += renderStringArray(value, inner_indent)
-			default:
-				result += fmt.Sprintf("???MapItem(%+v, %T)", value, value)
-			}
-			if i < len(pairs)-1 {
-				result += ","
-			}
-			result += "\n"
+func renderValue(node *yaml.Node, indent string) string {
+	node = resolveNode(node)
+	if node == nil {
+		return "null"
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		return renderMap(node, indent)
+	case yaml.SequenceNode:
+		return renderArray(node, indent)
+	case yaml.ScalarNode:
+		return renderScalar(node)
+	}
+	return fmt.Sprintf("???Node(%s)", nodeDescription(node))
+}
+
+func renderScalar(node *yaml.Node) string {
+	switch node.ShortTag() {
+	case "!!str":
+		return "\"" + node.Value + "\""
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err == nil {
+			return strconv.FormatBool(b)
 		}
-	default:
-		// t is some other type that we didn't name.
+	case "!!int":
+		var i int64
+		if err := node.Decode(&i); err == nil {
+			return fmt.Sprintf("%d", i)
+		}
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err == nil {
+			return fmt.Sprintf("%g", f)
+		}
+	case "!!null":
+		return "null"
 	}
-
-	result += indent + "}"
-	return result
+	return fmt.Sprintf("???Scalar(%s)", nodeDescription(node))
 }
 
-func renderArray(array []interface{}, indent string) (result string) {
-	result = "[\n"
+func renderMap(node *yaml.Node, indent string) (result string) {
+	result = "{\n"
 	inner_indent := indent + INDENT
-	for i, item := range array {
-		switch item := item.(type) {
-		case string:
-			result += inner_indent + "\"" + item + "\""
-		case bool:
-			if item {
-				result += inner_indent + "true"
-			} else {
-				result += inner_indent + "false"
-			}
-		case yaml.MapSlice:
-			result += inner_indent + renderMap(item, inner_indent) + ""
-		default:
-			result += inner_indent + fmt.Sprintf("???ArrayItem(%+v)", item)
-		}
-		if i < len(array)-1 {
+	for i := 0; i < len(node.Content); i += 2 {
+		// first print the key
+		result += fmt.Sprintf("%s\"%+v\": ", inner_indent, node.Content[i].Value)
+		// then the value
+		result += renderValue(node.Content[i+1], inner_indent)
+		if i < len(node.Content)-2 {
 			result += ","
 		}
 		result += "\n"
 	}
-	result += indent + "]"
+	result += indent + "}"
 	return result
 }
 
-func renderStringArray(array []string, indent string) (result string) {
+func renderArray(node *yaml.Node, indent string) (result string) {
 	result = "[\n"
 	inner_indent := indent + INDENT
-	for i, item := range array {
-		result += inner_indent + "\"" + item + "\""
-		if i < len(array)-1 {
+	for i, item := range node.Content {
+		result += inner_indent + renderValue(item, inner_indent)
+		if i < len(node.Content)-1 {
 			result += ","
 		}
 		result += "\n"
@@ -106,223 +95,251 @@ func renderStringArray(array []string, indent string) (result string) {
 	return result
 }
 
-func Render(info yaml.MapSlice) string {
-	return renderMap(info, "") + "\n"
+// Render returns the JSON text for a node.
+func Render(info *yaml.Node) string {
+	return renderValue(info, "") + "\n"
+}
+
+//
+// NODES
+// The following functions build the yaml.Nodes that represent Schemas.
+//
+
+func newMappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Content: make([]*yaml.Node, 0)}
+}
+
+func newSequenceNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Content: make([]*yaml.Node, 0)}
+}
+
+func nodeForString(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
+
+func nodeForBool(b bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(b)}
+}
+
+func nodeForInt64(i int64) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(i, 10)}
+}
+
+func nodeForFloat64(f float64) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(f, 'g', -1, 64)}
 }
 
-func (object *SchemaNumber) jsonValue() interface{} {
+func nodeForNull() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+func nodeForStringArray(array []string) *yaml.Node {
+	node := newSequenceNode()
+	for _, item := range array {
+		node.Content = append(node.Content, nodeForString(item))
+	}
+	return node
+}
+
+func appendPair(m *yaml.Node, key string, value *yaml.Node) {
+	m.Content = append(m.Content, nodeForString(key), value)
+}
+
+func (object *SchemaNumber) jsonValue() *yaml.Node {
 	if object.Integer != nil {
-		return object.Integer
+		return nodeForInt64(*object.Integer)
 	} else if object.Float != nil {
-		return object.Float
+		return nodeForFloat64(*object.Float)
 	} else {
-		return nil
+		return nodeForNull()
 	}
 }
 
-func (object *SchemaOrBoolean) jsonValue() interface{} {
+func (object *SchemaOrBoolean) jsonValue() *yaml.Node {
 	if object.Schema != nil {
 		return object.Schema.jsonValue()
 	} else if object.Boolean != nil {
-		return *object.Boolean
+		return nodeForBool(*object.Boolean)
 	} else {
-		return nil
+		return nodeForNull()
 	}
 }
 
-func (object *StringOrStringArray) jsonValue() interface{} {
+func (object *StringOrStringArray) jsonValue() *yaml.Node {
 	if object.String != nil {
-		return *object.String
+		return nodeForString(*object.String)
 	} else if object.StringArray != nil {
-		array := make([]interface{}, 0)
-		for _, item := range *(object.StringArray) {
-			array = append(array, item)
-		}
-		return array
+		return nodeForStringArray(*(object.StringArray))
 	} else {
-		return nil
+		return nodeForNull()
 	}
 }
 
-func (object *SchemaOrStringArray) jsonValue() interface{} {
+func (object *SchemaOrStringArray) jsonValue() *yaml.Node {
 	if object.Schema != nil {
 		return object.Schema.jsonValue()
 	} else if object.StringArray != nil {
-		array := make([]interface{}, 0)
-		for _, item := range *(object.StringArray) {
-			array = append(array, item)
-		}
-		return array
+		return nodeForStringArray(*(object.StringArray))
 	} else {
-		return nil
+		return nodeForNull()
 	}
 }
 
-func (object *SchemaOrSchemaArray) jsonValue() interface{} {
+func (object *SchemaOrSchemaArray) jsonValue() *yaml.Node {
 	if object.Schema != nil {
 		return object.Schema.jsonValue()
 	} else if object.SchemaArray != nil {
-		array := make([]interface{}, 0)
-		for _, item := range *(object.SchemaArray) {
-			array = append(array, item.jsonValue())
-		}
-		return array
+		return schemaArrayValue(object.SchemaArray)
 	} else {
-		return nil
+		return nodeForNull()
 	}
 }
 
-func (object *SchemaEnumValue) jsonValue() interface{} {
+func (object *SchemaEnumValue) jsonValue() *yaml.Node {
 	if object.String != nil {
-		return *object.String
+		return nodeForString(*object.String)
 	} else if object.Bool != nil {
-		return *object.Bool
+		return nodeForBool(*object.Bool)
 	} else {
-		return nil
+		return nodeForNull()
 	}
 }
 
-func namedSchemaArrayValue(array *[]*NamedSchema) interface{} {
-	m2 := yaml.MapSlice{}
+func namedSchemaArrayValue(array *[]*NamedSchema) *yaml.Node {
+	m2 := newMappingNode()
 	for _, pair := range *(array) {
-		var item2 yaml.MapItem
-		item2.Key = pair.Name
-		item2.Value = pair.Value.jsonValue()
-		m2 = append(m2, item2)
+		appendPair(m2, pair.Name, pair.Value.jsonValue())
 	}
 	return m2
 }
 
-func namedSchemaOrStringArrayValue(array *[]*NamedSchemaOrStringArray) interface{} {
-	m2 := yaml.MapSlice{}
+func namedSchemaOrStringArrayValue(array *[]*NamedSchemaOrStringArray) *yaml.Node {
+	m2 := newMappingNode()
 	for _, pair := range *(array) {
-		var item2 yaml.MapItem
-		item2.Key = pair.Name
-		item2.Value = pair.Value.jsonValue()
-		m2 = append(m2, item2)
+		appendPair(m2, pair.Name, pair.Value.jsonValue())
 	}
 	return m2
 }
 
-func schemaEnumArrayValue(array *[]SchemaEnumValue) []interface{} {
-	a := make([]interface{}, 0)
+func schemaEnumArrayValue(array *[]SchemaEnumValue) *yaml.Node {
+	a := newSequenceNode()
 	for _, item := range *array {
-		a = append(a, item.jsonValue())
+		a.Content = append(a.Content, item.jsonValue())
 	}
 	return a
 }
 
-func schemaArrayValue(array *[]*Schema) []interface{} {
-	a := make([]interface{}, 0)
+func schemaArrayValue(array *[]*Schema) *yaml.Node {
+	a := newSequenceNode()
 	for _, item := range *array {
-		a = append(a, item.jsonValue())
+		a.Content = append(a.Content, item.jsonValue())
 	}
 	return a
 }
 
-func (schema *Schema) jsonValue() yaml.MapSlice {
-	m := yaml.MapSlice{}
+func (schema *Schema) jsonValue() *yaml.Node {
+	m := newMappingNode()
 	if schema.Title != nil {
-		m = append(m, yaml.MapItem{"title", *schema.Title})
+		appendPair(m, "title", nodeForString(*schema.Title))
 	}
 	if schema.Id != nil {
-		m = append(m, yaml.MapItem{"id", *schema.Id})
+		appendPair(m, "id", nodeForString(*schema.Id))
 	}
 	if schema.Schema != nil {
-		m = append(m, yaml.MapItem{"$schema", *schema.Schema})
+		appendPair(m, "$schema", nodeForString(*schema.Schema))
 	}
 	if schema.Type != nil {
-		m = append(m, yaml.MapItem{"type", schema.Type.jsonValue()})
+		appendPair(m, "type", schema.Type.jsonValue())
 	}
 	if schema.Items != nil {
-		m = append(m, yaml.MapItem{"items", schema.Items.jsonValue()})
+		appendPair(m, "items", schema.Items.jsonValue())
 	}
 	if schema.Description != nil {
-		m = append(m, yaml.MapItem{"description", *schema.Description})
+		appendPair(m, "description", nodeForString(*schema.Description))
 	}
 	if schema.Required != nil {
-		m = append(m, yaml.MapItem{"required", *schema.Required})
+		appendPair(m, "required", nodeForStringArray(*schema.Required))
 	}
 	if schema.AdditionalProperties != nil {
-		m = append(m, yaml.MapItem{"additionalProperties", schema.AdditionalProperties.jsonValue()})
+		appendPair(m, "additionalProperties", schema.AdditionalProperties.jsonValue())
 	}
 	if schema.PatternProperties != nil {
-		m = append(m, yaml.MapItem{"patternProperties", namedSchemaArrayValue(schema.PatternProperties)})
+		appendPair(m, "patternProperties", namedSchemaArrayValue(schema.PatternProperties))
 	}
 	if schema.Properties != nil {
-		m = append(m, yaml.MapItem{"properties", namedSchemaArrayValue(schema.Properties)})
+		appendPair(m, "properties", namedSchemaArrayValue(schema.Properties))
 	}
 	if schema.Dependencies != nil {
-		m = append(m, yaml.MapItem{"dependencies", namedSchemaOrStringArrayValue(schema.Dependencies)})
+		appendPair(m, "dependencies", namedSchemaOrStringArrayValue(schema.Dependencies))
 	}
 	if schema.Ref != nil {
-		m = append(m, yaml.MapItem{"$ref", *schema.Ref})
+		appendPair(m, "$ref", nodeForString(*schema.Ref))
 	}
 	if schema.MultipleOf != nil {
-		m = append(m, yaml.MapItem{"multipleOf", schema.MultipleOf.jsonValue()})
+		appendPair(m, "multipleOf", schema.MultipleOf.jsonValue())
 	}
 	if schema.Maximum != nil {
-		m = append(m, yaml.MapItem{"maximum", schema.Maximum.jsonValue()})
+		appendPair(m, "maximum", schema.Maximum.jsonValue())
 	}
 	if schema.ExclusiveMaximum != nil {
-		m = append(m, yaml.MapItem{"exclusiveMaximum", *schema.ExclusiveMaximum})
+		appendPair(m, "exclusiveMaximum", nodeForBool(*schema.ExclusiveMaximum))
 	}
 	if schema.Minimum != nil {
-		m = append(m, yaml.MapItem{"minimum", schema.Minimum.jsonValue()})
+		appendPair(m, "minimum", schema.Minimum.jsonValue())
 	}
 	if schema.ExclusiveMinimum != nil {
-		m = append(m, yaml.MapItem{"exclusiveMinimum", *schema.ExclusiveMinimum})
+		appendPair(m, "exclusiveMinimum", nodeForBool(*schema.ExclusiveMinimum))
 	}
 	if schema.MaxLength != nil {
-		m = append(m, yaml.MapItem{"maxLength", *schema.MaxLength})
+		appendPair(m, "maxLength", nodeForInt64(*schema.MaxLength))
 	}
 	if schema.MinLength != nil {
-		m = append(m, yaml.MapItem{"minLength", *schema.MinLength})
+		appendPair(m, "minLength", nodeForInt64(*schema.MinLength))
 	}
 	if schema.Pattern != nil {
-		m = append(m, yaml.MapItem{"pattern", *schema.Pattern})
+		appendPair(m, "pattern", nodeForString(*schema.Pattern))
 	}
 	if schema.AdditionalItems != nil {
-		m = append(m, yaml.MapItem{"additionalItems", schema.AdditionalItems.jsonValue()})
+		appendPair(m, "additionalItems", schema.AdditionalItems.jsonValue())
 	}
 	if schema.MaxItems != nil {
-		m = append(m, yaml.MapItem{"maxItems", *schema.MaxItems})
+		appendPair(m, "maxItems", nodeForInt64(*schema.MaxItems))
 	}
 	if schema.MinItems != nil {
-		m = append(m, yaml.MapItem{"minItems", *schema.MinItems})
+		appendPair(m, "minItems", nodeForInt64(*schema.MinItems))
 	}
 	if schema.UniqueItems != nil {
-		m = append(m, yaml.MapItem{"uniqueItems", *schema.UniqueItems})
+		appendPair(m, "uniqueItems", nodeForBool(*schema.UniqueItems))
 	}
 	if schema.MaxProperties != nil {
-		m = append(m, yaml.MapItem{"maxProperties", *schema.MaxProperties})
+		appendPair(m, "maxProperties", nodeForInt64(*schema.MaxProperties))
 	}
 	if schema.MinProperties != nil {
-		m = append(m, yaml.MapItem{"minProperties", *schema.MinProperties})
+		appendPair(m, "minProperties", nodeForInt64(*schema.MinProperties))
 	}
 	if schema.Enumeration != nil {
-		m = append(m, yaml.MapItem{"enum", schemaEnumArrayValue(schema.Enumeration)})
+		appendPair(m, "enum", schemaEnumArrayValue(schema.Enumeration))
 	}
 	if schema.AllOf != nil {
-		m = append(m, yaml.MapItem{"allOf", schemaArrayValue(schema.AllOf)})
+		appendPair(m, "allOf", schemaArrayValue(schema.AllOf))
 	}
 	if schema.AnyOf != nil {
-		m = append(m, yaml.MapItem{"anyOf", schemaArrayValue(schema.AnyOf)})
+		appendPair(m, "anyOf", schemaArrayValue(schema.AnyOf))
 	}
 	if schema.OneOf != nil {
-		m = append(m, yaml.MapItem{"oneOf", schemaArrayValue(schema.OneOf)})
+		appendPair(m, "oneOf", schemaArrayValue(schema.OneOf))
 	}
 	if schema.Not != nil {
-		m = append(m, yaml.MapItem{"not", schema.Not.jsonValue()})
+		appendPair(m, "not", schema.Not.jsonValue())
 	}
 	if schema.Definitions != nil {
-		m = append(m, yaml.MapItem{"definitions", namedSchemaArrayValue(schema.Definitions)})
+		appendPair(m, "definitions", namedSchemaArrayValue(schema.Definitions))
 	}
 	if schema.Default != nil {
-		m = append(m, yaml.MapItem{"default", *schema.Default})
+		appendPair(m, "default", schema.Default)
 	}
 	if schema.Format != nil {
-		m = append(m, yaml.MapItem{"format", *schema.Format})
+		appendPair(m, "format", nodeForString(*schema.Format))
 	}
 	return m
 }
@@ -18,22 +18,47 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
 )
 
 const INDENT = "  "
 
-// basic escaping, will need to be improved or replaced
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+// escapes the characters that can't appear in JSON strings
 func escape(s string) string {
-	s = strings.Replace(s, "\n", "\\n", -1)
-	s = strings.Replace(s, "\"", "\\\"", -1)
-	return s
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString("\\\"")
+		case '\\':
+			b.WriteString("\\\\")
+		case '\n':
+			b.WriteString("\\n")
+		case '\r':
+			b.WriteString("\\r")
+		case '\t':
+			b.WriteString("\\t")
+		default:
+			if r < 0x20 {
+				b.WriteString(fmt.Sprintf("\\u%04x", r))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
 }
 
 type Writer struct {
 	b bytes.Buffer
+	// compact writers write no whitespace between values
+	compact bool
 }
 
 func (w *Writer) bytes() []byte {
@@ -44,124 +69,143 @@ func (w *Writer) writeString(s string) {
 	w.b.Write([]byte(s))
 }
 
-func (w *Writer) writeMap(info interface{}, indent string) {
-	w.writeString("{\n")
-	inner_indent := indent + INDENT
-	switch pairs := info.(type) {
-	case yaml.MapSlice:
-		for i, pair := range pairs {
-			// first print the key
-			w.writeString(fmt.Sprintf("%s\"%+v\": ", inner_indent, pair.Key))
-			// then the value
-			switch value := pair.Value.(type) {
-			case string:
-				w.writeString("\"")
-				w.writeString(escape(value))
-				w.writeString("\"")
-			case bool:
-				if value {
-					w.writeString("true")
-				} else {
-					w.writeString("false")
-				}
-			case []interface{}:
-				w.writeArray(value, inner_indent)
-			case yaml.MapSlice:
-				w.writeMap(value, inner_indent)
-			case int:
-				w.writeString(fmt.Sprintf("%d", value))
-			case int64:
-				w.writeString(fmt.Sprintf("%d", value))
-			case []string:
-				w.writeStringArray(value, inner_indent)
-			case float64:
-				w.writeString(fmt.Sprintf("%f", value))
-			case []yaml.MapSlice:
-				w.writeMapSliceArray(value, inner_indent)
-			default:
-				w.writeString(fmt.Sprintf("???MapItem(%+v, %T)", value, value))
+// Returns the node that a node represents, following aliases
+// and unwrapping documents.
+func resolveNode(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind {
+		case yaml.AliasNode:
+			node = node.Alias
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return nil
 			}
-			if i < len(pairs)-1 {
-				w.writeString(",")
-			}
-			w.writeString("\n")
+			node = node.Content[0]
+		default:
+			return node
 		}
+	}
+	return nil
+}
+
+func (w *Writer) writeValue(node *yaml.Node, indent string) {
+	node = resolveNode(node)
+	if node == nil {
+		w.writeString("null")
+		return
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		w.writeMap(node, indent)
+	case yaml.SequenceNode:
+		w.writeArray(node, indent)
+	case yaml.ScalarNode:
+		w.writeScalar(node)
 	default:
-		// t is some other type that we didn't name.
+		w.writeString(fmt.Sprintf("???Node(%+v)", node.Kind))
 	}
-	w.writeString(indent)
-	w.writeString("}")
 }
 
-func (w *Writer) writeArray(array []interface{}, indent string) {
-	w.writeString("[\n")
-	inner_indent := indent + INDENT
-	for i, item := range array {
-		w.writeString(inner_indent)
-		switch item := item.(type) {
-		case string:
-			w.writeString("\"")
-			w.writeString(item)
-			w.writeString("\"")
-		case bool:
-			if item {
+func (w *Writer) writeScalar(node *yaml.Node) {
+	switch node.ShortTag() {
+	case "!!str":
+		w.writeString("\"")
+		w.writeString(escape(node.Value))
+		w.writeString("\"")
+		return
+	case "!!bool":
+		var b bool
+		if node.Decode(&b) == nil {
+			if b {
 				w.writeString("true")
 			} else {
 				w.writeString("false")
 			}
-		case yaml.MapSlice:
-			w.writeMap(item, inner_indent)
-		default:
-			w.writeString(fmt.Sprintf("???ArrayItem(%+v)", item))
+			return
 		}
-		if i < len(array)-1 {
-			w.writeString(",")
+	case "!!int", "!!float":
+		// numbers are written as they were read when that is valid JSON
+		if jsonNumber.MatchString(node.Value) {
+			w.writeString(node.Value)
+			return
+		}
+		var f float64
+		if node.Decode(&f) == nil {
+			w.writeString(strconv.FormatFloat(f, 'g', -1, 64))
+			return
 		}
+	case "!!null":
+		w.writeString("null")
+		return
+	}
+	// other scalars, such as timestamps, are written as strings
+	w.writeString("\"")
+	w.writeString(escape(node.Value))
+	w.writeString("\"")
+}
+
+// Writes a line break and the indentation of the next line, unless the writer is compact.
+func (w *Writer) writeNewline(indent string) {
+	if !w.compact {
 		w.writeString("\n")
+		w.writeString(indent)
 	}
-	w.writeString(indent)
-	w.writeString("]")
 }
 
-func (w *Writer) writeStringArray(array []string, indent string) {
-	w.writeString("[\n")
+func (w *Writer) writeMap(node *yaml.Node, indent string) {
+	w.writeString("{")
 	inner_indent := indent + INDENT
-	for i, item := range array {
-		w.writeString(inner_indent)
-		w.writeString("\"")
-		w.writeString(escape(item))
-		w.writeString("\"")
-		if i < len(array)-1 {
+	for i := 0; i < len(node.Content); i += 2 {
+		w.writeNewline(inner_indent)
+		// first print the key
+		w.writeString(fmt.Sprintf("\"%s\":", escape(node.Content[i].Value)))
+		if !w.compact {
+			w.writeString(" ")
+		}
+		// then the value
+		w.writeValue(node.Content[i+1], inner_indent)
+		if i < len(node.Content)-2 {
 			w.writeString(",")
 		}
-		w.writeString("\n")
 	}
-	w.writeString(indent)
-	w.writeString("]")
+	w.writeNewline(indent)
+	w.writeString("}")
 }
 
-func (w *Writer) writeMapSliceArray(array []yaml.MapSlice, indent string) {
-	w.writeString("[\n")
+func (w *Writer) writeArray(node *yaml.Node, indent string) {
+	w.writeString("[")
 	inner_indent := indent + INDENT
-	for i, item := range array {
-		w.writeString(inner_indent)
-		w.writeMap(item, inner_indent)
-		if i < len(array)-1 {
+	for i, item := range node.Content {
+		w.writeNewline(inner_indent)
+		w.writeValue(item, inner_indent)
+		if i < len(node.Content)-1 {
 			w.writeString(",")
 		}
-		w.writeString("\n")
 	}
-	w.writeString(indent)
+	w.writeNewline(indent)
 	w.writeString("]")
 }
 
-func Marshal(in interface{}) (out []byte, err error) {
+// Marshal returns the JSON text for a node.
+func Marshal(in *yaml.Node) (out []byte, err error) {
 	var w Writer
-	m, ok := in.(yaml.MapSlice)
-	if !ok {
+	m := resolveNode(in)
+	if m == nil {
 		return nil, errors.New("invalid type passed to Marshal")
 	}
-	w.writeMap(m, "")
+	w.writeValue(m, "")
 	w.writeString("\n")
 	return w.bytes(), err
 }
+
+// MarshalCompact returns the JSON text for a node without any whitespace
+// between values, which is the form used inside of other JSON values.
+func MarshalCompact(in *yaml.Node) (out []byte, err error) {
+	w := Writer{compact: true}
+	m := resolveNode(in)
+	if m == nil {
+		return nil, errors.New("invalid type passed to MarshalCompact")
+	}
+	w.writeValue(m, "")
+	return w.bytes(), err
+}
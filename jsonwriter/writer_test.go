@@ -0,0 +1,28 @@
+package jsonwriter
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMarshal(t *testing.T) {
+	for _, test := range []struct{ yaml, json string }{
+		{"name: \"a \\\"b\\\"\\tc\\\\d\\u0001\"", "{\n  \"name\": \"a \\\"b\\\"\\tc\\\\d\\u0001\"\n}\n"},
+		{"\"x\\ny\": 1", "{\n  \"x\\ny\": 1\n}\n"},
+		{"created: 2017-01-01", "{\n  \"created\": \"2017-01-01\"\n}\n"},
+		{"- 1\n- true\n- null", "[\n  1,\n  true,\n  null\n]\n"},
+		{"pets", "\"pets\"\n"},
+	} {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(test.yaml), &node); err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		bytes, err := Marshal(&node)
+		if err != nil {
+			t.Errorf("Unexpected error: %+v", err)
+		} else if string(bytes) != test.json {
+			t.Errorf("Expected %q for %q, got %q", test.json, test.yaml, string(bytes))
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gnostic reads OpenAPI descriptions into gnostic models.
+// It is the library form of the gnostic tool: programs that embed
+// gnostic can read a description of either version with one call.
+//
+//	document, err := gnostic.ReadDocument("petstore.yaml")
+//	if err != nil { ... }
+//	fmt.Println(document.V2.Info.Title)
+package gnostic
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	yaml "gopkg.in/yaml.v3"
+)
+
+const ( // OpenAPI Version
+	OpenAPIvUnknown = 0
+	OpenAPIv2       = 2
+	OpenAPIv3       = 3
+)
+
+// Document is a compiled OpenAPI description. One of its models is set,
+// according to the version of the description that was read.
+type Document struct {
+	Version int
+	V2      *openapi_v2.Document
+	V3      *openapi_v3.Document
+	// Source is the parsed description that the model was compiled from,
+	// if it is available. Documents are written with their keys in the
+	// order that they have in the source.
+	Source *yaml.Node
+	// Filename is the file or URL that the description was read from, if it
+	// was read from one. References in the source are relative to it.
+	Filename string
+	// Warnings are the problems that were found in the source that don't
+	// prevent it from being compiled, such as keys that aren't strings.
+	Warnings []*compiler.Error
+}
+
+// Message returns the model of a document as a protocol buffer message.
+func (document *Document) Message() proto.Message {
+	switch document.Version {
+	case OpenAPIv2:
+		return document.V2
+	case OpenAPIv3:
+		return document.V3
+	}
+	return nil
+}
+
+// ReadDocument reads an OpenAPI description from a file or URL, compiles it
+// into the model for its version, and resolves its references. References
+// to other files are resolved relative to the description's location.
+// If the description has errors, they are returned along with the parts
+// of the model that could be compiled.
+func ReadDocument(filename string) (*Document, error) {
+	return ReadDocumentWithOptions(filename, nil)
+}
+
+// ReadDocumentWithOptions reads an OpenAPI description like ReadDocument,
+// using the specified options to compile it and resolve its references.
+func ReadDocumentWithOptions(filename string, options *compiler.CompilerOptions) (*Document, error) {
+	data, err := compiler.ReadBytesForFileWithOptions(filename, options)
+	if err != nil {
+		return nil, err
+	}
+	return readDocument(filename, data, options)
+}
+
+// ReadDocumentFromBytes compiles an OpenAPI description in JSON or YAML
+// and resolves its references. References to other files are resolved
+// relative to the current directory.
+func ReadDocumentFromBytes(data []byte) (*Document, error) {
+	return ReadDocumentFromBytesWithOptions(data, nil)
+}
+
+// ReadDocumentFromBytesWithOptions compiles an OpenAPI description like
+// ReadDocumentFromBytes, using the specified options.
+func ReadDocumentFromBytesWithOptions(data []byte, options *compiler.CompilerOptions) (*Document, error) {
+	return readDocument("", data, options)
+}
+
+func readDocument(filename string, data []byte, options *compiler.CompilerOptions) (*Document, error) {
+	info, err := compiler.ReadInfoFromBytesWithOptions(filename, data, options)
+	if err != nil {
+		return nil, err
+	}
+	version, err := DetectVersion(info)
+	if err != nil {
+		return nil, err
+	}
+	document := &Document{Version: version, Source: info, Filename: filename}
+	document.Warnings = compiler.WarningsForFileWithOptions(filename, options)
+	context := compiler.NewContextWithOptions("$root", options)
+	switch document.Version {
+	case OpenAPIv2:
+		document.V2, err = openapi_v2.NewDocument(info, context)
+		if err == nil {
+			_, err = document.V2.ResolveReferencesInContext(filename, compiler.NewContextWithOptions("$root", options))
+		}
+	case OpenAPIv3:
+		document.V3, err = openapi_v3.NewDocument(info, context)
+		if err == nil {
+			_, err = document.V3.ResolveReferencesInContext(filename, compiler.NewContextWithOptions("$root", options))
+		}
+	}
+	return document, err
+}
@@ -0,0 +1,1288 @@
+package gnostic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+func TestReadDocument(t *testing.T) {
+	document, err := ReadDocument("../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if document.Version != OpenAPIv2 || document.V2 == nil || document.V3 != nil {
+		t.Fatalf("Unexpected document: %+v", document)
+	}
+	// the parameters of the operation refer to a separate file
+	parameter := document.V2.Paths.Get("/pets").Get.Parameters[0]
+	if parameter.GetJsonReference() != nil || parameter.GetParameter() == nil {
+		t.Errorf("Unresolved reference in parameters: %+v", parameter)
+	}
+	document, err = ReadDocument("../examples/v3.0/json/petstore.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if document.Version != OpenAPIv3 || document.V3.Info.Title != "OpenAPI Petstore" {
+		t.Errorf("Unexpected document: %+v", document)
+	}
+}
+
+func TestReadDocumentFromBytes(t *testing.T) {
+	document, err := ReadDocumentFromBytes([]byte(`
+swagger: "2.0"
+info: {title: Sample, version: "1.0"}
+paths: {}
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if document.V2.Info.Title != "Sample" || document.Message() != document.V2 {
+		t.Errorf("Unexpected document: %+v", document)
+	}
+	_, err = ReadDocumentFromBytes([]byte(`title: Sample`))
+	if err == nil {
+		t.Errorf("Expected an error for a description without a version")
+	}
+	document, err = ReadDocumentFromBytes([]byte(`{"swagger": "2.0", "info": {"title": 1}}`))
+	if err == nil {
+		t.Errorf("Expected errors for an invalid description")
+	}
+	if document == nil || document.V2 == nil {
+		t.Errorf("Expected a partial model for an invalid description")
+	}
+}
+
+func TestReadDocumentWithOptions(t *testing.T) {
+	files := map[string]string{
+		"api.yaml": `
+swagger: "2.0"
+info: {title: Sample, version: "1.0"}
+paths:
+  /items:
+    get:
+      parameters:
+      - $ref: "parameters.yaml#/limit"
+      responses:
+        200: {description: OK}
+`,
+		"parameters.yaml": `
+limit: {name: limit, in: query, type: integer}
+`,
+	}
+	options := &compiler.CompilerOptions{
+		ReadFile: func(filename string) ([]byte, error) {
+			if source, ok := files[filename]; ok {
+				return []byte(source), nil
+			}
+			return nil, errors.New("not found: " + filename)
+		},
+	}
+	compiler.ClearCaches()
+	document, err := ReadDocumentWithOptions("api.yaml", options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	parameter := document.V2.Paths.Get("/items").Get.Parameters[0].GetParameter()
+	if parameter == nil || parameter.GetNonBodyParameter().GetQueryParameterSubSchema().Name != "limit" {
+		t.Errorf("Unresolved reference with injected reader: %+v", document.V2.Paths)
+	}
+
+	_, err = ReadDocumentWithOptions("https://example.com/api.yaml", &compiler.CompilerOptions{Offline: true})
+	if err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("Expected an error reading a remote file offline, got %v", err)
+	}
+
+	source := []byte(`{"swagger": "2.0", "info": {"title": "Sample", "version": "1.0"}, "paths": {}, "unknown": 1}`)
+	if _, err = ReadDocumentFromBytes(source); err == nil {
+		t.Errorf("Expected an error for an unknown field")
+	}
+	if _, err = ReadDocumentFromBytesWithOptions(source, &compiler.CompilerOptions{Lenient: true}); err != nil {
+		t.Errorf("Unexpected error for an unknown field in lenient mode: %+v", err)
+	}
+
+	recursive := []byte(`
+swagger: "2.0"
+info: {title: Sample, version: "1.0"}
+paths: {}
+definitions:
+  Node:
+    properties:
+      next: {$ref: "#/definitions/Node"}
+  List:
+    properties:
+      head: {$ref: "#/definitions/Node"}
+`)
+	document, err = ReadDocumentFromBytes(recursive)
+	if err != nil {
+		t.Fatalf("Unexpected error for a recursive definition: %+v", err)
+	}
+	next := document.V2.Definitions.AdditionalProperties[1].Value.Properties.AdditionalProperties[0].Value.Properties.AdditionalProperties[0].Value
+	if next.XRef != "#/definitions/Node" {
+		t.Errorf("Expected the recursive reference to be left unresolved, got %+v", next)
+	}
+
+	nested := []byte(`
+swagger: "2.0"
+info: {title: Sample, version: "1.0"}
+paths: {}
+definitions:
+  A: {properties: {b: {$ref: "#/definitions/B"}}}
+  B: {properties: {c: {$ref: "#/definitions/C"}}}
+  C: {properties: {d: {$ref: "#/definitions/D"}}}
+  D: {type: string}
+`)
+	_, err = ReadDocumentFromBytesWithOptions(nested, &compiler.CompilerOptions{MaxReferenceDepth: 2})
+	if err == nil || !strings.Contains(err.Error(), "more than 2 references") {
+		t.Errorf("Expected an error for deeply nested definitions, got %v", err)
+	}
+}
+
+func TestErrorList(t *testing.T) {
+	_, err := ReadDocument("../examples/errors/petstore-badproperties.yaml")
+	if err == nil {
+		t.Fatalf("Expected errors")
+	}
+	type expectation struct {
+		code string
+		path string
+		line int
+	}
+	expected := map[string]expectation{
+		"is missing required property: version":                                {compiler.ErrorCodeMissingProperty, "/info", 3},
+		"has invalid property: myproperty":                                     {compiler.ErrorCodeInvalidProperty, "/info", 4},
+		"has unexpected value for tags: pets (string)":                         {compiler.ErrorCodeUnexpectedValue, "/paths/~1pets/post", 46},
+		"has invalid properties: name, in, required, type, format, myproperty": {compiler.ErrorCodeInvalidProperty, "/paths/~1pets/get/parameters/0", 23},
+	}
+	for _, e := range compiler.ErrorList(err) {
+		if e.Severity != compiler.SeverityError {
+			t.Errorf("Unexpected severity for %s", e.Error())
+		}
+		if x, ok := expected[e.Message]; ok {
+			if e.Code != x.code || e.Path() != x.path || e.Line != x.line {
+				t.Errorf("%s: expected %s at %s line %d, got %s at %s line %d",
+					e.Message, x.code, x.path, x.line, e.Code, e.Path(), e.Line)
+			}
+			delete(expected, e.Message)
+		}
+	}
+	for message := range expected {
+		t.Errorf("Missing error: %s", message)
+	}
+}
+
+func TestNonStringKeys(t *testing.T) {
+	document, err := ReadDocumentFromBytesWithOptions([]byte(`
+swagger: "2.0"
+info: {title: Sample, version: "1.0"}
+paths:
+  /items:
+    get:
+      responses:
+        200: {description: OK}
+        0x194: {description: Not found}
+`), &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	codes := document.V2.Paths.Get("/items").Get.Responses.ResponseCode
+	if len(codes) != 2 || codes[0].Name != "200" || codes[1].Name != "404" {
+		t.Errorf("Unexpected response codes: %+v", codes)
+	}
+	if warnings := document.Warnings; len(warnings) != 2 ||
+		warnings[1].Error() != `WARNING key 0x194 is an integer and is read as the string "404"` ||
+		warnings[1].Severity != compiler.SeverityWarning || warnings[1].Code != compiler.ErrorCodeNonStringKey ||
+		warnings[1].Line != 9 || warnings[1].Column != 9 {
+		t.Errorf("Unexpected warnings: %+v", warnings)
+	}
+	if bytes, _ := document.YAML(); !strings.Contains(string(bytes), `"404":`) {
+		t.Errorf("Unexpected keys written:\n%s", bytes)
+	}
+	document, err = ReadDocumentFromBytes([]byte(`
+openapi: "3.0"
+info: {title: Sample, version: "1.0"}
+paths:
+  /items:
+    get:
+      responses:
+        200: {description: OK}
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if response := document.V3.Paths.Get("/items").Get.Responses.ResponseCode; len(response) != 1 || response[0].Name != "200" {
+		t.Errorf("Unexpected responses: %+v", response)
+	}
+	_, err = ReadDocumentFromBytes([]byte(`
+swagger: "2.0"
+info:
+  title: Sample
+  version: "1.0"
+  ? [a, b]
+  : value
+paths: {}
+`))
+	if err == nil || !strings.Contains(err.Error(), "ERROR $root.info map key is not a scalar") {
+		t.Errorf("Expected an error for a key that isn't a scalar, got %v", err)
+	}
+	// errors about invalid keys are reported at the keys
+	_, err = ReadDocumentFromBytesWithOptions([]byte(`
+swagger: "2.0"
+info: {title: Sample, version: "1.0"}
+paths:
+  /items:
+    get:
+      responses:
+        200: {description: OK}
+        true: {description: Yes}
+`), &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	errs := compiler.ErrorList(err)
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, "has invalid property: true") || errs[0].Line != 9 || errs[0].Column != 9 {
+		t.Errorf("Expected an error at line 9, column 9 for a boolean key, got %+v", errs)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	descriptions := map[string]string{
+		"/acme/pets/v1": `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      parameters:
+      - $ref: "registry://acme/common/v1#/parameters/limit"
+      responses:
+        200: {description: OK}
+`,
+		"/acme/common/v1": `
+parameters:
+  limit: {name: limit, in: query, type: integer}
+`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		description, ok := descriptions[strings.TrimPrefix(r.URL.Path, "/registry")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(description))
+	}))
+	defer server.Close()
+
+	registry := &compiler.Registry{URL: server.URL + "/registry/", Token: "secret"}
+	if location, _ := registry.Location("registry://acme/pets/v1#/paths"); location != server.URL+"/registry/acme/pets/v1" {
+		t.Errorf("Unexpected location %s", location)
+	}
+	options := &compiler.CompilerOptions{Cache: compiler.NewCache(), Registry: registry}
+	document, err := ReadDocumentWithOptions("registry://acme/pets/v1", options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	parameter := document.V2.Paths.Get("/pets").Get.Parameters[0].GetParameter()
+	if parameter == nil || parameter.GetNonBodyParameter().GetQueryParameterSubSchema().Name != "limit" {
+		t.Errorf("Unresolved registry reference: %+v", document.V2.Paths)
+	}
+
+	_, err = ReadDocumentWithOptions("registry://acme/missing/v1", options)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected an error for a missing description, got %v", err)
+	}
+	options = &compiler.CompilerOptions{Cache: compiler.NewCache(), Registry: &compiler.Registry{URL: server.URL}}
+	_, err = ReadDocumentWithOptions("registry://acme/pets/v1", options)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected an error for an unauthorized request, got %v", err)
+	}
+	_, err = ReadDocumentWithOptions("registry://acme/pets/v1", &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	if err == nil || !strings.Contains(err.Error(), "no API registry is configured") {
+		t.Errorf("Expected an error without a registry, got %v", err)
+	}
+}
+
+func TestSeparateCaches(t *testing.T) {
+	// unrelated descriptions that refer to files with the same names
+	for _, title := range []string{"First", "Second"} {
+		source := `{title: ` + title + `, type: object}`
+		options := &compiler.CompilerOptions{
+			Cache: compiler.NewCache(),
+			ReadFile: func(filename string) ([]byte, error) {
+				return []byte(source), nil
+			},
+		}
+		document, err := ReadDocumentFromBytesWithOptions([]byte(`
+swagger: "2.0"
+info: {title: Sample, version: "1.0"}
+paths: {}
+definitions:
+  Info: {$ref: "info.yaml"}
+`), options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		if schema := document.V2.Definitions.AdditionalProperties[0].Value; schema.Title != title {
+			t.Errorf("Expected %s, got %s", title, schema.Title)
+		}
+		if stats := options.Cache.Statistics(); stats.RefMisses != 1 || stats.Infos != 2 {
+			t.Errorf("Unexpected cache statistics: %+v", stats)
+		}
+	}
+}
+
+// A logger that saves its messages.
+type testLogger struct {
+	messages []string
+}
+
+func (logger *testLogger) Printf(format string, args ...interface{}) {
+	logger.messages = append(logger.messages, fmt.Sprintf(format, args...))
+}
+
+func TestFileURL(t *testing.T) {
+	filename, err := filepath.Abs("../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	fileurl := (&url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}).String()
+	document, err := ReadDocumentWithOptions(fileurl, &compiler.CompilerOptions{Cache: compiler.NewCache(), Offline: true})
+	if err != nil {
+		t.Fatalf("Unexpected error reading %s: %+v", fileurl, err)
+	}
+	if document.V2.Paths.Get("/pets").Get.Parameters[0].GetParameter() == nil {
+		t.Errorf("Unresolved reference in %s", fileurl)
+	}
+}
+
+func TestLogger(t *testing.T) {
+	logger := &testLogger{}
+	options := &compiler.CompilerOptions{Cache: compiler.NewCache(), Logger: logger}
+	if _, err := ReadDocumentWithOptions("../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml", options); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if len(logger.messages) != 0 {
+		t.Errorf("Unexpected messages: %+v", logger.messages)
+	}
+	options.Verbose = true
+	options.Cache.Clear()
+	if _, err := ReadDocumentWithOptions("../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml", options); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	found := false
+	for _, message := range logger.messages {
+		if strings.HasPrefix(message, "Reading info for ref") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Missing messages about references: %+v", logger.messages)
+	}
+}
+
+func TestEditDocument(t *testing.T) {
+	document, err := ReadDocument("../examples/v2.0/yaml/petstore.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document.V2.Info.SetTitle("Pet Store")
+	document.V2.Paths.RemovePath("/pets/{petId}")
+	document.V2.Info.SetVendorExtension("x-owner", &openapi_v2.Any{Yaml: "pets"})
+	document.V2.Info.SetVendorExtension("x-owner", &openapi_v2.Any{Yaml: "animals"})
+	if err = document.RenameSchema("Pet", "Animal"); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if err = document.RenameSchema("Pet", "Animal"); err == nil {
+		t.Errorf("Expected an error renaming a missing schema")
+	}
+	bytes, err := document.YAML()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	document, err = ReadDocumentFromBytes(bytes)
+	if err != nil {
+		t.Fatalf("Unexpected error reading the edited document: %+v\n%s", err, bytes)
+	}
+	if document.V2.Info.Title != "Pet Store" || len(document.V2.Info.VendorExtension) != 1 {
+		t.Errorf("Unexpected info: %+v", document.V2.Info)
+	}
+	if document.V2.Paths.Get("/pets/{petId}") != nil || document.V2.Definitions.Get("Animal") == nil {
+		t.Errorf("Unexpected document:\n%s", bytes)
+	}
+	items := document.V2.Definitions.Get("Pets").Items.Schema[0]
+	if items.XRef != "" || items.Required[0] != "id" {
+		t.Errorf("Unresolved reference to a renamed schema: %+v", items)
+	}
+}
+
+func TestSourceOrder(t *testing.T) {
+	source := `openapi: 3.0.0
+info:
+  version: 1.0.0
+  title: Ordered
+paths:
+  /zeta:
+    get:
+      responses:
+        "500":
+          description: failed
+        default:
+          description: other
+        "200":
+          description: ok
+      x-b: b
+      operationId: getZeta
+      x-a: a
+  /alpha: {}
+components:
+  schemas:
+    Z:
+      properties:
+        zz:
+          type: string
+        aa:
+          type: string
+      type: object
+`
+	document, err := ReadDocumentFromBytes([]byte(source))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	bytes, err := document.YAML()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if string(bytes) != source {
+		t.Errorf("Unexpected order:\n%s", bytes)
+	}
+	// keys that aren't in the source follow the keys that preceded them
+	document.V3.Paths.Path[0].Value.Get.Deprecated = &wrappers.BoolValue{Value: true}
+	bytes, _ = document.YAML()
+	if !strings.Contains(string(bytes), "          description: ok\n      deprecated: true\n      x-b: b\n") {
+		t.Errorf("Unexpected order of a new key:\n%s", bytes)
+	}
+}
+
+func TestAliasesAndMergeKeys(t *testing.T) {
+	source := `swagger: "2.0"
+info:
+  title: Aliases
+  version: 1.0.0
+x-ok: &ok
+  description: ok
+  schema:
+    type: object
+    required: [id, name]
+x-list: &list
+  summary: list
+  operationId: base
+paths:
+  /a:
+    get:
+      <<: *list
+      operationId: listA
+      responses:
+        "200": *ok
+  /b:
+    get:
+      <<: [*list]
+      responses:
+        "200":
+          <<: *ok
+          description: ok with b
+`
+	document, err := ReadDocumentFromBytes([]byte(source))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	a := document.V2.Paths.Get("/a").Get
+	b := document.V2.Paths.Get("/b").Get
+	if a.Summary != "list" || a.OperationId != "listA" || b.OperationId != "base" {
+		t.Errorf("Unexpected operations: %+v %+v", a, b)
+	}
+	responseA := a.Responses.ResponseCode[0].Value.GetResponse()
+	responseB := b.Responses.ResponseCode[0].Value.GetResponse()
+	if responseA.Description != "ok" || responseB.Description != "ok with b" || len(responseB.Schema.GetSchema().Required) != 2 {
+		t.Errorf("Unexpected responses: %+v %+v", responseA, responseB)
+	}
+
+	// aliases that refer to values that contain them are errors
+	_, err = ReadDocumentFromBytes([]byte("swagger: \"2.0\"\ninfo: &info\n  title: *info\n"))
+	if err == nil || !strings.Contains(err.Error(), "alias *info at line 3, column 10 refers to a value that contains it") {
+		t.Errorf("Unexpected error for a recursive alias: %v", err)
+	}
+
+	// repeated values can be written with anchors
+	info, err := document.RawInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	compiler.AddAnchors(info)
+	bytes := compiler.Marshal(info)
+	if !strings.Contains(string(bytes), "x-ok: &ok\n") || !strings.Contains(string(bytes), `"200": *ok`) {
+		t.Errorf("Expected aliases:\n%s", bytes)
+	}
+	written, err := ReadDocumentFromBytes(bytes)
+	if err != nil {
+		t.Fatalf("Unexpected error reading anchors: %+v\n%s", err, bytes)
+	}
+	if !proto.Equal(written.V2, document.V2) {
+		t.Errorf("Unexpected document written with anchors:\n%s", bytes)
+	}
+}
+
+func TestReferenceSiblings(t *testing.T) {
+	document, err := ReadDocumentFromBytes([]byte(`
+openapi: 3.0.0
+info: {title: Siblings, version: "1.0"}
+paths:
+  /items:
+    get:
+      responses:
+        "200":
+          $ref: "#/paths/~1all-items/get/responses/200"
+          description: The items of the current user
+  /all-items:
+    get:
+      responses:
+        "200":
+          description: A list of items
+  /things:
+    $ref: "#/paths/~1items"
+    summary: Things
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	reference := document.V3.Paths.Get("/items").Get.Responses.ResponseCode[0].Value.GetReference()
+	if reference.GetXRef() != "#/paths/~1all-items/get/responses/200" || reference.GetDescription() != "The items of the current user" {
+		t.Errorf("Unexpected reference: %+v", reference)
+	}
+	if bytes, _ := document.YAML(); !strings.Contains(string(bytes), "description: The items of the current user") {
+		t.Errorf("Missing description of a reference:\n%s", bytes)
+	}
+	// path items are replaced by the targets of their references,
+	// with the summaries and descriptions beside the references
+	things := document.V3.Paths.Get("/things")
+	if things.Summary != "Things" || things.XRef != "" || things.Get == nil {
+		t.Errorf("Unexpected path item: %+v", things)
+	}
+}
+
+func TestLargeNumbers(t *testing.T) {
+	document, err := ReadDocumentFromBytes([]byte(`
+swagger: "2.0"
+info: {title: Numbers, version: "1.0"}
+paths: {}
+definitions:
+  Id:
+    type: integer
+    format: int64
+    maximum: 9223372036854775807
+    minimum: -9223372036854775808
+    default: 9007199254740993
+    x-huge: 12345678901234567890
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	yamlBytes, _ := document.YAML()
+	jsonBytes, _ := document.JSON()
+	for _, expected := range []string{"maximum: 9223372036854775807", "minimum: -9223372036854775808", "default: 9007199254740993", "x-huge: 12345678901234567890"} {
+		if !strings.Contains(string(yamlBytes), expected) {
+			t.Errorf("Missing %s:\n%s", expected, yamlBytes)
+		}
+	}
+	if !strings.Contains(string(jsonBytes), `"maximum": 9223372036854775807`) {
+		t.Errorf("Unexpected JSON:\n%s", jsonBytes)
+	}
+	// numbers that are changed in the model are written with their new values
+	document.V2.Definitions.Get("Id").Maximum = &wrappers.DoubleValue{Value: 100}
+	if yamlBytes, _ = document.YAML(); !strings.Contains(string(yamlBytes), "maximum: 100\n") {
+		t.Errorf("Unexpected maximum:\n%s", yamlBytes)
+	}
+}
+
+func TestNullableTypes(t *testing.T) {
+	document, err := ReadDocumentFromBytes([]byte(`
+openapi: 3.0.0
+info: {title: Nullable, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Name:
+      type: [string, "null"]
+    Age:
+      type: integer
+      nullable: true
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	for _, name := range []string{"Name", "Age"} {
+		if schema := document.V3.Components.Schemas.Get(name); schema == nil || !schema.Nullable.GetValue() {
+			t.Errorf("Expected %s to be nullable: %+v", name, schema)
+		}
+	}
+	if typeName := document.V3.Components.Schemas.Get("Name").Type; typeName != "string" {
+		t.Errorf("Unexpected type: %s", typeName)
+	}
+	yamlBytes, _ := document.YAML()
+	if !strings.Contains(string(yamlBytes), "nullable: true\n      type: string\n") {
+		t.Errorf("Unexpected YAML:\n%s", yamlBytes)
+	}
+	// lists with more than one type that isn't null can't be represented in the model
+	_, err = ReadDocumentFromBytes([]byte(`
+openapi: 3.0.0
+info: {title: Nullable, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Mixed:
+      type: [string, integer]
+`))
+	if err == nil || !strings.Contains(err.Error(), "has unexpected value for type") {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+
+	document, err = ReadDocumentFromBytes([]byte(`
+swagger: "2.0"
+info: {title: Nullable, version: "1.0"}
+paths: {}
+definitions:
+  Name:
+    type: [string, "null"]
+  Age:
+    type: integer
+    x-nullable: true
+  Count:
+    type: integer
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	definitions := document.V2.Definitions
+	if !definitions.Get("Name").IsNullable() || !definitions.Get("Age").IsNullable() || definitions.Get("Count").IsNullable() {
+		t.Errorf("Unexpected nullability: %+v", definitions)
+	}
+	if typeName := definitions.Get("Name").TypeName(); typeName != "string" {
+		t.Errorf("Unexpected type: %s", typeName)
+	}
+}
+
+func TestMergeDocuments(t *testing.T) {
+	stream := `
+swagger: "2.0"
+info: {title: Fragments, version: "1.0"}
+paths: {}
+tags: [{name: pets}]
+---
+swagger: "2.0"
+definitions:
+  Pet: {type: object}
+tags: [{name: owners}]
+`
+	options := &compiler.CompilerOptions{MergeDocuments: true, Cache: compiler.NewCache()}
+	document, err := ReadDocumentFromBytesWithOptions([]byte(stream), options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if document.V2.Definitions.Get("Pet") == nil || len(document.V2.Tags) != 2 {
+		t.Errorf("Unexpected merged document: %+v", document.V2)
+	}
+	// without merging, the first document is read
+	document, err = ReadDocumentFromBytesWithOptions([]byte(stream), &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	if err != nil || document.V2.Definitions != nil {
+		t.Errorf("Unexpected document: %+v %+v", document, err)
+	}
+	// documents can't have different values for the same key
+	_, err = ReadDocumentFromBytesWithOptions([]byte(stream+"---\nswagger: \"3.0\"\n"), options)
+	if err == nil || !strings.Contains(err.Error(), "swagger at line 12, column 1 conflicts with the value at line 2, column 10") {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+	// items that are in more than one list are merged once
+	document, err = ReadDocumentFromBytesWithOptions([]byte(`
+swagger: "2.0"
+info: {title: Fragments, version: "1.0"}
+paths:
+  /pets:
+    get:
+      parameters: [{name: limit, in: query, type: integer}]
+      responses: {200: {description: OK}}
+tags: [{name: pets}]
+definitions:
+  Pet: {type: object, required: [name], properties: {name: {type: string}}}
+---
+paths:
+  /pets:
+    get:
+      parameters: [{name: limit, in: query, type: integer}, {name: tag, in: query, type: string}]
+tags: [{name: pets}, {name: owners}]
+definitions:
+  Pet:
+    required: [name, id]
+    properties: {id: {type: integer}}
+`), &compiler.CompilerOptions{MergeDocuments: true, Cache: compiler.NewCache()})
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if tags := document.V2.Tags; len(tags) != 2 || tags[0].Name != "pets" || tags[1].Name != "owners" {
+		t.Errorf("Unexpected tags: %+v", tags)
+	}
+	if parameters := document.V2.Paths.Get("/pets").Get.Parameters; len(parameters) != 2 {
+		t.Errorf("Unexpected parameters: %+v", parameters)
+	}
+	if required := document.V2.Definitions.Get("Pet").Required; len(required) != 2 || required[0] != "name" || required[1] != "id" {
+		t.Errorf("Unexpected required properties: %+v", required)
+	}
+}
+
+// Returns text in UTF-16 with the specified byte order, optionally with a byte order mark.
+func utf16Bytes(text string, bigEndian bool, bom bool) []byte {
+	units := utf16.Encode([]rune(text))
+	if bom {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	result := make([]byte, 0, 2*len(units))
+	for _, unit := range units {
+		if bigEndian {
+			result = append(result, byte(unit>>8), byte(unit))
+		} else {
+			result = append(result, byte(unit), byte(unit>>8))
+		}
+	}
+	return result
+}
+
+func TestEncodings(t *testing.T) {
+	yamlText := "swagger: \"2.0\"\ninfo: {title: Caf\u00e9 \U0001F600, version: \"1.0\"}\npaths: {}\n"
+	jsonText := `{"swagger": "2.0", "info": {"title": "Caf\u00e9 \U0001F600", "version": "1.0"}, "paths": {}}`
+	utf32 := []byte{0xFF, 0xFE, 0x00, 0x00}
+	for _, r := range jsonText {
+		utf32 = append(utf32, byte(r), byte(r>>8), byte(r>>16), byte(r>>24))
+	}
+	inputs := map[string][]byte{
+		"UTF-8 with BOM":         append([]byte{0xEF, 0xBB, 0xBF}, jsonText...),
+		"UTF-16LE with BOM":      utf16Bytes(yamlText, false, true),
+		"UTF-16BE with BOM":      utf16Bytes(jsonText, true, true),
+		"UTF-16LE without a BOM": utf16Bytes(jsonText, false, false),
+		"UTF-16BE without a BOM": utf16Bytes(yamlText, true, false),
+		"UTF-32LE with BOM":      utf32,
+	}
+	for name, input := range inputs {
+		document, err := ReadDocumentFromBytes(input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %+v", name, err)
+		} else if document.V2.Info.Title != "Caf\u00e9 \U0001F600" {
+			t.Errorf("%s: unexpected title %q", name, document.V2.Info.Title)
+		}
+	}
+	// files are also transcoded when they are read
+	options := &compiler.CompilerOptions{
+		Cache:    compiler.NewCache(),
+		ReadFile: func(filename string) ([]byte, error) { return inputs["UTF-16LE without a BOM"], nil },
+	}
+	bytes, err := compiler.ReadBytesForFileWithOptions("petstore.json", options)
+	if err != nil || string(bytes) != jsonText {
+		t.Errorf("Unexpected bytes %q: %+v", bytes, err)
+	}
+	if _, err := ReadDocumentFromBytes([]byte{0xFF, 0xFE, 's', 0, 'w'}); err == nil || !strings.Contains(err.Error(), "odd number of bytes") {
+		t.Errorf("Unexpected error: %+v", err)
+	}
+}
+
+func TestChainedReferences(t *testing.T) {
+	files := map[string]string{
+		"api/A.yaml": `
+swagger: "2.0"
+info: {title: Chained, version: "1.0"}
+paths: {}
+definitions:
+  Pet: {$ref: "sub/B.yaml#/Pet"}
+`,
+		// refs in B are relative to B, not to A
+		"api/sub/B.yaml": `
+Pet: {$ref: "./c/d.yaml#/Pet"}
+`,
+		"api/sub/c/d.yaml": `
+Pet:
+  type: object
+  properties:
+    name: {type: string}
+`,
+	}
+	options := &compiler.CompilerOptions{
+		Cache: compiler.NewCache(),
+		ReadFile: func(filename string) ([]byte, error) {
+			if text, ok := files[filepath.ToSlash(filename)]; ok {
+				return []byte(text), nil
+			}
+			return nil, errors.New("missing file " + filename)
+		},
+	}
+	document, err := ReadDocumentWithOptions(filepath.FromSlash("api/A.yaml"), options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if pet := document.V2.Definitions.Get("Pet"); pet.XRef != "" || pet.Properties == nil {
+		t.Errorf("Unresolved reference: %+v", pet)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	files := map[string]string{
+		"pets.yaml": `swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+definitions:
+  Pet: {$ref: "pet.yaml#/Pet"}
+`,
+		"pet.yaml": "Pet: {type: object}\n",
+	}
+	progress := compiler.NewProgress()
+	progress.SetStage("reading")
+	options := &compiler.CompilerOptions{
+		Cache:    compiler.NewCache(),
+		Progress: progress,
+		ReadFile: func(filename string) ([]byte, error) {
+			return []byte(files[filename]), nil
+		},
+	}
+	if _, err := ReadDocumentWithOptions("pets.yaml", options); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	event := progress.Event()
+	expected := compiler.ProgressEvent{
+		Stage: "reading",
+		Files: 2,
+		Bytes: int64(len(files["pets.yaml"]) + len(files["pet.yaml"])),
+		File:  "pet.yaml",
+	}
+	if event != expected {
+		t.Errorf("Expected %+v, found %+v", expected, event)
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	files := map[string]string{
+		"pets.yaml": `swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          schema: {$ref: "pet.yaml#/Pet"}
+`,
+		"pet.yaml": `Pet:
+  type: object
+  properties:
+    children: {$ref: "#/Pet"}
+`,
+	}
+	options := &compiler.CompilerOptions{
+		Cache: compiler.NewCache(),
+		ReadFile: func(filename string) ([]byte, error) {
+			return []byte(files[filename]), nil
+		},
+	}
+	info, err := compiler.ReadInfoFromBytesWithOptions("pets.yaml", []byte(files["pets.yaml"]), options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	context := compiler.NewContextWithOptions("$root", options)
+	for _, test := range []struct {
+		follow   bool
+		path     string
+		expected *compiler.SourcePosition
+	}{
+		{false, "info.title", &compiler.SourcePosition{File: "pets.yaml", Line: 2, Column: 8}},
+		{false, "paths./pets.get.responses.200", &compiler.SourcePosition{File: "pets.yaml", Line: 7, Column: 9}},
+		{false, "paths./pets.get.responses.200.schema.$ref", &compiler.SourcePosition{File: "pets.yaml", Line: 8, Column: 20}},
+		{false, "paths./pets.get.responses.200.schema.type", nil},
+		{true, "paths./pets.get.responses.200.schema.type", &compiler.SourcePosition{File: "pet.yaml", Line: 2, Column: 3}},
+		{true, "paths./pets.get.responses.200.schema.properties.children", &compiler.SourcePosition{File: "pet.yaml", Line: 4, Column: 5}},
+		// recursive references are followed once
+		{true, "paths./pets.get.responses.200.schema.properties.children.$ref", nil},
+		{true, "paths./pets.get.responses.200.schema.properties.children.type", nil},
+	} {
+		position := compiler.NewSourceMap("pets.yaml", info, test.follow, context)[test.path]
+		if !reflect.DeepEqual(position, test.expected) {
+			t.Errorf("Expected %s to be at %+v, found %+v", test.path, test.expected, position)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	for _, filename := range []string{
+		"../examples/v2.0/yaml/uber.yaml",
+		"../examples/v2.0/yaml/petstore-with-external-docs.yaml",
+		"../examples/v3.0/yaml/petstore.yaml",
+	} {
+		document, err := ReadDocument(filename)
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		original := proto.Clone(document.Message())
+		var clone proto.Message
+		switch document.Version {
+		case OpenAPIv2:
+			c := document.V2.Clone()
+			c.Info.Title = "changed"
+			c.Schemes = append(c.Schemes[:0], "changed")
+			c.Paths.Path[0].Name = "/changed"
+			c.Walk(&openapi_v2.Visitor{
+				VisitSchema: func(m *openapi_v2.Schema, path string) bool {
+					m.Description = "changed"
+					return true
+				},
+			})
+			clone = c
+		case OpenAPIv3:
+			c := document.V3.Clone()
+			c.Info.Title = "changed"
+			c.Servers[0].Url = "changed"
+			c.Paths.Path[0].Name = "/changed"
+			c.Walk(&openapi_v3.Visitor{
+				VisitSchema: func(m *openapi_v3.Schema, path string) bool {
+					m.Description = "changed"
+					return true
+				},
+			})
+			clone = c
+		}
+		if !proto.Equal(document.Message(), original) {
+			t.Errorf("Changes to a clone of %s changed the original", filename)
+		}
+		if proto.Equal(clone, original) {
+			t.Errorf("Clone of %s wasn't changed", filename)
+		}
+	}
+	if (*openapi_v2.Document)(nil).Clone() != nil {
+		t.Errorf("Clone of nil should be nil")
+	}
+	original := openapi_v3.NewSchemaOrReferenceWithSchema(&openapi_v3.Schema{Type: "string"})
+	clone := original.Clone()
+	clone.GetSchema().Type = "integer"
+	if original.GetSchema().Type != "string" {
+		t.Errorf("Changes to a clone of a oneof changed the original")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	read := func(text string) *openapi_v3.Document {
+		document, err := ReadDocumentFromBytes([]byte(text))
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+		return document.V3
+	}
+	old := read(`openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200": {description: pets}
+  /pets/{id}: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id: {type: integer}
+        name: {type: string}
+`)
+	new := read(`openapi: 3.0.0
+info: {title: Pets, version: "2.0"}
+paths:
+  /pets/{id}: {}
+  /pets:
+    get:
+      responses:
+        "200": {description: pets}
+      deprecated: true
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        name: {type: string}
+        tag: {type: string}
+`)
+	if !old.Equal(old.Clone()) {
+		t.Errorf("A document should equal its clone")
+	}
+	if old.Equal(new) {
+		t.Errorf("Different documents should not be equal")
+	}
+	differences := make([]string, 0)
+	for _, difference := range old.Diff(new) {
+		differences = append(differences, difference.String())
+	}
+	expected := []string{
+		`$root.info.version was changed from "1.0" to "2.0"`,
+		`$root.paths./pets.get.deprecated was added`,
+		`$root.components.schemas.Pet.required was changed from [id] to [id name]`,
+		`$root.components.schemas.Pet.properties.id was removed`,
+		`$root.components.schemas.Pet.properties.tag was added`,
+	}
+	if !reflect.DeepEqual(differences, expected) {
+		t.Errorf("Expected differences:\n%s\nFound:\n%s", strings.Join(expected, "\n"), strings.Join(differences, "\n"))
+	}
+}
+
+func TestMarshalAny(t *testing.T) {
+	document, err := ReadDocumentFromBytes([]byte(`swagger: "2.0"
+info: {title: Pets, version: "1.0", x-logo: {url: "logo.png"}}
+paths: {}
+definitions:
+  Pet:
+    type: object
+    default: {name: "x: y"}
+    enum: [cat, "007", 1.50]
+    example: {name: Fido, tags: [good, dog]}
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	pet := document.V2.Definitions.AdditionalProperties[0].Value
+
+	// values are written as they are in source documents
+	bytes, err := json.Marshal(pet.Example)
+	if err != nil || string(bytes) != `{"name":"Fido","tags":["good","dog"]}` {
+		t.Errorf("Unexpected JSON %s (%+v)", string(bytes), err)
+	}
+	bytes, err = yaml.Marshal(struct{ Enum []*openapi_v2.Any }{pet.Enum})
+	if err != nil || string(bytes) != "enum:\n    - cat\n    - \"007\"\n    - 1.50\n" {
+		t.Errorf("Unexpected YAML %q (%+v)", string(bytes), err)
+	}
+	text, err := (&jsonpb.Marshaler{}).MarshalToString(pet)
+	if err != nil || !strings.Contains(text, `"default":{"name":"x: y"}`) {
+		t.Errorf("Unexpected Protocol Buffer JSON %s (%+v)", text, err)
+	}
+
+	// and they are read from the same forms
+	for name, unmarshal := range map[string]func(*openapi_v2.Document) error{
+		"JSON": func(d *openapi_v2.Document) error {
+			bytes, err := json.Marshal(document.V2)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(bytes, d)
+		},
+		"YAML": func(d *openapi_v2.Document) error {
+			bytes, err := yaml.Marshal(document.V2)
+			if err != nil {
+				return err
+			}
+			return yaml.Unmarshal(bytes, d)
+		},
+		"Protocol Buffer JSON": func(d *openapi_v2.Document) error {
+			text, err := (&jsonpb.Marshaler{}).MarshalToString(document.V2)
+			if err != nil {
+				return err
+			}
+			return jsonpb.UnmarshalString(text, d)
+		},
+	} {
+		d := &openapi_v2.Document{}
+		if err := unmarshal(d); err != nil {
+			t.Errorf("Unexpected %s error: %+v", name, err)
+		} else if differences := document.V2.Diff(d); len(differences) != 0 {
+			t.Errorf("%s changed the document: %+v", name, differences)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	document, err := ReadDocumentFromBytes([]byte(`openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pet"}
+    delete:
+      deprecated: true
+      responses:
+        "204": {description: deleted}
+components:
+  schemas:
+    Pet: {type: object}
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	model := document.V3.Clone()
+	paths := make([]string, 0)
+	rewritten := model.Rewrite(&openapi_v3.Rewriter{
+		// rename a schema and the references to it
+		RewriteNamedSchema: func(m *openapi_v3.NamedSchema, path string) *openapi_v3.NamedSchema {
+			if m.Name == "Pet" {
+				m.Name = "Animal"
+			}
+			return m
+		},
+		RewriteReference: func(m *openapi_v3.Reference, path string) *openapi_v3.Reference {
+			paths = append(paths, path)
+			if m.XRef == "#/components/schemas/Pet" {
+				m.XRef = "#/components/schemas/Animal"
+			}
+			return m
+		},
+		// add a default response to each operation
+		RewriteResponses: func(m *openapi_v3.Responses, path string) *openapi_v3.Responses {
+			if m.Default == nil {
+				m.Default = openapi_v3.NewResponseOrReferenceWithResponse(&openapi_v3.Response{Description: "error"})
+			}
+			return m
+		},
+		// remove deprecated operations
+		RewriteOperation: func(m *openapi_v3.Operation, path string) *openapi_v3.Operation {
+			if m.Deprecated.GetValue() {
+				return nil
+			}
+			return m
+		},
+	})
+	if rewritten != model {
+		t.Errorf("Rewrite returned a different document")
+	}
+	if model.GetComponents().GetSchema("Animal") == nil || model.GetComponents().GetSchema("Pet") != nil {
+		t.Errorf("Pet was not renamed: %+v", model.GetComponents().GetSchemas())
+	}
+	pets := model.GetPath("/pets")
+	if pets.GetDelete() != nil {
+		t.Errorf("Deprecated operation was not removed")
+	}
+	if pets.GetGet().GetResponses().GetDefault().GetResponse().GetDescription() != "error" {
+		t.Errorf("Default response was not added: %+v", pets.GetGet().GetResponses())
+	}
+	expectedPath := "$root.paths./pets.get.responses.200.response.content.application/json.schema.reference"
+	if len(paths) != 1 || paths[0] != expectedPath {
+		t.Errorf("Unexpected reference paths: %+v", paths)
+	}
+	ref := pets.GetGet().GetResponses().GetResponseCode()[0].GetValue().GetResponse().GetContent().GetMediaType()[0].GetValue().GetSchema().GetReference()
+	if ref.GetXRef() != "#/components/schemas/Animal" {
+		t.Errorf("Reference was not renamed: %+v", ref)
+	}
+	// the original model is unchanged
+	if document.V3.GetComponents().GetSchema("Pet") == nil || document.V3.GetPath("/pets").GetDelete() == nil {
+		t.Errorf("Original model was changed")
+	}
+	// a rewriter can replace the root message
+	replacement := &openapi_v3.Document{Openapi: "3.0.0"}
+	if model.Rewrite(&openapi_v3.Rewriter{
+		RewriteDocument: func(m *openapi_v3.Document, path string) *openapi_v3.Document {
+			return replacement
+		},
+	}) != replacement {
+		t.Errorf("Root message was not replaced")
+	}
+}
+
+func TestResolveReference(t *testing.T) {
+	document, err := ReadDocumentFromBytes([]byte(`openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+        - {$ref: "#/components/parameters/Limit"}
+        - {$ref: "#/paths/~1pets~1{id}/get/parameters/1"}
+        - {$ref: "#/paths/~1pets~1{id}/get/parameters/3"}
+      responses:
+        "200": {description: a pet}
+components:
+  parameters:
+    Limit: {name: limit, in: query}
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	model := document.V3
+	get := model.GetPath("/pets/{id}").GetGet()
+	for ref, expected := range map[string]interface{}{
+		"#/components/parameters/Limit":                    model.GetComponents().GetParameter("Limit"),
+		"#/paths/~1pets~1{id}/get/parameters/1":            model.GetComponents().GetParameter("Limit"),
+		"#/paths/~1pets~1{id}/get/parameters/2":            model.GetComponents().GetParameter("Limit"),
+		"#/paths/~1pets~1%7Bid%7D/get/parameters/0/schema": get.GetParameters()[0].GetParameter().GetSchema().GetSchema(),
+		"#/paths/~1pets~1%7Bid%7D/get/responses/200":       get.GetResponse("200").GetResponse(),
+		"#/info": model.GetInfo(),
+		"#":      model,
+	} {
+		target, err := model.ResolveReference(ref)
+		if err != nil {
+			t.Errorf("Unexpected error resolving %s: %+v", ref, err)
+		} else if target != expected {
+			t.Errorf("Unexpected target of %s: %+v", ref, target)
+		}
+	}
+	for ref, expected := range map[string]string{
+		"#/paths/~1pets~1{id}/get/parameters/3": "unable to resolve #/paths/~1pets~1{id}/get/parameters/3, the reference is circular",
+		"#/paths/~1pets~1{id}/get/parameters/4": "unable to resolve #/paths/~1pets~1{id}/get/parameters/4, there is nothing at that location",
+		"#/components/parameters/Missing":       "unable to resolve #/components/parameters/Missing, there is nothing at that location",
+		"pets.yaml#/Pet":                        "unable to resolve pets.yaml#/Pet, only references within a document can be resolved",
+	} {
+		if _, err := model.ResolveReference(ref); err == nil || err.Error() != expected {
+			t.Errorf("Unexpected error resolving %s: %+v", ref, err)
+		}
+	}
+}
+
+// Reads a file into a YAML node.
+func readInfo(t *testing.T, filename string) *yaml.Node {
+	bytes, err := compiler.ReadBytesForFile(filename)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	info, err := compiler.ReadInfoFromBytes(filename, bytes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	return info
+}
+
+func TestValidateAgainstMetaSchema(t *testing.T) {
+	for _, filename := range []string{
+		"../examples/v2.0/yaml/petstore.yaml",
+		"../examples/v2.0/json/uber.json",
+		"../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml",
+	} {
+		if err := openapi_v2.ValidateAgainstMetaSchema(readInfo(t, filename)); err != nil {
+			t.Errorf("Unexpected errors for %s: %+v", filename, err)
+		}
+	}
+	err := openapi_v2.ValidateAgainstMetaSchema(readInfo(t, "../examples/errors/petstore-badproperties.yaml"))
+	if err == nil || !strings.Contains(err.Error(), `/info (line 3, column 3): missing required property "version"`) {
+		t.Errorf("Expected errors for an invalid description, got %v", err)
+	}
+	if schema, err := openapi_v3.MetaSchema(); err != nil || *schema.Id != "http://openapis.org/v3/schema.json#" {
+		t.Errorf("Unexpected schema: %v", err)
+	}
+}
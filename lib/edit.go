@@ -0,0 +1,113 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// A description can be read, changed, and written back:
+//
+//	document, err := gnostic.ReadDocument("petstore.yaml")
+//	...
+//	document.V2.Info.SetTitle("Pet Store")
+//	document.V2.Paths.RemovePath("/pets/{petId}")
+//	err = document.RenameSchema("Pet", "Animal")
+//	bytes, err := document.YAML()
+//
+// The models of documents can be changed with the SetX, AddX, and RemoveX
+// methods of their types. SetX and RemoveX keep the names in maps and
+// extensions unique, and RenameSchema keeps references consistent.
+
+// RawInfo returns the model of a document as a YAML node, with its keys
+// in the order of the document's source and its numbers written as they
+// are in the source.
+func (document *Document) RawInfo() (*yaml.Node, error) {
+	var info *yaml.Node
+	switch document.Version {
+	case OpenAPIv2:
+		info = document.V2.ToRawInfo()
+	case OpenAPIv3:
+		info = document.V3.ToRawInfo()
+	default:
+		return nil, errors.New("document has no model")
+	}
+	compiler.OrderLike(info, document.Source)
+	compiler.RestoreNumbers(info, document.Source)
+	return info, nil
+}
+
+// YAML writes the model of a document as a YAML description.
+func (document *Document) YAML() ([]byte, error) {
+	info, err := document.RawInfo()
+	if err != nil {
+		return nil, err
+	}
+	return compiler.Marshal(info), nil
+}
+
+// JSON writes the model of a document as a JSON description.
+func (document *Document) JSON() ([]byte, error) {
+	info, err := document.RawInfo()
+	if err != nil {
+		return nil, err
+	}
+	return jsonwriter.Marshal(info)
+}
+
+// RenameSchema renames a schema in the definitions of an OpenAPI 2 document
+// or the components of an OpenAPI 3 document, and changes the references to
+// it. References to the schema in other files are not changed.
+func (document *Document) RenameSchema(from, to string) error {
+	var prefix string
+	found, exists := false, false
+	switch document.Version {
+	case OpenAPIv2:
+		prefix = "#/definitions/"
+		definitions := document.V2.GetDefinitions()
+		if found, exists = definitions.Get(from) != nil, definitions.Get(to) != nil; found && !exists {
+			for _, pair := range definitions.AdditionalProperties {
+				if pair.Name == from {
+					pair.Name = to
+				}
+			}
+		}
+	case OpenAPIv3:
+		prefix = "#/components/schemas/"
+		schemas := document.V3.GetComponents().GetSchemas()
+		if found, exists = schemas.Get(from) != nil, schemas.Get(to) != nil; found && !exists {
+			for _, pair := range schemas.AdditionalProperties {
+				if pair.Name == from {
+					pair.Name = to
+				}
+			}
+		}
+	default:
+		return errors.New("document has no model")
+	}
+	if !found {
+		return errors.New(fmt.Sprintf("schema %s not found", from))
+	}
+	if exists {
+		return errors.New(fmt.Sprintf("schema %s already exists", to))
+	}
+	compiler.RenameReferences(document.Message(), prefix+compiler.PointerEscape(from), prefix+compiler.PointerEscape(to))
+	return nil
+}
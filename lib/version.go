@@ -0,0 +1,78 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gnostic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// The fields that identify the version of an OpenAPI description.
+var versionFields = []string{"swagger", "openapi"}
+
+// Examples of the supported values of each version field, used in error messages.
+var supportedVersions = map[string]string{
+	"swagger": "2.0",
+	"openapi": "3.0.x",
+}
+
+// VersionForField returns the version of OpenAPI that is identified by the
+// value of a "swagger" or "openapi" field, or OpenAPIvUnknown if the value
+// isn't one that gnostic can read. OpenAPI 3 descriptions may specify either
+// "3.0" or a patch release such as "3.0.1".
+func VersionForField(key, value string) int {
+	switch key {
+	case "swagger":
+		if value == "2.0" {
+			return OpenAPIv2
+		}
+	case "openapi":
+		if value == "3.0" || strings.HasPrefix(value, "3.0.") {
+			return OpenAPIv3
+		}
+	}
+	return OpenAPIvUnknown
+}
+
+// DetectVersion returns the version of an OpenAPI description read from JSON
+// or YAML. The version is identified by the description's "swagger" or
+// "openapi" field, and the error explains why a description that can't be
+// identified was rejected.
+func DetectVersion(info *yaml.Node) (int, error) {
+	m, ok := compiler.UnpackMap(info)
+	if !ok {
+		return OpenAPIvUnknown, errors.New("Unable to identify OpenAPI version: the description is not an object.")
+	}
+	for _, key := range versionFields {
+		node := compiler.MapValueForKey(m, key)
+		if node == nil {
+			continue
+		}
+		value, ok := compiler.StringForScalarNode(node)
+		if !ok {
+			return OpenAPIvUnknown, errors.New(fmt.Sprintf("Unable to identify OpenAPI version: the value of %q must be a string, such as %q.", key, supportedVersions[key]))
+		}
+		version := VersionForField(key, value)
+		if version == OpenAPIvUnknown {
+			return OpenAPIvUnknown, errors.New(fmt.Sprintf("Unsupported OpenAPI version: %s %q. Supported versions are swagger %q and openapi %q.", key, value, supportedVersions["swagger"], supportedVersions["openapi"]))
+		}
+		return version, nil
+	}
+	return OpenAPIvUnknown, errors.New("Unable to identify OpenAPI version: the description has no \"swagger\" or \"openapi\" field.")
+}
@@ -0,0 +1,42 @@
+package gnostic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func TestDetectVersion(t *testing.T) {
+	tests := []struct {
+		source  string
+		version int
+		err     string
+	}{
+		{`swagger: "2.0"`, OpenAPIv2, ""},
+		{`openapi: "3.0"`, OpenAPIv3, ""},
+		{`openapi: 3.0.2`, OpenAPIv3, ""},
+		{`{"openapi": "3.0.0"}`, OpenAPIv3, ""},
+		{`swagger: 2.0`, OpenAPIvUnknown, "must be a string"},
+		{`swagger: "1.2"`, OpenAPIvUnknown, `Unsupported OpenAPI version: swagger "1.2"`},
+		{`openapi: "3.1.0"`, OpenAPIvUnknown, `Unsupported OpenAPI version: openapi "3.1.0"`},
+		{`info: {title: Sample}`, OpenAPIvUnknown, `no "swagger" or "openapi" field`},
+		{`- swagger`, OpenAPIvUnknown, "not an object"},
+	}
+	for _, test := range tests {
+		info, err := compiler.ReadInfoFromBytes("", []byte(test.source))
+		if err != nil {
+			t.Fatalf("%s: %+v", test.source, err)
+		}
+		version, err := DetectVersion(info)
+		if version != test.version {
+			t.Errorf("%s: expected version %d, got %d", test.source, test.version, version)
+		}
+		if test.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %+v", test.source, err)
+		}
+		if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.source, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"fmt"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+func checkContactV2(l *linter, document *openapi_v2.Document) {
+	if contact := document.GetInfo().GetContact(); contact.GetName() == "" && contact.GetEmail() == "" && contact.GetUrl() == "" {
+		l.report(contextForPath("info"), "info has no contact information")
+	}
+}
+
+func checkContactV3(l *linter, document *openapi_v3.Document) {
+	if contact := document.GetInfo().GetContact(); contact.GetName() == "" && contact.GetEmail() == "" && contact.GetUrl() == "" {
+		l.report(contextForPath("info"), "info has no contact information")
+	}
+}
+
+func checkLicenseV2(l *linter, document *openapi_v2.Document) {
+	if document.GetInfo().GetLicense().GetName() == "" {
+		l.report(contextForPath("info"), "info has no license")
+	}
+}
+
+func checkLicenseV3(l *linter, document *openapi_v3.Document) {
+	if document.GetInfo().GetLicense().GetName() == "" {
+		l.report(contextForPath("info"), "info has no license")
+	}
+}
+
+// Servers of v2 documents are described by their hosts and schemes, which
+// don't have descriptions.
+func checkServerDescriptionsV2(l *linter, document *openapi_v2.Document) {}
+
+func checkServerDescriptionsV3(l *linter, document *openapi_v3.Document) {
+	for _, s := range serversV3(document) {
+		if s.server.Description == "" {
+			l.report(contextForPath(s.path...), fmt.Sprintf("server %s has no description", s.server.Url))
+		}
+	}
+}
+
+func checkOperationSummariesV2(l *linter, document *openapi_v2.Document) {
+	for _, o := range operationsV2(document) {
+		if o.operation.Summary == "" {
+			l.report(o.context, "operation has no summary")
+		}
+	}
+}
+
+func checkOperationSummariesV3(l *linter, document *openapi_v3.Document) {
+	for _, o := range operationsV3(document) {
+		if o.operation.Summary == "" {
+			l.report(o.context, "operation has no summary")
+		}
+	}
+}
+
+func checkTagDescriptionsV2(l *linter, document *openapi_v2.Document) {
+	for i, tag := range document.Tags {
+		if tag.Description == "" {
+			l.report(contextForPath("tags", fmt.Sprintf("%d", i)), fmt.Sprintf("tag %s has no description", tag.Name))
+		}
+	}
+}
+
+func checkTagDescriptionsV3(l *linter, document *openapi_v3.Document) {
+	for i, tag := range document.Tags {
+		if tag.Description == "" {
+			l.report(contextForPath("tags", fmt.Sprintf("%d", i)), fmt.Sprintf("tag %s has no description", tag.Name))
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package linter checks OpenAPI descriptions for problems that are allowed
+// by the specification but that usually make APIs harder to use, such as
+// operations that can't be named by generated clients.
+//
+//	document, err := gnostic.ReadDocument("petstore.yaml")
+//	...
+//	for _, problem := range linter.Lint(document) {
+//		fmt.Println(problem.Path(), problem.Code, problem.Message)
+//	}
+package linter
+
+import (
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	gnostic "github.com/googleapis/gnostic/lib"
+)
+
+// The names of the rulesets. Lint checks the rules of the default ruleset,
+// and the others are checked when they are named.
+const (
+	DefaultRuleset      = "default"
+	SecurityRuleset     = "security"
+	StrictRuleset       = "strict"
+	CompletenessRuleset = "completeness"
+)
+
+// A Rule is a check made by the linter. The problems that it finds are
+// reported with the rule's name as their code.
+type Rule struct {
+	Name        string
+	Description string
+	Ruleset     string
+	checkV2     func(l *linter, document *openapi_v2.Document)
+	checkV3     func(l *linter, document *openapi_v3.Document)
+}
+
+// Rules are the checks made by Lint, in the order that they are made.
+var Rules = []*Rule{
+	{
+		Name:        "operation-id",
+		Description: "Operations should have operationIds.",
+		Ruleset:     DefaultRuleset,
+		checkV2:     checkOperationIDsV2,
+		checkV3:     checkOperationIDsV3,
+	},
+	{
+		Name:        "operation-id-unique",
+		Description: "The operationIds of operations should be unique.",
+		Ruleset:     DefaultRuleset,
+		checkV2:     checkUniqueOperationIDsV2,
+		checkV3:     checkUniqueOperationIDsV3,
+	},
+	{
+		Name:        "operation-success-response",
+		Description: "Operations should describe a successful or default response.",
+		Ruleset:     DefaultRuleset,
+		checkV2:     checkSuccessResponsesV2,
+		checkV3:     checkSuccessResponsesV3,
+	},
+	{
+		Name:        "response-code",
+		Description: "Response codes should be HTTP status codes, or ranges like 2XX in OpenAPI 3, and should not be repeated.",
+		Ruleset:     DefaultRuleset,
+		checkV2:     checkResponseCodesV2,
+		checkV3:     checkResponseCodesV3,
+	},
+	{
+		Name:        "response-code-range",
+		Description: "Responses should be described for status codes instead of ranges.",
+		Ruleset:     StrictRuleset,
+		checkV2:     checkResponseCodeRangesV2,
+		checkV3:     checkResponseCodeRangesV3,
+	},
+	{
+		Name:        "security-api-key-in-query",
+		Description: "API keys should not be sent in query strings.",
+		Ruleset:     SecurityRuleset,
+		checkV2:     checkAPIKeysInQueryV2,
+		checkV3:     checkAPIKeysInQueryV3,
+	},
+	{
+		Name:        "security-insecure-server",
+		Description: "APIs should be served with TLS.",
+		Ruleset:     SecurityRuleset,
+		checkV2:     checkInsecureServersV2,
+		checkV3:     checkInsecureServersV3,
+	},
+	{
+		Name:        "security-basic-auth-without-tls",
+		Description: "Basic authentication should only be used with APIs that are served with TLS.",
+		Ruleset:     SecurityRuleset,
+		checkV2:     checkBasicAuthenticationV2,
+		checkV3:     checkBasicAuthenticationV3,
+	},
+	{
+		Name:        "security-operation-requirement",
+		Description: "Operations should have security requirements, which are empty for public operations.",
+		Ruleset:     SecurityRuleset,
+		checkV2:     checkOperationSecurityV2,
+		checkV3:     checkOperationSecurityV3,
+	},
+	{
+		Name:        "security-broad-scope",
+		Description: "OAuth scopes should not grant access to everything.",
+		Ruleset:     SecurityRuleset,
+		checkV2:     checkBroadScopesV2,
+		checkV3:     checkBroadScopesV3,
+	},
+	{
+		Name:        "info-contact",
+		Description: "The info of a description should have contact information.",
+		Ruleset:     CompletenessRuleset,
+		checkV2:     checkContactV2,
+		checkV3:     checkContactV3,
+	},
+	{
+		Name:        "info-license",
+		Description: "The info of a description should name a license.",
+		Ruleset:     CompletenessRuleset,
+		checkV2:     checkLicenseV2,
+		checkV3:     checkLicenseV3,
+	},
+	{
+		Name:        "server-description",
+		Description: "Servers should have descriptions.",
+		Ruleset:     CompletenessRuleset,
+		checkV2:     checkServerDescriptionsV2,
+		checkV3:     checkServerDescriptionsV3,
+	},
+	{
+		Name:        "operation-summary",
+		Description: "Operations should have summaries.",
+		Ruleset:     CompletenessRuleset,
+		checkV2:     checkOperationSummariesV2,
+		checkV3:     checkOperationSummariesV3,
+	},
+	{
+		Name:        "tag-description",
+		Description: "Tags should have descriptions.",
+		Ruleset:     CompletenessRuleset,
+		checkV2:     checkTagDescriptionsV2,
+		checkV3:     checkTagDescriptionsV3,
+	},
+}
+
+// IsRuleset returns true if a name is the name of a ruleset.
+func IsRuleset(name string) bool {
+	for _, rule := range Rules {
+		if rule.Ruleset == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint checks a compiled document with the rules of the default ruleset and
+// returns the problems that were found as warnings. A problem's Path locates
+// the value that it is about; its position is unknown because compiled models
+// don't record the positions of their values.
+func Lint(document *gnostic.Document) []*compiler.Error {
+	return LintWithRulesets(document, DefaultRuleset)
+}
+
+// LintWithRulesets checks a compiled document with the rules of the named
+// rulesets, like Lint.
+func LintWithRulesets(document *gnostic.Document, rulesets ...string) []*compiler.Error {
+	l := &linter{problems: make([]*compiler.Error, 0)}
+	for _, rule := range Rules {
+		if !containsString(rulesets, rule.Ruleset) {
+			continue
+		}
+		l.rule = rule
+		switch {
+		case document.Version == gnostic.OpenAPIv2 && document.V2 != nil:
+			rule.checkV2(l, document.V2)
+		case document.Version == gnostic.OpenAPIv3 && document.V3 != nil:
+			rule.checkV3(l, document.V3)
+		}
+	}
+	return l.problems
+}
+
+// linter collects the problems found by the rule that is being checked.
+type linter struct {
+	rule     *Rule
+	problems []*compiler.Error
+}
+
+func (l *linter) report(context *compiler.Context, message string) {
+	l.problems = append(l.problems, &compiler.Error{
+		Context:  context,
+		Message:  message,
+		Severity: compiler.SeverityWarning,
+		Code:     l.rule.Name,
+	})
+}
+
+// Returns the context of a value, given the names of the keys that lead to it from the root.
+func contextForPath(names ...string) *compiler.Context {
+	context := compiler.NewContext("$root", nil)
+	for _, name := range names {
+		context = compiler.NewContext(name, context)
+	}
+	return context
+}
+
+// Returns true if a list contains a string.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if a response code describes a successful or default response.
+func isSuccessResponseCode(code string) bool {
+	return code == "default" || (len(code) == 3 && (code[0] == '2' || code[0] == '3'))
+}
@@ -0,0 +1,342 @@
+package linter
+
+import (
+	"testing"
+
+	gnostic "github.com/googleapis/gnostic/lib"
+)
+
+func lint(t *testing.T, text string, rulesets ...string) map[string]string {
+	document, err := gnostic.ReadDocumentFromBytes([]byte(text))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if len(rulesets) == 0 {
+		rulesets = []string{DefaultRuleset}
+	}
+	problems := make(map[string]string)
+	for _, problem := range LintWithRulesets(document, rulesets...) {
+		problems[problem.Code+" "+problem.Path()] = problem.Message
+	}
+	return problems
+}
+
+func TestLintV2(t *testing.T) {
+	problems := lint(t, `
+swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        200:
+          description: pets
+    post:
+      operationId: listPets
+      responses:
+        400:
+          description: bad pet
+  /pets/{id}:
+    delete:
+      responses:
+        default:
+          description: deleted
+`)
+	expected := map[string]string{
+		"operation-id /paths/~1pets~1{id}/delete":       "operation has no operationId",
+		"operation-id-unique /paths/~1pets/post":        "operationId listPets is also used by /paths/~1pets/get",
+		"operation-success-response /paths/~1pets/post": "operation describes no successful or default response",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+}
+
+func TestLintV3(t *testing.T) {
+	problems := lint(t, `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      responses:
+        201:
+          description: pets
+    trace:
+      operationId: tracePets
+      responses:
+        404:
+          description: not found
+`)
+	expected := map[string]string{
+		"operation-id /paths/~1pets/get":                 "operation has no operationId",
+		"operation-success-response /paths/~1pets/trace": "operation describes no successful or default response",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+}
+
+func TestSecurityV2(t *testing.T) {
+	problems := lint(t, `
+swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+schemes: [https]
+securityDefinitions:
+  key: {type: apiKey, name: key, in: query}
+  header: {type: apiKey, name: X-Key, in: header}
+  basic: {type: basic}
+  oauth:
+    type: oauth2
+    flow: implicit
+    authorizationUrl: https://example.com/auth
+    scopes: {"pets:read": read pets, "pets:all": everything}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      schemes: [http]
+      responses:
+        200:
+          description: pets
+    post:
+      operationId: createPet
+      security: [{oauth: ["pets:all"]}]
+      responses:
+        200:
+          description: created
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        200:
+          description: healthy
+`, SecurityRuleset)
+	expected := map[string]string{
+		"security-api-key-in-query /securityDefinitions/key":              "API key is sent in the query string, where it is often logged",
+		"security-insecure-server /paths/~1pets/get/schemes":              "operation is served with http, which isn't encrypted",
+		"security-basic-auth-without-tls /securityDefinitions/basic":      "basic authentication sends passwords to servers that aren't encrypted",
+		"security-operation-requirement /paths/~1pets/get":                "operation has no security requirement",
+		"security-broad-scope /securityDefinitions/oauth/scopes/pets:all": "scope pets:all grants broad access",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+}
+
+func TestSecurityV3(t *testing.T) {
+	problems := lint(t, `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: "1.0"
+servers:
+- url: http://pets.example.com/v1
+- url: http://localhost:8080/v1
+- url: https://pets.example.com/v1
+security: [{basic: []}]
+components:
+  securitySchemes:
+    key: {type: apiKey, name: key, in: query}
+    basic: {type: http, scheme: basic}
+    oauth:
+      type: oauth2
+      flow:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes: {"*": everything}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        200:
+          description: pets
+`, SecurityRuleset)
+	expected := map[string]string{
+		"security-api-key-in-query /components/securitySchemes/key":                              "API key is sent in the query string, where it is often logged",
+		"security-insecure-server /servers/0":                                                    "server http://pets.example.com/v1 isn't encrypted",
+		"security-basic-auth-without-tls /components/securitySchemes/basic":                      "basic authentication sends passwords to servers that aren't encrypted",
+		"security-broad-scope /components/securitySchemes/oauth/flow/clientCredentials/scopes/*": "scope * grants broad access",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+	if IsRuleset("unknown") || !IsRuleset(SecurityRuleset) {
+		t.Errorf("unexpected rulesets")
+	}
+}
+
+func TestResponseCodesV2(t *testing.T) {
+	problems := lint(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        200: {description: pets}
+        "200": {description: more pets}
+        600: {description: impossible}
+        default: {description: error}
+`)
+	expected := map[string]string{
+		"response-code /paths/~1pets/get/responses/200": "response code 200 is described more than once",
+		"response-code /paths/~1pets/get/responses/600": "response code 600 is not a valid HTTP status code",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+}
+
+func TestResponseCodesV3(t *testing.T) {
+	text := `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        2XX: {description: pets}
+        4XX: {description: bad request}
+        099: {description: impossible}
+        X00: {description: not a range}
+`
+	problems := lint(t, text)
+	expected := map[string]string{
+		"response-code /paths/~1pets/get/responses/099": "response code 099 is not a valid HTTP status code",
+		"response-code /paths/~1pets/get/responses/X00": "response code X00 is not a valid HTTP status code",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+	problems = lint(t, text, StrictRuleset)
+	expected = map[string]string{
+		"response-code-range /paths/~1pets/get/responses/2XX": "response code 2XX is a range instead of a status code",
+		"response-code-range /paths/~1pets/get/responses/4XX": "response code 4XX is a range instead of a status code",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+}
+
+func TestCompletenessV2(t *testing.T) {
+	text := `
+swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+  license: {name: Apache 2.0}
+tags:
+- {name: pets, description: Everything about pets}
+- {name: owners}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      responses:
+        200: {description: pets}
+    post:
+      operationId: createPet
+      responses:
+        201: {description: created}
+`
+	if problems := lint(t, text); len(problems) != 0 {
+		t.Errorf("expected the completeness rules to be off by default, found %+v", problems)
+	}
+	problems := lint(t, text, CompletenessRuleset)
+	expected := map[string]string{
+		"info-contact /info":                   "info has no contact information",
+		"operation-summary /paths/~1pets/post": "operation has no summary",
+		"tag-description /tags/1":              "tag owners has no description",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+}
+
+func TestCompletenessV3(t *testing.T) {
+	problems := lint(t, `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: "1.0"
+  contact: {email: pets@example.com}
+servers:
+- {url: "https://example.com/v1", description: production}
+- {url: "https://staging.example.com/v1"}
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      servers:
+        url: "https://pets.example.com"
+      responses:
+        200: {description: pets}
+`, CompletenessRuleset)
+	expected := map[string]string{
+		"info-license /info":                           "info has no license",
+		"server-description /servers/1":                "server https://staging.example.com/v1 has no description",
+		"server-description /paths/~1pets/get/servers": "server https://pets.example.com has no description",
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"fmt"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+type operationV2 struct {
+	context   *compiler.Context
+	path      []string // the names of the keys that lead to the operation
+	operation *openapi_v2.Operation
+}
+
+// Returns the operations of a v2 document in the order that they are described.
+func operationsV2(document *openapi_v2.Document) []operationV2 {
+	operations := make([]operationV2, 0)
+	if document.Paths == nil {
+		return operations
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		if item == nil {
+			continue
+		}
+		methods := []struct {
+			name      string
+			operation *openapi_v2.Operation
+		}{
+			{"get", item.Get}, {"put", item.Put}, {"post", item.Post}, {"delete", item.Delete},
+			{"options", item.Options}, {"head", item.Head}, {"patch", item.Patch},
+		}
+		for _, method := range methods {
+			if method.operation != nil {
+				path := []string{"paths", pair.Name, method.name}
+				operations = append(operations, operationV2{context: contextForPath(path...), path: path, operation: method.operation})
+			}
+		}
+	}
+	return operations
+}
+
+type operationV3 struct {
+	context   *compiler.Context
+	path      []string // the names of the keys that lead to the operation
+	operation *openapi_v3.Operation
+}
+
+// Returns the operations of a v3 document in the order that they are described.
+func operationsV3(document *openapi_v3.Document) []operationV3 {
+	operations := make([]operationV3, 0)
+	if document.Paths == nil {
+		return operations
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		if item == nil {
+			continue
+		}
+		methods := []struct {
+			name      string
+			operation *openapi_v3.Operation
+		}{
+			{"get", item.Get}, {"put", item.Put}, {"post", item.Post}, {"delete", item.Delete},
+			{"options", item.Options}, {"head", item.Head}, {"patch", item.Patch}, {"trace", item.Trace},
+		}
+		for _, method := range methods {
+			if method.operation != nil {
+				path := []string{"paths", pair.Name, method.name}
+				operations = append(operations, operationV3{context: contextForPath(path...), path: path, operation: method.operation})
+			}
+		}
+	}
+	return operations
+}
+
+func checkOperationIDsV2(l *linter, document *openapi_v2.Document) {
+	for _, o := range operationsV2(document) {
+		if o.operation.OperationId == "" {
+			l.report(o.context, "operation has no operationId")
+		}
+	}
+}
+
+func checkOperationIDsV3(l *linter, document *openapi_v3.Document) {
+	for _, o := range operationsV3(document) {
+		if o.operation.OperationId == "" {
+			l.report(o.context, "operation has no operationId")
+		}
+	}
+}
+
+// uniqueIDs reports the operationIds that are used more than once.
+type uniqueIDs struct {
+	l    *linter
+	seen map[string]string
+}
+
+func (u *uniqueIDs) check(context *compiler.Context, id string) {
+	if id == "" {
+		return
+	}
+	if previous, ok := u.seen[id]; ok {
+		u.l.report(context, fmt.Sprintf("operationId %s is also used by %s", id, previous))
+		return
+	}
+	u.seen[id] = context.Pointer()
+}
+
+func checkUniqueOperationIDsV2(l *linter, document *openapi_v2.Document) {
+	u := &uniqueIDs{l: l, seen: make(map[string]string)}
+	for _, o := range operationsV2(document) {
+		u.check(o.context, o.operation.OperationId)
+	}
+}
+
+func checkUniqueOperationIDsV3(l *linter, document *openapi_v3.Document) {
+	u := &uniqueIDs{l: l, seen: make(map[string]string)}
+	for _, o := range operationsV3(document) {
+		u.check(o.context, o.operation.OperationId)
+	}
+}
+
+func checkSuccessResponsesV2(l *linter, document *openapi_v2.Document) {
+	for _, o := range operationsV2(document) {
+		found := false
+		if o.operation.Responses != nil {
+			for _, pair := range o.operation.Responses.ResponseCode {
+				found = found || isSuccessResponseCode(pair.Name)
+			}
+		}
+		if !found {
+			l.report(o.context, "operation describes no successful or default response")
+		}
+	}
+}
+
+func checkSuccessResponsesV3(l *linter, document *openapi_v3.Document) {
+	for _, o := range operationsV3(document) {
+		found := false
+		if responses := o.operation.Responses; responses != nil {
+			found = responses.Default != nil
+			for _, pair := range responses.ResponseCode {
+				found = found || isSuccessResponseCode(pair.Name)
+			}
+		}
+		if !found {
+			l.report(o.context, "operation describes no successful or default response")
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+)
+
+// HTTP status codes are between 100 and 599. OpenAPI 3 also allows ranges
+// of codes that have the same first digit, such as 2XX.
+var (
+	statusCode      = regexp.MustCompile("^[1-5][0-9][0-9]$")
+	statusCodeRange = regexp.MustCompile("^[1-5]XX$")
+)
+
+// Checks that the codes of a list of responses are valid and are not repeated.
+// Ranges are only valid when they are allowed.
+func (l *linter) checkResponseCodes(responses *compiler.Context, codes []string, ranges bool) {
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		context := compiler.NewContext(code, responses)
+		if !statusCode.MatchString(code) && !(ranges && statusCodeRange.MatchString(code)) {
+			l.report(context, fmt.Sprintf("response code %s is not a valid HTTP status code", code))
+		} else if seen[code] {
+			l.report(context, fmt.Sprintf("response code %s is described more than once", code))
+		}
+		seen[code] = true
+	}
+}
+
+func checkResponseCodesV2(l *linter, document *openapi_v2.Document) {
+	for _, o := range operationsV2(document) {
+		codes := make([]string, 0)
+		for _, pair := range o.operation.GetResponses().GetResponseCode() {
+			if pair.Name != "default" {
+				codes = append(codes, pair.Name)
+			}
+		}
+		l.checkResponseCodes(compiler.NewContext("responses", o.context), codes, false)
+	}
+}
+
+func checkResponseCodesV3(l *linter, document *openapi_v3.Document) {
+	for _, o := range operationsV3(document) {
+		codes := make([]string, 0)
+		for _, pair := range o.operation.GetResponses().GetResponseCode() {
+			codes = append(codes, pair.Name)
+		}
+		l.checkResponseCodes(compiler.NewContext("responses", o.context), codes, true)
+	}
+}
+
+// Ranges of response codes aren't allowed in v2 documents.
+func checkResponseCodeRangesV2(l *linter, document *openapi_v2.Document) {}
+
+func checkResponseCodeRangesV3(l *linter, document *openapi_v3.Document) {
+	for _, o := range operationsV3(document) {
+		for _, pair := range o.operation.GetResponses().GetResponseCode() {
+			if statusCodeRange.MatchString(pair.Name) {
+				context := compiler.NewContext(pair.Name, compiler.NewContext("responses", o.context))
+				l.report(context, fmt.Sprintf("response code %s is a range instead of a status code", pair.Name))
+			}
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linter
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// Returns true if a URL is served without TLS from a host other than the local one.
+func isInsecureURL(text string) bool {
+	u, err := url.Parse(text)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "ws") {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return false
+	}
+	return true
+}
+
+// Returns true if a scheme of a v2 document is served without TLS.
+func isInsecureScheme(scheme string) bool {
+	return scheme == "http" || scheme == "ws"
+}
+
+// Returns true if an OAuth scope grants access to everything, like "*" or "admin".
+func isBroadScope(scope string) bool {
+	scope = strings.ToLower(scope)
+	switch scope {
+	case "*", "all", "admin", "root", "superuser", "full", "full_access", "full-access":
+		return true
+	}
+	for _, suffix := range []string{":*", ".*", "/*", ":all", ".all", "/all"} {
+		if strings.HasSuffix(scope, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkAPIKeysInQueryV2(l *linter, document *openapi_v2.Document) {
+	for _, pair := range document.GetSecurityDefinitions().GetAdditionalProperties() {
+		if key := pair.Value.GetApiKeySecurity(); key != nil && key.In == "query" {
+			l.report(contextForPath("securityDefinitions", pair.Name), "API key is sent in the query string, where it is often logged")
+		}
+	}
+}
+
+func checkAPIKeysInQueryV3(l *linter, document *openapi_v3.Document) {
+	for _, pair := range document.GetComponents().GetSecuritySchemes().GetAdditionalProperties() {
+		if scheme := pair.Value; scheme != nil && scheme.Type == "apiKey" && scheme.In == "query" {
+			l.report(contextForPath("components", "securitySchemes", pair.Name), "API key is sent in the query string, where it is often logged")
+		}
+	}
+}
+
+// Returns true if a v2 document or any of its operations is served without TLS.
+func hasInsecureSchemesV2(document *openapi_v2.Document) bool {
+	for _, scheme := range document.Schemes {
+		if isInsecureScheme(scheme) {
+			return true
+		}
+	}
+	for _, o := range operationsV2(document) {
+		for _, scheme := range o.operation.Schemes {
+			if isInsecureScheme(scheme) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func checkInsecureServersV2(l *linter, document *openapi_v2.Document) {
+	for _, scheme := range document.Schemes {
+		if isInsecureScheme(scheme) {
+			l.report(contextForPath("schemes"), fmt.Sprintf("API is served with %s, which isn't encrypted", scheme))
+		}
+	}
+	for _, o := range operationsV2(document) {
+		for _, scheme := range o.operation.Schemes {
+			if isInsecureScheme(scheme) {
+				l.report(contextForPath(append(o.path, "schemes")...), fmt.Sprintf("operation is served with %s, which isn't encrypted", scheme))
+			}
+		}
+	}
+}
+
+// A server of a v3 document and the names of the keys that lead to it.
+type serverV3 struct {
+	path   []string
+	server *openapi_v3.Server
+}
+
+// Returns the servers of a v3 document, its paths, and its operations.
+func serversV3(document *openapi_v3.Document) []serverV3 {
+	servers := make([]serverV3, 0)
+	for i, server := range document.Servers {
+		servers = append(servers, serverV3{path: []string{"servers", fmt.Sprintf("%d", i)}, server: server})
+	}
+	for _, pair := range document.GetPaths().GetPath() {
+		if server := pair.Value.GetServers(); server != nil {
+			servers = append(servers, serverV3{path: []string{"paths", pair.Name, "servers"}, server: server})
+		}
+	}
+	for _, o := range operationsV3(document) {
+		if server := o.operation.Servers; server != nil {
+			servers = append(servers, serverV3{path: append(o.path, "servers"), server: server})
+		}
+	}
+	return servers
+}
+
+func checkInsecureServersV3(l *linter, document *openapi_v3.Document) {
+	for _, s := range serversV3(document) {
+		if isInsecureURL(s.server.Url) {
+			l.report(contextForPath(s.path...), fmt.Sprintf("server %s isn't encrypted", s.server.Url))
+		}
+	}
+}
+
+func checkBasicAuthenticationV2(l *linter, document *openapi_v2.Document) {
+	if !hasInsecureSchemesV2(document) {
+		return
+	}
+	for _, pair := range document.GetSecurityDefinitions().GetAdditionalProperties() {
+		if pair.Value.GetBasicAuthenticationSecurity() != nil {
+			l.report(contextForPath("securityDefinitions", pair.Name), "basic authentication sends passwords to servers that aren't encrypted")
+		}
+	}
+}
+
+func checkBasicAuthenticationV3(l *linter, document *openapi_v3.Document) {
+	insecure := false
+	for _, s := range serversV3(document) {
+		insecure = insecure || isInsecureURL(s.server.Url)
+	}
+	if !insecure {
+		return
+	}
+	for _, pair := range document.GetComponents().GetSecuritySchemes().GetAdditionalProperties() {
+		if scheme := pair.Value; scheme != nil && scheme.Type == "http" && strings.ToLower(scheme.Scheme) == "basic" {
+			l.report(contextForPath("components", "securitySchemes", pair.Name), "basic authentication sends passwords to servers that aren't encrypted")
+		}
+	}
+}
+
+// Operations that are meant to be public should have an empty list of
+// requirements, which is distinguished from a missing one.
+func checkOperationSecurityV2(l *linter, document *openapi_v2.Document) {
+	if document.Security != nil {
+		return
+	}
+	for _, o := range operationsV2(document) {
+		if o.operation.Security == nil {
+			l.report(o.context, "operation has no security requirement")
+		}
+	}
+}
+
+func checkOperationSecurityV3(l *linter, document *openapi_v3.Document) {
+	if document.Security != nil {
+		return
+	}
+	for _, o := range operationsV3(document) {
+		if o.operation.Security == nil {
+			l.report(o.context, "operation has no security requirement")
+		}
+	}
+}
+
+// Reports the broad scopes of an OAuth security scheme.
+func (l *linter) checkScopes(path []string, scopes []string) {
+	for _, scope := range scopes {
+		if isBroadScope(scope) {
+			l.report(contextForPath(append(path, scope)...), fmt.Sprintf("scope %s grants broad access", scope))
+		}
+	}
+}
+
+func checkBroadScopesV2(l *linter, document *openapi_v2.Document) {
+	for _, pair := range document.GetSecurityDefinitions().GetAdditionalProperties() {
+		var scopes *openapi_v2.Oauth2Scopes
+		switch {
+		case pair.Value.GetOauth2ImplicitSecurity() != nil:
+			scopes = pair.Value.GetOauth2ImplicitSecurity().Scopes
+		case pair.Value.GetOauth2PasswordSecurity() != nil:
+			scopes = pair.Value.GetOauth2PasswordSecurity().Scopes
+		case pair.Value.GetOauth2ApplicationSecurity() != nil:
+			scopes = pair.Value.GetOauth2ApplicationSecurity().Scopes
+		case pair.Value.GetOauth2AccessCodeSecurity() != nil:
+			scopes = pair.Value.GetOauth2AccessCodeSecurity().Scopes
+		}
+		names := make([]string, 0)
+		for _, scope := range scopes.GetAdditionalProperties() {
+			names = append(names, scope.Name)
+		}
+		l.checkScopes([]string{"securityDefinitions", pair.Name, "scopes"}, names)
+	}
+}
+
+func checkBroadScopesV3(l *linter, document *openapi_v3.Document) {
+	for _, pair := range document.GetComponents().GetSecuritySchemes().GetAdditionalProperties() {
+		flows := pair.Value.GetFlow()
+		for _, flow := range []struct {
+			name string
+			flow *openapi_v3.OauthFlow
+		}{
+			{"implicit", flows.GetImplicit()}, {"password", flows.GetPassword()},
+			{"clientCredentials", flows.GetClientCredentials()}, {"authorizationCode", flows.GetAuthorizationCode()},
+		} {
+			names := make([]string, 0)
+			for _, scope := range flow.flow.GetScopes().GetName() {
+				names = append(names, scope.Name)
+			}
+			l.checkScopes([]string{"components", "securitySchemes", pair.Name, "flow", flow.name, "scopes"}, names)
+		}
+	}
+}
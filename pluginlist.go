@@ -0,0 +1,85 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A list of plugins to run, which is read from a YAML file like this:
+//
+//	plugins:
+//	- name: go-generator
+//	  output: generated
+//	  parameters:
+//	    package: petstore
+//	- name: builtin:summary
+//	  output: "-"
+type pluginList struct {
+	Plugins []*pluginListEntry `yaml:"plugins"`
+}
+
+type pluginListEntry struct {
+	Name       string            `yaml:"name"`       // the name of the plugin, as in --plugin NAME
+	Output     string            `yaml:"output"`     // the location of its outputs, "." by default
+	Parameters map[string]string `yaml:"parameters"` // the parameters that are passed to it
+}
+
+// Reads a list of plugins and returns the calls that run them. Unknown
+// fields are errors.
+func readPluginList(filename string) ([]*PluginCall, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to read the plugins in %s: %s", filename, err.Error()))
+	}
+	list := &pluginList{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(list); err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to read the plugins in %s: %s", filename, err.Error()))
+	}
+	pluginCalls := make([]*PluginCall, 0)
+	for i, entry := range list.Plugins {
+		if entry.Name == "" {
+			return nil, errors.New(fmt.Sprintf("plugin %d in %s has no name", i+1, filename))
+		}
+		pluginCalls = append(pluginCalls, &PluginCall{Name: entry.Name, Invocation: entry.invocation()})
+	}
+	return pluginCalls, nil
+}
+
+// Returns the invocation of a plugin, in the form of a --PLUGIN-out value.
+func (entry *pluginListEntry) invocation() string {
+	output := entry.Output
+	if output == "" {
+		output = "."
+	}
+	if len(entry.Parameters) == 0 {
+		return output
+	}
+	parameters := make([]string, 0, len(entry.Parameters))
+	for name, value := range entry.Parameters {
+		parameters = append(parameters, name+"="+value)
+	}
+	sort.Strings(parameters)
+	return strings.Join(parameters, ",") + ":" + output
+}
@@ -18,13 +18,16 @@
 // It reads files from a "templates" directory, and generates
 // a Go source file containing base64-encoded representations of those
 // files. This allows these files to be directly compiled into the
-// executable.
+// executable. The generated file belongs to the package that is being
+// generated, which is named by the GOPACKAGE variable set by go generate.
 package main
 
 import (
 	"bytes"
 	"encoding/base64"
+	"go/format"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -44,13 +47,14 @@ const TEMPLATES_GO = `// Copyright 2017 Google Inc. All Rights Reserved.
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package {{.Package}}
 
 func templates() map[string]string {
 	return map[string]string{ {{range .TemplateStrings}}
         "{{.Name}}": "{{.Encoding}}",{{end}}
     }
-}`
+}
+`
 
 type NamedTemplateString struct {
 	Name     string // the name of the file to be generated by the template
@@ -79,9 +83,20 @@ func main() {
 		panic(err)
 	}
 	f := new(bytes.Buffer)
-	err = t.Execute(f, struct{ TemplateStrings []*NamedTemplateString }{templateStrings})
+	packageName := os.Getenv("GOPACKAGE")
+	if packageName == "" {
+		packageName = "main"
+	}
+	err = t.Execute(f, struct {
+		Package         string
+		TemplateStrings []*NamedTemplateString
+	}{packageName, templateStrings})
+	if err != nil {
+		panic(err)
+	}
+	formatted, err := format.Source(f.Bytes())
 	if err != nil {
 		panic(err)
 	}
-	ioutil.WriteFile("templates.go", f.Bytes(), 0644)
+	ioutil.WriteFile("templates.go", formatted, 0644)
 }
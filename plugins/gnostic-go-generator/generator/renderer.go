@@ -12,7 +12,12 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+//go:generate encode-templates
+
+// Package generator generates Go clients and servers for APIs with OpenAPI v2
+// descriptions. It is used by the gnostic-go-generator plugin and by the
+// go-generator plugin that is built into gnostic.
+package generator
 
 import (
 	"bytes"
@@ -143,6 +148,10 @@ func (renderer *ServiceRenderer) loadService(document *openapi.Document) (err er
 					var f ServiceTypeField
 					f.Name = strings.Title(pair2.Name)
 					f.Type = typeForSchema(pair2.Value)
+					if pair2.Value.IsNullable() && isScalarType(f.Type) {
+						// nullable values are pointers so that null can be distinguished from zero
+						f.Type = "*" + f.Type
+					}
 					f.JSONName = pair2.Name
 					t.Fields = append(t.Fields, &f)
 				}
@@ -347,13 +356,28 @@ func typeForName(name string, format string) (typeName string) {
 	}
 }
 
+// Returns true if a type is a Go scalar type that is generated for schemas.
+func isScalarType(typeName string) bool {
+	switch typeName {
+	case "string", "int", "int32":
+		return true
+	}
+	return false
+}
+
 func typeForSchema(schema *openapi.Schema) (typeName string) {
 	ref := schema.XRef
 	if ref != "" {
 		return typeForRef(ref)
 	}
 	if schema.Type != nil {
-		types := schema.Type.Value
+		// "null" is ignored, nullable values are handled by callers
+		types := make([]string, 0)
+		for _, t := range schema.Type.Value {
+			if t != "null" {
+				types = append(types, t)
+			}
+		}
 		format := schema.Format
 		if len(types) == 1 && types[0] == "string" {
 			return "string"
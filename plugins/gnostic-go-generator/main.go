@@ -12,8 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:generate encode-templates
-
 // gnostic_go_generator is a sample Gnostic plugin that generates Go
 // code that supports an API.
 package main
@@ -29,6 +27,7 @@ import (
 
 	openapi "github.com/googleapis/gnostic/OpenAPIv2"
 	plugins "github.com/googleapis/gnostic/plugins"
+	"github.com/googleapis/gnostic/plugins/gnostic-go-generator/generator"
 )
 
 // Helper: if error is not nil, record it, serializes and returns the response and exits
@@ -99,7 +98,7 @@ func main() {
 	sendAndExitIfError(err, response)
 
 	// Create the renderer.
-	renderer, err := NewServiceRenderer(document, packageName)
+	renderer, err := generator.NewServiceRenderer(document, packageName)
 	sendAndExitIfError(err, response)
 
 	// Run the renderer to generate files and add them to the response object.
@@ -0,0 +1,360 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/googleapis/gnostic/printer"
+)
+
+// A graphQLType is an object, input, enum, or scalar type of a schema.
+type graphQLType struct {
+	Kind        string // "type", "input", "enum", or "scalar"
+	Name        string
+	Description string
+	Fields      []*graphQLField
+	Values      []string
+}
+
+type graphQLField struct {
+	Name        string
+	JSONName    string
+	Description string
+	Type        string
+	Arguments   []*graphQLField
+}
+
+// A Resolver maps a query or mutation to the operation that resolves it.
+type Resolver struct {
+	Type      string      `json:"type"`
+	Field     string      `json:"field"`
+	Method    string      `json:"method"`
+	Path      string      `json:"path"`
+	Arguments []*Argument `json:"arguments,omitempty"`
+}
+
+// An Argument maps an argument of a query or mutation to a request parameter
+// or (with "in" set to "body") to the request body.
+type Argument struct {
+	Name      string `json:"name"`
+	In        string `json:"in"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// A Manifest describes how the queries and mutations of a generated schema
+// are resolved by the operations of an API.
+type Manifest struct {
+	Resolvers []*Resolver `json:"resolvers"`
+	// Fields lists the renamed fields of each type, mapping GraphQL names to JSON names.
+	Fields map[string]map[string]string `json:"fields,omitempty"`
+}
+
+type generator struct {
+	api      *api
+	types    []*graphQLType
+	byName   map[string]*graphQLType
+	manifest *Manifest
+}
+
+func newGenerator(a *api) *generator {
+	return &generator{
+		api:      a,
+		types:    make([]*graphQLType, 0),
+		byName:   make(map[string]*graphQLType, 0),
+		manifest: &Manifest{Resolvers: make([]*Resolver, 0), Fields: make(map[string]map[string]string, 0)},
+	}
+}
+
+var invalidName = regexp.MustCompile("[^_0-9A-Za-z]+")
+
+var separators = regexp.MustCompile("[^0-9A-Za-z]+")
+
+// Returns a valid GraphQL name in camel case, starting with an upper case
+// letter if exported is set. Names that are all upper case are kept.
+func graphQLName(name string, exported bool) string {
+	result := ""
+	for _, part := range separators.Split(name, -1) {
+		if part == "" {
+			continue
+		}
+		if result == "" && !exported {
+			if part != strings.ToUpper(part) {
+				part = strings.ToLower(part[:1]) + part[1:]
+			}
+		} else {
+			part = strings.ToUpper(part[:1]) + part[1:]
+		}
+		result += part
+	}
+	if result == "" {
+		return "_"
+	}
+	if unicode.IsDigit(rune(result[0])) {
+		result = "_" + result
+	}
+	return result
+}
+
+// Returns the name of an enum value, which must be a valid name in upper case.
+func enumValue(value string) string {
+	name := strings.ToUpper(strings.Trim(invalidName.ReplaceAllString(value, "_"), "_"))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	switch name {
+	case "TRUE", "FALSE", "NULL":
+		name += "_"
+	}
+	return name
+}
+
+// Adds a type, or returns an existing type with the same name.
+func (g *generator) addType(t *graphQLType) (*graphQLType, bool) {
+	if existing := g.byName[t.Name]; existing != nil {
+		return existing, false
+	}
+	g.byName[t.Name] = t
+	g.types = append(g.types, t)
+	return t, true
+}
+
+// Returns the GraphQL type of a shape. Objects and enums that aren't named
+// schemas are named for the context in which they appear, and objects are
+// written as input types if input is set.
+func (g *generator) typeReference(s *shape, context string, input bool) string {
+	if s.Ref != "" {
+		target := g.api.Schemas[s.Ref]
+		if target == nil {
+			return g.scalar("JSON", "Any JSON value.")
+		}
+		return g.typeReference(target, graphQLName(s.Ref, true), input)
+	}
+	switch {
+	case s.Type == "array" || s.Items != nil:
+		if s.Items == nil {
+			return "[" + g.scalar("JSON", "Any JSON value.") + "]"
+		}
+		return "[" + g.typeReference(s.Items, context+"Item", input) + "]"
+	case len(s.Properties) > 0 || len(s.AllOf) > 0:
+		return g.object(s, context, input)
+	case s.Type == "object" || s.Type == "":
+		return g.scalar("JSON", "Any JSON value.")
+	case s.Type == "string" && len(s.Enum) > 0:
+		t, added := g.addType(&graphQLType{Kind: "enum", Name: context, Description: s.Description})
+		if added {
+			for _, value := range s.Enum {
+				if !contains(t.Values, enumValue(value)) {
+					t.Values = append(t.Values, enumValue(value))
+				}
+			}
+		}
+		return t.Name
+	case s.Type == "integer" && s.Format == "int64":
+		// GraphQL integers have 32 bits
+		return g.scalar("Long", "A 64-bit integer.")
+	case s.Type == "integer":
+		return "Int"
+	case s.Type == "number":
+		return "Float"
+	case s.Type == "boolean":
+		return "Boolean"
+	}
+	return "String"
+}
+
+// Returns the name of a custom scalar type, adding it if necessary.
+func (g *generator) scalar(name, description string) string {
+	g.addType(&graphQLType{Kind: "scalar", Name: name, Description: description})
+	return name
+}
+
+// Returns the name of an object or input type, adding it if necessary.
+func (g *generator) object(s *shape, name string, input bool) string {
+	kind := "type"
+	if input {
+		kind, name = "input", name+"Input"
+	}
+	t, added := g.addType(&graphQLType{Kind: kind, Name: name, Description: s.Description})
+	if !added {
+		return t.Name
+	}
+	properties, required := g.api.properties(s, nil)
+	for _, p := range properties {
+		if g.hasField(t, graphQLName(p.Name, false)) {
+			// properties of allOf members can be repeated
+			continue
+		}
+		field := &graphQLField{
+			Name:        graphQLName(p.Name, false),
+			JSONName:    p.Name,
+			Description: g.api.resolve(p.Shape).Description,
+		}
+		field.Type = g.typeReference(p.Shape, strings.TrimSuffix(name, "Input")+graphQLName(p.Name, true), input)
+		if contains(required, p.Name) && !g.api.resolve(p.Shape).Nullable {
+			field.Type += "!"
+		}
+		if field.Name != p.Name {
+			if g.manifest.Fields[t.Name] == nil {
+				g.manifest.Fields[t.Name] = make(map[string]string, 0)
+			}
+			g.manifest.Fields[t.Name][field.Name] = p.Name
+		}
+		t.Fields = append(t.Fields, field)
+	}
+	if len(t.Fields) == 0 {
+		t.Fields = append(t.Fields, &graphQLField{Name: "_empty", Type: "Boolean"})
+	}
+	return t.Name
+}
+
+func (g *generator) hasField(t *graphQLType, name string) bool {
+	for _, field := range t.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the name of the field of an operation.
+func (op *operation) fieldName() string {
+	if op.ID != "" {
+		return graphQLName(op.ID, false)
+	}
+	return graphQLName(strings.ToLower(op.Method)+" "+strings.Replace(strings.Replace(op.Path, "{", "by ", -1), "}", "", -1), false)
+}
+
+// Generates the GraphQL schema of an API and the manifest that maps its
+// queries and mutations to operations. Named schemas are written as object
+// types; GET operations are queries and other operations are mutations.
+func (g *generator) generate() (string, *Manifest) {
+	for _, name := range g.api.SchemaNames {
+		g.typeReference(&shape{Ref: name}, "", false)
+	}
+	queries := &graphQLType{Kind: "type", Name: "Query"}
+	mutations := &graphQLType{Kind: "type", Name: "Mutation"}
+	for _, op := range g.api.Operations {
+		field := &graphQLField{Name: op.fieldName(), Description: op.Description}
+		parent := mutations
+		if op.Method == "GET" {
+			parent = queries
+		}
+		resolver := &Resolver{Type: parent.Name, Field: field.Name, Method: op.Method, Path: op.Path}
+		for _, p := range op.Parameters {
+			argument := &graphQLField{
+				Name:        graphQLName(p.Name, false),
+				Description: p.Description,
+				Type:        g.typeReference(p.Shape, graphQLName(field.Name, true)+graphQLName(p.Name, true), true),
+			}
+			if p.Required {
+				argument.Type += "!"
+			}
+			field.Arguments = append(field.Arguments, argument)
+			resolver.Arguments = append(resolver.Arguments, &Argument{Name: argument.Name, In: p.In, Parameter: p.Name})
+		}
+		if op.Body != nil {
+			argument := &graphQLField{Name: "input", Type: g.typeReference(op.Body, graphQLName(field.Name, true), true)}
+			if op.BodyRequired {
+				argument.Type += "!"
+			}
+			field.Arguments = append(field.Arguments, argument)
+			resolver.Arguments = append(resolver.Arguments, &Argument{Name: "input", In: "body"})
+		}
+		field.Type = "Boolean"
+		if op.Response != nil {
+			field.Type = g.typeReference(op.Response, graphQLName(field.Name, true)+"Response", false)
+		}
+		parent.Fields = append(parent.Fields, field)
+		g.manifest.Resolvers = append(g.manifest.Resolvers, resolver)
+	}
+	if len(queries.Fields) == 0 {
+		// schemas must have a query type with at least one field
+		queries.Fields = append(queries.Fields, &graphQLField{Name: "_empty", Type: "Boolean"})
+	}
+
+	code := &printer.Code{}
+	if g.api.Title != "" {
+		code.Print("# GraphQL schema of %s, generated by gnostic-graphql-generator.", g.api.Title)
+		code.Print()
+	}
+	printType(code, queries)
+	if len(mutations.Fields) > 0 {
+		printType(code, mutations)
+	}
+	for _, t := range g.types {
+		printType(code, t)
+	}
+	return strings.TrimSuffix(code.String(), "\n"), g.manifest
+}
+
+func printDescription(code *printer.Code, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	if !strings.Contains(description, "\n") {
+		code.Print("%s", fmt.Sprintf("%q", description))
+		return
+	}
+	code.Print(`"""`)
+	for _, line := range strings.Split(strings.Replace(description, `"""`, `\"""`, -1), "\n") {
+		code.Print("%s", line)
+	}
+	code.Print(`"""`)
+}
+
+func printType(code *printer.Code, t *graphQLType) {
+	printDescription(code, t.Description)
+	switch t.Kind {
+	case "scalar":
+		code.Print("scalar %s", t.Name)
+		code.Print()
+		return
+	case "enum":
+		code.Print("enum %s {", t.Name)
+		code.Indent()
+		for _, value := range t.Values {
+			code.Print("%s", value)
+		}
+		code.Outdent()
+		code.Print("}")
+		code.Print()
+		return
+	}
+	code.Print("%s %s {", t.Kind, t.Name)
+	code.Indent()
+	for _, field := range t.Fields {
+		printDescription(code, field.Description)
+		if len(field.Arguments) == 0 {
+			code.Print("%s: %s", field.Name, field.Type)
+			continue
+		}
+		code.Print("%s(", field.Name)
+		code.Indent()
+		for _, argument := range field.Arguments {
+			printDescription(code, argument.Description)
+			code.Print("%s: %s", argument.Name, argument.Type)
+		}
+		code.Outdent()
+		code.Print("): %s", field.Type)
+	}
+	code.Outdent()
+	code.Print("}")
+	code.Print()
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/lib"
+)
+
+const description = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: page_size
+          in: query
+          schema:
+            type: integer
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        201:
+          description: Created
+  /pets/{id}:
+    delete:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        204:
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      description: A pet.
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        status:
+          type: string
+          enum: [available, sold-out]
+        owner:
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      properties:
+        id:
+          type: integer
+          format: int64
+`
+
+const schema = `# GraphQL schema of Pets, generated by gnostic-graphql-generator.
+
+type Query {
+  listPets(
+    pageSize: Int
+  ): [Pet]
+}
+
+type Mutation {
+  createPet(
+    input: PetInput!
+  ): Boolean
+  deletePetsById(
+    id: String!
+  ): Boolean
+}
+
+"A pet."
+type Pet {
+  name: String!
+  status: PetStatus
+  owner: Owner
+}
+
+enum PetStatus {
+  AVAILABLE
+  SOLD_OUT
+}
+
+type Owner {
+  id: Long
+}
+
+"A 64-bit integer."
+scalar Long
+
+"A pet."
+input PetInput {
+  name: String!
+  status: PetStatus
+  owner: OwnerInput
+}
+
+input OwnerInput {
+  id: Long
+}
+`
+
+func TestGenerate(t *testing.T) {
+	document, err := gnostic.ReadDocumentFromBytes([]byte(description))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	sdl, manifest := newGenerator(apiV3(document.V3)).generate()
+	if sdl != schema {
+		t.Errorf("unexpected schema:\n%s", sdl)
+	}
+	if len(manifest.Resolvers) != 3 {
+		t.Fatalf("expected 3 resolvers, got %d", len(manifest.Resolvers))
+	}
+	r := manifest.Resolvers[0]
+	if r.Field != "listPets" || r.Method != "GET" || r.Arguments[0].Name != "pageSize" || r.Arguments[0].Parameter != "page_size" {
+		t.Errorf("unexpected resolver %+v", r)
+	}
+	r = manifest.Resolvers[1]
+	if r.Type != "Mutation" || r.Arguments[0].In != "body" {
+		t.Errorf("unexpected resolver %+v", r)
+	}
+}
+
+func TestGraphQLName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"page_size": "pageSize",
+		"x-request": "xRequest",
+		"PetId":     "petId",
+		"ID":        "ID",
+		"2fa":       "_2fa",
+	} {
+		if actual := graphQLName(name, false); actual != expected {
+			t.Errorf("graphQLName(%q) = %q, expected %q", name, actual, expected)
+		}
+	}
+	if !strings.HasPrefix(graphQLName("pet_status", true), "PetStatus") {
+		t.Errorf("unexpected exported name %q", graphQLName("pet_status", true))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-graphql-generator is a plugin that generates a GraphQL schema for
+// an API, along with a manifest that maps the queries and mutations of the
+// schema to the operations that resolve them.
+//
+// Named schemas become object types, and the request bodies that use them
+// become input types. GET operations are written as queries and all other
+// operations are written as mutations, with their parameters as arguments.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+	openapi_v3 "github.com/googleapis/gnostic/OpenAPIv3"
+	plugins "github.com/googleapis/gnostic/plugins"
+)
+
+// Record an error, then serialize and return a response.
+func sendAndExitIfError(err error, response *plugins.Response) {
+	if err != nil {
+		response.Errors = append(response.Errors, err.Error())
+		sendAndExit(response)
+	}
+}
+
+// Serialize and return a response.
+func sendAndExit(response *plugins.Response) {
+	responseBytes, _ := proto.Marshal(response)
+	os.Stdout.Write(responseBytes)
+	os.Exit(0)
+}
+
+func main() {
+	response := &plugins.Response{}
+
+	data, err := ioutil.ReadAll(os.Stdin)
+	sendAndExitIfError(err, response)
+	if len(data) == 0 {
+		sendAndExitIfError(errors.New("No input data.\n"), response)
+	}
+	request := &plugins.Request{}
+	err = proto.Unmarshal(data, request)
+	sendAndExitIfError(err, response)
+
+	var a *api
+	switch request.Wrapper.Version {
+	case "v2":
+		document := &openapi_v2.Document{}
+		err = proto.Unmarshal(request.Wrapper.Value, document)
+		sendAndExitIfError(err, response)
+		a = apiV2(document)
+	case "v3":
+		document := &openapi_v3.Document{}
+		err = proto.Unmarshal(request.Wrapper.Value, document)
+		sendAndExitIfError(err, response)
+		a = apiV3(document)
+	default:
+		err = errors.New(fmt.Sprintf("Unsupported OpenAPI version %s", request.Wrapper.Version))
+		sendAndExitIfError(err, response)
+	}
+
+	schema, manifest := newGenerator(a).generate()
+	response.Files = append(response.Files, &plugins.File{Name: "schema.graphql", Data: []byte(schema)})
+	bytes, err := json.MarshalIndent(manifest, "", "  ")
+	sendAndExitIfError(err, response)
+	response.Files = append(response.Files, &plugins.File{Name: "resolvers.json", Data: append(bytes, '\n')})
+
+	sendAndExit(response)
+}
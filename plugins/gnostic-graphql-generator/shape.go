@@ -0,0 +1,120 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+// A shape is a schema, read from either version of OpenAPI, with the
+// parts that can be described in GraphQL.
+type shape struct {
+	Ref         string // the name of a referenced named schema
+	Type        string
+	Format      string
+	Description string
+	Enum        []string
+	Properties  []*property
+	Required    []string
+	Items       *shape
+	AllOf       []*shape
+	Nullable    bool // values can be null even when they are required
+}
+
+type property struct {
+	Name  string
+	Shape *shape
+}
+
+// A parameter is an argument of an operation.
+type parameter struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	Shape       *shape
+}
+
+// An operation is an API method that is mapped to a query or mutation.
+type operation struct {
+	ID           string
+	Method       string
+	Path         string
+	Description  string
+	Parameters   []*parameter
+	Body         *shape
+	BodyRequired bool
+	Response     *shape
+}
+
+// An api is the schemas and operations of a description.
+type api struct {
+	Title       string
+	Description string
+	SchemaNames []string
+	Schemas     map[string]*shape
+	Operations  []*operation
+}
+
+func newAPI() *api {
+	return &api{
+		SchemaNames: make([]string, 0),
+		Schemas:     make(map[string]*shape, 0),
+		Operations:  make([]*operation, 0),
+	}
+}
+
+func (a *api) addSchema(name string, s *shape) {
+	a.SchemaNames = append(a.SchemaNames, name)
+	a.Schemas[name] = s
+}
+
+// Returns the shape that a shape refers to, following chains of references.
+func (a *api) resolve(s *shape) *shape {
+	for i := 0; s != nil && s.Ref != "" && i < len(a.Schemas); i++ {
+		target := a.Schemas[s.Ref]
+		if target == nil {
+			return &shape{}
+		}
+		s = target
+	}
+	return s
+}
+
+// Returns the properties of a shape, including those of its allOf members.
+// Named schemas that are being expanded are listed in seen.
+func (a *api) properties(s *shape, seen []string) ([]*property, []string) {
+	properties := make([]*property, 0)
+	required := make([]string, 0)
+	for _, member := range s.AllOf {
+		if member.Ref != "" {
+			if contains(seen, member.Ref) {
+				continue
+			}
+			seen = append(seen, member.Ref)
+		}
+		p, r := a.properties(a.resolve(member), seen)
+		properties = append(properties, p...)
+		required = append(required, r...)
+	}
+	properties = append(properties, s.Properties...)
+	required = append(required, s.Required...)
+	return properties, required
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+)
+
+// Returns the schemas and operations of an OpenAPI v2 document.
+func apiV2(document *openapi_v2.Document) *api {
+	a := newAPI()
+	if document.Info != nil {
+		a.Title, a.Description = document.Info.Title, document.Info.Description
+	}
+	if document.Definitions != nil {
+		for _, pair := range document.Definitions.AdditionalProperties {
+			a.addSchema(pair.Name, shapeV2(pair.Value))
+		}
+	}
+	if document.Paths == nil {
+		return a
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		operations := []*openapi_v2.Operation{item.Get, item.Put, item.Post, item.Delete, item.Patch}
+		for i, o := range operations {
+			if o == nil {
+				continue
+			}
+			op := &operation{
+				ID:          o.OperationId,
+				Method:      []string{"GET", "PUT", "POST", "DELETE", "PATCH"}[i],
+				Path:        strings.TrimSuffix(document.BasePath, "/") + pair.Name,
+				Description: o.Description,
+			}
+			if op.Description == "" {
+				op.Description = o.Summary
+			}
+			for _, p := range append(append([]*openapi_v2.ParametersItem{}, item.Parameters...), o.Parameters...) {
+				if body := p.GetParameter().GetBodyParameter(); body != nil {
+					op.Body, op.BodyRequired = shapeV2(body.Schema), body.Required.GetValue()
+				} else if nonBody := p.GetParameter().GetNonBodyParameter(); nonBody != nil {
+					if parameter := parameterV2(nonBody); parameter != nil {
+						op.Parameters = append(op.Parameters, parameter)
+					}
+				}
+			}
+			if o.Responses != nil {
+				for _, response := range o.Responses.ResponseCode {
+					if strings.HasPrefix(response.Name, "2") {
+						if schema := response.Value.GetResponse().GetSchema().GetSchema(); schema != nil {
+							op.Response = shapeV2(schema)
+						}
+						break
+					}
+				}
+			}
+			a.Operations = append(a.Operations, op)
+		}
+	}
+	return a
+}
+
+func parameterV2(p *openapi_v2.NonBodyParameter) *parameter {
+	scalar := func(typeName, format string) *shape { return &shape{Type: typeName, Format: format} }
+	switch {
+	case p.GetPathParameterSubSchema() != nil:
+		q := p.GetPathParameterSubSchema()
+		return &parameter{Name: q.Name, In: "path", Description: q.Description, Required: true, Shape: scalar(q.Type, q.Format)}
+	case p.GetQueryParameterSubSchema() != nil:
+		q := p.GetQueryParameterSubSchema()
+		s := scalar(q.Type, q.Format)
+		if q.Type == "array" && q.Items != nil {
+			s.Items = scalar(q.Items.Type, q.Items.Format)
+		}
+		return &parameter{Name: q.Name, In: "query", Description: q.Description, Required: q.Required.GetValue(), Shape: s}
+	case p.GetHeaderParameterSubSchema() != nil:
+		q := p.GetHeaderParameterSubSchema()
+		return &parameter{Name: q.Name, In: "header", Description: q.Description, Required: q.Required.GetValue(), Shape: scalar(q.Type, q.Format)}
+	}
+	return nil
+}
+
+func shapeV2(schema *openapi_v2.Schema) *shape {
+	if schema == nil {
+		return &shape{}
+	}
+	if strings.HasPrefix(schema.XRef, "#/definitions/") {
+		return &shape{Ref: strings.TrimPrefix(schema.XRef, "#/definitions/")}
+	}
+	s := &shape{Type: schema.TypeName(), Format: schema.Format, Description: schema.Description, Required: schema.Required, Nullable: schema.IsNullable()}
+	for _, value := range schema.Enum {
+		if node := value.ToRawInfo(); node.Tag == "!!str" {
+			s.Enum = append(s.Enum, node.Value)
+		}
+	}
+	if schema.Properties != nil {
+		for _, pair := range schema.Properties.AdditionalProperties {
+			s.Properties = append(s.Properties, &property{Name: pair.Name, Shape: shapeV2(pair.Value)})
+		}
+	}
+	if schema.Items != nil && len(schema.Items.Schema) > 0 {
+		s.Items = shapeV2(schema.Items.Schema[0])
+	}
+	for _, member := range schema.AllOf {
+		s.AllOf = append(s.AllOf, shapeV2(member))
+	}
+	return s
+}
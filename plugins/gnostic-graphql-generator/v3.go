@@ -0,0 +1,153 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	openapi_v3 "github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// Returns the schemas and operations of an OpenAPI v3 document.
+func apiV3(document *openapi_v3.Document) *api {
+	a := newAPI()
+	if document.Info != nil {
+		a.Title, a.Description = document.Info.Title, document.Info.Description
+	}
+	if document.Components != nil && document.Components.Schemas != nil {
+		for _, pair := range document.Components.Schemas.AdditionalProperties {
+			a.addSchema(pair.Name, shapeForSchemaV3(pair.Value))
+		}
+	}
+	if document.Paths == nil {
+		return a
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		operations := []*openapi_v3.Operation{item.Get, item.Put, item.Post, item.Delete, item.Patch}
+		for i, o := range operations {
+			if o == nil {
+				continue
+			}
+			op := &operation{
+				ID:          o.OperationId,
+				Method:      []string{"GET", "PUT", "POST", "DELETE", "PATCH"}[i],
+				Path:        pair.Name,
+				Description: o.Description,
+			}
+			if op.Description == "" {
+				op.Description = o.Summary
+			}
+			for _, p := range append(append([]*openapi_v3.ParameterOrReference{}, item.Parameters...), o.Parameters...) {
+				if p := parameterV3(document, p); p != nil && p.In != "cookie" {
+					op.Parameters = append(op.Parameters, &parameter{
+						Name:        p.Name,
+						In:          p.In,
+						Description: p.Description,
+						Required:    p.Required.GetValue() || p.In == "path",
+						Shape:       shapeV3(p.Schema),
+					})
+				}
+			}
+			if body := o.RequestBody.GetRequestBody(); body != nil {
+				if schema := jsonSchemaV3(body.Content); schema != nil {
+					op.Body, op.BodyRequired = shapeV3(schema), body.Required.GetValue()
+				}
+			}
+			if o.Responses != nil {
+				for _, response := range o.Responses.ResponseCode {
+					if strings.HasPrefix(response.Name, "2") {
+						if schema := jsonSchemaV3(response.Value.GetResponse().GetContent()); schema != nil {
+							op.Response = shapeV3(schema)
+						}
+						break
+					}
+				}
+			}
+			a.Operations = append(a.Operations, op)
+		}
+	}
+	return a
+}
+
+// Returns the parameter that a parameter refers to, or nil if it can't be found.
+func parameterV3(document *openapi_v3.Document, item *openapi_v3.ParameterOrReference) *openapi_v3.Parameter {
+	if p := item.GetParameter(); p != nil {
+		return p
+	}
+	if reference := item.GetReference(); reference != nil && document.Components != nil && document.Components.Parameters != nil {
+		for _, pair := range document.Components.Parameters.AdditionalProperties {
+			if reference.XRef == "#/components/parameters/"+pair.Name {
+				return pair.Value
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the schema of the JSON content of a request or response.
+func jsonSchemaV3(content *openapi_v3.Content) *openapi_v3.SchemaOrReference {
+	if content == nil {
+		return nil
+	}
+	for _, pair := range content.MediaType {
+		if pair.Name == "application/json" || strings.HasSuffix(pair.Name, "+json") {
+			return pair.Value.Schema
+		}
+	}
+	return nil
+}
+
+func shapeV3(schema *openapi_v3.SchemaOrReference) *shape {
+	if reference := schema.GetReference(); reference != nil {
+		if strings.HasPrefix(reference.XRef, "#/components/schemas/") {
+			return &shape{Ref: strings.TrimPrefix(reference.XRef, "#/components/schemas/")}
+		}
+		return &shape{}
+	}
+	return shapeForSchemaV3(schema.GetSchema())
+}
+
+func shapeForSchemaV3(schema *openapi_v3.Schema) *shape {
+	if schema == nil {
+		return &shape{}
+	}
+	if len(schema.AllOf) == 1 && schema.Type == "" && schema.Properties == nil {
+		// references in properties are wrapped in allOfs
+		s := shapeV3(schema.AllOf[0])
+		if s.Ref == "" && s.Description == "" {
+			s.Description = schema.Description
+		}
+		return s
+	}
+	s := &shape{Type: schema.Type, Format: schema.Format, Description: schema.Description, Required: schema.Required, Nullable: schema.Nullable.GetValue()}
+	for _, value := range schema.Enum {
+		if node := value.ToRawInfo(); node.Tag == "!!str" {
+			s.Enum = append(s.Enum, node.Value)
+		}
+	}
+	if schema.Properties != nil {
+		for _, pair := range schema.Properties.AdditionalProperties {
+			s.Properties = append(s.Properties, &property{Name: pair.Name, Shape: shapeForSchemaV3(pair.Value)})
+		}
+	}
+	if schema.Items != nil && len(schema.Items.SchemaOrReference) > 0 {
+		s.Items = shapeV3(schema.Items.SchemaOrReference[0])
+	}
+	for _, member := range schema.AllOf {
+		s.AllOf = append(s.AllOf, shapeV3(member))
+	}
+	return s
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/googleapis/gnostic/printer"
+)
+
+var schemaTypes = map[string]string{
+	"string":  "schema.TypeString",
+	"integer": "schema.TypeInt",
+	"number":  "schema.TypeFloat",
+	"boolean": "schema.TypeBool",
+	"list":    "schema.TypeList",
+	"object":  "schema.TypeList",
+	"map":     "schema.TypeMap",
+}
+
+// Generates the Go source of a resource: a function that returns its
+// schema for the Terraform plugin SDK and stubs of its CRUD functions.
+func generateResource(packageName string, r *resource) ([]byte, error) {
+	code := &printer.Code{}
+	name := camelCase(r.Name)
+	code.Print("// Code generated by gnostic-terraform-generator. The CRUD functions are")
+	code.Print("// stubs to be completed with calls to the API.")
+	code.Print("")
+	code.Print("package %s", packageName)
+	code.Print("")
+	code.Print("import (")
+	code.Print("\"context\"")
+	code.Print("")
+	code.Print("\"github.com/hashicorp/terraform-plugin-sdk/v2/diag\"")
+	code.Print("\"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema\"")
+	code.Print(")")
+	code.Print("")
+	code.Print("func resource%s() *schema.Resource {", name)
+	code.Print("return &schema.Resource{")
+	if r.Description != "" {
+		code.Print("Description: %q,", r.Description)
+	}
+	functions := []struct {
+		field    string
+		suffix   string
+		endpoint *endpoint
+	}{
+		{"CreateContext", "Create", r.Create},
+		{"ReadContext", "Read", r.Read},
+		{"UpdateContext", "Update", r.Update},
+		{"DeleteContext", "Delete", r.Delete},
+	}
+	for _, f := range functions {
+		if f.endpoint != nil {
+			code.Print("%s: resource%s%s,", f.field, name, f.suffix)
+		}
+	}
+	code.Print("Schema: map[string]*schema.Schema{")
+	generateAttributes(code, r.Attributes)
+	code.Print("},")
+	code.Print("}")
+	code.Print("}")
+	for _, f := range functions {
+		if f.endpoint == nil {
+			continue
+		}
+		code.Print("")
+		code.Print("// resource%s%s calls %s %s.", name, f.suffix, f.endpoint.Method, f.endpoint.Path)
+		code.Print("func resource%s%s(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {", name, f.suffix)
+		code.Print("return diag.Errorf(\"not implemented: %s %s\")", f.endpoint.Method, f.endpoint.Path)
+		code.Print("}")
+	}
+	return format.Source([]byte(code.String()))
+}
+
+// Generates the schemas of a list of attributes. The attribute named "id"
+// is omitted because Terraform tracks the ids of resources itself.
+func generateAttributes(code *printer.Code, attributes []*attribute) {
+	for _, a := range attributes {
+		if a.Name == "id" {
+			continue
+		}
+		if snakeCase(a.Name) != a.Name {
+			code.Print("// %s", a.Name)
+		}
+		code.Print("%q: {", snakeCase(a.Name))
+		generateAttribute(code, a)
+		code.Print("},")
+	}
+}
+
+func generateAttribute(code *printer.Code, a *attribute) {
+	code.Print("Type: %s,", schemaTypes[a.Type])
+	switch {
+	case a.Computed:
+		code.Print("Computed: true,")
+	case a.Required:
+		code.Print("Required: true,")
+	default:
+		code.Print("Optional: true,")
+	}
+	if a.ForceNew {
+		code.Print("ForceNew: true,")
+	}
+	if a.Description != "" {
+		code.Print("Description: %q,", strings.TrimSpace(a.Description))
+	}
+	switch a.Type {
+	case "object":
+		// nested objects are lists with one element
+		code.Print("MaxItems: 1,")
+		generateElem(code, a, a.Computed)
+	case "list", "map":
+		generateElem(code, a.Elem, a.Computed)
+	}
+}
+
+// Generates the elements of a list or map: a schema for scalars and a
+// resource for objects. The fields of computed objects are also computed.
+func generateElem(code *printer.Code, elem *attribute, computed bool) {
+	if elem.Type != "object" {
+		code.Print("Elem: &schema.Schema{Type: %s},", schemaTypes[elem.Type])
+		return
+	}
+	code.Print("Elem: &schema.Resource{")
+	code.Print("Schema: map[string]*schema.Schema{")
+	for _, a := range elem.Attributes {
+		a.ForceNew = false
+		a.Computed = a.Computed || computed
+	}
+	generateAttributes(code, elem.Attributes)
+	code.Print("},")
+	code.Print("},")
+}
+
+// Generates a function that returns the resources of a provider, named
+// with the provider's prefix, for the ResourcesMap of the provider.
+func generateResourcesMap(packageName, provider string, resources []*resource) ([]byte, error) {
+	code := &printer.Code{}
+	code.Print("// Code generated by gnostic-terraform-generator.")
+	code.Print("")
+	code.Print("package %s", packageName)
+	code.Print("")
+	code.Print("import \"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema\"")
+	code.Print("")
+	code.Print("// resourcesMap returns the resources of the %s provider.", provider)
+	code.Print("func resourcesMap() map[string]*schema.Resource {")
+	code.Print("return map[string]*schema.Resource{")
+	for _, r := range resources {
+		code.Print("%q: resource%s(),", fmt.Sprintf("%s_%s", provider, r.Name), camelCase(r.Name))
+	}
+	code.Print("}")
+	code.Print("}")
+	return format.Source([]byte(code.String()))
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gnostic-terraform-generator is a plugin that generates stubs of Terraform
+// provider resources for the CRUD-style resources of an API.
+//
+// A resource is an item path ending with a parameter, such as /pets/{id},
+// that can be read with GET and created with a POST to its collection or a
+// PUT to the item. Its schema is made of the fields of the created and read
+// messages: fields that are sent are arguments, and fields that are only
+// returned or are read-only are computed.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+	openapi_v3 "github.com/googleapis/gnostic/OpenAPIv3"
+	plugins "github.com/googleapis/gnostic/plugins"
+)
+
+// Record an error, then serialize and return a response.
+func sendAndExitIfError(err error, response *plugins.Response) {
+	if err != nil {
+		response.Errors = append(response.Errors, err.Error())
+		sendAndExit(response)
+	}
+}
+
+// Serialize and return a response.
+func sendAndExit(response *plugins.Response) {
+	responseBytes, _ := proto.Marshal(response)
+	os.Stdout.Write(responseBytes)
+	os.Exit(0)
+}
+
+func main() {
+	response := &plugins.Response{}
+
+	data, err := ioutil.ReadAll(os.Stdin)
+	sendAndExitIfError(err, response)
+	if len(data) == 0 {
+		sendAndExitIfError(errors.New("No input data.\n"), response)
+	}
+	request := &plugins.Request{}
+	err = proto.Unmarshal(data, request)
+	sendAndExitIfError(err, response)
+
+	// The package and provider names default to the name of the output directory.
+	packageName := path.Base(request.OutputPath)
+	provider := ""
+	for _, parameter := range request.Parameters {
+		switch parameter.Name {
+		case "package":
+			packageName = parameter.Value
+		case "provider":
+			provider = parameter.Value
+		}
+	}
+	if provider == "" {
+		provider = packageName
+	}
+
+	var endpoints []*endpoint
+	switch request.Wrapper.Version {
+	case "v2":
+		document := &openapi_v2.Document{}
+		err = proto.Unmarshal(request.Wrapper.Value, document)
+		sendAndExitIfError(err, response)
+		endpoints = endpointsV2(document)
+	case "v3":
+		document := &openapi_v3.Document{}
+		err = proto.Unmarshal(request.Wrapper.Value, document)
+		sendAndExitIfError(err, response)
+		endpoints = endpointsV3(document)
+	default:
+		err = errors.New(fmt.Sprintf("Unsupported OpenAPI version %s", request.Wrapper.Version))
+		sendAndExitIfError(err, response)
+	}
+
+	resources := findResources(endpoints)
+	if len(resources) == 0 {
+		sendAndExitIfError(errors.New("No CRUD-style resources were found."), response)
+	}
+	for _, r := range resources {
+		file := &plugins.File{Name: "resource_" + r.Name + ".go"}
+		file.Data, err = generateResource(packageName, r)
+		sendAndExitIfError(err, response)
+		response.Files = append(response.Files, file)
+	}
+	file := &plugins.File{Name: "resources.go"}
+	file.Data, err = generateResourcesMap(packageName, provider, resources)
+	sendAndExitIfError(err, response)
+	response.Files = append(response.Files, file)
+
+	sendAndExit(response)
+}
@@ -0,0 +1,200 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// An attribute is a field of a resource.
+type attribute struct {
+	Name        string // the name of the field in API messages
+	Type        string // "string", "integer", "number", "boolean", "list", "object", or "map"
+	Description string
+	Required    bool
+	Computed    bool
+	ForceNew    bool
+	Elem        *attribute   // the elements of lists and maps
+	Attributes  []*attribute // the fields of objects
+}
+
+// A message is a schema of a request or response body.
+type message struct {
+	Name       string // the name of the schema, if it is a named schema
+	Attributes []*attribute
+}
+
+// An endpoint is an operation that can manage resources.
+type endpoint struct {
+	Method      string
+	Path        string
+	Description string
+	Request     *message
+	Response    *message
+}
+
+// A resource is a kind of object that is managed with a set of
+// endpoints: a collection path that creates resources and an item
+// path, ending with a parameter, that reads, updates, and deletes them.
+type resource struct {
+	Name        string
+	Description string
+	Create      *endpoint
+	Read        *endpoint
+	Update      *endpoint
+	Delete      *endpoint
+	Attributes  []*attribute
+}
+
+// Returns the path of the collection that contains an item path, or ""
+// if a path doesn't end with a parameter.
+func collectionPath(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i < 0 || !strings.HasPrefix(path[i+1:], "{") || !strings.HasSuffix(path, "}") {
+		return ""
+	}
+	return path[:i]
+}
+
+// Returns the resources that are managed by a list of endpoints. Each item
+// path that can be read is a resource if it can also be created, either by
+// a POST to its collection or by a PUT to the item.
+func findResources(endpoints []*endpoint) []*resource {
+	byPath := make(map[string]map[string]*endpoint, 0)
+	paths := make([]string, 0)
+	for _, e := range endpoints {
+		if byPath[e.Path] == nil {
+			byPath[e.Path] = make(map[string]*endpoint, 0)
+			paths = append(paths, e.Path)
+		}
+		byPath[e.Path][e.Method] = e
+	}
+	resources := make([]*resource, 0)
+	for _, path := range paths {
+		collection := collectionPath(path)
+		item := byPath[path]
+		if collection == "" || item["GET"] == nil {
+			continue
+		}
+		r := &resource{Read: item["GET"], Delete: item["DELETE"]}
+		r.Update = item["PATCH"]
+		if item["PUT"] != nil {
+			r.Update = item["PUT"]
+		}
+		if create := byPath[collection]["POST"]; create != nil {
+			r.Create = create
+		} else if item["PUT"] != nil {
+			r.Create, r.Update = item["PUT"], nil
+		} else {
+			continue
+		}
+		r.Name = resourceName(r, collection)
+		r.Description = r.Read.Description
+		r.Attributes = resourceAttributes(r)
+		if r.Update == nil {
+			// resources that can't be updated are replaced when their arguments change
+			markForceNew(r.Attributes)
+		}
+		resources = append(resources, r)
+	}
+	sort.SliceStable(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	return resources
+}
+
+// Returns the name of a resource in snake case. Resources are named for the
+// schemas that describe them, or for the last segment of their collection path.
+func resourceName(r *resource, collection string) string {
+	if r.Read.Response != nil && r.Read.Response.Name != "" {
+		return snakeCase(r.Read.Response.Name)
+	}
+	segment := collection[strings.LastIndex(collection, "/")+1:]
+	return snakeCase(strings.TrimSuffix(segment, "s"))
+}
+
+// Returns the attributes of a resource. Attributes that are sent when
+// resources are created are arguments; attributes that are only read
+// are computed.
+func resourceAttributes(r *resource) []*attribute {
+	var arguments []*attribute
+	if r.Create.Request != nil {
+		arguments = r.Create.Request.Attributes
+	}
+	var results []*attribute
+	if r.Read.Response != nil {
+		results = r.Read.Response.Attributes
+	}
+	return mergeAttributes(arguments, results)
+}
+
+func mergeAttributes(arguments, results []*attribute) []*attribute {
+	merged := make([]*attribute, 0)
+	byName := make(map[string]*attribute, 0)
+	for _, argument := range arguments {
+		a := *argument
+		merged = append(merged, &a)
+		byName[a.Name] = &a
+	}
+	for _, result := range results {
+		if a, ok := byName[result.Name]; ok {
+			if a.Description == "" {
+				a.Description = result.Description
+			}
+			continue
+		}
+		a := *result
+		a.Required, a.Computed = false, true
+		merged = append(merged, &a)
+	}
+	return merged
+}
+
+func markForceNew(attributes []*attribute) {
+	for _, a := range attributes {
+		a.ForceNew = !a.Computed
+	}
+}
+
+// Returns a name in snake case, the style of Terraform names.
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			if i > 0 && runes[i-1] != '_' && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Returns a name in camel case, for the Go functions of resources.
+func camelCase(name string) string {
+	parts := strings.Split(snakeCase(name), "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/lib"
+)
+
+const description = `
+openapi: 3.0.0
+info:
+  title: Widgets
+  version: 1.0.0
+paths:
+  /widgets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [displayName]
+              properties:
+                displayName:
+                  type: string
+                labels:
+                  type: object
+      responses:
+        201:
+          description: Created
+  /widgets/{widget}:
+    get:
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Widget'
+    patch:
+      responses:
+        200:
+          description: OK
+components:
+  schemas:
+    Widget:
+      type: object
+      properties:
+        id:
+          type: string
+        displayName:
+          type: string
+        createTime:
+          type: string
+        parts:
+          type: array
+          items:
+            type: object
+            properties:
+              name:
+                type: string
+`
+
+func TestFindResources(t *testing.T) {
+	document, err := gnostic.ReadDocumentFromBytes([]byte(description))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	resources := findResources(endpointsV3(document.V3))
+	if len(resources) != 1 || resources[0].Name != "widget" || resources[0].Update == nil {
+		t.Fatalf("unexpected resources: %+v", resources)
+	}
+	code, err := generateResource("widgets", resources[0])
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, expected := range []string{
+		"\"display_name\": {\n\t\t\t\tType:     schema.TypeString,\n\t\t\t\tRequired: true,",
+		"\"labels\": {\n\t\t\t\tType:     schema.TypeMap,\n\t\t\t\tOptional: true,",
+		"\"create_time\": {\n\t\t\t\tType:     schema.TypeString,\n\t\t\t\tComputed: true,",
+		"UpdateContext: resourceWidgetUpdate,",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("generated code doesn't contain %q:\n%s", expected, string(code))
+		}
+	}
+	if strings.Contains(string(code), "\"id\"") {
+		t.Errorf("generated code contains the id attribute:\n%s", string(code))
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"displayName": "display_name",
+		"HTTPServer":  "http_server",
+		"pet-store":   "pet_store",
+	} {
+		if actual := snakeCase(name); actual != expected {
+			t.Errorf("snakeCase(%q) = %q, expected %q", name, actual, expected)
+		}
+	}
+}
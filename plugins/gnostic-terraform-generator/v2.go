@@ -0,0 +1,154 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+)
+
+// Returns the endpoints of an OpenAPI v2 document.
+func endpointsV2(document *openapi_v2.Document) []*endpoint {
+	endpoints := make([]*endpoint, 0)
+	if document.Paths == nil {
+		return endpoints
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		operations := []*openapi_v2.Operation{item.Get, item.Put, item.Post, item.Delete, item.Patch}
+		for i, operation := range operations {
+			if operation == nil {
+				continue
+			}
+			e := &endpoint{
+				Method:      []string{"GET", "PUT", "POST", "DELETE", "PATCH"}[i],
+				Path:        strings.TrimSuffix(document.BasePath, "/") + pair.Name,
+				Description: operation.Description,
+			}
+			if e.Description == "" {
+				e.Description = operation.Summary
+			}
+			for _, p := range operation.Parameters {
+				if body := p.GetParameter().GetBodyParameter(); body != nil {
+					e.Request = messageV2(document, body.Schema)
+				}
+			}
+			if operation.Responses != nil {
+				for _, response := range operation.Responses.ResponseCode {
+					if response.Name != "200" && response.Name != "201" {
+						continue
+					}
+					if schema := response.Value.GetResponse().GetSchema().GetSchema(); schema != nil {
+						e.Response = messageV2(document, schema)
+						break
+					}
+				}
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints
+}
+
+// Returns the schema that a schema refers to and its name, or the schema
+// itself if it isn't a reference.
+func resolveV2(document *openapi_v2.Document, schema *openapi_v2.Schema) (*openapi_v2.Schema, string) {
+	if schema.XRef == "" || document.Definitions == nil {
+		return schema, ""
+	}
+	for _, pair := range document.Definitions.AdditionalProperties {
+		if schema.XRef == "#/definitions/"+pair.Name {
+			return pair.Value, pair.Name
+		}
+	}
+	return schema, ""
+}
+
+func messageV2(document *openapi_v2.Document, schema *openapi_v2.Schema) *message {
+	if schema == nil {
+		return nil
+	}
+	schema, name := resolveV2(document, schema)
+	a := attributeV2(document, "", schema, nil)
+	if a.Type != "object" {
+		return nil
+	}
+	return &message{Name: name, Attributes: a.Attributes}
+}
+
+// Returns the attribute described by a schema. References that are being
+// expanded are listed in seen; recursive references are described as maps
+// of strings.
+func attributeV2(document *openapi_v2.Document, name string, schema *openapi_v2.Schema, seen []string) *attribute {
+	if schema.XRef != "" {
+		for _, ref := range seen {
+			if ref == schema.XRef {
+				return &attribute{Name: name, Type: "map", Elem: &attribute{Type: "string"}}
+			}
+		}
+		seen = append(seen, schema.XRef)
+		resolved, _ := resolveV2(document, schema)
+		a := attributeV2(document, name, resolved, seen)
+		if a.Description == "" {
+			a.Description = schema.Description
+		}
+		return a
+	}
+	a := &attribute{Name: name, Description: schema.Description, Computed: schema.ReadOnly.GetValue()}
+	if len(schema.AllOf) > 0 {
+		a.Type = "object"
+		for _, member := range schema.AllOf {
+			if m := attributeV2(document, "", member, seen); m.Type == "object" {
+				a.Attributes = append(a.Attributes, m.Attributes...)
+			}
+		}
+		if schema.Properties == nil {
+			return a
+		}
+	}
+	typeName := schema.TypeName()
+	switch {
+	case typeName == "array" || schema.Items != nil:
+		a.Type, a.Elem = "list", &attribute{Type: "string"}
+		if schema.Items != nil && len(schema.Items.Schema) > 0 {
+			a.Elem = attributeV2(document, "", schema.Items.Schema[0], seen)
+		}
+	case schema.Properties != nil:
+		a.Type = "object"
+		for _, property := range schema.Properties.AdditionalProperties {
+			p := attributeV2(document, property.Name, property.Value, seen)
+			p.Required = contains(schema.Required, property.Name)
+			a.Attributes = append(a.Attributes, p)
+		}
+	case typeName == "object" || typeName == "":
+		a.Type, a.Elem = "map", &attribute{Type: "string"}
+		if additional := schema.AdditionalProperties.GetSchema(); additional != nil {
+			a.Elem = attributeV2(document, "", additional, seen)
+		}
+	default:
+		a.Type = typeName
+	}
+	return a
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
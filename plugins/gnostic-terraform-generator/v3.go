@@ -0,0 +1,160 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	openapi_v3 "github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// Returns the endpoints of an OpenAPI v3 document.
+func endpointsV3(document *openapi_v3.Document) []*endpoint {
+	endpoints := make([]*endpoint, 0)
+	if document.Paths == nil {
+		return endpoints
+	}
+	for _, pair := range document.Paths.Path {
+		item := pair.Value
+		operations := []*openapi_v3.Operation{item.Get, item.Put, item.Post, item.Delete, item.Patch}
+		for i, operation := range operations {
+			if operation == nil {
+				continue
+			}
+			e := &endpoint{
+				Method:      []string{"GET", "PUT", "POST", "DELETE", "PATCH"}[i],
+				Path:        pair.Name,
+				Description: operation.Description,
+			}
+			if e.Description == "" {
+				e.Description = operation.Summary
+			}
+			if body := operation.RequestBody.GetRequestBody(); body != nil {
+				e.Request = messageV3(document, jsonSchemaV3(body.Content))
+			}
+			if operation.Responses != nil {
+				for _, response := range operation.Responses.ResponseCode {
+					if response.Name != "200" && response.Name != "201" {
+						continue
+					}
+					if schema := jsonSchemaV3(response.Value.GetResponse().GetContent()); schema != nil {
+						e.Response = messageV3(document, schema)
+						break
+					}
+				}
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints
+}
+
+// Returns the schema of the JSON content of a request or response.
+func jsonSchemaV3(content *openapi_v3.Content) *openapi_v3.SchemaOrReference {
+	if content == nil {
+		return nil
+	}
+	for _, pair := range content.MediaType {
+		if pair.Name == "application/json" || strings.HasSuffix(pair.Name, "+json") {
+			return pair.Value.Schema
+		}
+	}
+	return nil
+}
+
+// Returns the schema that a schema refers to and its name.
+func resolveV3(document *openapi_v3.Document, schema *openapi_v3.SchemaOrReference) (*openapi_v3.Schema, string) {
+	if s := schema.GetSchema(); s != nil {
+		return s, ""
+	}
+	reference := schema.GetReference()
+	if reference == nil || document.Components == nil || document.Components.Schemas == nil {
+		return nil, ""
+	}
+	for _, pair := range document.Components.Schemas.AdditionalProperties {
+		if reference.XRef == "#/components/schemas/"+pair.Name {
+			return pair.Value, pair.Name
+		}
+	}
+	return nil, ""
+}
+
+func messageV3(document *openapi_v3.Document, schema *openapi_v3.SchemaOrReference) *message {
+	if schema == nil {
+		return nil
+	}
+	_, name := resolveV3(document, schema)
+	a := attributeV3(document, "", schema, nil)
+	if a.Type != "object" {
+		return nil
+	}
+	return &message{Name: name, Attributes: a.Attributes}
+}
+
+// Returns the attribute described by a schema. References that are being
+// expanded are listed in seen; recursive references are described as maps
+// of strings.
+func attributeV3(document *openapi_v3.Document, name string, schema *openapi_v3.SchemaOrReference, seen []string) *attribute {
+	if reference := schema.GetReference(); reference != nil {
+		for _, ref := range seen {
+			if ref == reference.XRef {
+				return &attribute{Name: name, Type: "map", Elem: &attribute{Type: "string"}}
+			}
+		}
+		seen = append(seen, reference.XRef)
+	}
+	resolved, _ := resolveV3(document, schema)
+	if resolved == nil {
+		return &attribute{Name: name, Type: "map", Elem: &attribute{Type: "string"}}
+	}
+	return attributeForSchemaV3(document, name, resolved, seen)
+}
+
+func attributeForSchemaV3(document *openapi_v3.Document, name string, schema *openapi_v3.Schema, seen []string) *attribute {
+	a := &attribute{Name: name, Description: schema.Description, Computed: schema.ReadOnly.GetValue()}
+	if len(schema.AllOf) > 0 {
+		a.Type = "object"
+		for _, member := range schema.AllOf {
+			if m := attributeV3(document, "", member, seen); m.Type == "object" {
+				a.Attributes = append(a.Attributes, m.Attributes...)
+				if a.Description == "" {
+					a.Description = m.Description
+				}
+			}
+		}
+		if schema.Properties == nil {
+			return a
+		}
+	}
+	switch {
+	case schema.Type == "array" || schema.Items != nil:
+		a.Type, a.Elem = "list", &attribute{Type: "string"}
+		if schema.Items != nil && len(schema.Items.SchemaOrReference) > 0 {
+			a.Elem = attributeV3(document, "", schema.Items.SchemaOrReference[0], seen)
+		}
+	case schema.Properties != nil:
+		a.Type = "object"
+		for _, property := range schema.Properties.AdditionalProperties {
+			p := attributeForSchemaV3(document, property.Name, property.Value, seen)
+			p.Required = contains(schema.Required, property.Name)
+			a.Attributes = append(a.Attributes, p)
+		}
+	case schema.Type == "object" || schema.Type == "":
+		a.Type, a.Elem = "map", &attribute{Type: "string"}
+	default:
+		a.Type = schema.Type
+	}
+	return a
+}
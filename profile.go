@@ -0,0 +1,69 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// Start writing a CPU profile if one was requested.
+func (g *Gnostic) startProfiling() {
+	if g.cpuProfilePath == "" {
+		return
+	}
+	f, err := os.Create(g.cpuProfilePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to create CPU profile: %s\n", err.Error())
+		os.Exit(-1)
+	}
+	if err = pprof.StartCPUProfile(f); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to start CPU profile: %s\n", err.Error())
+		os.Exit(-1)
+	}
+	g.cpuProfile = f
+}
+
+// Finish the CPU profile and write a heap profile if they were requested.
+func (g *Gnostic) stopProfiling() {
+	if g.cpuProfile != nil {
+		pprof.StopCPUProfile()
+		g.cpuProfile.Close()
+		g.cpuProfile = nil
+	}
+	if g.memProfilePath != "" {
+		f, err := os.Create(g.memProfilePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to create memory profile: %s\n", err.Error())
+			return
+		}
+		defer f.Close()
+		runtime.GC() // report the memory that is still in use
+		if err = pprof.WriteHeapProfile(f); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to write memory profile: %s\n", err.Error())
+		}
+		g.memProfilePath = ""
+	}
+}
+
+// Exit with the specified code, finishing any profiles first.
+// Profiles are written even when compilation fails, since failures
+// on large descriptions can be as slow as successes.
+func (g *Gnostic) exit(code int) {
+	g.finish()
+	os.Exit(code)
+}
@@ -0,0 +1,191 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/googleapis/gnostic/compiler"
+)
+
+const (
+	// Status lines are shown on terminals when compilations take longer than this.
+	progressDelay = time.Second
+	// The intervals between the updates of status lines and of progress events.
+	progressLineInterval  = 100 * time.Millisecond
+	progressEventInterval = time.Second
+)
+
+// A progressReporter shows the progress of a compilation on a terminal with
+// a status line that is updated in place, or writes it as JSON events, one
+// on each line. Messages that are logged during the compilation are written
+// above the status line.
+type progressReporter struct {
+	progress *compiler.Progress
+	out      io.Writer
+	events   bool // write JSON events instead of a status line
+	logger   *log.Logger
+	start    time.Time
+	mutex    sync.Mutex
+	line     string    // the status line that is on the terminal, if any
+	hold     time.Time // status lines are not shown before this time
+	stopped  chan struct{}
+	done     sync.WaitGroup
+}
+
+// The JSON form of a progress event.
+type progressEvent struct {
+	compiler.ProgressEvent
+	Elapsed float64 `json:"elapsed"` // seconds since the compilation started
+}
+
+func newProgressReporter(progress *compiler.Progress, out io.Writer, events bool) *progressReporter {
+	r := &progressReporter{
+		progress: progress,
+		out:      out,
+		events:   events,
+		logger:   log.New(out, "", log.LstdFlags),
+		start:    time.Now(),
+		stopped:  make(chan struct{}),
+	}
+	r.hold = r.start.Add(progressDelay)
+	interval := progressLineInterval
+	if events {
+		interval = progressEventInterval
+	}
+	r.done.Add(1)
+	go func() {
+		defer r.done.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.update()
+			case <-r.stopped:
+				return
+			}
+		}
+	}()
+	return r
+}
+
+// Sets the stage of the compilation. Events are written when stages change.
+func (r *progressReporter) stage(name string) {
+	if r == nil {
+		return
+	}
+	r.progress.SetStage(name)
+	if r.events {
+		r.update()
+	}
+}
+
+// Writes an event or shows the status line.
+func (r *progressReporter) update() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	event := r.progress.Event()
+	if r.events {
+		r.writeEvent(event)
+	} else if line := statusLine(event); line != r.line && time.Now().After(r.hold) {
+		fmt.Fprintf(r.out, "\r\033[K%s", line)
+		r.line = line
+	}
+}
+
+func (r *progressReporter) writeEvent(event compiler.ProgressEvent) {
+	bytes, _ := json.Marshal(&progressEvent{ProgressEvent: event, Elapsed: time.Since(r.start).Seconds()})
+	r.out.Write(append(bytes, '\n'))
+}
+
+// Removes the status line so that other output can be written to the
+// terminal, and doesn't show it again until the delay has passed.
+func (r *progressReporter) clear() {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.clearLine()
+	r.hold = time.Now().Add(progressDelay)
+}
+
+func (r *progressReporter) clearLine() {
+	if r.line != "" {
+		fmt.Fprint(r.out, "\r\033[K")
+		r.line = ""
+	}
+}
+
+// Printf logs a message above the status line.
+func (r *progressReporter) Printf(format string, args ...interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.clearLine()
+	r.logger.Printf(format, args...)
+}
+
+// Stops reporting progress, removing the status line or writing a final event.
+// Reporters that have stopped are not changed.
+func (r *progressReporter) stop() {
+	if r == nil {
+		return
+	}
+	select {
+	case <-r.stopped:
+		return
+	default:
+	}
+	close(r.stopped)
+	r.done.Wait()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.clearLine()
+	if r.events {
+		r.progress.SetStage("done")
+		r.writeEvent(r.progress.Event())
+	}
+}
+
+// Returns a status line that describes a progress event.
+func statusLine(event compiler.ProgressEvent) string {
+	line := fmt.Sprintf("%s: %d files, %s", event.Stage, event.Files, byteCount(event.Bytes))
+	if event.File != "" {
+		file := event.File
+		if len(file) > 40 {
+			file = "..." + file[len(file)-37:]
+		}
+		line += " " + file
+	}
+	return strings.TrimSpace(line)
+}
+
+// Returns a size in bytes, KB, or MB.
+func byteCount(bytes int64) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	}
+	return fmt.Sprintf("%d bytes", bytes)
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+	gnostic "github.com/googleapis/gnostic/lib"
+	"github.com/googleapis/gnostic/query"
+	"gopkg.in/yaml.v3"
+)
+
+const queryUsage = `
+Usage: gnostic query OPENAPI_SOURCE EXPRESSION [OPTIONS]
+  Compile an OpenAPI description and write the values selected by a
+  JSONPath expression as a JSON array. For example, this lists the
+  operations that don't describe a 4xx response:
+    gnostic query petstore.yaml "$.paths.*[?(@.responses && !(@.responses.*~ =~ '^4'))]~"
+Options:
+  --locations         Write the JSON pointer of each value with the value.
+  --resolve-refs      Resolve $ref references before querying.
+`
+
+// Returns the compiled model of a description as a YAML node.
+func queryDocument(filename string, resolveReferences bool) (*yaml.Node, error) {
+	var document *gnostic.Document
+	var err error
+	if resolveReferences {
+		document, err = gnostic.ReadDocumentWithOptions(filename, &compiler.CompilerOptions{Cache: compiler.NewCache()})
+	} else {
+		var bytes []byte
+		bytes, err = compiler.ReadBytesForFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		document, err = compileDescription(bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return document.RawInfo()
+}
+
+// Returns the results of a query as a sequence of values, or of maps
+// that contain the location of each value with the value.
+func queryResults(matches []*query.Match, locations bool) *yaml.Node {
+	results := compiler.NewSequenceNode()
+	for _, match := range matches {
+		if !locations {
+			results.Content = append(results.Content, match.Node)
+			continue
+		}
+		result := compiler.NewMappingNode()
+		result.Content = append(result.Content,
+			compiler.NewScalarNodeForString("pointer"), compiler.NewScalarNodeForString(match.Pointer),
+			compiler.NewScalarNodeForString("value"), match.Node)
+		results.Content = append(results.Content, result)
+	}
+	return results
+}
+
+// Run a query with the specified command-line arguments.
+func runQuery(args []string) {
+	flags := flag.NewFlagSet("query", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, queryUsage) }
+	locations := flags.Bool("locations", false, "")
+	resolveReferences := flags.Bool("resolve-refs", false, "")
+	// options can follow the source and expression
+	positional := make([]string, 0)
+	for len(args) > 0 {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) > 0 {
+			positional = append(positional, args[0])
+			args = args[1:]
+		}
+	}
+	if len(positional) != 2 {
+		fmt.Fprint(os.Stderr, queryUsage)
+		os.Exit(-1)
+	}
+	q, err := query.Compile(positional[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(-1)
+	}
+	document, err := queryDocument(positional[0], *resolveReferences)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Errors reading %s\n%+v\n", positional[0], err)
+		os.Exit(-1)
+	}
+	bytes, err := jsonwriter.Marshal(queryResults(q.Evaluate(document), *locations))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(-1)
+	}
+	os.Stdout.Write(bytes)
+}
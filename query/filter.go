@@ -0,0 +1,158 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A filter decides whether a value is selected by a filter selector.
+type filter interface {
+	test(root *Match, current *Match) bool
+}
+
+type notFilter struct {
+	filter filter
+}
+
+func (f *notFilter) test(root *Match, current *Match) bool {
+	return !f.filter.test(root, current)
+}
+
+type andFilter struct {
+	a, b filter
+}
+
+func (f *andFilter) test(root *Match, current *Match) bool {
+	return f.a.test(root, current) && f.b.test(root, current)
+}
+
+type orFilter struct {
+	a, b filter
+}
+
+func (f *orFilter) test(root *Match, current *Match) bool {
+	return f.a.test(root, current) || f.b.test(root, current)
+}
+
+// is true if a path selects any values
+type existsFilter struct {
+	path *path
+}
+
+func (f *existsFilter) test(root *Match, current *Match) bool {
+	return len(f.path.evaluate(root, current)) > 0
+}
+
+// An operand of a comparison is a path or a literal value.
+type operand struct {
+	path    *path
+	literal *yaml.Node
+}
+
+func (o *operand) values(root *Match, current *Match) []*yaml.Node {
+	if o.path == nil {
+		return []*yaml.Node{o.literal}
+	}
+	values := make([]*yaml.Node, 0)
+	for _, match := range o.path.evaluate(root, current) {
+		values = append(values, resolve(match.Node))
+	}
+	return values
+}
+
+// is true if any of the values of its operands satisfy its operator
+type comparisonFilter struct {
+	left, right *operand
+	operator    string
+	pattern     *regexp.Regexp // for =~
+}
+
+func (f *comparisonFilter) test(root *Match, current *Match) bool {
+	for _, left := range f.left.values(root, current) {
+		if f.pattern != nil {
+			if left != nil && left.Kind == yaml.ScalarNode && f.pattern.MatchString(left.Value) {
+				return true
+			}
+			continue
+		}
+		for _, right := range f.right.values(root, current) {
+			if compare(left, right, f.operator) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns the number that a scalar node represents, if it is a number.
+func number(node *yaml.Node) (float64, bool) {
+	if node.Tag != "!!int" && node.Tag != "!!float" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(node.Value, 64)
+	return f, err == nil
+}
+
+// Compares scalar values. Numbers are compared numerically and other
+// scalars are compared as strings; maps and sequences are never equal.
+func compare(a *yaml.Node, b *yaml.Node, operator string) bool {
+	if a == nil || b == nil || a.Kind != yaml.ScalarNode || b.Kind != yaml.ScalarNode {
+		return operator == "!=" && a != b
+	}
+	var order int
+	x, xok := number(a)
+	y, yok := number(b)
+	switch {
+	case xok && yok:
+		if x < y {
+			order = -1
+		} else if x > y {
+			order = 1
+		}
+	case xok != yok:
+		// numbers and strings are different even if they are spelled the same
+		return operator == "!="
+	case a.Tag != b.Tag:
+		// values of different types are never equal
+		return operator == "!="
+	case a.Tag == "!!null":
+		// nulls are equal however they are spelled
+	default:
+		if a.Value < b.Value {
+			order = -1
+		} else if a.Value > b.Value {
+			order = 1
+		}
+	}
+	switch operator {
+	case "==":
+		return order == 0
+	case "!=":
+		return order != 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	case ">=":
+		return order >= 0
+	}
+	return false
+}
@@ -0,0 +1,358 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// parser reads query expressions.
+type parser struct {
+	text   string
+	offset int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return queryError(p.text, p.offset, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) done() bool {
+	return p.offset >= len(p.text)
+}
+
+func (p *parser) rest() string {
+	return p.text[p.offset:]
+}
+
+func (p *parser) peek(prefix string) bool {
+	return strings.HasPrefix(p.rest(), prefix)
+}
+
+func (p *parser) consume(prefix string) bool {
+	if p.peek(prefix) {
+		p.offset += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && unicode.IsSpace(rune(p.text[p.offset])) {
+		p.offset++
+	}
+}
+
+// Returns true if a character can appear in names that follow dots.
+func isNameCharacter(c byte) bool {
+	return c == '_' || c == '-' || c == '$' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (p *parser) parseName() (string, error) {
+	start := p.offset
+	for !p.done() && isNameCharacter(p.text[p.offset]) {
+		p.offset++
+	}
+	if p.offset == start {
+		return "", p.errorf("expected a name")
+	}
+	return p.text[start:p.offset], nil
+}
+
+// Parses the selectors that follow $ or @.
+func (p *parser) parsePath() (*path, error) {
+	result := &path{selectors: make([]selector, 0)}
+	for {
+		var s selector
+		var err error
+		switch {
+		case p.consume(".."):
+			if p.peek("[") {
+				s, err = p.parseBracket()
+			} else {
+				s, err = p.parseDotted()
+			}
+			s = &descendantSelector{selector: s}
+		case p.consume("."):
+			s, err = p.parseDotted()
+		case p.peek("["):
+			s, err = p.parseBracket()
+		case p.consume("~"):
+			s = &keyNameSelector{}
+		default:
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.selectors = append(result.selectors, s)
+	}
+}
+
+// Parses the name or wildcard that follows a dot.
+func (p *parser) parseDotted() (selector, error) {
+	if p.consume("*") {
+		return &wildcardSelector{}, nil
+	}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	return &keysSelector{names: []string{name}}, nil
+}
+
+// Parses a bracketed selector.
+func (p *parser) parseBracket() (selector, error) {
+	p.consume("[")
+	p.skipSpace()
+	var s selector
+	switch {
+	case p.consume("*"):
+		s = &wildcardSelector{}
+	case p.consume("?("):
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			return nil, p.errorf("expected ) to end the filter")
+		}
+		s = &filterSelector{filter: f}
+	case p.peek("'") || p.peek(`"`):
+		names := make([]string, 0)
+		for {
+			name, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, name)
+			p.skipSpace()
+			if !p.consume(",") {
+				break
+			}
+			p.skipSpace()
+		}
+		s = &keysSelector{names: names}
+	default:
+		indices := make([]int, 0)
+		for {
+			index, err := p.parseInteger()
+			if err != nil {
+				return nil, err
+			}
+			indices = append(indices, index)
+			p.skipSpace()
+			if !p.consume(",") {
+				break
+			}
+			p.skipSpace()
+		}
+		s = &indicesSelector{indices: indices}
+	}
+	p.skipSpace()
+	if !p.consume("]") {
+		return nil, p.errorf("expected ]")
+	}
+	return s, nil
+}
+
+func (p *parser) parseInteger() (int, error) {
+	start := p.offset
+	p.consume("-")
+	for !p.done() && p.text[p.offset] >= '0' && p.text[p.offset] <= '9' {
+		p.offset++
+	}
+	index, err := strconv.Atoi(p.text[start:p.offset])
+	if err != nil {
+		p.offset = start
+		return 0, p.errorf("expected an index, a quoted name, *, or a filter")
+	}
+	return index, nil
+}
+
+// Parses a string in single or double quotes. Backslashes escape the
+// following character.
+func (p *parser) parseString() (string, error) {
+	quote := p.text[p.offset]
+	p.offset++
+	var b strings.Builder
+	for !p.done() {
+		c := p.text[p.offset]
+		p.offset++
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\\' && !p.done():
+			b.WriteByte(p.text[p.offset])
+			p.offset++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) parseOr() (filter, error) {
+	f, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("||") {
+			return f, nil
+		}
+		g, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		f = &orFilter{a: f, b: g}
+	}
+}
+
+func (p *parser) parseAnd() (filter, error) {
+	f, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.skipSpace()
+		if !p.consume("&&") {
+			return f, nil
+		}
+		g, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		f = &andFilter{a: f, b: g}
+	}
+}
+
+func (p *parser) parseUnary() (filter, error) {
+	p.skipSpace()
+	if p.peek("!=") {
+		return nil, p.errorf("unexpected !=")
+	}
+	if p.consume("!") {
+		f, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: f}, nil
+	}
+	if p.consume("(") {
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return f, nil
+	}
+	return p.parseComparison()
+}
+
+// comparison operators, with longer operators before their prefixes
+var operators = []string{"==", "!=", "=~", "<=", ">=", "<", ">"}
+
+func (p *parser) parseComparison() (filter, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	operator := ""
+	for _, o := range operators {
+		if p.consume(o) {
+			operator = o
+			break
+		}
+	}
+	if operator == "" {
+		if left.path == nil {
+			return nil, p.errorf("expected a comparison")
+		}
+		return &existsFilter{path: left.path}, nil
+	}
+	p.skipSpace()
+	start := p.offset
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	f := &comparisonFilter{left: left, right: right, operator: operator}
+	if operator == "=~" {
+		if right.literal == nil || right.literal.Tag != "!!str" {
+			p.offset = start
+			return nil, p.errorf("=~ must be followed by a quoted regular expression")
+		}
+		f.pattern, err = regexp.Compile(right.literal.Value)
+		if err != nil {
+			p.offset = start
+			return nil, p.errorf("%s", err.Error())
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseOperand() (*operand, error) {
+	p.skipSpace()
+	switch {
+	case p.peek("@") || p.peek("$"):
+		relative := p.text[p.offset] == '@'
+		p.offset++
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		path.relative = relative
+		return &operand{path: path}, nil
+	case p.peek("'") || p.peek(`"`):
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return &operand{literal: compiler.NewScalarNodeForString(s)}, nil
+	case p.consume("true"):
+		return &operand{literal: compiler.NewScalarNodeForBool(true)}, nil
+	case p.consume("false"):
+		return &operand{literal: compiler.NewScalarNodeForBool(false)}, nil
+	case p.consume("null"):
+		return &operand{literal: compiler.NewNullNode()}, nil
+	}
+	start := p.offset
+	for !p.done() && strings.IndexByte("+-.0123456789eE", p.text[p.offset]) >= 0 {
+		p.offset++
+	}
+	text := p.text[start:p.offset]
+	if _, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return &operand{literal: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: text}}, nil
+	}
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return &operand{literal: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: text}}, nil
+	}
+	p.offset = start
+	return nil, p.errorf("expected a path or a value")
+}
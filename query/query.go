@@ -0,0 +1,294 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package query selects values from YAML and JSON documents with
+// expressions in a subset of JSONPath. Queries are made over yaml.Nodes,
+// such as the ToRawInfo forms of compiled models, so the values that they
+// select keep the order that they have in their documents.
+//
+//	q, err := query.Compile("$.paths.*[?(!(@.responses.*~ =~ '^4'))]")
+//	...
+//	for _, match := range q.Evaluate(document.ToRawInfo()) {
+//		fmt.Println(match.Pointer)
+//	}
+//
+// Expressions start with $, the root of the document, and are followed
+// by selectors:
+//
+//	.name or ['name']      the value of a key of a map
+//	['a','b']              the values of several keys
+//	[0] or [0,2]           items of a sequence
+//	.* or [*]              all of the values of a map or sequence
+//	..name or ..*          the values selected anywhere under a value
+//	~                      the keys of the selected values of maps
+//	[?(filter)]            the values of a map or sequence that match a filter
+//
+// Filters compare the values selected by paths that start with @, the value
+// being filtered, or $, with other paths and with strings, numbers, true,
+// false, and null, using ==, !=, <, <=, >, >=, and =~ (which matches regular
+// expressions). A path alone is true if it selects any values. Comparisons
+// are true if any of the values selected by their paths match. Filters can
+// be combined with !, &&, ||, and parentheses.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// A Query is a compiled expression.
+type Query struct {
+	expression string
+	path       *path
+}
+
+// A Match is a value selected by a query.
+type Match struct {
+	// Node is the selected value. Keys selected with ~ are their scalar nodes.
+	Node *yaml.Node
+	// Pointer is the JSON Pointer of the value in its document.
+	Pointer string
+	// Parent is the map or sequence that contains the value,
+	// or nil if it is the root of its document.
+	Parent *yaml.Node
+	// Key is the key of the value in its parent map, or empty if its parent is a sequence.
+	Key string
+	// Index is the index of the value in its parent sequence, or -1 if its parent is a map.
+	Index int
+}
+
+// Compile parses a query expression.
+func Compile(expression string) (*Query, error) {
+	p := &parser{text: expression}
+	p.skipSpace()
+	if !p.consume("$") {
+		return nil, p.errorf("queries must start with $")
+	}
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.rest())
+	}
+	return &Query{expression: expression, path: path}, nil
+}
+
+// MustCompile is like Compile but panics if the expression can't be parsed.
+func MustCompile(expression string) *Query {
+	q, err := Compile(expression)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+func (q *Query) String() string {
+	return q.expression
+}
+
+// Evaluate returns the values of a document that are selected by a query,
+// in document order. Documents are the root nodes of YAML files or nodes
+// produced by ToRawInfo methods.
+func (q *Query) Evaluate(document *yaml.Node) []*Match {
+	root := rootMatch(document)
+	return q.path.evaluate(root, root)
+}
+
+// Returns the match of the root of a document.
+func rootMatch(document *yaml.Node) *Match {
+	if document != nil && document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+		document = document.Content[0]
+	}
+	return &Match{Node: document, Index: -1}
+}
+
+// A path is a list of selectors that are applied in turn.
+type path struct {
+	relative  bool // relative paths start with @
+	selectors []selector
+}
+
+func (p *path) evaluate(root *Match, current *Match) []*Match {
+	matches := []*Match{root}
+	if p.relative {
+		matches = []*Match{current}
+	}
+	for _, s := range p.selectors {
+		selected := make([]*Match, 0)
+		for _, match := range matches {
+			selected = append(selected, s.selectFrom(root, match)...)
+		}
+		matches = selected
+	}
+	return matches
+}
+
+// A selector selects values from a matched value.
+type selector interface {
+	selectFrom(root *Match, match *Match) []*Match
+}
+
+// Returns the value of a node, following YAML aliases.
+func resolve(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// escapes the characters that have special meanings in JSON Pointers
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Returns the matches of the values that a map or sequence contains.
+func children(match *Match) []*Match {
+	node := resolve(match.Node)
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		list := make([]*Match, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, _ := compiler.KeyForNode(node.Content[i])
+			list = append(list, &Match{
+				Node:    node.Content[i+1],
+				Pointer: match.Pointer + "/" + pointerEscaper.Replace(key),
+				Parent:  node,
+				Key:     key,
+				Index:   -1,
+			})
+		}
+		return list
+	case yaml.SequenceNode:
+		list := make([]*Match, 0, len(node.Content))
+		for i, item := range node.Content {
+			list = append(list, &Match{
+				Node:    item,
+				Pointer: match.Pointer + "/" + strconv.Itoa(i),
+				Parent:  node,
+				Index:   i,
+			})
+		}
+		return list
+	}
+	return nil
+}
+
+// selects the values of named keys of maps
+type keysSelector struct {
+	names []string
+}
+
+func (s *keysSelector) selectFrom(root *Match, match *Match) []*Match {
+	if node := resolve(match.Node); node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	all := children(match)
+	selected := make([]*Match, 0)
+	for _, name := range s.names {
+		for _, child := range all {
+			if child.Key == name {
+				selected = append(selected, child)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// selects items of sequences; negative indices count from the end
+type indicesSelector struct {
+	indices []int
+}
+
+func (s *indicesSelector) selectFrom(root *Match, match *Match) []*Match {
+	if node := resolve(match.Node); node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	all := children(match)
+	selected := make([]*Match, 0)
+	for _, index := range s.indices {
+		if index < 0 {
+			index += len(all)
+		}
+		if index >= 0 && index < len(all) {
+			selected = append(selected, all[index])
+		}
+	}
+	return selected
+}
+
+// selects all of the values of maps and sequences
+type wildcardSelector struct{}
+
+func (s *wildcardSelector) selectFrom(root *Match, match *Match) []*Match {
+	return children(match)
+}
+
+// selects the keys of the values that were selected from maps
+type keyNameSelector struct{}
+
+func (s *keyNameSelector) selectFrom(root *Match, match *Match) []*Match {
+	if match.Parent == nil || match.Index >= 0 {
+		return nil
+	}
+	for i := 0; i+1 < len(match.Parent.Content); i += 2 {
+		if match.Parent.Content[i+1] == match.Node {
+			key := *match
+			key.Node = match.Parent.Content[i]
+			return []*Match{&key}
+		}
+	}
+	return nil
+}
+
+// applies a selector to a value and to all of its descendants
+type descendantSelector struct {
+	selector selector
+}
+
+func (s *descendantSelector) selectFrom(root *Match, match *Match) []*Match {
+	selected := s.selector.selectFrom(root, match)
+	for _, child := range children(match) {
+		selected = append(selected, s.selectFrom(root, child)...)
+	}
+	return selected
+}
+
+// selects the values of maps and sequences that match a filter
+type filterSelector struct {
+	filter filter
+}
+
+func (s *filterSelector) selectFrom(root *Match, match *Match) []*Match {
+	selected := make([]*Match, 0)
+	for _, child := range children(match) {
+		if s.filter.test(root, child) {
+			selected = append(selected, child)
+		}
+	}
+	return selected
+}
+
+// Returns an error that describes a problem with a query.
+func queryError(expression string, offset int, message string) error {
+	return errors.New(fmt.Sprintf("invalid query %q at offset %d: %s", expression, offset, message))
+}
@@ -0,0 +1,108 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const document = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets, public]
+      responses:
+        "200": {description: pets}
+        "404": {description: not found}
+    post:
+      operationId: createPet
+      responses:
+        "201": {description: created}
+  /pets/{id}:
+    parameters:
+    - {name: id, in: path, required: true}
+    delete:
+      operationId: deletePet
+      deprecated: true
+      responses:
+        "204": {description: deleted}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string, maxLength: 64}
+        age: {type: integer, maximum: 30}
+`
+
+func pointers(t *testing.T, expression string) string {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(document), &node); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	q, err := Compile(expression)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	list := make([]string, 0)
+	for _, match := range q.Evaluate(&node) {
+		if match.Node.Kind == yaml.ScalarNode {
+			list = append(list, match.Pointer+"="+match.Node.Value)
+		} else {
+			list = append(list, match.Pointer)
+		}
+	}
+	return strings.Join(list, " ")
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"$", ""},
+		{"$.info.title", "/info/title=Pets"},
+		{"$['info']['version']", "/info/version=1.0"},
+		{"$.paths['/pets'][\"get\",'post'].operationId", "/paths/~1pets/get/operationId=listPets /paths/~1pets/post/operationId=createPet"},
+		{"$.paths.*~", "/paths/~1pets=/pets /paths/~1pets~1{id}=/pets/{id}"},
+		{"$.paths['/pets'].get.tags[-1]", "/paths/~1pets/get/tags/1=public"},
+		{"$.paths['/pets'].get.tags[0,5]", "/paths/~1pets/get/tags/0=pets"},
+		{"$..operationId", "/paths/~1pets/get/operationId=listPets /paths/~1pets/post/operationId=createPet /paths/~1pets~1{id}/delete/operationId=deletePet"},
+		{"$..[?(@.deprecated == true)].operationId", "/paths/~1pets~1{id}/delete/operationId=deletePet"},
+		{"$.paths.*[?(@.responses && !(@.responses.*~ =~ '^4'))].operationId", "/paths/~1pets/post/operationId=createPet /paths/~1pets~1{id}/delete/operationId=deletePet"},
+		{"$.paths.*.parameters[?(@.in == 'path' && @.required)].name", "/paths/~1pets~1{id}/parameters/0/name=id"},
+		{"$.components.schemas.Pet.properties[?(@.maximum > 10 || @.maxLength >= 100)]~", "/components/schemas/Pet/properties/age=age"},
+		{"$.components.schemas.Pet.properties[?(@.type != 'string')]~", "/components/schemas/Pet/properties/age=age"},
+		{"$.paths.*.*.tags[?(@ == 'pets')]", "/paths/~1pets/get/tags/0=pets"},
+		{"$.paths.*[?(@.operationId == $.paths['/pets'].post.operationId)]", "/paths/~1pets/post"},
+		{"$.info.missing", ""},
+	}
+	for _, test := range tests {
+		if found := pointers(t, test.expression); found != test.expected {
+			t.Errorf("%s: expected %q, found %q", test.expression, test.expected, found)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, expression := range []string{
+		"info",
+		"$.",
+		"$[",
+		"$['name'",
+		"$[?(@.a == )]",
+		"$[?(@.a =~ 1)]",
+		"$[?(@.a =~ '(')]",
+		"$.a b",
+	} {
+		if _, err := Compile(expression); err == nil {
+			t.Errorf("expected an error compiling %s", expression)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/googleapis/gnostic/jsonwriter"
+	"github.com/googleapis/gnostic/query"
+)
+
+func TestQuery(t *testing.T) {
+	document, err := queryDocument("examples/v3.0/yaml/petstore.yaml", false)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	q := query.MustCompile("$.paths.*[?(@.operationId =~ '^list')].operationId")
+	bytes, err := jsonwriter.Marshal(queryResults(q.Evaluate(document), true))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	expected := `[
+  {
+    "pointer": "/paths/~1pets/get/operationId",
+    "value": "listPets"
+  }
+]
+`
+	if string(bytes) != expected {
+		t.Errorf("unexpected results %s", bytes)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonwriter"
+	"gopkg.in/yaml.v3"
+)
+
+const rewriteRefsUsage = `
+Usage: gnostic rewrite-refs OPENAPI_SOURCE --rule=FROM=TO... [OPTIONS]
+  Rewrite the $refs of a description with the first rule that applies to
+  each of them, and write the description with its other contents unchanged.
+  A * in FROM matches any text, which replaces the * in TO. For example,
+  this refers to local copies of shared schemas instead of published ones:
+    gnostic rewrite-refs api.yaml --rule="https://schemas.example.com/*=vendor/*" --out=local/api.yaml --vendor
+  and this refers to the published schemas again:
+    gnostic rewrite-refs local/api.yaml --rule="vendor/*=https://schemas.example.com/*"
+Options:
+  --rule=FROM=TO      Rewrite references that match FROM. Rules are tried in
+                      the order that they are given.
+  --out=PATH          Write the description to PATH instead of stdout.
+  --vendor            Copy the targets of references that are rewritten from
+                      URLs to local files into those files, which are relative
+                      to the output. The references in the copies are
+                      rewritten with the same rules.
+  --allow-private-network
+                      Copy files from loopback, link-local, and private
+                      network addresses.
+`
+
+// A list of reference rules that is read from repeated flags.
+type referenceRules []*compiler.ReferenceRule
+
+func (rules *referenceRules) String() string {
+	return fmt.Sprintf("%d rules", len(*rules))
+}
+
+func (rules *referenceRules) Set(text string) error {
+	rule, err := compiler.ParseReferenceRule(text)
+	if err != nil {
+		return err
+	}
+	*rules = append(*rules, rule)
+	return nil
+}
+
+// Returns true if a location is a URL instead of a local file.
+func isRemote(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https" || u.Scheme == compiler.RegistryScheme)
+}
+
+// Splits a reference into its file and its fragment, which includes the #.
+func splitRef(ref string) (string, string) {
+	if i := strings.Index(ref, "#"); i >= 0 {
+		return ref[:i], ref[i:]
+	}
+	return ref, ""
+}
+
+// Returns the text of a parsed file in the format of its name.
+func marshalFile(filename string, node *yaml.Node) ([]byte, error) {
+	if strings.ToLower(filepath.Ext(filename)) == ".json" {
+		return jsonwriter.Marshal(node)
+	}
+	// styles and comments are kept, so that only the references change
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	encoder.Close()
+	return buffer.Bytes(), nil
+}
+
+// Copies remote files into local ones.
+type vendorer struct {
+	rules   referenceRules
+	root    string // the directory that local files are relative to
+	options *compiler.CompilerOptions
+	copied  map[string]bool
+}
+
+// Copies the file that a reference in a copied file refers to and returns
+// the reference to the copy, which is relative to the copied file. Remote
+// files that no rule maps to local files are referred to by their URLs.
+func (v *vendorer) vendorRef(ref string, source string, local string) (string, error) {
+	file, fragment := splitRef(ref)
+	if file == "" {
+		return ref, nil
+	}
+	location := compiler.FileForRef(source, ref)
+	mapped, ok := v.rewrite(location + fragment)
+	if !ok {
+		return location + fragment, nil
+	}
+	target, _ := splitRef(mapped)
+	target = filepath.Join(v.root, target)
+	relative, err := filepath.Rel(filepath.Dir(local), target)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(relative) + fragment, v.vendorFile(location, target)
+}
+
+// Returns the local file that a rule maps a remote location to, relative
+// to the output.
+func (v *vendorer) rewrite(ref string) (string, bool) {
+	for _, rule := range v.rules {
+		if rewritten, ok := rule.Rewrite(ref); ok {
+			file, _ := splitRef(rewritten)
+			return rewritten, isRemote(ref) && !isRemote(file)
+		}
+	}
+	return "", false
+}
+
+// Copies a remote file to a local one, rewriting its references.
+func (v *vendorer) vendorFile(location string, local string) error {
+	if v.copied[local] {
+		return nil
+	}
+	v.copied[local] = true
+	data, err := compiler.ReadBytesForReferenceWithOptions(location, v.options)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return errors.New(fmt.Sprintf("unable to read %s: %s", location, err.Error()))
+	}
+	for _, value := range compiler.ReferenceNodes(&node) {
+		ref, err := v.vendorRef(value.Value, location, local)
+		if err != nil {
+			return err
+		}
+		value.Value = ref
+	}
+	output, err := marshalFile(local, &node)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(local, output, 0644)
+}
+
+// Rewrites the references of a description with the specified command-line arguments.
+func rewriteRefs(args []string) {
+	flags := flag.NewFlagSet("rewrite-refs", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, rewriteRefsUsage) }
+	var rules referenceRules
+	flags.Var(&rules, "rule", "")
+	out := flags.String("out", "-", "")
+	vendor := flags.Bool("vendor", false, "")
+	allowPrivateNetwork := flags.Bool("allow-private-network", false, "")
+	// options can follow the source
+	positional := make([]string, 0)
+	for len(args) > 0 {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) > 0 {
+			positional = append(positional, args[0])
+			args = args[1:]
+		}
+	}
+	if len(positional) != 1 || len(rules) == 0 {
+		fmt.Fprint(os.Stderr, rewriteRefsUsage)
+		os.Exit(-1)
+	}
+	source := positional[0]
+	if err := rewriteRefsInFile(source, *out, rules, *vendor, *allowPrivateNetwork); err != nil {
+		fmt.Fprintf(os.Stderr, "Errors rewriting references in %s\n%+v\n", source, err)
+		os.Exit(-1)
+	}
+}
+
+// Rewrites the references of a description and writes it to a file or to stdout.
+func rewriteRefsInFile(source string, out string, rules referenceRules, vendor bool, allowPrivateNetwork bool) error {
+	options := &compiler.CompilerOptions{Cache: compiler.NewCache(), AllowPrivateNetworks: allowPrivateNetwork}
+	data, err := compiler.ReadBytesForFileWithOptions(source, options)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	changed := compiler.RewriteReferencesInNode(&node, rules)
+	if vendor {
+		// local files are relative to the rewritten description
+		v := &vendorer{rules: rules, root: ".", options: options, copied: make(map[string]bool)}
+		if out != "-" {
+			v.root = filepath.Dir(out)
+		}
+		for _, ref := range changed {
+			mapped, ok := v.rewrite(ref)
+			if !ok {
+				continue
+			}
+			file, _ := splitRef(mapped)
+			if err := v.vendorFile(compiler.FileForRef(source, ref), filepath.Join(v.root, file)); err != nil {
+				return err
+			}
+		}
+	}
+	output, err := marshalFile(source, &node)
+	if err != nil {
+		return err
+	}
+	if out == "-" {
+		_, err = os.Stdout.Write(output)
+		return err
+	}
+	return ioutil.WriteFile(out, output, 0644)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/compiler"
+)
+
+func TestRewriteRefs(t *testing.T) {
+	files := map[string]string{
+		"/schemas/pet.yaml":    "Pet:\n  type: object\n  properties:\n    id: {$ref: 'common.yaml#/Id'}\n",
+		"/schemas/common.yaml": "Id: {type: integer}\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(files[r.URL.Path]))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "api.yaml")
+	description := `swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+definitions:
+  # pets are shared
+  Pet: {$ref: '` + server.URL + `/schemas/pet.yaml#/Pet'}
+`
+	_ = ioutil.WriteFile(source, []byte(description), 0644)
+
+	rule, _ := compiler.ParseReferenceRule(server.URL + "/schemas/*=vendor/*")
+	out := filepath.Join(dir, "local", "api.yaml")
+	if err := rewriteRefsInFile(source, out, referenceRules{rule}, true, true); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for file, expected := range map[string]string{
+		"local/api.yaml":           "  # pets are shared\n  Pet: {$ref: 'vendor/pet.yaml#/Pet'}\n",
+		"local/vendor/pet.yaml":    "id: {$ref: 'common.yaml#/Id'}",
+		"local/vendor/common.yaml": "Id: {type: integer}",
+	} {
+		bytes, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil || !strings.Contains(string(bytes), expected) {
+			t.Errorf("Unexpected %s: %+v\n%s", file, err, bytes)
+		}
+	}
+
+	// the reverse rule refers to the published schemas again
+	rule, _ = compiler.ParseReferenceRule("vendor/*=" + server.URL + "/schemas/*")
+	published := filepath.Join(dir, "published.yaml")
+	if err := rewriteRefsInFile(out, published, referenceRules{rule}, false, false); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	if bytes, _ := ioutil.ReadFile(published); string(bytes) != description {
+		t.Errorf("Unexpected description:\n%s", bytes)
+	}
+}
@@ -0,0 +1,441 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonschema"
+	gnostic "github.com/googleapis/gnostic/lib"
+	"github.com/googleapis/gnostic/linter"
+	"github.com/googleapis/gnostic/validator"
+	"gopkg.in/yaml.v3"
+)
+
+const serveUsage = `
+Usage: gnostic serve [OPTIONS]
+  Run an HTTP service that compiles OpenAPI descriptions. Descriptions are
+  sent as the bodies of POST requests in JSON or YAML, and results are
+  returned as JSON.
+Endpoints:
+  /compile            Compile a description and return its model.
+  /validate           Report whether a description has errors, including
+                      the violations of the specification that the models
+                      don't enforce.
+  /lint               Report the errors and lint problems of a description.
+                      Rulesets other than the default one, like "security",
+                      are checked when they are named by ruleset parameters.
+  /convert?format=F   Convert a description to json, yaml, text, or pb.
+  /diff               Compare the schemas of the descriptions in the "old"
+                      and "new" fields of a JSON request.
+Options:
+  --addr=ADDRESS      Listen on the specified address (default ":8080").
+  --max-size=BYTES    Reject requests with larger bodies (default 10MB).
+  --read-header-timeout=DURATION
+                      Close connections whose request headers aren't read
+                      within the duration (default 10s).
+  --read-timeout=DURATION
+                      Close connections whose requests aren't read within
+                      the duration (default 1m).
+  --write-timeout=DURATION
+                      Close connections whose responses aren't written
+                      within the duration after their requests were read
+                      (default 2m).
+  --idle-timeout=DURATION
+                      Close idle connections after the duration (default 2m).
+References to other files and URLs are not resolved.
+`
+
+// server handles the requests of the gnostic service.
+type server struct {
+	maxSize int64
+	logger  *log.Logger
+}
+
+// serveError is a compilation error or lint problem in a response.
+type serveError struct {
+	Message   string `json:"message"`
+	Severity  string `json:"severity"`
+	Code      string `json:"code,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndLine   int    `json:"endLine,omitempty"`
+	EndColumn int    `json:"endColumn,omitempty"`
+}
+
+// serveResult is the response to a request for a single description.
+type serveResult struct {
+	Version  int             `json:"version,omitempty"`
+	Valid    *bool           `json:"valid,omitempty"`
+	Errors   []*serveError   `json:"errors"`
+	Problems []*serveError   `json:"problems,omitempty"`
+	Document json.RawMessage `json:"document,omitempty"`
+	Format   string          `json:"format,omitempty"`
+	Output   interface{}     `json:"output,omitempty"`
+}
+
+// serveChange is a difference between schemas in a diff response.
+type serveChange struct {
+	Path     string `json:"path"`
+	Kind     string `json:"kind"`
+	Effect   string `json:"effect"`
+	OldValue string `json:"oldValue,omitempty"`
+	NewValue string `json:"newValue,omitempty"`
+	Breaking bool   `json:"breaking"`
+}
+
+// serveDiff is the response to a diff request.
+type serveDiff struct {
+	Changes  []*serveChange `json:"changes"`
+	Breaking bool           `json:"breaking"`
+}
+
+func newServer(maxSize int64) *server {
+	return &server{maxSize: maxSize, logger: log.New(os.Stderr, "", log.LstdFlags)}
+}
+
+// Returns a handler for all of the service's endpoints.
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/compile", s.post(s.compile))
+	mux.HandleFunc("/validate", s.post(s.validate))
+	mux.HandleFunc("/lint", s.post(s.lint))
+	mux.HandleFunc("/convert", s.post(s.convert))
+	mux.HandleFunc("/diff", s.post(s.diff))
+	return mux
+}
+
+// Wraps an endpoint so that it only accepts POST requests with bodies
+// that aren't too large, and writes its result or error as JSON.
+func (s *server) post(endpoint func(r *http.Request, body []byte) (interface{}, int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			s.write(w, http.StatusMethodNotAllowed, errorResponse(errors.New("Only POST requests are accepted.")))
+			return
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, s.maxSize))
+		if err != nil {
+			s.write(w, http.StatusRequestEntityTooLarge, errorResponse(errors.New(fmt.Sprintf("Request bodies are limited to %d bytes.", s.maxSize))))
+			return
+		}
+		result, status := endpoint(r, body)
+		s.write(w, status, result)
+	}
+}
+
+func (s *server) write(w http.ResponseWriter, status int, result interface{}) {
+	bytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		s.logger.Printf("Error writing response: %+v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(bytes, '\n'))
+}
+
+// Returns the response to a request that couldn't be handled.
+func errorResponse(err error) *serveResult {
+	return &serveResult{Errors: serveErrors(err)}
+}
+
+// Converts compilation errors to their form in responses.
+func serveErrors(err error) []*serveError {
+	list := make([]*serveError, 0)
+	for _, e := range compiler.ErrorList(err) {
+		item := &serveError{
+			Message:   e.Message,
+			Severity:  e.Severity.String(),
+			Code:      e.Code,
+			Line:      e.Line,
+			Column:    e.Column,
+			EndLine:   e.EndLine,
+			EndColumn: e.EndColumn,
+		}
+		if e.Context != nil {
+			item.Path = e.Path()
+		}
+		list = append(list, item)
+	}
+	return list
+}
+
+// Returns the options used to read the descriptions sent to the service.
+// Each request has its own cache, and references to other files are not read.
+func descriptionOptions() *compiler.CompilerOptions {
+	return &compiler.CompilerOptions{Cache: compiler.NewCache(), LocalReferencesOnly: true}
+}
+
+// Compiles a description without resolving its references, since the
+// files and URLs that they name belong to the service and not the client.
+func compileDescription(body []byte) (*gnostic.Document, error) {
+	options := descriptionOptions()
+	info, err := compiler.ReadInfoFromBytesWithOptions("", body, options)
+	if err != nil {
+		return nil, err
+	}
+	version, err := gnostic.DetectVersion(info)
+	if err != nil {
+		return nil, err
+	}
+	document := &gnostic.Document{Version: version, Source: info}
+	context := compiler.NewContextWithOptions("$root", options)
+	switch version {
+	case OpenAPIv2:
+		document.V2, err = openapi_v2.NewDocument(info, context)
+	case OpenAPIv3:
+		document.V3, err = openapi_v3.NewDocument(info, context)
+	}
+	return document, err
+}
+
+// Returns the status of a response to a request for a description.
+// Descriptions that couldn't be read at all are bad requests; descriptions
+// that were compiled with errors are still described in the response.
+func statusForDocument(document *gnostic.Document) int {
+	if document == nil {
+		return http.StatusBadRequest
+	}
+	return http.StatusOK
+}
+
+func (s *server) compile(r *http.Request, body []byte) (interface{}, int) {
+	document, err := compileDescription(body)
+	result := &serveResult{Errors: serveErrors(err)}
+	if document != nil {
+		result.Version = document.Version
+		if err == nil {
+			result.Document, err = document.JSON()
+			if err != nil {
+				return errorResponse(err), http.StatusInternalServerError
+			}
+		}
+	}
+	return result, statusForDocument(document)
+}
+
+func (s *server) validate(r *http.Request, body []byte) (interface{}, int) {
+	document, err := compileDescription(body)
+	problems := make([]*compiler.Error, 0)
+	if err == nil {
+		problems = validator.ValidateWithOptions(document, descriptionOptions())
+		err = validator.Errors(problems)
+	}
+	valid := err == nil
+	result := &serveResult{Valid: &valid, Errors: serveErrors(err)}
+	// warnings don't make descriptions invalid
+	for _, problem := range problems {
+		if problem.Severity != compiler.SeverityError {
+			result.Problems = append(result.Problems, serveErrors(problem)...)
+		}
+	}
+	if document != nil {
+		result.Version = document.Version
+	}
+	return result, http.StatusOK
+}
+
+func (s *server) lint(r *http.Request, body []byte) (interface{}, int) {
+	rulesets, err := lintRulesets(r.URL.Query()["ruleset"])
+	if err != nil {
+		return errorResponse(err), http.StatusBadRequest
+	}
+	document, err := compileDescription(body)
+	result := &serveResult{Errors: serveErrors(err), Problems: make([]*serveError, 0)}
+	if document != nil {
+		result.Version = document.Version
+		if err == nil {
+			for _, problem := range linter.LintWithRulesets(document, rulesets...) {
+				result.Problems = append(result.Problems, serveErrors(problem)...)
+			}
+		}
+	}
+	return result, statusForDocument(document)
+}
+
+func (s *server) convert(r *http.Request, body []byte) (interface{}, int) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	document, err := compileDescription(body)
+	result := &serveResult{Errors: serveErrors(err), Format: format}
+	if document == nil || err != nil {
+		return result, statusForDocument(document)
+	}
+	result.Version = document.Version
+	var output []byte
+	switch format {
+	case "json":
+		output, err = document.JSON()
+		result.Output = json.RawMessage(output)
+	case "yaml":
+		output, err = document.YAML()
+		result.Output = string(output)
+	case "text":
+		result.Output = proto.MarshalTextString(document.Message())
+	case "pb":
+		// byte slices are written as base64 strings
+		result.Output, err = proto.Marshal(document.Message())
+	default:
+		return errorResponse(errors.New(fmt.Sprintf("Unknown format %s. 'json', 'yaml', 'text', and 'pb' are accepted.", format))), http.StatusBadRequest
+	}
+	if err != nil {
+		return errorResponse(err), http.StatusInternalServerError
+	}
+	return result, http.StatusOK
+}
+
+func (s *server) diff(r *http.Request, body []byte) (interface{}, int) {
+	var request struct {
+		Old string `json:"old"`
+		New string `json:"new"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return errorResponse(err), http.StatusBadRequest
+	}
+	oldPrefix, oldSchemas, err := schemasForDiff(request.Old)
+	if err != nil {
+		return errorResponse(errors.New("old: " + err.Error())), http.StatusBadRequest
+	}
+	newPrefix, newSchemas, err := schemasForDiff(request.New)
+	if err != nil {
+		return errorResponse(errors.New("new: " + err.Error())), http.StatusBadRequest
+	}
+	if oldPrefix != newPrefix {
+		return errorResponse(errors.New("Descriptions of different OpenAPI versions can't be compared.")), http.StatusBadRequest
+	}
+	result := &serveDiff{Changes: make([]*serveChange, 0)}
+	for _, change := range diffNamedSchemas(oldPrefix, oldSchemas, newSchemas) {
+		result.Changes = append(result.Changes, &serveChange{
+			Path:     change.Path,
+			Kind:     change.Kind.String(),
+			Effect:   change.Effect.String(),
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+			Breaking: change.IsBreaking(),
+		})
+		result.Breaking = result.Breaking || change.IsBreaking()
+	}
+	return result, http.StatusOK
+}
+
+// Returns the named schemas of a description and the pointer to them.
+func schemasForDiff(text string) (string, *yaml.Node, error) {
+	info, err := compiler.ReadInfoFromBytesWithOptions("", []byte(text), descriptionOptions())
+	if err != nil {
+		return "", nil, err
+	}
+	version, err := gnostic.DetectVersion(info)
+	if err != nil {
+		return "", nil, err
+	}
+	document, _ := compiler.UnpackMap(info)
+	if version == OpenAPIv2 {
+		schemas, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "definitions"))
+		return "#/definitions", schemas, nil
+	}
+	components, _ := compiler.UnpackMap(compiler.MapValueForKey(document, "components"))
+	schemas, _ := compiler.UnpackMap(compiler.MapValueForKey(components, "schemas"))
+	return "#/components/schemas", schemas, nil
+}
+
+// Returns the changes between two maps of named schemas.
+func diffNamedSchemas(prefix string, oldSchemas *yaml.Node, newSchemas *yaml.Node) []*jsonschema.Change {
+	changes := make([]*jsonschema.Change, 0)
+	schema := func(schemas *yaml.Node, name string) *jsonschema.Schema {
+		if value := compiler.MapValueForKey(schemas, name); value != nil {
+			return jsonschema.NewSchemaFromObject(value)
+		}
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, schemas := range []*yaml.Node{oldSchemas, newSchemas} {
+		if schemas == nil {
+			continue
+		}
+		for i := 0; i < len(schemas.Content); i += 2 {
+			name, ok := compiler.KeyForNode(schemas.Content[i])
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			oldSchema := schema(oldSchemas, name)
+			if oldSchema == nil {
+				// adding a named schema doesn't affect existing messages
+				changes = append(changes, &jsonschema.Change{
+					Path:   prefix + "/" + name,
+					Kind:   jsonschema.KeywordAdded,
+					Effect: jsonschema.EffectNone,
+				})
+				continue
+			}
+			for _, change := range jsonschema.Diff(oldSchema, schema(newSchemas, name)) {
+				change.Path = prefix + "/" + name + change.Path
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes
+}
+
+// Returns the HTTP server of the gnostic service for the specified
+// command-line arguments. Its timeouts keep slow and idle clients from
+// holding connections open indefinitely.
+func newHTTPServer(args []string) *http.Server {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, serveUsage) }
+	addr := flags.String("addr", ":8080", "")
+	maxSize := flags.Int64("max-size", 10<<20, "")
+	readHeaderTimeout := flags.Duration("read-header-timeout", 10*time.Second, "")
+	readTimeout := flags.Duration("read-timeout", time.Minute, "")
+	writeTimeout := flags.Duration("write-timeout", 2*time.Minute, "")
+	idleTimeout := flags.Duration("idle-timeout", 2*time.Minute, "")
+	flags.Parse(args)
+	s := newServer(*maxSize)
+	return &http.Server{
+		Addr:              *addr,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: *readHeaderTimeout,
+		ReadTimeout:       *readTimeout,
+		WriteTimeout:      *writeTimeout,
+		IdleTimeout:       *idleTimeout,
+		ErrorLog:          s.logger,
+	}
+}
+
+// Run the gnostic service with the specified command-line arguments.
+func serve(args []string) {
+	httpServer := newHTTPServer(args)
+	httpServer.ErrorLog.Printf("Serving gnostic on %s", httpServer.Addr)
+	if err := httpServer.ListenAndServe(); err != nil {
+		httpServer.ErrorLog.Printf("%+v", err)
+		os.Exit(-1)
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const servePetstore = `
+swagger: "2.0"
+info:
+  title: Pets
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          description: pets
+definitions:
+  Pet:
+    type: object
+    properties:
+      name:
+        type: string
+`
+
+func post(t *testing.T, path string, body string, result interface{}) int {
+	server := httptest.NewServer(newServer(1 << 20).handler())
+	defer server.Close()
+	response, err := http.Post(server.URL+path, "application/yaml", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer response.Body.Close()
+	if err = json.NewDecoder(response.Body).Decode(result); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return response.StatusCode
+}
+
+func TestServeCompile(t *testing.T) {
+	var result struct {
+		Version  int
+		Errors   []serveError
+		Document struct{ Info struct{ Title string } }
+	}
+	if status := post(t, "/compile", servePetstore, &result); status != http.StatusOK {
+		t.Errorf("unexpected status %d", status)
+	}
+	if result.Version != 2 || len(result.Errors) != 0 || result.Document.Info.Title != "Pets" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestServeValidate(t *testing.T) {
+	var result struct {
+		Valid  bool
+		Errors []serveError
+	}
+	post(t, "/validate", servePetstore+"x-unknown: [\n", &result)
+	if result.Valid || len(result.Errors) == 0 {
+		t.Errorf("expected a syntax error, found %+v", result)
+	}
+	post(t, "/validate", strings.Replace(servePetstore, "title: Pets", "name: Pets", 1), &result)
+	if result.Valid || len(result.Errors) != 2 {
+		t.Errorf("expected two errors, found %+v", result)
+	}
+	for _, e := range result.Errors {
+		if e.Path != "/info" || e.Line == 0 {
+			t.Errorf("expected an error located in info, found %+v", e)
+		}
+	}
+}
+
+func TestServeValidateDoesNotReadReferencedFiles(t *testing.T) {
+	file, err := ioutil.TempFile("", "secret")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString("secret: {type: string}\n")
+	file.Close()
+	var result struct {
+		Valid    bool
+		Errors   []serveError
+		Problems []serveError
+	}
+	description := strings.Replace(servePetstore, "        type: string", "        $ref: '"+file.Name()+"#/secret'", 1)
+	post(t, "/validate", description, &result)
+	if !result.Valid || len(result.Problems) != 1 ||
+		result.Problems[0].Message != "reference to "+file.Name()+"#/secret was not checked because references to other files are not resolved" {
+		t.Errorf("expected an unresolved reference, found %+v", result)
+	}
+}
+
+func TestServeLint(t *testing.T) {
+	var result struct{ Problems []serveError }
+	post(t, "/lint", servePetstore, &result)
+	if len(result.Problems) != 1 || result.Problems[0].Code != "operation-id" || result.Problems[0].Path != "/paths/~1pets/get" {
+		t.Errorf("unexpected problems %+v", result.Problems)
+	}
+	post(t, "/lint?ruleset=security", servePetstore, &result)
+	if len(result.Problems) != 2 || result.Problems[1].Code != "security-operation-requirement" {
+		t.Errorf("unexpected problems %+v", result.Problems)
+	}
+	if status := post(t, "/lint?ruleset=unknown", servePetstore, &result); status != http.StatusBadRequest {
+		t.Errorf("expected an unknown ruleset to be rejected, found status %d", status)
+	}
+}
+
+func TestServeConvert(t *testing.T) {
+	var result struct {
+		Format string
+		Output string
+	}
+	post(t, "/convert?format=yaml", servePetstore, &result)
+	if result.Format != "yaml" || !strings.Contains(result.Output, "swagger: \"2.0\"") {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if status := post(t, "/convert?format=xml", servePetstore, &result); status != http.StatusBadRequest {
+		t.Errorf("expected an unknown format to be rejected, found status %d", status)
+	}
+}
+
+func TestServeDiff(t *testing.T) {
+	request, _ := json.Marshal(map[string]string{
+		"old": servePetstore,
+		"new": strings.Replace(servePetstore, "type: string", "type: integer", 1),
+	})
+	var result serveDiff
+	post(t, "/diff", string(request), &result)
+	if !result.Breaking || len(result.Changes) != 1 || result.Changes[0].Path != "#/definitions/Pet/properties/name/type" {
+		t.Errorf("unexpected changes %+v", result.Changes)
+	}
+}
+
+func TestServeLimits(t *testing.T) {
+	server := httptest.NewServer(newServer(16).handler())
+	defer server.Close()
+	response, err := http.Post(server.URL+"/compile", "application/yaml", strings.NewReader(servePetstore))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected a large request to be rejected, found status %d", response.StatusCode)
+	}
+	response, err = http.Get(server.URL + "/compile")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected a GET request to be rejected, found status %d", response.StatusCode)
+	}
+}
+
+func TestServeTimeouts(t *testing.T) {
+	defaults := newHTTPServer(nil)
+	if defaults.Addr != ":8080" || defaults.ReadHeaderTimeout != 10*time.Second || defaults.ReadTimeout != time.Minute ||
+		defaults.WriteTimeout != 2*time.Minute || defaults.IdleTimeout != 2*time.Minute {
+		t.Errorf("unexpected default server %+v", defaults)
+	}
+	server := newHTTPServer([]string{"--addr=127.0.0.1:0", "--read-header-timeout=100ms", "--read-timeout=1s", "--write-timeout=3s", "--idle-timeout=4s"})
+	if server.ReadHeaderTimeout != 100*time.Millisecond || server.ReadTimeout != time.Second ||
+		server.WriteTimeout != 3*time.Second || server.IdleTimeout != 4*time.Second {
+		t.Errorf("unexpected server %+v", server)
+	}
+	// clients that don't send their requests are disconnected
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	go server.Serve(listener)
+	defer server.Close()
+	connection, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer connection.Close()
+	connection.Write([]byte("POST /compile HTTP/1.1\r\n"))
+	connection.SetReadDeadline(time.Now().Add(5 * time.Second))
+	start := time.Now()
+	ioutil.ReadAll(connection)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected a slow client to be disconnected, waited %s", elapsed)
+	}
+}
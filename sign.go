@@ -0,0 +1,163 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+const verifyUsage = `
+Usage: gnostic verify FILE --key=PATH [OPTIONS]
+  Verify the signature that was written for a binary proto by
+  gnostic --pb-out=FILE --sign-key=PRIVATE_KEY. Keys are Ed25519 keys in
+  PEM files, like those written by "openssl genpkey -algorithm ed25519"
+  and "openssl pkey -pubout".
+Options:
+  --key=PATH          Verify with the public key, or the public part of the
+                      private key, in the specified PEM file.
+  --signature=PATH    Read the signature from the specified file instead of
+                      FILE.sig.
+`
+
+// The extension of the files that signatures are written to.
+const signatureExtension = ".sig"
+
+// Reads the first PEM block of a key file.
+func readPEMBlock(filename string) (*pem.Block, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(fmt.Sprintf("%s is not a PEM file", filename))
+	}
+	return block, nil
+}
+
+// Reads an Ed25519 private key from a PKCS #8 PEM file.
+func readPrivateKey(filename string) (ed25519.PrivateKey, error) {
+	block, err := readPEMBlock(filename)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to read the private key in %s: %s", filename, err.Error()))
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("%s is not an Ed25519 private key", filename))
+	}
+	return privateKey, nil
+}
+
+// Reads an Ed25519 public key from a PEM file of a public or private key.
+func readPublicKey(filename string) (ed25519.PublicKey, error) {
+	block, err := readPEMBlock(filename)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type == "PRIVATE KEY" {
+		privateKey, err := readPrivateKey(filename)
+		if err != nil {
+			return nil, err
+		}
+		return privateKey.Public().(ed25519.PublicKey), nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("unable to read the public key in %s: %s", filename, err.Error()))
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("%s is not an Ed25519 public key", filename))
+	}
+	return publicKey, nil
+}
+
+// Returns the detached signature of a file's contents, which is written in base64.
+func signatureBytes(key ed25519.PrivateKey, data []byte) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, data)) + "\n")
+}
+
+// Returns an error if a signature was not made for a file's contents with a key.
+func verifySignature(key ed25519.PublicKey, data []byte, signature []byte) error {
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil || len(decoded) != ed25519.SignatureSize {
+		return errors.New("the signature is not a base64 Ed25519 signature")
+	}
+	if !ed25519.Verify(key, data, decoded) {
+		return errors.New("the signature does not match the file and key")
+	}
+	return nil
+}
+
+// Verifies the signature of a file, which is read from FILE.sig if signatureFile is empty.
+func verifyFile(filename string, keyFile string, signatureFile string) error {
+	if signatureFile == "" {
+		signatureFile = filename + signatureExtension
+	}
+	key, err := readPublicKey(keyFile)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	signature, err := ioutil.ReadFile(signatureFile)
+	if err != nil {
+		return err
+	}
+	return verifySignature(key, data, signature)
+}
+
+// Verifies the signature of a file with the specified command-line arguments.
+func verify(args []string) {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, verifyUsage) }
+	key := flags.String("key", "", "")
+	signature := flags.String("signature", "", "")
+	// options can follow the file
+	positional := make([]string, 0)
+	for len(args) > 0 {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) > 0 {
+			positional = append(positional, args[0])
+			args = args[1:]
+		}
+	}
+	if len(positional) != 1 || *key == "" {
+		fmt.Fprint(os.Stderr, verifyUsage)
+		os.Exit(-1)
+	}
+	filename := positional[0]
+	if err := verifyFile(filename, *key, *signature); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to verify %s: %s\n", filename, err.Error())
+		os.Exit(-1)
+	}
+	fmt.Printf("Verified %s\n", filename)
+}
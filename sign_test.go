@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Writes a PEM file of a key.
+func writeKey(t *testing.T, filename string, blockType string, der []byte) {
+	if err := ioutil.WriteFile(filename, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gnostic")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	privateDER, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	publicDER, _ := x509.MarshalPKIXPublicKey(publicKey)
+	keyFile := filepath.Join(dir, "key.pem")
+	publicFile := filepath.Join(dir, "public.pem")
+	writeKey(t, keyFile, "PRIVATE KEY", privateDER)
+	writeKey(t, publicFile, "PUBLIC KEY", publicDER)
+
+	// signatures are written next to protos that are named by directories
+	output, err := exec.Command("gnostic", "examples/v2.0/yaml/petstore.yaml", "--pb-out="+dir, "--sign-key="+keyFile).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%+v\n%s", err, output)
+	}
+	proto := filepath.Join(dir, "petstore.pb")
+	for _, key := range []string{publicFile, keyFile} {
+		if err := verifyFile(proto, key, ""); err != nil {
+			t.Errorf("Unexpected error verifying with %s: %+v", key, err)
+		}
+	}
+	output, err = exec.Command("gnostic", "verify", proto, "--key="+publicFile).CombinedOutput()
+	if err != nil || !strings.Contains(string(output), "Verified") {
+		t.Errorf("Unexpected result of gnostic verify: %+v\n%s", err, output)
+	}
+
+	// changed protos and other keys are rejected
+	data, _ := ioutil.ReadFile(proto)
+	_ = ioutil.WriteFile(proto, append(data, 0), 0644)
+	if err := verifyFile(proto, publicFile, ""); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Expected a changed proto to be rejected, got %v", err)
+	}
+	_ = ioutil.WriteFile(proto, data, 0644)
+	otherKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	otherDER, _ := x509.MarshalPKIXPublicKey(otherKey)
+	otherFile := filepath.Join(dir, "other.pem")
+	writeKey(t, otherFile, "PUBLIC KEY", otherDER)
+	if err := verifyFile(proto, otherFile, ""); err == nil {
+		t.Errorf("Expected a signature to be rejected with another key")
+	}
+	output, err = exec.Command("gnostic", "verify", proto, "--key="+otherFile).CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "Unable to verify") {
+		t.Errorf("Expected gnostic verify to fail: %+v\n%s", err, output)
+	}
+
+	// signatures require files
+	output, err = exec.Command("gnostic", "examples/v2.0/yaml/petstore.yaml", "--pb-out=-", "--sign-key="+keyFile).CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "--sign-key requires --pb-out") {
+		t.Errorf("Expected signing to stdout to fail: %+v\n%s", err, output)
+	}
+}
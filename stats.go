@@ -0,0 +1,144 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// compilationStats describe the work done to compile a description and write
+// its outputs, so that the performance of builds can be tracked over time.
+type compilationStats struct {
+	Source  string         `json:"source"`
+	Seconds float64        `json:"seconds"` // the time from the start of the compilation to the end of the last stage
+	Stages  []*stageStats  `json:"stages"`  // the stages, in the order that they started
+	Files   int            `json:"files"`   // the files that were read, including referenced files
+	Bytes   int64          `json:"bytes"`   // the sizes of those files
+	Nodes   int            `json:"nodes"`   // the nodes of the parsed sources, not including referenced files
+	Cache   cacheStats     `json:"cache"`
+	Outputs map[string]int `json:"outputs"` // the sizes of the outputs, by the kind of output
+	start   time.Time
+	stage   *stageStats // the stage in progress
+	started time.Time   // when the stage in progress started
+}
+
+type stageStats struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"` // the total time spent in the stage
+}
+
+// The counts of the reference cache, with their hit rates.
+type cacheStats struct {
+	FileHits    int64   `json:"fileHits"`
+	FileMisses  int64   `json:"fileMisses"`
+	FileHitRate float64 `json:"fileHitRate"`
+	InfoHits    int64   `json:"infoHits"`
+	InfoMisses  int64   `json:"infoMisses"`
+	InfoHitRate float64 `json:"infoHitRate"`
+	RefHits     int64   `json:"refHits"`
+	RefMisses   int64   `json:"refMisses"`
+	RefHitRate  float64 `json:"refHitRate"`
+}
+
+func newCompilationStats(source string) *compilationStats {
+	return &compilationStats{
+		Source:  source,
+		Stages:  make([]*stageStats, 0),
+		Outputs: make(map[string]int),
+		start:   time.Now(),
+	}
+}
+
+// Ends the stage in progress and starts another. Stages that are repeated,
+// such as those of the documents of a YAML stream, are timed together.
+func (s *compilationStats) startStage(name string) {
+	if s == nil {
+		return
+	}
+	s.endStage()
+	for _, stage := range s.Stages {
+		if stage.Name == name {
+			s.stage = stage
+		}
+	}
+	if s.stage == nil {
+		s.stage = &stageStats{Name: name}
+		s.Stages = append(s.Stages, s.stage)
+	}
+	s.started = time.Now()
+}
+
+func (s *compilationStats) endStage() {
+	if s.stage != nil {
+		s.stage.Seconds += time.Since(s.started).Seconds()
+		s.stage = nil
+	}
+}
+
+// Counts the nodes of a parsed source.
+func (s *compilationStats) countNodes(node *yaml.Node) {
+	if s == nil || node == nil {
+		return
+	}
+	s.Nodes++
+	for _, child := range node.Content {
+		s.countNodes(child)
+	}
+}
+
+// Adds the counts of a cache, which are reset when it is cleared.
+func (s *compilationStats) addCache(stats compiler.CacheStats) {
+	if s == nil {
+		return
+	}
+	s.Cache.FileHits += stats.FileHits
+	s.Cache.FileMisses += stats.FileMisses
+	s.Cache.InfoHits += stats.InfoHits
+	s.Cache.InfoMisses += stats.InfoMisses
+	s.Cache.RefHits += stats.RefHits
+	s.Cache.RefMisses += stats.RefMisses
+}
+
+// Adds the size of an output.
+func (s *compilationStats) addOutput(kind string, size int) {
+	if s == nil {
+		return
+	}
+	s.Outputs[kind] += size
+}
+
+// Ends the last stage and returns the statistics as JSON.
+func (s *compilationStats) bytes(progress compiler.ProgressEvent) []byte {
+	s.endStage()
+	s.Seconds = time.Since(s.start).Seconds()
+	s.Files, s.Bytes = progress.Files, progress.Bytes
+	s.Cache.FileHitRate = hitRate(s.Cache.FileHits, s.Cache.FileMisses)
+	s.Cache.InfoHitRate = hitRate(s.Cache.InfoHits, s.Cache.InfoMisses)
+	s.Cache.RefHitRate = hitRate(s.Cache.RefHits, s.Cache.RefMisses)
+	bytes, _ := json.MarshalIndent(s, "", "  ")
+	return bytes
+}
+
+// Returns the fraction of lookups that were hits, or zero if there were none.
+func hitRate(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
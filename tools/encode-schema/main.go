@@ -0,0 +1,79 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// encode-schema is a support tool for building JSON schemas directly
+// into an application binary.
+//
+// It reads a schema file and generates a Go source file containing the
+// text of the schema as a string constant named metaSchemaText. The
+// generated file belongs to the package that is being generated, which
+// is named by the GOPACKAGE variable set by go generate.
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+const SCHEMA_GO = `// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// THIS FILE IS AUTOMATICALLY GENERATED FROM %s BY encode-schema.
+
+package %s
+
+// The text of %s.
+const metaSchemaText = %s
+`
+
+// Returns a Go expression for a string, written as a raw string literal so
+// that the schema can be read in the generated file.
+func rawString(text string) string {
+	return "`" + strings.Replace(text, "`", "` + \"`\" + `", -1) + "`"
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Printf("Usage: %s SCHEMA_FILE OUTPUT_FILE\n", path.Base(os.Args[0]))
+		os.Exit(-1)
+	}
+	input, output := os.Args[1], os.Args[2]
+	data, err := ioutil.ReadFile(input)
+	if err != nil {
+		panic(err)
+	}
+	source := fmt.Sprintf(SCHEMA_GO, input, os.Getenv("GOPACKAGE"), input, rawString(string(data)))
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(output, formatted, 0644); err != nil {
+		panic(err)
+	}
+}
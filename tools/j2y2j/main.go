@@ -17,11 +17,13 @@ package main
 
 import (
 	"fmt"
-	"github.com/googleapis/gnostic/jsonschema"
-	"gopkg.in/yaml.v2"
 	"io/ioutil"
 	"os"
 	"path"
+
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/googleapis/gnostic/jsonschema"
+	"gopkg.in/yaml.v3"
 )
 
 func usage() {
@@ -41,18 +43,15 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	var info yaml.MapSlice
+	var info yaml.Node
 	err = yaml.Unmarshal(file, &info)
 
 	switch os.Args[2] {
 	case "--json":
-		result := jsonschema.Render(info)
+		result := jsonschema.Render(&info)
 		fmt.Printf("%s", result)
 	case "--yaml":
-		result, err := yaml.Marshal(info)
-		if err != nil {
-			panic(err)
-		}
+		result := compiler.Marshal(&info)
 		fmt.Printf("%s", string(result))
 	default:
 		usage()
@@ -0,0 +1,328 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform changes compiled OpenAPI descriptions with pipelines of
+// steps read from YAML, so that common changes can be made without writing
+// programs that edit models.
+//
+//	transforms:
+//	- rename-schema: {from: Pet, to: Animal}
+//	- add-server: {url: "https://api.example.com", description: Production}
+//	- set: {path: "$.info", key: version, value: "2.0"}
+//	- delete: {path: "$.paths.*[?(@.deprecated == true)]"}
+//	- rewrite-refs: [{from: "https://schemas.example.com/*", to: "vendor/schemas/*"}]
+//
+// Steps are applied in order. Paths are expressions of package query.
+package transform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	gnostic "github.com/googleapis/gnostic/lib"
+	"github.com/googleapis/gnostic/query"
+	"gopkg.in/yaml.v3"
+)
+
+// A Pipeline is a list of steps that are applied to a document in turn.
+type Pipeline struct {
+	Steps []*Step `yaml:"transforms"`
+}
+
+// A Step is one change to a document. Exactly one of its fields is set.
+type Step struct {
+	RenameSchema *RenameSchema `yaml:"rename-schema,omitempty"`
+	AddServer    *AddServer    `yaml:"add-server,omitempty"`
+	Set          *Set          `yaml:"set,omitempty"`
+	Delete       *Delete       `yaml:"delete,omitempty"`
+	RewriteRefs  []*RewriteRef `yaml:"rewrite-refs,omitempty"`
+}
+
+// RenameSchema renames a schema and the references to it.
+type RenameSchema struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// AddServer adds a server to an OpenAPI 3 document. The hosts, base paths,
+// and schemes of OpenAPI 2 documents are set from the server's URL instead,
+// since they can only describe one server.
+type AddServer struct {
+	URL         string `yaml:"url"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// Set replaces the values selected by a path. If Key is set, the key is
+// set to the value in each of the selected maps, where it is added if it
+// doesn't already exist.
+type Set struct {
+	Path  string    `yaml:"path"`
+	Key   string    `yaml:"key,omitempty"`
+	Value yaml.Node `yaml:"value"`
+}
+
+// Delete removes the values selected by a path from their maps and sequences.
+type Delete struct {
+	Path string `yaml:"path"`
+}
+
+// RewriteRef is a rule that changes references. Each reference is changed by
+// the first rule of a rewrite-refs step that applies to it, as described by
+// compiler.ReferenceRule.
+type RewriteRef struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// ReadPipeline reads a pipeline from YAML. Unknown fields are errors.
+func ReadPipeline(data []byte) (*Pipeline, error) {
+	pipeline := &Pipeline{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(pipeline); err != nil {
+		return nil, err
+	}
+	for i, step := range pipeline.Steps {
+		if err := step.validate(); err != nil {
+			return nil, errors.New(fmt.Sprintf("transform %d: %s", i+1, err.Error()))
+		}
+	}
+	return pipeline, nil
+}
+
+// Returns the name of a step's kind.
+func (step *Step) name() string {
+	names := make([]string, 0)
+	if step.RenameSchema != nil {
+		names = append(names, "rename-schema")
+	}
+	if step.AddServer != nil {
+		names = append(names, "add-server")
+	}
+	if step.Set != nil {
+		names = append(names, "set")
+	}
+	if step.Delete != nil {
+		names = append(names, "delete")
+	}
+	if step.RewriteRefs != nil {
+		names = append(names, "rewrite-refs")
+	}
+	return strings.Join(names, ", ")
+}
+
+// Checks that a step is complete, so that mistakes are found before any steps are applied.
+func (step *Step) validate() error {
+	name := step.name()
+	if name == "" {
+		return errors.New("no transform is specified")
+	}
+	if strings.Contains(name, ",") {
+		return errors.New(fmt.Sprintf("only one transform can be specified, found %s", name))
+	}
+	var paths []string
+	switch {
+	case step.RenameSchema != nil:
+		if step.RenameSchema.From == "" || step.RenameSchema.To == "" {
+			return errors.New("rename-schema requires from and to")
+		}
+	case step.AddServer != nil:
+		if step.AddServer.URL == "" {
+			return errors.New("add-server requires a url")
+		}
+	case step.Set != nil:
+		if step.Set.Value.Kind == 0 {
+			return errors.New("set requires a value")
+		}
+		paths = append(paths, step.Set.Path)
+	case step.Delete != nil:
+		paths = append(paths, step.Delete.Path)
+	case step.RewriteRefs != nil:
+		if _, err := step.referenceRules(); err != nil {
+			return err
+		}
+	}
+	for _, path := range paths {
+		if path == "" {
+			return errors.New(fmt.Sprintf("%s requires a path", name))
+		}
+		if _, err := query.Compile(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply applies the steps of a pipeline to a document and returns the
+// changed document. Documents are changed in place when possible, and
+// steps that edit values recompile the document from its edited form.
+// References are unchanged unless a step renames their targets or rewrites them.
+func (pipeline *Pipeline) Apply(document *gnostic.Document) (*gnostic.Document, error) {
+	var err error
+	for i, step := range pipeline.Steps {
+		document, err = step.apply(document)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("transform %d (%s): %s", i+1, step.name(), err.Error()))
+		}
+	}
+	return document, nil
+}
+
+func (step *Step) apply(document *gnostic.Document) (*gnostic.Document, error) {
+	switch {
+	case step.RenameSchema != nil:
+		return document, document.RenameSchema(step.RenameSchema.From, step.RenameSchema.To)
+	case step.AddServer != nil:
+		return document, addServer(document, step.AddServer)
+	case step.Set != nil:
+		return edit(document, step.Set.Path, func(match *query.Match) {
+			set(match, step.Set)
+		})
+	case step.Delete != nil:
+		return edit(document, step.Delete.Path, remove)
+	case step.RewriteRefs != nil:
+		rules, err := step.referenceRules()
+		if err != nil {
+			return nil, err
+		}
+		compiler.RewriteReferences(document.Message(), rules)
+		return document, nil
+	}
+	return nil, errors.New("no transform is specified")
+}
+
+// Returns the rules of a rewrite-refs step.
+func (step *Step) referenceRules() ([]*compiler.ReferenceRule, error) {
+	if len(step.RewriteRefs) == 0 {
+		return nil, errors.New("rewrite-refs requires at least one rule")
+	}
+	rules := make([]*compiler.ReferenceRule, 0, len(step.RewriteRefs))
+	for _, r := range step.RewriteRefs {
+		rule, err := compiler.NewReferenceRule(r.From, r.To)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func addServer(document *gnostic.Document, server *AddServer) error {
+	switch document.Version {
+	case gnostic.OpenAPIv2:
+		u, err := url.Parse(server.URL)
+		if err != nil || u.Host == "" {
+			return errors.New(fmt.Sprintf("invalid server URL %s", server.URL))
+		}
+		document.V2.Host = u.Host
+		document.V2.BasePath = u.Path
+		if u.Scheme != "" {
+			document.V2.Schemes = []string{u.Scheme}
+		}
+	case gnostic.OpenAPIv3:
+		document.V3.Servers = append(document.V3.Servers, &openapi_v3.Server{Url: server.URL, Description: server.Description})
+	default:
+		return errors.New("document has no model")
+	}
+	return nil
+}
+
+// Applies a change to each of the values of a document that are selected
+// by a path, and compiles the changed document.
+func edit(document *gnostic.Document, path string, change func(match *query.Match)) (*gnostic.Document, error) {
+	info, err := document.RawInfo()
+	if err != nil {
+		return nil, err
+	}
+	matches := query.MustCompile(path).Evaluate(info)
+	if len(matches) == 0 {
+		return document, nil
+	}
+	// values are changed in reverse order so that removing items
+	// doesn't change the indices of the items that precede them
+	for i := len(matches) - 1; i >= 0; i-- {
+		change(matches[i])
+	}
+	result := &gnostic.Document{Version: document.Version, Source: info}
+	context := compiler.NewContext("$root", nil)
+	switch document.Version {
+	case gnostic.OpenAPIv2:
+		result.V2, err = openapi_v2.NewDocument(info, context)
+	case gnostic.OpenAPIv3:
+		result.V3, err = openapi_v3.NewDocument(info, context)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Returns a deep copy of a node, so that values set in several places don't share nodes.
+func copyNode(node *yaml.Node) *yaml.Node {
+	copied := *node
+	if node.Content != nil {
+		copied.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			copied.Content[i] = copyNode(child)
+		}
+	}
+	return &copied
+}
+
+func set(match *query.Match, s *Set) {
+	value := copyNode(&s.Value)
+	if s.Key == "" {
+		if match.Parent != nil {
+			*match.Node = *value
+		}
+		return
+	}
+	node := match.Node
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key, _ := compiler.KeyForNode(node.Content[i]); key == s.Key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, compiler.NewScalarNodeForString(s.Key), value)
+}
+
+func remove(match *query.Match) {
+	parent := match.Parent
+	if parent == nil {
+		return // the root can't be removed
+	}
+	for i, child := range parent.Content {
+		if child != match.Node {
+			continue
+		}
+		if parent.Kind == yaml.MappingNode {
+			// keys are selected with ~; both their keys and values are removed
+			i -= i % 2
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+		} else {
+			parent.Content = append(parent.Content[:i], parent.Content[i+1:]...)
+		}
+		return
+	}
+}
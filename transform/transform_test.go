@@ -0,0 +1,170 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	gnostic "github.com/googleapis/gnostic/lib"
+)
+
+const v3Description = `
+openapi: 3.0.0
+info:
+  title: Pets
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+    delete:
+      operationId: deletePets
+      deprecated: true
+      responses:
+        "204":
+          description: deleted
+components:
+  schemas:
+    Pet:
+      type: object
+`
+
+func apply(t *testing.T, description string, transforms string) *gnostic.Document {
+	pipeline, err := ReadPipeline([]byte(transforms))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := gnostic.ReadDocumentFromBytes([]byte(description))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err = pipeline.Apply(document)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return document
+}
+
+func TestApplyV3(t *testing.T) {
+	document := apply(t, v3Description, `
+transforms:
+- rename-schema: {from: Pet, to: Animal}
+- add-server: {url: "https://api.example.com/v1", description: Production}
+- set: {path: "$.info", key: version, value: "2.0"}
+- set: {path: "$.info.title", value: Animals}
+- set: {path: "$.paths.*.*", key: x-owner, value: pets}
+- delete: {path: "$.paths.*[?(@.deprecated == true)]"}
+- set: {path: "$.paths['/pets']~", value: /animals}
+`)
+	v3 := document.V3
+	if v3.Info.Version != "2.0" || v3.Info.Title != "Animals" {
+		t.Errorf("unexpected info %+v", v3.Info)
+	}
+	if len(v3.Servers) != 1 || v3.Servers[0].Url != "https://api.example.com/v1" {
+		t.Errorf("unexpected servers %+v", v3.Servers)
+	}
+	if v3.Components.Schemas.Get("Animal") == nil || v3.Components.Schemas.Get("Pet") != nil {
+		t.Errorf("unexpected schemas %+v", v3.Components.Schemas)
+	}
+	item := v3.Paths.Get("/animals")
+	if item == nil || item.Get == nil || item.Delete != nil || v3.Paths.Get("/pets") != nil {
+		t.Fatalf("unexpected paths %+v", v3.Paths)
+	}
+	schema := item.Get.Responses.ResponseCode[0].Value.GetResponse().Content.MediaType[0].Value.Schema
+	if schema.GetReference().XRef != "#/components/schemas/Animal" {
+		t.Errorf("unexpected reference %+v", schema)
+	}
+	if len(item.Get.SpecificationExtension) != 1 || item.Get.SpecificationExtension[0].Value.GetString_() != "pets" {
+		t.Errorf("unexpected extensions %+v", item.Get.SpecificationExtension)
+	}
+}
+
+func TestApplyV2(t *testing.T) {
+	document := apply(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+`, `
+transforms:
+- add-server: {url: "https://api.example.com/v1"}
+`)
+	if document.V2.Host != "api.example.com" || document.V2.BasePath != "/v1" || document.V2.Schemes[0] != "https" {
+		t.Errorf("unexpected document %+v", document.V2)
+	}
+}
+
+func TestRewriteRefs(t *testing.T) {
+	// the references aren't resolved, since their targets don't exist
+	info, err := compiler.ReadInfoFromBytes("", []byte(`
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+definitions:
+  Pet: {$ref: "https://schemas.example.com/pet.yaml#/Pet"}
+  Owner: {$ref: "https://schemas.example.com/people/owner.yaml"}
+  Tag: {$ref: "common/tag.yaml"}
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	v2, err := openapi_v2.NewDocument(info, nil)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	pipeline, err := ReadPipeline([]byte(`
+transforms:
+- rewrite-refs:
+  - {from: "https://schemas.example.com/people/*", to: "people/*"}
+  - {from: "https://schemas.example.com/*", to: "vendor/*"}
+  - {from: common, to: "https://schemas.example.com/common"}
+`))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document, err := pipeline.Apply(&gnostic.Document{Version: gnostic.OpenAPIv2, V2: v2, Source: info})
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for name, expected := range map[string]string{
+		"Pet":   "vendor/pet.yaml#/Pet",
+		"Owner": "people/owner.yaml",
+		"Tag":   "https://schemas.example.com/common/tag.yaml",
+	} {
+		if ref := document.V2.Definitions.Get(name).XRef; ref != expected {
+			t.Errorf("unexpected reference for %s: %s", name, ref)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for transforms, message := range map[string]string{
+		"transforms:\n- {}":                                            "transform 1: no transform is specified",
+		"transforms:\n- unknown: {}":                                   "field unknown not found",
+		"transforms:\n- delete: {path: '$['}":                          "invalid query",
+		"transforms:\n- set: {path: $.info}":                           "set requires a value",
+		"transforms:\n- rename-schema: {from: A}\n  delete: {path: $}": "only one transform can be specified",
+		"transforms:\n- rewrite-refs: []":                              "rewrite-refs requires at least one rule",
+		"transforms:\n- rewrite-refs: [{from: a, to: 'b/*'}]":          "the * in b/* must match a * in a",
+	} {
+		if _, err := ReadPipeline([]byte(transforms)); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("expected %q for %q, found %v", message, transforms, err)
+		}
+	}
+	pipeline, _ := ReadPipeline([]byte("transforms:\n- set: {path: $.info.title, value: [1]}"))
+	document, _ := gnostic.ReadDocumentFromBytes([]byte(v3Description))
+	if _, err := pipeline.Apply(document); err == nil || !strings.Contains(err.Error(), "transform 1 (set)") {
+		t.Errorf("expected an error compiling an invalid change, found %v", err)
+	}
+	pipeline, _ = ReadPipeline([]byte("transforms:\n- rename-schema: {from: Missing, to: Other}"))
+	if _, err := pipeline.Apply(document); err == nil || !strings.Contains(err.Error(), "schema Missing not found") {
+		t.Errorf("expected an error renaming a missing schema, found %v", err)
+	}
+}
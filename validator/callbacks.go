@@ -0,0 +1,104 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// Runtime expressions, such as $request.body#/callbackUrl, which name values
+// of the requests and responses of operations.
+var runtimeExpression = regexp.MustCompile("^\\$(url|method|statusCode|" +
+	"(request|response)\\.(header\\.[-!#$%&'*+.^_`|~0-9A-Za-z]+|query\\.[^{}]+|path\\.[^{}]+|body(#(/([^/~{}]|~[01])*)*)?))$")
+
+// Checks the key of a callback, which is a runtime expression or a URL
+// that contains runtime expressions in braces.
+func (v *validator) checkCallbackExpression(path []string, key string) {
+	if strings.HasPrefix(key, "$") {
+		if !runtimeExpression.MatchString(key) {
+			v.error(path, fmt.Sprintf("callback expression %s is not a valid runtime expression", key))
+		}
+		return
+	}
+	expressions := 0
+	for rest := key; rest != ""; {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			break
+		}
+		end := strings.Index(rest[open:], "}")
+		if rest[open] == '}' || end < 0 {
+			v.error(path, fmt.Sprintf("callback expression %s has unmatched braces", key))
+			return
+		}
+		expression := rest[open+1 : open+end]
+		if !runtimeExpression.MatchString(expression) {
+			v.error(path, fmt.Sprintf("callback expression %s contains %s, which is not a valid runtime expression", key, expression))
+		}
+		expressions++
+		rest = rest[open+end+1:]
+	}
+	if expressions == 0 {
+		v.warning(path, fmt.Sprintf("callback expression %s doesn't contain a runtime expression", key))
+	}
+}
+
+// Checks the callbacks of a map, and the callbacks of their operations.
+func (v *validator) checkCallbacks(path []string, callbacks *openapi_v3.Callbacks) {
+	for _, pair := range callbacks.GetName() {
+		for _, expression := range pair.Value.GetCallback().GetExpression() {
+			expressionPath := join(path, pair.Name, expression.Name)
+			v.checkCallbackExpression(expressionPath, expression.Name)
+			if expression.Value.GetXRef() != "" {
+				continue
+			}
+			operations := pathItemOperationsV3(expressionPath, expression.Value)
+			if len(operations) == 0 {
+				v.warning(expressionPath, fmt.Sprintf("callback %s has no operations", pair.Name))
+			}
+			v.checkCallbackParameters(join(expressionPath, "parameters"), expression.Value.GetParameters())
+			for _, operation := range operations {
+				v.checkCallbackParameters(join(operation.path, "parameters"), operation.operation.Parameters)
+				v.checkCallbacks(join(operation.path, "callbacks"), operation.operation.Callbacks)
+			}
+		}
+	}
+}
+
+// Warns about the path parameters of callback requests, since the URLs of
+// callbacks are runtime expressions instead of path templates.
+func (v *validator) checkCallbackParameters(path []string, parameters []*openapi_v3.ParameterOrReference) {
+	for i, parameter := range parameters {
+		if p := parameter.GetParameter(); p != nil && p.In == "path" {
+			v.warning(join(path, strconv.Itoa(i)), fmt.Sprintf("callback parameter %s is a path parameter, but callback URLs aren't path templates", p.Name))
+		}
+	}
+}
+
+// Callbacks were introduced in OpenAPI 3.
+func checkCallbacksV2(v *validator, document *openapi_v2.Document) {}
+
+func checkCallbacksV3(v *validator, document *openapi_v3.Document) {
+	for _, operation := range operationsV3(document) {
+		v.checkCallbacks(join(operation.path, "callbacks"), operation.operation.Callbacks)
+	}
+	v.checkCallbacks([]string{"components", "callbacks"}, document.GetComponents().GetCallbacks())
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// A graph of the named schemas of a document, with edges from each schema to
+// the named schemas that it is composed of with allOf, oneOf, or anyOf. Edges
+// are not followed through properties or items, which are object boundaries
+// that generated types can refer to, so only cycles of compositions that can
+// never be expanded are found.
+type compositionGraph struct {
+	path  []string            // the names of the keys that lead to the named schemas
+	names []string            // the names of the schemas in the order that they are declared
+	edges map[string][]string // the schemas that each schema is composed of
+}
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+// Reports the cycles in a composition graph at the first schema
+// of each cycle, in the order that the schemas are declared.
+func (v *validator) checkCompositions(graph *compositionGraph) {
+	order := make(map[string]int)
+	for i, name := range graph.names {
+		order[name] = i
+	}
+	states := make(map[string]int)
+	stack := make([]string, 0)
+	reported := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		states[name] = visiting
+		stack = append(stack, name)
+		for _, next := range graph.edges[name] {
+			if _, ok := order[next]; !ok {
+				continue
+			}
+			switch states[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				for i := range stack {
+					if stack[i] == next {
+						v.reportCycle(graph.path, order, stack[i:], reported)
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		states[name] = visited
+	}
+	for _, name := range graph.names {
+		if states[name] == unvisited {
+			visit(name)
+		}
+	}
+}
+
+// Reports a cycle, starting from the schema in it that is declared first.
+func (v *validator) reportCycle(path []string, order map[string]int, cycle []string, reported map[string]bool) {
+	first := 0
+	for i, name := range cycle {
+		if order[name] < order[cycle[first]] {
+			first = i
+		}
+	}
+	names := append(append([]string{}, cycle[first:]...), cycle[:first]...)
+	key := strings.Join(names, " ")
+	if reported[key] {
+		return
+	}
+	reported[key] = true
+	v.error(join(path, names[0]), fmt.Sprintf("schema %s is composed of itself: %s", names[0], strings.Join(append(names, names[0]), " -> ")))
+}
+
+// Returns the name of a schema that a reference refers to in a list of named schemas,
+// or an empty string if it refers to something else.
+func composedName(ref string, prefix string) string {
+	if strings.HasPrefix(ref, prefix) {
+		return strings.TrimPrefix(ref, prefix)
+	}
+	return ""
+}
+
+// Returns the named schemas that a v2 schema is composed of.
+func compositionsV2(schema *openapi_v2.Schema) []string {
+	names := make([]string, 0)
+	for _, s := range schema.GetAllOf() {
+		if name := composedName(s.XRef, "#/definitions/"); name != "" {
+			names = append(names, name)
+		} else if s.XRef == "" {
+			names = append(names, compositionsV2(s)...)
+		}
+	}
+	return names
+}
+
+// Returns the named schemas that a v3 schema is composed of.
+func compositionsV3(schema *openapi_v3.Schema) []string {
+	names := make([]string, 0)
+	for _, list := range [][]*openapi_v3.SchemaOrReference{schema.GetAllOf(), schema.GetOneOf(), schema.GetAnyOf()} {
+		for _, s := range list {
+			if ref := s.GetReference(); ref != nil {
+				if name := composedName(ref.XRef, "#/components/schemas/"); name != "" {
+					names = append(names, name)
+				}
+			} else {
+				names = append(names, compositionsV3(s.GetSchema())...)
+			}
+		}
+	}
+	return names
+}
+
+func checkCompositionsV2(v *validator, document *openapi_v2.Document) {
+	graph := &compositionGraph{path: []string{"definitions"}, edges: make(map[string][]string)}
+	for _, pair := range document.GetDefinitions().GetAdditionalProperties() {
+		graph.names = append(graph.names, pair.Name)
+		// a definition that is a reference is composed of the schema that it refers to
+		graph.edges[pair.Name] = compositionsV2(&openapi_v2.Schema{AllOf: []*openapi_v2.Schema{pair.Value}})
+	}
+	v.checkCompositions(graph)
+}
+
+func checkCompositionsV3(v *validator, document *openapi_v3.Document) {
+	graph := &compositionGraph{path: []string{"components", "schemas"}, edges: make(map[string][]string)}
+	for _, pair := range document.GetComponents().GetSchemas().GetAdditionalProperties() {
+		graph.names = append(graph.names, pair.Name)
+		graph.edges[pair.Name] = compositionsV3(pair.Value)
+	}
+	v.checkCompositions(graph)
+}
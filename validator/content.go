@@ -0,0 +1,100 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"strconv"
+
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// A contentVisitor visits the parameters, headers, and media types of a v3
+// document with the names of the keys that lead to them. Visits of values
+// that a visitor doesn't have a function for are skipped.
+type contentVisitor struct {
+	parameter func(path []string, parameter *openapi_v3.Parameter)
+	header    func(path []string, header *openapi_v3.Header)
+	mediaType func(path []string, name string, mediaType *openapi_v3.MediaType)
+}
+
+func (c *contentVisitor) visitContent(path []string, content *openapi_v3.Content) {
+	for _, pair := range content.GetMediaType() {
+		if c.mediaType != nil {
+			c.mediaType(join(path, "content", pair.Name), pair.Name, pair.Value)
+		}
+	}
+}
+
+func (c *contentVisitor) visitParameter(path []string, parameter *openapi_v3.Parameter) {
+	if parameter == nil {
+		return
+	}
+	if c.parameter != nil {
+		c.parameter(path, parameter)
+	}
+	c.visitContent(path, parameter.Content)
+}
+
+func (c *contentVisitor) visitParameters(path []string, parameters []*openapi_v3.ParameterOrReference) {
+	for i, parameter := range parameters {
+		c.visitParameter(join(path, strconv.Itoa(i)), parameter.GetParameter())
+	}
+}
+
+func (c *contentVisitor) visitHeaders(path []string, headers *openapi_v3.Headers) {
+	for _, pair := range headers.GetName() {
+		header := pair.Value.GetHeader()
+		if header == nil {
+			continue
+		}
+		if c.header != nil {
+			c.header(join(path, pair.Name), header)
+		}
+		c.visitContent(join(path, pair.Name), header.Content)
+	}
+}
+
+func (c *contentVisitor) visitResponse(path []string, response *openapi_v3.Response) {
+	c.visitContent(path, response.GetContent())
+	c.visitHeaders(join(path, "headers"), response.GetHeaders())
+}
+
+// Visits the values of a document in the order that they are described,
+// followed by its components.
+func (c *contentVisitor) visitDocument(document *openapi_v3.Document) {
+	for _, pair := range document.GetPaths().GetPath() {
+		c.visitParameters([]string{"paths", pair.Name, "parameters"}, pair.Value.GetParameters())
+	}
+	for _, operation := range operationsV3(document) {
+		c.visitParameters(join(operation.path, "parameters"), operation.operation.Parameters)
+		c.visitContent(join(operation.path, "requestBody"), operation.operation.GetRequestBody().GetRequestBody().GetContent())
+		responses := operation.operation.GetResponses()
+		c.visitResponse(join(operation.path, "responses", "default"), responses.GetDefault().GetResponse())
+		for _, pair := range responses.GetResponseCode() {
+			c.visitResponse(join(operation.path, "responses", pair.Name), pair.Value.GetResponse())
+		}
+	}
+	components := document.GetComponents()
+	for _, pair := range components.GetResponses().GetResponseCode() {
+		c.visitResponse([]string{"components", "responses", pair.Name}, pair.Value.GetResponse())
+	}
+	for _, pair := range components.GetParameters().GetAdditionalProperties() {
+		c.visitParameter([]string{"components", "parameters", pair.Name}, pair.Value)
+	}
+	for _, pair := range components.GetRequestBodies().GetAdditionalProperties() {
+		c.visitContent([]string{"components", "requestBodies", pair.Name}, pair.Value.GetContent())
+	}
+	c.visitHeaders([]string{"components", "headers"}, components.GetHeaders())
+}
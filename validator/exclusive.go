@@ -0,0 +1,67 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// Checks that a value doesn't have both example and examples. The model
+// only keeps the examples that are written as lists, so examples are only
+// known to be missing when the list is nil.
+func (v *validator) checkExamples(path []string, kind string, example *openapi_v3.ExampleOrReference, examples []*openapi_v3.ExampleOrReference) {
+	if example != nil && examples != nil {
+		v.error(path, fmt.Sprintf("%s has both example and examples", kind))
+	}
+}
+
+// Checks that a parameter or header has either a schema or content with
+// one media type.
+func (v *validator) checkSchemaOrContent(path []string, kind string, schema *openapi_v3.SchemaOrReference, content *openapi_v3.Content) {
+	switch {
+	case schema != nil && content != nil:
+		v.error(path, fmt.Sprintf("%s has both schema and content", kind))
+	case schema == nil && content == nil:
+		v.error(path, fmt.Sprintf("%s has neither schema nor content", kind))
+	case content != nil && len(content.MediaType) != 1:
+		v.error(join(path, "content"), fmt.Sprintf("content of %s has %d media types instead of one", kind, len(content.MediaType)))
+	}
+}
+
+// The fields of v2 documents that are mutually exclusive are described by
+// different types, such as body parameters and other parameters.
+func checkExclusiveFieldsV2(v *validator, document *openapi_v2.Document) {}
+
+func checkExclusiveFieldsV3(v *validator, document *openapi_v3.Document) {
+	visitor := &contentVisitor{
+		parameter: func(path []string, parameter *openapi_v3.Parameter) {
+			kind := "parameter " + parameter.Name
+			v.checkExamples(path, kind, parameter.Example, parameter.Examples)
+			v.checkSchemaOrContent(path, kind, parameter.Schema, parameter.Content)
+		},
+		header: func(path []string, header *openapi_v3.Header) {
+			kind := "header " + path[len(path)-1]
+			v.checkExamples(path, kind, header.Example, header.Examples)
+			v.checkSchemaOrContent(path, kind, header.Schema, header.Content)
+		},
+		mediaType: func(path []string, name string, mediaType *openapi_v3.MediaType) {
+			v.checkExamples(path, "media type "+name, mediaType.Example, mediaType.Examples)
+		},
+	}
+	visitor.visitDocument(document)
+}
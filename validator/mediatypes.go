@@ -0,0 +1,150 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// The top-level types of registered media types, and * for ranges.
+var mediaTypeTypes = map[string]bool{
+	"application": true, "audio": true, "example": true, "font": true, "image": true,
+	"message": true, "model": true, "multipart": true, "text": true, "video": true, "*": true,
+}
+
+// The registered structured syntax suffixes, such as +json in application/hal+json.
+var mediaTypeSuffixes = map[string]bool{
+	"json": true, "xml": true, "yaml": true, "zip": true, "gzip": true, "cbor": true, "ber": true,
+	"der": true, "fastinfoset": true, "wbxml": true, "json-seq": true, "jwt": true, "sqlite3": true,
+	"cbor-seq": true, "zstd": true,
+}
+
+// Media types that are commonly used in API descriptions. Media types that are
+// almost the same as one of these are probably misspelled.
+var commonMediaTypes = []string{
+	"application/json", "application/xml", "application/yaml", "application/x-yaml",
+	"application/x-www-form-urlencoded", "application/octet-stream", "application/pdf",
+	"application/zip", "application/gzip", "application/javascript", "application/x-ndjson",
+	"application/problem+json", "application/problem+xml", "application/merge-patch+json",
+	"application/json-patch+json", "application/hal+json", "application/ld+json",
+	"application/grpc", "application/protobuf", "application/x-protobuf",
+	"multipart/form-data", "multipart/mixed", "multipart/related",
+	"text/plain", "text/html", "text/csv", "text/xml", "text/css", "text/markdown", "text/event-stream",
+	"image/png", "image/jpeg", "image/gif", "image/webp", "image/tiff", "image/bmp", "image/svg+xml",
+	"audio/mpeg", "audio/ogg", "video/mp4", "video/mpeg",
+}
+
+// Checks the syntax of a media type, which may have parameters, and warns
+// about media types that are probably misspelled.
+func (v *validator) checkMediaType(path []string, mediaType string) {
+	name, _, err := mime.ParseMediaType(mediaType)
+	parts := strings.Split(name, "/")
+	if err != nil || len(parts) != 2 || parts[1] == "" || (parts[0] == "*" && parts[1] != "*") {
+		v.error(path, fmt.Sprintf("%q is not a valid media type", mediaType))
+		return
+	}
+	if !mediaTypeTypes[parts[0]] && !strings.HasPrefix(parts[0], "x-") {
+		v.warning(path, fmt.Sprintf("media type %s doesn't have a registered type", name))
+		return
+	}
+	if i := strings.LastIndex(parts[1], "+"); i >= 0 {
+		if suffix := parts[1][i+1:]; !mediaTypeSuffixes[suffix] {
+			v.warning(path, fmt.Sprintf("media type %s doesn't have a registered suffix", name))
+		}
+		return
+	}
+	if mistake := misspelledMediaType(name); mistake != "" {
+		v.warning(path, fmt.Sprintf("media type %s is probably a misspelling of %s", name, mistake))
+	}
+}
+
+// Returns the common media type that a media type is probably a
+// misspelling of, or an empty string if there is none.
+func misspelledMediaType(name string) string {
+	for _, common := range commonMediaTypes {
+		if name == common {
+			return ""
+		}
+	}
+	for _, common := range commonMediaTypes {
+		if strings.SplitN(common, "/", 2)[0] == strings.SplitN(name, "/", 2)[0] && editDistance(name, common) <= 2 {
+			return common
+		}
+	}
+	return ""
+}
+
+// Returns the number of characters that must be inserted, deleted, or
+// replaced to change one string into another.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
+
+// Checks the media types of a v2 consumes or produces list.
+func (v *validator) checkMediaTypeList(path []string, mediaTypes []string) {
+	for i, mediaType := range mediaTypes {
+		v.checkMediaType(join(path, strconv.Itoa(i)), mediaType)
+	}
+}
+
+func checkMediaTypesV2(v *validator, document *openapi_v2.Document) {
+	v.checkMediaTypeList([]string{"consumes"}, document.Consumes)
+	v.checkMediaTypeList([]string{"produces"}, document.Produces)
+	for _, operation := range operationsV2(document) {
+		v.checkMediaTypeList(join(operation.path, "consumes"), operation.operation.Consumes)
+		v.checkMediaTypeList(join(operation.path, "produces"), operation.operation.Produces)
+	}
+}
+
+func checkMediaTypesV3(v *validator, document *openapi_v3.Document) {
+	visitor := &contentVisitor{
+		mediaType: func(path []string, name string, mediaType *openapi_v3.MediaType) {
+			v.checkMediaType(path, name)
+		},
+	}
+	visitor.visitDocument(document)
+}
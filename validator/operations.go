@@ -0,0 +1,89 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// An operation of a v2 document, with the path item that contains it.
+type operationV2 struct {
+	path      []string // the names of the keys that lead to the operation
+	item      *openapi_v2.PathItem
+	operation *openapi_v2.Operation
+}
+
+// Returns the operations of a v2 document in the order that they are described.
+func operationsV2(document *openapi_v2.Document) []operationV2 {
+	operations := make([]operationV2, 0)
+	for _, pair := range document.GetPaths().GetPath() {
+		item := pair.Value
+		if item == nil {
+			continue
+		}
+		methods := []struct {
+			name      string
+			operation *openapi_v2.Operation
+		}{
+			{"get", item.Get}, {"put", item.Put}, {"post", item.Post}, {"delete", item.Delete},
+			{"options", item.Options}, {"head", item.Head}, {"patch", item.Patch},
+		}
+		for _, method := range methods {
+			if method.operation != nil {
+				path := []string{"paths", pair.Name, method.name}
+				operations = append(operations, operationV2{path: path, item: item, operation: method.operation})
+			}
+		}
+	}
+	return operations
+}
+
+// An operation of a v3 document, with the path item that contains it.
+type operationV3 struct {
+	path      []string // the names of the keys that lead to the operation
+	item      *openapi_v3.PathItem
+	operation *openapi_v3.Operation
+}
+
+// Returns the operations of a v3 document in the order that they are described.
+func operationsV3(document *openapi_v3.Document) []operationV3 {
+	operations := make([]operationV3, 0)
+	for _, pair := range document.GetPaths().GetPath() {
+		operations = append(operations, pathItemOperationsV3([]string{"paths", pair.Name}, pair.Value)...)
+	}
+	return operations
+}
+
+// Returns the operations of a v3 path item, given the names of the keys that lead to it.
+func pathItemOperationsV3(path []string, item *openapi_v3.PathItem) []operationV3 {
+	operations := make([]operationV3, 0)
+	if item == nil {
+		return operations
+	}
+	methods := []struct {
+		name      string
+		operation *openapi_v3.Operation
+	}{
+		{"get", item.Get}, {"put", item.Put}, {"post", item.Post}, {"delete", item.Delete},
+		{"options", item.Options}, {"head", item.Head}, {"patch", item.Patch}, {"trace", item.Trace},
+	}
+	for _, method := range methods {
+		if method.operation != nil {
+			operations = append(operations, operationV3{path: join(path, method.name), item: item, operation: method.operation})
+		}
+	}
+	return operations
+}
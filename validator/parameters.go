@@ -0,0 +1,222 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// The fields of path templates, such as {petId} in /pets/{petId}.
+var pathTemplateField = regexp.MustCompile(`{([^{}/]+)}`)
+
+// A parameter of either version, with the names of the keys that lead to its
+// declaration, which may be a reference to it.
+type parameter struct {
+	path     []string
+	name     string
+	in       string
+	required bool
+}
+
+// Returns the names of the fields of a path template.
+func pathTemplateFields(template string) map[string]bool {
+	fields := make(map[string]bool)
+	for _, match := range pathTemplateField.FindAllStringSubmatch(template, -1) {
+		fields[match[1]] = true
+	}
+	return fields
+}
+
+// Returns the parameters of a v2 list. Parameters that are referred to are
+// found in the document's parameters; references to other files are skipped,
+// since they are checked after the references are resolved.
+func parametersV2(document *openapi_v2.Document, items []*openapi_v2.ParametersItem, path []string) []*parameter {
+	parameters := make([]*parameter, 0)
+	for i, item := range items {
+		p := item.GetParameter()
+		if ref := item.GetJsonReference().GetXRef(); ref != "" {
+			p = nil
+			for _, pair := range document.GetParameters().GetAdditionalProperties() {
+				if ref == "#/parameters/"+pair.Name {
+					p = pair.Value
+				}
+			}
+		}
+		if p == nil {
+			continue
+		}
+		parameter := parameterV2(p)
+		if parameter == nil {
+			continue
+		}
+		parameter.path = join(path, strconv.Itoa(i))
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// Returns the name, location, and requirement of a v2 parameter, or nil if
+// it is neither a body parameter nor another kind of parameter.
+func parameterV2(p *openapi_v2.Parameter) *parameter {
+	parameter := &parameter{}
+	if body := p.GetBodyParameter(); body != nil {
+		parameter.name, parameter.in, parameter.required = body.Name, body.In, body.Required.GetValue()
+	} else if s := p.GetNonBodyParameter().GetHeaderParameterSubSchema(); s != nil {
+		parameter.name, parameter.in, parameter.required = s.Name, s.In, s.Required.GetValue()
+	} else if s := p.GetNonBodyParameter().GetFormDataParameterSubSchema(); s != nil {
+		parameter.name, parameter.in, parameter.required = s.Name, s.In, s.Required.GetValue()
+	} else if s := p.GetNonBodyParameter().GetQueryParameterSubSchema(); s != nil {
+		parameter.name, parameter.in, parameter.required = s.Name, s.In, s.Required.GetValue()
+	} else if s := p.GetNonBodyParameter().GetPathParameterSubSchema(); s != nil {
+		parameter.name, parameter.in, parameter.required = s.Name, s.In, s.Required.GetValue()
+	} else {
+		return nil
+	}
+	return parameter
+}
+
+// Returns the parameters of a v3 list, like parametersV2.
+func parametersV3(document *openapi_v3.Document, items []*openapi_v3.ParameterOrReference, path []string) []*parameter {
+	parameters := make([]*parameter, 0)
+	for i, item := range items {
+		p := item.GetParameter()
+		if ref := item.GetReference().GetXRef(); ref != "" {
+			p = nil
+			for _, pair := range document.GetComponents().GetParameters().GetAdditionalProperties() {
+				if ref == "#/components/parameters/"+pair.Name {
+					p = pair.Value
+				}
+			}
+		}
+		if p == nil {
+			continue
+		}
+		parameters = append(parameters, &parameter{path: join(path, strconv.Itoa(i)), name: p.Name, in: p.In, required: p.Required.GetValue()})
+	}
+	return parameters
+}
+
+// An operation and the parameters that it declares.
+type parameterOperation struct {
+	path       []string
+	parameters []*parameter
+}
+
+// Checks that the path parameters of a path item and its operations match the
+// fields of its path template and are required, and that no two parameters in
+// a list have the same name and location. Parameters of an operation override
+// the parameters of its path item that have the same name and location.
+func (v *validator) checkPathParameters(itemPath []string, shared []*parameter, operations []parameterOperation) {
+	fields := pathTemplateFields(itemPath[1])
+	v.checkParameterList(fields, shared)
+	if len(operations) == 0 {
+		v.checkPathFields(itemPath, fields, shared)
+		return
+	}
+	for _, operation := range operations {
+		v.checkParameterList(fields, operation.parameters)
+		v.checkPathFields(operation.path, fields, append(append([]*parameter{}, shared...), operation.parameters...))
+	}
+}
+
+// Checks the parameters of one list.
+func (v *validator) checkParameterList(fields map[string]bool, parameters []*parameter) {
+	seen := make(map[string]bool)
+	for _, p := range parameters {
+		key := p.in + " " + p.name
+		if seen[key] {
+			v.error(p.path, fmt.Sprintf("parameter %s in %s is declared more than once", p.name, p.in))
+		}
+		seen[key] = true
+		if p.in != "path" {
+			continue
+		}
+		if !fields[p.name] {
+			v.error(p.path, fmt.Sprintf("path parameter %s is not in the path template", p.name))
+		}
+		if !p.required {
+			v.error(p.path, fmt.Sprintf("path parameter %s is not required", p.name))
+		}
+	}
+}
+
+// Checks that each field of a path template is declared as a path parameter.
+func (v *validator) checkPathFields(path []string, fields map[string]bool, parameters []*parameter) {
+	declared := make(map[string]bool)
+	for _, p := range parameters {
+		if p.in == "path" {
+			declared[p.name] = true
+		}
+	}
+	for _, name := range sortedKeys(fields) {
+		if !declared[name] {
+			v.error(path, fmt.Sprintf("path template field %s is not declared as a path parameter", name))
+		}
+	}
+}
+
+// Returns the keys of a set in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func checkPathParametersV2(v *validator, document *openapi_v2.Document) {
+	operations := operationsV2(document)
+	for _, pair := range document.GetPaths().GetPath() {
+		if pair.Value == nil {
+			continue
+		}
+		path := []string{"paths", pair.Name}
+		shared := parametersV2(document, pair.Value.Parameters, join(path, "parameters"))
+		list := make([]parameterOperation, 0)
+		for _, operation := range operations {
+			if operation.item == pair.Value {
+				parameters := parametersV2(document, operation.operation.Parameters, join(operation.path, "parameters"))
+				list = append(list, parameterOperation{path: operation.path, parameters: parameters})
+			}
+		}
+		v.checkPathParameters(path, shared, list)
+	}
+}
+
+func checkPathParametersV3(v *validator, document *openapi_v3.Document) {
+	operations := operationsV3(document)
+	for _, pair := range document.GetPaths().GetPath() {
+		if pair.Value == nil {
+			continue
+		}
+		path := []string{"paths", pair.Name}
+		shared := parametersV3(document, pair.Value.Parameters, join(path, "parameters"))
+		list := make([]parameterOperation, 0)
+		for _, operation := range operations {
+			if operation.item == pair.Value {
+				parameters := parametersV3(document, operation.operation.Parameters, join(operation.path, "parameters"))
+				list = append(list, parameterOperation{path: operation.path, parameters: parameters})
+			}
+		}
+		v.checkPathParameters(path, shared, list)
+	}
+}
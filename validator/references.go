@@ -0,0 +1,300 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	"gopkg.in/yaml.v3"
+)
+
+// The kinds of the values that can contain references, and the kinds of the
+// values in their fields. Fields of the kind "map:KIND" are maps of values of
+// KIND, "list:KIND" are lists of them, and the field "*" is any field that
+// isn't an extension.
+type referenceGrammar map[string]map[string]string
+
+var referenceGrammarV2 = referenceGrammar{
+	"document": {
+		"paths": "paths", "definitions": "map:schema", "parameters": "map:parameter", "responses": "map:response",
+	},
+	"paths": {"*": "pathItem"},
+	"pathItem": {
+		"get": "operation", "put": "operation", "post": "operation", "delete": "operation",
+		"options": "operation", "head": "operation", "patch": "operation", "parameters": "list:parameter",
+	},
+	"operation": {"parameters": "list:parameter", "responses": "responses"},
+	"responses": {"*": "response"},
+	"response":  {"schema": "schema"},
+	"parameter": {"schema": "schema"},
+	"schema": {
+		"properties": "map:schema", "items": "schema", "additionalProperties": "schema", "allOf": "list:schema",
+	},
+}
+
+var referenceGrammarV3 = referenceGrammar{
+	"document": {"paths": "paths", "components": "components"},
+	"components": {
+		"schemas": "map:schema", "responses": "map:response", "parameters": "map:parameter",
+		"examples": "map:example", "requestBodies": "map:requestBody", "headers": "map:header",
+		"securitySchemes": "map:securityScheme", "links": "map:link", "callbacks": "map:callback",
+	},
+	"paths": {"*": "pathItem"},
+	"pathItem": {
+		"get": "operation", "put": "operation", "post": "operation", "delete": "operation",
+		"options": "operation", "head": "operation", "patch": "operation", "trace": "operation",
+		"parameters": "list:parameter",
+	},
+	"operation": {
+		"parameters": "list:parameter", "requestBody": "requestBody", "responses": "responses", "callbacks": "map:callback",
+	},
+	"responses":   {"*": "response"},
+	"response":    {"headers": "map:header", "content": "map:mediaType", "links": "map:link"},
+	"parameter":   {"schema": "schema", "content": "map:mediaType", "examples": "map:example"},
+	"header":      {"schema": "schema", "content": "map:mediaType", "examples": "map:example"},
+	"requestBody": {"content": "map:mediaType"},
+	"mediaType":   {"schema": "schema", "examples": "map:example", "encoding": "map:encoding"},
+	"encoding":    {"headers": "map:header"},
+	"callback":    {"*": "pathItem"},
+	"schema": {
+		"properties": "map:schema", "items": "schema", "additionalProperties": "schema",
+		"allOf": "list:schema", "oneOf": "list:schema", "anyOf": "list:schema", "not": "schema",
+	},
+}
+
+// Compilers of the kinds of values that references can refer to.
+type referenceTargets map[string]func(node *yaml.Node, context *compiler.Context) error
+
+var referenceTargetsV2 = referenceTargets{
+	"schema": func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v2.NewSchema(n, c); return err },
+	"parameter": func(n *yaml.Node, c *compiler.Context) error {
+		// the compiler doesn't require the names and locations of parameters
+		p, err := openapi_v2.NewParameter(n, c)
+		if err == nil {
+			if parameter := parameterV2(p); parameter == nil || parameter.name == "" || parameter.in == "" {
+				err = compiler.NewError(c, "is not a parameter")
+			}
+		}
+		return err
+	},
+	"response": func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v2.NewResponse(n, c); return err },
+	"pathItem": func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v2.NewPathItem(n, c); return err },
+}
+
+var referenceTargetsV3 = referenceTargets{
+	"schema":      func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewSchema(n, c); return err },
+	"parameter":   func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewParameter(n, c); return err },
+	"response":    func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewResponse(n, c); return err },
+	"requestBody": func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewRequestBody(n, c); return err },
+	"header":      func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewHeader(n, c); return err },
+	"example":     func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewExample(n, c); return err },
+	"link":        func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewLink(n, c); return err },
+	"callback":    func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewCallback(n, c); return err },
+	"securityScheme": func(n *yaml.Node, c *compiler.Context) error {
+		_, err := openapi_v3.NewSecurityScheme(n, c)
+		return err
+	},
+	"pathItem": func(n *yaml.Node, c *compiler.Context) error { _, err := openapi_v3.NewPathItem(n, c); return err },
+}
+
+// referenceChecker finds the references in the source of a document and in
+// the files that it refers to, and checks that their targets exist and are
+// values of the kinds that the references are used for.
+type referenceChecker struct {
+	v        *validator
+	grammar  referenceGrammar
+	targets  referenceTargets
+	filename string            // the file of the document
+	source   *yaml.Node        // the source of the document
+	context  *compiler.Context // the context that other files are read in
+	visited  map[string]bool
+}
+
+// Checks the values of a node, which is a value of a kind. Problems are
+// reported at path, which is the path of the value in the document or, for
+// values of other files, the path of the reference that led to them. File
+// is the file that contains the value, and location describes the value for
+// files other than the document.
+func (r *referenceChecker) walk(path []string, file string, location string, node *yaml.Node, kind string) {
+	m, ok := compiler.UnpackMap(node)
+	if !ok {
+		return
+	}
+	if ref := compiler.MapValueForKey(m, "$ref"); ref != nil && ref.Kind == yaml.ScalarNode {
+		r.checkReference(path, file, location, ref.Value, kind)
+		return
+	}
+	fields := r.grammar[kind]
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		key := m.Content[i].Value
+		field, ok := fields[key]
+		if !ok && !strings.HasPrefix(key, "x-") {
+			field, ok = fields["*"]
+		}
+		if !ok {
+			continue
+		}
+		value := m.Content[i+1]
+		switch {
+		case strings.HasPrefix(field, "map:"):
+			if values, ok := compiler.UnpackMap(value); ok {
+				for j := 0; j+1 < len(values.Content); j += 2 {
+					name := values.Content[j].Value
+					r.walk(r.childPath(path, file, key, name), file, location+"/"+escape(key)+"/"+escape(name), values.Content[j+1], field[4:])
+				}
+			}
+		case strings.HasPrefix(field, "list:"):
+			for j, item := range compiler.SequenceItems(value) {
+				r.walk(r.childPath(path, file, key, strconv.Itoa(j)), file, location+"/"+escape(key)+"/"+strconv.Itoa(j), item, field[5:])
+			}
+		default:
+			if items, ok := compiler.SequenceNodeForNode(value); ok {
+				// v2 items can be lists of schemas
+				for j, item := range items.Content {
+					r.walk(r.childPath(path, file, key, strconv.Itoa(j)), file, location+"/"+escape(key)+"/"+strconv.Itoa(j), item, field)
+				}
+			} else {
+				r.walk(r.childPath(path, file, key), file, location+"/"+escape(key), value, field)
+			}
+		}
+	}
+}
+
+// Returns the path of a value in a file. Values in other files than the
+// document are reported at the path of the reference that led to them.
+func (r *referenceChecker) childPath(path []string, file string, names ...string) []string {
+	if file != r.filename {
+		return path
+	}
+	return join(path, names...)
+}
+
+// Checks a reference that is used for a value of a kind, then checks the
+// target if it is in another file.
+func (r *referenceChecker) checkReference(path []string, file string, location string, ref string, kind string) {
+	compile, ok := r.targets[kind]
+	if !ok {
+		// the compiler reports references to other kinds of values
+		return
+	}
+	from := ""
+	if file != r.filename {
+		from = fmt.Sprintf(" in %s#%s", file, location)
+	}
+	targetFile := compiler.FileForRef(file, ref)
+	fragment := ""
+	if parts := strings.SplitN(ref, "#", 2); len(parts) == 2 {
+		fragment = parts[1]
+	}
+	var target *yaml.Node
+	if targetFile == r.filename && r.source != nil {
+		target = nodeForPointer(r.source, fragment)
+	} else if compiler.OptionsForContext(r.context).LocalReferencesOnly {
+		r.v.warning(path, fmt.Sprintf("reference to %s%s was not checked because references to other files are not resolved", ref, from))
+		return
+	} else if info, err := compiler.ReadInfoForRefInContext(file, ref, r.context); err == nil {
+		target = info
+	}
+	if target == nil {
+		r.v.error(path, fmt.Sprintf("reference to %s%s doesn't refer to an existing value", ref, from))
+		return
+	}
+	if err := compile(target, compiler.NewContext("$root", nil)); err != nil {
+		r.v.error(path, fmt.Sprintf("reference to %s%s refers to %s#%s (line %d), which is not a valid %s",
+			ref, from, targetFile, fragment, lineForNode(target), kindName(kind)))
+		return
+	}
+	key := targetFile + "#" + fragment + " " + kind
+	if targetFile == r.filename || r.visited[key] {
+		return
+	}
+	r.visited[key] = true
+	r.walk(path, targetFile, fragment, target, kind)
+}
+
+// Returns the node that a JSON pointer refers to, or nil if there is none.
+func nodeForPointer(root *yaml.Node, pointer string) *yaml.Node {
+	node := root
+	for _, segment := range strings.Split(pointer, "/")[1:] {
+		segment = strings.Replace(strings.Replace(segment, "~1", "/", -1), "~0", "~", -1)
+		if items, ok := compiler.SequenceNodeForNode(node); ok {
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(items.Content) {
+				return nil
+			}
+			node = items.Content[i]
+		} else if node = compiler.MapValueForKey(node, segment); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Returns the line of a node, or of the content of a document node.
+func lineForNode(node *yaml.Node) int {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	return node.Line
+}
+
+// Escapes a key for a JSON pointer.
+func escape(key string) string {
+	return strings.Replace(strings.Replace(key, "~", "~0", -1), "/", "~1", -1)
+}
+
+// Returns the name of a kind for messages, such as "request body" for requestBody.
+func kindName(kind string) string {
+	var name strings.Builder
+	for _, r := range kind {
+		if unicode.IsUpper(r) {
+			name.WriteRune(' ')
+		}
+		name.WriteRune(unicode.ToLower(r))
+	}
+	return name.String()
+}
+
+// Checks the references in the source of the document that is being validated.
+// References can only be found in documents that have sources.
+func (v *validator) checkReferences(grammar referenceGrammar, targets referenceTargets) {
+	if v.document.Source == nil {
+		return
+	}
+	r := &referenceChecker{
+		v:        v,
+		grammar:  grammar,
+		targets:  targets,
+		filename: v.document.Filename,
+		source:   v.document.Source,
+		context:  compiler.NewContextWithOptions("$root", v.options),
+		visited:  make(map[string]bool),
+	}
+	r.walk([]string{}, r.filename, "", r.source, "document")
+}
+
+func checkReferencesV2(v *validator, document *openapi_v2.Document) {
+	v.checkReferences(referenceGrammarV2, referenceTargetsV2)
+}
+
+func checkReferencesV3(v *validator, document *openapi_v3.Document) {
+	v.checkReferences(referenceGrammarV3, referenceTargetsV3)
+}
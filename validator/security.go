@@ -0,0 +1,165 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"gopkg.in/yaml.v3"
+)
+
+// A declared security scheme.
+type securityScheme struct {
+	path   []string
+	oauth  bool            // true if the scheme's requirements list scopes
+	scopes map[string]bool // the scopes of an OAuth scheme, or nil if any scope is allowed
+	used   bool
+}
+
+// A requirement of one scheme in a security requirement.
+type securityRequirement struct {
+	path   []string
+	name   string
+	scopes []string
+}
+
+// Checks that security requirements name declared schemes and scopes,
+// and warns about the schemes that aren't used, in the order that they
+// are declared.
+func (v *validator) checkSecurity(names []string, schemes map[string]*securityScheme, requirements []securityRequirement) {
+	for _, requirement := range requirements {
+		scheme, ok := schemes[requirement.name]
+		if !ok {
+			v.error(requirement.path, fmt.Sprintf("security requirement refers to %s, which is not a declared security scheme", requirement.name))
+			continue
+		}
+		scheme.used = true
+		if !scheme.oauth {
+			if len(requirement.scopes) > 0 {
+				v.error(requirement.path, fmt.Sprintf("security requirement lists scopes for %s, which doesn't use scopes", requirement.name))
+			}
+			continue
+		}
+		for _, scope := range requirement.scopes {
+			if scheme.scopes != nil && !scheme.scopes[scope] {
+				v.error(requirement.path, fmt.Sprintf("security requirement refers to scope %s, which is not a scope of %s", scope, requirement.name))
+			}
+		}
+	}
+	for _, name := range names {
+		if scheme := schemes[name]; !scheme.used {
+			v.warning(scheme.path, fmt.Sprintf("security scheme %s is not used", name))
+		}
+	}
+}
+
+// Returns the requirements of a list of v2 security requirements.
+func securityRequirementsV2(path []string, list []*openapi_v2.SecurityRequirement) []securityRequirement {
+	requirements := make([]securityRequirement, 0)
+	for i, requirement := range list {
+		for _, pair := range requirement.GetAdditionalProperties() {
+			requirements = append(requirements, securityRequirement{
+				path:   join(path, "security", fmt.Sprintf("%d", i), pair.Name),
+				name:   pair.Name,
+				scopes: pair.Value.GetValue(),
+			})
+		}
+	}
+	return requirements
+}
+
+func checkSecurityRequirementsV2(v *validator, document *openapi_v2.Document) {
+	names := make([]string, 0)
+	schemes := make(map[string]*securityScheme)
+	for _, pair := range document.GetSecurityDefinitions().GetAdditionalProperties() {
+		scheme := &securityScheme{path: []string{"securityDefinitions", pair.Name}}
+		var scopes *openapi_v2.Oauth2Scopes
+		switch {
+		case pair.Value.GetOauth2ImplicitSecurity() != nil:
+			scopes = pair.Value.GetOauth2ImplicitSecurity().Scopes
+		case pair.Value.GetOauth2PasswordSecurity() != nil:
+			scopes = pair.Value.GetOauth2PasswordSecurity().Scopes
+		case pair.Value.GetOauth2ApplicationSecurity() != nil:
+			scopes = pair.Value.GetOauth2ApplicationSecurity().Scopes
+		case pair.Value.GetOauth2AccessCodeSecurity() != nil:
+			scopes = pair.Value.GetOauth2AccessCodeSecurity().Scopes
+		}
+		if scopes != nil {
+			scheme.oauth = true
+			scheme.scopes = make(map[string]bool)
+			for _, scope := range scopes.GetAdditionalProperties() {
+				scheme.scopes[scope.Name] = true
+			}
+		}
+		names = append(names, pair.Name)
+		schemes[pair.Name] = scheme
+	}
+	requirements := securityRequirementsV2(nil, document.Security)
+	for _, o := range operationsV2(document) {
+		requirements = append(requirements, securityRequirementsV2(o.path, o.operation.Security)...)
+	}
+	v.checkSecurity(names, schemes, requirements)
+}
+
+// Returns the requirements of a list of v3 security requirements.
+// The scopes of each requirement are a YAML list.
+func securityRequirementsV3(path []string, list []*openapi_v3.SecurityRequirement) []securityRequirement {
+	requirements := make([]securityRequirement, 0)
+	for i, requirement := range list {
+		for _, pair := range requirement.GetName() {
+			var scopes []string
+			if yaml.Unmarshal([]byte(pair.Value.GetYaml()), &scopes) != nil {
+				scopes = nil
+			}
+			requirements = append(requirements, securityRequirement{
+				path:   join(path, "security", fmt.Sprintf("%d", i), pair.Name),
+				name:   pair.Name,
+				scopes: scopes,
+			})
+		}
+	}
+	return requirements
+}
+
+func checkSecurityRequirementsV3(v *validator, document *openapi_v3.Document) {
+	names := make([]string, 0)
+	schemes := make(map[string]*securityScheme)
+	for _, pair := range document.GetComponents().GetSecuritySchemes().GetAdditionalProperties() {
+		scheme := &securityScheme{path: []string{"components", "securitySchemes", pair.Name}}
+		switch pair.Value.GetType() {
+		case "oauth2":
+			scheme.oauth = true
+			scheme.scopes = make(map[string]bool)
+			flows := pair.Value.GetFlow()
+			for _, flow := range []*openapi_v3.OauthFlow{flows.GetImplicit(), flows.GetPassword(), flows.GetClientCredentials(), flows.GetAuthorizationCode()} {
+				for _, scope := range flow.GetScopes().GetName() {
+					scheme.scopes[scope.Name] = true
+				}
+			}
+		case "openIdConnect":
+			// the scopes of OpenID Connect schemes are described by their providers
+			scheme.oauth = true
+		}
+		names = append(names, pair.Name)
+		schemes[pair.Name] = scheme
+	}
+	requirements := securityRequirementsV3(nil, document.Security)
+	for _, o := range operationsV3(document) {
+		requirements = append(requirements, securityRequirementsV3(o.path, o.operation.Security)...)
+	}
+	v.checkSecurity(names, schemes, requirements)
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+)
+
+// The styles that parameters can be serialized with in each location.
+// The first style of each location is its default style.
+var parameterStyles = map[string][]string{
+	"path":   {"simple", "matrix", "label"},
+	"query":  {"form", "spaceDelimited", "pipeDelimited", "deepObject"},
+	"header": {"simple"},
+	"cookie": {"form"},
+}
+
+// Returns the type of a schema, following references to the schemas of
+// the document's components.
+func schemaTypeV3(document *openapi_v3.Document, schema *openapi_v3.SchemaOrReference) string {
+	if ref := schema.GetReference().GetXRef(); ref != "" {
+		for _, pair := range document.GetComponents().GetSchemas().GetAdditionalProperties() {
+			if ref == "#/components/schemas/"+pair.Name {
+				return pair.Value.GetType()
+			}
+		}
+		return ""
+	}
+	return schema.GetSchema().GetType()
+}
+
+// Checks the style and explode settings of a parameter or header. Explode is
+// true by default for the form style and false for the others.
+func (v *validator) checkSerialization(document *openapi_v3.Document, path []string, kind string, in string, style string, explodeValue *wrappers.BoolValue, schema *openapi_v3.SchemaOrReference) {
+	styles, ok := parameterStyles[in]
+	if !ok {
+		return
+	}
+	if style == "" {
+		style = styles[0]
+	} else if !containsString(styles, style) {
+		v.error(join(path, "style"), fmt.Sprintf("%s can't have style %s, which isn't allowed in %s", kind, style, in))
+		return
+	}
+	explode := style == "form"
+	if explodeValue != nil {
+		explode = explodeValue.Value
+	}
+	schemaType := schemaTypeV3(document, schema)
+	switch style {
+	case "deepObject":
+		if !explode {
+			v.warning(join(path, "style"), fmt.Sprintf("%s has style deepObject without explode, which isn't defined", kind))
+		}
+		if schemaType != "" && schemaType != "object" {
+			v.warning(join(path, "style"), fmt.Sprintf("%s has style deepObject, which is only defined for objects, but it is a %s", kind, schemaType))
+		}
+	case "spaceDelimited", "pipeDelimited":
+		if explode {
+			v.warning(join(path, "style"), fmt.Sprintf("%s has style %s with explode, which isn't defined", kind, style))
+		}
+		if schemaType != "" && schemaType != "array" && schemaType != "object" {
+			v.warning(join(path, "style"), fmt.Sprintf("%s has style %s, which is only defined for arrays and objects, but it is a %s", kind, style, schemaType))
+		}
+	}
+}
+
+// The serializations of v2 parameters are described by their collection
+// formats, which the compiler checks.
+func checkParameterSerializationV2(v *validator, document *openapi_v2.Document) {}
+
+func checkParameterSerializationV3(v *validator, document *openapi_v3.Document) {
+	visitor := &contentVisitor{
+		parameter: func(path []string, parameter *openapi_v3.Parameter) {
+			kind := "parameter " + parameter.Name
+			v.checkSerialization(document, path, kind, parameter.In, parameter.Style, parameter.Explode, parameter.Schema)
+		},
+		header: func(path []string, header *openapi_v3.Header) {
+			kind := "header " + path[len(path)-1]
+			v.checkSerialization(document, path, kind, "header", header.Style, header.Explode, header.Schema)
+		},
+	}
+	visitor.visitDocument(document)
+}
+
+// Returns true if a list contains a string.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+// Copyright 2017 Google Inc. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validator checks OpenAPI descriptions for violations of the rules
+// of the specification that the structure of gnostic's models doesn't
+// enforce, such as security requirements that name undeclared schemes.
+// It reports these as errors, and reports values that are allowed but are
+// probably mistakes, such as unused security schemes, as warnings.
+//
+//	document, err := gnostic.ReadDocument("petstore.yaml")
+//	...
+//	for _, problem := range validator.Validate(document) {
+//		fmt.Println(problem.Severity, problem.Path(), problem.Code, problem.Message)
+//	}
+package validator
+
+import (
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	gnostic "github.com/googleapis/gnostic/lib"
+)
+
+// A Check is a group of related rules of the specification. The problems
+// that it finds are reported with the check's name as their code.
+type Check struct {
+	Name        string
+	Description string
+	checkV2     func(v *validator, document *openapi_v2.Document)
+	checkV3     func(v *validator, document *openapi_v3.Document)
+}
+
+// Checks are the checks made by Validate, in the order that they are made.
+var Checks = []*Check{
+	{
+		Name:        "security-requirements",
+		Description: "Security requirements should name declared security schemes and their scopes, and declared schemes should be used.",
+		checkV2:     checkSecurityRequirementsV2,
+		checkV3:     checkSecurityRequirementsV3,
+	},
+	{
+		Name:        "path-parameters",
+		Description: "Path templates and path parameters should match, path parameters should be required, and parameters should be unique.",
+		checkV2:     checkPathParametersV2,
+		checkV3:     checkPathParametersV3,
+	},
+	{
+		Name:        "media-types",
+		Description: "Media types should be valid and should not be misspelled.",
+		checkV2:     checkMediaTypesV2,
+		checkV3:     checkMediaTypesV3,
+	},
+	{
+		Name:        "composition-cycles",
+		Description: "Schemas should not be composed of themselves with allOf, oneOf, or anyOf.",
+		checkV2:     checkCompositionsV2,
+		checkV3:     checkCompositionsV3,
+	},
+	{
+		Name:        "exclusive-fields",
+		Description: "Values should not have both example and examples, and parameters and headers should have either a schema or content.",
+		checkV2:     checkExclusiveFieldsV2,
+		checkV3:     checkExclusiveFieldsV3,
+	},
+	{
+		Name:        "references",
+		Description: "References should refer to existing values of the kinds that they are used for.",
+		checkV2:     checkReferencesV2,
+		checkV3:     checkReferencesV3,
+	},
+	{
+		Name:        "parameter-serialization",
+		Description: "Parameters and headers should have styles that are allowed in their locations and that clients support.",
+		checkV2:     checkParameterSerializationV2,
+		checkV3:     checkParameterSerializationV3,
+	},
+	{
+		Name:        "callbacks",
+		Description: "Callbacks should be named by valid runtime expressions and should describe operations.",
+		checkV2:     checkCallbacksV2,
+		checkV3:     checkCallbacksV3,
+	},
+}
+
+// Validate checks a compiled document with all of the checks and returns the
+// problems that were found. A problem's Path locates the value that it is
+// about; its position is unknown because compiled models don't record the
+// positions of their values.
+func Validate(document *gnostic.Document) []*compiler.Error {
+	return ValidateWithOptions(document, nil)
+}
+
+// ValidateWithOptions checks a compiled document like Validate, using the
+// specified options to read the files that its references name. Services
+// that validate untrusted descriptions should set LocalReferencesOnly, so
+// that references to other files are reported as unresolved instead of
+// being read from the service's file system or network.
+func ValidateWithOptions(document *gnostic.Document, options *compiler.CompilerOptions) []*compiler.Error {
+	v := &validator{document: document, options: options, problems: make([]*compiler.Error, 0)}
+	for _, check := range Checks {
+		v.check = check
+		switch {
+		case document.Version == gnostic.OpenAPIv2 && document.V2 != nil:
+			check.checkV2(v, document.V2)
+		case document.Version == gnostic.OpenAPIv3 && document.V3 != nil:
+			check.checkV3(v, document.V3)
+		}
+	}
+	return v.problems
+}
+
+// Errors returns the problems that are errors, or nil if there are none.
+func Errors(problems []*compiler.Error) error {
+	errors := make([]error, 0)
+	for _, problem := range problems {
+		if problem.Severity == compiler.SeverityError {
+			errors = append(errors, problem)
+		}
+	}
+	return compiler.NewErrorGroupOrNil(errors)
+}
+
+// validator collects the problems found by the check that is being made.
+type validator struct {
+	document *gnostic.Document
+	options  *compiler.CompilerOptions
+	check    *Check
+	problems []*compiler.Error
+}
+
+func (v *validator) report(severity compiler.Severity, path []string, message string) {
+	v.problems = append(v.problems, &compiler.Error{
+		Context:  contextForPath(path...),
+		Message:  message,
+		Severity: severity,
+		Code:     v.check.Name,
+	})
+}
+
+// Reports a violation of the specification.
+func (v *validator) error(path []string, message string) {
+	v.report(compiler.SeverityError, path, message)
+}
+
+// Reports a value that is allowed but is probably a mistake.
+func (v *validator) warning(path []string, message string) {
+	v.report(compiler.SeverityWarning, path, message)
+}
+
+// Returns the context of a value, given the names of the keys that lead to it from the root.
+func contextForPath(names ...string) *compiler.Context {
+	context := compiler.NewContext("$root", nil)
+	for _, name := range names {
+		context = compiler.NewContext(name, context)
+	}
+	return context
+}
+
+// Returns a path that extends another one. The other path is not changed.
+func join(path []string, names ...string) []string {
+	result := make([]string, 0, len(path)+len(names))
+	return append(append(result, path...), names...)
+}
@@ -0,0 +1,566 @@
+package validator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/OpenAPIv3"
+	"github.com/googleapis/gnostic/compiler"
+	gnostic "github.com/googleapis/gnostic/lib"
+)
+
+// Returns the problems found in a description, keyed by their severities, codes, and paths.
+func validate(t *testing.T, text string) map[string]string {
+	document, err := gnostic.ReadDocumentFromBytes([]byte(text))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	problems := make(map[string]string)
+	for _, problem := range Validate(document) {
+		problems[problem.Severity.String()+" "+problem.Code+" "+problem.Path()] = problem.Message
+	}
+	return problems
+}
+
+// Checks that the problems found in a description are the expected ones.
+func expectProblems(t *testing.T, problems map[string]string, expected map[string]string) {
+	if len(problems) != len(expected) {
+		t.Errorf("expected %d problems, found %+v", len(expected), problems)
+	}
+	for key, message := range expected {
+		if problems[key] != message {
+			t.Errorf("expected %s: %s, found %q", key, message, problems[key])
+		}
+	}
+}
+
+func TestSecurityRequirementsV2(t *testing.T) {
+	problems := validate(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+security: [{key: []}]
+securityDefinitions:
+  key: {type: apiKey, name: key, in: header}
+  basic: {type: basic}
+  oauth:
+    type: oauth2
+    flow: implicit
+    authorizationUrl: https://example.com/auth
+    scopes: {"pets:read": read pets}
+paths:
+  /pets:
+    get:
+      security: [{oauth: ["pets:read", "pets:write"]}, {token: []}]
+      responses:
+        200: {description: pets}
+    post:
+      security: [{key: [admin]}]
+      responses:
+        200: {description: created}
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR security-requirements /paths/~1pets/get/security/0/oauth": "security requirement refers to scope pets:write, which is not a scope of oauth",
+		"ERROR security-requirements /paths/~1pets/get/security/1/token": "security requirement refers to token, which is not a declared security scheme",
+		"ERROR security-requirements /paths/~1pets/post/security/0/key":  "security requirement lists scopes for key, which doesn't use scopes",
+		"WARNING security-requirements /securityDefinitions/basic":       "security scheme basic is not used",
+	})
+}
+
+func TestSecurityRequirementsV3(t *testing.T) {
+	problems := validate(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+security: [{oauth: [read]}, {oidc: [profile]}]
+components:
+  securitySchemes:
+    oauth:
+      type: oauth2
+      flow:
+        authorizationCode:
+          authorizationUrl: https://example.com/auth
+          tokenUrl: https://example.com/token
+          scopes: {read: read pets}
+    oidc: {type: openIdConnect, openIdConnectUrl: https://example.com/.well-known/openid-configuration}
+    bearer: {type: http, scheme: bearer}
+paths:
+  /pets:
+    get:
+      security: [{oauth: [write]}, {bearer: [read]}]
+      responses:
+        200: {description: pets}
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR security-requirements /paths/~1pets/get/security/0/oauth":  "security requirement refers to scope write, which is not a scope of oauth",
+		"ERROR security-requirements /paths/~1pets/get/security/1/bearer": "security requirement lists scopes for bearer, which doesn't use scopes",
+	})
+}
+
+func TestPathParametersV2(t *testing.T) {
+	problems := validate(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+parameters:
+  petId: {name: petId, in: path, required: true, type: string}
+paths:
+  /pets/{petId}:
+    parameters:
+    - $ref: "#/parameters/petId"
+    get:
+      parameters:
+      - {name: limit, in: query, type: integer}
+      - {name: limit, in: query, type: integer}
+      responses:
+        200: {description: pet}
+  /owners/{ownerId}/pets/{petId}:
+    get:
+      parameters:
+      - {name: ownerId, in: path, required: false, type: string}
+      - {name: name, in: path, required: true, type: string}
+      responses:
+        200: {description: pets}
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR path-parameters /paths/~1pets~1{petId}/get/parameters/1":                    "parameter limit in query is declared more than once",
+		"ERROR path-parameters /paths/~1owners~1{ownerId}~1pets~1{petId}/get/parameters/0": "path parameter ownerId is not required",
+		"ERROR path-parameters /paths/~1owners~1{ownerId}~1pets~1{petId}/get/parameters/1": "path parameter name is not in the path template",
+		"ERROR path-parameters /paths/~1owners~1{ownerId}~1pets~1{petId}/get":              "path template field petId is not declared as a path parameter",
+	})
+}
+
+func TestPathParametersV3(t *testing.T) {
+	problems := validate(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+components:
+  parameters:
+    petId: {name: petId, in: path, required: true, schema: {type: string}}
+paths:
+  /pets/{petId}:
+    parameters:
+    - $ref: "#/components/parameters/petId"
+    - {name: petId, in: path, required: true, schema: {type: string}}
+    get:
+      responses:
+        200: {description: pet}
+  /pets/{petId}/photos/{photoId}:
+    parameters:
+    - $ref: "#/components/parameters/petId"
+    get:
+      parameters:
+      - {name: photoId, in: path, required: true, schema: {type: string}}
+      responses:
+        200: {description: photo}
+    delete:
+      responses:
+        200: {description: deleted}
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR path-parameters /paths/~1pets~1{petId}/parameters/1":              "parameter petId in path is declared more than once",
+		"ERROR path-parameters /paths/~1pets~1{petId}~1photos~1{photoId}/delete": "path template field photoId is not declared as a path parameter",
+	})
+}
+
+func TestMediaTypesV2(t *testing.T) {
+	problems := validate(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+consumes: [application/json, "application/json; charset=utf-8", application/vnd.pets+json]
+produces: [application/jsn, json]
+paths:
+  /pets:
+    get:
+      produces: [application/vnd.pets+jsn, "text/plain; charset=", aplication/json]
+      responses:
+        200: {description: pets}
+`)
+	expectProblems(t, problems, map[string]string{
+		"WARNING media-types /produces/0":                  "media type application/jsn is probably a misspelling of application/json",
+		"ERROR media-types /produces/1":                    `"json" is not a valid media type`,
+		"WARNING media-types /paths/~1pets/get/produces/0": "media type application/vnd.pets+jsn doesn't have a registered suffix",
+		"ERROR media-types /paths/~1pets/get/produces/1":   `"text/plain; charset=" is not a valid media type`,
+		"WARNING media-types /paths/~1pets/get/produces/2": "media type aplication/json doesn't have a registered type",
+	})
+}
+
+func TestMediaTypesV3(t *testing.T) {
+	problems := validate(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json: {}
+          multipart/form-dta: {}
+      responses:
+        200:
+          description: pets
+          content:
+            "*/*": {}
+            image/*: {}
+            "*/json": {}
+          headers:
+            X-Rate-Limit:
+              content:
+                text/plian: {}
+components:
+  requestBodies:
+    pet:
+      content:
+        application/problem+json: {}
+        application/x-yaml: {}
+`)
+	expectProblems(t, problems, map[string]string{
+		"WARNING media-types /paths/~1pets/post/requestBody/content/multipart~1form-dta":                "media type multipart/form-dta is probably a misspelling of multipart/form-data",
+		"ERROR media-types /paths/~1pets/post/responses/200/content/*~1json":                            `"*/json" is not a valid media type`,
+		"WARNING media-types /paths/~1pets/post/responses/200/headers/X-Rate-Limit/content/text~1plian": "media type text/plian is probably a misspelling of text/plain",
+	})
+}
+
+func TestCompositionCyclesV2(t *testing.T) {
+	problems := validate(t, `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+paths: {}
+definitions:
+  Pet:
+    allOf:
+    - $ref: "#/definitions/Animal"
+    - properties:
+        owner: {$ref: "#/definitions/Owner"}
+  Animal:
+    allOf:
+    - allOf: [{$ref: "#/definitions/Named"}]
+  Named:
+    $ref: "#/definitions/Pet"
+  Owner:
+    allOf: [{$ref: "#/definitions/Person"}]
+  Person:
+    properties:
+      pets:
+        type: array
+        items: {$ref: "#/definitions/Owner"}
+  Self:
+    allOf: [{$ref: "#/definitions/Self"}]
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR composition-cycles /definitions/Pet":  "schema Pet is composed of itself: Pet -> Animal -> Named -> Pet",
+		"ERROR composition-cycles /definitions/Self": "schema Self is composed of itself: Self -> Self",
+	})
+}
+
+func TestCompositionCyclesV3(t *testing.T) {
+	problems := validate(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths: {}
+components:
+  schemas:
+    Shape:
+      oneOf:
+      - $ref: "#/components/schemas/Circle"
+      - $ref: "#/components/schemas/Group"
+    Circle:
+      properties:
+        radius: {type: number}
+    Group:
+      anyOf:
+      - $ref: "#/components/schemas/Shape"
+    Tree:
+      properties:
+        children:
+          type: array
+          items: {$ref: "#/components/schemas/Tree"}
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR composition-cycles /components/schemas/Shape": "schema Shape is composed of itself: Shape -> Group -> Shape",
+	})
+}
+
+func TestExclusiveFieldsV3(t *testing.T) {
+	problems := validate(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      parameters:
+      - name: limit
+        in: query
+        schema: {type: integer}
+        example: 10
+        examples:
+          small: {value: 1}
+      - name: filter
+        in: query
+        schema: {type: string}
+        content:
+          application/json: {schema: {type: object}}
+      - name: sort
+        in: query
+      responses:
+        200:
+          description: pets
+          headers:
+            X-Rate-Limit:
+              content:
+                text/plain: {}
+                application/json: {}
+          content:
+            application/json:
+              schema: {type: array}
+              example: []
+              examples:
+                empty: {value: []}
+components:
+  parameters:
+    offset: {name: offset, in: query, schema: {type: integer}, example: 0}
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR exclusive-fields /paths/~1pets/get/parameters/0":                               "parameter limit has both example and examples",
+		"ERROR exclusive-fields /paths/~1pets/get/parameters/1":                               "parameter filter has both schema and content",
+		"ERROR exclusive-fields /paths/~1pets/get/parameters/2":                               "parameter sort has neither schema nor content",
+		"ERROR exclusive-fields /paths/~1pets/get/responses/200/headers/X-Rate-Limit/content": "content of header X-Rate-Limit has 2 media types instead of one",
+		"ERROR exclusive-fields /paths/~1pets/get/responses/200/content/application~1json":    "media type application/json has both example and examples",
+	})
+}
+
+func TestReferencesV2(t *testing.T) {
+	text := `
+swagger: "2.0"
+info: {title: Pets, version: "1.0"}
+parameters:
+  limit: {name: limit, in: query, type: integer}
+definitions:
+  Pet:
+    properties:
+      owner: {$ref: "#/definitions/Owner"}
+  Name: {type: string}
+paths:
+  /pets:
+    get:
+      parameters:
+      - $ref: "#/definitions/Pet"
+      - $ref: "#/parameters/limit"
+      - $ref: "#/definitions/Name"
+      responses:
+        200:
+          description: pets
+          schema: {$ref: "#/parameters/limit"}
+`
+	info, err := compiler.ReadInfoFromBytes("", []byte(text))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	model, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	problems := make(map[string]string)
+	for _, problem := range Validate(&gnostic.Document{Version: gnostic.OpenAPIv2, V2: model, Source: info}) {
+		if problem.Code == "references" {
+			problems[problem.Severity.String()+" "+problem.Code+" "+problem.Path()] = problem.Message
+		}
+	}
+	expectProblems(t, problems, map[string]string{
+		"ERROR references /definitions/Pet/properties/owner":      "reference to #/definitions/Owner doesn't refer to an existing value",
+		"ERROR references /paths/~1pets/get/parameters/0":         "reference to #/definitions/Pet refers to #/definitions/Pet (line 8), which is not a valid parameter",
+		"ERROR references /paths/~1pets/get/parameters/2":         "reference to #/definitions/Name refers to #/definitions/Name (line 10), which is not a valid parameter",
+		"ERROR references /paths/~1pets/get/responses/200/schema": "reference to #/parameters/limit refers to #/parameters/limit (line 5), which is not a valid schema",
+	})
+}
+
+func TestReferencesV3(t *testing.T) {
+	problems := validate(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      parameters:
+      - $ref: "#/components/schemas/Pet"
+      responses:
+        200: {description: pets}
+    post:
+      requestBody:
+        $ref: "#/components/requestBodies/pet"
+      responses:
+        200: {description: created}
+components:
+  schemas:
+    Pet: {type: object}
+  requestBodies:
+    pet:
+      content:
+        application/json:
+          schema: {$ref: "#/components/parameters/limit"}
+  parameters:
+    limit: {name: limit, in: query, schema: {type: integer}}
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR references /paths/~1pets/get/parameters/0":                                 "reference to #/components/schemas/Pet refers to #/components/schemas/Pet (line 18), which is not a valid parameter",
+		"ERROR references /components/requestBodies/pet/content/application~1json/schema": "reference to #/components/parameters/limit refers to #/components/parameters/limit (line 25), which is not a valid schema",
+	})
+}
+
+func TestReferencesInOtherFiles(t *testing.T) {
+	document, err := gnostic.ReadDocument("../examples/v2.0/yaml/petstore-separate/spec/swagger.yaml")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	for _, problem := range Validate(document) {
+		if problem.Code == "references" {
+			t.Errorf("unexpected problem %s: %s", problem.Path(), problem.Message)
+		}
+	}
+}
+
+func TestLocalReferencesOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validator")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+	secret := filepath.Join(dir, "secret.yaml")
+	if err = ioutil.WriteFile(secret, []byte("secret: {type: string}\n"), 0644); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	text := `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema: {$ref: "` + secret + `#/secret"}
+      responses:
+        "200": {description: pets}
+`
+	options := &compiler.CompilerOptions{Cache: compiler.NewCache(), LocalReferencesOnly: true}
+	options.ReadFile = func(filename string) ([]byte, error) {
+		t.Errorf("unexpected read of %s", filename)
+		return ioutil.ReadFile(filename)
+	}
+	info, err := compiler.ReadInfoFromBytesWithOptions("", []byte(text), options)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	document := &gnostic.Document{Version: gnostic.OpenAPIv3, Source: info}
+	if document.V3, err = openapi_v3.NewDocument(info, compiler.NewContextWithOptions("$root", options)); err != nil {
+		t.Fatalf("%+v", err)
+	}
+	problems := make(map[string]string)
+	for _, problem := range ValidateWithOptions(document, options) {
+		problems[problem.Severity.String()+" "+problem.Code+" "+problem.Path()] = problem.Message
+	}
+	expectProblems(t, problems, map[string]string{
+		"WARNING references /paths/~1pets/get/parameters/0/schema": "reference to " + secret + "#/secret was not checked because references to other files are not resolved",
+	})
+}
+
+func TestParameterSerializationV3(t *testing.T) {
+	problems := validate(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets/{id}:
+    parameters:
+    - {name: id, in: path, required: true, style: form, schema: {type: string}}
+    get:
+      parameters:
+      - {name: filter, in: query, style: deepObject, schema: {type: object}}
+      - {name: sort, in: query, style: deepObject, explode: true, schema: {type: string}}
+      - {name: tags, in: query, style: pipeDelimited, schema: {type: array}}
+      - {name: ids, in: query, style: spaceDelimited, explode: true, schema: {$ref: "#/components/schemas/Ids"}}
+      - {name: fields, in: query, explode: false, schema: {type: array}}
+      - {name: session, in: cookie, style: simple, schema: {type: string}}
+      responses:
+        200:
+          description: pets
+          headers:
+            X-Rate-Limit: {style: matrix, schema: {type: integer}}
+components:
+  schemas:
+    Ids: {type: array}
+`)
+	expectProblems(t, problems, map[string]string{
+		"ERROR parameter-serialization /paths/~1pets~1{id}/parameters/0/style":                           "parameter id can't have style form, which isn't allowed in path",
+		"WARNING parameter-serialization /paths/~1pets~1{id}/get/parameters/0/style":                     "parameter filter has style deepObject without explode, which isn't defined",
+		"WARNING parameter-serialization /paths/~1pets~1{id}/get/parameters/1/style":                     "parameter sort has style deepObject, which is only defined for objects, but it is a string",
+		"WARNING parameter-serialization /paths/~1pets~1{id}/get/parameters/3/style":                     "parameter ids has style spaceDelimited with explode, which isn't defined",
+		"ERROR parameter-serialization /paths/~1pets~1{id}/get/parameters/5/style":                       "parameter session can't have style simple, which isn't allowed in cookie",
+		"ERROR parameter-serialization /paths/~1pets~1{id}/get/responses/200/headers/X-Rate-Limit/style": "header X-Rate-Limit can't have style matrix, which isn't allowed in header",
+	})
+}
+
+func TestCallbacksV3(t *testing.T) {
+	problems := validate(t, `
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /subscriptions:
+    post:
+      callbacks:
+        created:
+          "{$request.body#/callbackUrl}":
+            post:
+              responses:
+                200: {description: received}
+          "https://example.com/notify?id={$response.body#/id}&from={$request.header.X-From}":
+            post:
+              parameters:
+              - {name: id, in: path, required: true, schema: {type: string}}
+              responses:
+                200: {description: received}
+        updated:
+          "$request.query.url":
+            put:
+              responses:
+                200: {description: received}
+          "$request.bdy":
+            put:
+              responses:
+                200: {description: received}
+          "{$url":
+            summary: unmatched
+          "https://example.com/{$request.cookie.id}":
+            summary: a cookie
+      responses:
+        201: {description: subscribed}
+components:
+  callbacks:
+    static:
+      "https://example.com/static":
+        post:
+          responses:
+            200: {description: received}
+`)
+	expectProblems(t, problems, map[string]string{
+		"WARNING callbacks /paths/~1subscriptions/post/callbacks/created/https:~1~1example.com~1notify?id={$response.body#~1id}&from={$request.header.X-From}/post/parameters/0": "callback parameter id is a path parameter, but callback URLs aren't path templates",
+		"ERROR callbacks /paths/~1subscriptions/post/callbacks/updated/$request.bdy":                                                                                             "callback expression $request.bdy is not a valid runtime expression",
+		"ERROR callbacks /paths/~1subscriptions/post/callbacks/updated/{$url":                                                                                                    "callback expression {$url has unmatched braces",
+		"WARNING callbacks /paths/~1subscriptions/post/callbacks/updated/{$url":                                                                                                  "callback updated has no operations",
+		"ERROR callbacks /paths/~1subscriptions/post/callbacks/updated/https:~1~1example.com~1{$request.cookie.id}":                                                              "callback expression https://example.com/{$request.cookie.id} contains $request.cookie.id, which is not a valid runtime expression",
+		"WARNING callbacks /paths/~1subscriptions/post/callbacks/updated/https:~1~1example.com~1{$request.cookie.id}":                                                            "callback updated has no operations",
+		"WARNING callbacks /components/callbacks/static/https:~1~1example.com~1static":                                                                                           "callback expression https://example.com/static doesn't contain a runtime expression",
+	})
+}
+
+func TestErrors(t *testing.T) {
+	problems := []*compiler.Error{
+		{Message: "unused", Severity: compiler.SeverityWarning},
+	}
+	if err := Errors(problems); err != nil {
+		t.Errorf("expected warnings to be ignored, found %+v", err)
+	}
+	problems = append(problems, &compiler.Error{Message: "undeclared"})
+	if err := Errors(problems); err == nil || err.Error() != "ERROR undeclared" {
+		t.Errorf("expected an error, found %+v", err)
+	}
+}